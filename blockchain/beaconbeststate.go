@@ -43,6 +43,7 @@ type BeaconBestState struct {
 	ShardCommittee                         map[byte][]incognitokey.CommitteePublicKey `json:"ShardCommittee"`        // current committee and validator of all shard
 	ShardPendingValidator                  map[byte][]incognitokey.CommitteePublicKey `json:"ShardPendingValidator"` // pending candidate waiting for swap to get in committee of all shard
 	AutoStaking                            map[string]bool                            `json:"AutoStaking"`
+	UnbondingStakes                        map[string]uint64                          `json:"UnbondingStakes"` // committee public key -> beacon height to release stake
 	CurrentRandomNumber                    int64                                      `json:"CurrentRandomNumber"`
 	CurrentRandomTimeStamp                 int64                                      `json:"CurrentRandomTimeStamp"` // random timestamp for this epoch
 	IsGetRandomNumber                      bool                                       `json:"IsGetRandomNumber"`
@@ -94,6 +95,7 @@ func NewBeaconBestStateWithConfig(netparam *Params) *BeaconBestState {
 	beaconBestState.ShardCommittee = make(map[byte][]incognitokey.CommitteePublicKey)
 	beaconBestState.ShardPendingValidator = make(map[byte][]incognitokey.CommitteePublicKey)
 	beaconBestState.AutoStaking = make(map[string]bool)
+	beaconBestState.UnbondingStakes = make(map[string]uint64)
 	beaconBestState.Params = make(map[string]string)
 	beaconBestState.CurrentRandomNumber = -1
 	beaconBestState.MaxBeaconCommitteeSize = netparam.MaxBeaconCommitteeSize
@@ -418,6 +420,18 @@ func (beaconBestState *BeaconBestState) GetBytes() []byte {
 			res = append(res, []byte("false")...)
 		}
 	}
+	// unbonding stakes are only queued from BeaconHeightBreakPointUnbonding, bytes of earlier best states are unchanged
+	keysStrs3 := []string{}
+	for k := range beaconBestState.UnbondingStakes {
+		keysStrs3 = append(keysStrs3, k)
+	}
+	sort.Strings(keysStrs3)
+	for _, key := range keysStrs3 {
+		res = append(res, []byte(key)...)
+		releaseHeightBytes := make([]byte, 8)
+		binary.LittleEndian.PutUint64(releaseHeightBytes, beaconBestState.UnbondingStakes[key])
+		res = append(res, releaseHeightBytes...)
+	}
 	randomNumBytes := make([]byte, 8)
 	binary.LittleEndian.PutUint64(randomNumBytes, uint64(beaconBestState.CurrentRandomNumber))
 	res = append(res, randomNumBytes...)
@@ -587,6 +601,19 @@ func (beaconBestState *BeaconBestState) UpdateLastCrossShardState(shardStates ma
 	beaconBestState.updateLastCrossShardState(shardStates)
 }
 
+func (beaconBestState *BeaconBestState) GetLastCrossShardState() map[byte]map[byte]uint64 {
+	beaconBestState.lock.RLock()
+	defer beaconBestState.lock.RUnlock()
+	res := make(map[byte]map[byte]uint64)
+	for fromShard, toShards := range beaconBestState.LastCrossShardState {
+		res[fromShard] = make(map[byte]uint64)
+		for toShard, height := range toShards {
+			res[fromShard][toShard] = height
+		}
+	}
+	return res
+}
+
 func (beaconBestState *BeaconBestState) GetAutoStakingList() map[string]bool {
 	beaconBestState.lock.RLock()
 	defer beaconBestState.lock.RUnlock()
@@ -596,6 +623,15 @@ func (beaconBestState *BeaconBestState) GetAutoStakingList() map[string]bool {
 	}
 	return m
 }
+func (beaconBestState *BeaconBestState) GetUnbondingStakes() map[string]uint64 {
+	beaconBestState.lock.RLock()
+	defer beaconBestState.lock.RUnlock()
+	m := make(map[string]uint64)
+	for k, v := range beaconBestState.UnbondingStakes {
+		m[k] = v
+	}
+	return m
+}
 func (beaconBestState *BeaconBestState) GetAllCommitteeValidatorCandidateFlattenList() []string {
 	beaconBestState.lock.RLock()
 	defer beaconBestState.lock.RUnlock()
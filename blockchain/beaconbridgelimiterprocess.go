@@ -0,0 +1,128 @@
+package blockchain
+
+import (
+	"strconv"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/database"
+	"github.com/incognitochain/incognito-chain/database/lvdb"
+	"github.com/incognitochain/incognito-chain/metadata"
+)
+
+func (blockchain *BlockChain) processBridgeLimiterInstructions(block *BeaconBlock, bd *[]database.BatchData) error {
+	if !blockchain.isBridgeLimiterActive(block.Header.Height) {
+		return nil
+	}
+	db := blockchain.GetDatabase()
+	chainParams := blockchain.config.ChainParams
+	currentBridgeLimiterState, err := InitCurrentBridgeLimiterStateFromDB(db, chainParams.BridgeTokenLimits, block.Header.Height, chainParams.Epoch)
+	if err != nil {
+		Logger.log.Error(err)
+		return nil
+	}
+	for _, inst := range block.Body.Instructions {
+		if len(inst) < 4 {
+			continue // Not error, just not bridge limiter instruction
+		}
+		switch inst[0] {
+		case strconv.Itoa(metadata.IssuingETHRequestMeta), strconv.Itoa(metadata.IssuingBTCRequestMeta):
+			blockchain.processLimitedIssuingReq(inst, currentBridgeLimiterState)
+		case strconv.Itoa(metadata.BurningRequestMeta):
+			blockchain.processQueuedBridgeReq(inst, currentBridgeLimiterState)
+		case strconv.Itoa(metadata.BurningConfirmMeta), strconv.Itoa(metadata.BurningConfirmForEVMChainMeta):
+			blockchain.processLimitedBurningConfirm(inst, currentBridgeLimiterState)
+		case strconv.Itoa(metadata.BridgeControlRequestMeta):
+			blockchain.processBridgeControl(inst, currentBridgeLimiterState)
+		}
+	}
+	err = storeBridgeLimiterStateToDB(db, currentBridgeLimiterState)
+	if err != nil {
+		Logger.log.Error(err)
+	}
+	return nil
+}
+
+func (blockchain *BlockChain) processQueuedBridgeReq(
+	instruction []string,
+	currentBridgeLimiterState *CurrentBridgeLimiterState,
+) {
+	if instruction[2] != common.BridgeRequestQueuedChainStatus {
+		return
+	}
+	var request lvdb.BridgeQueuedRequest
+	err := decodeContent(instruction[3], &request)
+	if err != nil {
+		Logger.log.Errorf("ERROR: an error occured while decoding queued bridge request: %+v", err)
+		return
+	}
+	currentBridgeLimiterState.addQueuedRequest(&request)
+}
+
+// processLimitedIssuingReq counts eth and btc issuance, the token, amount and tx id of their accepted contents are decoded alike
+func (blockchain *BlockChain) processLimitedIssuingReq(
+	instruction []string,
+	currentBridgeLimiterState *CurrentBridgeLimiterState,
+) {
+	switch instruction[2] {
+	case common.BridgeRequestQueuedChainStatus:
+		blockchain.processQueuedBridgeReq(instruction, currentBridgeLimiterState)
+
+	case "accepted":
+		metaType, _ := strconv.Atoi(instruction[0])
+		var issuingETHAcceptedInst metadata.IssuingETHAcceptedInst
+		err := decodeContent(instruction[3], &issuingETHAcceptedInst)
+		if err != nil {
+			Logger.log.Errorf("ERROR: an error occured while decoding accepted issuance instruction: %+v", err)
+			return
+		}
+		currentBridgeLimiterState.countRequest(metaType, issuingETHAcceptedInst.IncTokenID, issuingETHAcceptedInst.IssuingAmount)
+		currentBridgeLimiterState.releaseRequest(issuingETHAcceptedInst.TxReqID)
+
+	case "rejected":
+		txReqID, err := common.Hash{}.NewHashFromStr(instruction[3])
+		if err != nil {
+			Logger.log.Errorf("ERROR: an error occured while parsing tx id of rejected issuance instruction: %+v", err)
+			return
+		}
+		currentBridgeLimiterState.releaseRequest(*txReqID)
+	}
+}
+
+func (blockchain *BlockChain) processLimitedBurningConfirm(
+	instruction []string,
+	currentBridgeLimiterState *CurrentBridgeLimiterState,
+) {
+	if len(instruction) < 8 {
+		return
+	}
+	_, incTokenID, amount, err := decodeBurningConfirmAmount(instruction)
+	if err != nil {
+		Logger.log.Errorf("ERROR: an error occured while decoding burning confirm instruction: %+v", err)
+		return
+	}
+	txReqID, err := common.Hash{}.NewHashFromStr(instruction[5])
+	if err != nil {
+		Logger.log.Errorf("ERROR: an error occured while parsing tx id of burning confirm instruction: %+v", err)
+		return
+	}
+	currentBridgeLimiterState.countRequest(metadata.BurningRequestMeta, *incTokenID, amount)
+	currentBridgeLimiterState.releaseRequest(*txReqID)
+}
+
+func (blockchain *BlockChain) processBridgeControl(
+	instruction []string,
+	currentBridgeLimiterState *CurrentBridgeLimiterState,
+) {
+	if instruction[2] != common.BridgeControlAcceptedChainStatus {
+		return
+	}
+	var controlAction metadata.BridgeControlAction
+	err := decodeContent(instruction[3], &controlAction)
+	if err != nil {
+		Logger.log.Errorf("ERROR: an error occured while decoding bridge control action: %+v", err)
+		return
+	}
+	if !currentBridgeLimiterState.applyBridgeControl(controlAction.Meta) {
+		Logger.log.Warnf("WARNING: bridge control of tx %s could not be applied", controlAction.TxReqID.String())
+	}
+}
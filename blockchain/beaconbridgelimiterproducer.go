@@ -0,0 +1,203 @@
+package blockchain
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strconv"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/database"
+	"github.com/incognitochain/incognito-chain/database/lvdb"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+	"github.com/incognitochain/incognito-chain/metadata"
+)
+
+// buildQueuedBridgeInst queues a request over the caps of its token, the instruction carries the queued request with its position
+func buildQueuedBridgeInst(
+	metaType int,
+	shardID byte,
+	request *lvdb.BridgeQueuedRequest,
+	currentBridgeLimiterState *CurrentBridgeLimiterState,
+) ([]string, error) {
+	currentBridgeLimiterState.queueRequest(request)
+	requestBytes, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+	return buildInstruction(metaType, shardID, common.BridgeRequestQueuedChainStatus, base64.StdEncoding.EncodeToString(requestBytes)), nil
+}
+
+// buildReleasedBridgeInst builds the instruction processing a queued request,
+// a queued deposit of a token pair which is taken by another deposit meanwhile is rejected
+func (blockchain *BlockChain) buildReleasedBridgeInst(
+	request *lvdb.BridgeQueuedRequest,
+	beaconHeight uint64,
+	db database.DatabaseInterface,
+	ac *metadata.AccumulatedValues,
+) ([]string, bool, error) {
+	if request.MetaType == metadata.BurningRequestMeta {
+		burningConfirm, err := buildBurningConfirmInst([]string{strconv.Itoa(metadata.BurningRequestMeta), request.Content}, beaconHeight, db)
+		if err != nil {
+			return nil, false, err
+		}
+		return burningConfirm, true, nil
+	}
+
+	contentBytes, err := base64.StdEncoding.DecodeString(request.Content)
+	if err != nil {
+		return nil, false, err
+	}
+	// btc is not on an evm chain, its bridge token is stored without chain id
+	var md metadata.IssuingETHAcceptedInst
+	if request.MetaType == metadata.IssuingBTCRequestMeta {
+		var issuingBTCAcceptedInst metadata.IssuingBTCAcceptedInst
+		err = json.Unmarshal(contentBytes, &issuingBTCAcceptedInst)
+		md.IncTokenID = issuingBTCAcceptedInst.IncTokenID
+		md.ExternalTokenID = issuingBTCAcceptedInst.ExternalTokenID
+	} else {
+		err = json.Unmarshal(contentBytes, &md)
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	rejectedInst := buildInstruction(request.MetaType, request.ShardID, "rejected", request.TxReqID.String())
+	canProcess, err := ac.CanProcessTokenPair(md.ChainID, md.ExternalTokenID, md.IncTokenID)
+	if err != nil || !canProcess {
+		Logger.log.Warnf("WARNING: queued deposit of tx %s has an invalid token pair in current block", request.TxReqID.String())
+		return rejectedInst, false, nil
+	}
+	isValid, err := db.CanProcessTokenPair(md.ChainID, md.ExternalTokenID, md.IncTokenID)
+	if err != nil || !isValid {
+		Logger.log.Warnf("WARNING: queued deposit of tx %s has an invalid token pair with previous blocks", request.TxReqID.String())
+		return rejectedInst, false, nil
+	}
+	ac.DBridgeTokenPair[md.IncTokenID.String()] = md.ExternalTokenID
+	if request.MetaType != metadata.IssuingBTCRequestMeta {
+		ac.DBridgeTokenChainIDs[md.IncTokenID.String()] = md.ChainID
+	}
+	return buildInstruction(request.MetaType, request.ShardID, "accepted", request.Content), true, nil
+}
+
+// buildInstructionsForReleasingBridgeReqs releases queued requests which fit in the caps of their tokens, in the order they were queued
+func (blockchain *BlockChain) buildInstructionsForReleasingBridgeReqs(
+	currentBridgeLimiterState *CurrentBridgeLimiterState,
+	beaconHeight uint64,
+	db database.DatabaseInterface,
+	ac *metadata.AccumulatedValues,
+) [][]string {
+	if currentBridgeLimiterState == nil {
+		Logger.log.Warn("WARN - [buildInstructionsForReleasingBridgeReqs]: Current bridge limiter state is null.")
+		return [][]string{}
+	}
+	instructions := [][]string{}
+	queuedRequests := append([]*lvdb.BridgeQueuedRequest{}, currentBridgeLimiterState.QueuedRequests...)
+	for _, request := range queuedRequests {
+		if !currentBridgeLimiterState.canProcessRequest(request.MetaType, request.TokenID, request.Amount) {
+			continue
+		}
+		inst, accepted, err := blockchain.buildReleasedBridgeInst(request, beaconHeight, db, ac)
+		if err != nil {
+			Logger.log.Errorf("ERROR: an error occured while releasing queued request of tx %s: %+v", request.TxReqID.String(), err)
+			continue
+		}
+		if accepted {
+			currentBridgeLimiterState.countRequest(request.MetaType, request.TokenID, request.Amount)
+		}
+		currentBridgeLimiterState.releaseRequest(request.TxReqID)
+		instructions = append(instructions, inst)
+	}
+	return instructions
+}
+
+// buildInstructionsForBurningReq confirms a burning request on beacon, the request is queued if it is over the caps of its token
+func (blockchain *BlockChain) buildInstructionsForBurningReq(
+	contentStr string,
+	shardID byte,
+	metaType int,
+	currentBridgeLimiterState *CurrentBridgeLimiterState,
+	beaconHeight uint64,
+	db database.DatabaseInterface,
+) ([][]string, error) {
+	if currentBridgeLimiterState == nil {
+		Logger.log.Warn("WARN - [buildInstructionsForBurningReq]: Current bridge limiter state is null.")
+		return [][]string{}, nil
+	}
+	burningConfirm, err := buildBurningConfirmInst([]string{strconv.Itoa(metaType), contentStr}, beaconHeight, db)
+	if err != nil {
+		return [][]string{}, err
+	}
+	var burningReqAction BurningReqAction
+	err = decodeContent(contentStr, &burningReqAction)
+	if err != nil {
+		return [][]string{}, err
+	}
+	md := burningReqAction.Meta
+	if currentBridgeLimiterState.canProcessRequest(metaType, md.TokenID, md.BurningAmount) {
+		currentBridgeLimiterState.countRequest(metaType, md.TokenID, md.BurningAmount)
+		return [][]string{burningConfirm}, nil
+	}
+	queuedInst, err := buildQueuedBridgeInst(metaType, shardID, &lvdb.BridgeQueuedRequest{
+		TxReqID:  *burningReqAction.RequestedTxID,
+		MetaType: metaType,
+		TokenID:  md.TokenID,
+		Amount:   md.BurningAmount,
+		ShardID:  shardID,
+		Content:  contentStr,
+	}, currentBridgeLimiterState)
+	if err != nil {
+		return [][]string{}, err
+	}
+	BLogger.log.Infof("Burning request of tx %s is queued by bridge limiter", burningReqAction.RequestedTxID.String())
+	return [][]string{queuedInst}, nil
+}
+
+// buildInstructionsForBridgeControl applies a control request approved by the beacon committee,
+// a released request is processed right after the control instruction regardless of the caps of its token
+func (blockchain *BlockChain) buildInstructionsForBridgeControl(
+	contentStr string,
+	shardID byte,
+	metaType int,
+	currentBridgeLimiterState *CurrentBridgeLimiterState,
+	beaconCommittee []incognitokey.CommitteePublicKey,
+	beaconHeight uint64,
+	db database.DatabaseInterface,
+	ac *metadata.AccumulatedValues,
+) ([][]string, error) {
+	if currentBridgeLimiterState == nil {
+		Logger.log.Warn("WARN - [buildInstructionsForBridgeControl]: Current bridge limiter state is null.")
+		return [][]string{}, nil
+	}
+	contentBytes, err := base64.StdEncoding.DecodeString(contentStr)
+	if err != nil {
+		Logger.log.Errorf("ERROR: an error occured while decoding content string of bridge control action: %+v", err)
+		return [][]string{}, nil
+	}
+	var controlAction metadata.BridgeControlAction
+	err = json.Unmarshal(contentBytes, &controlAction)
+	if err != nil {
+		Logger.log.Errorf("ERROR: an error occured while unmarshaling bridge control action: %+v", err)
+		return [][]string{}, nil
+	}
+	rejectedInst := buildInstruction(metaType, shardID, common.BridgeControlRejectedChainStatus, contentStr)
+	controlReq := controlAction.Meta
+	if !controlReq.IsApprovedByCommittee(beaconCommittee) || !currentBridgeLimiterState.applyBridgeControl(controlReq) {
+		return [][]string{rejectedInst}, nil
+	}
+	instructions := [][]string{
+		buildInstruction(metaType, shardID, common.BridgeControlAcceptedChainStatus, contentStr),
+	}
+	if controlReq.Action != metadata.BridgeControlReleaseAction {
+		return instructions, nil
+	}
+	request := currentBridgeLimiterState.findQueuedRequest(controlReq.ReleasedTxReqID)
+	inst, accepted, err := blockchain.buildReleasedBridgeInst(request, beaconHeight, db, ac)
+	if err != nil {
+		Logger.log.Errorf("ERROR: an error occured while releasing queued request of tx %s: %+v", request.TxReqID.String(), err)
+		return instructions, nil
+	}
+	if accepted {
+		currentBridgeLimiterState.countRequest(request.MetaType, request.TokenID, request.Amount)
+	}
+	currentBridgeLimiterState.releaseRequest(request.TxReqID)
+	return append(instructions, inst), nil
+}
@@ -12,6 +12,7 @@ import (
 	rCommon "github.com/ethereum/go-ethereum/common"
 	"github.com/incognitochain/incognito-chain/common"
 	"github.com/incognitochain/incognito-chain/common/base58"
+	"github.com/incognitochain/incognito-chain/database/lvdb"
 	"github.com/incognitochain/incognito-chain/metadata"
 	"github.com/pkg/errors"
 )
@@ -24,6 +25,7 @@ type UpdatingInfo struct {
 	tokenID         common.Hash
 	externalTokenID []byte
 	isCentralized   bool
+	chainID         uint64
 }
 
 type BurningReqAction struct {
@@ -45,10 +47,13 @@ func (blockchain *BlockChain) processBridgeInstructions(block *BeaconBlock, bd *
 		case strconv.Itoa(metadata.IssuingRequestMeta):
 			updatingInfoByTokenID, err = blockchain.processIssuingReq(inst, updatingInfoByTokenID)
 
+		case strconv.Itoa(metadata.IssuingBTCRequestMeta):
+			updatingInfoByTokenID, err = blockchain.processIssuingBTCReq(inst, updatingInfoByTokenID)
+
 		case strconv.Itoa(metadata.ContractingRequestMeta):
 			updatingInfoByTokenID, err = blockchain.processContractingReq(inst, updatingInfoByTokenID)
 
-		case strconv.Itoa(metadata.BurningConfirmMeta):
+		case strconv.Itoa(metadata.BurningConfirmMeta), strconv.Itoa(metadata.BurningConfirmForEVMChainMeta):
 			updatingInfoByTokenID, err = blockchain.processBurningReq(inst, updatingInfoByTokenID)
 
 		}
@@ -69,6 +74,7 @@ func (blockchain *BlockChain) processBridgeInstructions(block *BeaconBlock, bd *
 		}
 		err := blockchain.GetDatabase().UpdateBridgeTokenInfo(
 			updatingInfo.tokenID,
+			updatingInfo.chainID,
 			updatingInfo.externalTokenID,
 			updatingInfo.isCentralized,
 			updatingAmt,
@@ -116,17 +122,13 @@ func (blockchain *BlockChain) processContractingReq(instruction []string, updati
 	return updatingInfoByTokenID, nil
 }
 
-func (blockchain *BlockChain) processBurningReq(instruction []string, updatingInfoByTokenID map[common.Hash]UpdatingInfo) (map[common.Hash]UpdatingInfo, error) {
-	if len(instruction) < 8 {
-		return nil, nil // skip the instruction
-	}
-
+// decodeBurningConfirmAmount returns tokens of a BurningConfirm instruction and its amount in the unit of the incognito token
+func decodeBurningConfirmAmount(instruction []string) ([]byte, *common.Hash, uint64, error) {
 	externalTokenID, _, errExtToken := base58.Base58Check{}.Decode(instruction[2])
 	incTokenIDBytes, _, errIncToken := base58.Base58Check{}.Decode(instruction[6])
 	amountBytes, _, errAmount := base58.Base58Check{}.Decode(instruction[4])
 	if err := common.CheckError(errExtToken, errIncToken, errAmount); err != nil {
-		BLogger.log.Error(errors.WithStack(err))
-		return nil, nil
+		return nil, nil, 0, err
 	}
 	amt := big.NewInt(0).SetBytes(amountBytes)
 	amount := uint64(0)
@@ -137,7 +139,29 @@ func (blockchain *BlockChain) processBurningReq(instruction []string, updatingIn
 	}
 
 	incTokenID := &common.Hash{}
-	incTokenID, _ = (*incTokenID).NewHash(incTokenIDBytes)
+	incTokenID, err := (*incTokenID).NewHash(incTokenIDBytes)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	return externalTokenID, incTokenID, amount, nil
+}
+
+func (blockchain *BlockChain) processBurningReq(instruction []string, updatingInfoByTokenID map[common.Hash]UpdatingInfo) (map[common.Hash]UpdatingInfo, error) {
+	if len(instruction) < 8 {
+		return nil, nil // skip the instruction
+	}
+
+	externalTokenID, incTokenID, amount, err := decodeBurningConfirmAmount(instruction)
+	if err != nil {
+		BLogger.log.Error(errors.WithStack(err))
+		return nil, nil
+	}
+	chainID, err := GetBurningConfirmChainID(instruction)
+	if err != nil {
+		BLogger.log.Error(errors.WithStack(err))
+		return nil, nil
+	}
+
 	updatingInfo, found := updatingInfoByTokenID[*incTokenID]
 	if found {
 		updatingInfo.deductAmt += amount
@@ -148,6 +172,7 @@ func (blockchain *BlockChain) processBurningReq(instruction []string, updatingIn
 			tokenID:         *incTokenID,
 			externalTokenID: externalTokenID,
 			isCentralized:   false,
+			chainID:         chainID,
 		}
 	}
 	updatingInfoByTokenID[*incTokenID] = updatingInfo
@@ -173,6 +198,32 @@ func (blockchain *BlockChain) processIssuingETHReq(instruction []string, updatin
 	}
 
 	db := blockchain.GetDatabase()
+	if instruction[2] == common.BridgeRequestQueuedChainStatus {
+		// the deposit is marked issued so that it can not be queued again, the token is issued when the request is released
+		var request lvdb.BridgeQueuedRequest
+		err := decodeContent(instruction[3], &request)
+		if err != nil {
+			fmt.Println("WARNING: an error occured while decoding queued issuance instruction: ", err)
+			return updatingInfoByTokenID, nil
+		}
+		var issuingETHAcceptedInst metadata.IssuingETHAcceptedInst
+		err = decodeContent(request.Content, &issuingETHAcceptedInst)
+		if err != nil {
+			fmt.Println("WARNING: an error occured while decoding content of queued issuance instruction: ", err)
+			return updatingInfoByTokenID, nil
+		}
+		err = db.InsertETHTxHashIssued(issuingETHAcceptedInst.UniqETHTx)
+		if err != nil {
+			fmt.Println("WARNING: an error occured while inserting ETH tx hash issued to leveldb: ", err)
+			return updatingInfoByTokenID, nil
+		}
+		err = db.TrackBridgeReqWithStatus(request.TxReqID, common.BridgeRequestQueuedStatus, nil)
+		if err != nil {
+			fmt.Println("WARNING: an error occured while tracking bridge request with queued status to leveldb: ", err)
+		}
+		return updatingInfoByTokenID, nil
+	}
+
 	contentBytes, err := base64.StdEncoding.DecodeString(instruction[3])
 	if err != nil {
 		fmt.Println("WARNING: an error occured while decoding content string of accepted issuance instruction: ", err)
@@ -200,12 +251,92 @@ func (blockchain *BlockChain) processIssuingETHReq(instruction []string, updatin
 			tokenID:         issuingETHAcceptedInst.IncTokenID,
 			externalTokenID: issuingETHAcceptedInst.ExternalTokenID,
 			isCentralized:   false,
+			chainID:         issuingETHAcceptedInst.ChainID,
 		}
 	}
 	updatingInfoByTokenID[issuingETHAcceptedInst.IncTokenID] = updatingInfo
 	return updatingInfoByTokenID, nil
 }
 
+func (blockchain *BlockChain) processIssuingBTCReq(instruction []string, updatingInfoByTokenID map[common.Hash]UpdatingInfo) (map[common.Hash]UpdatingInfo, error) {
+	if len(instruction) != 4 {
+		return nil, nil // skip the instruction
+	}
+
+	if instruction[2] == "rejected" {
+		txReqID, err := common.Hash{}.NewHashFromStr(instruction[3])
+		if err != nil {
+			fmt.Println("WARNING: an error occured while building tx request id in bytes from string: ", err)
+			return nil, nil
+		}
+		err = blockchain.GetDatabase().TrackBridgeReqWithStatus(*txReqID, common.BridgeRequestRejectedStatus, nil)
+		if err != nil {
+			fmt.Println("WARNING: an error occured while tracking bridge request with rejected status to leveldb: ", err)
+		}
+		return nil, nil
+	}
+
+	db := blockchain.GetDatabase()
+	if instruction[2] == common.BridgeRequestQueuedChainStatus {
+		// the deposit is marked issued so that it can not be queued again, the token is issued when the request is released
+		var request lvdb.BridgeQueuedRequest
+		err := decodeContent(instruction[3], &request)
+		if err != nil {
+			fmt.Println("WARNING: an error occured while decoding queued issuance instruction: ", err)
+			return updatingInfoByTokenID, nil
+		}
+		var issuingBTCAcceptedInst metadata.IssuingBTCAcceptedInst
+		err = decodeContent(request.Content, &issuingBTCAcceptedInst)
+		if err != nil {
+			fmt.Println("WARNING: an error occured while decoding content of queued btc issuance instruction: ", err)
+			return updatingInfoByTokenID, nil
+		}
+		err = db.InsertBTCTxIssued(issuingBTCAcceptedInst.UniqBTCTx)
+		if err != nil {
+			fmt.Println("WARNING: an error occured while inserting BTC tx issued to leveldb: ", err)
+			return updatingInfoByTokenID, nil
+		}
+		err = db.TrackBridgeReqWithStatus(request.TxReqID, common.BridgeRequestQueuedStatus, nil)
+		if err != nil {
+			fmt.Println("WARNING: an error occured while tracking bridge request with queued status to leveldb: ", err)
+		}
+		return updatingInfoByTokenID, nil
+	}
+
+	contentBytes, err := base64.StdEncoding.DecodeString(instruction[3])
+	if err != nil {
+		fmt.Println("WARNING: an error occured while decoding content string of accepted btc issuance instruction: ", err)
+		return nil, nil
+	}
+	var issuingBTCAcceptedInst metadata.IssuingBTCAcceptedInst
+	err = json.Unmarshal(contentBytes, &issuingBTCAcceptedInst)
+	if err != nil {
+		fmt.Println("WARNING: an error occured while unmarshaling accepted btc issuance instruction: ", err)
+		return nil, nil
+	}
+	err = db.InsertBTCTxIssued(issuingBTCAcceptedInst.UniqBTCTx)
+	if err != nil {
+		fmt.Println("WARNING: an error occured while inserting BTC tx issued to leveldb: ", err)
+		return nil, nil
+	}
+
+	updatingInfo, found := updatingInfoByTokenID[issuingBTCAcceptedInst.IncTokenID]
+	if found {
+		updatingInfo.countUpAmt += issuingBTCAcceptedInst.IssuingAmount
+	} else {
+		updatingInfo = UpdatingInfo{
+			countUpAmt:      issuingBTCAcceptedInst.IssuingAmount,
+			deductAmt:       0,
+			tokenID:         issuingBTCAcceptedInst.IncTokenID,
+			externalTokenID: issuingBTCAcceptedInst.ExternalTokenID,
+			isCentralized:   false,
+			chainID:         0, // btc is not on an evm chain, its bridge token is stored without chain id
+		}
+	}
+	updatingInfoByTokenID[issuingBTCAcceptedInst.IncTokenID] = updatingInfo
+	return updatingInfoByTokenID, nil
+}
+
 func (blockchain *BlockChain) processIssuingReq(instruction []string, updatingInfoByTokenID map[common.Hash]UpdatingInfo) (map[common.Hash]UpdatingInfo, error) {
 	if len(instruction) != 4 {
 		return nil, nil // skip the instruction
@@ -261,7 +392,7 @@ func decodeContent(content string, action interface{}) error {
 
 func (blockchain *BlockChain) storeBurningConfirm(block *ShardBlock, bd *[]database.BatchData) error {
 	for _, inst := range block.Body.Instructions {
-		if inst[0] != strconv.Itoa(metadata.BurningConfirmMeta) {
+		if inst[0] != strconv.Itoa(metadata.BurningConfirmMeta) && inst[0] != strconv.Itoa(metadata.BurningConfirmForEVMChainMeta) {
 			continue
 		}
 		BLogger.log.Infof("storeBurningConfirm for block %d, inst %v", block.Header.Height, inst)
@@ -285,6 +416,9 @@ func (blockchain *BlockChain) updateBridgeIssuanceStatus(block *ShardBlock, bd *
 		if metaType == metadata.IssuingETHResponseMeta {
 			meta := tx.GetMetadata().(*metadata.IssuingETHResponse)
 			reqTxID = meta.RequestedTxID
+		} else if metaType == metadata.IssuingBTCResponseMeta {
+			meta := tx.GetMetadata().(*metadata.IssuingBTCResponse)
+			reqTxID = meta.RequestedTxID
 		} else if metaType == metadata.IssuingResponseMeta {
 			meta := tx.GetMetadata().(*metadata.IssuingResponse)
 			reqTxID = meta.RequestedTxID
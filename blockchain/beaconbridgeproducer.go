@@ -42,6 +42,9 @@ func (blockchain *BlockChain) buildBridgeInstructions(
 			newInst, err = blockchain.buildInstructionsForContractingReq(contentStr, shardID, metaType)
 
 		case metadata.BurningRequestMeta:
+			if blockchain.isBridgeLimiterActive(beaconHeight) {
+				continue // confirmed by stateful instructions
+			}
 			burningConfirm := []string{}
 			burningConfirm, err = buildBurningConfirmInst(inst, beaconHeight, db)
 			newInst = [][]string{burningConfirm}
@@ -75,10 +78,14 @@ func buildBurningConfirmInst(inst []string, height uint64, db database.DatabaseI
 	shardID := byte(common.BridgeShardID)
 
 	// Convert to external tokenID
-	tokenID, err := findExternalTokenID(&md.TokenID, db)
+	tokenInfo, err := findBridgeTokenInfo(&md.TokenID, db)
 	if err != nil {
 		return nil, err
 	}
+	if tokenInfo.ChainID != md.ChainID {
+		return nil, errors.Errorf("token %s is bridged from chain %d, not chain %d", md.TokenID.String(), tokenInfo.ChainID, md.ChainID)
+	}
+	tokenID := tokenInfo.ExternalTokenID
 
 	// Convert amount to big.Int to get bytes later
 	amount := big.NewInt(0).SetUint64(md.BurningAmount)
@@ -90,20 +97,60 @@ func buildBurningConfirmInst(inst []string, height uint64, db database.DatabaseI
 	// Convert height to big.Int to get bytes later
 	h := big.NewInt(0).SetUint64(height)
 
+	// Burns on the default chain keep the instruction verified by its deployed contracts,
+	// others have the chain id before the height so contracts of a chain reject instructions of other chains
+	if md.ChainID == metadata.DefaultEVMChainID {
+		return []string{
+			strconv.Itoa(metadata.BurningConfirmMeta),
+			strconv.Itoa(int(shardID)),
+			base58.Base58Check{}.Encode(tokenID, 0x00),
+			md.RemoteAddress,
+			base58.Base58Check{}.Encode(amount.Bytes(), 0x00),
+			txID.String(),
+			base58.Base58Check{}.Encode(md.TokenID[:], 0x00),
+			base58.Base58Check{}.Encode(h.Bytes(), 0x00),
+		}, nil
+	}
+	chainID := big.NewInt(0).SetUint64(md.ChainID)
 	return []string{
-		strconv.Itoa(metadata.BurningConfirmMeta),
+		strconv.Itoa(metadata.BurningConfirmForEVMChainMeta),
 		strconv.Itoa(int(shardID)),
 		base58.Base58Check{}.Encode(tokenID, 0x00),
 		md.RemoteAddress,
 		base58.Base58Check{}.Encode(amount.Bytes(), 0x00),
 		txID.String(),
 		base58.Base58Check{}.Encode(md.TokenID[:], 0x00),
+		base58.Base58Check{}.Encode(chainID.Bytes(), 0x00),
 		base58.Base58Check{}.Encode(h.Bytes(), 0x00),
 	}, nil
 }
 
+// GetBurningConfirmChainID returns the evm chain of a BurningConfirm or BurningConfirmForEVMChain instruction
+func GetBurningConfirmChainID(inst []string) (uint64, error) {
+	if inst[0] != strconv.Itoa(metadata.BurningConfirmForEVMChainMeta) {
+		return metadata.DefaultEVMChainID, nil
+	}
+	if len(inst) < 9 {
+		return 0, errors.New("invalid length of BurningConfirmForEVMChain inst")
+	}
+	chainID, _, err := base58.Base58Check{}.Decode(inst[7])
+	if err != nil {
+		return 0, err
+	}
+	return big.NewInt(0).SetBytes(chainID).Uint64(), nil
+}
+
 // findExternalTokenID finds the external tokenID for a bridge token from database
 func findExternalTokenID(tokenID *common.Hash, db database.DatabaseInterface) ([]byte, error) {
+	token, err := findBridgeTokenInfo(tokenID, db)
+	if err != nil {
+		return nil, err
+	}
+	return token.ExternalTokenID, nil
+}
+
+// findBridgeTokenInfo finds the info of a bridge token having an external tokenID from database
+func findBridgeTokenInfo(tokenID *common.Hash, db database.DatabaseInterface) (*lvdb.BridgeTokenInfo, error) {
 	allBridgeTokensBytes, err := db.GetAllBridgeTokens()
 	if err != nil {
 		return nil, err
@@ -115,7 +162,7 @@ func findExternalTokenID(tokenID *common.Hash, db database.DatabaseInterface) ([
 	}
 	for _, token := range allBridgeTokens {
 		if token.TokenID.IsEqual(tokenID) && len(token.ExternalTokenID) > 0 {
-			return token.ExternalTokenID, nil
+			return token, nil
 		}
 	}
 	return nil, errors.New("invalid tokenID")
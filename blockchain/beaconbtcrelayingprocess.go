@@ -0,0 +1,60 @@
+package blockchain
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strconv"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/database"
+	"github.com/incognitochain/incognito-chain/metadata"
+)
+
+func (blockchain *BlockChain) processBTCRelayingInstructions(block *BeaconBlock, bd *[]database.BatchData) error {
+	db := blockchain.GetDatabase()
+	currentBTCRelayingState, err := InitCurrentBTCRelayingStateFromDB(db, &blockchain.config.ChainParams.BTCRelaying)
+	if err != nil {
+		Logger.log.Error(err)
+		return nil
+	}
+	for _, inst := range block.Body.Instructions {
+		if len(inst) != 4 {
+			continue // Not error, just not btc relaying instruction
+		}
+		if inst[0] == strconv.Itoa(metadata.RelayingBTCHeadersMeta) &&
+			inst[2] == common.BTCRelayingHeadersAcceptedChainStatus {
+			blockchain.processRelayingBTCHeaders(inst, currentBTCRelayingState)
+		}
+	}
+	err = storeBTCRelayingStateToDB(db, currentBTCRelayingState)
+	if err != nil {
+		Logger.log.Error(err)
+	}
+	return nil
+}
+
+func (blockchain *BlockChain) processRelayingBTCHeaders(
+	instruction []string,
+	currentBTCRelayingState *CurrentBTCRelayingState,
+) {
+	contentBytes, err := base64.StdEncoding.DecodeString(instruction[3])
+	if err != nil {
+		Logger.log.Errorf("ERROR: an error occured while decoding content string of relaying btc headers action: %+v", err)
+		return
+	}
+	var relayingAction metadata.RelayingBTCHeadersAction
+	err = json.Unmarshal(contentBytes, &relayingAction)
+	if err != nil {
+		Logger.log.Errorf("ERROR: an error occured while unmarshaling relaying btc headers action: %+v", err)
+		return
+	}
+	headers, err := metadata.ParseRelayingBTCHeaders(relayingAction.Meta.HeaderStrs)
+	if err != nil {
+		Logger.log.Errorf("WARNING: an error occured while parsing accepted btc headers: %+v", err)
+		return
+	}
+	err = currentBTCRelayingState.applyRelayingBTCHeaders(headers)
+	if err != nil {
+		Logger.log.Errorf("WARNING: accepted btc headers of tx %s could not be applied: %+v", relayingAction.TxReqID.String(), err)
+	}
+}
@@ -0,0 +1,143 @@
+package blockchain
+
+import (
+	"encoding/base64"
+	"encoding/json"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/database/lvdb"
+	"github.com/incognitochain/incognito-chain/metadata"
+)
+
+func (blockchain *BlockChain) buildInstructionsForRelayingBTCHeaders(
+	contentStr string,
+	shardID byte,
+	metaType int,
+	currentBTCRelayingState *CurrentBTCRelayingState,
+) ([][]string, error) {
+	if currentBTCRelayingState == nil {
+		Logger.log.Warn("WARN - [buildInstructionsForRelayingBTCHeaders]: Current btc relaying state is null.")
+		return [][]string{}, nil
+	}
+	contentBytes, err := base64.StdEncoding.DecodeString(contentStr)
+	if err != nil {
+		Logger.log.Errorf("ERROR: an error occured while decoding content string of relaying btc headers action: %+v", err)
+		return [][]string{}, nil
+	}
+	var relayingAction metadata.RelayingBTCHeadersAction
+	err = json.Unmarshal(contentBytes, &relayingAction)
+	if err != nil {
+		Logger.log.Errorf("ERROR: an error occured while unmarshaling relaying btc headers action: %+v", err)
+		return [][]string{}, nil
+	}
+	status := common.BTCRelayingHeadersAcceptedChainStatus
+	headers, err := metadata.ParseRelayingBTCHeaders(relayingAction.Meta.HeaderStrs)
+	if err == nil {
+		err = currentBTCRelayingState.applyRelayingBTCHeaders(headers)
+	}
+	if err != nil {
+		Logger.log.Warnf("WARNING: relayed btc headers of tx %s are rejected: %+v", relayingAction.TxReqID.String(), err)
+		status = common.BTCRelayingHeadersRejectedChainStatus
+	}
+	inst := buildInstruction(metaType, shardID, status, contentStr)
+	return [][]string{inst}, nil
+}
+
+func (blockchain *BlockChain) buildInstructionsForIssuingBTCReq(
+	contentStr string,
+	shardID byte,
+	metaType int,
+	currentBTCRelayingState *CurrentBTCRelayingState,
+	ac *metadata.AccumulatedValues,
+	currentBridgeLimiterState *CurrentBridgeLimiterState,
+) ([][]string, error) {
+	if currentBTCRelayingState == nil {
+		Logger.log.Warn("WARN - [buildInstructionsForIssuingBTCReq]: Current btc relaying state is null.")
+		return [][]string{}, nil
+	}
+	issuingBTCReqAction, err := metadata.ParseBTCIssuingInstContent(contentStr)
+	if err != nil {
+		Logger.log.Errorf("ERROR: an error occured while parsing issuing btc action content: %+v", err)
+		return [][]string{}, nil
+	}
+	rejectedInst := buildInstruction(metaType, shardID, "rejected", issuingBTCReqAction.TxReqID.String())
+
+	amount, uniqBTCTx, err := currentBTCRelayingState.verifyBTCDeposit(issuingBTCReqAction.Meta)
+	if err != nil {
+		Logger.log.Warnf("WARNING: btc deposit of tx %s is rejected: %+v", issuingBTCReqAction.TxReqID.String(), err)
+		return [][]string{rejectedInst}, nil
+	}
+	if metadata.IsBTCTxUsedInBlock(uniqBTCTx, ac.UniqBTCTxsUsed) {
+		Logger.log.Warnf("WARNING: btc deposit of tx %s is already issued in current block", issuingBTCReqAction.TxReqID.String())
+		return [][]string{rejectedInst}, nil
+	}
+	db := blockchain.GetDatabase()
+	isIssued, err := db.IsBTCTxIssued(uniqBTCTx)
+	if err != nil {
+		Logger.log.Errorf("ERROR: an error occured while checking the btc deposit is issued or not: %+v", err)
+		return [][]string{rejectedInst}, nil
+	}
+	if isIssued {
+		Logger.log.Warnf("WARNING: btc deposit of tx %s is already issued in previous blocks", issuingBTCReqAction.TxReqID.String())
+		return [][]string{rejectedInst}, nil
+	}
+
+	incTokenID, err := common.Hash{}.NewHashFromStr(blockchain.config.ChainParams.BTCRelaying.IncTokenIDStr)
+	if err != nil {
+		Logger.log.Errorf("ERROR: an error occured while parsing pBTC token id: %+v", err)
+		return [][]string{rejectedInst}, nil
+	}
+	externalTokenID := []byte(BTCRelayingExternalTokenIDStr)
+	// btc is not on an evm chain, its bridge token is stored without chain id
+	canProcess, err := ac.CanProcessTokenPair(0, externalTokenID, *incTokenID)
+	if err != nil || !canProcess {
+		Logger.log.Warnf("WARNING: pair of pBTC token id & btc is invalid in current block: %+v", err)
+		return [][]string{rejectedInst}, nil
+	}
+	isValid, err := db.CanProcessTokenPair(0, externalTokenID, *incTokenID)
+	if err != nil || !isValid {
+		Logger.log.Warnf("WARNING: pair of pBTC token id & btc is invalid with previous blocks: %+v", err)
+		return [][]string{rejectedInst}, nil
+	}
+
+	issuingBTCAcceptedInst := metadata.IssuingBTCAcceptedInst{
+		ShardID:         shardID,
+		IssuingAmount:   amount * BTCRelayingSatoshiToNanoAmount,
+		ReceiverAddrStr: issuingBTCReqAction.Meta.ReceiverAddressStr,
+		IncTokenID:      *incTokenID,
+		TxReqID:         issuingBTCReqAction.TxReqID,
+		UniqBTCTx:       uniqBTCTx,
+		ExternalTokenID: externalTokenID,
+	}
+	issuingBTCAcceptedInstBytes, err := json.Marshal(issuingBTCAcceptedInst)
+	if err != nil {
+		Logger.log.Errorf("ERROR: an error occured while marshaling issuingBTCAccepted instruction: %+v", err)
+		return [][]string{rejectedInst}, nil
+	}
+	ac.UniqBTCTxsUsed = append(ac.UniqBTCTxsUsed, uniqBTCTx)
+	ac.DBridgeTokenPair[incTokenID.String()] = externalTokenID
+
+	acceptedContent := base64.StdEncoding.EncodeToString(issuingBTCAcceptedInstBytes)
+	if currentBridgeLimiterState == nil {
+		return [][]string{buildInstruction(metaType, shardID, "accepted", acceptedContent)}, nil
+	}
+	if !currentBridgeLimiterState.canProcessRequest(metaType, *incTokenID, issuingBTCAcceptedInst.IssuingAmount) {
+		queuedInst, err := buildQueuedBridgeInst(metaType, shardID, &lvdb.BridgeQueuedRequest{
+			TxReqID:  issuingBTCReqAction.TxReqID,
+			MetaType: metaType,
+			TokenID:  *incTokenID,
+			Amount:   issuingBTCAcceptedInst.IssuingAmount,
+			ShardID:  shardID,
+			Content:  acceptedContent,
+		}, currentBridgeLimiterState)
+		if err != nil {
+			Logger.log.Errorf("ERROR: an error occured while queueing btc issuance request: %+v", err)
+			return [][]string{rejectedInst}, nil
+		}
+		Logger.log.Infof("btc issuance request of tx %s is queued by bridge limiter", issuingBTCReqAction.TxReqID.String())
+		return [][]string{queuedInst}, nil
+	}
+	currentBridgeLimiterState.countRequest(metaType, *incTokenID, issuingBTCAcceptedInst.IssuingAmount)
+	acceptedInst := buildInstruction(metaType, shardID, "accepted", acceptedContent)
+	return [][]string{acceptedInst}, nil
+}
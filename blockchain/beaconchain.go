@@ -102,6 +102,11 @@ func (chain *BeaconChain) ValidatePreSignBlock(block common.BlockInterface) erro
 	return chain.Blockchain.VerifyPreSignBeaconBlock(block.(*BeaconBlock), true)
 }
 
+// IsCompactBlockActive is always false, beacon blocks are sent in full
+func (chain *BeaconChain) IsCompactBlockActive(block common.BlockInterface) bool {
+	return false
+}
+
 // func (chain *BeaconChain) ValidateAndInsertBlock(block common.BlockInterface) error {
 // 	var beaconBestState BeaconBestState
 // 	beaconBlock := block.(*BeaconBlock)
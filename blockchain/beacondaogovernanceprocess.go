@@ -0,0 +1,139 @@
+package blockchain
+
+import (
+	"strconv"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/database"
+	"github.com/incognitochain/incognito-chain/database/lvdb"
+	"github.com/incognitochain/incognito-chain/metadata"
+)
+
+func (blockchain *BlockChain) processDAOGovernanceInstructions(block *BeaconBlock, bd *[]database.BatchData) error {
+	db := blockchain.GetDatabase()
+	currentDAOGovernanceState, err := InitCurrentDAOGovernanceStateFromDB(db)
+	if err != nil {
+		Logger.log.Error(err)
+		return nil
+	}
+	for _, inst := range block.Body.Instructions {
+		if len(inst) < 4 {
+			continue // Not error, just not dao governance instruction
+		}
+		switch inst[0] {
+		case strconv.Itoa(metadata.IncDAORewardRequestMeta):
+			blockchain.processIncDAOTreasuryReward(inst, currentDAOGovernanceState)
+		case strconv.Itoa(metadata.DAOProposalMeta):
+			blockchain.processDAOProposal(inst, currentDAOGovernanceState)
+		case strconv.Itoa(metadata.DAOVoteMeta):
+			blockchain.processDAOVote(inst, currentDAOGovernanceState, block.Header.Height)
+		case strconv.Itoa(metadata.DAOProposalTallyMeta):
+			blockchain.processDAOProposalTally(inst, currentDAOGovernanceState)
+		case strconv.Itoa(metadata.DAOTreasuryPayoutMeta):
+			blockchain.processDAOTreasuryPayout(inst, currentDAOGovernanceState)
+		case strconv.Itoa(metadata.DAOParamChangeMeta):
+			blockchain.processDAOParamChange(inst, currentDAOGovernanceState)
+		}
+	}
+	err = storeDAOGovernanceStateToDB(db, currentDAOGovernanceState)
+	if err != nil {
+		Logger.log.Error(err)
+	}
+	return nil
+}
+
+func (blockchain *BlockChain) processIncDAOTreasuryReward(
+	instruction []string,
+	currentDAOGovernanceState *CurrentDAOGovernanceState,
+) {
+	if instruction[2] != common.IncDAOTreasuryRewardChainStatus {
+		return // paid to the dao address by its shard
+	}
+	incDAORewardInfo, err := metadata.NewIncDAORewardInfoFromStr(instruction[3])
+	if err != nil {
+		Logger.log.Errorf("ERROR: an error occured while decoding dao treasury reward instruction: %+v", err)
+		return
+	}
+	currentDAOGovernanceState.addTreasuryReward(incDAORewardInfo.IncDAOReward)
+}
+
+func (blockchain *BlockChain) processDAOProposal(
+	instruction []string,
+	currentDAOGovernanceState *CurrentDAOGovernanceState,
+) {
+	if instruction[2] != common.DAOGovernanceAcceptedChainStatus {
+		return
+	}
+	var proposal lvdb.DAOProposal
+	err := decodeContent(instruction[3], &proposal)
+	if err != nil {
+		Logger.log.Errorf("ERROR: an error occured while decoding accepted dao proposal instruction: %+v", err)
+		return
+	}
+	currentDAOGovernanceState.addProposal(&proposal)
+}
+
+func (blockchain *BlockChain) processDAOVote(
+	instruction []string,
+	currentDAOGovernanceState *CurrentDAOGovernanceState,
+	beaconHeight uint64,
+) {
+	if instruction[2] != common.DAOGovernanceAcceptedChainStatus {
+		return
+	}
+	var voteAction metadata.DAOVoteAction
+	err := decodeContent(instruction[3], &voteAction)
+	if err != nil {
+		Logger.log.Errorf("ERROR: an error occured while decoding accepted dao vote instruction: %+v", err)
+		return
+	}
+	voterAddress, err := normalizeDAOAddress(voteAction.Meta.VoterAddress)
+	if err != nil {
+		Logger.log.Errorf("ERROR: an error occured while normalizing voter address of dao vote: %+v", err)
+		return
+	}
+	currentDAOGovernanceState.applyVote(voteAction.Meta.ProposalID, voterAddress, voteAction.Meta.Approve, beaconHeight)
+}
+
+func (blockchain *BlockChain) processDAOProposalTally(
+	instruction []string,
+	currentDAOGovernanceState *CurrentDAOGovernanceState,
+) {
+	var content metadata.DAOProposalTallyContent
+	err := decodeContent(instruction[3], &content)
+	if err != nil {
+		Logger.log.Errorf("ERROR: an error occured while decoding dao proposal tally instruction: %+v", err)
+		return
+	}
+	currentDAOGovernanceState.applyTally(content, instruction[2] == common.DAOProposalPassedChainStatus)
+}
+
+func (blockchain *BlockChain) processDAOTreasuryPayout(
+	instruction []string,
+	currentDAOGovernanceState *CurrentDAOGovernanceState,
+) {
+	var content metadata.DAOTreasuryPayoutContent
+	err := decodeContent(instruction[3], &content)
+	if err != nil {
+		Logger.log.Errorf("ERROR: an error occured while decoding dao treasury payout instruction: %+v", err)
+		return
+	}
+	currentDAOGovernanceState.applyTreasuryPayout(content, instruction[2] == common.DAOGovernanceAcceptedChainStatus)
+}
+
+func (blockchain *BlockChain) processDAOParamChange(
+	instruction []string,
+	currentDAOGovernanceState *CurrentDAOGovernanceState,
+) {
+	if instruction[2] != common.DAOGovernanceAcceptedChainStatus {
+		return
+	}
+	var content metadata.DAOParamChangeContent
+	err := decodeContent(instruction[3], &content)
+	if err != nil {
+		Logger.log.Errorf("ERROR: an error occured while decoding dao param change instruction: %+v", err)
+		return
+	}
+	currentDAOGovernanceState.setGovernedParam(content)
+	currentDAOGovernanceState.setProposalStatus(content.ProposalID, common.DAOProposalExecutedStatus)
+}
@@ -0,0 +1,279 @@
+package blockchain
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strconv"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/database/lvdb"
+	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/incognitochain/incognito-chain/wallet"
+)
+
+func buildDAOGovernanceInst(metaType int, shardIDStr string, status string, content interface{}) ([]string, error) {
+	contentBytes, err := json.Marshal(content)
+	if err != nil {
+		return nil, err
+	}
+	return []string{
+		strconv.Itoa(metaType),
+		shardIDStr,
+		status,
+		base64.StdEncoding.EncodeToString(contentBytes),
+	}, nil
+}
+
+func (blockchain *BlockChain) buildInstructionsForDAOProposal(
+	contentStr string,
+	shardID byte,
+	metaType int,
+	currentDAOGovernanceState *CurrentDAOGovernanceState,
+	votingWeights *daoVotingWeights,
+	beaconHeight uint64,
+) ([][]string, error) {
+	if currentDAOGovernanceState == nil {
+		Logger.log.Warn("WARN - [buildInstructionsForDAOProposal]: Current dao governance state is null.")
+		return [][]string{}, nil
+	}
+	contentBytes, err := base64.StdEncoding.DecodeString(contentStr)
+	if err != nil {
+		Logger.log.Errorf("ERROR: an error occured while decoding content string of dao proposal action: %+v", err)
+		return [][]string{}, nil
+	}
+	var proposalAction metadata.DAOProposalAction
+	err = json.Unmarshal(contentBytes, &proposalAction)
+	if err != nil {
+		Logger.log.Errorf("ERROR: an error occured while unmarshaling dao proposal action: %+v", err)
+		return [][]string{}, nil
+	}
+	rejectedInst := []string{
+		strconv.Itoa(metaType),
+		strconv.Itoa(int(shardID)),
+		common.DAOGovernanceRejectedChainStatus,
+		contentStr,
+	}
+	params := currentDAOGovernanceState.GetEffectiveParams(blockchain.config.ChainParams.IncDAOGovernance)
+	if !isIncDAOGovernanceActive(params, beaconHeight) {
+		return [][]string{rejectedInst}, nil
+	}
+	proposalReq := proposalAction.Meta
+	proposerAddress, err := normalizeDAOAddress(proposalReq.ProposerAddress)
+	if err != nil {
+		return [][]string{rejectedInst}, nil
+	}
+	weights, _, err := votingWeights.get()
+	if err != nil {
+		return [][]string{}, err
+	}
+	if weights[proposerAddress] == 0 {
+		Logger.log.Warnf("WARNING: proposer of dao proposal %s holds no staked PRV", proposalAction.TxReqID.String())
+		return [][]string{rejectedInst}, nil
+	}
+	if _, found := currentDAOGovernanceState.Proposals[proposalAction.TxReqID]; found {
+		return [][]string{rejectedInst}, nil
+	}
+	if proposalReq.Type == metadata.DAOTreasurySpendProposal && !currentDAOGovernanceState.hasTreasuryAmounts(proposalReq.Amounts) {
+		Logger.log.Warnf("WARNING: dao proposal %s spends more than the treasury", proposalAction.TxReqID.String())
+		return [][]string{rejectedInst}, nil
+	}
+	proposal := &lvdb.DAOProposal{
+		ProposalID:            proposalAction.TxReqID,
+		Type:                  proposalReq.Type,
+		ProposerAddress:       proposerAddress,
+		Description:           proposalReq.Description,
+		ReceiverAddress:       proposalReq.ReceiverAddress,
+		Amounts:               proposalReq.Amounts,
+		ParamName:             proposalReq.ParamName,
+		TokenID:               proposalReq.TokenID,
+		ParamValue:            proposalReq.ParamValue,
+		Status:                common.DAOProposalVotingStatus,
+		CreatedBeaconHeight:   beaconHeight,
+		VotingEndBeaconHeight: beaconHeight + params.VotingPeriod,
+		Votes:                 map[string]bool{},
+	}
+	inst, err := buildDAOGovernanceInst(metaType, strconv.Itoa(int(shardID)), common.DAOGovernanceAcceptedChainStatus, proposal)
+	if err != nil {
+		return [][]string{}, err
+	}
+	currentDAOGovernanceState.addProposal(proposal)
+	return [][]string{inst}, nil
+}
+
+func (blockchain *BlockChain) buildInstructionsForDAOVote(
+	contentStr string,
+	shardID byte,
+	metaType int,
+	currentDAOGovernanceState *CurrentDAOGovernanceState,
+	votingWeights *daoVotingWeights,
+	beaconHeight uint64,
+) ([][]string, error) {
+	if currentDAOGovernanceState == nil {
+		Logger.log.Warn("WARN - [buildInstructionsForDAOVote]: Current dao governance state is null.")
+		return [][]string{}, nil
+	}
+	contentBytes, err := base64.StdEncoding.DecodeString(contentStr)
+	if err != nil {
+		Logger.log.Errorf("ERROR: an error occured while decoding content string of dao vote action: %+v", err)
+		return [][]string{}, nil
+	}
+	var voteAction metadata.DAOVoteAction
+	err = json.Unmarshal(contentBytes, &voteAction)
+	if err != nil {
+		Logger.log.Errorf("ERROR: an error occured while unmarshaling dao vote action: %+v", err)
+		return [][]string{}, nil
+	}
+	inst := []string{
+		strconv.Itoa(metaType),
+		strconv.Itoa(int(shardID)),
+		common.DAOGovernanceRejectedChainStatus,
+		contentStr,
+	}
+	if !isIncDAOGovernanceActive(blockchain.config.ChainParams.IncDAOGovernance, beaconHeight) {
+		return [][]string{inst}, nil
+	}
+	voteReq := voteAction.Meta
+	voterAddress, err := normalizeDAOAddress(voteReq.VoterAddress)
+	if err != nil {
+		return [][]string{inst}, nil
+	}
+	weights, _, err := votingWeights.get()
+	if err != nil {
+		return [][]string{}, err
+	}
+	// votes without weight are rejected so that they do not bloat proposals
+	if weights[voterAddress] == 0 {
+		return [][]string{inst}, nil
+	}
+	if currentDAOGovernanceState.applyVote(voteReq.ProposalID, voterAddress, voteReq.Approve, beaconHeight) {
+		inst[2] = common.DAOGovernanceAcceptedChainStatus
+	}
+	return [][]string{inst}, nil
+}
+
+/*
+buildInstructionsForDAOGovernance is run at the beginning of every beacon block once governance is on:
+- proposals whose voting is over are tallied with the voting weights of the block, passed ones wait for the timelock
+- passed proposals whose timelock is over are executed, a treasury spend fails if the treasury cannot pay it
+*/
+func (blockchain *BlockChain) buildInstructionsForDAOGovernance(
+	currentDAOGovernanceState *CurrentDAOGovernanceState,
+	votingWeights *daoVotingWeights,
+	beaconHeight uint64,
+) [][]string {
+	if currentDAOGovernanceState == nil {
+		Logger.log.Warn("WARN - [buildInstructionsForDAOGovernance]: Current dao governance state is null.")
+		return [][]string{}
+	}
+	params := currentDAOGovernanceState.GetEffectiveParams(blockchain.config.ChainParams.IncDAOGovernance)
+	if !isIncDAOGovernanceActive(params, beaconHeight) {
+		return [][]string{}
+	}
+	instructions := [][]string{}
+	for _, proposal := range currentDAOGovernanceState.getSortedProposals() {
+		if proposal.Status != common.DAOProposalVotingStatus || proposal.VotingEndBeaconHeight >= beaconHeight {
+			continue
+		}
+		inst, err := buildDAOProposalTallyInst(proposal, currentDAOGovernanceState, votingWeights, params, beaconHeight)
+		if err != nil {
+			Logger.log.Errorf("ERROR: an error occured while tallying dao proposal %s: %+v", proposal.ProposalID.String(), err)
+			continue
+		}
+		instructions = append(instructions, inst)
+	}
+	for _, proposal := range currentDAOGovernanceState.getSortedProposals() {
+		if proposal.Status != common.DAOProposalPassedStatus || proposal.ExecutionBeaconHeight > beaconHeight {
+			continue
+		}
+		inst, err := buildDAOProposalExecutionInst(proposal, currentDAOGovernanceState)
+		if err != nil {
+			Logger.log.Errorf("ERROR: an error occured while executing dao proposal %s: %+v", proposal.ProposalID.String(), err)
+			continue
+		}
+		instructions = append(instructions, inst)
+	}
+	return instructions
+}
+
+func buildDAOProposalTallyInst(
+	proposal *lvdb.DAOProposal,
+	currentDAOGovernanceState *CurrentDAOGovernanceState,
+	votingWeights *daoVotingWeights,
+	params IncDAOGovernanceParams,
+	beaconHeight uint64,
+) ([]string, error) {
+	weights, totalWeight, err := votingWeights.get()
+	if err != nil {
+		return nil, err
+	}
+	content := metadata.DAOProposalTallyContent{
+		ProposalID:            proposal.ProposalID,
+		TotalWeight:           totalWeight,
+		ExecutionBeaconHeight: beaconHeight + params.Timelock,
+	}
+	for voterAddress, approve := range proposal.Votes {
+		if approve {
+			content.YesWeight = addWithoutOverflow(content.YesWeight, weights[voterAddress])
+		} else {
+			content.NoWeight = addWithoutOverflow(content.NoWeight, weights[voterAddress])
+		}
+	}
+	passed := isDAOProposalPassed(content.YesWeight, content.NoWeight, content.TotalWeight, params)
+	status := common.DAOGovernanceRejectedChainStatus
+	if passed {
+		status = common.DAOProposalPassedChainStatus
+	}
+	inst, err := buildDAOGovernanceInst(metadata.DAOProposalTallyMeta, strconv.Itoa(metadata.BeaconOnly), status, content)
+	if err != nil {
+		return nil, err
+	}
+	currentDAOGovernanceState.applyTally(content, passed)
+	return inst, nil
+}
+
+func buildDAOProposalExecutionInst(
+	proposal *lvdb.DAOProposal,
+	currentDAOGovernanceState *CurrentDAOGovernanceState,
+) ([]string, error) {
+	if proposal.Type == metadata.DAOParamChangeProposal {
+		content := metadata.DAOParamChangeContent{
+			ProposalID: proposal.ProposalID,
+			ParamName:  proposal.ParamName,
+			TokenID:    proposal.TokenID,
+			ParamValue: proposal.ParamValue,
+		}
+		inst, err := buildDAOGovernanceInst(metadata.DAOParamChangeMeta, strconv.Itoa(metadata.BeaconOnly), common.DAOGovernanceAcceptedChainStatus, content)
+		if err != nil {
+			return nil, err
+		}
+		currentDAOGovernanceState.setGovernedParam(content)
+		currentDAOGovernanceState.setProposalStatus(proposal.ProposalID, common.DAOProposalExecutedStatus)
+		return inst, nil
+	}
+
+	keyWallet, err := wallet.Base58CheckDeserialize(proposal.ReceiverAddress)
+	if err != nil {
+		return nil, err
+	}
+	if len(keyWallet.KeySet.PaymentAddress.Pk) == 0 {
+		return nil, errors.New("receiver address of the treasury spend is invalid")
+	}
+	pk := keyWallet.KeySet.PaymentAddress.Pk
+	receiverShardID := common.GetShardIDFromLastByte(pk[len(pk)-1])
+	content := metadata.DAOTreasuryPayoutContent{
+		ProposalID:      proposal.ProposalID,
+		ReceiverAddress: proposal.ReceiverAddress,
+		Amounts:         proposal.Amounts,
+	}
+	status := common.DAOGovernanceAcceptedChainStatus
+	if !currentDAOGovernanceState.hasTreasuryAmounts(proposal.Amounts) {
+		status = common.DAOGovernanceRejectedChainStatus
+	}
+	inst, err := buildDAOGovernanceInst(metadata.DAOTreasuryPayoutMeta, strconv.Itoa(int(receiverShardID)), status, content)
+	if err != nil {
+		return nil, err
+	}
+	currentDAOGovernanceState.applyTreasuryPayout(content, status == common.DAOGovernanceAcceptedChainStatus)
+	return inst, nil
+}
@@ -0,0 +1,124 @@
+package blockchain
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strconv"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/database"
+	"github.com/incognitochain/incognito-chain/metadata"
+)
+
+func (blockchain *BlockChain) processMintableTokenInstructions(block *BeaconBlock, bd *[]database.BatchData) error {
+	db := blockchain.GetDatabase()
+	currentMintableTokenState, err := InitCurrentMintableTokenStateFromDB(db)
+	if err != nil {
+		Logger.log.Error(err)
+		return nil
+	}
+	for _, inst := range block.Body.Instructions {
+		if len(inst) != 4 {
+			continue // Not error, just not mintable token instruction
+		}
+		switch inst[0] {
+		case strconv.Itoa(metadata.MintableTokenInitMeta):
+			blockchain.processMintableTokenInit(block.Header.Height, inst, currentMintableTokenState)
+		case strconv.Itoa(metadata.MintableTokenMintMeta):
+			blockchain.processMintableTokenMint(inst, currentMintableTokenState)
+		case strconv.Itoa(metadata.MintableTokenBurnMeta):
+			blockchain.processMintableTokenBurn(inst, currentMintableTokenState)
+		}
+	}
+	err = storeMintableTokenStateToDB(db, currentMintableTokenState)
+	if err != nil {
+		Logger.log.Error(err)
+	}
+	return nil
+}
+
+func (blockchain *BlockChain) trackMintableTokenStatus(txReqID common.Hash, status int) {
+	err := blockchain.GetDatabase().TrackMintableTokenStatus(txReqID[:], byte(status))
+	if err != nil {
+		Logger.log.Errorf("ERROR: an error occured while tracking mintable token status: %+v", err)
+	}
+}
+
+func (blockchain *BlockChain) processMintableTokenInit(
+	beaconHeight uint64,
+	instruction []string,
+	currentMintableTokenState *CurrentMintableTokenState,
+) {
+	contentBytes, err := base64.StdEncoding.DecodeString(instruction[3])
+	if err != nil {
+		Logger.log.Errorf("ERROR: an error occured while decoding content string of mintable token init action: %+v", err)
+		return
+	}
+	var initAction metadata.MintableTokenInitAction
+	err = json.Unmarshal(contentBytes, &initAction)
+	if err != nil {
+		Logger.log.Errorf("ERROR: an error occured while unmarshaling mintable token init action: %+v", err)
+		return
+	}
+	status := common.MintableTokenRejectedStatus
+	if instruction[2] == common.MintableTokenAcceptedChainStatus &&
+		currentMintableTokenState.applyMintableTokenInit(initAction, beaconHeight) {
+		status = common.MintableTokenAcceptedStatus
+	}
+	blockchain.trackMintableTokenStatus(initAction.TxReqID, status)
+}
+
+func (blockchain *BlockChain) processMintableTokenMint(
+	instruction []string,
+	currentMintableTokenState *CurrentMintableTokenState,
+) {
+	if instruction[2] == common.MintableTokenRejectedChainStatus {
+		contentBytes, err := base64.StdEncoding.DecodeString(instruction[3])
+		if err != nil {
+			Logger.log.Errorf("ERROR: an error occured while decoding content string of mintable token mint action: %+v", err)
+			return
+		}
+		var mintAction metadata.MintableTokenMintAction
+		err = json.Unmarshal(contentBytes, &mintAction)
+		if err != nil {
+			Logger.log.Errorf("ERROR: an error occured while unmarshaling mintable token mint action: %+v", err)
+			return
+		}
+		blockchain.trackMintableTokenStatus(mintAction.TxReqID, common.MintableTokenRejectedStatus)
+		return
+	}
+	var acceptedContent metadata.MintableTokenMintAcceptedContent
+	err := json.Unmarshal([]byte(instruction[3]), &acceptedContent)
+	if err != nil {
+		Logger.log.Errorf("WARNING: an error occured while unmarshaling MintableTokenMintAcceptedContent: %+v", err)
+		return
+	}
+	if !currentMintableTokenState.applyMintableTokenMint(acceptedContent.TokenID, acceptedContent.Amount, acceptedContent.Nonce) {
+		Logger.log.Errorf("WARNING: accepted mint of token %s could not be applied", acceptedContent.TokenID.String())
+		return
+	}
+	blockchain.trackMintableTokenStatus(acceptedContent.TxReqID, common.MintableTokenAcceptedStatus)
+}
+
+func (blockchain *BlockChain) processMintableTokenBurn(
+	instruction []string,
+	currentMintableTokenState *CurrentMintableTokenState,
+) {
+	contentBytes, err := base64.StdEncoding.DecodeString(instruction[3])
+	if err != nil {
+		Logger.log.Errorf("ERROR: an error occured while decoding content string of mintable token burn action: %+v", err)
+		return
+	}
+	var burnAction metadata.MintableTokenBurnAction
+	err = json.Unmarshal(contentBytes, &burnAction)
+	if err != nil {
+		Logger.log.Errorf("ERROR: an error occured while unmarshaling mintable token burn action: %+v", err)
+		return
+	}
+	status := common.MintableTokenRejectedStatus
+	if instruction[2] == common.MintableTokenAcceptedChainStatus &&
+		currentMintableTokenState.applyMintableTokenBurn(burnAction.Meta.TokenID, burnAction.Meta.BurnedAmount) {
+		status = common.MintableTokenAcceptedStatus
+	}
+	blockchain.trackMintableTokenStatus(burnAction.TxReqID, status)
+}
@@ -0,0 +1,137 @@
+package blockchain
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strconv"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/incognitochain/incognito-chain/wallet"
+)
+
+func (blockchain *BlockChain) buildInstructionsForMintableTokenInit(
+	contentStr string,
+	shardID byte,
+	metaType int,
+	currentMintableTokenState *CurrentMintableTokenState,
+	beaconHeight uint64,
+) ([][]string, error) {
+	if currentMintableTokenState == nil {
+		Logger.log.Warn("WARN - [buildInstructionsForMintableTokenInit]: Current mintable token state is null.")
+		return [][]string{}, nil
+	}
+	contentBytes, err := base64.StdEncoding.DecodeString(contentStr)
+	if err != nil {
+		Logger.log.Errorf("ERROR: an error occured while decoding content string of mintable token init action: %+v", err)
+		return [][]string{}, nil
+	}
+	var initAction metadata.MintableTokenInitAction
+	err = json.Unmarshal(contentBytes, &initAction)
+	if err != nil {
+		Logger.log.Errorf("ERROR: an error occured while unmarshaling mintable token init action: %+v", err)
+		return [][]string{}, nil
+	}
+	status := common.MintableTokenAcceptedChainStatus
+	if !currentMintableTokenState.applyMintableTokenInit(initAction, beaconHeight) {
+		status = common.MintableTokenRejectedChainStatus
+	}
+	inst := []string{
+		strconv.Itoa(metaType),
+		strconv.Itoa(int(shardID)),
+		status,
+		contentStr,
+	}
+	return [][]string{inst}, nil
+}
+
+func (blockchain *BlockChain) buildInstructionsForMintableTokenMint(
+	contentStr string,
+	shardID byte,
+	metaType int,
+	currentMintableTokenState *CurrentMintableTokenState,
+) ([][]string, error) {
+	if currentMintableTokenState == nil {
+		Logger.log.Warn("WARN - [buildInstructionsForMintableTokenMint]: Current mintable token state is null.")
+		return [][]string{}, nil
+	}
+	contentBytes, err := base64.StdEncoding.DecodeString(contentStr)
+	if err != nil {
+		Logger.log.Errorf("ERROR: an error occured while decoding content string of mintable token mint action: %+v", err)
+		return [][]string{}, nil
+	}
+	var mintAction metadata.MintableTokenMintAction
+	err = json.Unmarshal(contentBytes, &mintAction)
+	if err != nil {
+		Logger.log.Errorf("ERROR: an error occured while unmarshaling mintable token mint action: %+v", err)
+		return [][]string{}, nil
+	}
+	mintReq := mintAction.Meta
+	keyWallet, err := wallet.Base58CheckDeserialize(mintReq.ReceiverAddressStr)
+	if err != nil || len(keyWallet.KeySet.PaymentAddress.Pk) == 0 ||
+		!currentMintableTokenState.applyMintableTokenMint(mintReq.TokenID, mintReq.Amount, mintReq.Nonce) {
+		inst := []string{
+			strconv.Itoa(metaType),
+			strconv.Itoa(int(shardID)),
+			common.MintableTokenRejectedChainStatus,
+			contentStr,
+		}
+		return [][]string{inst}, nil
+	}
+	receiverPk := keyWallet.KeySet.PaymentAddress.Pk
+	acceptedContent := metadata.MintableTokenMintAcceptedContent{
+		TokenID:            mintReq.TokenID,
+		TokenName:          currentMintableTokenState.Tokens[mintReq.TokenID].TokenName,
+		Amount:             mintReq.Amount,
+		ReceiverAddressStr: mintReq.ReceiverAddressStr,
+		Nonce:              mintReq.Nonce,
+		TxReqID:            mintAction.TxReqID,
+		ShardID:            common.GetShardIDFromLastByte(receiverPk[len(receiverPk)-1]),
+	}
+	acceptedContentBytes, err := json.Marshal(acceptedContent)
+	if err != nil {
+		Logger.log.Errorf("ERROR: an error occured while marshaling MintableTokenMintAcceptedContent: %+v", err)
+		return [][]string{}, nil
+	}
+	inst := []string{
+		strconv.Itoa(metaType),
+		strconv.Itoa(int(shardID)),
+		common.MintableTokenAcceptedChainStatus,
+		string(acceptedContentBytes),
+	}
+	return [][]string{inst}, nil
+}
+
+func (blockchain *BlockChain) buildInstructionsForMintableTokenBurn(
+	contentStr string,
+	shardID byte,
+	metaType int,
+	currentMintableTokenState *CurrentMintableTokenState,
+) ([][]string, error) {
+	if currentMintableTokenState == nil {
+		Logger.log.Warn("WARN - [buildInstructionsForMintableTokenBurn]: Current mintable token state is null.")
+		return [][]string{}, nil
+	}
+	contentBytes, err := base64.StdEncoding.DecodeString(contentStr)
+	if err != nil {
+		Logger.log.Errorf("ERROR: an error occured while decoding content string of mintable token burn action: %+v", err)
+		return [][]string{}, nil
+	}
+	var burnAction metadata.MintableTokenBurnAction
+	err = json.Unmarshal(contentBytes, &burnAction)
+	if err != nil {
+		Logger.log.Errorf("ERROR: an error occured while unmarshaling mintable token burn action: %+v", err)
+		return [][]string{}, nil
+	}
+	status := common.MintableTokenAcceptedChainStatus
+	if !currentMintableTokenState.applyMintableTokenBurn(burnAction.Meta.TokenID, burnAction.Meta.BurnedAmount) {
+		status = common.MintableTokenRejectedChainStatus
+	}
+	inst := []string{
+		strconv.Itoa(metaType),
+		strconv.Itoa(int(shardID)),
+		status,
+		contentStr,
+	}
+	return [][]string{inst}, nil
+}
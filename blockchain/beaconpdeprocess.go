@@ -19,32 +19,65 @@ func (blockchain *BlockChain) processPDEInstructions(block *BeaconBlock, bd *[]d
 		Logger.log.Error(err)
 		return nil
 	}
+	isIndexerEnabled := blockchain.config.EnablePDEMarketIndexer
+	marketEvents := []*lvdb.PDEMarketEvent{}
+	positionLedger := newPDELiquidityPositionLedger(db)
 	for _, inst := range block.Body.Instructions {
 		if len(inst) < 2 {
 			continue // Not error, just not PDE instruction
 		}
 		var err error
+		var poolsBefore map[string]pdePoolValues
+		var sharesBefore map[string]uint64
 		switch inst[0] {
 		case strconv.Itoa(metadata.PDEContributionMeta):
+			if isIndexerEnabled {
+				poolsBefore = snapshotPDEPoolValues(currentPDEState)
+				sharesBefore = snapshotPDEShares(currentPDEState)
+			}
 			err = blockchain.processPDEContributionV2(beaconHeight, inst, currentPDEState)
 		case strconv.Itoa(metadata.PDETradeRequestMeta):
+			if isIndexerEnabled {
+				poolsBefore = snapshotPDEPoolValues(currentPDEState)
+			}
 			err = blockchain.processPDETrade(beaconHeight, inst, currentPDEState)
 		case strconv.Itoa(metadata.PDEWithdrawalRequestMeta):
+			if isIndexerEnabled {
+				poolsBefore = snapshotPDEPoolValues(currentPDEState)
+			}
 			err = blockchain.processPDEWithdrawal(beaconHeight, inst, currentPDEState)
 		}
 		if err != nil {
 			Logger.log.Error(err)
 			return nil
 		}
+		if poolsBefore != nil {
+			events := buildPDEMarketEvents(inst, block.Header.Height, block.Header.Timestamp, poolsBefore, currentPDEState)
+			marketEvents = append(marketEvents, events...)
+			err = positionLedger.applyPDEInstruction(inst, beaconHeight, events, sharesBefore, currentPDEState)
+			if err != nil {
+				Logger.log.Errorf("ERROR: an error occured while updating pde liquidity positions: %+v", err)
+			}
+		}
 	}
 	// store updated currentPDEState to leveldb with new beacon height
 	err = storePDEStateToDB(
 		db,
 		beaconHeight+1,
 		currentPDEState,
+		blockchain.config.PDEStateRetention,
 	)
 	if err != nil {
 		Logger.log.Error(err)
+		return nil
+	}
+	if !isIndexerEnabled {
+		return nil
+	}
+	blockchain.storePDEMarketEvents(marketEvents, bd)
+	err = positionLedger.store()
+	if err != nil {
+		Logger.log.Errorf("ERROR: an error occured while storing pde liquidity positions: %+v", err)
 	}
 	return nil
 }
@@ -450,18 +450,14 @@ func (blockchain *BlockChain) buildInstructionsForPDETrade(
 		tokenPoolValueToSell = pdePoolPair.Token1PoolValue
 		tokenPoolValueToBuy = pdePoolPair.Token2PoolValue
 	}
-	invariant := big.NewInt(0)
-	invariant.Mul(big.NewInt(int64(tokenPoolValueToSell)), big.NewInt(int64(tokenPoolValueToBuy)))
 	fee := pdeTradeReqAction.Meta.TradingFee
-	newTokenPoolValueToSell := big.NewInt(0)
-	newTokenPoolValueToSell.Add(big.NewInt(int64(tokenPoolValueToSell)), big.NewInt(int64(pdeTradeReqAction.Meta.SellAmount)))
-
-	newTokenPoolValueToBuy := big.NewInt(0).Div(invariant, newTokenPoolValueToSell).Uint64()
-	modValue := big.NewInt(0).Mod(invariant, newTokenPoolValueToSell)
-	if modValue.Cmp(big.NewInt(0)) != 0 {
-		newTokenPoolValueToBuy++
-	}
-	if tokenPoolValueToBuy <= newTokenPoolValueToBuy {
+	receiveAmt, newTokenPoolValueToBuy, newTokenPoolValueToSell, ok := metadata.CalculatePDETradeAmounts(
+		tokenPoolValueToBuy,
+		tokenPoolValueToSell,
+		pdeTradeReqAction.Meta.SellAmount,
+		fee,
+	)
+	if !ok {
 		inst := []string{
 			strconv.Itoa(metaType),
 			strconv.Itoa(int(shardID)),
@@ -471,7 +467,6 @@ func (blockchain *BlockChain) buildInstructionsForPDETrade(
 		return [][]string{inst}, nil
 	}
 
-	receiveAmt := tokenPoolValueToBuy - newTokenPoolValueToBuy
 	if pdeTradeReqAction.Meta.MinAcceptableAmount > receiveAmt {
 		inst := []string{
 			strconv.Itoa(metaType),
@@ -483,11 +478,10 @@ func (blockchain *BlockChain) buildInstructionsForPDETrade(
 	}
 
 	// update current pde state on mem
-	newTokenPoolValueToSell.Add(newTokenPoolValueToSell, big.NewInt(int64(fee)))
 	pdePoolPair.Token1PoolValue = newTokenPoolValueToBuy
-	pdePoolPair.Token2PoolValue = newTokenPoolValueToSell.Uint64()
+	pdePoolPair.Token2PoolValue = newTokenPoolValueToSell
 	if pdePoolPair.Token1IDStr == pdeTradeReqAction.Meta.TokenIDToSellStr {
-		pdePoolPair.Token1PoolValue = newTokenPoolValueToSell.Uint64()
+		pdePoolPair.Token1PoolValue = newTokenPoolValueToSell
 		pdePoolPair.Token2PoolValue = newTokenPoolValueToBuy
 	}
 
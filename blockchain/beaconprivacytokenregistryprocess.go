@@ -0,0 +1,62 @@
+package blockchain
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strconv"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/database"
+	"github.com/incognitochain/incognito-chain/metadata"
+)
+
+func (blockchain *BlockChain) processPrivacyTokenRegistryInstructions(block *BeaconBlock, bd *[]database.BatchData) error {
+	db := blockchain.GetDatabase()
+	currentPrivacyTokenRegistryState, err := InitCurrentPrivacyTokenRegistryStateFromDB(db)
+	if err != nil {
+		Logger.log.Error(err)
+		return nil
+	}
+	for _, inst := range block.Body.Instructions {
+		if len(inst) != 4 || inst[0] != strconv.Itoa(metadata.PrivacyTokenRegistrationMeta) {
+			continue // Not error, just not privacy token registration instruction
+		}
+		blockchain.processPrivacyTokenRegistration(block.Header.Height, inst, currentPrivacyTokenRegistryState)
+	}
+	err = storePrivacyTokenRegistryStateToDB(db, currentPrivacyTokenRegistryState)
+	if err != nil {
+		Logger.log.Error(err)
+	}
+	return nil
+}
+
+func (blockchain *BlockChain) processPrivacyTokenRegistration(
+	beaconHeight uint64,
+	instruction []string,
+	currentPrivacyTokenRegistryState *CurrentPrivacyTokenRegistryState,
+) {
+	db := blockchain.GetDatabase()
+	contentBytes, err := base64.StdEncoding.DecodeString(instruction[3])
+	if err != nil {
+		Logger.log.Errorf("ERROR: an error occured while decoding content string of privacy token registration action: %+v", err)
+		return
+	}
+	var registrationAction metadata.PrivacyTokenRegistrationAction
+	err = json.Unmarshal(contentBytes, &registrationAction)
+	if err != nil {
+		Logger.log.Errorf("ERROR: an error occured while unmarshaling privacy token registration action: %+v", err)
+		return
+	}
+	status := common.PrivacyTokenRegistryRejectedStatus
+	if instruction[2] == common.PrivacyTokenRegistryAcceptedChainStatus {
+		if currentPrivacyTokenRegistryState.applyPrivacyTokenRegistration(registrationAction.Meta, registrationAction.TxReqID, beaconHeight) {
+			status = common.PrivacyTokenRegistryAcceptedStatus
+		} else {
+			Logger.log.Errorf("WARNING: accepted registration of privacy token %s could not be applied", registrationAction.Meta.TokenID)
+		}
+	}
+	err = db.TrackPrivacyTokenRegistryStatus(registrationAction.TxReqID[:], byte(status))
+	if err != nil {
+		Logger.log.Errorf("ERROR: an error occured while tracking privacy token registry status: %+v", err)
+	}
+}
@@ -0,0 +1,51 @@
+package blockchain
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strconv"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+	"github.com/incognitochain/incognito-chain/metadata"
+)
+
+func (blockchain *BlockChain) buildInstructionsForPrivacyTokenRegistration(
+	contentStr string,
+	shardID byte,
+	metaType int,
+	currentPrivacyTokenRegistryState *CurrentPrivacyTokenRegistryState,
+	beaconCommittee []incognitokey.CommitteePublicKey,
+	beaconHeight uint64,
+) ([][]string, error) {
+	if currentPrivacyTokenRegistryState == nil {
+		Logger.log.Warn("WARN - [buildInstructionsForPrivacyTokenRegistration]: Current privacy token registry state is null.")
+		return [][]string{}, nil
+	}
+	contentBytes, err := base64.StdEncoding.DecodeString(contentStr)
+	if err != nil {
+		Logger.log.Errorf("ERROR: an error occured while decoding content string of privacy token registration action: %+v", err)
+		return [][]string{}, nil
+	}
+	var registrationAction metadata.PrivacyTokenRegistrationAction
+	err = json.Unmarshal(contentBytes, &registrationAction)
+	if err != nil {
+		Logger.log.Errorf("ERROR: an error occured while unmarshaling privacy token registration action: %+v", err)
+		return [][]string{}, nil
+	}
+	status := common.PrivacyTokenRegistryAcceptedChainStatus
+	registration := registrationAction.Meta
+	if registration.IsSignedByCommittee() && !registration.IsApprovedByCommittee(beaconCommittee) {
+		Logger.log.Warnf("WARNING: registration of bridge token %s is not approved by the beacon committee", registration.TokenID)
+		status = common.PrivacyTokenRegistryRejectedChainStatus
+	} else if !currentPrivacyTokenRegistryState.applyPrivacyTokenRegistration(registration, registrationAction.TxReqID, beaconHeight) {
+		status = common.PrivacyTokenRegistryRejectedChainStatus
+	}
+	inst := []string{
+		strconv.Itoa(metaType),
+		strconv.Itoa(int(shardID)),
+		status,
+		contentStr,
+	}
+	return [][]string{inst}, nil
+}
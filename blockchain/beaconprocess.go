@@ -2,7 +2,6 @@ package blockchain
 
 import (
 	"bytes"
-	"encoding/json"
 	"fmt"
 	"reflect"
 	"sort"
@@ -341,7 +340,7 @@ func (blockchain *BlockChain) verifyPreProcessingBeaconBlock(beaconBlock *Beacon
 		return NewBlockChainError(FetchBeaconBlockError, err)
 	}
 	previousBeaconBlock := NewBeaconBlock()
-	err = json.Unmarshal(parentBlockBytes, previousBeaconBlock)
+	err = UnmarshalStoredBeaconBlock(parentBlockBytes, previousBeaconBlock)
 	if err != nil {
 		return NewBlockChainError(UnmashallJsonBeaconBlockError, fmt.Errorf("Failed to unmarshall parent block of block height %+v", beaconBlock.Header.Height))
 	}
@@ -933,6 +932,16 @@ func (beaconBestState *BeaconBestState) processInstruction(instruction []string)
 			}
 		}
 	}
+	if instruction[0] == UnbondingAction {
+		if err := beaconBestState.processUnbondingInstruction(instruction); err != nil {
+			return err, false, []incognitokey.CommitteePublicKey{}, []incognitokey.CommitteePublicKey{}
+		}
+		return nil, false, []incognitokey.CommitteePublicKey{}, []incognitokey.CommitteePublicKey{}
+	}
+	if instruction[0] == ReturnStakingAction {
+		beaconBestState.processReturnStakingInstruction(instruction)
+		return nil, false, []incognitokey.CommitteePublicKey{}, []incognitokey.CommitteePublicKey{}
+	}
 	if instruction[0] == SwapAction {
 		Logger.log.Info("Swap Instruction", instruction)
 		inPublickeys := strings.Split(instruction[1], ",")
@@ -1225,5 +1234,41 @@ func (blockchain *BlockChain) processStoreBeaconBlock(
 		return NewBlockChainError(ProcessPDEInstructionError, err)
 	}
 
+	// execute, store
+	err = blockchain.processStakingPoolInstructions(beaconBlock, &batchPutData)
+	if err != nil {
+		return NewBlockChainError(ProcessStakingPoolInstructionError, err)
+	}
+
+	// execute, store
+	err = blockchain.processPrivacyTokenRegistryInstructions(beaconBlock, &batchPutData)
+	if err != nil {
+		return NewBlockChainError(ProcessPrivacyTokenRegistryInstructionError, err)
+	}
+
+	// execute, store
+	err = blockchain.processMintableTokenInstructions(beaconBlock, &batchPutData)
+	if err != nil {
+		return NewBlockChainError(ProcessMintableTokenInstructionError, err)
+	}
+
+	// execute, store
+	err = blockchain.processBridgeLimiterInstructions(beaconBlock, &batchPutData)
+	if err != nil {
+		return NewBlockChainError(ProcessBridgeLimiterInstructionError, err)
+	}
+
+	// execute, store
+	err = blockchain.processDAOGovernanceInstructions(beaconBlock, &batchPutData)
+	if err != nil {
+		return NewBlockChainError(ProcessDAOGovernanceInstructionError, err)
+	}
+
+	// execute, store
+	err = blockchain.processBTCRelayingInstructions(beaconBlock, &batchPutData)
+	if err != nil {
+		return NewBlockChainError(ProcessBTCRelayingInstructionError, err)
+	}
+
 	return blockchain.config.DataBase.PutBatch(batchPutData)
 }
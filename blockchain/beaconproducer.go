@@ -375,6 +375,7 @@ func (beaconBestState *BeaconBestState) GenerateInstruction(
 	instructions := [][]string{}
 	instructions = append(instructions, bridgeInstructions...)
 	instructions = append(instructions, acceptedRewardInstructions...)
+	allSwapInstructions := [][]string{}
 	//=======Swap
 	// Shard Swap: both abnormal or normal swap
 	var keys []int
@@ -384,6 +385,7 @@ func (beaconBestState *BeaconBestState) GenerateInstruction(
 	sort.Ints(keys)
 	for _, shardID := range keys {
 		instructions = append(instructions, swapInstructions[byte(shardID)]...)
+		allSwapInstructions = append(allSwapInstructions, swapInstructions[byte(shardID)]...)
 	}
 	// Beacon normal swap
 
@@ -417,11 +419,18 @@ func (beaconBestState *BeaconBestState) GenerateInstruction(
 			swapBeaconInstructions = append(swapBeaconInstructions, "beacon")
 			swapBeaconInstructions = append(swapBeaconInstructions, string(badProducersWithPunishmentBytes))
 			instructions = append(instructions, swapBeaconInstructions)
+			allSwapInstructions = append(allSwapInstructions, swapBeaconInstructions)
 			// Generate instruction storing validators pubkey and send to bridge
 			beaconRootInst, _ := buildBeaconSwapConfirmInstruction(currentValidators, newBeaconHeight)
 			instructions = append(instructions, beaconRootInst)
 		}
 	}
+	// Unbonding: hold stake of swapped out validators, release the ones passed unbonding period
+	unbondingInstructions, err := blockchain.buildUnbondingInstructions(beaconBestState, allSwapInstructions, newBeaconHeight, chainParamEpoch)
+	if err != nil {
+		return [][]string{}, NewBlockChainError(BuildReturnStakingInstructionError, err)
+	}
+	instructions = append(instructions, unbondingInstructions...)
 	// Stake
 	instructions = append(instructions, stakeInstructions...)
 	// Stop Auto Staking
@@ -552,6 +561,13 @@ func (beaconBestState *BeaconBestState) GetValidStakers(stakers []string) []stri
 		panic(err)
 	}
 	stakers = common.GetValidStaker(candidateShardWaitingForNextRandomStr, stakers)
+
+	// stake of unbonding validators is not returned yet
+	unbondingStakersStr := []string{}
+	for publicKey := range beaconBestState.UnbondingStakes {
+		unbondingStakersStr = append(unbondingStakersStr, publicKey)
+	}
+	stakers = common.GetValidStaker(unbondingStakersStr, stakers)
 	return stakers
 }
 
@@ -738,7 +754,7 @@ func (blockchain *BlockChain) GetShardStateFromBlock(newBeaconHeight uint64, sha
 	bridgeInstructions = append(bridgeInstructions, bridgeInstructionForBlock...)
 
 	// Collect stateful actions
-	statefulActions := blockchain.collectStatefulActions(shardBlock.Instructions)
+	statefulActions := blockchain.collectStatefulActions(shardBlock.Instructions, newBeaconHeight)
 
 	Logger.log.Infof("Becon Produce: Got Shard Block %+v Shard %+v \n", shardBlock.Header.Height, shardID)
 	return shardStates, stakeInstructions, tempValidStakePublicKeys, swapInstructions, bridgeInstructions, acceptedRewardInstructions, stopAutoStakingInstructions, statefulActions
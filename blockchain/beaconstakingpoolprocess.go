@@ -0,0 +1,259 @@
+package blockchain
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/database"
+	"github.com/incognitochain/incognito-chain/database/lvdb"
+	"github.com/incognitochain/incognito-chain/metadata"
+)
+
+func (blockchain *BlockChain) processStakingPoolInstructions(block *BeaconBlock, bd *[]database.BatchData) error {
+	db := blockchain.GetDatabase()
+	currentStakingPoolState, err := InitCurrentStakingPoolStateFromDB(db)
+	if err != nil {
+		Logger.log.Error(err)
+		return nil
+	}
+	for _, inst := range block.Body.Instructions {
+		if len(inst) < 2 {
+			continue // Not error, just not staking pool instruction
+		}
+		var err error
+		switch inst[0] {
+		case strconv.Itoa(metadata.StakingPoolCreationMeta):
+			err = blockchain.processStakingPoolCreation(block.Header.Height, inst, currentStakingPoolState)
+		case strconv.Itoa(metadata.StakingPoolDepositMeta):
+			err = blockchain.processStakingPoolDeposit(inst, currentStakingPoolState)
+		case strconv.Itoa(metadata.StakingPoolWithdrawalRequestMeta):
+			err = blockchain.processStakingPoolWithdrawal(inst, currentStakingPoolState)
+		case StakeAction:
+			processStakingPoolStake(inst, currentStakingPoolState)
+		case SwapAction:
+			blockchain.processStakingPoolSwap(inst, currentStakingPoolState)
+		}
+		if err != nil {
+			Logger.log.Error(err)
+			return nil
+		}
+	}
+	err = storeStakingPoolStateToDB(db, currentStakingPoolState)
+	if err != nil {
+		Logger.log.Error(err)
+	}
+	return nil
+}
+
+func (blockchain *BlockChain) processStakingPoolCreation(
+	beaconHeight uint64,
+	instruction []string,
+	currentStakingPoolState *CurrentStakingPoolState,
+) error {
+	if len(instruction) != 4 {
+		return nil // skip the instruction
+	}
+	db := blockchain.GetDatabase()
+	if instruction[2] == common.StakingPoolRejectedChainStatus {
+		contentBytes, err := base64.StdEncoding.DecodeString(instruction[3])
+		if err != nil {
+			Logger.log.Errorf("ERROR: an error occured while decoding content string of staking pool creation action: %+v", err)
+			return nil
+		}
+		var creationAction metadata.StakingPoolCreationAction
+		err = json.Unmarshal(contentBytes, &creationAction)
+		if err != nil {
+			Logger.log.Errorf("ERROR: an error occured while unmarshaling staking pool creation action: %+v", err)
+			return nil
+		}
+		err = db.TrackStakingPoolStatus(creationAction.TxReqID[:], byte(common.StakingPoolRejectedStatus))
+		if err != nil {
+			Logger.log.Errorf("ERROR: an error occured while tracking staking pool rejected creation status: %+v", err)
+		}
+		return nil
+	}
+	var acceptedContent metadata.StakingPoolCreationAcceptedContent
+	err := json.Unmarshal([]byte(instruction[3]), &acceptedContent)
+	if err != nil {
+		Logger.log.Errorf("WARNING: an error occured while unmarshaling StakingPoolCreationAcceptedContent: %+v", err)
+		return nil
+	}
+	currentStakingPoolState.StakingPools[acceptedContent.CommitteePublicKey] = &lvdb.StakingPool{
+		CommitteePublicKey:  acceptedContent.CommitteePublicKey,
+		OperatorAddressStr:  acceptedContent.OperatorAddressStr,
+		CommissionRate:      acceptedContent.CommissionRate,
+		CreatedBeaconHeight: beaconHeight,
+		CreationTxReqID:     acceptedContent.TxReqID,
+	}
+	currentStakingPoolState.Delegations[acceptedContent.CommitteePublicKey] = map[string]uint64{}
+	currentStakingPoolState.markTouched(acceptedContent.CommitteePublicKey)
+	err = db.TrackStakingPoolStatus(acceptedContent.TxReqID[:], byte(common.StakingPoolAcceptedStatus))
+	if err != nil {
+		Logger.log.Errorf("ERROR: an error occured while tracking staking pool accepted creation status: %+v", err)
+	}
+	return nil
+}
+
+func (blockchain *BlockChain) processStakingPoolDeposit(
+	instruction []string,
+	currentStakingPoolState *CurrentStakingPoolState,
+) error {
+	if len(instruction) != 4 {
+		return nil // skip the instruction
+	}
+	db := blockchain.GetDatabase()
+	var depositContent metadata.StakingPoolDepositAcceptedContent
+	err := json.Unmarshal([]byte(instruction[3]), &depositContent)
+	if err != nil {
+		Logger.log.Errorf("WARNING: an error occured while unmarshaling StakingPoolDepositAcceptedContent: %+v", err)
+		return nil
+	}
+	if instruction[2] == common.StakingPoolRefundChainStatus {
+		err = db.TrackStakingPoolStatus(depositContent.TxReqID[:], byte(common.StakingPoolRefundStatus))
+		if err != nil {
+			Logger.log.Errorf("ERROR: an error occured while tracking staking pool refunded deposit status: %+v", err)
+		}
+		return nil
+	}
+	stakingPool, found := currentStakingPoolState.StakingPools[depositContent.CommitteePublicKey]
+	if !found || stakingPool == nil {
+		Logger.log.Errorf("WARNING: could not find out staking pool with committee public key: %s", depositContent.CommitteePublicKey)
+		return nil
+	}
+	delegations, err := currentStakingPoolState.getDelegations(db, depositContent.CommitteePublicKey)
+	if err != nil {
+		return err
+	}
+	delegations[depositContent.DelegatorAddressStr] += depositContent.DepositAmount
+	stakingPool.TotalDelegation += depositContent.DepositAmount
+	currentStakingPoolState.markTouched(depositContent.CommitteePublicKey)
+	err = db.TrackStakingPoolStatus(depositContent.TxReqID[:], byte(common.StakingPoolAcceptedStatus))
+	if err != nil {
+		Logger.log.Errorf("ERROR: an error occured while tracking staking pool accepted deposit status: %+v", err)
+	}
+	return nil
+}
+
+func (blockchain *BlockChain) processStakingPoolWithdrawal(
+	instruction []string,
+	currentStakingPoolState *CurrentStakingPoolState,
+) error {
+	if len(instruction) != 4 {
+		return nil // skip the instruction
+	}
+	db := blockchain.GetDatabase()
+	if instruction[2] == common.StakingPoolRejectedChainStatus {
+		contentBytes, err := base64.StdEncoding.DecodeString(instruction[3])
+		if err != nil {
+			Logger.log.Errorf("ERROR: an error occured while decoding content string of staking pool withdrawal action: %+v", err)
+			return nil
+		}
+		var withdrawalAction metadata.StakingPoolWithdrawalRequestAction
+		err = json.Unmarshal(contentBytes, &withdrawalAction)
+		if err != nil {
+			Logger.log.Errorf("ERROR: an error occured while unmarshaling staking pool withdrawal action: %+v", err)
+			return nil
+		}
+		err = db.TrackStakingPoolStatus(withdrawalAction.TxReqID[:], byte(common.StakingPoolRejectedStatus))
+		if err != nil {
+			Logger.log.Errorf("ERROR: an error occured while tracking staking pool rejected withdrawal status: %+v", err)
+		}
+		return nil
+	}
+	var wdAcceptedContent metadata.StakingPoolWithdrawalAcceptedContent
+	err := json.Unmarshal([]byte(instruction[3]), &wdAcceptedContent)
+	if err != nil {
+		Logger.log.Errorf("WARNING: an error occured while unmarshaling StakingPoolWithdrawalAcceptedContent: %+v", err)
+		return nil
+	}
+	stakingPool, found := currentStakingPoolState.StakingPools[wdAcceptedContent.CommitteePublicKey]
+	if !found || stakingPool == nil {
+		Logger.log.Errorf("WARNING: could not find out staking pool with committee public key: %s", wdAcceptedContent.CommitteePublicKey)
+		return nil
+	}
+	// the delegation of a released withdrawal was already taken when it was queued
+	if instruction[2] == common.StakingPoolReleasedChainStatus {
+		unbondingWithdrawals := []lvdb.StakingPoolWithdrawal{}
+		for _, withdrawal := range stakingPool.UnbondingWithdrawals {
+			if withdrawal.TxReqID != wdAcceptedContent.TxReqID {
+				unbondingWithdrawals = append(unbondingWithdrawals, withdrawal)
+			}
+		}
+		stakingPool.UnbondingWithdrawals = unbondingWithdrawals
+		currentStakingPoolState.markTouched(wdAcceptedContent.CommitteePublicKey)
+		err = db.TrackStakingPoolStatus(wdAcceptedContent.TxReqID[:], byte(common.StakingPoolAcceptedStatus))
+		if err != nil {
+			Logger.log.Errorf("ERROR: an error occured while tracking staking pool released withdrawal status: %+v", err)
+		}
+		return nil
+	}
+	delegations, err := currentStakingPoolState.getDelegations(db, wdAcceptedContent.CommitteePublicKey)
+	if err != nil {
+		return err
+	}
+	if delegations[wdAcceptedContent.DelegatorAddressStr] < wdAcceptedContent.WithdrawalAmount {
+		Logger.log.Errorf("WARNING: delegation of %s is less than withdrawal amount", wdAcceptedContent.DelegatorAddressStr)
+		return nil
+	}
+	delegations[wdAcceptedContent.DelegatorAddressStr] -= wdAcceptedContent.WithdrawalAmount
+	stakingPool.TotalDelegation -= wdAcceptedContent.WithdrawalAmount
+	status := common.StakingPoolAcceptedStatus
+	if instruction[2] == common.StakingPoolUnbondingChainStatus {
+		stakingPool.UnbondingWithdrawals = append(stakingPool.UnbondingWithdrawals, lvdb.StakingPoolWithdrawal{
+			DelegatorAddressStr: wdAcceptedContent.DelegatorAddressStr,
+			WithdrawalAmount:    wdAcceptedContent.WithdrawalAmount,
+			TxReqID:             wdAcceptedContent.TxReqID,
+			ShardID:             wdAcceptedContent.ShardID,
+		})
+		status = common.StakingPoolUnbondingStatus
+	}
+	currentStakingPoolState.markTouched(wdAcceptedContent.CommitteePublicKey)
+	err = db.TrackStakingPoolStatus(wdAcceptedContent.TxReqID[:], byte(status))
+	if err != nil {
+		Logger.log.Errorf("ERROR: an error occured while tracking staking pool accepted withdrawal status: %+v", err)
+	}
+	return nil
+}
+
+// processStakingPoolStake marks pools staked by stake instructions built from their delegations
+func processStakingPoolStake(
+	instruction []string,
+	currentStakingPoolState *CurrentStakingPoolState,
+) {
+	if len(instruction) != 6 || instruction[2] != "shard" {
+		return
+	}
+	for _, committeePublicKey := range strings.Split(instruction[1], ",") {
+		stakingPool, found := currentStakingPoolState.StakingPools[committeePublicKey]
+		if !found || stakingPool == nil {
+			continue
+		}
+		stakingPool.IsStaked = true
+		currentStakingPoolState.markTouched(committeePublicKey)
+	}
+}
+
+// processStakingPoolSwap unstakes pools which are swapped out without auto re-staking,
+// their delegations become withdrawable in full
+func (blockchain *BlockChain) processStakingPoolSwap(
+	instruction []string,
+	currentStakingPoolState *CurrentStakingPoolState,
+) {
+	if len(instruction) < 4 || len(instruction[2]) == 0 {
+		return
+	}
+	for _, committeePublicKey := range strings.Split(instruction[2], ",") {
+		stakingPool, found := currentStakingPoolState.StakingPools[committeePublicKey]
+		if !found || stakingPool == nil || !stakingPool.IsStaked {
+			continue
+		}
+		if _, ok := blockchain.BestState.Beacon.AutoStaking[committeePublicKey]; ok {
+			continue
+		}
+		stakingPool.IsStaked = false
+		currentStakingPoolState.markTouched(committeePublicKey)
+	}
+}
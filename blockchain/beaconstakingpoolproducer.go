@@ -0,0 +1,283 @@
+package blockchain
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strconv"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/database/lvdb"
+	"github.com/incognitochain/incognito-chain/metadata"
+)
+
+func (blockchain *BlockChain) buildInstructionsForStakingPoolCreation(
+	contentStr string,
+	shardID byte,
+	metaType int,
+	currentStakingPoolState *CurrentStakingPoolState,
+	beaconHeight uint64,
+) ([][]string, error) {
+	if currentStakingPoolState == nil {
+		Logger.log.Warn("WARN - [buildInstructionsForStakingPoolCreation]: Current staking pool state is null.")
+		return [][]string{}, nil
+	}
+	contentBytes, err := base64.StdEncoding.DecodeString(contentStr)
+	if err != nil {
+		Logger.log.Errorf("ERROR: an error occured while decoding content string of staking pool creation action: %+v", err)
+		return [][]string{}, nil
+	}
+	var creationAction metadata.StakingPoolCreationAction
+	err = json.Unmarshal(contentBytes, &creationAction)
+	if err != nil {
+		Logger.log.Errorf("ERROR: an error occured while unmarshaling staking pool creation action: %+v", err)
+		return [][]string{}, nil
+	}
+	creationMeta := creationAction.Meta
+	_, found := currentStakingPoolState.StakingPools[creationMeta.CommitteePublicKey]
+	if found || len(blockchain.BestState.Beacon.GetValidStakers([]string{creationMeta.CommitteePublicKey})) == 0 {
+		inst := []string{
+			strconv.Itoa(metaType),
+			strconv.Itoa(int(shardID)),
+			common.StakingPoolRejectedChainStatus,
+			contentStr,
+		}
+		return [][]string{inst}, nil
+	}
+
+	currentStakingPoolState.StakingPools[creationMeta.CommitteePublicKey] = &lvdb.StakingPool{
+		CommitteePublicKey:  creationMeta.CommitteePublicKey,
+		OperatorAddressStr:  creationMeta.OperatorAddressStr,
+		CommissionRate:      creationMeta.CommissionRate,
+		CreatedBeaconHeight: beaconHeight,
+		CreationTxReqID:     creationAction.TxReqID,
+	}
+	currentStakingPoolState.Delegations[creationMeta.CommitteePublicKey] = map[string]uint64{}
+	acceptedContent := metadata.StakingPoolCreationAcceptedContent{
+		CommitteePublicKey: creationMeta.CommitteePublicKey,
+		OperatorAddressStr: creationMeta.OperatorAddressStr,
+		CommissionRate:     creationMeta.CommissionRate,
+		TxReqID:            creationAction.TxReqID,
+		ShardID:            shardID,
+	}
+	acceptedContentBytes, err := json.Marshal(acceptedContent)
+	if err != nil {
+		Logger.log.Errorf("ERROR: an error occured while marshaling StakingPoolCreationAcceptedContent: %+v", err)
+		return [][]string{}, nil
+	}
+	inst := []string{
+		strconv.Itoa(metaType),
+		strconv.Itoa(int(shardID)),
+		common.StakingPoolAcceptedChainStatus,
+		string(acceptedContentBytes),
+	}
+	return [][]string{inst}, nil
+}
+
+// isStakingPoolStopped tells if the key of a pool stopped auto staking or is in unbonding queue
+func (blockchain *BlockChain) isStakingPoolStopped(stakingPool *lvdb.StakingPool) bool {
+	if _, isUnbonding := blockchain.BestState.Beacon.UnbondingStakes[stakingPool.CommitteePublicKey]; isUnbonding {
+		return true
+	}
+	isAutoStaking, found := blockchain.BestState.Beacon.AutoStaking[stakingPool.CommitteePublicKey]
+	return found && !isAutoStaking
+}
+
+// buildInstructionsForStakingPoolRelease pays unbonding withdrawals of pools whose stake is released
+func buildInstructionsForStakingPoolRelease(
+	currentStakingPoolState *CurrentStakingPoolState,
+	releasedPublicKeys []string,
+) [][]string {
+	if currentStakingPoolState == nil {
+		Logger.log.Warn("WARN - [buildInstructionsForStakingPoolRelease]: Current staking pool state is null.")
+		return [][]string{}
+	}
+	insts := [][]string{}
+	for _, committeePublicKey := range releasedPublicKeys {
+		stakingPool, found := currentStakingPoolState.StakingPools[committeePublicKey]
+		if !found || stakingPool == nil {
+			continue
+		}
+		for _, withdrawal := range stakingPool.UnbondingWithdrawals {
+			releasedContent := metadata.StakingPoolWithdrawalAcceptedContent{
+				CommitteePublicKey:  committeePublicKey,
+				DelegatorAddressStr: withdrawal.DelegatorAddressStr,
+				WithdrawalAmount:    withdrawal.WithdrawalAmount,
+				TxReqID:             withdrawal.TxReqID,
+				ShardID:             withdrawal.ShardID,
+			}
+			releasedContentBytes, err := json.Marshal(releasedContent)
+			if err != nil {
+				Logger.log.Errorf("ERROR: an error occured while marshaling StakingPoolWithdrawalAcceptedContent: %+v", err)
+				continue
+			}
+			insts = append(insts, []string{
+				strconv.Itoa(metadata.StakingPoolWithdrawalRequestMeta),
+				strconv.Itoa(int(withdrawal.ShardID)),
+				common.StakingPoolReleasedChainStatus,
+				string(releasedContentBytes),
+			})
+		}
+		stakingPool.UnbondingWithdrawals = nil
+	}
+	return insts
+}
+
+func (blockchain *BlockChain) buildInstructionsForStakingPoolDeposit(
+	contentStr string,
+	shardID byte,
+	metaType int,
+	currentStakingPoolState *CurrentStakingPoolState,
+) ([][]string, error) {
+	if currentStakingPoolState == nil {
+		Logger.log.Warn("WARN - [buildInstructionsForStakingPoolDeposit]: Current staking pool state is null.")
+		return [][]string{}, nil
+	}
+	contentBytes, err := base64.StdEncoding.DecodeString(contentStr)
+	if err != nil {
+		Logger.log.Errorf("ERROR: an error occured while decoding content string of staking pool deposit action: %+v", err)
+		return [][]string{}, nil
+	}
+	var depositAction metadata.StakingPoolDepositAction
+	err = json.Unmarshal(contentBytes, &depositAction)
+	if err != nil {
+		Logger.log.Errorf("ERROR: an error occured while unmarshaling staking pool deposit action: %+v", err)
+		return [][]string{}, nil
+	}
+	depositMeta := depositAction.Meta
+	depositContent := metadata.StakingPoolDepositAcceptedContent{
+		CommitteePublicKey:  depositMeta.CommitteePublicKey,
+		DelegatorAddressStr: depositMeta.DelegatorAddressStr,
+		DepositAmount:       depositMeta.DepositAmount,
+		TxReqID:             depositAction.TxReqID,
+		ShardID:             shardID,
+	}
+	depositContentBytes, err := json.Marshal(depositContent)
+	if err != nil {
+		Logger.log.Errorf("ERROR: an error occured while marshaling StakingPoolDepositAcceptedContent: %+v", err)
+		return [][]string{}, nil
+	}
+
+	stakingPool, found := currentStakingPoolState.StakingPools[depositMeta.CommitteePublicKey]
+	if !found || stakingPool == nil {
+		inst := []string{
+			strconv.Itoa(metaType),
+			strconv.Itoa(int(shardID)),
+			common.StakingPoolRefundChainStatus,
+			string(depositContentBytes),
+		}
+		return [][]string{inst}, nil
+	}
+	delegations, err := currentStakingPoolState.getDelegations(blockchain.GetDatabase(), depositMeta.CommitteePublicKey)
+	if err != nil {
+		return [][]string{}, err
+	}
+	delegations[depositMeta.DelegatorAddressStr] += depositMeta.DepositAmount
+	stakingPool.TotalDelegation += depositMeta.DepositAmount
+
+	insts := [][]string{
+		{
+			strconv.Itoa(metaType),
+			strconv.Itoa(int(shardID)),
+			common.StakingPoolAcceptedChainStatus,
+			string(depositContentBytes),
+		},
+	}
+	// the pool becomes a shard candidate once its delegations cover the staking amount
+	if !stakingPool.IsStaked &&
+		stakingPool.TotalDelegation >= blockchain.config.ChainParams.StakingAmountShard &&
+		len(blockchain.BestState.Beacon.GetValidStakers([]string{stakingPool.CommitteePublicKey})) > 0 {
+		stakingPool.IsStaked = true
+		insts = append(insts, []string{
+			StakeAction,
+			stakingPool.CommitteePublicKey,
+			"shard",
+			stakingPool.CreationTxReqID.String(),
+			stakingPool.OperatorAddressStr,
+			"true",
+		})
+	}
+	return insts, nil
+}
+
+func (blockchain *BlockChain) buildInstructionsForStakingPoolWithdrawal(
+	contentStr string,
+	shardID byte,
+	metaType int,
+	currentStakingPoolState *CurrentStakingPoolState,
+	beaconHeight uint64,
+) ([][]string, error) {
+	if currentStakingPoolState == nil {
+		Logger.log.Warn("WARN - [buildInstructionsForStakingPoolWithdrawal]: Current staking pool state is null.")
+		return [][]string{}, nil
+	}
+	contentBytes, err := base64.StdEncoding.DecodeString(contentStr)
+	if err != nil {
+		Logger.log.Errorf("ERROR: an error occured while decoding content string of staking pool withdrawal action: %+v", err)
+		return [][]string{}, nil
+	}
+	var withdrawalAction metadata.StakingPoolWithdrawalRequestAction
+	err = json.Unmarshal(contentBytes, &withdrawalAction)
+	if err != nil {
+		Logger.log.Errorf("ERROR: an error occured while unmarshaling staking pool withdrawal action: %+v", err)
+		return [][]string{}, nil
+	}
+	wdMeta := withdrawalAction.Meta
+	rejectedInst := []string{
+		strconv.Itoa(metaType),
+		strconv.Itoa(int(shardID)),
+		common.StakingPoolRejectedChainStatus,
+		contentStr,
+	}
+	stakingPool, found := currentStakingPoolState.StakingPools[wdMeta.CommitteePublicKey]
+	if !found || stakingPool == nil {
+		return [][]string{rejectedInst}, nil
+	}
+	delegations, err := currentStakingPoolState.getDelegations(blockchain.GetDatabase(), wdMeta.CommitteePublicKey)
+	if err != nil {
+		return [][]string{}, err
+	}
+	if delegations[wdMeta.DelegatorAddressStr] < wdMeta.WithdrawalAmount {
+		return [][]string{rejectedInst}, nil
+	}
+	// a staked or unbonding pool must keep at least the staking amount,
+	// except for partial unstake of a stopped pool which is paid once stake of the pool is released
+	status := common.StakingPoolAcceptedChainStatus
+	_, isUnbonding := blockchain.BestState.Beacon.UnbondingStakes[stakingPool.CommitteePublicKey]
+	if (stakingPool.IsStaked || isUnbonding) &&
+		stakingPool.TotalDelegation-wdMeta.WithdrawalAmount < blockchain.config.ChainParams.StakingAmountShard {
+		if !blockchain.isUnbondingActive(beaconHeight) || !blockchain.isStakingPoolStopped(stakingPool) {
+			return [][]string{rejectedInst}, nil
+		}
+		status = common.StakingPoolUnbondingChainStatus
+	}
+	delegations[wdMeta.DelegatorAddressStr] -= wdMeta.WithdrawalAmount
+	stakingPool.TotalDelegation -= wdMeta.WithdrawalAmount
+	if status == common.StakingPoolUnbondingChainStatus {
+		stakingPool.UnbondingWithdrawals = append(stakingPool.UnbondingWithdrawals, lvdb.StakingPoolWithdrawal{
+			DelegatorAddressStr: wdMeta.DelegatorAddressStr,
+			WithdrawalAmount:    wdMeta.WithdrawalAmount,
+			TxReqID:             withdrawalAction.TxReqID,
+			ShardID:             shardID,
+		})
+	}
+
+	acceptedContent := metadata.StakingPoolWithdrawalAcceptedContent{
+		CommitteePublicKey:  wdMeta.CommitteePublicKey,
+		DelegatorAddressStr: wdMeta.DelegatorAddressStr,
+		WithdrawalAmount:    wdMeta.WithdrawalAmount,
+		TxReqID:             withdrawalAction.TxReqID,
+		ShardID:             shardID,
+	}
+	acceptedContentBytes, err := json.Marshal(acceptedContent)
+	if err != nil {
+		Logger.log.Errorf("ERROR: an error occured while marshaling StakingPoolWithdrawalAcceptedContent: %+v", err)
+		return [][]string{}, nil
+	}
+	inst := []string{
+		strconv.Itoa(metaType),
+		strconv.Itoa(int(shardID)),
+		status,
+		string(acceptedContentBytes),
+	}
+	return [][]string{inst}, nil
+}
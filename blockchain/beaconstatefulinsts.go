@@ -3,7 +3,6 @@ package blockchain
 import (
 	"encoding/base64"
 	"encoding/json"
-	"math/big"
 	"sort"
 	"strconv"
 
@@ -16,6 +15,7 @@ import (
 // build instructions at beacon chain before syncing to shards
 func (blockchain *BlockChain) collectStatefulActions(
 	shardBlockInstructions [][]string,
+	beaconHeight uint64,
 ) [][]string {
 	// stateful instructions are dependently processed with results of instructioins before them in shards2beacon blocks
 	statefulInsts := [][]string{}
@@ -35,9 +35,19 @@ func (blockchain *BlockChain) collectStatefulActions(
 		switch metaType {
 		case metadata.IssuingRequestMeta, metadata.IssuingETHRequestMeta,
 			metadata.PDEContributionMeta, metadata.PDETradeRequestMeta,
-			metadata.PDEWithdrawalRequestMeta:
+			metadata.PDEWithdrawalRequestMeta, metadata.StakingPoolCreationMeta,
+			metadata.StakingPoolDepositMeta, metadata.StakingPoolWithdrawalRequestMeta,
+			metadata.PrivacyTokenRegistrationMeta, metadata.MintableTokenInitMeta,
+			metadata.MintableTokenMintMeta, metadata.MintableTokenBurnMeta,
+			metadata.RelayingBTCHeadersMeta, metadata.IssuingBTCRequestMeta,
+			metadata.DAOProposalMeta, metadata.DAOVoteMeta:
 			statefulInsts = append(statefulInsts, inst)
 
+		case metadata.BurningRequestMeta, metadata.BridgeControlRequestMeta:
+			if blockchain.isBridgeLimiterActive(beaconHeight) {
+				statefulInsts = append(statefulInsts, inst)
+			}
+
 		default:
 			continue
 		}
@@ -68,12 +78,60 @@ func (blockchain *BlockChain) buildStatefulInstructions(
 	if err != nil {
 		Logger.log.Error(err)
 	}
+	currentStakingPoolState, err := InitCurrentStakingPoolStateFromDB(db)
+	if err != nil {
+		Logger.log.Error(err)
+	}
+	currentPrivacyTokenRegistryState, err := InitCurrentPrivacyTokenRegistryStateFromDB(db)
+	if err != nil {
+		Logger.log.Error(err)
+	}
+	currentMintableTokenState, err := InitCurrentMintableTokenStateFromDB(db)
+	if err != nil {
+		Logger.log.Error(err)
+	}
+	currentBTCRelayingState, err := InitCurrentBTCRelayingStateFromDB(db, &blockchain.config.ChainParams.BTCRelaying)
+	if err != nil {
+		Logger.log.Error(err)
+	}
+	// bridge requests are not capped before the break point, a nil limiter state lets them through
+	var currentBridgeLimiterState *CurrentBridgeLimiterState
+	if blockchain.isBridgeLimiterActive(beaconHeight) {
+		currentBridgeLimiterState, err = InitCurrentBridgeLimiterStateFromDB(db, blockchain.config.ChainParams.BridgeTokenLimits, beaconHeight, blockchain.config.ChainParams.Epoch)
+		if err != nil {
+			Logger.log.Error(err)
+		}
+	}
+	currentDAOGovernanceState, err := InitCurrentDAOGovernanceStateFromDB(db)
+	if err != nil {
+		Logger.log.Error(err)
+	}
+	// voting weights have their own staking pool state so that pool changes of the block do not affect them
+	weightsStakingPoolState, err := InitCurrentStakingPoolStateFromDB(db)
+	if err != nil {
+		Logger.log.Error(err)
+	}
+	daoVotingWeights := newDAOVotingWeights(blockchain.BestState.Beacon, weightsStakingPoolState, db, blockchain.config.ChainParams.StakingAmountShard)
 	accumulatedValues := &metadata.AccumulatedValues{
-		UniqETHTxsUsed:   [][]byte{},
-		DBridgeTokenPair: map[string][]byte{},
-		CBridgeTokens:    []*common.Hash{},
+		UniqETHTxsUsed:       [][]byte{},
+		UniqBTCTxsUsed:       [][]byte{},
+		DBridgeTokenPair:     map[string][]byte{},
+		DBridgeTokenChainIDs: map[string]uint64{},
+		CBridgeTokens:        []*common.Hash{},
 	}
+	// queued bridge requests which fit in the caps are released before new requests
 	instructions := [][]string{}
+	if currentBridgeLimiterState != nil {
+		instructions = blockchain.buildInstructionsForReleasingBridgeReqs(currentBridgeLimiterState, beaconHeight, db, accumulatedValues)
+	}
+	// proposals are tallied and executed before new proposals and votes
+	instructions = append(instructions, blockchain.buildInstructionsForDAOGovernance(currentDAOGovernanceState, daoVotingWeights, beaconHeight)...)
+	// unbonding withdrawals of staking pools are paid with the stake returned in the block
+	releasedPublicKeys, err := blockchain.getReleasedUnbondingStakes(blockchain.BestState.Beacon, beaconHeight)
+	if err != nil {
+		Logger.log.Error(err)
+	}
+	instructions = append(instructions, buildInstructionsForStakingPoolRelease(currentStakingPoolState, releasedPublicKeys)...)
 	pdeContributionActionsByShardID := map[byte][][]string{}
 	pdeTradeActionsByShardID := map[byte][][]string{}
 	pdeWithdrawalActionsByShardID := map[byte][][]string{}
@@ -98,7 +156,20 @@ func (blockchain *BlockChain) buildStatefulInstructions(
 				newInst, err = blockchain.buildInstructionsForIssuingReq(contentStr, shardID, metaType, accumulatedValues)
 
 			case metadata.IssuingETHRequestMeta:
-				newInst, err = blockchain.buildInstructionsForIssuingETHReq(contentStr, shardID, metaType, accumulatedValues)
+				newInst, err = blockchain.buildInstructionsForIssuingETHReq(contentStr, shardID, metaType, accumulatedValues, currentBridgeLimiterState)
+
+			case metadata.BurningRequestMeta:
+				newInst, err = blockchain.buildInstructionsForBurningReq(contentStr, shardID, metaType, currentBridgeLimiterState, beaconHeight, db)
+
+			case metadata.BridgeControlRequestMeta:
+				beaconCommittee := blockchain.BestState.Beacon.GetBeaconCommittee()
+				newInst, err = blockchain.buildInstructionsForBridgeControl(contentStr, shardID, metaType, currentBridgeLimiterState, beaconCommittee, beaconHeight, db, accumulatedValues)
+
+			case metadata.DAOProposalMeta:
+				newInst, err = blockchain.buildInstructionsForDAOProposal(contentStr, shardID, metaType, currentDAOGovernanceState, daoVotingWeights, beaconHeight)
+
+			case metadata.DAOVoteMeta:
+				newInst, err = blockchain.buildInstructionsForDAOVote(contentStr, shardID, metaType, currentDAOGovernanceState, daoVotingWeights, beaconHeight)
 
 			case metadata.PDEContributionMeta:
 				pdeContributionActionsByShardID = groupPDEActionsByShardID(
@@ -118,6 +189,34 @@ func (blockchain *BlockChain) buildStatefulInstructions(
 					action,
 					shardID,
 				)
+			case metadata.StakingPoolCreationMeta:
+				newInst, err = blockchain.buildInstructionsForStakingPoolCreation(contentStr, shardID, metaType, currentStakingPoolState, beaconHeight)
+
+			case metadata.StakingPoolDepositMeta:
+				newInst, err = blockchain.buildInstructionsForStakingPoolDeposit(contentStr, shardID, metaType, currentStakingPoolState)
+
+			case metadata.StakingPoolWithdrawalRequestMeta:
+				newInst, err = blockchain.buildInstructionsForStakingPoolWithdrawal(contentStr, shardID, metaType, currentStakingPoolState, beaconHeight)
+
+			case metadata.PrivacyTokenRegistrationMeta:
+				beaconCommittee := blockchain.BestState.Beacon.GetBeaconCommittee()
+				newInst, err = blockchain.buildInstructionsForPrivacyTokenRegistration(contentStr, shardID, metaType, currentPrivacyTokenRegistryState, beaconCommittee, beaconHeight)
+
+			case metadata.MintableTokenInitMeta:
+				newInst, err = blockchain.buildInstructionsForMintableTokenInit(contentStr, shardID, metaType, currentMintableTokenState, beaconHeight)
+
+			case metadata.MintableTokenMintMeta:
+				newInst, err = blockchain.buildInstructionsForMintableTokenMint(contentStr, shardID, metaType, currentMintableTokenState)
+
+			case metadata.MintableTokenBurnMeta:
+				newInst, err = blockchain.buildInstructionsForMintableTokenBurn(contentStr, shardID, metaType, currentMintableTokenState)
+
+			case metadata.RelayingBTCHeadersMeta:
+				newInst, err = blockchain.buildInstructionsForRelayingBTCHeaders(contentStr, shardID, metaType, currentBTCRelayingState)
+
+			case metadata.IssuingBTCRequestMeta:
+				newInst, err = blockchain.buildInstructionsForIssuingBTCReq(contentStr, shardID, metaType, currentBTCRelayingState, accumulatedValues, currentBridgeLimiterState)
+
 			default:
 				continue
 			}
@@ -207,18 +306,12 @@ func sortPDETradeInstsByFee(
 
 		// sort trade actions by trading fee
 		sort.Slice(tradeActions, func(i, j int) bool {
-			// comparing a/b to c/d is equivalent with comparing a*d to c*b
-			firstItemProportion := big.NewInt(0)
-			firstItemProportion.Mul(
-				big.NewInt(int64(tradeActions[i].Meta.TradingFee)),
-				big.NewInt(int64(tradeActions[j].Meta.SellAmount)),
-			)
-			secondItemProportion := big.NewInt(0)
-			secondItemProportion.Mul(
-				big.NewInt(int64(tradeActions[j].Meta.TradingFee)),
-				big.NewInt(int64(tradeActions[i].Meta.SellAmount)),
+			return metadata.HasHigherPDETradingFeeRate(
+				tradeActions[i].Meta.TradingFee,
+				tradeActions[i].Meta.SellAmount,
+				tradeActions[j].Meta.TradingFee,
+				tradeActions[j].Meta.SellAmount,
 			)
-			return firstItemProportion.Cmp(secondItemProportion) == 1
 		})
 		sortedExistingPairTradeActions = append(sortedExistingPairTradeActions, tradeActions...)
 	}
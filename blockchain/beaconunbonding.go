@@ -0,0 +1,131 @@
+package blockchain
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+/*
+	Unbonding format:
+	- ["unbonding" "pubkey1,pubkey2,..." "releaseBeaconHeight"]
+	Return staking format:
+	- ["returnstaking" "pubkey1,pubkey2,..."]
+*/
+
+// isUnbondingActive tells if stake of swapped out validators is held in unbonding queue at a beacon height,
+// before the break point stake is returned as soon as validators are swapped out
+func (blockchain *BlockChain) isUnbondingActive(beaconHeight uint64) bool {
+	return beaconHeight >= blockchain.config.ChainParams.BeaconHeightBreakPointUnbonding
+}
+
+// buildUnbondingInstructions queues stake of swapped out validators and releases stake which passed its unbonding period
+func (blockchain *BlockChain) buildUnbondingInstructions(
+	beaconBestState *BeaconBestState,
+	swapInstructions [][]string,
+	newBeaconHeight uint64,
+	chainParamEpoch uint64,
+) ([][]string, error) {
+	if !blockchain.isUnbondingActive(newBeaconHeight) {
+		return [][]string{}, nil
+	}
+	instructions := [][]string{}
+	releaseHeight := newBeaconHeight + blockchain.config.ChainParams.UnbondingEpochs*chainParamEpoch
+	unbondingInstruction := beaconBestState.buildUnbondingInstruction(swapInstructions, releaseHeight)
+	if len(unbondingInstruction) > 0 {
+		instructions = append(instructions, unbondingInstruction)
+	}
+	returnStakingInstruction, err := blockchain.buildReturnStakingInstruction(beaconBestState, newBeaconHeight)
+	if err != nil {
+		return [][]string{}, err
+	}
+	if len(returnStakingInstruction) > 0 {
+		instructions = append(instructions, returnStakingInstruction)
+	}
+	return instructions, nil
+}
+
+// buildUnbondingInstruction queues stake of swapped out validators which won't be re-staked
+func (beaconBestState *BeaconBestState) buildUnbondingInstruction(swapInstructions [][]string, releaseHeight uint64) []string {
+	unbondingPublicKeys := []string{}
+	for _, swapInstruction := range swapInstructions {
+		if len(swapInstruction) < 4 || swapInstruction[0] != SwapAction || len(swapInstruction[2]) == 0 {
+			continue
+		}
+		for _, outPublicKey := range strings.Split(swapInstruction[2], ",") {
+			if isAutoRestaking, ok := beaconBestState.AutoStaking[outPublicKey]; ok && isAutoRestaking {
+				continue
+			}
+			if _, ok := beaconBestState.UnbondingStakes[outPublicKey]; ok {
+				continue
+			}
+			unbondingPublicKeys = append(unbondingPublicKeys, outPublicKey)
+		}
+	}
+	if len(unbondingPublicKeys) == 0 {
+		return []string{}
+	}
+	return []string{UnbondingAction, strings.Join(unbondingPublicKeys, ","), strconv.FormatUint(releaseHeight, 10)}
+}
+
+// getReleasedUnbondingStakes returns sorted keys whose stake passed its unbonding period at a new beacon height,
+// release of keys in the producer black list is deferred until their punishment is over
+func (blockchain *BlockChain) getReleasedUnbondingStakes(beaconBestState *BeaconBestState, newBeaconHeight uint64) ([]string, error) {
+	if !blockchain.isUnbondingActive(newBeaconHeight) || len(beaconBestState.UnbondingStakes) == 0 {
+		return []string{}, nil
+	}
+	producersBlackList, err := blockchain.GetDatabase().GetProducersBlackList(newBeaconHeight - 1)
+	if err != nil {
+		return []string{}, err
+	}
+	releasedPublicKeys := []string{}
+	for publicKey, releaseHeight := range beaconBestState.UnbondingStakes {
+		if releaseHeight > newBeaconHeight {
+			continue
+		}
+		if _, ok := producersBlackList[publicKey]; ok {
+			continue
+		}
+		releasedPublicKeys = append(releasedPublicKeys, publicKey)
+	}
+	sort.Strings(releasedPublicKeys)
+	return releasedPublicKeys, nil
+}
+
+// buildReturnStakingInstruction releases stake which passed its unbonding period
+func (blockchain *BlockChain) buildReturnStakingInstruction(beaconBestState *BeaconBestState, newBeaconHeight uint64) ([]string, error) {
+	releasedPublicKeys, err := blockchain.getReleasedUnbondingStakes(beaconBestState, newBeaconHeight)
+	if err != nil {
+		return []string{}, err
+	}
+	if len(releasedPublicKeys) == 0 {
+		return []string{}, nil
+	}
+	return []string{ReturnStakingAction, strings.Join(releasedPublicKeys, ",")}, nil
+}
+
+func (beaconBestState *BeaconBestState) processUnbondingInstruction(instruction []string) error {
+	if len(instruction) != 3 {
+		return nil
+	}
+	releaseHeight, err := strconv.ParseUint(instruction[2], 10, 64)
+	if err != nil {
+		return NewBlockChainError(ProcessUnbondingInstructionError, err)
+	}
+	if beaconBestState.UnbondingStakes == nil {
+		beaconBestState.UnbondingStakes = make(map[string]uint64)
+	}
+	for _, publicKey := range strings.Split(instruction[1], ",") {
+		beaconBestState.UnbondingStakes[publicKey] = releaseHeight
+	}
+	return nil
+}
+
+func (beaconBestState *BeaconBestState) processReturnStakingInstruction(instruction []string) {
+	if len(instruction) != 2 {
+		return
+	}
+	for _, publicKey := range strings.Split(instruction[1], ",") {
+		delete(beaconBestState.UnbondingStakes, publicKey)
+	}
+}
@@ -0,0 +1,75 @@
+package blockchain
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestBuildUnbondingInstructions(t *testing.T) {
+	bc, db, cleanup := newTestStakingPoolChain(t)
+	defer cleanup()
+	bc.config.ChainParams.BeaconHeightBreakPointUnbonding = 100
+	bc.config.ChainParams.UnbondingEpochs = 2
+	beaconBestState := &BeaconBestState{
+		AutoStaking:     map[string]bool{"restaked": true, "stopped": false},
+		UnbondingStakes: map[string]uint64{"unbonding": 90, "released": 100, "pending": 101},
+	}
+	swapInstructions := [][]string{
+		{SwapAction, "in", "out,restaked,stopped,unbonding", "shard", "0"},
+	}
+
+	insts, err := bc.buildUnbondingInstructions(beaconBestState, swapInstructions, 99, 10)
+	if err != nil || len(insts) != 0 {
+		t.Errorf("unbonding should not be built before the break point: %+v %+v", insts, err)
+	}
+	insts, err = bc.buildUnbondingInstructions(beaconBestState, swapInstructions, 100, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := [][]string{
+		{UnbondingAction, "out,stopped", "120"},
+		{ReturnStakingAction, "released,unbonding"},
+	}
+	if !reflect.DeepEqual(insts, expected) {
+		t.Errorf("unexpected unbonding instructions: %+v", insts)
+	}
+
+	// release of punished producers is deferred
+	if err := db.StoreProducersBlackList(99, map[string]uint8{"unbonding": 1}); err != nil {
+		t.Fatal(err)
+	}
+	insts, err = bc.buildUnbondingInstructions(beaconBestState, [][]string{}, 100, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(insts, [][]string{{ReturnStakingAction, "released"}}) {
+		t.Errorf("unexpected return staking instructions: %+v", insts)
+	}
+}
+
+func TestProcessUnbondingInstructions(t *testing.T) {
+	beaconBestState := &BeaconBestState{}
+	bytesWithoutUnbonding := beaconBestState.GetBytes()
+	if err := beaconBestState.processUnbondingInstruction([]string{UnbondingAction, "a,b", "120"}); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(beaconBestState.UnbondingStakes, map[string]uint64{"a": 120, "b": 120}) {
+		t.Errorf("unexpected unbonding stakes: %+v", beaconBestState.UnbondingStakes)
+	}
+	if bytes.Equal(bytesWithoutUnbonding, beaconBestState.GetBytes()) {
+		t.Errorf("unbonding stakes should be part of the best state bytes")
+	}
+	if err := beaconBestState.processUnbondingInstruction([]string{UnbondingAction, "c", "height"}); err == nil {
+		t.Errorf("invalid release height should be rejected")
+	}
+
+	beaconBestState.processReturnStakingInstruction([]string{ReturnStakingAction, "a,b"})
+	if len(beaconBestState.UnbondingStakes) != 0 {
+		t.Errorf("returned stakes should leave the unbonding queue: %+v", beaconBestState.UnbondingStakes)
+	}
+	// best states without unbonding stakes keep the bytes of best states before the break point
+	if !bytes.Equal(bytesWithoutUnbonding, beaconBestState.GetBytes()) {
+		t.Errorf("empty unbonding queue should not change the best state bytes")
+	}
+}
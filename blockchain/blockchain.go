@@ -24,6 +24,7 @@ import (
 	"github.com/incognitochain/incognito-chain/privacy"
 	"github.com/incognitochain/incognito-chain/pubsub"
 	"github.com/incognitochain/incognito-chain/transaction"
+	"github.com/incognitochain/incognito-chain/wallet"
 	libp2p "github.com/libp2p/go-libp2p-peer"
 	"github.com/pkg/errors"
 )
@@ -66,6 +67,10 @@ type Config struct {
 	IsBlockGenStarted bool
 	PubSubManager     *pubsub.PubSubManager
 	RandomClient      btc.RandomClient
+	// number of beacon heights before the best one whose pde state is kept, 0 keeps all of them
+	PDEStateRetention uint64
+	// index trades, contributions, withdrawals and liquidity positions of pde for market data rpcs
+	EnablePDEMarketIndexer bool
 	Server            interface {
 		BoardcastNodeState() error
 		PublishNodeState(userLayer string, shardID int) error
@@ -196,7 +201,7 @@ func (blockchain *BlockChain) initChainState() error {
 	bestStateBeaconBytes, err := blockchain.config.DataBase.FetchBeaconBestState()
 	if err == nil {
 		beacon := &BeaconBestState{}
-		err = json.Unmarshal(bestStateBeaconBytes, beacon)
+		err = UnmarshalStoredBeaconBestState(bestStateBeaconBytes, beacon)
 		//update singleton object
 		SetBeaconBestState(beacon)
 		//update beacon field in blockchain Beststate
@@ -231,7 +236,7 @@ func (blockchain *BlockChain) initChainState() error {
 		bestStateBytes, err := blockchain.config.DataBase.FetchShardBestState(shardID)
 		if err == nil {
 			shardBestState := &ShardBestState{}
-			err = json.Unmarshal(bestStateBytes, shardBestState)
+			err = UnmarshalStoredShardBestState(bestStateBytes, shardBestState)
 			//update singleton object
 			SetBestStateShard(shardID, shardBestState)
 			//update Shard field in blockchain Beststate
@@ -417,7 +422,7 @@ func (blockchain *BlockChain) GetBeaconBlockByHash(beaconBlockHash common.Hash)
 		return nil, 0, err
 	}
 	beaconBlock := NewBeaconBlock()
-	err = json.Unmarshal(beaconBlockBytes, beaconBlock)
+	err = UnmarshalStoredBeaconBlock(beaconBlockBytes, beaconBlock)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -464,7 +469,7 @@ func (blockchain *BlockChain) GetShardBlockByHash(hash common.Hash) (*ShardBlock
 	}
 
 	block := ShardBlock{}
-	err = json.Unmarshal(blockBytes, &block)
+	err = UnmarshalStoredShardBlock(blockBytes, &block)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -493,7 +498,7 @@ func (blockchain *BlockChain) GetShardBestState(shardID byte) (*ShardBestState,
 	bestState := ShardBestState{}
 	bestStateBytes, err := blockchain.config.DataBase.FetchShardBestState(shardID)
 	if err == nil {
-		err = json.Unmarshal(bestStateBytes, &bestState)
+		err = UnmarshalStoredShardBestState(bestStateBytes, &bestState)
 	}
 	return &bestState, err
 }
@@ -999,6 +1004,36 @@ func (blockchain *BlockChain) PrivacyCustomTokenIDCrossShardExisted(tokenID *com
 	return blockchain.config.DataBase.PrivacyTokenIDCrossShardExisted(*tokenID)
 }
 
+// NewAccountUsageChecker returns checker which is used to discover wallet accounts,
+// an account is used if it has output coins of PRV or any privacy token, list of tokens is loaded once
+func (blockchain *BlockChain) NewAccountUsageChecker() (wallet.AccountUsageChecker, error) {
+	tokenIDs := []common.Hash{common.PRVCoinID}
+	listPrivacyToken, listPrivacyTokenCrossShard, err := blockchain.ListPrivacyCustomToken()
+	if err != nil {
+		return nil, err
+	}
+	for tokenID := range listPrivacyToken {
+		tokenIDs = append(tokenIDs, tokenID)
+	}
+	for tokenID := range listPrivacyTokenCrossShard {
+		if _, ok := listPrivacyToken[tokenID]; !ok {
+			tokenIDs = append(tokenIDs, tokenID)
+		}
+	}
+	return func(pubKey []byte, shardID byte) (bool, error) {
+		for _, tokenID := range tokenIDs {
+			outCoins, err := blockchain.config.DataBase.GetOutcoinsByPubkey(tokenID, pubKey, shardID)
+			if err != nil {
+				return false, err
+			}
+			if len(outCoins) > 0 {
+				return true, nil
+			}
+		}
+		return false, nil
+	}, nil
+}
+
 // ListCustomToken - return all custom token which existed in network
 func (blockchain *BlockChain) ListPrivacyCustomToken() (map[common.Hash]transaction.TxCustomTokenPrivacy, map[common.Hash]CrossShardTokenPrivacyMetaData, error) {
 	data, err := blockchain.config.DataBase.ListPrivacyToken()
@@ -1147,11 +1182,26 @@ func (blockchain *BlockChain) BuildInstRewardForIncDAO(epoch uint64, totalReward
 	return resInst, nil
 }
 
-func (blockchain *BlockChain) BuildInstRewardForShards(epoch uint64, totalRewards []map[common.Hash]uint64) ([][]string, error) {
+func (blockchain *BlockChain) BuildInstRewardForIncDAOTreasury(epoch uint64, totalReward map[common.Hash]uint64) ([][]string, error) {
+	resInst := [][]string{}
+	treasuryRewardInst, err := metadata.BuildInstForIncDAOTreasuryReward(totalReward)
+	if err != nil {
+		Logger.log.Errorf("BuildInstRewardForIncDAOTreasury error %+v\n Totalreward: %+v, epoch: %+v\n", err, totalReward, epoch)
+		return nil, err
+	}
+	resInst = append(resInst, treasuryRewardInst)
+	return resInst, nil
+}
+
+func (blockchain *BlockChain) BuildInstRewardForShards(epoch uint64, totalRewards []map[common.Hash]uint64, poolCommitteeKeys [][]string) ([][]string, error) {
 	resInst := [][]string{}
 	for i, reward := range totalRewards {
 		if len(reward) > 0 {
-			shardRewardInst, err := metadata.BuildInstForShardReward(reward, epoch, byte(i))
+			var poolKeys []string
+			if i < len(poolCommitteeKeys) && len(poolCommitteeKeys[i]) > 0 {
+				poolKeys = poolCommitteeKeys[i]
+			}
+			shardRewardInst, err := metadata.BuildInstForShardReward(reward, epoch, byte(i), poolKeys)
 			if err != nil {
 				Logger.log.Errorf("BuildInstForShardReward error %+v\n Totalreward: %+v, epoch: %+v\n; shard:%+v", err, reward, epoch, byte(i))
 				return nil, err
@@ -1162,6 +1212,61 @@ func (blockchain *BlockChain) BuildInstRewardForShards(epoch uint64, totalReward
 	return resInst, nil
 }
 
+// BuildInstRewardForStakingPools splits rewards of staking pools to their delegators,
+// one instruction per pool and per shard of receivers
+func (blockchain *BlockChain) BuildInstRewardForStakingPools(
+	epoch uint64,
+	totalRewards map[string]map[common.Hash]uint64,
+	currentStakingPoolState *CurrentStakingPoolState,
+) ([][]string, error) {
+	resInst := [][]string{}
+	var committeePublicKeys []string
+	for k := range totalRewards {
+		committeePublicKeys = append(committeePublicKeys, k)
+	}
+	sort.Strings(committeePublicKeys)
+	for _, committeePublicKey := range committeePublicKeys {
+		stakingPool := currentStakingPoolState.StakingPools[committeePublicKey]
+		delegations, err := currentStakingPoolState.getDelegations(blockchain.GetDatabase(), committeePublicKey)
+		if err != nil {
+			return nil, err
+		}
+		rewardsByShard := map[byte]map[string]map[common.Hash]uint64{}
+		for coinID, reward := range totalRewards[committeePublicKey] {
+			for paymentAddressStr, amount := range splitStakingPoolReward(stakingPool, delegations, reward) {
+				keyWallet, err := wallet.Base58CheckDeserialize(paymentAddressStr)
+				if err != nil {
+					Logger.log.Errorf("BuildInstRewardForStakingPools error %+v\n address: %+v", err, paymentAddressStr)
+					continue
+				}
+				pk := keyWallet.KeySet.PaymentAddress.Pk
+				shardID := common.GetShardIDFromLastByte(pk[len(pk)-1])
+				if _, ok := rewardsByShard[shardID]; !ok {
+					rewardsByShard[shardID] = map[string]map[common.Hash]uint64{}
+				}
+				if _, ok := rewardsByShard[shardID][paymentAddressStr]; !ok {
+					rewardsByShard[shardID][paymentAddressStr] = map[common.Hash]uint64{}
+				}
+				rewardsByShard[shardID][paymentAddressStr][coinID] += amount
+			}
+		}
+		var shardIDs []int
+		for k := range rewardsByShard {
+			shardIDs = append(shardIDs, int(k))
+		}
+		sort.Ints(shardIDs)
+		for _, shardID := range shardIDs {
+			stakingPoolRewardInst, err := metadata.BuildInstForStakingPoolReward(committeePublicKey, epoch, byte(shardID), rewardsByShard[byte(shardID)])
+			if err != nil {
+				Logger.log.Errorf("BuildInstForStakingPoolReward error %+v\n committee public key: %+v, epoch: %+v\n; shard:%+v", err, committeePublicKey, epoch, shardID)
+				return nil, err
+			}
+			resInst = append(resInst, stakingPoolRewardInst)
+		}
+	}
+	return resInst, nil
+}
+
 func (blockchain *BlockChain) BuildResponseTransactionFromTxsWithMetadata(
 	transactions []metadata.Transaction,
 	blkProducerPrivateKey *privacy.PrivateKey,
@@ -1339,7 +1444,7 @@ func (blockchain *BlockChain) BackupShardChain(writer io.Writer, shardID byte) e
 		return err
 	}
 	shardBestState := &ShardBestState{}
-	err = json.Unmarshal(bestStateBytes, shardBestState)
+	err = UnmarshalStoredShardBestState(bestStateBytes, shardBestState)
 	bestShardHeight := shardBestState.ShardHeight
 	var i uint64
 	for i = 1; i < bestShardHeight; i++ {
@@ -1374,7 +1479,7 @@ func (blockchain *BlockChain) BackupBeaconChain(writer io.Writer) error {
 		return err
 	}
 	beaconBestState := &BeaconBestState{}
-	err = json.Unmarshal(bestStateBytes, beaconBestState)
+	err = UnmarshalStoredBeaconBestState(bestStateBytes, beaconBestState)
 	bestBeaconHeight := beaconBestState.BeaconHeight
 	var i uint64
 	for i = 1; i < bestBeaconHeight; i++ {
@@ -0,0 +1,424 @@
+package blockchain
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/database"
+)
+
+/*
+	Blocks and best states are stored in db by the binary codec of wire:
+	- value starts with storageMagic then storageVersion, json of old db always starts with '{'
+	- decoders read both formats so db can be upgraded in place while node is running
+	- wire imports blockchain, so it registers its codec by RegisterStorageCodec,
+	  values are stored as json until a codec is registered
+*/
+const (
+	storageMagic   = byte(0xB1)
+	storageVersion = byte(1)
+	// number of blocks migrated between 2 progress reports
+	storageMigrationProgressStep = 1000
+)
+
+// StorageCodec encodes blocks and best states without storage magic and version
+type StorageCodec interface {
+	EncodeShardBlock(shardBlock *ShardBlock) ([]byte, error)
+	DecodeShardBlock(data []byte, shardBlock *ShardBlock) error
+	EncodeBeaconBlock(beaconBlock *BeaconBlock) ([]byte, error)
+	DecodeBeaconBlock(data []byte, beaconBlock *BeaconBlock) error
+	EncodeShardBestState(shardBestState *ShardBestState) ([]byte, error)
+	DecodeShardBestState(data []byte, shardBestState *ShardBestState) error
+	EncodeBeaconBestState(beaconBestState *BeaconBestState) ([]byte, error)
+	DecodeBeaconBestState(data []byte, beaconBestState *BeaconBestState) error
+}
+
+var storageCodec StorageCodec
+
+// RegisterStorageCodec set codec of blocks and best states stored in db
+func RegisterStorageCodec(codec StorageCodec) {
+	storageCodec = codec
+}
+
+func isBinaryStoredValue(data []byte) bool {
+	return len(data) >= 2 && data[0] == storageMagic
+}
+
+func marshalStoredValue(encode func() ([]byte, error)) ([]byte, error) {
+	payload, err := encode()
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte{storageMagic, storageVersion}, payload...), nil
+}
+
+func storedValuePayload(data []byte) ([]byte, error) {
+	if data[1] != storageVersion {
+		return nil, fmt.Errorf("unsupported stored value version %+v", data[1])
+	}
+	if storageCodec == nil {
+		return nil, errors.New("no codec is registered to decode binary stored value")
+	}
+	return data[2:], nil
+}
+
+// MarshalStorage implements database.StorageMarshaler
+func (shardBlock *ShardBlock) MarshalStorage() ([]byte, error) {
+	if storageCodec == nil {
+		return json.Marshal(shardBlock)
+	}
+	return marshalStoredValue(func() ([]byte, error) {
+		return storageCodec.EncodeShardBlock(shardBlock)
+	})
+}
+
+// MarshalStorage implements database.StorageMarshaler
+func (beaconBlock *BeaconBlock) MarshalStorage() ([]byte, error) {
+	if storageCodec == nil {
+		return json.Marshal(beaconBlock)
+	}
+	return marshalStoredValue(func() ([]byte, error) {
+		return storageCodec.EncodeBeaconBlock(beaconBlock)
+	})
+}
+
+// MarshalStorage implements database.StorageMarshaler
+func (shardBestState *ShardBestState) MarshalStorage() ([]byte, error) {
+	if storageCodec == nil {
+		return json.Marshal(shardBestState)
+	}
+	shardBestState.lock.RLock()
+	defer shardBestState.lock.RUnlock()
+	return marshalStoredValue(func() ([]byte, error) {
+		return storageCodec.EncodeShardBestState(shardBestState)
+	})
+}
+
+// MarshalStorage implements database.StorageMarshaler
+func (beaconBestState *BeaconBestState) MarshalStorage() ([]byte, error) {
+	if storageCodec == nil {
+		return json.Marshal(beaconBestState)
+	}
+	beaconBestState.lock.RLock()
+	defer beaconBestState.lock.RUnlock()
+	return marshalStoredValue(func() ([]byte, error) {
+		return storageCodec.EncodeBeaconBestState(beaconBestState)
+	})
+}
+
+// UnmarshalStoredShardBlock decode shard block stored in db by binary or json encoding
+func UnmarshalStoredShardBlock(data []byte, shardBlock *ShardBlock) error {
+	if !isBinaryStoredValue(data) {
+		if err := json.Unmarshal(data, shardBlock); err != nil {
+			return NewBlockChainError(DecodeStoredBlockError, err)
+		}
+		totalTxsFee, err := unmarshalTotalTxsFee(data)
+		if err != nil {
+			return NewBlockChainError(DecodeStoredBlockError, err)
+		}
+		shardBlock.Header.TotalTxsFee = totalTxsFee
+		return nil
+	}
+	payload, err := storedValuePayload(data)
+	if err != nil {
+		return NewBlockChainError(DecodeStoredBlockError, err)
+	}
+	if err := storageCodec.DecodeShardBlock(payload, shardBlock); err != nil {
+		return NewBlockChainError(DecodeStoredBlockError, err)
+	}
+	return nil
+}
+
+// UnmarshalStoredBeaconBlock decode beacon block stored in db by binary or json encoding
+func UnmarshalStoredBeaconBlock(data []byte, beaconBlock *BeaconBlock) error {
+	if !isBinaryStoredValue(data) {
+		if err := json.Unmarshal(data, beaconBlock); err != nil {
+			return NewBlockChainError(DecodeStoredBlockError, err)
+		}
+		return nil
+	}
+	payload, err := storedValuePayload(data)
+	if err != nil {
+		return NewBlockChainError(DecodeStoredBlockError, err)
+	}
+	if err := storageCodec.DecodeBeaconBlock(payload, beaconBlock); err != nil {
+		return NewBlockChainError(DecodeStoredBlockError, err)
+	}
+	return nil
+}
+
+// UnmarshalStoredShardBestState decode shard best state stored in db by binary or json encoding
+func UnmarshalStoredShardBestState(data []byte, shardBestState *ShardBestState) error {
+	if !isBinaryStoredValue(data) {
+		if err := json.Unmarshal(data, shardBestState); err != nil {
+			return NewBlockChainError(DecodeStoredBestStateError, err)
+		}
+		return nil
+	}
+	payload, err := storedValuePayload(data)
+	if err != nil {
+		return NewBlockChainError(DecodeStoredBestStateError, err)
+	}
+	if err := storageCodec.DecodeShardBestState(payload, shardBestState); err != nil {
+		return NewBlockChainError(DecodeStoredBestStateError, err)
+	}
+	return nil
+}
+
+// UnmarshalStoredBeaconBestState decode beacon best state stored in db by binary or json encoding
+func UnmarshalStoredBeaconBestState(data []byte, beaconBestState *BeaconBestState) error {
+	if !isBinaryStoredValue(data) {
+		if err := json.Unmarshal(data, beaconBestState); err != nil {
+			return NewBlockChainError(DecodeStoredBestStateError, err)
+		}
+		return nil
+	}
+	payload, err := storedValuePayload(data)
+	if err != nil {
+		return NewBlockChainError(DecodeStoredBestStateError, err)
+	}
+	if err := storageCodec.DecodeBeaconBestState(payload, beaconBestState); err != nil {
+		return NewBlockChainError(DecodeStoredBestStateError, err)
+	}
+	return nil
+}
+
+// verifyStoredShardBlock check body of a decoded block against roots in its header,
+// block hash only covers header so a body decoded wrongly can't be detected by hash.
+// Genesis block is created without roots
+func verifyStoredShardBlock(shardBlock *ShardBlock) error {
+	if shardBlock.Header.Height == 1 {
+		return nil
+	}
+	txMerkleTree := Merkle{}.BuildMerkleTreeStore(shardBlock.Body.Transactions)
+	txRoot := common.Hash{}
+	if len(txMerkleTree) > 0 {
+		txRoot = *txMerkleTree[len(txMerkleTree)-1]
+	}
+	if !txRoot.IsEqual(&shardBlock.Header.TxRoot) {
+		return NewBlockChainError(VerifyStoredBlockError, fmt.Errorf("shard block %+v has transaction root %+v, expect %+v", shardBlock.Header.Hash(), txRoot, shardBlock.Header.TxRoot))
+	}
+	_, shardTxMerkleData := CreateShardTxRoot2(shardBlock.Body.Transactions)
+	shardTxRoot := shardTxMerkleData[len(shardTxMerkleData)-1]
+	if !shardTxRoot.IsEqual(&shardBlock.Header.ShardTxRoot) {
+		return NewBlockChainError(VerifyStoredBlockError, fmt.Errorf("shard block %+v has shard transaction root %+v, expect %+v", shardBlock.Header.Hash(), shardTxRoot, shardBlock.Header.ShardTxRoot))
+	}
+	if !VerifyMerkleCrossTransaction(shardBlock.Body.CrossTransactions, shardBlock.Header.CrossTransactionRoot) {
+		return NewBlockChainError(VerifyStoredBlockError, fmt.Errorf("shard block %+v has wrong cross transaction root", shardBlock.Header.Hash()))
+	}
+	return nil
+}
+
+// verifyStoredBeaconBlock check body of a decoded beacon block against hashes in its header
+func verifyStoredBeaconBlock(beaconBlock *BeaconBlock) error {
+	if beaconBlock.Header.Height == 1 {
+		return nil
+	}
+	if !verifyHashFromShardState(beaconBlock.Body.ShardState, beaconBlock.Header.ShardStateHash) {
+		return NewBlockChainError(VerifyStoredBlockError, fmt.Errorf("beacon block %+v has wrong shard state hash", beaconBlock.Header.Hash()))
+	}
+	instructions := []string{}
+	for _, inst := range beaconBlock.Body.Instructions {
+		instructions = append(instructions, inst...)
+	}
+	if hash, ok := verifyHashFromStringArray(instructions, beaconBlock.Header.InstructionHash); !ok {
+		return NewBlockChainError(VerifyStoredBlockError, fmt.Errorf("beacon block %+v has instruction hash %+v, expect %+v", beaconBlock.Header.Hash(), hash, beaconBlock.Header.InstructionHash))
+	}
+	return nil
+}
+
+// unmarshalTotalTxsFee parse fee map of shard header by token id string,
+// json decoder of common.Hash map keys loses token ids
+func unmarshalTotalTxsFee(data []byte) (map[common.Hash]uint64, error) {
+	temp := &struct {
+		Header struct {
+			TotalTxsFee map[string]uint64
+		}
+	}{}
+	if err := json.Unmarshal(data, temp); err != nil {
+		return nil, err
+	}
+	totalTxsFee := make(map[common.Hash]uint64, len(temp.Header.TotalTxsFee))
+	for tokenIDStr, fee := range temp.Header.TotalTxsFee {
+		tokenID, err := common.Hash{}.NewHashFromStr(tokenIDStr)
+		if err != nil {
+			return nil, err
+		}
+		totalTxsFee[*tokenID] = fee
+	}
+	return totalTxsFee, nil
+}
+
+// StorageMigrations return migrations of stored data, they are run at node start or by cmd migratedb
+func StorageMigrations() []database.Migration {
+	return []database.Migration{
+		{
+			Version:     database.SchemaVersionBinaryBlock,
+			Description: "re-encode stored shard and beacon blocks from json to binary",
+			Migrate:     migrateBlocksToBinary,
+		},
+		{
+			Version:     database.SchemaVersionPDEStateJournal,
+			Description: "store pde state by latest records and journals instead of a copy for every beacon height",
+			Migrate:     migratePDEStateToJournal,
+		},
+		{
+			Version:     database.SchemaVersionBinaryBestState,
+			Description: "re-encode stored shard and beacon best states from json to binary",
+			Migrate:     migrateBestStatesToBinary,
+		},
+	}
+}
+
+// decodeStoredShardBlock decode and verify a stored shard block, it returns hash of decoded block
+func decodeStoredShardBlock(value []byte) (common.Hash, database.StorageMarshaler, error) {
+	shardBlock := &ShardBlock{}
+	if err := UnmarshalStoredShardBlock(value, shardBlock); err != nil {
+		return common.Hash{}, nil, err
+	}
+	if err := verifyStoredShardBlock(shardBlock); err != nil {
+		return common.Hash{}, nil, err
+	}
+	return shardBlock.Header.Hash(), shardBlock, nil
+}
+
+// decodeStoredBeaconBlock decode and verify a stored beacon block, it returns hash of decoded block
+func decodeStoredBeaconBlock(value []byte) (common.Hash, database.StorageMarshaler, error) {
+	beaconBlock := &BeaconBlock{}
+	if err := UnmarshalStoredBeaconBlock(value, beaconBlock); err != nil {
+		return common.Hash{}, nil, err
+	}
+	if err := verifyStoredBeaconBlock(beaconBlock); err != nil {
+		return common.Hash{}, nil, err
+	}
+	return beaconBlock.Header.Hash(), beaconBlock, nil
+}
+
+// migrateBlocksToBinary re-encode json blocks, blocks already in binary are skipped so it can be resumed.
+// Binary value is decoded again and verified before it replaces json value
+func migrateBlocksToBinary(db database.DatabaseInterface, progress func(done uint64)) error {
+	if storageCodec == nil {
+		return NewBlockChainError(MigrateStoredBlockError, errors.New("no codec is registered to encode blocks"))
+	}
+	done := uint64(0)
+	migrate := func(hash common.Hash, value []byte, decode func([]byte) (common.Hash, database.StorageMarshaler, error)) error {
+		if isBinaryStoredValue(value) {
+			return nil
+		}
+		blockHash, block, err := decode(value)
+		if err != nil {
+			return NewBlockChainError(MigrateStoredBlockError, fmt.Errorf("block %+v: %+v", hash, err))
+		}
+		if !blockHash.IsEqual(&hash) {
+			return NewBlockChainError(MigrateStoredBlockError, fmt.Errorf("decoded block has hash %+v, expect %+v", blockHash, hash))
+		}
+		newValue, err := block.MarshalStorage()
+		if err != nil {
+			return NewBlockChainError(MigrateStoredBlockError, err)
+		}
+		if newBlockHash, _, err := decode(newValue); err != nil || !newBlockHash.IsEqual(&hash) {
+			return NewBlockChainError(MigrateStoredBlockError, fmt.Errorf("block %+v can't be decoded from binary, err %+v", hash, err))
+		}
+		if err := db.StoreRawBlock(hash, newValue); err != nil {
+			return err
+		}
+		done++
+		if done%storageMigrationProgressStep == 0 {
+			progress(done)
+		}
+		return nil
+	}
+	for shardID := 0; shardID < common.MaxShardNumber; shardID++ {
+		err := db.ForEachShardBlock(byte(shardID), func(hash common.Hash, value []byte) error {
+			return migrate(hash, value, decodeStoredShardBlock)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	if err := db.ForEachBeaconBlock(func(hash common.Hash, value []byte) error {
+		return migrate(hash, value, decodeStoredBeaconBlock)
+	}); err != nil {
+		return err
+	}
+	progress(done)
+	return nil
+}
+
+// migrateBestStatesToBinary re-encode json best states, node also writes best states in binary
+// after every inserted block so best states which are already binary are skipped
+func migrateBestStatesToBinary(db database.DatabaseInterface, progress func(done uint64)) error {
+	if storageCodec == nil {
+		return NewBlockChainError(MigrateStoredBlockError, errors.New("no codec is registered to encode best states"))
+	}
+	done := uint64(0)
+	if value, err := db.FetchBeaconBestState(); err == nil && !isBinaryStoredValue(value) {
+		beaconBestState := &BeaconBestState{}
+		if err := UnmarshalStoredBeaconBestState(value, beaconBestState); err != nil {
+			return NewBlockChainError(MigrateStoredBlockError, err)
+		}
+		if err := db.StoreBeaconBestState(beaconBestState, nil); err != nil {
+			return err
+		}
+		done++
+	}
+	for shardID := 0; shardID < common.MaxShardNumber; shardID++ {
+		value, err := db.FetchShardBestState(byte(shardID))
+		if err != nil || isBinaryStoredValue(value) {
+			continue
+		}
+		shardBestState := &ShardBestState{}
+		if err := UnmarshalStoredShardBestState(value, shardBestState); err != nil {
+			return NewBlockChainError(MigrateStoredBlockError, err)
+		}
+		if err := db.StoreShardBestState(shardBestState, byte(shardID), nil); err != nil {
+			return err
+		}
+		done++
+	}
+	progress(done)
+	return nil
+}
+
+// StoredBlockReport is result of VerifyStoredBlocks
+type StoredBlockReport struct {
+	JSONBlocks   uint64
+	BinaryBlocks uint64
+}
+
+// VerifyStoredBlocks decode every stored block, check it against its hash in db and check its body against roots in header
+func VerifyStoredBlocks(db database.DatabaseInterface, progress func(checked uint64)) (*StoredBlockReport, error) {
+	report := &StoredBlockReport{}
+	verify := func(hash common.Hash, value []byte, decode func([]byte) (common.Hash, database.StorageMarshaler, error)) error {
+		blockHash, _, err := decode(value)
+		if err != nil {
+			return err
+		}
+		if !blockHash.IsEqual(&hash) {
+			return NewBlockChainError(VerifyStoredBlockError, fmt.Errorf("decoded block has hash %+v, expect %+v", blockHash, hash))
+		}
+		if isBinaryStoredValue(value) {
+			report.BinaryBlocks++
+		} else {
+			report.JSONBlocks++
+		}
+		if checked := report.BinaryBlocks + report.JSONBlocks; progress != nil && checked%storageMigrationProgressStep == 0 {
+			progress(checked)
+		}
+		return nil
+	}
+	for shardID := 0; shardID < common.MaxShardNumber; shardID++ {
+		err := db.ForEachShardBlock(byte(shardID), func(hash common.Hash, value []byte) error {
+			return verify(hash, value, decodeStoredShardBlock)
+		})
+		if err != nil {
+			return report, err
+		}
+	}
+	err := db.ForEachBeaconBlock(func(hash common.Hash, value []byte) error {
+		return verify(hash, value, decodeStoredBeaconBlock)
+	})
+	return report, err
+}
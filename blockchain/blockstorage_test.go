@@ -0,0 +1,267 @@
+package blockchain
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/database"
+	_ "github.com/incognitochain/incognito-chain/database/lvdb"
+)
+
+// testStorageCodec encodes payload of stored values by json,
+// binary codec is registered by wire which can't be imported by blockchain tests
+type testStorageCodec struct{}
+
+func (testStorageCodec) EncodeShardBlock(shardBlock *ShardBlock) ([]byte, error) {
+	return json.Marshal(shardBlock)
+}
+
+func (testStorageCodec) DecodeShardBlock(data []byte, shardBlock *ShardBlock) error {
+	return UnmarshalStoredShardBlock(data, shardBlock)
+}
+
+func (testStorageCodec) EncodeBeaconBlock(beaconBlock *BeaconBlock) ([]byte, error) {
+	return json.Marshal(beaconBlock)
+}
+
+func (testStorageCodec) DecodeBeaconBlock(data []byte, beaconBlock *BeaconBlock) error {
+	return UnmarshalStoredBeaconBlock(data, beaconBlock)
+}
+
+func (testStorageCodec) EncodeShardBestState(shardBestState *ShardBestState) ([]byte, error) {
+	return json.Marshal(shardBestState)
+}
+
+func (testStorageCodec) DecodeShardBestState(data []byte, shardBestState *ShardBestState) error {
+	return json.Unmarshal(data, shardBestState)
+}
+
+func (testStorageCodec) EncodeBeaconBestState(beaconBestState *BeaconBestState) ([]byte, error) {
+	return json.Marshal(beaconBestState)
+}
+
+func (testStorageCodec) DecodeBeaconBestState(data []byte, beaconBestState *BeaconBestState) error {
+	return json.Unmarshal(data, beaconBestState)
+}
+
+func newStorageTestShardBlock() *ShardBlock {
+	// block must pass sanity check of json decoder
+	shardBlock := newCompactTestShardBlock()
+	shardBlock.ValidationData = "{}"
+	shardBlock.Header.Version = SHARD_BLOCK_VERSION
+	shardBlock.Header.PreviousBlockHash = common.HashH([]byte("previous"))
+	shardBlock.Header.Round = 1
+	shardBlock.Header.Epoch = 1
+	shardBlock.Header.CommitteeRoot = common.HashH([]byte("committee"))
+	shardBlock.Header.BeaconHeight = 1
+	shardBlock.Header.TotalTxsFee = map[common.Hash]uint64{common.PRVCoinID: 8}
+	_, shardTxMerkleData := CreateShardTxRoot2(shardBlock.Body.Transactions)
+	shardBlock.Header.ShardTxRoot = shardTxMerkleData[len(shardTxMerkleData)-1]
+	crossTransactionRoot, _ := CreateMerkleCrossTransaction(shardBlock.Body.CrossTransactions)
+	shardBlock.Header.CrossTransactionRoot = *crossTransactionRoot
+	return shardBlock
+}
+
+func newStorageTestBeaconBlock() *BeaconBlock {
+	beaconBlock := NewBeaconBlock()
+	beaconBlock.Header.Height = 5
+	beaconBlock.Body.Instructions = [][]string{{"stake", "a"}}
+	beaconBlock.Header.ShardStateHash, _ = generateHashFromShardState(beaconBlock.Body.ShardState)
+	beaconBlock.Header.InstructionHash, _ = generateHashFromStringArray([]string{"stake", "a"})
+	return beaconBlock
+}
+
+func TestUnmarshalStoredShardBlock(t *testing.T) {
+	RegisterStorageCodec(testStorageCodec{})
+	defer RegisterStorageCodec(nil)
+	shardBlock := newStorageTestShardBlock()
+	binaryData, err := shardBlock.MarshalStorage()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !isBinaryStoredValue(binaryData) {
+		t.Fatal("block must be stored by registered codec")
+	}
+	jsonData, err := json.Marshal(shardBlock)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, data := range [][]byte{binaryData, jsonData} {
+		decodedBlock := &ShardBlock{}
+		if err := UnmarshalStoredShardBlock(data, decodedBlock); err != nil {
+			t.Fatal(err)
+		}
+		if !decodedBlock.Hash().IsEqual(shardBlock.Hash()) || decodedBlock.Header.TotalTxsFee[common.PRVCoinID] != 8 {
+			t.Fatalf("decoded block %+v must equal stored block", decodedBlock.Header)
+		}
+	}
+
+	RegisterStorageCodec(nil)
+	if err := UnmarshalStoredShardBlock(binaryData, &ShardBlock{}); err == nil {
+		t.Error("binary block can't be decoded without codec")
+	}
+	if data, err := shardBlock.MarshalStorage(); err != nil || isBinaryStoredValue(data) {
+		t.Errorf("block must be stored as json without codec, err %+v", err)
+	}
+}
+
+func TestUnmarshalStoredBeaconBlock(t *testing.T) {
+	RegisterStorageCodec(testStorageCodec{})
+	defer RegisterStorageCodec(nil)
+	beaconBlock := newStorageTestBeaconBlock()
+	binaryData, err := beaconBlock.MarshalStorage()
+	if err != nil {
+		t.Fatal(err)
+	}
+	jsonData, err := json.Marshal(beaconBlock)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, data := range [][]byte{binaryData, jsonData} {
+		decodedBlock := NewBeaconBlock()
+		if err := UnmarshalStoredBeaconBlock(data, decodedBlock); err != nil {
+			t.Fatal(err)
+		}
+		if !decodedBlock.Hash().IsEqual(beaconBlock.Hash()) {
+			t.Fatal("decoded block must equal stored block")
+		}
+	}
+	binaryData[1] = storageVersion + 1
+	if err := UnmarshalStoredBeaconBlock(binaryData, NewBeaconBlock()); err == nil {
+		t.Fatal("unknown stored block version must be rejected")
+	}
+}
+
+func TestUnmarshalStoredBestState(t *testing.T) {
+	RegisterStorageCodec(testStorageCodec{})
+	defer RegisterStorageCodec(nil)
+	shardBestState := &ShardBestState{ShardID: 2, ShardHeight: 10, BestBlock: newStorageTestShardBlock()}
+	data, err := shardBestState.MarshalStorage()
+	if err != nil || !isBinaryStoredValue(data) {
+		t.Fatalf("shard best state must be stored by registered codec, err %+v", err)
+	}
+	decodedShardBestState := &ShardBestState{}
+	if err := UnmarshalStoredShardBestState(data, decodedShardBestState); err != nil {
+		t.Fatal(err)
+	}
+	if decodedShardBestState.ShardID != 2 || decodedShardBestState.ShardHeight != 10 || decodedShardBestState.BestBlock.Header.Height != shardBestState.BestBlock.Header.Height {
+		t.Errorf("decoded shard best state %+v must equal stored state", decodedShardBestState)
+	}
+
+	beaconBestState := &BeaconBestState{BeaconHeight: 5, AutoStaking: map[string]bool{"a": true}}
+	jsonData, _ := json.Marshal(beaconBestState)
+	for _, data := range [][]byte{jsonData, append([]byte{storageMagic, storageVersion}, jsonData...)} {
+		decodedBeaconBestState := &BeaconBestState{}
+		if err := UnmarshalStoredBeaconBestState(data, decodedBeaconBestState); err != nil {
+			t.Fatal(err)
+		}
+		if decodedBeaconBestState.BeaconHeight != 5 || !decodedBeaconBestState.AutoStaking["a"] {
+			t.Errorf("decoded beacon best state %+v must equal stored state", decodedBeaconBestState)
+		}
+	}
+}
+
+func TestVerifyStoredBlockBody(t *testing.T) {
+	shardBlock := newStorageTestShardBlock()
+	if err := verifyStoredShardBlock(shardBlock); err != nil {
+		t.Fatal(err)
+	}
+	shardBlock.Body.Transactions = shardBlock.Body.Transactions[1:]
+	if err := verifyStoredShardBlock(shardBlock); err == nil {
+		t.Error("shard block with missing tx must be rejected")
+	}
+	shardBlock = newStorageTestShardBlock()
+	shardBlock.Header.ShardTxRoot = common.Hash{}
+	if err := verifyStoredShardBlock(shardBlock); err == nil {
+		t.Error("shard block with wrong shard tx root must be rejected")
+	}
+
+	beaconBlock := newStorageTestBeaconBlock()
+	if err := verifyStoredBeaconBlock(beaconBlock); err != nil {
+		t.Fatal(err)
+	}
+	beaconBlock.Body.Instructions = [][]string{{"stake", "b"}}
+	if err := verifyStoredBeaconBlock(beaconBlock); err == nil {
+		t.Error("beacon block with wrong instructions must be rejected")
+	}
+	// genesis blocks have no roots
+	beaconBlock.Header.Height = 1
+	if err := verifyStoredBeaconBlock(beaconBlock); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestStorageMigrations(t *testing.T) {
+	dbPath, err := ioutil.TempDir(os.TempDir(), "test_")
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %+v", err)
+	}
+	defer os.RemoveAll(dbPath)
+	db, err := database.Open("leveldb", dbPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	// blocks and best states stored as json by old node
+	shardBlock := newStorageTestShardBlock()
+	shardBlockJSON, _ := json.Marshal(shardBlock)
+	if err := db.StoreShardBlock(json.RawMessage(shardBlockJSON), shardBlock.Header.Hash(), shardBlock.Header.ShardID, nil); err != nil {
+		t.Fatal(err)
+	}
+	beaconBlock := newStorageTestBeaconBlock()
+	beaconBlockJSON, _ := json.Marshal(beaconBlock)
+	if err := db.StoreBeaconBlock(json.RawMessage(beaconBlockJSON), beaconBlock.Header.Hash(), nil); err != nil {
+		t.Fatal(err)
+	}
+	shardBestStateJSON, _ := json.Marshal(&ShardBestState{ShardID: 1, ShardHeight: 10})
+	if err := db.StoreShardBestState(json.RawMessage(shardBestStateJSON), 1, nil); err != nil {
+		t.Fatal(err)
+	}
+	beaconBestStateJSON, _ := json.Marshal(&BeaconBestState{BeaconHeight: 5})
+	if err := db.StoreBeaconBestState(json.RawMessage(beaconBestStateJSON), nil); err != nil {
+		t.Fatal(err)
+	}
+	if report, err := VerifyStoredBlocks(db, nil); err != nil || report.JSONBlocks != 2 || report.BinaryBlocks != 0 {
+		t.Fatalf("expect 2 json blocks, got %+v, err %+v", report, err)
+	}
+	if err := database.RunMigrations(db, StorageMigrations(), database.CurrentSchemaVersion, nil); err == nil {
+		t.Fatal("migration must fail without codec")
+	}
+
+	RegisterStorageCodec(testStorageCodec{})
+	defer RegisterStorageCodec(nil)
+	if err := database.RunMigrations(db, StorageMigrations(), database.CurrentSchemaVersion, nil); err != nil {
+		t.Fatal(err)
+	}
+	if version, _ := db.GetSchemaVersion(); version != database.CurrentSchemaVersion {
+		t.Fatalf("expect schema version %+v, got %+v", database.CurrentSchemaVersion, version)
+	}
+	if report, err := VerifyStoredBlocks(db, nil); err != nil || report.JSONBlocks != 0 || report.BinaryBlocks != 2 {
+		t.Fatalf("expect 2 binary blocks, got %+v, err %+v", report, err)
+	}
+	blockBytes, err := db.FetchBlock(shardBlock.Header.Hash())
+	if err != nil {
+		t.Fatal(err)
+	}
+	decodedBlock := &ShardBlock{}
+	if err := UnmarshalStoredShardBlock(blockBytes, decodedBlock); err != nil || !decodedBlock.Hash().IsEqual(shardBlock.Hash()) {
+		t.Fatalf("migrated block must equal stored block, err %+v", err)
+	}
+	shardBestStateBytes, _ := db.FetchShardBestState(1)
+	shardBestState := &ShardBestState{}
+	if err := UnmarshalStoredShardBestState(shardBestStateBytes, shardBestState); err != nil || !isBinaryStoredValue(shardBestStateBytes) || shardBestState.ShardHeight != 10 {
+		t.Fatalf("shard best state must be migrated, err %+v", err)
+	}
+	beaconBestStateBytes, _ := db.FetchBeaconBestState()
+	beaconBestState := &BeaconBestState{}
+	if err := UnmarshalStoredBeaconBestState(beaconBestStateBytes, beaconBestState); err != nil || !isBinaryStoredValue(beaconBestStateBytes) || beaconBestState.BeaconHeight != 5 {
+		t.Fatalf("beacon best state must be migrated, err %+v", err)
+	}
+	if migrations, err := database.PendingMigrations(db, StorageMigrations(), database.CurrentSchemaVersion); err != nil || len(migrations) != 0 {
+		t.Fatalf("expect no pending migration, got %+v, err %+v", len(migrations), err)
+	}
+}
@@ -37,7 +37,7 @@ func DecodeInstruction(inst []string) ([]byte, error) {
 			return nil, err
 		}
 
-	case strconv.Itoa(metadata.BurningConfirmMeta):
+	case strconv.Itoa(metadata.BurningConfirmMeta), strconv.Itoa(metadata.BurningConfirmForEVMChainMeta):
 		var err error
 		flatten, err = decodeBurningConfirmInst(inst)
 		if err != nil {
@@ -96,11 +96,16 @@ func parseAndPadAddress(instContent string) ([]byte, error) {
 	return addrs, nil
 }
 
-// decodeBurningConfirmInst decodes and flattens a BurningConfirm instruction
+// decodeBurningConfirmInst decodes and flattens a BurningConfirm instruction,
+// instructions of evm sidechains have the chain id as uint256 before the height
 func decodeBurningConfirmInst(inst []string) ([]byte, error) {
 	if len(inst) < 8 {
 		return nil, errors.New("invalid length of BurningConfirm inst")
 	}
+	hasChainID := inst[0] == strconv.Itoa(metadata.BurningConfirmForEVMChainMeta)
+	if hasChainID && len(inst) < 9 {
+		return nil, errors.New("invalid length of BurningConfirmForEVMChain inst")
+	}
 	m, errMeta := strconv.Atoi(inst[0])
 	s, errShard := strconv.Atoi(inst[1])
 	metaType := byte(m)
@@ -110,8 +115,13 @@ func decodeBurningConfirmInst(inst []string) ([]byte, error) {
 	amount, _, errAmount := base58.Base58Check{}.Decode(inst[4])
 	txID, errTx := common.Hash{}.NewHashFromStr(inst[5])
 	incTokenID, _, errIncToken := base58.Base58Check{}.Decode(inst[6])
-	height, _, errHeight := base58.Base58Check{}.Decode(inst[7])
-	if err := common.CheckError(errMeta, errShard, errToken, errAddr, errAmount, errTx, errIncToken, errHeight); err != nil {
+	var chainID []byte
+	var errChainID error
+	if hasChainID {
+		chainID, _, errChainID = base58.Base58Check{}.Decode(inst[7])
+	}
+	height, _, errHeight := base58.Base58Check{}.Decode(inst[len(inst)-1])
+	if err := common.CheckError(errMeta, errShard, errToken, errAddr, errAmount, errTx, errIncToken, errChainID, errHeight); err != nil {
 		err = errors.Wrapf(err, "inst: %+v", inst)
 		BLogger.log.Error(err)
 		return nil, err
@@ -126,6 +136,9 @@ func decodeBurningConfirmInst(inst []string) ([]byte, error) {
 	flatten = append(flatten, toBytes32BigEndian(amount)...)
 	flatten = append(flatten, txID[:]...)
 	flatten = append(flatten, incTokenID...)
+	if hasChainID {
+		flatten = append(flatten, toBytes32BigEndian(chainID)...)
+	}
 	flatten = append(flatten, toBytes32BigEndian(height)...)
 	return flatten, nil
 }
@@ -164,26 +177,29 @@ func pickInstructionWithType(
 	return found
 }
 
-// pickInstructionFromBeaconBlocks extracts all instructions of a specific type
-func pickInstructionFromBeaconBlocks(beaconBlocks []*BeaconBlock, instType string) [][]string {
+// pickInstructionFromBeaconBlocks extracts all instructions of some specific types, keeping their order in blocks
+func pickInstructionFromBeaconBlocks(beaconBlocks []*BeaconBlock, instTypes ...string) [][]string {
 	insts := [][]string{}
 	for _, block := range beaconBlocks {
-		found := pickInstructionWithType(block.Body.Instructions, instType)
-		if len(found) > 0 {
-			insts = append(insts, found...)
+		for _, inst := range block.Body.Instructions {
+			for _, instType := range instTypes {
+				if inst[0] == instType {
+					insts = append(insts, inst)
+					break
+				}
+			}
 		}
 	}
 	return insts
 }
 
-// pickBurningConfirmInstruction finds all BurningConfirmMeta instructions
+// pickBurningConfirmInstruction finds all BurningConfirmMeta and BurningConfirmForEVMChainMeta instructions
 func pickBurningConfirmInstruction(
 	beaconBlocks []*BeaconBlock,
 	height uint64,
 ) [][]string {
 	// Pick
-	instType := strconv.Itoa(metadata.BurningConfirmMeta)
-	insts := pickInstructionFromBeaconBlocks(beaconBlocks, instType)
+	insts := pickInstructionFromBeaconBlocks(beaconBlocks, strconv.Itoa(metadata.BurningConfirmMeta), strconv.Itoa(metadata.BurningConfirmForEVMChainMeta))
 
 	// Replace beacon block height with shard's
 	h := big.NewInt(0).SetUint64(height)
@@ -0,0 +1,332 @@
+package blockchain
+
+import (
+	"encoding/json"
+	"math"
+	"sort"
+	"strconv"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/database"
+	"github.com/incognitochain/incognito-chain/database/lvdb"
+	"github.com/incognitochain/incognito-chain/metadata"
+)
+
+// isBridgeLimiterActive tells if bridge requests go through the bridge limiter at a beacon height,
+// before the break point burning requests are confirmed by bridge instructions and no request is capped
+func (blockchain *BlockChain) isBridgeLimiterActive(beaconHeight uint64) bool {
+	return beaconHeight >= blockchain.config.ChainParams.BeaconHeightBreakPointBridgeLimiter
+}
+
+// CurrentBridgeLimiterState holds usage of caps of bridge tokens in the epoch of a beacon block
+// and requests queued because they are over the caps or their token is paused
+type CurrentBridgeLimiterState struct {
+	Quotas         map[common.Hash]*lvdb.BridgeTokenQuota
+	QueuedRequests []*lvdb.BridgeQueuedRequest // in the order they were queued
+	limits         map[string]BridgeTokenLimit
+	beaconHeight   uint64
+	epoch          uint64
+	touchedQuotas  map[common.Hash]bool
+	queuedCount    uint64 // number of requests queued by the beacon block
+	newlyQueued    []*lvdb.BridgeQueuedRequest
+	released       []*lvdb.BridgeQueuedRequest
+	// keys of token and direction having queued requests which wait for quota of the epoch,
+	// new requests of them are queued behind so that requests are released in order
+	waitingForQuota map[string]bool
+}
+
+func InitCurrentBridgeLimiterStateFromDB(
+	db database.DatabaseInterface,
+	limits map[string]BridgeTokenLimit,
+	beaconHeight uint64,
+	chainParamEpoch uint64,
+) (*CurrentBridgeLimiterState, error) {
+	quotasBytes, err := db.GetAllBridgeTokenQuotas()
+	if err != nil {
+		return nil, err
+	}
+	quotas := make(map[common.Hash]*lvdb.BridgeTokenQuota)
+	for _, quotaBytes := range quotasBytes {
+		var quota lvdb.BridgeTokenQuota
+		err = json.Unmarshal(quotaBytes, &quota)
+		if err != nil {
+			return nil, err
+		}
+		quotas[quota.TokenID] = &quota
+	}
+	requestsBytes, err := db.GetAllBridgeQueuedRequests()
+	if err != nil {
+		return nil, err
+	}
+	queuedRequests := []*lvdb.BridgeQueuedRequest{}
+	for _, requestBytes := range requestsBytes {
+		var request lvdb.BridgeQueuedRequest
+		err = json.Unmarshal(requestBytes, &request)
+		if err != nil {
+			return nil, err
+		}
+		queuedRequests = append(queuedRequests, &request)
+	}
+	// caps changed by passed proposals of incognito dao override the ones of chain params
+	governedParams, err := getDAOGovernedParamsFromDB(db)
+	if err != nil {
+		return nil, err
+	}
+	limits = applyDAOGovernedBridgeTokenLimits(limits, governedParams)
+	return newBridgeLimiterState(quotas, queuedRequests, limits, beaconHeight, chainParamEpoch), nil
+}
+
+// GetBridgeLimiterState returns the bridge limiter state which the next beacon block starts from
+func (blockchain *BlockChain) GetBridgeLimiterState() (*CurrentBridgeLimiterState, error) {
+	chainParams := blockchain.config.ChainParams
+	beaconHeight := blockchain.BestState.Beacon.BeaconHeight + 1
+	return InitCurrentBridgeLimiterStateFromDB(blockchain.GetDatabase(), chainParams.BridgeTokenLimits, beaconHeight, chainParams.Epoch)
+}
+
+func newBridgeLimiterState(
+	quotas map[common.Hash]*lvdb.BridgeTokenQuota,
+	queuedRequests []*lvdb.BridgeQueuedRequest,
+	limits map[string]BridgeTokenLimit,
+	beaconHeight uint64,
+	chainParamEpoch uint64,
+) *CurrentBridgeLimiterState {
+	return &CurrentBridgeLimiterState{
+		Quotas:          quotas,
+		QueuedRequests:  queuedRequests,
+		limits:          limits,
+		beaconHeight:    beaconHeight,
+		epoch:           (beaconHeight-1)/chainParamEpoch + 1, // same as epoch of the beacon block
+		touchedQuotas:   make(map[common.Hash]bool),
+		newlyQueued:     []*lvdb.BridgeQueuedRequest{},
+		released:        []*lvdb.BridgeQueuedRequest{},
+		waitingForQuota: make(map[string]bool),
+	}
+}
+
+// GetQuota returns usage of caps of a token in the current epoch, amounts counted in previous epochs are dropped
+func (state *CurrentBridgeLimiterState) GetQuota(tokenID common.Hash) *lvdb.BridgeTokenQuota {
+	quota, found := state.Quotas[tokenID]
+	if !found || quota == nil {
+		quota = &lvdb.BridgeTokenQuota{
+			TokenID: tokenID,
+			Epoch:   state.epoch,
+		}
+		state.Quotas[tokenID] = quota
+	}
+	if quota.Epoch != state.epoch {
+		quota.Epoch = state.epoch
+		quota.IssuedAmount = 0
+		quota.BurnedAmount = 0
+	}
+	return quota
+}
+
+// GetLimit returns the caps of a token, zero caps are not enforced
+func (state *CurrentBridgeLimiterState) GetLimit(tokenID common.Hash) BridgeTokenLimit {
+	return state.limits[tokenID.String()]
+}
+
+// IsPaused returns true if requests of the token or of all tokens are paused
+func (state *CurrentBridgeLimiterState) IsPaused(tokenID common.Hash) bool {
+	if quota, found := state.Quotas[common.Hash{}]; found && quota.Paused {
+		return true
+	}
+	quota, found := state.Quotas[tokenID]
+	return found && quota.Paused
+}
+
+// GetRemainingQuota returns the amount which can still be issued or burned in the current epoch
+func (state *CurrentBridgeLimiterState) GetRemainingQuota(metaType int, tokenID common.Hash) uint64 {
+	limit := state.GetLimit(tokenID)
+	quota := state.GetQuota(tokenID)
+	maxPerEpoch, used := limit.MaxIssuingPerEpoch, quota.IssuedAmount
+	if metaType == metadata.BurningRequestMeta {
+		maxPerEpoch, used = limit.MaxBurningPerEpoch, quota.BurnedAmount
+	}
+	if maxPerEpoch == 0 {
+		return math.MaxUint64
+	}
+	if used >= maxPerEpoch {
+		return 0
+	}
+	return maxPerEpoch - used
+}
+
+// isOverTxCap returns true if the amount of a request is over the per-tx cap of its token
+func (state *CurrentBridgeLimiterState) isOverTxCap(metaType int, tokenID common.Hash, amount uint64) bool {
+	limit := state.GetLimit(tokenID)
+	maxPerTx := limit.MaxIssuingPerTx
+	if metaType == metadata.BurningRequestMeta {
+		maxPerTx = limit.MaxBurningPerTx
+	}
+	return maxPerTx > 0 && amount > maxPerTx
+}
+
+func buildQuotaWaitingKey(metaType int, tokenID common.Hash) string {
+	return strconv.Itoa(metaType) + "-" + tokenID.String()
+}
+
+/*
+canProcessRequest returns false if a request must be queued:
+- its token or the whole bridge is paused
+- its amount is over the per-tx cap of its token
+- its amount is over the remaining quota of the epoch, or earlier requests of the token wait for the quota
+*/
+func (state *CurrentBridgeLimiterState) canProcessRequest(metaType int, tokenID common.Hash, amount uint64) bool {
+	if state.IsPaused(tokenID) || state.isOverTxCap(metaType, tokenID, amount) {
+		return false
+	}
+	waitingKey := buildQuotaWaitingKey(metaType, tokenID)
+	if state.waitingForQuota[waitingKey] {
+		return false
+	}
+	if amount > state.GetRemainingQuota(metaType, tokenID) {
+		state.waitingForQuota[waitingKey] = true
+		return false
+	}
+	return true
+}
+
+// countRequest counts the amount of a processed request into usage of its token in the current epoch
+func (state *CurrentBridgeLimiterState) countRequest(metaType int, tokenID common.Hash, amount uint64) {
+	quota := state.GetQuota(tokenID)
+	if metaType == metadata.BurningRequestMeta {
+		quota.BurnedAmount = addWithoutOverflow(quota.BurnedAmount, amount)
+	} else {
+		quota.IssuedAmount = addWithoutOverflow(quota.IssuedAmount, amount)
+	}
+	state.touchedQuotas[tokenID] = true
+}
+
+func addWithoutOverflow(a uint64, b uint64) uint64 {
+	if a > math.MaxUint64-b {
+		return math.MaxUint64
+	}
+	return a + b
+}
+
+// queueRequest sets position of a request in the queue by the beacon block queueing it and appends the request to the queue
+func (state *CurrentBridgeLimiterState) queueRequest(request *lvdb.BridgeQueuedRequest) {
+	request.BeaconHeight = state.beaconHeight
+	request.Index = state.queuedCount
+	state.addQueuedRequest(request)
+}
+
+// addQueuedRequest appends a request whose position is already set to the queue
+func (state *CurrentBridgeLimiterState) addQueuedRequest(request *lvdb.BridgeQueuedRequest) {
+	state.queuedCount++
+	state.QueuedRequests = append(state.QueuedRequests, request)
+	state.newlyQueued = append(state.newlyQueued, request)
+}
+
+func (state *CurrentBridgeLimiterState) findQueuedRequest(txReqID common.Hash) *lvdb.BridgeQueuedRequest {
+	for _, request := range state.QueuedRequests {
+		if request.TxReqID.IsEqual(&txReqID) {
+			return request
+		}
+	}
+	return nil
+}
+
+// releaseRequest removes a request from the queue, it returns nil if the request is not queued
+func (state *CurrentBridgeLimiterState) releaseRequest(txReqID common.Hash) *lvdb.BridgeQueuedRequest {
+	for i, request := range state.QueuedRequests {
+		if !request.TxReqID.IsEqual(&txReqID) {
+			continue
+		}
+		state.QueuedRequests = append(state.QueuedRequests[:i:i], state.QueuedRequests[i+1:]...)
+		for j, newRequest := range state.newlyQueued {
+			if newRequest == request {
+				state.newlyQueued = append(state.newlyQueued[:j:j], state.newlyQueued[j+1:]...)
+				return request
+			}
+		}
+		state.released = append(state.released, request)
+		return request
+	}
+	return nil
+}
+
+// setPaused pauses or unpauses requests of a token, the zero token id stands for all tokens
+func (state *CurrentBridgeLimiterState) setPaused(tokenID common.Hash, paused bool) {
+	quota := state.GetQuota(tokenID)
+	quota.Paused = paused
+	state.touchedQuotas[tokenID] = true
+}
+
+func (state *CurrentBridgeLimiterState) getControlNonce() uint64 {
+	return state.GetQuota(common.Hash{}).ControlNonce
+}
+
+func (state *CurrentBridgeLimiterState) increaseControlNonce() {
+	quota := state.GetQuota(common.Hash{})
+	quota.ControlNonce++
+	state.touchedQuotas[common.Hash{}] = true
+}
+
+// applyBridgeControl applies an approved control request with the next nonce, it returns false otherwise
+func (state *CurrentBridgeLimiterState) applyBridgeControl(
+	controlReq metadata.BridgeControlRequest,
+) bool {
+	if controlReq.Nonce != state.getControlNonce() {
+		return false
+	}
+	switch controlReq.Action {
+	case metadata.BridgeControlPauseAction:
+		state.setPaused(controlReq.TokenID, true)
+	case metadata.BridgeControlUnpauseAction:
+		state.setPaused(controlReq.TokenID, false)
+	case metadata.BridgeControlReleaseAction:
+		if state.findQueuedRequest(controlReq.ReleasedTxReqID) == nil {
+			return false
+		}
+	default:
+		return false
+	}
+	state.increaseControlNonce()
+	return true
+}
+
+func storeBridgeLimiterStateToDB(
+	db database.DatabaseInterface,
+	currentBridgeLimiterState *CurrentBridgeLimiterState,
+) error {
+	var keys []common.Hash
+	for k := range currentBridgeLimiterState.touchedQuotas {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].String() < keys[j].String()
+	})
+	for _, tokenID := range keys {
+		quota, found := currentBridgeLimiterState.Quotas[tokenID]
+		if !found || quota == nil {
+			continue
+		}
+		quotaBytes, err := json.Marshal(quota)
+		if err != nil {
+			return err
+		}
+		err = db.StoreBridgeTokenQuota(tokenID, quotaBytes)
+		if err != nil {
+			return err
+		}
+	}
+	for _, request := range currentBridgeLimiterState.newlyQueued {
+		requestBytes, err := json.Marshal(request)
+		if err != nil {
+			return err
+		}
+		err = db.StoreBridgeQueuedRequest(request.BeaconHeight, request.Index, requestBytes)
+		if err != nil {
+			return err
+		}
+	}
+	for _, request := range currentBridgeLimiterState.released {
+		err := db.DeleteBridgeQueuedRequest(request.BeaconHeight, request.Index)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,238 @@
+package blockchain
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strconv"
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/database/lvdb"
+	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/incognitochain/incognito-chain/mocks"
+)
+
+func newTestBridgeLimiterState(limits map[string]BridgeTokenLimit, beaconHeight uint64) *CurrentBridgeLimiterState {
+	return newBridgeLimiterState(
+		map[common.Hash]*lvdb.BridgeTokenQuota{},
+		[]*lvdb.BridgeQueuedRequest{},
+		limits,
+		beaconHeight,
+		100,
+	)
+}
+
+func TestBridgeLimiterCaps(t *testing.T) {
+	tokenID := common.Hash{1}
+	limits := map[string]BridgeTokenLimit{
+		tokenID.String(): {MaxIssuingPerTx: 100, MaxIssuingPerEpoch: 150},
+	}
+	state := newTestBridgeLimiterState(limits, 10)
+	issuing := metadata.IssuingETHRequestMeta
+
+	if state.canProcessRequest(issuing, tokenID, 101) {
+		t.Errorf("request over the per-tx cap should be queued")
+	}
+	if !state.canProcessRequest(issuing, tokenID, 100) {
+		t.Errorf("request within the caps should be processed")
+	}
+	state.countRequest(issuing, tokenID, 100)
+	if state.GetRemainingQuota(issuing, tokenID) != 50 {
+		t.Errorf("unexpected remaining quota: %d", state.GetRemainingQuota(issuing, tokenID))
+	}
+	if state.canProcessRequest(issuing, tokenID, 60) {
+		t.Errorf("request over the remaining quota should be queued")
+	}
+	if state.canProcessRequest(issuing, tokenID, 10) {
+		t.Errorf("request behind a request waiting for quota should be queued")
+	}
+	if !state.canProcessRequest(metadata.BurningRequestMeta, tokenID, 1000) {
+		t.Errorf("burning of a token without burning caps should be processed")
+	}
+	if !state.canProcessRequest(issuing, common.Hash{2}, 1000) {
+		t.Errorf("request of a token without caps should be processed")
+	}
+}
+
+func TestBridgeLimiterEpochReset(t *testing.T) {
+	tokenID := common.Hash{1}
+	limits := map[string]BridgeTokenLimit{
+		tokenID.String(): {MaxBurningPerEpoch: 100},
+	}
+	state := newTestBridgeLimiterState(limits, 100)
+	state.countRequest(metadata.BurningRequestMeta, tokenID, 100)
+	if state.GetRemainingQuota(metadata.BurningRequestMeta, tokenID) != 0 {
+		t.Errorf("quota of the epoch should be used up")
+	}
+
+	nextState := newBridgeLimiterState(state.Quotas, state.QueuedRequests, limits, 101, 100)
+	if nextState.GetRemainingQuota(metadata.BurningRequestMeta, tokenID) != 100 {
+		t.Errorf("quota should be restored in a new epoch, got %d", nextState.GetRemainingQuota(metadata.BurningRequestMeta, tokenID))
+	}
+}
+
+func TestBridgeLimiterQueue(t *testing.T) {
+	tokenID := common.Hash{1}
+	state := newTestBridgeLimiterState(map[string]BridgeTokenLimit{}, 10)
+	for i := byte(1); i <= 3; i++ {
+		state.queueRequest(&lvdb.BridgeQueuedRequest{
+			TxReqID:  common.Hash{i},
+			MetaType: metadata.IssuingETHRequestMeta,
+			TokenID:  tokenID,
+			Amount:   10,
+		})
+	}
+	if state.releaseRequest(common.Hash{2}) == nil {
+		t.Errorf("queued request should be released")
+	}
+	if state.releaseRequest(common.Hash{2}) != nil {
+		t.Errorf("released request should not be released again")
+	}
+	state.queueRequest(&lvdb.BridgeQueuedRequest{TxReqID: common.Hash{4}, TokenID: tokenID})
+
+	expected := []common.Hash{{1}, {3}, {4}}
+	if len(state.QueuedRequests) != len(expected) {
+		t.Fatalf("unexpected queue: %+v", state.QueuedRequests)
+	}
+	for i, request := range state.QueuedRequests {
+		if !request.TxReqID.IsEqual(&expected[i]) || request.BeaconHeight != 10 {
+			t.Errorf("unexpected request at %d: %+v", i, request)
+		}
+	}
+	if state.QueuedRequests[2].Index != 3 {
+		t.Errorf("index of a request queued after a release should not collide, got %d", state.QueuedRequests[2].Index)
+	}
+	if len(state.newlyQueued) != 3 || len(state.released) != 0 {
+		t.Errorf("request queued and released by the same block should not be stored")
+	}
+}
+
+func TestBridgeLimiterControl(t *testing.T) {
+	tokenID := common.Hash{1}
+	state := newTestBridgeLimiterState(map[string]BridgeTokenLimit{}, 10)
+	pause := metadata.BridgeControlRequest{Action: metadata.BridgeControlPauseAction, TokenID: tokenID, Nonce: 0}
+	if !state.applyBridgeControl(pause) {
+		t.Errorf("control with the next nonce should be applied")
+	}
+	if state.applyBridgeControl(pause) {
+		t.Errorf("control with a used nonce should be rejected")
+	}
+	if !state.IsPaused(tokenID) || state.IsPaused(common.Hash{2}) {
+		t.Errorf("only the paused token should be paused")
+	}
+	if state.canProcessRequest(metadata.BurningRequestMeta, tokenID, 1) {
+		t.Errorf("request of a paused token should be queued")
+	}
+
+	pauseAll := metadata.BridgeControlRequest{Action: metadata.BridgeControlPauseAction, Nonce: 1}
+	if !state.applyBridgeControl(pauseAll) || !state.IsPaused(common.Hash{2}) {
+		t.Errorf("pausing the zero token should pause all tokens")
+	}
+
+	release := metadata.BridgeControlRequest{Action: metadata.BridgeControlReleaseAction, ReleasedTxReqID: common.Hash{9}, Nonce: 2}
+	if state.applyBridgeControl(release) {
+		t.Errorf("release of a request which is not queued should be rejected")
+	}
+	state.queueRequest(&lvdb.BridgeQueuedRequest{TxReqID: common.Hash{9}, TokenID: tokenID})
+	if !state.applyBridgeControl(release) {
+		t.Errorf("release of a queued request should be applied")
+	}
+
+	unpause := metadata.BridgeControlRequest{Action: metadata.BridgeControlUnpauseAction, TokenID: tokenID, Nonce: 3}
+	if !state.applyBridgeControl(unpause) || !state.IsPaused(tokenID) {
+		t.Errorf("token should stay paused while the whole bridge is paused")
+	}
+	if state.GetQuota(common.Hash{}).ControlNonce != 4 {
+		t.Errorf("unexpected control nonce: %d", state.GetQuota(common.Hash{}).ControlNonce)
+	}
+}
+
+func TestBridgeLimiterReleaseBTCIssuance(t *testing.T) {
+	incTokenID := common.Hash{7}
+	externalTokenID := []byte(BTCRelayingExternalTokenIDStr)
+	content, _ := json.Marshal(metadata.IssuingBTCAcceptedInst{
+		IssuingAmount:   500,
+		IncTokenID:      incTokenID,
+		TxReqID:         common.Hash{8},
+		UniqBTCTx:       []byte{1, 2, 3},
+		ExternalTokenID: externalTokenID,
+	})
+	state := newTestBridgeLimiterState(map[string]BridgeTokenLimit{
+		incTokenID.String(): {MaxIssuingPerEpoch: 1000},
+	}, 10)
+	state.queueRequest(&lvdb.BridgeQueuedRequest{
+		TxReqID:  common.Hash{8},
+		MetaType: metadata.IssuingBTCRequestMeta,
+		TokenID:  incTokenID,
+		Amount:   500,
+		Content:  base64.StdEncoding.EncodeToString(content),
+	})
+	db := &mocks.DatabaseInterface{}
+	db.On("CanProcessTokenPair", uint64(0), externalTokenID, incTokenID).Return(true, nil)
+	ac := &metadata.AccumulatedValues{
+		DBridgeTokenPair:     map[string][]byte{},
+		DBridgeTokenChainIDs: map[string]uint64{},
+	}
+
+	bc := BlockChain{}
+	insts := bc.buildInstructionsForReleasingBridgeReqs(state, 10, db, ac)
+	if len(insts) != 1 || insts[0][0] != strconv.Itoa(metadata.IssuingBTCRequestMeta) || insts[0][2] != "accepted" {
+		t.Fatalf("expected btc issuance to be released, got %+v", insts)
+	}
+	if len(state.QueuedRequests) != 0 || state.GetQuota(incTokenID).IssuedAmount != 500 {
+		t.Errorf("released btc issuance should be counted and removed from the queue")
+	}
+	if _, found := ac.DBridgeTokenChainIDs[incTokenID.String()]; found {
+		t.Errorf("btc token should not get an evm chain id")
+	}
+}
+
+func TestBridgeLimiterRevert(t *testing.T) {
+	_, db, cleanup := newTestStakingPoolChain(t)
+	defer cleanup()
+	tokenID := common.Hash{1}
+	limits := map[string]BridgeTokenLimit{}
+	storeBlock := func(beaconHeight uint64, update func(state *CurrentBridgeLimiterState)) {
+		if err := db.CleanBackup(true, 0); err != nil {
+			t.Fatal(err)
+		}
+		state, err := InitCurrentBridgeLimiterStateFromDB(db, limits, beaconHeight, 100)
+		if err != nil {
+			t.Fatal(err)
+		}
+		update(state)
+		if err := storeBridgeLimiterStateToDB(db, state); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	storeBlock(10, func(state *CurrentBridgeLimiterState) {
+		state.countRequest(metadata.IssuingETHRequestMeta, tokenID, 100)
+		state.queueRequest(&lvdb.BridgeQueuedRequest{TxReqID: common.Hash{2}, TokenID: tokenID, Amount: 10})
+	})
+	// a reverted block gives back the quota, the nonce and the queue it changed
+	storeBlock(11, func(state *CurrentBridgeLimiterState) {
+		state.countRequest(metadata.IssuingETHRequestMeta, tokenID, 50)
+		state.releaseRequest(common.Hash{2})
+		state.queueRequest(&lvdb.BridgeQueuedRequest{TxReqID: common.Hash{3}, TokenID: tokenID, Amount: 20})
+		if !state.applyBridgeControl(metadata.BridgeControlRequest{Action: metadata.BridgeControlPauseAction, Nonce: 0}) {
+			t.Errorf("control with the next nonce should be applied")
+		}
+	})
+	if err := db.RestoreBeaconStates(); err != nil {
+		t.Fatal(err)
+	}
+	state, err := InitCurrentBridgeLimiterStateFromDB(db, limits, 12, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if state.GetQuota(tokenID).IssuedAmount != 100 {
+		t.Errorf("unexpected quota after revert: %+v", state.GetQuota(tokenID))
+	}
+	if state.GetQuota(common.Hash{}).ControlNonce != 0 || state.IsPaused(tokenID) {
+		t.Errorf("unexpected bridge control after revert: %+v", state.GetQuota(common.Hash{}))
+	}
+	if len(state.QueuedRequests) != 1 || state.QueuedRequests[0].TxReqID != (common.Hash{2}) {
+		t.Errorf("unexpected queue after revert: %+v", state.QueuedRequests)
+	}
+}
@@ -10,6 +10,7 @@ import (
 
 	rCommon "github.com/ethereum/go-ethereum/common"
 	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/database/lvdb"
 	"github.com/incognitochain/incognito-chain/metadata"
 	"github.com/incognitochain/incognito-chain/privacy"
 	"github.com/incognitochain/incognito-chain/transaction"
@@ -100,6 +101,7 @@ func (blockchain *BlockChain) buildInstructionsForIssuingETHReq(
 	shardID byte,
 	metaType int,
 	ac *metadata.AccumulatedValues,
+	currentBridgeLimiterState *CurrentBridgeLimiterState,
 ) ([][]string, error) {
 	fmt.Println("[Decentralized bridge token issuance] Starting...")
 	instructions := [][]string{}
@@ -118,9 +120,13 @@ func (blockchain *BlockChain) buildInstructionsForIssuingETHReq(
 		return append(instructions, rejectedInst), nil
 	}
 
-	// NOTE: since TxHash from constructedReceipt is always '0x0000000000000000000000000000000000000000000000000000000000000000'
-	// so must build unique eth tx as combination of block hash and tx index.
-	uniqETHTx := append(md.BlockHash[:], []byte(strconv.Itoa(int(md.TxIndex)))...)
+	chain, err := blockchain.GetEVMChain(md.ChainID)
+	if err != nil {
+		fmt.Println("WARNING: an issue occured while getting chain of the deposit: ", err)
+		return append(instructions, rejectedInst), nil
+	}
+
+	uniqETHTx := metadata.GetUniqETHTx(md.ChainID, md.BlockHash, md.TxIndex)
 	isUsedInBlock := metadata.IsETHTxHashUsedInBlock(uniqETHTx, ac.UniqETHTxsUsed)
 	if isUsedInBlock {
 		fmt.Println("WARNING: already issued for the hash in current block: ", uniqETHTx)
@@ -136,7 +142,7 @@ func (blockchain *BlockChain) buildInstructionsForIssuingETHReq(
 		return append(instructions, rejectedInst), nil
 	}
 
-	logMap, err := metadata.PickAndParseLogMapFromReceipt(ethReceipt, blockchain.config.ChainParams.EthContractAddressStr)
+	logMap, err := chain.PickAndParseDepositLogMap(ethReceipt)
 	if err != nil {
 		fmt.Println("WARNING: an error occured while parsing log map from receipt: ", err)
 		return append(instructions, rejectedInst), nil
@@ -156,7 +162,7 @@ func (blockchain *BlockChain) buildInstructionsForIssuingETHReq(
 		return append(instructions, rejectedInst), nil
 	}
 	ethereumToken := ethereumAddr.Bytes()
-	canProcess, err := ac.CanProcessTokenPair(ethereumToken, md.IncTokenID)
+	canProcess, err := ac.CanProcessTokenPair(md.ChainID, ethereumToken, md.IncTokenID)
 	if err != nil {
 		fmt.Println("WARNING: an error occured while checking it can process for token pair on the current block or not: ", err)
 		return append(instructions, rejectedInst), nil
@@ -166,7 +172,7 @@ func (blockchain *BlockChain) buildInstructionsForIssuingETHReq(
 		return append(instructions, rejectedInst), nil
 	}
 
-	isValid, err := db.CanProcessTokenPair(ethereumToken, md.IncTokenID)
+	isValid, err := db.CanProcessTokenPair(md.ChainID, ethereumToken, md.IncTokenID)
 	if err != nil {
 		fmt.Println("WARNING: an error occured while checking it can process for token pair on the previous blocks or not: ", err)
 		return append(instructions, rejectedInst), nil
@@ -202,6 +208,7 @@ func (blockchain *BlockChain) buildInstructionsForIssuingETHReq(
 		TxReqID:         issuingETHReqAction.TxReqID,
 		UniqETHTx:       uniqETHTx,
 		ExternalTokenID: ethereumToken,
+		ChainID:         md.ChainID,
 	}
 	issuingETHAcceptedInstBytes, err := json.Marshal(issuingETHAcceptedInst)
 	if err != nil {
@@ -210,8 +217,30 @@ func (blockchain *BlockChain) buildInstructionsForIssuingETHReq(
 	}
 	ac.UniqETHTxsUsed = append(ac.UniqETHTxsUsed, uniqETHTx)
 	ac.DBridgeTokenPair[md.IncTokenID.String()] = ethereumToken
-
-	acceptedInst := buildInstruction(metaType, shardID, "accepted", base64.StdEncoding.EncodeToString(issuingETHAcceptedInstBytes))
+	ac.DBridgeTokenChainIDs[md.IncTokenID.String()] = md.ChainID
+
+	acceptedContent := base64.StdEncoding.EncodeToString(issuingETHAcceptedInstBytes)
+	if currentBridgeLimiterState == nil {
+		return append(instructions, buildInstruction(metaType, shardID, "accepted", acceptedContent)), nil
+	}
+	if !currentBridgeLimiterState.canProcessRequest(metaType, md.IncTokenID, amount) {
+		queuedInst, err := buildQueuedBridgeInst(metaType, shardID, &lvdb.BridgeQueuedRequest{
+			TxReqID:  issuingETHReqAction.TxReqID,
+			MetaType: metaType,
+			TokenID:  md.IncTokenID,
+			Amount:   amount,
+			ShardID:  shardID,
+			Content:  acceptedContent,
+		}, currentBridgeLimiterState)
+		if err != nil {
+			fmt.Println("WARNING: an error occured while queueing issuance request: ", err)
+			return append(instructions, rejectedInst), nil
+		}
+		fmt.Println("INFO: issuance request is queued by bridge limiter: ", issuingETHReqAction.TxReqID.String())
+		return append(instructions, queuedInst), nil
+	}
+	currentBridgeLimiterState.countRequest(metaType, md.IncTokenID, amount)
+	acceptedInst := buildInstruction(metaType, shardID, "accepted", acceptedContent)
 	return append(instructions, acceptedInst), nil
 }
 
@@ -356,3 +385,75 @@ func (blockGenerator *BlockGenerator) buildETHIssuanceTx(
 	fmt.Println("[Decentralized bridge token issuance] Create tx ok.")
 	return resTx, nil
 }
+
+func (blockGenerator *BlockGenerator) buildBTCIssuanceTx(
+	contentStr string,
+	producerPrivateKey *privacy.PrivateKey,
+	shardID byte,
+) (metadata.Transaction, error) {
+	fmt.Println("[BTC relaying issuance] Starting...")
+	contentBytes, err := base64.StdEncoding.DecodeString(contentStr)
+	if err != nil {
+		fmt.Println("WARNING: an error occured while decoding content string of BTC accepted issuance instruction: ", err)
+		return nil, nil
+	}
+	var issuingBTCAcceptedInst metadata.IssuingBTCAcceptedInst
+	err = json.Unmarshal(contentBytes, &issuingBTCAcceptedInst)
+	if err != nil {
+		fmt.Println("WARNING: an error occured while unmarshaling BTC accepted issuance instruction: ", err)
+		return nil, nil
+	}
+
+	if shardID != issuingBTCAcceptedInst.ShardID {
+		return nil, nil
+	}
+
+	key, err := wallet.Base58CheckDeserialize(issuingBTCAcceptedInst.ReceiverAddrStr)
+	if err != nil {
+		fmt.Println("WARNING: an error occured while deserializing receiver address string: ", err)
+		return nil, nil
+	}
+
+	receiver := &privacy.PaymentInfo{
+		Amount:         issuingBTCAcceptedInst.IssuingAmount,
+		PaymentAddress: key.KeySet.PaymentAddress,
+	}
+	var propertyID [common.HashSize]byte
+	copy(propertyID[:], issuingBTCAcceptedInst.IncTokenID[:])
+	propID := common.Hash(propertyID)
+	tokenParams := &transaction.CustomTokenPrivacyParamTx{
+		PropertyID:  propID.String(),
+		Amount:      issuingBTCAcceptedInst.IssuingAmount,
+		TokenTxType: transaction.CustomTokenInit,
+		Receiver:    []*privacy.PaymentInfo{receiver},
+		TokenInput:  []*privacy.InputCoin{},
+		Mintable:    true,
+	}
+
+	issuingBTCRes := metadata.NewIssuingBTCResponse(
+		issuingBTCAcceptedInst.TxReqID,
+		issuingBTCAcceptedInst.UniqBTCTx,
+		issuingBTCAcceptedInst.ExternalTokenID,
+		metadata.IssuingBTCResponseMeta,
+	)
+	resTx := &transaction.TxCustomTokenPrivacy{}
+	initErr := resTx.Init(
+		transaction.NewTxPrivacyTokenInitParams(producerPrivateKey,
+			[]*privacy.PaymentInfo{},
+			nil,
+			0,
+			tokenParams,
+			blockGenerator.chain.config.DataBase,
+			issuingBTCRes,
+			false,
+			false,
+			shardID, nil))
+
+	if initErr != nil {
+		fmt.Println("WARNING: an error occured while initializing response tx: ", initErr)
+		return nil, nil
+	}
+
+	fmt.Println("[BTC relaying issuance] Create tx ok.")
+	return resTx, nil
+}
@@ -0,0 +1,48 @@
+package bridgeverifier
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	UnExpectedError = iota
+	DecodeProofError
+	InstructionPathError
+	CommitteeNotFoundError
+	NotEnoughSignaturesError
+	SignatureError
+	SwapInstructionError
+)
+
+var ErrCodeMessage = map[int]struct {
+	code    int
+	message string
+}{
+	UnExpectedError:          {-1, "Unexpected error"},
+	DecodeProofError:         {-2, "Decode Proof Error"},
+	InstructionPathError:     {-3, "Instruction Path Error"},
+	CommitteeNotFoundError:   {-4, "Committee Not Found Error"},
+	NotEnoughSignaturesError: {-5, "Not Enough Signatures Error"},
+	SignatureError:           {-6, "Signature Error"},
+	SwapInstructionError:     {-7, "Swap Instruction Error"},
+}
+
+type BridgeVerifierError struct {
+	Code    int
+	Message string
+	err     error
+}
+
+func (e BridgeVerifierError) Error() string {
+	return fmt.Sprintf("%d: %s \n %+v", e.Code, e.Message, e.err)
+}
+
+func NewBridgeVerifierError(key int, err error) *BridgeVerifierError {
+	return &BridgeVerifierError{
+		Code:    ErrCodeMessage[key].code,
+		Message: ErrCodeMessage[key].message,
+		err:     errors.Wrap(err, ErrCodeMessage[key].message),
+	}
+}
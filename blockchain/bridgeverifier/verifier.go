@@ -0,0 +1,354 @@
+package bridgeverifier
+
+import (
+	"bytes"
+	"encoding/hex"
+	"math/big"
+	"strconv"
+
+	rCommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/incognitochain/incognito-chain/rpcserver/jsonresult"
+	"github.com/pkg/errors"
+)
+
+// Off-chain verification of the proofs returned by getburnproof, getbeaconswapproof and getbridgeswapproof,
+// it checks what the vault and incognito proxy contracts check so a proof can be validated before relaying it:
+// the instruction is in the instruction merkle tree of a block and more than 2/3 of the committee of the block signed it
+
+const (
+	bridgeSigSize = 65
+	// meta type, shard id, start height and number of signers of a swap confirm instruction
+	swapInstHeaderSize = 1 + 1 + 32 + 32
+)
+
+// Committee is the list of addresses signing beacon or bridge blocks from StartHeight
+type Committee struct {
+	StartHeight uint64
+	Signers     []rCommon.Address
+}
+
+// NewCommitteeFromKeys converts base58 committee public keys, as in beacon best state, to signer addresses
+func NewCommitteeFromKeys(startHeight uint64, committeeKeys []string) (*Committee, error) {
+	committee := &Committee{StartHeight: startHeight}
+	for _, key := range committeeKeys {
+		cKey := &incognitokey.CommitteePublicKey{}
+		if err := cKey.FromBase58(key); err != nil {
+			return nil, NewBridgeVerifierError(UnExpectedError, err)
+		}
+		pk, err := crypto.DecompressPubkey(cKey.MiningPubKey[common.BridgeConsensus])
+		if err != nil {
+			return nil, NewBridgeVerifierError(UnExpectedError, errors.Wrapf(err, "bridge key of %s", key))
+		}
+		committee.Signers = append(committee.Signers, crypto.PubkeyToAddress(*pk))
+	}
+	return committee, nil
+}
+
+// Verifier keeps the trusted beacon and bridge committees, the committees are only changed by verified swap proofs
+type Verifier struct {
+	beaconCommittees []*Committee
+	bridgeCommittees []*Committee
+}
+
+func NewVerifier(beaconCommittee *Committee, bridgeCommittee *Committee) *Verifier {
+	return &Verifier{
+		beaconCommittees: []*Committee{beaconCommittee},
+		bridgeCommittees: []*Committee{bridgeCommittee},
+	}
+}
+
+func findCommittee(committees []*Committee, height uint64) (*Committee, error) {
+	for i := len(committees) - 1; i >= 0; i-- {
+		if committees[i].StartHeight <= height {
+			return committees[i], nil
+		}
+	}
+	return nil, NewBridgeVerifierError(CommitteeNotFoundError, errors.Errorf("no committee signs block %d", height))
+}
+
+// GetBeaconCommittee returns the trusted committee signing the beacon block at height
+func (v *Verifier) GetBeaconCommittee(height uint64) (*Committee, error) {
+	return findCommittee(v.beaconCommittees, height)
+}
+
+// GetBridgeCommittee returns the trusted committee signing the bridge block at height
+func (v *Verifier) GetBridgeCommittee(height uint64) (*Committee, error) {
+	return findCommittee(v.bridgeCommittees, height)
+}
+
+// blockProof is the proof of an instruction in one beacon or bridge block
+type blockProof struct {
+	instPath       [][]byte
+	instPathIsLeft []bool
+	instRoot       []byte
+	blkData        []byte
+	sigs           [][]byte
+	sigIdxs        []int
+}
+
+func decodeHexHash(s string) ([]byte, error) {
+	// the sibling of the last node of a level with an odd number of nodes is empty
+	if s == "" {
+		return make([]byte, common.HashSize), nil
+	}
+	h, err := hex.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	if len(h) != common.HashSize {
+		return nil, errors.Errorf("%s is not %d bytes", s, common.HashSize)
+	}
+	return h, nil
+}
+
+func newBlockProof(path []string, isLeft []bool, root string, blkData string, sigs []string, sigIdxs []int) (*blockProof, error) {
+	if len(path) != len(isLeft) {
+		return nil, NewBridgeVerifierError(DecodeProofError, errors.New("instruction path and its sides have different lengths"))
+	}
+	if len(sigs) != len(sigIdxs) {
+		return nil, NewBridgeVerifierError(DecodeProofError, errors.New("signatures and signer indexes have different lengths"))
+	}
+	proof := &blockProof{
+		instPathIsLeft: isLeft,
+		sigIdxs:        sigIdxs,
+	}
+	for _, node := range path {
+		h, err := decodeHexHash(node)
+		if err != nil {
+			return nil, NewBridgeVerifierError(DecodeProofError, errors.Wrap(err, "instruction path"))
+		}
+		proof.instPath = append(proof.instPath, h)
+	}
+	var err error
+	if root == "" || blkData == "" {
+		return nil, NewBridgeVerifierError(DecodeProofError, errors.New("instruction root or block data is empty"))
+	}
+	if proof.instRoot, err = decodeHexHash(root); err != nil {
+		return nil, NewBridgeVerifierError(DecodeProofError, errors.Wrap(err, "instruction root"))
+	}
+	if proof.blkData, err = decodeHexHash(blkData); err != nil {
+		return nil, NewBridgeVerifierError(DecodeProofError, errors.Wrap(err, "block data"))
+	}
+	for _, sigStr := range sigs {
+		sig, err := hex.DecodeString(sigStr)
+		if err != nil || len(sig) != bridgeSigSize {
+			return nil, NewBridgeVerifierError(DecodeProofError, errors.Errorf("signature %s is invalid", sigStr))
+		}
+		proof.sigs = append(proof.sigs, sig)
+	}
+	return proof, nil
+}
+
+func newBeaconProof(proof *jsonresult.GetInstructionProof) (*blockProof, error) {
+	return newBlockProof(proof.BeaconInstPath, proof.BeaconInstPathIsLeft, proof.BeaconInstRoot, proof.BeaconBlkData, proof.BeaconSigs, proof.BeaconSigIdxs)
+}
+
+func newBridgeProof(proof *jsonresult.GetInstructionProof) (*blockProof, error) {
+	return newBlockProof(proof.BridgeInstPath, proof.BridgeInstPathIsLeft, proof.BridgeInstRoot, proof.BridgeBlkData, proof.BridgeSigs, proof.BridgeSigIdxs)
+}
+
+// VerifyInstructionPath checks that the merkle path leads from the hash of an instruction to the root of
+// a tree built by blockchain.BuildKeccak256MerkleTree, an empty sibling on the right is the node itself
+func VerifyInstructionPath(instHash []byte, path [][]byte, isLeft []bool, root []byte) bool {
+	if len(path) != len(isLeft) {
+		return false
+	}
+	emptyNode := make([]byte, common.HashSize)
+	hash := instHash
+	for i, node := range path {
+		var parent common.Hash
+		switch {
+		case isLeft[i]:
+			parent = common.Keccak256(node, hash)
+		case bytes.Equal(node, emptyNode):
+			parent = common.Keccak256(hash, hash)
+		default:
+			parent = common.Keccak256(hash, node)
+		}
+		hash = parent[:]
+	}
+	return bytes.Equal(hash, root)
+}
+
+// verify checks the instruction is in the block and more than 2/3 of the committee signed the block
+func (proof *blockProof) verify(instHash []byte, committee *Committee) error {
+	if !VerifyInstructionPath(instHash, proof.instPath, proof.instPathIsLeft, proof.instRoot) {
+		return NewBridgeVerifierError(InstructionPathError, errors.New("instruction is not in the block"))
+	}
+	if len(proof.sigIdxs) <= len(committee.Signers)*2/3 {
+		return NewBridgeVerifierError(NotEnoughSignaturesError, errors.Errorf("%d of %d signers signed the block", len(proof.sigIdxs), len(committee.Signers)))
+	}
+
+	// signers sign the keccak256 of the hash of the block, the hash of a block is keccak256 of its meta hash and instruction root
+	blkHash := common.Keccak256(proof.blkData, proof.instRoot)
+	signedHash := common.Keccak256(blkHash[:])
+	for i, sig := range proof.sigs {
+		idx := proof.sigIdxs[i]
+		if idx < 0 || idx >= len(committee.Signers) {
+			return NewBridgeVerifierError(SignatureError, errors.Errorf("signer %d is not in the committee", idx))
+		}
+		if i > 0 && idx <= proof.sigIdxs[i-1] {
+			return NewBridgeVerifierError(SignatureError, errors.New("signer indexes are not increasing"))
+		}
+		pk, err := crypto.SigToPub(signedHash[:], sig)
+		if err != nil {
+			return NewBridgeVerifierError(SignatureError, err)
+		}
+		if crypto.PubkeyToAddress(*pk) != committee.Signers[idx] {
+			return NewBridgeVerifierError(SignatureError, errors.Errorf("signature %d is not of signer %d", i, idx))
+		}
+	}
+	return nil
+}
+
+func decodeInstruction(proof *jsonresult.GetInstructionProof) ([]byte, error) {
+	inst, err := hex.DecodeString(proof.Instruction)
+	if err != nil || len(inst) == 0 {
+		return nil, NewBridgeVerifierError(DecodeProofError, errors.Errorf("instruction %s is invalid", proof.Instruction))
+	}
+	return inst, nil
+}
+
+func decodeHeight(heightStr string) ([]byte, uint64, error) {
+	height, err := hex.DecodeString(heightStr)
+	if err != nil || len(height) != common.HashSize {
+		return nil, 0, NewBridgeVerifierError(DecodeProofError, errors.Errorf("height %s is invalid", heightStr))
+	}
+	h := big.NewInt(0).SetBytes(height)
+	if !h.IsUint64() {
+		return nil, 0, NewBridgeVerifierError(DecodeProofError, errors.Errorf("height %s is too big", heightStr))
+	}
+	return height, h.Uint64(), nil
+}
+
+// VerifyBurnProof verifies a proof returned by getburnproof against the trusted committees,
+// the instruction is hashed along with the height of each block as the contracts do
+func (v *Verifier) VerifyBurnProof(proof *jsonresult.GetInstructionProof) error {
+	inst, err := decodeInstruction(proof)
+	if err != nil {
+		return err
+	}
+	beaconHeightBytes, beaconHeight, err := decodeHeight(proof.BeaconHeight)
+	if err != nil {
+		return err
+	}
+	bridgeHeightBytes, bridgeHeight, err := decodeHeight(proof.BridgeHeight)
+	if err != nil {
+		return err
+	}
+
+	beaconProof, err := newBeaconProof(proof)
+	if err != nil {
+		return err
+	}
+	beaconCommittee, err := v.GetBeaconCommittee(beaconHeight)
+	if err != nil {
+		return err
+	}
+	beaconInstHash := common.Keccak256(inst, beaconHeightBytes)
+	if err := beaconProof.verify(beaconInstHash[:], beaconCommittee); err != nil {
+		return errors.Wrap(err, "beacon proof")
+	}
+
+	bridgeProof, err := newBridgeProof(proof)
+	if err != nil {
+		return err
+	}
+	bridgeCommittee, err := v.GetBridgeCommittee(bridgeHeight)
+	if err != nil {
+		return err
+	}
+	bridgeInstHash := common.Keccak256(inst, bridgeHeightBytes)
+	if err := bridgeProof.verify(bridgeInstHash[:], bridgeCommittee); err != nil {
+		return errors.Wrap(err, "bridge proof")
+	}
+	return nil
+}
+
+// parseSwapConfirmInst parses a decoded BeaconSwapConfirm or BridgeSwapConfirm instruction into the new committee
+func parseSwapConfirmInst(inst []byte, metaType int) (*Committee, error) {
+	if len(inst) < swapInstHeaderSize || inst[0] != byte(metaType) {
+		return nil, NewBridgeVerifierError(SwapInstructionError, errors.Errorf("instruction is not of meta type %s", strconv.Itoa(metaType)))
+	}
+	startHeight := big.NewInt(0).SetBytes(inst[2:34])
+	numSigners := big.NewInt(0).SetBytes(inst[34:66])
+	if !startHeight.IsUint64() || !numSigners.IsInt64() || uint64(len(inst)-swapInstHeaderSize) != numSigners.Uint64()*common.HashSize {
+		return nil, NewBridgeVerifierError(SwapInstructionError, errors.New("invalid length of swap instruction"))
+	}
+	committee := &Committee{StartHeight: startHeight.Uint64()}
+	for i := swapInstHeaderSize; i < len(inst); i += common.HashSize {
+		committee.Signers = append(committee.Signers, rCommon.BytesToAddress(inst[i:i+common.HashSize]))
+	}
+	return committee, nil
+}
+
+func latestCommittee(committees []*Committee) *Committee {
+	return committees[len(committees)-1]
+}
+
+// ApplyBeaconSwapProof verifies a proof returned by getbeaconswapproof against the latest beacon committee
+// and trusts the new beacon committee from its start height
+func (v *Verifier) ApplyBeaconSwapProof(proof *jsonresult.GetInstructionProof) (*Committee, error) {
+	inst, err := decodeInstruction(proof)
+	if err != nil {
+		return nil, err
+	}
+	committee, err := parseSwapConfirmInst(inst, metadata.BeaconSwapConfirmMeta)
+	if err != nil {
+		return nil, err
+	}
+	latest := latestCommittee(v.beaconCommittees)
+	if committee.StartHeight <= latest.StartHeight {
+		return nil, NewBridgeVerifierError(SwapInstructionError, errors.Errorf("beacon committee from %d is not after the latest one from %d", committee.StartHeight, latest.StartHeight))
+	}
+
+	beaconProof, err := newBeaconProof(proof)
+	if err != nil {
+		return nil, err
+	}
+	instHash := common.Keccak256(inst)
+	if err := beaconProof.verify(instHash[:], latest); err != nil {
+		return nil, errors.Wrap(err, "beacon proof")
+	}
+	v.beaconCommittees = append(v.beaconCommittees, committee)
+	return committee, nil
+}
+
+// ApplyBridgeSwapProof verifies a proof returned by getbridgeswapproof against the latest beacon and bridge committees
+// and trusts the new bridge committee from its start height
+func (v *Verifier) ApplyBridgeSwapProof(proof *jsonresult.GetInstructionProof) (*Committee, error) {
+	inst, err := decodeInstruction(proof)
+	if err != nil {
+		return nil, err
+	}
+	committee, err := parseSwapConfirmInst(inst, metadata.BridgeSwapConfirmMeta)
+	if err != nil {
+		return nil, err
+	}
+	latest := latestCommittee(v.bridgeCommittees)
+	if committee.StartHeight <= latest.StartHeight {
+		return nil, NewBridgeVerifierError(SwapInstructionError, errors.Errorf("bridge committee from %d is not after the latest one from %d", committee.StartHeight, latest.StartHeight))
+	}
+
+	instHash := common.Keccak256(inst)
+	beaconProof, err := newBeaconProof(proof)
+	if err != nil {
+		return nil, err
+	}
+	if err := beaconProof.verify(instHash[:], latestCommittee(v.beaconCommittees)); err != nil {
+		return nil, errors.Wrap(err, "beacon proof")
+	}
+	bridgeProof, err := newBridgeProof(proof)
+	if err != nil {
+		return nil, err
+	}
+	if err := bridgeProof.verify(instHash[:], latest); err != nil {
+		return nil, errors.Wrap(err, "bridge proof")
+	}
+	v.bridgeCommittees = append(v.bridgeCommittees, committee)
+	return committee, nil
+}
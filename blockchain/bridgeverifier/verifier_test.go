@@ -0,0 +1,292 @@
+package bridgeverifier
+
+import (
+	"encoding/hex"
+	"math/big"
+	"strconv"
+	"testing"
+
+	rCommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/incognitochain/incognito-chain/blockchain"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/common/base58"
+	"github.com/incognitochain/incognito-chain/consensus/signatureschemes/bridgesig"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/incognitochain/incognito-chain/rpcserver/jsonresult"
+	"github.com/stretchr/testify/assert"
+)
+
+func init() {
+	blockchain.BLogger.Init(common.NewBackend(nil).Logger("test", true))
+}
+
+type testCommittee struct {
+	seeds [][]byte
+	sks   [][]byte
+	addrs []rCommon.Address
+}
+
+func newTestCommittee(name string, size int) *testCommittee {
+	c := &testCommittee{}
+	for i := 0; i < size; i++ {
+		seed := common.HashB([]byte(name + strconv.Itoa(i)))
+		sk, pk := bridgesig.KeyGen(seed)
+		c.seeds = append(c.seeds, seed)
+		c.sks = append(c.sks, bridgesig.SKBytes(&sk))
+		c.addrs = append(c.addrs, crypto.PubkeyToAddress(pk))
+	}
+	return c
+}
+
+// quorum returns the fewest signers of the committee enough to sign a block
+func (c *testCommittee) quorum() []int {
+	signers := []int{}
+	for i := 0; i <= len(c.addrs)*2/3; i++ {
+		signers = append(signers, i)
+	}
+	return signers
+}
+
+func (c *testCommittee) committee(startHeight uint64) *Committee {
+	return &Committee{StartHeight: startHeight, Signers: c.addrs}
+}
+
+type testBlockProof struct {
+	path    []string
+	isLeft  []bool
+	root    string
+	blkData string
+	sigs    []string
+	sigIdxs []int
+}
+
+// signBlockWithInst builds a block of insts signed by signers of the committee and the proof of insts[id]
+func signBlockWithInst(t *testing.T, insts [][]string, id int, c *testCommittee, signers []int) *testBlockProof {
+	flattenInsts, err := blockchain.FlattenAndConvertStringInst(insts)
+	assert.Nil(t, err)
+	merkles := blockchain.BuildKeccak256MerkleTree(flattenInsts)
+	path, isLeft := blockchain.GetKeccak256MerkleProofFromTree(merkles, id)
+	root := blockchain.GetKeccak256MerkleRoot(flattenInsts)
+
+	proof := &testBlockProof{isLeft: isLeft, root: hex.EncodeToString(root)}
+	for _, node := range path {
+		proof.path = append(proof.path, hex.EncodeToString(node))
+	}
+	blkData := common.HashH([]byte("meta of block " + strconv.Itoa(len(insts))))
+	proof.blkData = hex.EncodeToString(blkData[:])
+	blkHash := common.Keccak256(blkData[:], root)
+	for _, idx := range signers {
+		sig, err := bridgesig.Sign(c.sks[idx], blkHash[:])
+		assert.Nil(t, err)
+		proof.sigs = append(proof.sigs, hex.EncodeToString(sig))
+		proof.sigIdxs = append(proof.sigIdxs, idx)
+	}
+	return proof
+}
+
+func burningConfirmInst(height int64) []string {
+	return []string{
+		strconv.Itoa(metadata.BurningConfirmMeta),
+		strconv.Itoa(common.BridgeShardID),
+		base58.Base58Check{}.Encode(rCommon.HexToAddress("0xaa").Bytes(), 0x00),
+		"00000000000000000000000000000000000000cc",
+		base58.Base58Check{}.Encode(big.NewInt(100).Bytes(), 0x00),
+		common.HashH([]byte("burn")).String(),
+		base58.Base58Check{}.Encode(common.HashB([]byte("token")), 0x00),
+		base58.Base58Check{}.Encode(big.NewInt(height).Bytes(), 0x00),
+	}
+}
+
+func otherInsts(n int) [][]string {
+	insts := [][]string{}
+	for i := 0; i < n; i++ {
+		insts = append(insts, []string{"37", strconv.Itoa(i), "other instruction"})
+	}
+	return insts
+}
+
+func newBurnProof(t *testing.T, beacon *testCommittee, beaconSigners []int, bridge *testCommittee, bridgeSigners []int, beaconHeight int64, bridgeHeight int64) *jsonresult.GetInstructionProof {
+	beaconInst := burningConfirmInst(beaconHeight)
+	bridgeInst := burningConfirmInst(bridgeHeight)
+	// odd number of instructions so the proof has an empty sibling
+	beaconInsts := append(otherInsts(4), beaconInst)
+	bridgeInsts := append(otherInsts(1), append([][]string{bridgeInst}, otherInsts(2)...)...)
+	beaconProof := signBlockWithInst(t, beaconInsts, 4, beacon, beaconSigners)
+	bridgeProof := signBlockWithInst(t, bridgeInsts, 1, bridge, bridgeSigners)
+
+	beaconFlat, err := blockchain.DecodeInstruction(beaconInst)
+	assert.Nil(t, err)
+	bridgeFlat, err := blockchain.DecodeInstruction(bridgeInst)
+	assert.Nil(t, err)
+	return &jsonresult.GetInstructionProof{
+		Instruction:          hex.EncodeToString(bridgeFlat[:len(bridgeFlat)-32]),
+		BeaconHeight:         hex.EncodeToString(beaconFlat[len(beaconFlat)-32:]),
+		BridgeHeight:         hex.EncodeToString(bridgeFlat[len(bridgeFlat)-32:]),
+		BeaconInstPath:       beaconProof.path,
+		BeaconInstPathIsLeft: beaconProof.isLeft,
+		BeaconInstRoot:       beaconProof.root,
+		BeaconBlkData:        beaconProof.blkData,
+		BeaconSigs:           beaconProof.sigs,
+		BeaconSigIdxs:        beaconProof.sigIdxs,
+		BridgeInstPath:       bridgeProof.path,
+		BridgeInstPathIsLeft: bridgeProof.isLeft,
+		BridgeInstRoot:       bridgeProof.root,
+		BridgeBlkData:        bridgeProof.blkData,
+		BridgeSigs:           bridgeProof.sigs,
+		BridgeSigIdxs:        bridgeProof.sigIdxs,
+	}
+}
+
+func TestVerifyInstructionPath(t *testing.T) {
+	for n := 1; n <= 9; n++ {
+		insts := otherInsts(n)
+		flattenInsts, err := blockchain.FlattenAndConvertStringInst(insts)
+		assert.Nil(t, err)
+		merkles := blockchain.BuildKeccak256MerkleTree(flattenInsts)
+		root := blockchain.GetKeccak256MerkleRoot(flattenInsts)
+		for id := range insts {
+			path, isLeft := blockchain.GetKeccak256MerkleProofFromTree(merkles, id)
+			for i := range path {
+				if path[i] == nil {
+					path[i] = make([]byte, common.HashSize)
+				}
+			}
+			instHash := common.Keccak256(flattenInsts[id])
+			assert.True(t, VerifyInstructionPath(instHash[:], path, isLeft, root), "%d of %d insts", id, n)
+			otherHash := common.Keccak256([]byte("not in tree"))
+			assert.False(t, VerifyInstructionPath(otherHash[:], path, isLeft, root))
+		}
+	}
+}
+
+func TestVerifyBurnProof(t *testing.T) {
+	beacon := newTestCommittee("beacon", 4)
+	bridge := newTestCommittee("bridge", 4)
+	v := NewVerifier(beacon.committee(1), bridge.committee(1))
+
+	proof := newBurnProof(t, beacon, []int{0, 1, 3}, bridge, []int{1, 2, 3}, 100, 50)
+	assert.Nil(t, v.VerifyBurnProof(proof))
+
+	// Not more than 2/3 of the committee
+	proof = newBurnProof(t, beacon, []int{0, 1}, bridge, []int{1, 2, 3}, 100, 50)
+	assert.NotNil(t, v.VerifyBurnProof(proof))
+
+	// Signed by the beacon committee instead of the bridge one
+	proof = newBurnProof(t, beacon, []int{0, 1, 2}, beacon, []int{0, 1, 2}, 100, 50)
+	assert.NotNil(t, v.VerifyBurnProof(proof))
+
+	// Duplicated signer
+	proof = newBurnProof(t, beacon, []int{0, 1, 2}, bridge, []int{1, 2, 3}, 100, 50)
+	proof.BeaconSigs[2] = proof.BeaconSigs[1]
+	proof.BeaconSigIdxs[2] = proof.BeaconSigIdxs[1]
+	assert.NotNil(t, v.VerifyBurnProof(proof))
+
+	// Instruction is changed
+	proof = newBurnProof(t, beacon, []int{0, 1, 2}, bridge, []int{1, 2, 3}, 100, 50)
+	proof.Instruction = proof.Instruction[:len(proof.Instruction)-2] + "ff"
+	assert.NotNil(t, v.VerifyBurnProof(proof))
+
+	// Height is changed
+	proof = newBurnProof(t, beacon, []int{0, 1, 2}, bridge, []int{1, 2, 3}, 100, 50)
+	proof.BridgeHeight = proof.BeaconHeight
+	assert.NotNil(t, v.VerifyBurnProof(proof))
+
+	// No committee before the trusted one
+	proof = newBurnProof(t, beacon, []int{0, 1, 2}, bridge, []int{1, 2, 3}, 100, 0)
+	assert.NotNil(t, v.VerifyBurnProof(proof))
+}
+
+func swapConfirmInst(metaType int, startHeight int64, c *testCommittee) []string {
+	addrs := []byte{}
+	for _, addr := range c.addrs {
+		addrs = append(addrs, addr.Bytes()...)
+	}
+	return []string{
+		strconv.Itoa(metaType),
+		strconv.Itoa(common.BridgeShardID),
+		base58.Base58Check{}.Encode(big.NewInt(startHeight).Bytes(), 0x00),
+		base58.Base58Check{}.Encode(big.NewInt(int64(len(c.addrs))).Bytes(), 0x00),
+		base58.Base58Check{}.Encode(addrs, 0x00),
+	}
+}
+
+func newSwapProof(t *testing.T, inst []string, beacon *testCommittee, bridge *testCommittee) *jsonresult.GetInstructionProof {
+	insts := append(otherInsts(2), inst)
+	beaconProof := signBlockWithInst(t, insts, 2, beacon, beacon.quorum())
+	flat, err := blockchain.DecodeInstruction(inst)
+	assert.Nil(t, err)
+	proof := &jsonresult.GetInstructionProof{
+		Instruction:          hex.EncodeToString(flat),
+		BeaconInstPath:       beaconProof.path,
+		BeaconInstPathIsLeft: beaconProof.isLeft,
+		BeaconInstRoot:       beaconProof.root,
+		BeaconBlkData:        beaconProof.blkData,
+		BeaconSigs:           beaconProof.sigs,
+		BeaconSigIdxs:        beaconProof.sigIdxs,
+	}
+	if bridge != nil {
+		bridgeProof := signBlockWithInst(t, [][]string{inst}, 0, bridge, bridge.quorum())
+		proof.BridgeInstPath = bridgeProof.path
+		proof.BridgeInstPathIsLeft = bridgeProof.isLeft
+		proof.BridgeInstRoot = bridgeProof.root
+		proof.BridgeBlkData = bridgeProof.blkData
+		proof.BridgeSigs = bridgeProof.sigs
+		proof.BridgeSigIdxs = bridgeProof.sigIdxs
+	}
+	return proof
+}
+
+func TestApplySwapProofs(t *testing.T) {
+	beacon := newTestCommittee("beacon", 4)
+	bridge := newTestCommittee("bridge", 4)
+	newBeacon := newTestCommittee("new beacon", 5)
+	newBridge := newTestCommittee("new bridge", 3)
+	v := NewVerifier(beacon.committee(1), bridge.committee(1))
+
+	// A bridge swap is not a beacon swap
+	bridgeSwapInst := swapConfirmInst(metadata.BridgeSwapConfirmMeta, 60, newBridge)
+	_, err := v.ApplyBeaconSwapProof(newSwapProof(t, bridgeSwapInst, beacon, nil))
+	assert.NotNil(t, err)
+
+	// Swaps signed by an untrusted committee
+	beaconSwapInst := swapConfirmInst(metadata.BeaconSwapConfirmMeta, 90, newBeacon)
+	_, err = v.ApplyBeaconSwapProof(newSwapProof(t, beaconSwapInst, newBeacon, nil))
+	assert.NotNil(t, err)
+	_, err = v.ApplyBridgeSwapProof(newSwapProof(t, bridgeSwapInst, beacon, newBridge))
+	assert.NotNil(t, err)
+
+	committee, err := v.ApplyBeaconSwapProof(newSwapProof(t, beaconSwapInst, beacon, nil))
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(90), committee.StartHeight)
+	assert.Equal(t, newBeacon.addrs, committee.Signers)
+	committee, err = v.ApplyBridgeSwapProof(newSwapProof(t, bridgeSwapInst, newBeacon, bridge))
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(60), committee.StartHeight)
+
+	// Replaying an older swap is rejected
+	_, err = v.ApplyBeaconSwapProof(newSwapProof(t, swapConfirmInst(metadata.BeaconSwapConfirmMeta, 80, beacon), newBeacon, nil))
+	assert.NotNil(t, err)
+
+	// Blocks before the swaps are still signed by the old committees
+	assert.Nil(t, v.VerifyBurnProof(newBurnProof(t, beacon, []int{0, 1, 2}, bridge, []int{0, 1, 2}, 89, 59)))
+	assert.NotNil(t, v.VerifyBurnProof(newBurnProof(t, beacon, []int{0, 1, 2}, bridge, []int{0, 1, 2}, 90, 59)))
+	assert.Nil(t, v.VerifyBurnProof(newBurnProof(t, newBeacon, []int{0, 1, 3, 4}, newBridge, []int{0, 1, 2}, 90, 60)))
+}
+
+func TestNewCommitteeFromKeys(t *testing.T) {
+	c := newTestCommittee("beacon", 3)
+	keys := []string{}
+	for _, seed := range c.seeds {
+		key, err := incognitokey.NewCommitteeKeyFromSeed(seed, common.HashB(seed))
+		assert.Nil(t, err)
+		keyStr, err := key.ToBase58()
+		assert.Nil(t, err)
+		keys = append(keys, keyStr)
+	}
+	committee, err := NewCommitteeFromKeys(10, keys)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(10), committee.StartHeight)
+	assert.Equal(t, c.addrs, committee.Signers)
+}
@@ -23,6 +23,11 @@ const (
 	GetBlockHeaderResultError
 	ParseNonceResultError
 	ParseTimestampResultError
+	ParseBTCHeaderError
+	ParseBTCTxError
+	MerkleProofError
+	ProofOfWorkError
+	DepositOutputError
 )
 
 var ErrCodeMessage = map[int]struct {
@@ -41,6 +46,11 @@ var ErrCodeMessage = map[int]struct {
 	GetBlockHeaderResultError: {-10, "Get Block Header Result Error"},
 	ParseNonceResultError:     {-11, "Parse Nonce Result Error"},
 	ParseTimestampResultError: {-12, "Parse Timestamp Result Error"},
+	ParseBTCHeaderError:       {-13, "Parse BTC Header Error"},
+	ParseBTCTxError:           {-14, "Parse BTC Tx Error"},
+	MerkleProofError:          {-15, "Merkle Proof Error"},
+	ProofOfWorkError:          {-16, "Proof Of Work Error"},
+	DepositOutputError:        {-17, "Deposit Output Error"},
 }
 
 type BTCAPIError struct {
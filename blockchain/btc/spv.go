@@ -0,0 +1,264 @@
+package btc
+
+import (
+	"bytes"
+	"encoding/hex"
+	"math/big"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/pkg/errors"
+)
+
+// Simplified payment verification of bitcoin headers and transactions, it is used by the trustless bitcoin bridge:
+// headers are relayed to beacon and deposits are proved by a merkle path to a relayed header
+
+const (
+	BTCHeaderSize = 80
+	// a transaction of 64 bytes could be mistaken for an inner node of the merkle tree
+	BTCAmbiguousTxSize = 64
+	// deposit commitment is OP_RETURN followed by a push of 32 bytes
+	BTCDepositCommitmentSize = 32
+	btcOpReturn              = 0x6a
+)
+
+var bigOne = big.NewInt(1)
+
+// ParseBTCHeader decodes a header in hex of its 80 bytes serialization
+func ParseBTCHeader(headerStr string) (*wire.BlockHeader, error) {
+	headerBytes, err := hex.DecodeString(headerStr)
+	if err != nil {
+		return nil, NewBTCAPIError(ParseBTCHeaderError, err)
+	}
+	if len(headerBytes) != BTCHeaderSize {
+		return nil, NewBTCAPIError(ParseBTCHeaderError, errors.Errorf("header must have %d bytes, got %d", BTCHeaderSize, len(headerBytes)))
+	}
+	header := &wire.BlockHeader{}
+	err = header.Deserialize(bytes.NewReader(headerBytes))
+	if err != nil {
+		return nil, NewBTCAPIError(ParseBTCHeaderError, err)
+	}
+	return header, nil
+}
+
+// SerializeBTCHeader returns 80 bytes serialization of header
+func SerializeBTCHeader(header *wire.BlockHeader) ([]byte, error) {
+	var buf bytes.Buffer
+	err := header.Serialize(&buf)
+	if err != nil {
+		return nil, NewBTCAPIError(ParseBTCHeaderError, err)
+	}
+	return buf.Bytes(), nil
+}
+
+// ParseBTCTx decodes a raw transaction in hex, transactions with witness are accepted
+func ParseBTCTx(txStr string) (*wire.MsgTx, error) {
+	txBytes, err := hex.DecodeString(txStr)
+	if err != nil {
+		return nil, NewBTCAPIError(ParseBTCTxError, err)
+	}
+	tx := &wire.MsgTx{}
+	err = tx.Deserialize(bytes.NewReader(txBytes))
+	if err != nil {
+		return nil, NewBTCAPIError(ParseBTCTxError, err)
+	}
+	if tx.SerializeSizeStripped() == BTCAmbiguousTxSize {
+		return nil, NewBTCAPIError(ParseBTCTxError, errors.New("transaction of 64 bytes is not accepted"))
+	}
+	return tx, nil
+}
+
+// ParseMerkleProof decodes hashes of a merkle path, they are in the byte-reversed hex like block and tx hashes
+func ParseMerkleProof(proofStrs []string) ([]chainhash.Hash, error) {
+	proof := []chainhash.Hash{}
+	for _, proofStr := range proofStrs {
+		hash, err := chainhash.NewHashFromStr(proofStr)
+		if err != nil {
+			return nil, NewBTCAPIError(MerkleProofError, err)
+		}
+		proof = append(proof, *hash)
+	}
+	return proof, nil
+}
+
+func hashMerkleBranches(left *chainhash.Hash, right *chainhash.Hash) chainhash.Hash {
+	var buf [chainhash.HashSize * 2]byte
+	copy(buf[:chainhash.HashSize], left[:])
+	copy(buf[chainhash.HashSize:], right[:])
+	return chainhash.DoubleHashH(buf[:])
+}
+
+// CalcMerkleRootFromProof hashes txHash up the tree with siblings of proof from the bottom,
+// txIndex is the position of tx in its block and decides the side of each sibling
+func CalcMerkleRootFromProof(txHash chainhash.Hash, txIndex uint, proof []chainhash.Hash) chainhash.Hash {
+	root := txHash
+	for _, sibling := range proof {
+		if txIndex&1 == 0 {
+			root = hashMerkleBranches(&root, &sibling)
+		} else {
+			root = hashMerkleBranches(&sibling, &root)
+		}
+		txIndex >>= 1
+	}
+	return root
+}
+
+// VerifyMerkleProof returns nil if tx at txIndex is committed in merkleRoot by proof
+func VerifyMerkleProof(merkleRoot chainhash.Hash, txHash chainhash.Hash, txIndex uint, proof []chainhash.Hash) error {
+	if txIndex>>uint(len(proof)) != 0 {
+		return NewBTCAPIError(MerkleProofError, errors.Errorf("tx index %d is out of a merkle tree of depth %d", txIndex, len(proof)))
+	}
+	root := CalcMerkleRootFromProof(txHash, txIndex, proof)
+	if !root.IsEqual(&merkleRoot) {
+		return NewBTCAPIError(MerkleProofError, errors.Errorf("merkle root %s is different from %s", root.String(), merkleRoot.String()))
+	}
+	return nil
+}
+
+// CompactToBig converts the compact representation of a target in header bits to a big integer
+func CompactToBig(compact uint32) *big.Int {
+	mantissa := compact & 0x007fffff
+	isNegative := compact&0x00800000 != 0
+	exponent := uint(compact >> 24)
+
+	var bn *big.Int
+	if exponent <= 3 {
+		mantissa >>= 8 * (3 - exponent)
+		bn = big.NewInt(int64(mantissa))
+	} else {
+		bn = big.NewInt(int64(mantissa))
+		bn.Lsh(bn, 8*(exponent-3))
+	}
+	if isNegative {
+		bn = bn.Neg(bn)
+	}
+	return bn
+}
+
+// BigToCompact converts a target to its compact representation in header bits
+func BigToCompact(n *big.Int) uint32 {
+	if n.Sign() == 0 {
+		return 0
+	}
+	var mantissa uint32
+	exponent := uint(len(n.Bytes()))
+	if exponent <= 3 {
+		mantissa = uint32(n.Bits()[0])
+		mantissa <<= 8 * (3 - exponent)
+	} else {
+		tn := new(big.Int).Set(n)
+		mantissa = uint32(tn.Rsh(tn, 8*(exponent-3)).Bits()[0])
+	}
+	if mantissa&0x00800000 != 0 {
+		mantissa >>= 8
+		exponent++
+	}
+	compact := uint32(exponent<<24) | mantissa
+	if n.Sign() < 0 {
+		compact |= 0x00800000
+	}
+	return compact
+}
+
+// CalcWork returns the expected number of hashes to find a header with bits, it is 2^256 / (target + 1)
+func CalcWork(bits uint32) *big.Int {
+	target := CompactToBig(bits)
+	if target.Sign() <= 0 {
+		return big.NewInt(0)
+	}
+	denominator := new(big.Int).Add(target, bigOne)
+	return new(big.Int).Div(new(big.Int).Lsh(bigOne, 256), denominator)
+}
+
+// CheckProofOfWork returns nil if hash of header does not exceed the target of its bits,
+// the target itself must be in (0, powLimit]
+func CheckProofOfWork(header *wire.BlockHeader, powLimit *big.Int) error {
+	target := CompactToBig(header.Bits)
+	if target.Sign() <= 0 {
+		return NewBTCAPIError(ProofOfWorkError, errors.Errorf("target %064x is not positive", target))
+	}
+	if powLimit != nil && target.Cmp(powLimit) > 0 {
+		return NewBTCAPIError(ProofOfWorkError, errors.Errorf("target %064x is higher than the limit %064x", target, powLimit))
+	}
+	hash := header.BlockHash()
+	// hash is a little endian number
+	hashBytes := hash.CloneBytes()
+	for i, j := 0, len(hashBytes)-1; i < j; i, j = i+1, j-1 {
+		hashBytes[i], hashBytes[j] = hashBytes[j], hashBytes[i]
+	}
+	if new(big.Int).SetBytes(hashBytes).Cmp(target) > 0 {
+		return NewBTCAPIError(ProofOfWorkError, errors.Errorf("hash %s is higher than the target %064x", hash.String(), target))
+	}
+	return nil
+}
+
+/*
+CalcNextRequiredBits returns bits required for the header after parent by the difficulty rules of params:
+  - bits are retargeted every TargetTimespan by the time taken by the interval of parent, started at intervalStartTime
+  - otherwise bits of parent are kept, on networks reducing min difficulty a header found after MinDiffReductionTime
+    may use the limit and others use lastNonMinBits, which are bits of the last header in the interval not using the limit
+*/
+func CalcNextRequiredBits(
+	params *chaincfg.Params,
+	parentHeight uint64,
+	parentBits uint32,
+	parentTime int64,
+	intervalStartTime int64,
+	lastNonMinBits uint32,
+	newTime int64,
+) uint32 {
+	blocksPerRetarget := uint64(params.TargetTimespan / params.TargetTimePerBlock)
+	if (parentHeight+1)%blocksPerRetarget != 0 {
+		if params.ReduceMinDifficulty {
+			if newTime > parentTime+int64(params.MinDiffReductionTime.Seconds()) {
+				return params.PowLimitBits
+			}
+			return lastNonMinBits
+		}
+		return parentBits
+	}
+
+	targetTimespan := int64(params.TargetTimespan.Seconds())
+	minTimespan := targetTimespan / params.RetargetAdjustmentFactor
+	maxTimespan := targetTimespan * params.RetargetAdjustmentFactor
+	actualTimespan := parentTime - intervalStartTime
+	if actualTimespan < minTimespan {
+		actualTimespan = minTimespan
+	} else if actualTimespan > maxTimespan {
+		actualTimespan = maxTimespan
+	}
+	newTarget := CompactToBig(parentBits)
+	newTarget.Mul(newTarget, big.NewInt(actualTimespan))
+	newTarget.Div(newTarget, big.NewInt(targetTimespan))
+	if newTarget.Cmp(params.PowLimit) > 0 {
+		newTarget.Set(params.PowLimit)
+	}
+	return BigToCompact(newTarget)
+}
+
+// GetBTCDepositCommitment returns the data of the first OP_RETURN output of tx which pushes exactly 32 bytes
+func GetBTCDepositCommitment(tx *wire.MsgTx) ([]byte, bool) {
+	for _, txOut := range tx.TxOut {
+		script := txOut.PkScript
+		if len(script) == BTCDepositCommitmentSize+2 && script[0] == btcOpReturn && script[1] == BTCDepositCommitmentSize {
+			return script[2:], true
+		}
+	}
+	return nil, false
+}
+
+// GetBTCDepositAmount returns the amount in satoshi of output at outputIndex of tx, the output must pay to depositScript
+func GetBTCDepositAmount(tx *wire.MsgTx, outputIndex uint32, depositScript []byte) (uint64, error) {
+	if int(outputIndex) >= len(tx.TxOut) {
+		return 0, NewBTCAPIError(DepositOutputError, errors.Errorf("output %d is not found in tx %s", outputIndex, tx.TxHash().String()))
+	}
+	txOut := tx.TxOut[outputIndex]
+	if len(depositScript) == 0 || !bytes.Equal(txOut.PkScript, depositScript) {
+		return 0, NewBTCAPIError(DepositOutputError, errors.Errorf("output %d of tx %s does not pay to the deposit script", outputIndex, tx.TxHash().String()))
+	}
+	if txOut.Value <= 0 {
+		return 0, NewBTCAPIError(DepositOutputError, errors.Errorf("output %d of tx %s has no value", outputIndex, tx.TxHash().String()))
+	}
+	return uint64(txOut.Value), nil
+}
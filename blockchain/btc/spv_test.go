@@ -0,0 +1,255 @@
+package btc
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+)
+
+// headers of blocks 1 and 2 of bitcoin mainnet
+const (
+	mainnetBlock1HeaderStr = "010000006fe28c0ab6f1b372c1a6a246ae63f74f931e8365e15a089c68d6190000000000982051fd1e4ba744bbbe680e1fee14677ba1a3c3540bf7b1cdb606e857233e0e61bc6649ffff001d01e36299"
+	mainnetBlock1HashStr   = "00000000839a8e6886ab5951d76f411475428afc90947ee320161bbf18eb6048"
+	mainnetBlock2HeaderStr = "010000004860eb18bf1b1620e37e9490fc8a427514416fd75159ab86688e9a8300000000d5fdcc541e25de1c7a5addedf24858b8bb665c9f36ef744ee42c316022c90f9bb0bc6649ffff001d08d2bd61"
+)
+
+func TestParseBTCHeader(t *testing.T) {
+	header, err := ParseBTCHeader(mainnetBlock1HeaderStr)
+	if err != nil {
+		t.Fatalf("Failed to parse header: %v", err)
+	}
+	if header.BlockHash().String() != mainnetBlock1HashStr {
+		t.Errorf("Wrong block hash %s", header.BlockHash().String())
+	}
+	if !header.PrevBlock.IsEqual(chaincfg.MainNetParams.GenesisHash) {
+		t.Error("Block 1 must be a child of the genesis block")
+	}
+	headerBytes, err := SerializeBTCHeader(header)
+	if err != nil || hex.EncodeToString(headerBytes) != mainnetBlock1HeaderStr {
+		t.Error("Serialization of header must be the relayed one")
+	}
+
+	header2, err := ParseBTCHeader(mainnetBlock2HeaderStr)
+	if err != nil {
+		t.Fatalf("Failed to parse header: %v", err)
+	}
+	if header2.PrevBlock.String() != mainnetBlock1HashStr {
+		t.Error("Block 2 must be a child of block 1")
+	}
+
+	if _, err := ParseBTCHeader(mainnetBlock1HeaderStr[:158]); err == nil {
+		t.Error("Header of 79 bytes must be rejected")
+	}
+	if _, err := ParseBTCHeader("zz" + mainnetBlock1HeaderStr[2:]); err == nil {
+		t.Error("Header which is not hex must be rejected")
+	}
+}
+
+func TestCheckProofOfWork(t *testing.T) {
+	powLimit := chaincfg.MainNetParams.PowLimit
+	genesisHeader := chaincfg.MainNetParams.GenesisBlock.Header
+	if err := CheckProofOfWork(&genesisHeader, powLimit); err != nil {
+		t.Errorf("Genesis header must have valid proof of work: %v", err)
+	}
+	header, _ := ParseBTCHeader(mainnetBlock1HeaderStr)
+	if err := CheckProofOfWork(header, powLimit); err != nil {
+		t.Errorf("Block 1 must have valid proof of work: %v", err)
+	}
+
+	header.Nonce++
+	if err := CheckProofOfWork(header, powLimit); err == nil {
+		t.Error("Header with a wrong nonce must be rejected")
+	}
+
+	header.Nonce--
+	header.Bits = 0x1e00ffff
+	if err := CheckProofOfWork(header, powLimit); err == nil {
+		t.Error("Target higher than the limit must be rejected")
+	}
+}
+
+func TestCompactConversion(t *testing.T) {
+	for _, bits := range []uint32{0x1d00ffff, 0x1b0404cb, 0x170d21b9, 0x207fffff} {
+		if BigToCompact(CompactToBig(bits)) != bits {
+			t.Errorf("Wrong conversion of bits %08x", bits)
+		}
+	}
+	if BigToCompact(chaincfg.MainNetParams.PowLimit) != chaincfg.MainNetParams.PowLimitBits {
+		t.Error("Limit must be converted to bits of the limit")
+	}
+	if CalcWork(0x1d00ffff).Uint64() != 0x100010001 {
+		t.Errorf("Wrong work %s of the min difficulty", CalcWork(0x1d00ffff).String())
+	}
+}
+
+func TestCalcNextRequiredBits(t *testing.T) {
+	params := &chaincfg.MainNetParams
+	parentBits := uint32(0x1b0404cb)
+	targetTimespan := int64(params.TargetTimespan.Seconds())
+	intervalStartTime := int64(1500000000)
+
+	// bits are kept in an interval
+	bits := CalcNextRequiredBits(params, 2014, parentBits, intervalStartTime+600, intervalStartTime, parentBits, intervalStartTime+1200)
+	if bits != parentBits {
+		t.Errorf("Bits must be kept in an interval, got %08x", bits)
+	}
+
+	// interval taking the target timespan keeps the difficulty
+	bits = CalcNextRequiredBits(params, 2015, parentBits, intervalStartTime+targetTimespan, intervalStartTime, parentBits, intervalStartTime+targetTimespan+600)
+	if bits != parentBits {
+		t.Errorf("Difficulty must be kept, got %08x", bits)
+	}
+
+	// interval taking twice the target timespan halves the difficulty
+	bits = CalcNextRequiredBits(params, 2015, parentBits, intervalStartTime+2*targetTimespan, intervalStartTime, parentBits, intervalStartTime+2*targetTimespan+600)
+	expected := BigToCompact(CompactToBig(parentBits).Lsh(CompactToBig(parentBits), 1))
+	if bits != expected {
+		t.Errorf("Difficulty must be halved, got %08x, expected %08x", bits, expected)
+	}
+
+	// adjustment is clamped to a factor of 4
+	fastBits := CalcNextRequiredBits(params, 4031, parentBits, intervalStartTime+1, intervalStartTime, parentBits, intervalStartTime+600)
+	clampedBits := CalcNextRequiredBits(params, 4031, parentBits, intervalStartTime+targetTimespan/4, intervalStartTime, parentBits, intervalStartTime+600)
+	if fastBits != clampedBits {
+		t.Errorf("Adjustment must be clamped, got %08x and %08x", fastBits, clampedBits)
+	}
+
+	// target never exceeds the limit
+	bits = CalcNextRequiredBits(params, 2015, params.PowLimitBits, intervalStartTime+10*targetTimespan, intervalStartTime, params.PowLimitBits, intervalStartTime+10*targetTimespan)
+	if bits != params.PowLimitBits {
+		t.Errorf("Target must not exceed the limit, got %08x", bits)
+	}
+
+	// test networks allow the min difficulty after 20 minutes
+	testParams := &chaincfg.TestNet3Params
+	parentTime := intervalStartTime + 6000
+	bits = CalcNextRequiredBits(testParams, 2014, parentBits, parentTime, intervalStartTime, parentBits, parentTime+int64((21*time.Minute).Seconds()))
+	if bits != testParams.PowLimitBits {
+		t.Errorf("Min difficulty must be allowed, got %08x", bits)
+	}
+	bits = CalcNextRequiredBits(testParams, 2014, testParams.PowLimitBits, parentTime, intervalStartTime, parentBits, parentTime+600)
+	if bits != parentBits {
+		t.Errorf("Bits of the last header not using the min difficulty must be used, got %08x", bits)
+	}
+}
+
+// buildMerkleTree returns levels of the merkle tree of leaves from the bottom, an odd node is paired with itself
+func buildMerkleTree(leaves []chainhash.Hash) [][]chainhash.Hash {
+	levels := [][]chainhash.Hash{leaves}
+	for len(levels[len(levels)-1]) > 1 {
+		level := levels[len(levels)-1]
+		nextLevel := []chainhash.Hash{}
+		for i := 0; i < len(level); i += 2 {
+			right := level[i]
+			if i+1 < len(level) {
+				right = level[i+1]
+			}
+			nextLevel = append(nextLevel, hashMerkleBranches(&level[i], &right))
+		}
+		levels = append(levels, nextLevel)
+	}
+	return levels
+}
+
+func buildMerkleProof(levels [][]chainhash.Hash, index int) []chainhash.Hash {
+	proof := []chainhash.Hash{}
+	for _, level := range levels[:len(levels)-1] {
+		sibling := index ^ 1
+		if sibling >= len(level) {
+			sibling = index
+		}
+		proof = append(proof, level[sibling])
+		index >>= 1
+	}
+	return proof
+}
+
+func TestVerifyMerkleProof(t *testing.T) {
+	leaves := []chainhash.Hash{}
+	for i := 0; i < 5; i++ {
+		leaves = append(leaves, chainhash.DoubleHashH([]byte{byte(i)}))
+	}
+	levels := buildMerkleTree(leaves)
+	root := levels[len(levels)-1][0]
+	for i, leaf := range leaves {
+		proof := buildMerkleProof(levels, i)
+		if err := VerifyMerkleProof(root, leaf, uint(i), proof); err != nil {
+			t.Errorf("Proof of tx %d must be valid: %v", i, err)
+		}
+		if err := VerifyMerkleProof(root, leaf, uint(i^1), proof); err == nil && i != 4 {
+			t.Errorf("Proof of tx %d must be rejected at another index", i)
+		}
+		if err := VerifyMerkleProof(root, leaf, uint(i+8), proof); err == nil {
+			t.Errorf("Index out of the tree must be rejected")
+		}
+	}
+	if err := VerifyMerkleProof(root, leaves[0], 0, buildMerkleProof(levels, 1)); err == nil {
+		t.Error("Proof of another tx must be rejected")
+	}
+
+	// block with only the coinbase tx
+	genesisBlock := chaincfg.MainNetParams.GenesisBlock
+	if err := VerifyMerkleProof(genesisBlock.Header.MerkleRoot, genesisBlock.Transactions[0].TxHash(), 0, []chainhash.Hash{}); err != nil {
+		t.Errorf("Coinbase tx of genesis block must be proved: %v", err)
+	}
+
+	proofStrs := []string{leaves[1].String(), leaves[2].String()}
+	proof, err := ParseMerkleProof(proofStrs)
+	if err != nil || !proof[0].IsEqual(&leaves[1]) || !proof[1].IsEqual(&leaves[2]) {
+		t.Error("Wrong parsing of merkle proof")
+	}
+}
+
+func buildDepositTx(depositScript []byte, commitment []byte) *wire.MsgTx {
+	tx := wire.NewMsgTx(wire.TxVersion)
+	tx.AddTxIn(wire.NewTxIn(wire.NewOutPoint(&chainhash.Hash{1}, 0), []byte{0x51}, nil))
+	tx.AddTxOut(wire.NewTxOut(1000, []byte{0x76, 0xa9}))
+	tx.AddTxOut(wire.NewTxOut(0, append([]byte{btcOpReturn, BTCDepositCommitmentSize}, commitment...)))
+	tx.AddTxOut(wire.NewTxOut(150000, depositScript))
+	return tx
+}
+
+func TestBTCDeposit(t *testing.T) {
+	depositScript := []byte{0x00, 0x14, 0x01, 0x02, 0x03}
+	commitment := chainhash.DoubleHashB([]byte("receiver"))
+	tx := buildDepositTx(depositScript, commitment)
+
+	var buf bytes.Buffer
+	if err := tx.Serialize(&buf); err != nil {
+		t.Fatal(err)
+	}
+	parsedTx, err := ParseBTCTx(hex.EncodeToString(buf.Bytes()))
+	if err != nil {
+		t.Fatalf("Failed to parse tx: %v", err)
+	}
+	if parsedTx.TxHash() != tx.TxHash() {
+		t.Error("Wrong hash of parsed tx")
+	}
+
+	foundCommitment, found := GetBTCDepositCommitment(parsedTx)
+	if !found || !bytes.Equal(foundCommitment, commitment) {
+		t.Error("Commitment of deposit must be found")
+	}
+	if _, found := GetBTCDepositCommitment(buildDepositTx(depositScript, commitment[:20])); found {
+		t.Error("Commitment must have 32 bytes")
+	}
+
+	amount, err := GetBTCDepositAmount(parsedTx, 2, depositScript)
+	if err != nil || amount != 150000 {
+		t.Errorf("Wrong deposit amount %d: %v", amount, err)
+	}
+	if _, err := GetBTCDepositAmount(parsedTx, 0, depositScript); err == nil {
+		t.Error("Output not paying to the deposit script must be rejected")
+	}
+	if _, err := GetBTCDepositAmount(parsedTx, 3, depositScript); err == nil {
+		t.Error("Output out of tx must be rejected")
+	}
+	if _, err := GetBTCDepositAmount(parsedTx, 2, []byte{}); err == nil {
+		t.Error("Empty deposit script must be rejected")
+	}
+}
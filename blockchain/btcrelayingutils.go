@@ -0,0 +1,420 @@
+package blockchain
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/incognitochain/incognito-chain/blockchain/btc"
+	"github.com/incognitochain/incognito-chain/database"
+	"github.com/incognitochain/incognito-chain/database/lvdb"
+	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/pkg/errors"
+)
+
+// number of headers whose median timestamp a new header must exceed
+const btcMedianTimeHeaders = 11
+
+/*
+CurrentBTCRelayingState is the relayed bitcoin header chain, headers are read from db on demand
+and new headers and changes of the best chain are kept in memory until they are stored:
+- a header is accepted only if its parent is known, so every header descends from the checkpoint
+- the best header is the one with the most chain work, its ancestors make the main chain
+*/
+type CurrentBTCRelayingState struct {
+	db             database.DatabaseInterface
+	params         *BTCRelayingParams
+	BestHeaderHash chainhash.Hash
+	headers        map[chainhash.Hash]*lvdb.BTCHeaderInfo
+	newHeaders     []chainhash.Hash
+	// hash of the main chain by height, zero hash means the height is removed from the main chain
+	mainChain           map[uint64]chainhash.Hash
+	isBestHeaderChanged bool
+}
+
+func InitCurrentBTCRelayingStateFromDB(
+	db database.DatabaseInterface,
+	params *BTCRelayingParams,
+) (*CurrentBTCRelayingState, error) {
+	state := &CurrentBTCRelayingState{
+		db:         db,
+		params:     params,
+		headers:    make(map[chainhash.Hash]*lvdb.BTCHeaderInfo),
+		newHeaders: []chainhash.Hash{},
+		mainChain:  make(map[uint64]chainhash.Hash),
+	}
+	bestHashBytes, err := db.GetBTCBestHeaderHash()
+	if err != nil {
+		return nil, err
+	}
+	if len(bestHashBytes) > 0 {
+		bestHash, err := chainhash.NewHash(bestHashBytes)
+		if err != nil {
+			return nil, err
+		}
+		state.BestHeaderHash = *bestHash
+		return state, nil
+	}
+
+	// nothing is relayed yet, start from the checkpoint
+	checkpoint := params.CheckpointHeader
+	checkpointBytes, err := btc.SerializeBTCHeader(&checkpoint)
+	if err != nil {
+		return nil, err
+	}
+	checkpointHash := checkpoint.BlockHash()
+	state.addHeader(&lvdb.BTCHeaderInfo{
+		Header:            checkpointBytes,
+		Hash:              checkpointHash.String(),
+		Height:            params.CheckpointHeight,
+		ChainWork:         btc.CalcWork(checkpoint.Bits),
+		IntervalStartTime: checkpoint.Timestamp.Unix(),
+		LastNonMinBits:    checkpoint.Bits,
+	})
+	state.mainChain[params.CheckpointHeight] = checkpointHash
+	state.BestHeaderHash = checkpointHash
+	state.isBestHeaderChanged = true
+	return state, nil
+}
+
+func (state *CurrentBTCRelayingState) addHeader(headerInfo *lvdb.BTCHeaderInfo) {
+	hash, _ := chainhash.NewHashFromStr(headerInfo.Hash)
+	state.headers[*hash] = headerInfo
+	state.newHeaders = append(state.newHeaders, *hash)
+}
+
+// GetHeader returns nil if the header is not relayed
+func (state *CurrentBTCRelayingState) GetHeader(hash chainhash.Hash) (*lvdb.BTCHeaderInfo, error) {
+	if headerInfo, found := state.headers[hash]; found {
+		return headerInfo, nil
+	}
+	headerInfoBytes, err := state.db.GetBTCHeader(hash.CloneBytes())
+	if err != nil {
+		return nil, err
+	}
+	if len(headerInfoBytes) == 0 {
+		return nil, nil
+	}
+	var headerInfo lvdb.BTCHeaderInfo
+	err = json.Unmarshal(headerInfoBytes, &headerInfo)
+	if err != nil {
+		return nil, err
+	}
+	state.headers[hash] = &headerInfo
+	return &headerInfo, nil
+}
+
+// GetMainChainHash returns nil if no header of the main chain is at height
+func (state *CurrentBTCRelayingState) GetMainChainHash(height uint64) (*chainhash.Hash, error) {
+	if hash, found := state.mainChain[height]; found {
+		if hash == (chainhash.Hash{}) {
+			return nil, nil
+		}
+		return &hash, nil
+	}
+	hashBytes, err := state.db.GetBTCMainChainHash(height)
+	if err != nil {
+		return nil, err
+	}
+	if len(hashBytes) == 0 {
+		return nil, nil
+	}
+	return chainhash.NewHash(hashBytes)
+}
+
+func (state *CurrentBTCRelayingState) GetBestHeader() (*lvdb.BTCHeaderInfo, error) {
+	bestHeader, err := state.GetHeader(state.BestHeaderHash)
+	if err != nil {
+		return nil, err
+	}
+	if bestHeader == nil {
+		return nil, fmt.Errorf("best btc header %s is not found", state.BestHeaderHash.String())
+	}
+	return bestHeader, nil
+}
+
+// calcPastMedianTime returns the median timestamp of the last headers up to headerInfo, fewer headers are used near the checkpoint
+func (state *CurrentBTCRelayingState) calcPastMedianTime(
+	headerInfo *lvdb.BTCHeaderInfo,
+	pendingHeaders map[chainhash.Hash]*lvdb.BTCHeaderInfo,
+) (int64, error) {
+	timestamps := []int64{}
+	for i := 0; i < btcMedianTimeHeaders && headerInfo != nil; i++ {
+		header, err := btc.ParseBTCHeader(hex.EncodeToString(headerInfo.Header))
+		if err != nil {
+			return 0, err
+		}
+		timestamps = append(timestamps, header.Timestamp.Unix())
+		if headerInfo.Height == state.params.CheckpointHeight {
+			break
+		}
+		parentInfo, found := pendingHeaders[header.PrevBlock]
+		if !found {
+			parentInfo, err = state.GetHeader(header.PrevBlock)
+			if err != nil {
+				return 0, err
+			}
+		}
+		headerInfo = parentInfo
+	}
+	sort.Slice(timestamps, func(i, j int) bool {
+		return timestamps[i] < timestamps[j]
+	})
+	return timestamps[len(timestamps)/2], nil
+}
+
+func (state *CurrentBTCRelayingState) buildHeaderInfo(
+	header *wire.BlockHeader,
+	parentInfo *lvdb.BTCHeaderInfo,
+	pendingHeaders map[chainhash.Hash]*lvdb.BTCHeaderInfo,
+) (*lvdb.BTCHeaderInfo, error) {
+	btcParams := state.params.BTCParams
+	parent, err := btc.ParseBTCHeader(hex.EncodeToString(parentInfo.Header))
+	if err != nil {
+		return nil, err
+	}
+	medianTime, err := state.calcPastMedianTime(parentInfo, pendingHeaders)
+	if err != nil {
+		return nil, err
+	}
+	newTime := header.Timestamp.Unix()
+	if newTime <= medianTime {
+		return nil, fmt.Errorf("timestamp of header %s is not after the median time of its previous headers", header.BlockHash().String())
+	}
+	requiredBits := btc.CalcNextRequiredBits(
+		btcParams,
+		parentInfo.Height,
+		parent.Bits,
+		parent.Timestamp.Unix(),
+		parentInfo.IntervalStartTime,
+		parentInfo.LastNonMinBits,
+		newTime,
+	)
+	if header.Bits != requiredBits {
+		return nil, fmt.Errorf("bits of header %s are %08x, required %08x", header.BlockHash().String(), header.Bits, requiredBits)
+	}
+	err = btc.CheckProofOfWork(header, btcParams.PowLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	headerBytes, err := btc.SerializeBTCHeader(header)
+	if err != nil {
+		return nil, err
+	}
+	height := parentInfo.Height + 1
+	blocksPerRetarget := uint64(btcParams.TargetTimespan / btcParams.TargetTimePerBlock)
+	intervalStartTime := parentInfo.IntervalStartTime
+	lastNonMinBits := header.Bits
+	if height%blocksPerRetarget == 0 {
+		intervalStartTime = newTime
+	} else if btcParams.ReduceMinDifficulty && header.Bits == btcParams.PowLimitBits {
+		lastNonMinBits = parentInfo.LastNonMinBits
+	}
+	return &lvdb.BTCHeaderInfo{
+		Header:            headerBytes,
+		Hash:              header.BlockHash().String(),
+		Height:            height,
+		ChainWork:         new(big.Int).Add(parentInfo.ChainWork, btc.CalcWork(header.Bits)),
+		IntervalStartTime: intervalStartTime,
+		LastNonMinBits:    lastNonMinBits,
+	}, nil
+}
+
+/*
+applyRelayingBTCHeaders adds headers to the relayed header chain, either all of them or none:
+- headers must be in order, the first one extends a relayed header
+- bits must follow the difficulty rules of the bitcoin network and hashes must meet them
+- headers which are already relayed are skipped
+The best header is moved if one of the new headers has more chain work
+*/
+func (state *CurrentBTCRelayingState) applyRelayingBTCHeaders(headers []*wire.BlockHeader) error {
+	pendingHeaders := make(map[chainhash.Hash]*lvdb.BTCHeaderInfo)
+	newHeaderInfos := []*lvdb.BTCHeaderInfo{}
+	for _, header := range headers {
+		hash := header.BlockHash()
+		relayedInfo, err := state.GetHeader(hash)
+		if err != nil {
+			return err
+		}
+		if relayedInfo != nil {
+			continue
+		}
+		parentInfo, found := pendingHeaders[header.PrevBlock]
+		if !found {
+			parentInfo, err = state.GetHeader(header.PrevBlock)
+			if err != nil {
+				return err
+			}
+		}
+		if parentInfo == nil {
+			return fmt.Errorf("parent %s of header %s is not relayed", header.PrevBlock.String(), hash.String())
+		}
+		headerInfo, err := state.buildHeaderInfo(header, parentInfo, pendingHeaders)
+		if err != nil {
+			return err
+		}
+		pendingHeaders[hash] = headerInfo
+		newHeaderInfos = append(newHeaderInfos, headerInfo)
+	}
+
+	bestHeader, err := state.GetBestHeader()
+	if err != nil {
+		return err
+	}
+	var newBestHeader *lvdb.BTCHeaderInfo
+	for _, headerInfo := range newHeaderInfos {
+		state.addHeader(headerInfo)
+		if headerInfo.ChainWork.Cmp(bestHeader.ChainWork) > 0 {
+			bestHeader = headerInfo
+			newBestHeader = headerInfo
+		}
+	}
+	if newBestHeader != nil {
+		return state.setBestHeader(newBestHeader)
+	}
+	return nil
+}
+
+// setBestHeader moves the main chain to the ancestors of bestHeader, heights above it are removed
+func (state *CurrentBTCRelayingState) setBestHeader(bestHeader *lvdb.BTCHeaderInfo) error {
+	bestHash, err := chainhash.NewHashFromStr(bestHeader.Hash)
+	if err != nil {
+		return err
+	}
+	oldBestHeader, err := state.GetBestHeader()
+	if err != nil {
+		return err
+	}
+	for height := bestHeader.Height + 1; height <= oldBestHeader.Height; height++ {
+		state.mainChain[height] = chainhash.Hash{}
+	}
+	headerInfo := bestHeader
+	for {
+		hash, err := chainhash.NewHashFromStr(headerInfo.Hash)
+		if err != nil {
+			return err
+		}
+		mainChainHash, err := state.GetMainChainHash(headerInfo.Height)
+		if err != nil {
+			return err
+		}
+		if mainChainHash != nil && mainChainHash.IsEqual(hash) {
+			break
+		}
+		state.mainChain[headerInfo.Height] = *hash
+		if headerInfo.Height == state.params.CheckpointHeight {
+			break
+		}
+		header, err := btc.ParseBTCHeader(hex.EncodeToString(headerInfo.Header))
+		if err != nil {
+			return err
+		}
+		headerInfo, err = state.GetHeader(header.PrevBlock)
+		if err != nil {
+			return err
+		}
+		if headerInfo == nil {
+			return fmt.Errorf("parent %s of header %s is not relayed", header.PrevBlock.String(), hash.String())
+		}
+	}
+	state.BestHeaderHash = *bestHash
+	state.isBestHeaderChanged = true
+	return nil
+}
+
+/*
+verifyBTCDeposit returns the amount in satoshi and the unique id of the deposit proved by issuingReq:
+- block of the deposit must be on the main chain with enough confirmations
+- the tx must be committed in the merkle root of the block
+- the output must pay to the deposit script
+Whether the deposit is already issued is checked by callers
+*/
+func (state *CurrentBTCRelayingState) verifyBTCDeposit(issuingReq metadata.IssuingBTCRequest) (uint64, []byte, error) {
+	depositScript, err := hex.DecodeString(state.params.DepositScriptStr)
+	if err != nil || len(depositScript) == 0 {
+		return 0, nil, errors.New("deposit script of btc relaying is not set")
+	}
+	blockHash, tx, proof, err := issuingReq.ParseBTCDepositProof()
+	if err != nil {
+		return 0, nil, err
+	}
+	headerInfo, err := state.GetHeader(*blockHash)
+	if err != nil {
+		return 0, nil, err
+	}
+	if headerInfo == nil {
+		return 0, nil, errors.Errorf("btc block %s is not relayed", blockHash.String())
+	}
+	mainChainHash, err := state.GetMainChainHash(headerInfo.Height)
+	if err != nil {
+		return 0, nil, err
+	}
+	if mainChainHash == nil || !mainChainHash.IsEqual(blockHash) {
+		return 0, nil, errors.Errorf("btc block %s is not on the main chain", blockHash.String())
+	}
+	bestHeader, err := state.GetBestHeader()
+	if err != nil {
+		return 0, nil, err
+	}
+	if bestHeader.Height-headerInfo.Height+1 < state.params.Confirmations {
+		return 0, nil, errors.Errorf("btc block %s has %d confirmations, required %d", blockHash.String(), bestHeader.Height-headerInfo.Height+1, state.params.Confirmations)
+	}
+	header, err := btc.ParseBTCHeader(hex.EncodeToString(headerInfo.Header))
+	if err != nil {
+		return 0, nil, err
+	}
+	txHash := tx.TxHash()
+	err = btc.VerifyMerkleProof(header.MerkleRoot, txHash, issuingReq.TxIndex, proof)
+	if err != nil {
+		return 0, nil, err
+	}
+	amount, err := btc.GetBTCDepositAmount(tx, issuingReq.OutputIndex, depositScript)
+	if err != nil {
+		return 0, nil, err
+	}
+	return amount, metadata.GetUniqBTCTx(txHash, issuingReq.OutputIndex), nil
+}
+
+func storeBTCRelayingStateToDB(
+	db database.DatabaseInterface,
+	currentBTCRelayingState *CurrentBTCRelayingState,
+) error {
+	for _, hash := range currentBTCRelayingState.newHeaders {
+		headerInfoBytes, err := json.Marshal(currentBTCRelayingState.headers[hash])
+		if err != nil {
+			return err
+		}
+		err = db.StoreBTCHeader(hash.CloneBytes(), headerInfoBytes)
+		if err != nil {
+			return err
+		}
+	}
+	var heights []uint64
+	for height := range currentBTCRelayingState.mainChain {
+		heights = append(heights, height)
+	}
+	sort.Slice(heights, func(i, j int) bool {
+		return heights[i] < heights[j]
+	})
+	for _, height := range heights {
+		hash := currentBTCRelayingState.mainChain[height]
+		var err error
+		if hash == (chainhash.Hash{}) {
+			err = db.DeleteBTCMainChainHash(height)
+		} else {
+			err = db.StoreBTCMainChainHash(height, hash.CloneBytes())
+		}
+		if err != nil {
+			return err
+		}
+	}
+	if currentBTCRelayingState.isBestHeaderChanged {
+		return db.StoreBTCBestHeaderHash(currentBTCRelayingState.BestHeaderHash.CloneBytes())
+	}
+	return nil
+}
@@ -0,0 +1,259 @@
+package blockchain
+
+import (
+	"bytes"
+	"encoding/hex"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/incognitochain/incognito-chain/blockchain/btc"
+	"github.com/incognitochain/incognito-chain/database"
+	_ "github.com/incognitochain/incognito-chain/database/lvdb"
+	"github.com/incognitochain/incognito-chain/metadata"
+)
+
+var testBTCDepositScript = []byte{0x00, 0x14, 0xaa, 0xbb, 0xcc}
+
+func newTestBTCRelayingParams() *BTCRelayingParams {
+	return &BTCRelayingParams{
+		BTCParams:        &chaincfg.RegressionNetParams,
+		CheckpointHeader: chaincfg.RegressionNetParams.GenesisBlock.Header,
+		CheckpointHeight: 0,
+		Confirmations:    2,
+		DepositScriptStr: hex.EncodeToString(testBTCDepositScript),
+	}
+}
+
+// mineTestBTCHeader returns a child of parent meeting bits, blocks of regression tests are found in a few tries
+func mineTestBTCHeader(parent *wire.BlockHeader, merkleRoot chainhash.Hash, bits uint32, timestamp time.Time) *wire.BlockHeader {
+	header := &wire.BlockHeader{
+		Version:    1,
+		PrevBlock:  parent.BlockHash(),
+		MerkleRoot: merkleRoot,
+		Timestamp:  timestamp,
+		Bits:       bits,
+	}
+	for btc.CheckProofOfWork(header, nil) != nil {
+		header.Nonce++
+	}
+	return header
+}
+
+func mineTestBTCHeaders(parent *wire.BlockHeader, count int, salt byte) []*wire.BlockHeader {
+	headers := []*wire.BlockHeader{}
+	for i := 0; i < count; i++ {
+		header := mineTestBTCHeader(parent, chainhash.Hash{salt, byte(i)}, chaincfg.RegressionNetParams.PowLimitBits, parent.Timestamp.Add(10*time.Minute))
+		headers = append(headers, header)
+		parent = header
+	}
+	return headers
+}
+
+func checkTestBTCMainChain(t *testing.T, state *CurrentBTCRelayingState, headers []*wire.BlockHeader, fromHeight uint64) {
+	for i, header := range headers {
+		hash := header.BlockHash()
+		mainChainHash, err := state.GetMainChainHash(fromHeight + uint64(i))
+		if err != nil || mainChainHash == nil || !mainChainHash.IsEqual(&hash) {
+			t.Errorf("header %s should be at height %d of the main chain", hash.String(), fromHeight+uint64(i))
+		}
+	}
+}
+
+func TestBTCRelayingHeaderChain(t *testing.T) {
+	dbPath, err := ioutil.TempDir(os.TempDir(), "test_btcrelaying")
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %+v", err)
+	}
+	defer os.RemoveAll(dbPath)
+	db, err := database.Open("leveldb", dbPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	params := newTestBTCRelayingParams()
+	genesis := params.CheckpointHeader
+
+	state, err := InitCurrentBTCRelayingStateFromDB(db, params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	chainA := mineTestBTCHeaders(&genesis, 5, 0xa)
+	if err := state.applyRelayingBTCHeaders(chainA); err != nil {
+		t.Fatalf("headers extending the checkpoint should be accepted: %+v", err)
+	}
+	if err := storeBTCRelayingStateToDB(db, state); err != nil {
+		t.Fatal(err)
+	}
+
+	// state is restored from db
+	state, err = InitCurrentBTCRelayingStateFromDB(db, params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bestHeader, err := state.GetBestHeader()
+	if err != nil || bestHeader.Height != 5 || bestHeader.Hash != chainA[4].BlockHash().String() {
+		t.Fatalf("unexpected best header %+v: %+v", bestHeader, err)
+	}
+	checkTestBTCMainChain(t, state, chainA, 1)
+
+	// relayed headers are skipped
+	if err := state.applyRelayingBTCHeaders(chainA[3:]); err != nil {
+		t.Errorf("relayed headers should be skipped: %+v", err)
+	}
+
+	// a fork with less work does not move the best header
+	chainB := mineTestBTCHeaders(chainA[1], 4, 0xb)
+	if err := state.applyRelayingBTCHeaders(chainB[:2]); err != nil {
+		t.Fatalf("headers of a fork should be accepted: %+v", err)
+	}
+	if state.BestHeaderHash != chainA[4].BlockHash() {
+		t.Errorf("best header should not move to a fork with less work")
+	}
+
+	// a fork with more work replaces the main chain
+	if err := db.CleanBackup(true, 0); err != nil {
+		t.Fatal(err)
+	}
+	if err := state.applyRelayingBTCHeaders(chainB[2:]); err != nil {
+		t.Fatalf("headers of a fork should be accepted: %+v", err)
+	}
+	if err := storeBTCRelayingStateToDB(db, state); err != nil {
+		t.Fatal(err)
+	}
+	state, _ = InitCurrentBTCRelayingStateFromDB(db, params)
+	bestHeader, err = state.GetBestHeader()
+	if err != nil || bestHeader.Height != 6 || bestHeader.Hash != chainB[3].BlockHash().String() {
+		t.Fatalf("unexpected best header %+v: %+v", bestHeader, err)
+	}
+	checkTestBTCMainChain(t, state, chainA[:2], 1)
+	checkTestBTCMainChain(t, state, chainB, 3)
+
+	// a reverted beacon block puts back the main chain it replaced
+	if err := db.RestoreBeaconStates(); err != nil {
+		t.Fatal(err)
+	}
+	state, _ = InitCurrentBTCRelayingStateFromDB(db, params)
+	bestHeader, err = state.GetBestHeader()
+	if err != nil || bestHeader.Height != 5 || bestHeader.Hash != chainA[4].BlockHash().String() {
+		t.Fatalf("unexpected best header after revert %+v: %+v", bestHeader, err)
+	}
+	checkTestBTCMainChain(t, state, chainA, 1)
+	if revertedInfo, _ := state.GetHeader(chainB[3].BlockHash()); revertedInfo != nil {
+		t.Errorf("header relayed by a reverted block should be removed")
+	}
+	if err := db.CleanBackup(true, 0); err != nil {
+		t.Fatal(err)
+	}
+	if err := state.applyRelayingBTCHeaders(chainB); err != nil {
+		t.Fatalf("headers of a fork should be accepted again: %+v", err)
+	}
+	if err := storeBTCRelayingStateToDB(db, state); err != nil {
+		t.Fatal(err)
+	}
+	state, _ = InitCurrentBTCRelayingStateFromDB(db, params)
+
+	// headers are rejected all together
+	tip := chainB[3]
+	orphan := mineTestBTCHeaders(chainA[4], 2, 0xc)[1]
+	wrongBits := mineTestBTCHeader(tip, chainhash.Hash{0xd}, 0x2000ffff, tip.Timestamp.Add(10*time.Minute))
+	tooEarly := mineTestBTCHeader(tip, chainhash.Hash{0xe}, chaincfg.RegressionNetParams.PowLimitBits, chainB[0].Timestamp)
+	valid := mineTestBTCHeaders(tip, 1, 0xf)[0]
+	for _, headers := range [][]*wire.BlockHeader{
+		{orphan},
+		{wrongBits},
+		{tooEarly},
+		{valid, orphan},
+	} {
+		if err := state.applyRelayingBTCHeaders(headers); err == nil {
+			t.Errorf("invalid headers should be rejected")
+		}
+	}
+	if relayedInfo, _ := state.GetHeader(valid.BlockHash()); relayedInfo != nil {
+		t.Errorf("valid header of a rejected batch should not be relayed")
+	}
+}
+
+func TestBTCRelayingDeposit(t *testing.T) {
+	dbPath, err := ioutil.TempDir(os.TempDir(), "test_btcrelaying")
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %+v", err)
+	}
+	defer os.RemoveAll(dbPath)
+	db, err := database.Open("leveldb", dbPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	params := newTestBTCRelayingParams()
+	genesis := params.CheckpointHeader
+	state, err := InitCurrentBTCRelayingStateFromDB(db, params)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	receiverAddressStr := "receiver"
+	coinbaseTx := wire.NewMsgTx(wire.TxVersion)
+	coinbaseTx.AddTxIn(wire.NewTxIn(wire.NewOutPoint(&chainhash.Hash{}, 0xffffffff), []byte{0x51, 0x51}, nil))
+	coinbaseTx.AddTxOut(wire.NewTxOut(5000000000, []byte{0x51}))
+	depositTx := wire.NewMsgTx(wire.TxVersion)
+	depositTx.AddTxIn(wire.NewTxIn(wire.NewOutPoint(&chainhash.Hash{1}, 0), []byte{0x51}, nil))
+	depositTx.AddTxOut(wire.NewTxOut(0, append([]byte{0x6a, btc.BTCDepositCommitmentSize}, metadata.GetBTCDepositCommitment(receiverAddressStr)...)))
+	depositTx.AddTxOut(wire.NewTxOut(250000, testBTCDepositScript))
+	coinbaseHash := coinbaseTx.TxHash()
+	depositHash := depositTx.TxHash()
+	merkleRoot := chainhash.DoubleHashH(append(coinbaseHash.CloneBytes(), depositHash.CloneBytes()...))
+
+	depositHeader := mineTestBTCHeader(&genesis, merkleRoot, chaincfg.RegressionNetParams.PowLimitBits, genesis.Timestamp.Add(10*time.Minute))
+	if err := state.applyRelayingBTCHeaders([]*wire.BlockHeader{depositHeader}); err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	depositTx.Serialize(&buf)
+	issuingReq := metadata.IssuingBTCRequest{
+		BlockHash:          depositHeader.BlockHash().String(),
+		TxStr:              hex.EncodeToString(buf.Bytes()),
+		TxIndex:            1,
+		MerkleProofStrs:    []string{coinbaseHash.String()},
+		OutputIndex:        1,
+		ReceiverAddressStr: receiverAddressStr,
+	}
+	if _, _, err := state.verifyBTCDeposit(issuingReq); err == nil {
+		t.Errorf("deposit without enough confirmations should be rejected")
+	}
+
+	if err := state.applyRelayingBTCHeaders(mineTestBTCHeaders(depositHeader, 1, 0xa)); err != nil {
+		t.Fatal(err)
+	}
+	amount, uniqBTCTx, err := state.verifyBTCDeposit(issuingReq)
+	if err != nil || amount != 250000 || !bytes.Equal(uniqBTCTx, metadata.GetUniqBTCTx(depositHash, 1)) {
+		t.Errorf("deposit should be accepted, got %d: %+v", amount, err)
+	}
+
+	wrongIndexReq := issuingReq
+	wrongIndexReq.TxIndex = 0
+	wrongOutputReq := issuingReq
+	wrongOutputReq.OutputIndex = 0
+	wrongReceiverReq := issuingReq
+	wrongReceiverReq.ReceiverAddressStr = "another receiver"
+	for _, req := range []metadata.IssuingBTCRequest{wrongIndexReq, wrongOutputReq, wrongReceiverReq} {
+		if _, _, err := state.verifyBTCDeposit(req); err == nil {
+			t.Errorf("invalid deposit proof should be rejected")
+		}
+	}
+
+	// block of the deposit leaves the main chain
+	if err := state.applyRelayingBTCHeaders(mineTestBTCHeaders(&genesis, 3, 0xb)); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := state.verifyBTCDeposit(issuingReq); err == nil {
+		t.Errorf("deposit in a block out of the main chain should be rejected")
+	}
+
+	params.DepositScriptStr = ""
+	if _, _, err := state.verifyBTCDeposit(issuingReq); err == nil {
+		t.Errorf("deposit should be rejected when the deposit script is not set")
+	}
+}
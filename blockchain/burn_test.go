@@ -267,9 +267,10 @@ func TestBuildBridgeInst(t *testing.T) {
 	height := uint64(123)
 	token := common.Hash{2}
 	testCases := []struct {
-		desc  string
-		insts [][]string
-		out   [][]string
+		desc       string
+		insts      [][]string
+		out        [][]string
+		breakPoint uint64
 	}{
 		{
 			desc:  "Action from consensus",
@@ -282,15 +283,22 @@ func TestBuildBridgeInst(t *testing.T) {
 			out:   [][]string{},
 		},
 		{
-			desc:  "ERC20",
-			insts: [][]string{setupBurningRequest(2)},
-			out:   [][]string{setupBurningConfirmInst(int64(height), token[:])},
+			desc:       "ERC20",
+			insts:      [][]string{setupBurningRequest(2)},
+			out:        [][]string{setupBurningConfirmInst(int64(height), token[:])},
+			breakPoint: height + 1,
+		},
+		{
+			desc:       "Burning request is built by stateful instructions from the break point",
+			insts:      [][]string{setupBurningRequest(2)},
+			out:        [][]string{},
+			breakPoint: height,
 		},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.desc, func(t *testing.T) {
-			bc := BlockChain{}
+			bc := BlockChain{config: Config{ChainParams: &Params{BeaconHeightBreakPointBridgeLimiter: tc.breakPoint}}}
 			insts, err := bc.buildBridgeInstructions(
 				0,
 				tc.insts,
@@ -310,6 +318,66 @@ func TestBuildBridgeInst(t *testing.T) {
 	}
 }
 
+func TestBuildInstructionsForBurningReq(t *testing.T) {
+	height := uint64(123)
+	token := common.Hash{2}
+	testCases := []struct {
+		desc   string
+		limit  BridgeTokenLimit
+		queued bool
+	}{
+		{
+			desc:  "No caps",
+			limit: BridgeTokenLimit{},
+		},
+		{
+			desc:  "Within caps",
+			limit: BridgeTokenLimit{MaxBurningPerTx: 2000, MaxBurningPerEpoch: 5000},
+		},
+		{
+			desc:   "Over per-tx cap",
+			limit:  BridgeTokenLimit{MaxBurningPerTx: 1000},
+			queued: true,
+		},
+		{
+			desc:   "Over per-epoch cap",
+			limit:  BridgeTokenLimit{MaxBurningPerEpoch: 1999},
+			queued: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			bc := BlockChain{}
+			state := newBridgeLimiterState(
+				map[common.Hash]*lvdb.BridgeTokenQuota{},
+				[]*lvdb.BridgeQueuedRequest{},
+				map[string]BridgeTokenLimit{token.String(): tc.limit},
+				height,
+				100,
+			)
+			request := setupBurningRequest(2)
+			insts, err := bc.buildInstructionsForBurningReq(request[1], 0, metadata.BurningRequestMeta, state, height, setupDB(t))
+			if err != nil {
+				t.Error(err)
+			}
+			if len(insts) != 1 {
+				t.Fatalf("expected 1 inst, got %+v", insts)
+			}
+			if tc.queued {
+				if insts[0][2] != common.BridgeRequestQueuedChainStatus || len(state.QueuedRequests) != 1 {
+					t.Errorf("expected queued request, got %+v", insts[0])
+				}
+				return
+			}
+			checkBurningConfirmInst(t, insts[0], setupBurningConfirmInst(int64(height), token[:]))
+			if state.GetQuota(token).BurnedAmount != 2000 {
+				t.Errorf("expected burned amount counted, got %+v", state.GetQuota(token))
+			}
+		})
+	}
+}
+
 func TestBurnConfirmScaleAmount(t *testing.T) {
 	testCases := []struct {
 		desc   string
@@ -355,6 +423,79 @@ func TestBurnConfirmScaleAmount(t *testing.T) {
 	}
 }
 
+func TestBurnConfirmForEVMChain(t *testing.T) {
+	chainID := uint64(56)
+	token := common.Hash{5}
+	height := int64(123)
+	expected := []string{
+		"73",
+		"1",
+		encode58(getExternalID(5)),
+		remoteAddress,
+		encode58(big.NewInt(2000).Bytes()),
+		txid,
+		encode58(token[:]),
+		encode58(big.NewInt(int64(chainID)).Bytes()),
+		encode58(big.NewInt(height).Bytes()),
+	}
+
+	inst := setupBurningRequestForChain(5, chainID)
+	c, err := buildBurningConfirmInst(inst, uint64(height), setupDB(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkBurningConfirmInst(t, c, expected)
+	if id, err := GetBurningConfirmChainID(c); err != nil || id != chainID {
+		t.Errorf("expected chain id %d, got %d: %+v", chainID, id, err)
+	}
+
+	// token is burned for the chain which it is bridged from only
+	if _, err := buildBurningConfirmInst(setupBurningRequest(5), uint64(height), setupDB(t)); err == nil {
+		t.Error("burning of a token of a sidechain for the default chain should be rejected")
+	}
+	if _, err := buildBurningConfirmInst(setupBurningRequestForChain(2, chainID), uint64(height), setupDB(t)); err == nil {
+		t.Error("burning of a token of the default chain for a sidechain should be rejected")
+	}
+
+	// chain id is flattened before the height
+	d, err := decodeBurningConfirmInst(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(d) != 226 || big.NewInt(0).SetBytes(d[162:194]).Uint64() != chainID || big.NewInt(0).SetBytes(d[194:226]).Int64() != height {
+		t.Errorf("wrong decoded inst %x", d)
+	}
+
+	// burning confirm insts of all chains are picked in order
+	b := []*BeaconBlock{&BeaconBlock{Body: BeaconBody{Instructions: [][]string{
+		c,
+		[]string{"1", "2"},
+		setupBurningConfirmInst(height, incToken[:]),
+	}}}}
+	insts := pickBurningConfirmInstruction(b, 456)
+	if len(insts) != 2 || insts[0][0] != "73" || insts[1][0] != "72" {
+		t.Errorf("unexpected picked insts %+v", insts)
+	}
+}
+
+func setupBurningRequestForChain(id byte, chainID uint64) []string {
+	meta := metadata.BurningRequest{
+		BurnerAddress: privacy.PaymentAddress{},
+		BurningAmount: 2000,
+		TokenID:       common.Hash{id},
+		TokenName:     "token",
+		RemoteAddress: remoteAddress,
+		ChainID:       chainID,
+	}
+	txHash, _ := common.Hash{}.NewHashFromStr(txid)
+	actionContent, _ := json.Marshal(map[string]interface{}{
+		"meta":          meta,
+		"RequestedTxID": txHash,
+	})
+	action := base64.StdEncoding.EncodeToString(actionContent)
+	return []string{"27", action}
+}
+
 func setupBurningRequest(id byte) []string {
 	meta := metadata.BurningRequest{
 		BurnerAddress: privacy.PaymentAddress{},
@@ -457,6 +598,11 @@ func setupTokenInfos(t *testing.T) []byte {
 		newToken(2),
 		newToken(3),
 		newToken(0),
+		&lvdb.BridgeTokenInfo{
+			TokenID:         &common.Hash{5},
+			ExternalTokenID: getExternalID(5),
+			ChainID:         56,
+		},
 	}
 	tokenInfo, err := json.Marshal(tokens)
 	if err != nil {
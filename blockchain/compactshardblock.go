@@ -0,0 +1,235 @@
+package blockchain
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/incognitochain/incognito-chain/transaction"
+)
+
+/*
+	CompactShardBlock is used to relay a shard block to nodes which already have most of its txs in mempool:
+	- header, instructions and cross transactions are sent as they are
+	- each tx is replaced by a short id, salted by block hash so a tx has different short ids in different blocks
+	- txs which can't be in mempool (reward and return staking txs created by producer) are prefilled
+	Receiver reconstructs block from its mempool, requests missing txs by short ids,
+	then checks tx root in header to detect collision of short ids
+*/
+type CompactShardBlock struct {
+	ValidationData    string
+	Header            ShardHeader
+	Instructions      [][]string
+	CrossTransactions map[byte][]CrossTransaction
+	ShortTxIDs        []uint64
+	PrefilledTxs      []PrefilledTx
+}
+
+// PrefilledTx is a tx sent in full with compact block, Index is position of tx in block
+type PrefilledTx struct {
+	Index int
+	Tx    metadata.Transaction
+}
+
+func (prefilledTx *PrefilledTx) UnmarshalJSON(data []byte) error {
+	temp := &struct {
+		Index int
+		Tx    json.RawMessage
+	}{}
+	if err := json.Unmarshal(data, temp); err != nil {
+		return err
+	}
+	tx, err := UnmarshalTransaction(temp.Tx)
+	if err != nil {
+		return err
+	}
+	prefilledTx.Index = temp.Index
+	prefilledTx.Tx = tx
+	return nil
+}
+
+// UnmarshalTransaction parse tx in block from json by its type
+func UnmarshalTransaction(data []byte) (metadata.Transaction, error) {
+	temp := &struct {
+		Type string
+	}{}
+	if err := json.Unmarshal(data, temp); err != nil {
+		return nil, err
+	}
+	var tx metadata.Transaction
+	switch temp.Type {
+	case common.TxNormalType, common.TxRewardType, common.TxReturnStakingType:
+		tx = &transaction.Tx{}
+	case common.TxCustomTokenPrivacyType:
+		tx = &transaction.TxCustomTokenPrivacy{}
+	default:
+		return nil, errors.New("can not parse a wrong tx")
+	}
+	if err := json.Unmarshal(data, tx); err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
+
+// ShortTxID return 8 first bytes of hash of block hash and tx hash
+func ShortTxID(blockHash common.Hash, txHash common.Hash) uint64 {
+	shortIDHash := common.HashH(append(blockHash.GetBytes(), txHash.GetBytes()...))
+	return binary.LittleEndian.Uint64(shortIDHash[:8])
+}
+
+// isPrefilledTx check if tx is created by block producer, so other nodes don't have it in mempool
+func isPrefilledTx(tx metadata.Transaction) bool {
+	switch tx.GetType() {
+	case common.TxRewardType, common.TxReturnStakingType:
+		return true
+	case common.TxCustomTokenPrivacyType:
+		// token tx created by producer has a reward tx inside
+		if tokenTx, ok := tx.(*transaction.TxCustomTokenPrivacy); ok {
+			return tokenTx.TxPrivacyTokenData.TxNormal.GetType() == common.TxRewardType
+		}
+	}
+	return false
+}
+
+// IsCompactShardBlockActive tells if a shard block is proposed and relayed as compact block,
+// before the break point nodes may not be able to reconstruct compact blocks so full block is sent
+func (blockchain *BlockChain) IsCompactShardBlockActive(shardBlock *ShardBlock) bool {
+	return shardBlock.Header.BeaconHeight >= blockchain.config.ChainParams.BeaconHeightBreakPointCompactBlock
+}
+
+func NewCompactShardBlock(shardBlock *ShardBlock) *CompactShardBlock {
+	blockHash := shardBlock.Header.Hash()
+	compactBlock := &CompactShardBlock{
+		ValidationData:    shardBlock.ValidationData,
+		Header:            shardBlock.Header,
+		Instructions:      shardBlock.Body.Instructions,
+		CrossTransactions: shardBlock.Body.CrossTransactions,
+		ShortTxIDs:        []uint64{},
+		PrefilledTxs:      []PrefilledTx{},
+	}
+	for index, tx := range shardBlock.Body.Transactions {
+		if isPrefilledTx(tx) {
+			compactBlock.PrefilledTxs = append(compactBlock.PrefilledTxs, PrefilledTx{Index: index, Tx: tx})
+		} else {
+			compactBlock.ShortTxIDs = append(compactBlock.ShortTxIDs, ShortTxID(blockHash, *tx.Hash()))
+		}
+	}
+	return compactBlock
+}
+
+func (compactBlock *CompactShardBlock) Hash() *common.Hash {
+	hash := compactBlock.Header.Hash()
+	return &hash
+}
+
+// NumberOfTxs return number of txs in full block
+func (compactBlock *CompactShardBlock) NumberOfTxs() int {
+	return len(compactBlock.ShortTxIDs) + len(compactBlock.PrefilledTxs)
+}
+
+/*
+	CompactShardBlockBuilder fill txs of compact block from txs known by node:
+	- AddTxs add txs which match short ids of missing txs, it can be called many times (mempool then response of peers)
+	- MissingShortTxIDs return short ids which are not found yet
+	- Build return full block after all txs are found
+*/
+type CompactShardBlockBuilder struct {
+	compactBlock *CompactShardBlock
+	blockHash    common.Hash
+	txs          []metadata.Transaction
+	missing      map[uint64][]int // short id -> indexes of tx in block
+}
+
+func NewCompactShardBlockBuilder(compactBlock *CompactShardBlock) (*CompactShardBlockBuilder, error) {
+	numberOfTxs := compactBlock.NumberOfTxs()
+	builder := &CompactShardBlockBuilder{
+		compactBlock: compactBlock,
+		blockHash:    compactBlock.Header.Hash(),
+		txs:          make([]metadata.Transaction, numberOfTxs),
+		missing:      make(map[uint64][]int),
+	}
+	for _, prefilledTx := range compactBlock.PrefilledTxs {
+		if prefilledTx.Index < 0 || prefilledTx.Index >= numberOfTxs || builder.txs[prefilledTx.Index] != nil || prefilledTx.Tx == nil {
+			return nil, NewBlockChainError(ReconstructCompactShardBlockError, fmt.Errorf("Wrong prefilled tx at index %+v", prefilledTx.Index))
+		}
+		builder.txs[prefilledTx.Index] = prefilledTx.Tx
+	}
+	shortTxIDIndex := 0
+	for index := range builder.txs {
+		if builder.txs[index] != nil {
+			continue
+		}
+		shortTxID := compactBlock.ShortTxIDs[shortTxIDIndex]
+		builder.missing[shortTxID] = append(builder.missing[shortTxID], index)
+		shortTxIDIndex++
+	}
+	return builder, nil
+}
+
+func (builder *CompactShardBlockBuilder) BlockHash() common.Hash {
+	return builder.blockHash
+}
+
+func (builder *CompactShardBlockBuilder) AddTxs(txs []metadata.Transaction) {
+	for _, tx := range txs {
+		if len(builder.missing) == 0 {
+			return
+		}
+		shortTxID := ShortTxID(builder.blockHash, *tx.Hash())
+		indexes, ok := builder.missing[shortTxID]
+		if !ok {
+			continue
+		}
+		for _, index := range indexes {
+			builder.txs[index] = tx
+		}
+		delete(builder.missing, shortTxID)
+	}
+}
+
+func (builder *CompactShardBlockBuilder) MissingShortTxIDs() []uint64 {
+	shortTxIDs := make([]uint64, 0, len(builder.missing))
+	for shortTxID := range builder.missing {
+		shortTxIDs = append(shortTxIDs, shortTxID)
+	}
+	return shortTxIDs
+}
+
+func (builder *CompactShardBlockBuilder) IsComplete() bool {
+	return len(builder.missing) == 0
+}
+
+// Build return full block, error means collision of short ids or wrong compact block, full block must be fetched instead
+func (builder *CompactShardBlockBuilder) Build() (*ShardBlock, error) {
+	if !builder.IsComplete() {
+		return nil, NewBlockChainError(ReconstructCompactShardBlockError, fmt.Errorf("Block %+v is missing %+v txs", builder.blockHash, len(builder.missing)))
+	}
+	txMerkleTree := Merkle{}.BuildMerkleTreeStore(builder.txs)
+	txRoot := &common.Hash{}
+	if len(txMerkleTree) > 0 {
+		txRoot = txMerkleTree[len(txMerkleTree)-1]
+	}
+	if !bytes.Equal(builder.compactBlock.Header.TxRoot.GetBytes(), txRoot.GetBytes()) {
+		return nil, NewBlockChainError(ReconstructCompactShardBlockError, fmt.Errorf("Expect transaction root hash %+v but get %+v", builder.compactBlock.Header.TxRoot, txRoot))
+	}
+	shardBlock := &ShardBlock{
+		ValidationData: builder.compactBlock.ValidationData,
+		Header:         builder.compactBlock.Header,
+		Body: ShardBody{
+			Instructions:      builder.compactBlock.Instructions,
+			CrossTransactions: builder.compactBlock.CrossTransactions,
+			Transactions:      builder.txs,
+		},
+	}
+	if shardBlock.Body.Instructions == nil {
+		shardBlock.Body.Instructions = [][]string{}
+	}
+	if shardBlock.Body.CrossTransactions == nil {
+		shardBlock.Body.CrossTransactions = make(map[byte][]CrossTransaction)
+	}
+	return shardBlock, nil
+}
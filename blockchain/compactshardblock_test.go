@@ -0,0 +1,111 @@
+package blockchain
+
+import (
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/incognitochain/incognito-chain/transaction"
+)
+
+func newCompactTestShardBlock() *ShardBlock {
+	txs := []metadata.Transaction{
+		&transaction.Tx{Type: common.TxNormalType, LockTime: 1, Fee: 1},
+		&transaction.Tx{Type: common.TxRewardType, LockTime: 2},
+		&transaction.Tx{Type: common.TxNormalType, LockTime: 3, Fee: 3},
+		&transaction.Tx{Type: common.TxNormalType, LockTime: 4, Fee: 4},
+	}
+	txMerkleTree := Merkle{}.BuildMerkleTreeStore(txs)
+	return &ShardBlock{
+		Header: ShardHeader{
+			ShardID: 1,
+			Height:  10,
+			TxRoot:  *txMerkleTree[len(txMerkleTree)-1],
+		},
+		Body: ShardBody{
+			Instructions:      [][]string{},
+			CrossTransactions: make(map[byte][]CrossTransaction),
+			Transactions:      txs,
+		},
+	}
+}
+
+func TestNewCompactShardBlock(t *testing.T) {
+	shardBlock := newCompactTestShardBlock()
+	compactBlock := NewCompactShardBlock(shardBlock)
+	if compactBlock.NumberOfTxs() != 4 || len(compactBlock.PrefilledTxs) != 1 || compactBlock.PrefilledTxs[0].Index != 1 {
+		t.Fatalf("reward tx must be prefilled, got %+v short ids and %+v prefilled txs", len(compactBlock.ShortTxIDs), len(compactBlock.PrefilledTxs))
+	}
+	if !compactBlock.Hash().IsEqual(shardBlock.Hash()) {
+		t.Fatal("compact block must have hash of full block")
+	}
+	blockHash := shardBlock.Header.Hash()
+	if ShortTxID(blockHash, *shardBlock.Body.Transactions[0].Hash()) == ShortTxID(common.HashH([]byte("other")), *shardBlock.Body.Transactions[0].Hash()) {
+		t.Fatal("short id must be salted by block hash")
+	}
+}
+
+func TestCompactShardBlockBuilder(t *testing.T) {
+	shardBlock := newCompactTestShardBlock()
+	builder, err := NewCompactShardBlockBuilder(NewCompactShardBlock(shardBlock))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// mempool has some txs of block and other txs
+	builder.AddTxs([]metadata.Transaction{
+		shardBlock.Body.Transactions[3],
+		&transaction.Tx{Type: common.TxNormalType, LockTime: 5, Fee: 5},
+		shardBlock.Body.Transactions[0],
+	})
+	if builder.IsComplete() || len(builder.MissingShortTxIDs()) != 1 {
+		t.Fatalf("expect 1 missing tx, got %+v", len(builder.MissingShortTxIDs()))
+	}
+	if _, err := builder.Build(); err == nil {
+		t.Fatal("incomplete block must not be built")
+	}
+	// missing tx is received from peers
+	builder.AddTxs([]metadata.Transaction{shardBlock.Body.Transactions[2]})
+	block, err := builder.Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !block.Hash().IsEqual(shardBlock.Hash()) || block.Body.Hash() != shardBlock.Body.Hash() {
+		t.Fatal("reconstructed block must equal full block")
+	}
+}
+
+func TestCompactShardBlockBuilderWrongTxRoot(t *testing.T) {
+	shardBlock := newCompactTestShardBlock()
+	compactBlock := NewCompactShardBlock(shardBlock)
+	// short ids of tx 2 and tx 3 are swapped, tx root can't match
+	compactBlock.ShortTxIDs[1], compactBlock.ShortTxIDs[2] = compactBlock.ShortTxIDs[2], compactBlock.ShortTxIDs[1]
+	builder, err := NewCompactShardBlockBuilder(compactBlock)
+	if err != nil {
+		t.Fatal(err)
+	}
+	builder.AddTxs(shardBlock.Body.Transactions)
+	if _, err := builder.Build(); err == nil {
+		t.Fatal("block with wrong tx root must not be built")
+	}
+}
+
+func TestCompactShardBlockBuilderWrongPrefilledTx(t *testing.T) {
+	compactBlock := NewCompactShardBlock(newCompactTestShardBlock())
+	compactBlock.PrefilledTxs[0].Index = 10
+	if _, err := NewCompactShardBlockBuilder(compactBlock); err == nil {
+		t.Fatal("prefilled tx out of block must be rejected")
+	}
+}
+
+func TestIsCompactShardBlockActive(t *testing.T) {
+	bc := &BlockChain{config: Config{ChainParams: &Params{BeaconHeightBreakPointCompactBlock: 100}}}
+	shardBlock := newCompactTestShardBlock()
+	shardBlock.Header.BeaconHeight = 99
+	if bc.IsCompactShardBlockActive(shardBlock) {
+		t.Error("full block must be sent before the break point")
+	}
+	shardBlock.Header.BeaconHeight = 100
+	if !bc.IsCompactShardBlockActive(shardBlock) {
+		t.Error("compact block must be sent from the break point")
+	}
+}
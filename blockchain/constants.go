@@ -51,6 +51,7 @@ const (
 	MainnetOffset           = 4
 	MainnetSwapOffset       = 4
 	MainnetAssignOffset     = 8
+	MainnetUnbondingEpochs  = 4
 
 	MainNetShardCommitteeSize     = 32
 	MainNetMinShardCommitteeSize  = 22
@@ -91,6 +92,7 @@ const (
 	TestnetOffset           = 1
 	TestnetSwapOffset       = 1
 	TestnetAssignOffset     = 2
+	TestnetUnbondingEpochs  = 1
 
 	TestNetShardCommitteeSize     = 16
 	TestNetMinShardCommitteeSize  = 4
@@ -190,4 +192,51 @@ const (
 	StakeAction   = "stake"
 	AssignAction  = "assign"
 	StopAutoStake = "stopautostake"
+	// stake of swapped out validators is held in unbonding queue before being returned
+	UnbondingAction     = "unbonding"
+	ReturnStakingAction = "returnstaking"
+)
+
+// trustless bitcoin bridge
+const (
+	// token id of pBTC issued for deposits proved by relayed bitcoin headers
+	BTCRelayingIncTokenIDStr = "8e4e1f0872498ad6b52576cf4be1914118bec3048363e96f39e6e26fbbb1eca3"
+	// external token id of pBTC in bridge token info
+	BTCRelayingExternalTokenIDStr = "BTC"
+	// 1 satoshi = 10 nano pBTC
+	BTCRelayingSatoshiToNanoAmount = 10
+	// headers are relayed from the genesis of bitcoin networks
+	BTCRelayingCheckpointHeight     = 0
+	MainnetBTCRelayingConfirmations = 6
+	TestnetBTCRelayingConfirmations = 3
+	// committee controlled scripts of deposits, issuance is rejected until they are set
+	MainnetBTCDepositScriptStr = ""
+	TestnetBTCDepositScriptStr = ""
+)
+
+// evm chains bridge
+const (
+	// chain name of the ethereum deployment the bridge was launched with
+	MainnetETHChainName = "ethereum"
+	TestnetETHChainName = "kovan"
+	// blocks of deposits on ethereum must be followed by this number of blocks, the block of a deposit included
+	MainnetETHConfirmations = 15
+	TestnetETHConfirmations = 15
+)
+
+// incognito dao governance
+const (
+	// governance is off until a fork height is set, the dao reward is paid to the dao address before it
+	MainnetIncDAOGovernanceStartBeaconHeight = 0
+	TestnetIncDAOGovernanceStartBeaconHeight = 0
+	// number of beacon blocks a proposal is open for votes
+	MainnetIncDAOVotingPeriod = 10000
+	TestnetIncDAOVotingPeriod = 700
+	// number of beacon blocks a passed proposal waits before it is executed
+	MainnetIncDAOTimelock = 2000
+	TestnetIncDAOTimelock = 100
+	// percent of total voting weight which must vote on a proposal
+	IncDAOQuorumPercent = 20
+	// percent of cast voting weight which must be exceeded by weight voting yes
+	IncDAOPassPercent = 50
 )
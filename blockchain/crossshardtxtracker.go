@@ -0,0 +1,160 @@
+package blockchain
+
+import (
+	"encoding/json"
+	"sort"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/database"
+	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/incognitochain/incognito-chain/transaction"
+)
+
+const (
+	CrossShardTxIncludedInSourceBlock     = "includedinsourceblock"
+	CrossShardTxIncludedInCrossShardBlock = "includedincrossshardblock"
+	CrossShardTxAcceptedAtDestination     = "acceptedatdestination"
+	CrossShardTxUnknown                   = "unknown"
+)
+
+// CrossShardTransaction is stored for every tx sending output coins to other shards
+type CrossShardTransaction struct {
+	TxID                common.Hash
+	SourceShardID       byte
+	SourceBlockHash     common.Hash
+	SourceBlockHeight   uint64
+	DestinationShardIDs []byte
+}
+
+type CrossShardDeliveryStatus struct {
+	ShardID             byte
+	Status              string
+	CrossShardBlockHash common.Hash
+	AcceptedBlockHeight uint64
+}
+
+type CrossShardTransactionStatus struct {
+	CrossShardTransaction
+	Destinations []CrossShardDeliveryStatus
+	IsDelivered  bool
+}
+
+// getCrossShardDestinations return sorted list of shards receiving output coins (PRV or privacy token) of tx
+func getCrossShardDestinations(tx metadata.Transaction, shardID byte) []byte {
+	destinations := make(map[byte]bool)
+	if tx.GetProof() != nil {
+		for _, outCoin := range tx.GetProof().GetOutputCoins() {
+			destinations[common.GetShardIDFromLastByte(outCoin.CoinDetails.GetPubKeyLastByte())] = true
+		}
+	}
+	if tx.GetType() == common.TxCustomTokenPrivacyType {
+		customTokenPrivacyTx := tx.(*transaction.TxCustomTokenPrivacy)
+		if customTokenPrivacyTx.TxPrivacyTokenData.TxNormal.GetProof() != nil {
+			for _, outCoin := range customTokenPrivacyTx.TxPrivacyTokenData.TxNormal.GetProof().GetOutputCoins() {
+				destinations[common.GetShardIDFromLastByte(outCoin.CoinDetails.GetPubKeyLastByte())] = true
+			}
+		}
+	}
+	delete(destinations, shardID)
+	destinationShardIDs := []byte{}
+	for destinationShardID := range destinations {
+		destinationShardIDs = append(destinationShardIDs, destinationShardID)
+	}
+	sort.Slice(destinationShardIDs, func(i, j int) bool {
+		return destinationShardIDs[i] < destinationShardIDs[j]
+	})
+	return destinationShardIDs
+}
+
+// storeCrossShardTransactions index txs of shard block which send output coins to other shards
+func (blockchain *BlockChain) storeCrossShardTransactions(shardBlock *ShardBlock, bd *[]database.BatchData) error {
+	for _, tx := range shardBlock.Body.Transactions {
+		destinationShardIDs := getCrossShardDestinations(tx, shardBlock.Header.ShardID)
+		if len(destinationShardIDs) == 0 {
+			continue
+		}
+		crossShardTx := CrossShardTransaction{
+			TxID:                *tx.Hash(),
+			SourceShardID:       shardBlock.Header.ShardID,
+			SourceBlockHash:     shardBlock.Header.Hash(),
+			SourceBlockHeight:   shardBlock.Header.Height,
+			DestinationShardIDs: destinationShardIDs,
+		}
+		crossShardTxBytes, err := json.Marshal(crossShardTx)
+		if err != nil {
+			return NewBlockChainError(StoreCrossShardTransactionError, err)
+		}
+		err = blockchain.config.DataBase.StoreCrossShardTransaction(crossShardTx.TxID, crossShardTxBytes, bd)
+		if err != nil {
+			return NewBlockChainError(StoreCrossShardTransactionError, err)
+		}
+	}
+	return nil
+}
+
+func (blockchain *BlockChain) deleteCrossShardTransactions(shardBlock *ShardBlock) error {
+	for _, tx := range shardBlock.Body.Transactions {
+		if len(getCrossShardDestinations(tx, shardBlock.Header.ShardID)) == 0 {
+			continue
+		}
+		if err := blockchain.config.DataBase.DeleteCrossShardTransaction(*tx.Hash()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isSyncingShard tells if node stores blocks of a shard, only these nodes know if cross shard blocks are accepted by the shard
+func (blockchain *BlockChain) isSyncingShard(shardID byte) bool {
+	return common.IndexOfByte(shardID, blockchain.Synker.GetCurrentSyncShards()) >= 0
+}
+
+// GetCrossShardTransactionStatus return delivery status of a cross shard tx on each destination shard:
+// - included in source block: source shard block is stored but not confirmed by beacon yet
+// - included in cross shard block: beacon confirmed cross shard block (same hash as source block) for destination shard
+// - accepted at destination: destination shard block included the cross shard block
+// - unknown: node doesn't sync destination shard so it can't tell if the cross shard block is accepted
+func (blockchain *BlockChain) GetCrossShardTransactionStatus(txID common.Hash) (*CrossShardTransactionStatus, error) {
+	crossShardTxBytes, err := blockchain.config.DataBase.GetCrossShardTransaction(txID)
+	if err != nil {
+		return nil, NewBlockChainError(GetCrossShardTransactionStatusError, err)
+	}
+	if len(crossShardTxBytes) == 0 {
+		return nil, nil
+	}
+	var crossShardTx CrossShardTransaction
+	if err := json.Unmarshal(crossShardTxBytes, &crossShardTx); err != nil {
+		return nil, NewBlockChainError(GetCrossShardTransactionStatusError, err)
+	}
+	lastCrossShardState := blockchain.BestState.Beacon.GetLastCrossShardState()
+	result := &CrossShardTransactionStatus{
+		CrossShardTransaction: crossShardTx,
+		Destinations:          []CrossShardDeliveryStatus{},
+		IsDelivered:           true,
+	}
+	for _, destinationShardID := range crossShardTx.DestinationShardIDs {
+		deliveryStatus := CrossShardDeliveryStatus{
+			ShardID:             destinationShardID,
+			Status:              CrossShardTxIncludedInSourceBlock,
+			CrossShardBlockHash: crossShardTx.SourceBlockHash,
+		}
+		if lastCrossShardState[crossShardTx.SourceShardID][destinationShardID] >= crossShardTx.SourceBlockHeight {
+			deliveryStatus.Status = CrossShardTxIncludedInCrossShardBlock
+		}
+		if err := blockchain.config.DataBase.HasIncomingCrossShard(destinationShardID, crossShardTx.SourceShardID, crossShardTx.SourceBlockHash); err == nil {
+			acceptedBlockHeight, err := blockchain.config.DataBase.GetIncomingCrossShard(destinationShardID, crossShardTx.SourceShardID, crossShardTx.SourceBlockHash)
+			if err != nil {
+				return nil, NewBlockChainError(GetCrossShardTransactionStatusError, err)
+			}
+			deliveryStatus.Status = CrossShardTxAcceptedAtDestination
+			deliveryStatus.AcceptedBlockHeight = acceptedBlockHeight
+		} else if !blockchain.isSyncingShard(destinationShardID) {
+			deliveryStatus.Status = CrossShardTxUnknown
+		}
+		if deliveryStatus.Status != CrossShardTxAcceptedAtDestination {
+			result.IsDelivered = false
+		}
+		result.Destinations = append(result.Destinations, deliveryStatus)
+	}
+	return result, nil
+}
@@ -0,0 +1,80 @@
+package blockchain
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/database"
+)
+
+func TestGetCrossShardTransactionStatus(t *testing.T) {
+	dbPath, err := ioutil.TempDir(os.TempDir(), "test_crossshardtx")
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %+v", err)
+	}
+	defer os.RemoveAll(dbPath)
+	db, err := database.Open("leveldb", dbPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bc := &BlockChain{
+		BestState: &BestState{Beacon: &BeaconBestState{}},
+		config:    Config{DataBase: db},
+	}
+
+	status, err := bc.GetCrossShardTransactionStatus(common.Hash{1})
+	if err != nil || status != nil {
+		t.Errorf("unknown tx should not have status: %+v %+v", status, err)
+	}
+
+	crossShardTx := CrossShardTransaction{
+		TxID:                common.Hash{1},
+		SourceShardID:       0,
+		SourceBlockHash:     common.Hash{2},
+		SourceBlockHeight:   5,
+		DestinationShardIDs: []byte{1, 2},
+	}
+	crossShardTxBytes, _ := json.Marshal(crossShardTx)
+	if err := db.StoreCrossShardTransaction(crossShardTx.TxID, crossShardTxBytes, nil); err != nil {
+		t.Fatal(err)
+	}
+	bc.BestState.Beacon.LastCrossShardState = map[byte]map[byte]uint64{0: {1: 5, 2: 4}}
+	bc.Synker.Status.Shards = map[byte]struct{}{1: {}}
+
+	// node syncing shard 1 only can't tell delivery status on shard 2
+	status, err = bc.GetCrossShardTransactionStatus(crossShardTx.TxID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(status.Destinations) != 2 || status.IsDelivered {
+		t.Fatalf("unexpected status: %+v", status)
+	}
+	if status.Destinations[0].Status != CrossShardTxIncludedInCrossShardBlock || status.Destinations[1].Status != CrossShardTxUnknown {
+		t.Errorf("unexpected delivery status: %+v", status.Destinations)
+	}
+
+	if err := db.StoreIncomingCrossShard(1, 0, 7, crossShardTx.SourceBlockHash, nil); err != nil {
+		t.Fatal(err)
+	}
+	bc.Synker.Status.Shards = map[byte]struct{}{1: {}, 2: {}}
+	status, _ = bc.GetCrossShardTransactionStatus(crossShardTx.TxID)
+	if status.Destinations[0].Status != CrossShardTxAcceptedAtDestination || status.Destinations[0].AcceptedBlockHeight != 7 {
+		t.Errorf("unexpected delivery status on shard 1: %+v", status.Destinations[0])
+	}
+	if status.Destinations[1].Status != CrossShardTxIncludedInSourceBlock || status.IsDelivered {
+		t.Errorf("unexpected delivery status on shard 2: %+v", status.Destinations[1])
+	}
+
+	if err := db.StoreIncomingCrossShard(2, 0, 9, crossShardTx.SourceBlockHash, nil); err != nil {
+		t.Fatal(err)
+	}
+	// accepted status is kept after node stops syncing the shard
+	bc.Synker.Status.Shards = map[byte]struct{}{}
+	status, _ = bc.GetCrossShardTransactionStatus(crossShardTx.TxID)
+	if !status.IsDelivered || status.Destinations[1].AcceptedBlockHeight != 9 {
+		t.Errorf("tx should be delivered to all destination shards: %+v", status)
+	}
+}
@@ -0,0 +1,500 @@
+package blockchain
+
+import (
+	"encoding/json"
+	"math/big"
+	"sort"
+	"strings"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/database"
+	"github.com/incognitochain/incognito-chain/database/lvdb"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/incognitochain/incognito-chain/wallet"
+)
+
+// CurrentDAOGovernanceState holds the treasury of Incognito DAO, chain params changed by proposals
+// and proposals which are in voting or wait for their execution
+type CurrentDAOGovernanceState struct {
+	Proposals        map[common.Hash]*lvdb.DAOProposal
+	Treasury         map[common.Hash]uint64
+	GovernedParams   map[string]uint64
+	touchedProposals map[common.Hash]bool
+	treasuryTouched  bool
+	paramsTouched    bool
+}
+
+func InitCurrentDAOGovernanceStateFromDB(
+	db database.DatabaseInterface,
+) (*CurrentDAOGovernanceState, error) {
+	proposalsBytes, err := db.GetAllDAOProposals()
+	if err != nil {
+		return nil, err
+	}
+	proposals := make(map[common.Hash]*lvdb.DAOProposal)
+	for _, proposalBytes := range proposalsBytes {
+		var proposal lvdb.DAOProposal
+		err = json.Unmarshal(proposalBytes, &proposal)
+		if err != nil {
+			return nil, err
+		}
+		if proposal.Status != common.DAOProposalVotingStatus && proposal.Status != common.DAOProposalPassedStatus {
+			continue
+		}
+		proposals[proposal.ProposalID] = &proposal
+	}
+	treasury := make(map[common.Hash]uint64)
+	treasuryBytes, err := db.GetDAOTreasury()
+	if err != nil {
+		return nil, err
+	}
+	if len(treasuryBytes) > 0 {
+		err = json.Unmarshal(treasuryBytes, &treasury)
+		if err != nil {
+			return nil, err
+		}
+	}
+	governedParams, err := getDAOGovernedParamsFromDB(db)
+	if err != nil {
+		return nil, err
+	}
+	return &CurrentDAOGovernanceState{
+		Proposals:        proposals,
+		Treasury:         treasury,
+		GovernedParams:   governedParams,
+		touchedProposals: make(map[common.Hash]bool),
+	}, nil
+}
+
+// GetDAOGovernanceState returns the governance state which the next beacon block starts from
+func (blockchain *BlockChain) GetDAOGovernanceState() (*CurrentDAOGovernanceState, error) {
+	return InitCurrentDAOGovernanceStateFromDB(blockchain.GetDatabase())
+}
+
+// GetIncDAOGovernanceParams returns governance params of the chain, they are overridden by the ones of the governance state
+func (blockchain *BlockChain) GetIncDAOGovernanceParams() IncDAOGovernanceParams {
+	return blockchain.config.ChainParams.IncDAOGovernance
+}
+
+// GetDAOProposal returns nil if the proposal is not found
+func (blockchain *BlockChain) GetDAOProposal(proposalID common.Hash) (*lvdb.DAOProposal, error) {
+	return metadata.GetDAOProposal(blockchain.GetDatabase(), proposalID)
+}
+
+// GetAllDAOProposals returns proposals of every status in the order they were created
+func (blockchain *BlockChain) GetAllDAOProposals() ([]*lvdb.DAOProposal, error) {
+	proposalsBytes, err := blockchain.GetDatabase().GetAllDAOProposals()
+	if err != nil {
+		return nil, err
+	}
+	state := &CurrentDAOGovernanceState{Proposals: make(map[common.Hash]*lvdb.DAOProposal)}
+	for _, proposalBytes := range proposalsBytes {
+		var proposal lvdb.DAOProposal
+		err = json.Unmarshal(proposalBytes, &proposal)
+		if err != nil {
+			return nil, err
+		}
+		state.Proposals[proposal.ProposalID] = &proposal
+	}
+	return state.getSortedProposals(), nil
+}
+
+func getDAOGovernedParamsFromDB(db database.DatabaseInterface) (map[string]uint64, error) {
+	governedParams := make(map[string]uint64)
+	paramsBytes, err := db.GetDAOGovernedParams()
+	if err != nil {
+		return nil, err
+	}
+	if len(paramsBytes) > 0 {
+		err = json.Unmarshal(paramsBytes, &governedParams)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return governedParams, nil
+}
+
+func isIncDAOGovernanceActive(params IncDAOGovernanceParams, beaconHeight uint64) bool {
+	return params.StartBeaconHeight > 0 && beaconHeight >= params.StartBeaconHeight
+}
+
+// GetEffectiveParams returns governance params of the chain overridden by the ones changed by proposals
+func (state *CurrentDAOGovernanceState) GetEffectiveParams(params IncDAOGovernanceParams) IncDAOGovernanceParams {
+	if value, found := state.GovernedParams[metadata.DAOVotingPeriodParam]; found {
+		params.VotingPeriod = value
+	}
+	if value, found := state.GovernedParams[metadata.DAOQuorumPercentParam]; found {
+		params.QuorumPercent = value
+	}
+	if value, found := state.GovernedParams[metadata.DAOPassPercentParam]; found {
+		params.PassPercent = value
+	}
+	if value, found := state.GovernedParams[metadata.DAOTimelockParam]; found {
+		params.Timelock = value
+	}
+	return params
+}
+
+// applyDAOGovernedBridgeTokenLimits returns caps of bridge tokens overridden by the ones changed by proposals,
+// the caps of the chain params are not modified
+func applyDAOGovernedBridgeTokenLimits(
+	limits map[string]BridgeTokenLimit,
+	governedParams map[string]uint64,
+) map[string]BridgeTokenLimit {
+	if len(governedParams) == 0 {
+		return limits
+	}
+	governedLimits := make(map[string]BridgeTokenLimit, len(limits))
+	for tokenIDStr, limit := range limits {
+		governedLimits[tokenIDStr] = limit
+	}
+	for key, value := range governedParams {
+		parts := strings.SplitN(key, "-", 2)
+		if len(parts) != 2 || !metadata.IsBridgeTokenLimitParam(parts[0]) {
+			continue
+		}
+		limit := governedLimits[parts[1]]
+		switch parts[0] {
+		case metadata.BridgeMaxIssuingPerTxParam:
+			limit.MaxIssuingPerTx = value
+		case metadata.BridgeMaxIssuingPerEpochParam:
+			limit.MaxIssuingPerEpoch = value
+		case metadata.BridgeMaxBurningPerTxParam:
+			limit.MaxBurningPerTx = value
+		case metadata.BridgeMaxBurningPerEpochParam:
+			limit.MaxBurningPerEpoch = value
+		}
+		governedLimits[parts[1]] = limit
+	}
+	return governedLimits
+}
+
+// getSortedProposals returns proposals in the order they were created
+func (state *CurrentDAOGovernanceState) getSortedProposals() []*lvdb.DAOProposal {
+	proposals := []*lvdb.DAOProposal{}
+	for _, proposal := range state.Proposals {
+		proposals = append(proposals, proposal)
+	}
+	sort.Slice(proposals, func(i, j int) bool {
+		if proposals[i].CreatedBeaconHeight != proposals[j].CreatedBeaconHeight {
+			return proposals[i].CreatedBeaconHeight < proposals[j].CreatedBeaconHeight
+		}
+		return proposals[i].ProposalID.String() < proposals[j].ProposalID.String()
+	})
+	return proposals
+}
+
+func (state *CurrentDAOGovernanceState) addTreasuryReward(amounts map[common.Hash]uint64) {
+	for tokenID, amount := range amounts {
+		state.Treasury[tokenID] = addWithoutOverflow(state.Treasury[tokenID], amount)
+	}
+	state.treasuryTouched = true
+}
+
+func (state *CurrentDAOGovernanceState) hasTreasuryAmounts(amounts map[common.Hash]uint64) bool {
+	for tokenID, amount := range amounts {
+		if state.Treasury[tokenID] < amount {
+			return false
+		}
+	}
+	return true
+}
+
+// payFromTreasury deducts amounts from the treasury, it returns false and deducts nothing if the treasury is insufficient
+func (state *CurrentDAOGovernanceState) payFromTreasury(amounts map[common.Hash]uint64) bool {
+	if !state.hasTreasuryAmounts(amounts) {
+		return false
+	}
+	for tokenID, amount := range amounts {
+		state.Treasury[tokenID] -= amount
+	}
+	state.treasuryTouched = true
+	return true
+}
+
+// applyTreasuryPayout pays an accepted payout of a treasury spend, a rejected payout fails the proposal
+func (state *CurrentDAOGovernanceState) applyTreasuryPayout(content metadata.DAOTreasuryPayoutContent, accepted bool) {
+	if accepted && state.payFromTreasury(content.Amounts) {
+		state.setProposalStatus(content.ProposalID, common.DAOProposalExecutedStatus)
+		return
+	}
+	state.setProposalStatus(content.ProposalID, common.DAOProposalFailedStatus)
+}
+
+func (state *CurrentDAOGovernanceState) addProposal(proposal *lvdb.DAOProposal) {
+	if proposal.Votes == nil {
+		proposal.Votes = make(map[string]bool)
+	}
+	state.Proposals[proposal.ProposalID] = proposal
+	state.touchedProposals[proposal.ProposalID] = true
+}
+
+// applyVote records a vote of a normalized voter address, it returns false if the proposal is not open for votes
+func (state *CurrentDAOGovernanceState) applyVote(
+	proposalID common.Hash,
+	voterAddress string,
+	approve bool,
+	beaconHeight uint64,
+) bool {
+	proposal, found := state.Proposals[proposalID]
+	if !found || proposal.Status != common.DAOProposalVotingStatus || beaconHeight > proposal.VotingEndBeaconHeight {
+		return false
+	}
+	proposal.Votes[voterAddress] = approve
+	state.touchedProposals[proposalID] = true
+	return true
+}
+
+func (state *CurrentDAOGovernanceState) applyTally(content metadata.DAOProposalTallyContent, passed bool) {
+	proposal, found := state.Proposals[content.ProposalID]
+	if !found {
+		return
+	}
+	proposal.YesWeight = content.YesWeight
+	proposal.NoWeight = content.NoWeight
+	proposal.TotalWeight = content.TotalWeight
+	proposal.ExecutionBeaconHeight = content.ExecutionBeaconHeight
+	proposal.Status = common.DAOProposalRejectedStatus
+	if passed {
+		proposal.Status = common.DAOProposalPassedStatus
+	}
+	state.touchedProposals[content.ProposalID] = true
+}
+
+func (state *CurrentDAOGovernanceState) setProposalStatus(proposalID common.Hash, status string) {
+	proposal, found := state.Proposals[proposalID]
+	if !found {
+		return
+	}
+	proposal.Status = status
+	state.touchedProposals[proposalID] = true
+}
+
+func (state *CurrentDAOGovernanceState) setGovernedParam(content metadata.DAOParamChangeContent) {
+	state.GovernedParams[metadata.BuildDAOGovernedParamKey(content.ParamName, content.TokenID)] = content.ParamValue
+	state.paramsTouched = true
+}
+
+// isDAOProposalPassed returns true if votes reach the quorum of total weight and
+// weight voting yes is over the pass percent of cast weight
+func isDAOProposalPassed(
+	yesWeight uint64,
+	noWeight uint64,
+	totalWeight uint64,
+	params IncDAOGovernanceParams,
+) bool {
+	if totalWeight == 0 {
+		return false
+	}
+	hundred := big.NewInt(100)
+	castWeight := new(big.Int).Add(new(big.Int).SetUint64(yesWeight), new(big.Int).SetUint64(noWeight))
+	if castWeight.Sign() == 0 {
+		return false
+	}
+	quorum := new(big.Int).Mul(new(big.Int).SetUint64(totalWeight), new(big.Int).SetUint64(params.QuorumPercent))
+	if new(big.Int).Mul(castWeight, hundred).Cmp(quorum) < 0 {
+		return false
+	}
+	pass := new(big.Int).Mul(castWeight, new(big.Int).SetUint64(params.PassPercent))
+	return new(big.Int).Mul(new(big.Int).SetUint64(yesWeight), hundred).Cmp(pass) > 0
+}
+
+// normalizeDAOAddress returns the payment address only serialization of an address
+// so that every key serialization of an account votes as the same voter
+func normalizeDAOAddress(addressStr string) (string, error) {
+	keyWallet, err := wallet.Base58CheckDeserialize(addressStr)
+	if err != nil {
+		return "", err
+	}
+	paymentAddressWallet := wallet.KeyWallet{}
+	paymentAddressWallet.KeySet.PaymentAddress = keyWallet.KeySet.PaymentAddress
+	return paymentAddressWallet.Base58CheckSerialize(wallet.PaymentAddressType), nil
+}
+
+// daoVotingWeights lazily computes voting weights once per beacon block since most blocks do not need them
+type daoVotingWeights struct {
+	beaconBestState    *BeaconBestState
+	stakingPoolState   *CurrentStakingPoolState
+	db                 database.DatabaseInterface
+	stakingAmountShard uint64
+	weights            map[string]uint64
+	totalWeight        uint64
+	loaded             bool
+}
+
+func newDAOVotingWeights(
+	beaconBestState *BeaconBestState,
+	stakingPoolState *CurrentStakingPoolState,
+	db database.DatabaseInterface,
+	stakingAmountShard uint64,
+) *daoVotingWeights {
+	return &daoVotingWeights{
+		beaconBestState:    beaconBestState,
+		stakingPoolState:   stakingPoolState,
+		db:                 db,
+		stakingAmountShard: stakingAmountShard,
+	}
+}
+
+// get returns staked PRV by normalized payment address and their sum
+func (votingWeights *daoVotingWeights) get() (map[string]uint64, uint64, error) {
+	if votingWeights.loaded {
+		return votingWeights.weights, votingWeights.totalWeight, nil
+	}
+	weights, totalWeight, err := getDAOVotingWeights(
+		votingWeights.beaconBestState,
+		votingWeights.stakingPoolState,
+		votingWeights.db,
+		votingWeights.stakingAmountShard,
+	)
+	if err != nil {
+		return nil, 0, err
+	}
+	votingWeights.weights = weights
+	votingWeights.totalWeight = totalWeight
+	votingWeights.loaded = true
+	return weights, totalWeight, nil
+}
+
+/*
+getDAOVotingWeights counts staked PRV of accounts:
+- stake of a shard validator, in committee, pending or candidate, counts for its reward receiver
+- stake of a validator bound to a staked pool counts for delegators of the pool, pro rata to their delegations
+*/
+func getDAOVotingWeights(
+	beaconBestState *BeaconBestState,
+	stakingPoolState *CurrentStakingPoolState,
+	db database.DatabaseInterface,
+	stakingAmountShard uint64,
+) (map[string]uint64, uint64, error) {
+	weights := map[string]uint64{}
+	totalWeight := uint64(0)
+	addWeight := func(addressStr string, amount uint64) {
+		normalizedAddressStr, err := normalizeDAOAddress(addressStr)
+		if err != nil || amount == 0 {
+			return
+		}
+		weights[normalizedAddressStr] = addWithoutOverflow(weights[normalizedAddressStr], amount)
+		totalWeight = addWithoutOverflow(totalWeight, amount)
+	}
+	validators := []incognitokey.CommitteePublicKey{}
+	var shardIDs []int
+	for shardID := range beaconBestState.ShardCommittee {
+		shardIDs = append(shardIDs, int(shardID))
+	}
+	for shardID := range beaconBestState.ShardPendingValidator {
+		if _, found := beaconBestState.ShardCommittee[shardID]; !found {
+			shardIDs = append(shardIDs, int(shardID))
+		}
+	}
+	sort.Ints(shardIDs)
+	for _, shardID := range shardIDs {
+		validators = append(validators, beaconBestState.ShardCommittee[byte(shardID)]...)
+		validators = append(validators, beaconBestState.ShardPendingValidator[byte(shardID)]...)
+	}
+	validators = append(validators, beaconBestState.CandidateShardWaitingForCurrentRandom...)
+	validators = append(validators, beaconBestState.CandidateShardWaitingForNextRandom...)
+	counted := map[string]bool{}
+	for _, validator := range validators {
+		validatorStr, err := validator.ToBase58()
+		if err != nil {
+			return nil, 0, err
+		}
+		if counted[validatorStr] {
+			continue
+		}
+		counted[validatorStr] = true
+		stakingPool, found := stakingPoolState.StakingPools[validatorStr]
+		if found && stakingPool != nil && stakingPool.IsStaked {
+			continue
+		}
+		addWeight(beaconBestState.RewardReceiver[validator.GetIncKeyBase58()], stakingAmountShard)
+	}
+	var committeePublicKeys []string
+	for k := range stakingPoolState.StakingPools {
+		committeePublicKeys = append(committeePublicKeys, k)
+	}
+	sort.Strings(committeePublicKeys)
+	for _, committeePublicKey := range committeePublicKeys {
+		stakingPool := stakingPoolState.StakingPools[committeePublicKey]
+		if stakingPool == nil || !stakingPool.IsStaked || !counted[committeePublicKey] {
+			continue
+		}
+		delegations, err := stakingPoolState.getDelegations(db, committeePublicKey)
+		if err != nil {
+			return nil, 0, err
+		}
+		for delegatorAddressStr, amount := range delegations {
+			addWeight(delegatorAddressStr, amount)
+		}
+	}
+	return weights, totalWeight, nil
+}
+
+// GetDAOVotingWeight returns the voting weight of an account and the total one,
+// proposals and votes of the next beacon block are checked against them
+func (blockchain *BlockChain) GetDAOVotingWeight(addressStr string) (uint64, uint64, error) {
+	normalizedAddressStr, err := normalizeDAOAddress(addressStr)
+	if err != nil {
+		return 0, 0, err
+	}
+	db := blockchain.GetDatabase()
+	stakingPoolState, err := InitCurrentStakingPoolStateFromDB(db)
+	if err != nil {
+		return 0, 0, err
+	}
+	weights, totalWeight, err := getDAOVotingWeights(blockchain.BestState.Beacon, stakingPoolState, db, blockchain.config.ChainParams.StakingAmountShard)
+	if err != nil {
+		return 0, 0, err
+	}
+	return weights[normalizedAddressStr], totalWeight, nil
+}
+
+func storeDAOGovernanceStateToDB(
+	db database.DatabaseInterface,
+	currentDAOGovernanceState *CurrentDAOGovernanceState,
+) error {
+	var keys []common.Hash
+	for k := range currentDAOGovernanceState.touchedProposals {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].String() < keys[j].String()
+	})
+	for _, proposalID := range keys {
+		proposal, found := currentDAOGovernanceState.Proposals[proposalID]
+		if !found || proposal == nil {
+			continue
+		}
+		proposalBytes, err := json.Marshal(proposal)
+		if err != nil {
+			return err
+		}
+		err = db.StoreDAOProposal(proposalID, proposalBytes)
+		if err != nil {
+			return err
+		}
+	}
+	if currentDAOGovernanceState.treasuryTouched {
+		treasuryBytes, err := json.Marshal(currentDAOGovernanceState.Treasury)
+		if err != nil {
+			return err
+		}
+		err = db.StoreDAOTreasury(treasuryBytes)
+		if err != nil {
+			return err
+		}
+	}
+	if currentDAOGovernanceState.paramsTouched {
+		paramsBytes, err := json.Marshal(currentDAOGovernanceState.GovernedParams)
+		if err != nil {
+			return err
+		}
+		err = db.StoreDAOGovernedParams(paramsBytes)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,213 @@
+package blockchain
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/database/lvdb"
+	"github.com/incognitochain/incognito-chain/metadata"
+)
+
+func newTestDAOGovernanceState(treasury map[common.Hash]uint64) *CurrentDAOGovernanceState {
+	return &CurrentDAOGovernanceState{
+		Proposals:        map[common.Hash]*lvdb.DAOProposal{},
+		Treasury:         treasury,
+		GovernedParams:   map[string]uint64{},
+		touchedProposals: map[common.Hash]bool{},
+	}
+}
+
+func newTestDAOVotingWeights(weights map[string]uint64, totalWeight uint64) *daoVotingWeights {
+	return &daoVotingWeights{
+		weights:     weights,
+		totalWeight: totalWeight,
+		loaded:      true,
+	}
+}
+
+func TestIsDAOProposalPassed(t *testing.T) {
+	params := IncDAOGovernanceParams{QuorumPercent: 20, PassPercent: 50}
+	if isDAOProposalPassed(19, 0, 100, params) {
+		t.Errorf("votes under the quorum should not pass")
+	}
+	if !isDAOProposalPassed(20, 0, 100, params) {
+		t.Errorf("votes reaching the quorum should pass")
+	}
+	if isDAOProposalPassed(15, 15, 100, params) {
+		t.Errorf("half of cast weight voting yes should not pass")
+	}
+	if !isDAOProposalPassed(16, 15, 100, params) {
+		t.Errorf("more than half of cast weight voting yes should pass")
+	}
+	if isDAOProposalPassed(0, 0, 0, params) {
+		t.Errorf("proposals should not pass without staked PRV")
+	}
+	// weights of the whole supply must not overflow
+	if !isDAOProposalPassed(1<<62, 1<<61, 1<<63, params) {
+		t.Errorf("large weights should be compared without overflow")
+	}
+}
+
+func TestDAOGovernanceVoting(t *testing.T) {
+	state := newTestDAOGovernanceState(map[common.Hash]uint64{})
+	proposalID := common.Hash{1}
+	state.addProposal(&lvdb.DAOProposal{
+		ProposalID:            proposalID,
+		Status:                common.DAOProposalVotingStatus,
+		VotingEndBeaconHeight: 20,
+	})
+	if !state.applyVote(proposalID, "voter", false, 20) {
+		t.Errorf("vote at the end of voting should be accepted")
+	}
+	if !state.applyVote(proposalID, "voter", true, 20) || !state.Proposals[proposalID].Votes["voter"] {
+		t.Errorf("a later vote should replace the earlier one")
+	}
+	if state.applyVote(proposalID, "voter", false, 21) {
+		t.Errorf("vote after the end of voting should be rejected")
+	}
+	if state.applyVote(common.Hash{2}, "voter", true, 10) {
+		t.Errorf("vote on an unknown proposal should be rejected")
+	}
+}
+
+func TestDAOGovernanceTallyAndExecution(t *testing.T) {
+	params := &Params{
+		IncDAOGovernance: IncDAOGovernanceParams{
+			StartBeaconHeight: 1,
+			VotingPeriod:      10,
+			QuorumPercent:     20,
+			PassPercent:       50,
+			Timelock:          5,
+		},
+	}
+	bc := &BlockChain{config: Config{ChainParams: params}}
+	state := newTestDAOGovernanceState(map[common.Hash]uint64{common.PRVCoinID: 1000})
+	weights := newTestDAOVotingWeights(map[string]uint64{"yes": 60, "no": 40}, 200)
+	for i := byte(1); i <= 3; i++ {
+		state.addProposal(&lvdb.DAOProposal{
+			ProposalID:            common.Hash{i},
+			Type:                  metadata.DAOTreasurySpendProposal,
+			ReceiverAddress:       TestnetIncognitoDAOAddress,
+			Amounts:               map[common.Hash]uint64{common.PRVCoinID: 600},
+			Status:                common.DAOProposalVotingStatus,
+			CreatedBeaconHeight:   10,
+			VotingEndBeaconHeight: 20,
+			Votes:                 map[string]bool{"yes": true, "no": false},
+		})
+	}
+	// the third proposal does not reach the pass percent
+	state.Proposals[common.Hash{3}].Votes["yes"] = false
+
+	insts := bc.buildInstructionsForDAOGovernance(state, weights, 20)
+	if len(insts) != 0 {
+		t.Errorf("proposals should not be tallied before the end of voting: %+v", insts)
+	}
+	insts = bc.buildInstructionsForDAOGovernance(state, weights, 21)
+	if len(insts) != 3 {
+		t.Fatalf("unexpected tally instructions: %+v", insts)
+	}
+	if insts[0][2] != common.DAOProposalPassedChainStatus || insts[2][2] != common.DAOGovernanceRejectedChainStatus {
+		t.Errorf("unexpected tally statuses: %+v", insts)
+	}
+	if state.Proposals[common.Hash{1}].ExecutionBeaconHeight != 26 || state.Proposals[common.Hash{3}].Status != common.DAOProposalRejectedStatus {
+		t.Errorf("unexpected tallied proposals: %+v %+v", state.Proposals[common.Hash{1}], state.Proposals[common.Hash{3}])
+	}
+
+	insts = bc.buildInstructionsForDAOGovernance(state, weights, 25)
+	if len(insts) != 0 {
+		t.Errorf("passed proposals should wait for the timelock: %+v", insts)
+	}
+	// the treasury only pays the first proposal
+	insts = bc.buildInstructionsForDAOGovernance(state, weights, 26)
+	if len(insts) != 2 || insts[0][0] != strconv.Itoa(metadata.DAOTreasuryPayoutMeta) {
+		t.Fatalf("unexpected payout instructions: %+v", insts)
+	}
+	if insts[0][2] != common.DAOGovernanceAcceptedChainStatus || insts[1][2] != common.DAOGovernanceRejectedChainStatus {
+		t.Errorf("unexpected payout statuses: %+v", insts)
+	}
+	if state.Treasury[common.PRVCoinID] != 400 {
+		t.Errorf("unexpected treasury: %+v", state.Treasury)
+	}
+	if state.Proposals[common.Hash{1}].Status != common.DAOProposalExecutedStatus || state.Proposals[common.Hash{2}].Status != common.DAOProposalFailedStatus {
+		t.Errorf("unexpected executed proposals: %+v %+v", state.Proposals[common.Hash{1}], state.Proposals[common.Hash{2}])
+	}
+}
+
+func TestDAOGovernedParams(t *testing.T) {
+	tokenID := common.Hash{1}
+	state := newTestDAOGovernanceState(map[common.Hash]uint64{})
+	state.setGovernedParam(metadata.DAOParamChangeContent{ParamName: metadata.DAOTimelockParam, ParamValue: 7})
+	state.setGovernedParam(metadata.DAOParamChangeContent{ParamName: metadata.BridgeMaxIssuingPerTxParam, TokenID: tokenID, ParamValue: 50})
+
+	params := state.GetEffectiveParams(IncDAOGovernanceParams{VotingPeriod: 10, Timelock: 5})
+	if params.Timelock != 7 || params.VotingPeriod != 10 {
+		t.Errorf("unexpected effective params: %+v", params)
+	}
+
+	limits := map[string]BridgeTokenLimit{
+		tokenID.String(): {MaxIssuingPerTx: 100, MaxBurningPerTx: 100},
+	}
+	governedLimits := applyDAOGovernedBridgeTokenLimits(limits, state.GovernedParams)
+	if governedLimits[tokenID.String()].MaxIssuingPerTx != 50 || governedLimits[tokenID.String()].MaxBurningPerTx != 100 {
+		t.Errorf("unexpected governed limits: %+v", governedLimits)
+	}
+	if limits[tokenID.String()].MaxIssuingPerTx != 100 {
+		t.Errorf("limits of chain params should not be modified")
+	}
+}
+
+func TestDAOGovernanceRevert(t *testing.T) {
+	_, db, cleanup := newTestStakingPoolChain(t)
+	defer cleanup()
+	quorumKey := metadata.BuildDAOGovernedParamKey(metadata.DAOQuorumPercentParam, common.Hash{})
+	storeBlock := func(update func(state *CurrentDAOGovernanceState)) {
+		if err := db.CleanBackup(true, 0); err != nil {
+			t.Fatal(err)
+		}
+		state, err := InitCurrentDAOGovernanceStateFromDB(db)
+		if err != nil {
+			t.Fatal(err)
+		}
+		update(state)
+		if err := storeDAOGovernanceStateToDB(db, state); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	storeBlock(func(state *CurrentDAOGovernanceState) {
+		state.addTreasuryReward(map[common.Hash]uint64{common.PRVCoinID: 1000})
+		state.addProposal(&lvdb.DAOProposal{ProposalID: common.Hash{1}, Status: common.DAOProposalPassedStatus})
+		state.setGovernedParam(metadata.DAOParamChangeContent{ParamName: metadata.DAOQuorumPercentParam, ParamValue: 30})
+	})
+	// a reverted block gives the payout back to the treasury and restores the proposal and params
+	storeBlock(func(state *CurrentDAOGovernanceState) {
+		state.applyTreasuryPayout(metadata.DAOTreasuryPayoutContent{
+			ProposalID: common.Hash{1},
+			Amounts:    map[common.Hash]uint64{common.PRVCoinID: 400},
+		}, true)
+		state.addProposal(&lvdb.DAOProposal{ProposalID: common.Hash{2}, Status: common.DAOProposalVotingStatus})
+		state.setGovernedParam(metadata.DAOParamChangeContent{ParamName: metadata.DAOQuorumPercentParam, ParamValue: 40})
+	})
+	if err := db.RestoreBeaconStates(); err != nil {
+		t.Fatal(err)
+	}
+	state, err := InitCurrentDAOGovernanceStateFromDB(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// token ids are json map keys of the stored treasury, which common.Hash does not decode, so only amounts are checked
+	treasuryAmount := uint64(0)
+	for _, amount := range state.Treasury {
+		treasuryAmount += amount
+	}
+	if treasuryAmount != 1000 {
+		t.Errorf("unexpected treasury after revert: %+v", state.Treasury)
+	}
+	if len(state.Proposals) != 1 || state.Proposals[common.Hash{1}] == nil || state.Proposals[common.Hash{1}].Status != common.DAOProposalPassedStatus {
+		t.Errorf("unexpected proposals after revert: %+v", state.Proposals)
+	}
+	if state.GovernedParams[quorumKey] != 30 {
+		t.Errorf("unexpected governed params after revert: %+v", state.GovernedParams)
+	}
+}
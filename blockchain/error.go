@@ -164,6 +164,23 @@ const (
 	NotEnoughRewardError
 	InitPDETradeResponseTransactionError
 	ProcessPDEInstructionError
+	ProcessStakingPoolInstructionError
+	InitStakingPoolResponseTransactionError
+	ProcessUnbondingInstructionError
+	BuildReturnStakingInstructionError
+	StoreCrossShardTransactionError
+	GetCrossShardTransactionStatusError
+	ReconstructCompactShardBlockError
+	DecodeStoredBlockError
+	MigrateStoredBlockError
+	ProcessPrivacyTokenRegistryInstructionError
+	ProcessMintableTokenInstructionError
+	ProcessBTCRelayingInstructionError
+	GetEVMChainError
+	ProcessBridgeLimiterInstructionError
+	ProcessDAOGovernanceInstructionError
+	DecodeStoredBestStateError
+	VerifyStoredBlockError
 )
 
 var ErrCodeMessage = map[int]struct {
@@ -313,6 +330,23 @@ var ErrCodeMessage = map[int]struct {
 	NotEnoughRewardError:                              {-1140, "Not enough reward Error"},
 	InitPDETradeResponseTransactionError:              {-1141, "Init PDE trade response tx Error"},
 	ProcessPDEInstructionError:                        {-1142, "Process PDE instruction Error"},
+	ProcessStakingPoolInstructionError:                {-1143, "Process staking pool instruction Error"},
+	InitStakingPoolResponseTransactionError:           {-1144, "Init staking pool response tx Error"},
+	ProcessUnbondingInstructionError:                  {-1145, "Process unbonding instruction Error"},
+	BuildReturnStakingInstructionError:                {-1146, "Build return staking instruction Error"},
+	StoreCrossShardTransactionError:                   {-1147, "Store cross shard transaction Error"},
+	GetCrossShardTransactionStatusError:               {-1148, "Get cross shard transaction status Error"},
+	ReconstructCompactShardBlockError:                 {-1149, "Reconstruct compact shard block Error"},
+	DecodeStoredBlockError:                            {-1150, "Decode stored block Error"},
+	MigrateStoredBlockError:                           {-1151, "Migrate stored block Error"},
+	ProcessPrivacyTokenRegistryInstructionError:       {-1152, "Process privacy token registry instruction Error"},
+	ProcessMintableTokenInstructionError:              {-1153, "Process mintable token instruction Error"},
+	ProcessBTCRelayingInstructionError:                {-1154, "Process btc relaying instruction Error"},
+	GetEVMChainError:                                  {-1155, "Get evm chain Error"},
+	ProcessBridgeLimiterInstructionError:              {-1156, "Process bridge limiter instruction Error"},
+	ProcessDAOGovernanceInstructionError:              {-1157, "Process dao governance instruction Error"},
+	DecodeStoredBestStateError:                        {-1158, "Decode stored best state Error"},
+	VerifyStoredBlockError:                            {-1159, "Verify stored block Error"},
 }
 
 type BlockChainError struct {
@@ -110,6 +110,7 @@ type ChainInterface interface {
 	ValidateBlockSignatures(block common.BlockInterface, committee []incognitokey.CommitteePublicKey) error
 	ValidatePreSignBlock(block common.BlockInterface) error
 	GetShardID() int
+	IsCompactBlockActive(block common.BlockInterface) bool
 }
 
 type BestStateInterface interface {
@@ -0,0 +1,68 @@
+package blockchain
+
+import (
+	"encoding/json"
+
+	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/incognitochain/incognito-chain/privacy"
+	"github.com/incognitochain/incognito-chain/transaction"
+	"github.com/incognitochain/incognito-chain/wallet"
+)
+
+// buildMintableTokenMintTx issues minted amount of an accepted mint request to its receiver
+func (blockGenerator *BlockGenerator) buildMintableTokenMintTx(
+	contentStr string,
+	producerPrivateKey *privacy.PrivateKey,
+	shardID byte,
+) (metadata.Transaction, error) {
+	var mintContent metadata.MintableTokenMintAcceptedContent
+	err := json.Unmarshal([]byte(contentStr), &mintContent)
+	if err != nil {
+		Logger.log.Errorf("ERROR: an error occured while unmarshaling mintable token mint content: %+v", err)
+		return nil, nil
+	}
+	if mintContent.ShardID != shardID {
+		return nil, nil
+	}
+	keyWallet, err := wallet.Base58CheckDeserialize(mintContent.ReceiverAddressStr)
+	if err != nil {
+		Logger.log.Errorf("ERROR: an error occured while deserializing receiver address string: %+v", err)
+		return nil, nil
+	}
+	mintRes := metadata.NewMintableTokenResponse(
+		mintContent.TxReqID,
+		metadata.MintableTokenResponseMeta,
+	)
+	receiver := &privacy.PaymentInfo{
+		Amount:         mintContent.Amount,
+		PaymentAddress: keyWallet.KeySet.PaymentAddress,
+	}
+	tokenParams := &transaction.CustomTokenPrivacyParamTx{
+		PropertyID:     mintContent.TokenID.String(),
+		PropertyName:   mintContent.TokenName,
+		PropertySymbol: mintContent.TokenName,
+		Amount:         mintContent.Amount,
+		TokenTxType:    transaction.CustomTokenInit,
+		Receiver:       []*privacy.PaymentInfo{receiver},
+		TokenInput:     []*privacy.InputCoin{},
+		Mintable:       true,
+	}
+	resTx := &transaction.TxCustomTokenPrivacy{}
+	initErr := resTx.Init(
+		transaction.NewTxPrivacyTokenInitParams(producerPrivateKey,
+			[]*privacy.PaymentInfo{},
+			nil,
+			0,
+			tokenParams,
+			blockGenerator.chain.config.DataBase,
+			mintRes,
+			false,
+			false,
+			shardID,
+			nil))
+	if initErr != nil {
+		Logger.log.Errorf("ERROR: an error occured while initializing mintable token response tx: %+v", initErr)
+		return nil, nil
+	}
+	return resTx, nil
+}
@@ -0,0 +1,130 @@
+package blockchain
+
+import (
+	"encoding/json"
+	"math"
+	"sort"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/database"
+	"github.com/incognitochain/incognito-chain/database/lvdb"
+	"github.com/incognitochain/incognito-chain/metadata"
+)
+
+type CurrentMintableTokenState struct {
+	Tokens        map[common.Hash]*lvdb.MintableToken
+	touchedTokens map[common.Hash]bool
+}
+
+func InitCurrentMintableTokenStateFromDB(
+	db database.DatabaseInterface,
+) (*CurrentMintableTokenState, error) {
+	tokensBytes, err := db.GetAllMintableTokens()
+	if err != nil {
+		return nil, err
+	}
+	tokens := make(map[common.Hash]*lvdb.MintableToken)
+	for _, tokenBytes := range tokensBytes {
+		var token lvdb.MintableToken
+		err = json.Unmarshal(tokenBytes, &token)
+		if err != nil {
+			return nil, err
+		}
+		tokens[token.TokenID] = &token
+	}
+	return &CurrentMintableTokenState{
+		Tokens:        tokens,
+		touchedTokens: make(map[common.Hash]bool),
+	}, nil
+}
+
+// applyMintableTokenInit adds a new token to the state, it returns false if the token id is used
+func (state *CurrentMintableTokenState) applyMintableTokenInit(
+	initAction metadata.MintableTokenInitAction,
+	beaconHeight uint64,
+) bool {
+	if _, found := state.Tokens[initAction.TokenID]; found {
+		return false
+	}
+	state.Tokens[initAction.TokenID] = &lvdb.MintableToken{
+		TokenID:             initAction.TokenID,
+		TokenName:           initAction.Meta.TokenName,
+		TokenSymbol:         initAction.Meta.TokenSymbol,
+		AuthorityAddresses:  initAction.Meta.AuthorityAddresses,
+		RequiredSignatures:  initAction.Meta.RequiredSignatures,
+		CreatedBeaconHeight: beaconHeight,
+		InitTxReqID:         initAction.TxReqID,
+	}
+	state.touchedTokens[initAction.TokenID] = true
+	return true
+}
+
+/*
+	applyMintableTokenMint counts up supply of a token, it returns false if:
+	- token is not found
+	- nonce is not the next mint nonce of token
+	- supply overflows
+	Signatures of the request are verified by shards because the minting authority never changes
+*/
+func (state *CurrentMintableTokenState) applyMintableTokenMint(
+	tokenID common.Hash,
+	amount uint64,
+	nonce uint64,
+) bool {
+	token, found := state.Tokens[tokenID]
+	if !found || token == nil {
+		return false
+	}
+	if nonce != token.MintNonce || token.TotalMinted > math.MaxUint64-amount {
+		return false
+	}
+	token.TotalMinted += amount
+	token.MintNonce++
+	state.touchedTokens[tokenID] = true
+	return true
+}
+
+// applyMintableTokenBurn counts up burned amount of a token, coins of a burn request are already burned on shard
+func (state *CurrentMintableTokenState) applyMintableTokenBurn(
+	tokenID common.Hash,
+	amount uint64,
+) bool {
+	token, found := state.Tokens[tokenID]
+	if !found || token == nil {
+		return false
+	}
+	if token.TotalBurned > math.MaxUint64-amount {
+		return false
+	}
+	token.TotalBurned += amount
+	state.touchedTokens[tokenID] = true
+	return true
+}
+
+func storeMintableTokenStateToDB(
+	db database.DatabaseInterface,
+	currentMintableTokenState *CurrentMintableTokenState,
+) error {
+	var keys []common.Hash
+	for k := range currentMintableTokenState.touchedTokens {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].String() < keys[j].String()
+	})
+	for _, tokenID := range keys {
+		token, found := currentMintableTokenState.Tokens[tokenID]
+		if !found || token == nil {
+			continue
+		}
+		tokenBytes, err := json.Marshal(token)
+		if err != nil {
+			return err
+		}
+		err = db.StoreMintableToken(tokenID, tokenBytes)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
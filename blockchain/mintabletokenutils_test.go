@@ -0,0 +1,110 @@
+package blockchain
+
+import (
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/database/lvdb"
+	"github.com/incognitochain/incognito-chain/metadata"
+)
+
+func TestMintableTokenSupply(t *testing.T) {
+	state := &CurrentMintableTokenState{
+		Tokens:        make(map[common.Hash]*lvdb.MintableToken),
+		touchedTokens: make(map[common.Hash]bool),
+	}
+	tokenID := metadata.GetMintableTokenID(common.Hash{1})
+	initAction := metadata.MintableTokenInitAction{
+		Meta: metadata.MintableTokenInit{
+			TokenName:          "Stable",
+			TokenSymbol:        "STB",
+			AuthorityAddresses: []string{"authority"},
+			RequiredSignatures: 1,
+		},
+		TokenID: tokenID,
+		TxReqID: common.Hash{1},
+	}
+	if !state.applyMintableTokenInit(initAction, 10) {
+		t.Errorf("init of a new token should be accepted")
+	}
+	if state.applyMintableTokenInit(initAction, 11) {
+		t.Errorf("init of an existed token should be rejected")
+	}
+
+	if !state.applyMintableTokenMint(tokenID, 100, 0) {
+		t.Errorf("mint with the next nonce should be accepted")
+	}
+	if state.applyMintableTokenMint(tokenID, 100, 0) {
+		t.Errorf("mint with a used nonce should be rejected")
+	}
+	if state.applyMintableTokenMint(common.Hash{2}, 100, 0) {
+		t.Errorf("mint of an unknown token should be rejected")
+	}
+	if !state.applyMintableTokenMint(tokenID, 50, 1) {
+		t.Errorf("mint with the next nonce should be accepted")
+	}
+	if !state.applyMintableTokenBurn(tokenID, 30) {
+		t.Errorf("burn of a mintable token should be accepted")
+	}
+
+	token := state.Tokens[tokenID]
+	if token.TotalMinted != 150 || token.TotalBurned != 30 || token.CirculatingSupply() != 120 || token.MintNonce != 2 {
+		t.Errorf("unexpected supply: %+v", token)
+	}
+}
+
+func TestMintableTokenRevert(t *testing.T) {
+	_, db, cleanup := newTestStakingPoolChain(t)
+	defer cleanup()
+	tokenID := metadata.GetMintableTokenID(common.Hash{1})
+	storeBlock := func(update func(state *CurrentMintableTokenState)) {
+		if err := db.CleanBackup(true, 0); err != nil {
+			t.Fatal(err)
+		}
+		state, err := InitCurrentMintableTokenStateFromDB(db)
+		if err != nil {
+			t.Fatal(err)
+		}
+		update(state)
+		if err := storeMintableTokenStateToDB(db, state); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	storeBlock(func(state *CurrentMintableTokenState) {
+		initAction := metadata.MintableTokenInitAction{
+			Meta:    metadata.MintableTokenInit{TokenName: "Stable", TokenSymbol: "STB", AuthorityAddresses: []string{"authority"}, RequiredSignatures: 1},
+			TokenID: tokenID,
+			TxReqID: common.Hash{1},
+		}
+		if !state.applyMintableTokenInit(initAction, 10) || !state.applyMintableTokenMint(tokenID, 100, 0) {
+			t.Fatalf("init and mint should be accepted")
+		}
+	})
+	// a reverted block gives back the nonce and supply it changed
+	mintAndBurn := func(state *CurrentMintableTokenState) {
+		if !state.applyMintableTokenMint(tokenID, 50, 1) || !state.applyMintableTokenBurn(tokenID, 30) {
+			t.Fatalf("mint with the next nonce and burn should be accepted")
+		}
+	}
+	storeBlock(mintAndBurn)
+	if err := db.RestoreBeaconStates(); err != nil {
+		t.Fatal(err)
+	}
+	state, err := InitCurrentMintableTokenStateFromDB(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	token := state.Tokens[tokenID]
+	if token == nil || token.MintNonce != 1 || token.TotalMinted != 100 || token.TotalBurned != 0 {
+		t.Fatalf("unexpected token after revert: %+v", token)
+	}
+
+	// the re-proposed block is applied again
+	storeBlock(mintAndBurn)
+	state, _ = InitCurrentMintableTokenStateFromDB(db)
+	token = state.Tokens[tokenID]
+	if token.MintNonce != 2 || token.TotalMinted != 150 || token.CirculatingSupply() != 120 {
+		t.Errorf("unexpected token after re-applying the block: %+v", token)
+	}
+}
@@ -1,9 +1,13 @@
 package blockchain
 
 import (
+	"fmt"
 	"time"
 
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/wire"
 	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/metadata"
 )
 
 type SlashLevel struct {
@@ -17,34 +21,93 @@ to differentiate network as well as addresses and keys for one network
 from those intended for use on another network
 */
 type Params struct {
-	Name                             string // Name defines a human-readable identifier for the network.
-	Net                              uint32 // Net defines the magic bytes used to identify the network.
-	DefaultPort                      string // DefaultPort defines the default peer-to-peer port for the network.
-	MaxShardCommitteeSize            int
-	MinShardCommitteeSize            int
-	MaxBeaconCommitteeSize           int
-	MinBeaconCommitteeSize           int
-	MinShardBlockInterval            time.Duration
-	MaxShardBlockCreation            time.Duration
-	MinBeaconBlockInterval           time.Duration
-	MaxBeaconBlockCreation           time.Duration
-	StakingAmountShard               uint64
-	ActiveShards                     int
-	GenesisBeaconBlock               *BeaconBlock // GenesisBlock defines the first block of the chain.
-	GenesisShardBlock                *ShardBlock  // GenesisBlock defines the first block of the chain.
-	BasicReward                      uint64
-	Epoch                            uint64
-	RandomTime                       uint64
-	SlashLevels                      []SlashLevel
-	EthContractAddressStr            string // smart contract of ETH for bridge
-	Offset                           int    // default offset for swap policy, is used for cases that good producers length is less than max committee size
-	SwapOffset                       int    // is used for case that good producers length is equal to max committee size
-	IncognitoDAOAddress              string
-	CentralizedWebsitePaymentAddress string //centralized website's pubkey
-	CheckForce                       bool   // true on testnet and false on mainnet
-	ChainVersion                     string
-	AssignOffset                     int
-	BeaconHeightBreakPointBurnAddr   uint64
+	Name                                string // Name defines a human-readable identifier for the network.
+	Net                                 uint32 // Net defines the magic bytes used to identify the network.
+	DefaultPort                         string // DefaultPort defines the default peer-to-peer port for the network.
+	MaxShardCommitteeSize               int
+	MinShardCommitteeSize               int
+	MaxBeaconCommitteeSize              int
+	MinBeaconCommitteeSize              int
+	MinShardBlockInterval               time.Duration
+	MaxShardBlockCreation               time.Duration
+	MinBeaconBlockInterval              time.Duration
+	MaxBeaconBlockCreation              time.Duration
+	StakingAmountShard                  uint64
+	ActiveShards                        int
+	GenesisBeaconBlock                  *BeaconBlock // GenesisBlock defines the first block of the chain.
+	GenesisShardBlock                   *ShardBlock  // GenesisBlock defines the first block of the chain.
+	BasicReward                         uint64
+	Epoch                               uint64
+	RandomTime                          uint64
+	SlashLevels                         []SlashLevel
+	Offset                              int // default offset for swap policy, is used for cases that good producers length is less than max committee size
+	SwapOffset                          int // is used for case that good producers length is equal to max committee size
+	IncognitoDAOAddress                 string
+	CentralizedWebsitePaymentAddress    string //centralized website's pubkey
+	CheckForce                          bool   // true on testnet and false on mainnet
+	ChainVersion                        string
+	AssignOffset                        int
+	UnbondingEpochs                     uint64 // number of epochs stake of swapped out validators is held before being returned
+	BeaconHeightBreakPointBurnAddr      uint64
+	BeaconHeightBreakPointUnbonding     uint64 // stake of swapped out validators is held in unbonding queue from this beacon height
+	BeaconHeightBreakPointCompactBlock  uint64 // shard blocks are proposed and relayed as compact blocks from this beacon height
+	BeaconHeightBreakPointBridgeLimiter uint64 // bridge requests are capped, queued and confirmed by stateful instructions from this beacon height
+	BTCRelaying                         BTCRelayingParams
+	EVMChains                           []metadata.EVMChain         // registry of evm chains which the bridge contracts are deployed on
+	BridgeTokenLimits                   map[string]BridgeTokenLimit // caps of bridge tokens by incognito token id, other tokens are not capped
+	IncDAOGovernance                    IncDAOGovernanceParams
+}
+
+// IncDAOGovernanceParams configures governance of the incognito dao treasury by staked PRV.
+// Voting period, timelock and percents are defaults, passed param change proposals override them
+type IncDAOGovernanceParams struct {
+	StartBeaconHeight uint64 // dao rewards go to the treasury from this height, zero disables governance
+	VotingPeriod      uint64
+	QuorumPercent     uint64
+	PassPercent       uint64
+	Timelock          uint64
+}
+
+// BridgeTokenLimit caps amounts of a bridge token, in its incognito unit, issued by a deposit or burned by a withdrawal
+// and issued or burned in an epoch, a zero cap is no cap. Beacon queues requests over the caps until they fit,
+// a request over a per-tx cap never fits so it waits for a bridge control request to release it
+type BridgeTokenLimit struct {
+	MaxIssuingPerTx    uint64
+	MaxIssuingPerEpoch uint64
+	MaxBurningPerTx    uint64
+	MaxBurningPerEpoch uint64
+}
+
+// BTCRelayingParams configures the trustless bitcoin bridge
+type BTCRelayingParams struct {
+	BTCParams *chaincfg.Params // bitcoin network of relayed headers
+	// relayed headers must descend from the checkpoint header, it is trusted without validation
+	CheckpointHeader wire.BlockHeader
+	CheckpointHeight uint64
+	// number of headers of the best chain from the block of a deposit to the best header, both included
+	Confirmations    uint64
+	DepositScriptStr string // hex of the committee controlled script which deposits are paid to
+	IncTokenIDStr    string
+}
+
+// SetEVMHeaderSources sets nodes which headers of deposits on evm chains are read from.
+// Chains of the registry are params of the network since they change which deposits are accepted,
+// only their header sources are local to a node
+func (params *Params) SetEVMHeaderSources(headerSources map[uint64]metadata.EVMHeaderSource) error {
+	for chainID, headerSource := range headerSources {
+		registered := false
+		for i := range params.EVMChains {
+			if params.EVMChains[i].ChainID == chainID {
+				params.EVMChains[i].HeaderSource = headerSource
+				registered = true
+				break
+			}
+		}
+		if !registered {
+			return NewBlockChainError(GetEVMChainError, fmt.Errorf("chain %d is not in the registry of evm chains", chainID))
+		}
+	}
+	return nil
 }
 
 type GenesisParams struct {
@@ -97,7 +160,7 @@ func init() {
 		Offset:                           TestnetOffset,
 		AssignOffset:                     TestnetAssignOffset,
 		SwapOffset:                       TestnetSwapOffset,
-		EthContractAddressStr:            TestnetETHContractAddressStr,
+		UnbondingEpochs:                  TestnetUnbondingEpochs,
 		IncognitoDAOAddress:              TestnetIncognitoDAOAddress,
 		CentralizedWebsitePaymentAddress: TestnetCentralizedWebsitePaymentAddress,
 		SlashLevels: []SlashLevel{
@@ -105,9 +168,31 @@ func init() {
 			SlashLevel{MinRange: 50, PunishedEpoches: 2},
 			SlashLevel{MinRange: 75, PunishedEpoches: 3},
 		},
-		CheckForce:                     false,
-		ChainVersion:                   "version-chain-test.json",
-		BeaconHeightBreakPointBurnAddr: 250000,
+		CheckForce:                          false,
+		ChainVersion:                        "version-chain-test.json",
+		BeaconHeightBreakPointBurnAddr:      250000,
+		BeaconHeightBreakPointUnbonding:     300000,
+		BeaconHeightBreakPointCompactBlock:  320000,
+		BeaconHeightBreakPointBridgeLimiter: 340000,
+		BTCRelaying: BTCRelayingParams{
+			BTCParams:        &chaincfg.TestNet3Params,
+			CheckpointHeader: chaincfg.TestNet3Params.GenesisBlock.Header,
+			CheckpointHeight: BTCRelayingCheckpointHeight,
+			Confirmations:    TestnetBTCRelayingConfirmations,
+			DepositScriptStr: TestnetBTCDepositScriptStr,
+			IncTokenIDStr:    BTCRelayingIncTokenIDStr,
+		},
+		EVMChains: []metadata.EVMChain{
+			metadata.NewDefaultEVMChain(TestnetETHChainName, TestnetETHContractAddressStr, TestnetETHConfirmations),
+		},
+		BridgeTokenLimits: map[string]BridgeTokenLimit{},
+		IncDAOGovernance: IncDAOGovernanceParams{
+			StartBeaconHeight: TestnetIncDAOGovernanceStartBeaconHeight,
+			VotingPeriod:      TestnetIncDAOVotingPeriod,
+			QuorumPercent:     IncDAOQuorumPercent,
+			PassPercent:       IncDAOPassPercent,
+			Timelock:          TestnetIncDAOTimelock,
+		},
 	}
 	// END TESTNET
 	// FOR MAINNET
@@ -142,7 +227,7 @@ func init() {
 		Offset:                           MainnetOffset,
 		SwapOffset:                       MainnetSwapOffset,
 		AssignOffset:                     MainnetAssignOffset,
-		EthContractAddressStr:            MainETHContractAddressStr,
+		UnbondingEpochs:                  MainnetUnbondingEpochs,
 		IncognitoDAOAddress:              MainnetIncognitoDAOAddress,
 		CentralizedWebsitePaymentAddress: MainnetCentralizedWebsitePaymentAddress,
 		SlashLevels: []SlashLevel{
@@ -150,8 +235,30 @@ func init() {
 			SlashLevel{MinRange: 50, PunishedEpoches: 2},
 			SlashLevel{MinRange: 75, PunishedEpoches: 3},
 		},
-		CheckForce:                     false,
-		ChainVersion:                   "version-chain-main.json",
-		BeaconHeightBreakPointBurnAddr: 150500,
+		CheckForce:                          false,
+		ChainVersion:                        "version-chain-main.json",
+		BeaconHeightBreakPointBurnAddr:      150500,
+		BeaconHeightBreakPointUnbonding:     250000,
+		BeaconHeightBreakPointCompactBlock:  280000,
+		BeaconHeightBreakPointBridgeLimiter: 300000,
+		BTCRelaying: BTCRelayingParams{
+			BTCParams:        &chaincfg.MainNetParams,
+			CheckpointHeader: chaincfg.MainNetParams.GenesisBlock.Header,
+			CheckpointHeight: BTCRelayingCheckpointHeight,
+			Confirmations:    MainnetBTCRelayingConfirmations,
+			DepositScriptStr: MainnetBTCDepositScriptStr,
+			IncTokenIDStr:    BTCRelayingIncTokenIDStr,
+		},
+		EVMChains: []metadata.EVMChain{
+			metadata.NewDefaultEVMChain(MainnetETHChainName, MainETHContractAddressStr, MainnetETHConfirmations),
+		},
+		BridgeTokenLimits: map[string]BridgeTokenLimit{},
+		IncDAOGovernance: IncDAOGovernanceParams{
+			StartBeaconHeight: MainnetIncDAOGovernanceStartBeaconHeight,
+			VotingPeriod:      MainnetIncDAOVotingPeriod,
+			QuorumPercent:     IncDAOQuorumPercent,
+			PassPercent:       IncDAOPassPercent,
+			Timelock:          MainnetIncDAOTimelock,
+		},
 	}
 }
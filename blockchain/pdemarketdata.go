@@ -0,0 +1,292 @@
+package blockchain
+
+import (
+	"math"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/database/lvdb"
+)
+
+// Prices of pde market data are amounts of quote token for one base token, in their smallest units,
+// amounts of the token sold by a trade include its trading fee which stays in the pool
+
+var DefaultPDELiquidityDepthPercents = []float64{0.5, 1, 2, 5, 10}
+
+type PDEMarketTrade struct {
+	TxReqID         common.Hash
+	BeaconHeight    uint64
+	Timestamp       int64
+	TokenIDToBuyStr string
+	BaseAmount      uint64
+	QuoteAmount     uint64
+	Price           float64
+}
+
+type PDECandle struct {
+	StartTime   int64
+	Open        float64
+	High        float64
+	Low         float64
+	Close       float64
+	BaseVolume  uint64
+	QuoteVolume uint64
+	TradeCount  int
+}
+
+type PDEMarketSummary struct {
+	BaseTokenIDStr     string
+	QuoteTokenIDStr    string
+	FromTime           int64
+	ToTime             int64
+	SpotPrice          float64
+	LastPrice          float64
+	PriceChangePercent float64
+	High               float64
+	Low                float64
+	BaseVolume         uint64
+	QuoteVolume        uint64
+	TradeCount         int
+	ContributionCount  int
+	WithdrawalCount    int
+	BasePoolValue      uint64
+	QuotePoolValue     uint64
+}
+
+type PDELiquidityLevel struct {
+	PriceChangePercent float64
+	Price              float64
+	BaseAmount         uint64
+	QuoteAmount        uint64
+}
+
+// PDELiquidityDepth shows how much can be traded on a pool pair until the price moves by given percents,
+// bids are base amounts to sell to move the price down, asks are quote amounts to sell to move the price up
+type PDELiquidityDepth struct {
+	BaseTokenIDStr  string
+	QuoteTokenIDStr string
+	BasePoolValue   uint64
+	QuotePoolValue  uint64
+	SpotPrice       float64
+	Bids            []PDELiquidityLevel
+	Asks            []PDELiquidityLevel
+}
+
+func computePDEPrice(baseAmount uint64, quoteAmount uint64) float64 {
+	if baseAmount == 0 {
+		return 0
+	}
+	return float64(quoteAmount) / float64(baseAmount)
+}
+
+func isBaseToken1(token1IDStr string, baseTokenIDStr string) bool {
+	return token1IDStr == baseTokenIDStr
+}
+
+// NewPDEMarketTrade returns nil if the event is not a trade
+func NewPDEMarketTrade(event *lvdb.PDEMarketEvent, baseTokenIDStr string) *PDEMarketTrade {
+	if event == nil || event.Type != common.PDEMarketTradeEvent {
+		return nil
+	}
+	trade := &PDEMarketTrade{
+		TxReqID:         event.TxReqID,
+		BeaconHeight:    event.BeaconHeight,
+		Timestamp:       event.Timestamp,
+		TokenIDToBuyStr: event.TokenIDToBuyStr,
+		BaseAmount:      event.Token2Amount,
+		QuoteAmount:     event.Token1Amount,
+	}
+	if isBaseToken1(event.Token1IDStr, baseTokenIDStr) {
+		trade.BaseAmount = event.Token1Amount
+		trade.QuoteAmount = event.Token2Amount
+	}
+	trade.Price = computePDEPrice(trade.BaseAmount, trade.QuoteAmount)
+	return trade
+}
+
+func getPDEPoolValues(
+	token1IDStr string,
+	token1PoolValue uint64,
+	token2PoolValue uint64,
+	baseTokenIDStr string,
+) (uint64, uint64) {
+	if isBaseToken1(token1IDStr, baseTokenIDStr) {
+		return token1PoolValue, token2PoolValue
+	}
+	return token2PoolValue, token1PoolValue
+}
+
+// BuildPDECandles groups trades in [fromTime, toTime) into candles of interval seconds,
+// candles start at multiples of interval and empty ones after the first trade carry the previous close price
+func BuildPDECandles(
+	events []*lvdb.PDEMarketEvent,
+	baseTokenIDStr string,
+	interval int64,
+	fromTime int64,
+	toTime int64,
+) []*PDECandle {
+	candles := []*PDECandle{}
+	if interval <= 0 || toTime <= fromTime {
+		return candles
+	}
+	var current *PDECandle
+	for _, event := range events {
+		trade := NewPDEMarketTrade(event, baseTokenIDStr)
+		if trade == nil || trade.Timestamp < fromTime || trade.Timestamp >= toTime || trade.Price == 0 {
+			continue
+		}
+		startTime := trade.Timestamp - trade.Timestamp%interval
+		if current != nil && current.StartTime != startTime {
+			candles = append(candles, current)
+			for gapTime := current.StartTime + interval; gapTime < startTime; gapTime += interval {
+				candles = append(candles, &PDECandle{
+					StartTime: gapTime,
+					Open:      current.Close,
+					High:      current.Close,
+					Low:       current.Close,
+					Close:     current.Close,
+				})
+			}
+			current = nil
+		}
+		if current == nil {
+			current = &PDECandle{
+				StartTime: startTime,
+				Open:      trade.Price,
+				High:      trade.Price,
+				Low:       trade.Price,
+			}
+		}
+		current.High = math.Max(current.High, trade.Price)
+		current.Low = math.Min(current.Low, trade.Price)
+		current.Close = trade.Price
+		current.BaseVolume += trade.BaseAmount
+		current.QuoteVolume += trade.QuoteAmount
+		current.TradeCount++
+	}
+	if current != nil {
+		candles = append(candles, current)
+		for gapTime := current.StartTime + interval; gapTime < toTime; gapTime += interval {
+			candles = append(candles, &PDECandle{
+				StartTime: gapTime,
+				Open:      current.Close,
+				High:      current.Close,
+				Low:       current.Close,
+				Close:     current.Close,
+			})
+		}
+	}
+	return candles
+}
+
+// BuildPDEMarketSummary sums up events in [fromTime, toTime), pool pair is the latest state of the pair
+func BuildPDEMarketSummary(
+	events []*lvdb.PDEMarketEvent,
+	poolPair *lvdb.PDEPoolForPair,
+	baseTokenIDStr string,
+	quoteTokenIDStr string,
+	fromTime int64,
+	toTime int64,
+) *PDEMarketSummary {
+	summary := &PDEMarketSummary{
+		BaseTokenIDStr:  baseTokenIDStr,
+		QuoteTokenIDStr: quoteTokenIDStr,
+		FromTime:        fromTime,
+		ToTime:          toTime,
+	}
+	if poolPair != nil {
+		summary.BasePoolValue, summary.QuotePoolValue = getPDEPoolValues(
+			poolPair.Token1IDStr,
+			poolPair.Token1PoolValue,
+			poolPair.Token2PoolValue,
+			baseTokenIDStr,
+		)
+		summary.SpotPrice = computePDEPrice(summary.BasePoolValue, summary.QuotePoolValue)
+	}
+	openPrice := float64(0)
+	for _, event := range events {
+		if event.Timestamp < fromTime || event.Timestamp >= toTime {
+			continue
+		}
+		switch event.Type {
+		case common.PDEMarketContributionEvent:
+			summary.ContributionCount++
+			continue
+		case common.PDEMarketWithdrawalEvent:
+			summary.WithdrawalCount++
+			continue
+		}
+		trade := NewPDEMarketTrade(event, baseTokenIDStr)
+		if trade == nil || trade.Price == 0 {
+			continue
+		}
+		if summary.TradeCount == 0 {
+			openPrice = trade.Price
+			summary.High = trade.Price
+			summary.Low = trade.Price
+		}
+		summary.High = math.Max(summary.High, trade.Price)
+		summary.Low = math.Min(summary.Low, trade.Price)
+		summary.LastPrice = trade.Price
+		summary.BaseVolume += trade.BaseAmount
+		summary.QuoteVolume += trade.QuoteAmount
+		summary.TradeCount++
+	}
+	if openPrice > 0 {
+		summary.PriceChangePercent = (summary.LastPrice - openPrice) / openPrice * 100
+	}
+	return summary
+}
+
+// BuildPDELiquidityDepth computes depth levels of a constant product pool, trading fee is not counted
+func BuildPDELiquidityDepth(
+	poolPair *lvdb.PDEPoolForPair,
+	baseTokenIDStr string,
+	quoteTokenIDStr string,
+	percents []float64,
+) *PDELiquidityDepth {
+	depth := &PDELiquidityDepth{
+		BaseTokenIDStr:  baseTokenIDStr,
+		QuoteTokenIDStr: quoteTokenIDStr,
+		Bids:            []PDELiquidityLevel{},
+		Asks:            []PDELiquidityLevel{},
+	}
+	if poolPair == nil {
+		return depth
+	}
+	depth.BasePoolValue, depth.QuotePoolValue = getPDEPoolValues(
+		poolPair.Token1IDStr,
+		poolPair.Token1PoolValue,
+		poolPair.Token2PoolValue,
+		baseTokenIDStr,
+	)
+	if depth.BasePoolValue == 0 || depth.QuotePoolValue == 0 {
+		return depth
+	}
+	depth.SpotPrice = computePDEPrice(depth.BasePoolValue, depth.QuotePoolValue)
+	basePoolValue := float64(depth.BasePoolValue)
+	quotePoolValue := float64(depth.QuotePoolValue)
+	for _, percent := range percents {
+		if percent <= 0 {
+			continue
+		}
+		ratio := percent / 100
+		// price = quote / base and base * quote is invariant,
+		// so moving the price by a ratio r scales base and quote by sqrt(1 +/- r)
+		depth.Asks = append(depth.Asks, PDELiquidityLevel{
+			PriceChangePercent: percent,
+			Price:              depth.SpotPrice * (1 + ratio),
+			BaseAmount:         uint64(basePoolValue * (1 - 1/math.Sqrt(1+ratio))),
+			QuoteAmount:        uint64(quotePoolValue * (math.Sqrt(1+ratio) - 1)),
+		})
+		if ratio >= 1 {
+			continue
+		}
+		depth.Bids = append(depth.Bids, PDELiquidityLevel{
+			PriceChangePercent: percent,
+			Price:              depth.SpotPrice * (1 - ratio),
+			BaseAmount:         uint64(basePoolValue * (1/math.Sqrt(1-ratio) - 1)),
+			QuoteAmount:        uint64(quotePoolValue * (1 - math.Sqrt(1-ratio))),
+		})
+	}
+	return depth
+}
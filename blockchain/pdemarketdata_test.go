@@ -0,0 +1,146 @@
+package blockchain
+
+import (
+	"encoding/json"
+	"strconv"
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/database/lvdb"
+	"github.com/incognitochain/incognito-chain/metadata"
+)
+
+func TestPDEMarketEventsOfTrade(t *testing.T) {
+	token1IDStr := "0000000000000000000000000000000000000000000000000000000000000005"
+	token2IDStr := "0000000000000000000000000000000000000000000000000000000000000007"
+	poolPairKey := string(lvdb.BuildPDEPoolForPairKey(100, token1IDStr, token2IDStr))
+	currentPDEState := &CurrentPDEState{
+		PDEPoolPairs: map[string]*lvdb.PDEPoolForPair{
+			poolPairKey: {
+				Token1IDStr:     token1IDStr,
+				Token1PoolValue: 1000,
+				Token2IDStr:     token2IDStr,
+				Token2PoolValue: 2000,
+			},
+		},
+	}
+	poolsBefore := snapshotPDEPoolValues(currentPDEState)
+	// sell 210 of token 2 with fee 10 for 95 of token 1
+	currentPDEState.PDEPoolPairs[poolPairKey].Token1PoolValue = 905
+	currentPDEState.PDEPoolPairs[poolPairKey].Token2PoolValue = 2210
+	content, _ := json.Marshal(metadata.PDETradeAcceptedContent{
+		TokenIDToBuyStr: token1IDStr,
+		ReceiveAmount:   95,
+		Token1IDStr:     token1IDStr,
+		Token2IDStr:     token2IDStr,
+		RequestedTxID:   common.Hash{1},
+	})
+	inst := []string{strconv.Itoa(metadata.PDETradeRequestMeta), "0", common.PDETradeAcceptedChainStatus, string(content)}
+	events := buildPDEMarketEvents(inst, 101, 1000, poolsBefore, currentPDEState)
+	if len(events) != 1 {
+		t.Fatalf("expected 1 event, got %d", len(events))
+	}
+	event := events[0]
+	if event.Type != common.PDEMarketTradeEvent || event.Token1Amount != 95 || event.Token2Amount != 210 {
+		t.Errorf("wrong trade event %+v", event)
+	}
+	if event.Token1PoolValue != 905 || event.Token2PoolValue != 2210 {
+		t.Errorf("wrong pool values after trade %+v", event)
+	}
+
+	trade := NewPDEMarketTrade(event, token1IDStr)
+	if trade.BaseAmount != 95 || trade.QuoteAmount != 210 || trade.TokenIDToBuyStr != token1IDStr {
+		t.Errorf("wrong trade %+v", trade)
+	}
+
+	refundInst := []string{strconv.Itoa(metadata.PDETradeRequestMeta), "0", common.PDETradeRefundChainStatus, "content"}
+	if len(buildPDEMarketEvents(refundInst, 101, 1000, poolsBefore, currentPDEState)) != 0 {
+		t.Errorf("refunded trade should not build any event")
+	}
+}
+
+func TestBuildPDECandles(t *testing.T) {
+	token1IDStr := "0000000000000000000000000000000000000000000000000000000000000005"
+	token2IDStr := "0000000000000000000000000000000000000000000000000000000000000007"
+	newTrade := func(timestamp int64, token1Amount uint64, token2Amount uint64) *lvdb.PDEMarketEvent {
+		return &lvdb.PDEMarketEvent{
+			Type:            common.PDEMarketTradeEvent,
+			Timestamp:       timestamp,
+			Token1IDStr:     token1IDStr,
+			Token2IDStr:     token2IDStr,
+			Token1Amount:    token1Amount,
+			Token2Amount:    token2Amount,
+			TokenIDToBuyStr: token1IDStr,
+		}
+	}
+	events := []*lvdb.PDEMarketEvent{
+		newTrade(60, 10, 20),
+		newTrade(90, 10, 40),
+		{Type: common.PDEMarketContributionEvent, Timestamp: 130, Token1IDStr: token1IDStr, Token2IDStr: token2IDStr, Token1Amount: 5, Token2Amount: 5},
+		newTrade(130, 10, 10),
+		newTrade(250, 10, 30),
+	}
+	candles := BuildPDECandles(events, token1IDStr, 60, 60, 360)
+	if len(candles) != 5 {
+		t.Fatalf("expected 5 candles, got %d", len(candles))
+	}
+	first := candles[0]
+	if first.StartTime != 60 || first.Open != 2 || first.High != 4 || first.Low != 2 || first.Close != 4 || first.TradeCount != 2 {
+		t.Errorf("wrong first candle %+v", first)
+	}
+	if first.BaseVolume != 20 || first.QuoteVolume != 60 {
+		t.Errorf("wrong volume of first candle %+v", first)
+	}
+	if candles[1].StartTime != 120 || candles[1].Close != 1 || candles[1].TradeCount != 1 {
+		t.Errorf("wrong second candle %+v", candles[1])
+	}
+	if candles[2].StartTime != 180 || candles[2].Open != 1 || candles[2].TradeCount != 0 {
+		t.Errorf("empty candle should carry the previous close %+v", candles[2])
+	}
+	if candles[3].StartTime != 240 || candles[3].Close != 3 || candles[4].StartTime != 300 || candles[4].Close != 3 {
+		t.Errorf("wrong last candles %+v %+v", candles[3], candles[4])
+	}
+
+	// quote by token 1 inverts prices
+	inverted := BuildPDECandles(events, token2IDStr, 60, 60, 120)
+	if len(inverted) != 1 || inverted[0].Open != 0.5 || inverted[0].Close != 0.25 {
+		t.Errorf("wrong inverted candles %+v", inverted)
+	}
+
+	summary := BuildPDEMarketSummary(events, nil, token1IDStr, token2IDStr, 0, 360)
+	if summary.TradeCount != 4 || summary.ContributionCount != 1 || summary.High != 4 || summary.Low != 1 || summary.LastPrice != 3 {
+		t.Errorf("wrong summary %+v", summary)
+	}
+	if summary.PriceChangePercent != 50 {
+		t.Errorf("expected price change 50%%, got %v", summary.PriceChangePercent)
+	}
+}
+
+func TestBuildPDELiquidityDepth(t *testing.T) {
+	poolPair := &lvdb.PDEPoolForPair{
+		Token1IDStr:     "0000000000000000000000000000000000000000000000000000000000000005",
+		Token1PoolValue: 1000000,
+		Token2IDStr:     "0000000000000000000000000000000000000000000000000000000000000007",
+		Token2PoolValue: 4000000,
+	}
+	depth := BuildPDELiquidityDepth(poolPair, poolPair.Token1IDStr, poolPair.Token2IDStr, []float64{1, 10, 100})
+	if depth.SpotPrice != 4 {
+		t.Errorf("expected spot price 4, got %v", depth.SpotPrice)
+	}
+	if len(depth.Asks) != 3 || len(depth.Bids) != 2 {
+		t.Fatalf("wrong number of levels %+v", depth)
+	}
+	for idx := 1; idx < len(depth.Asks); idx++ {
+		if depth.Asks[idx].QuoteAmount <= depth.Asks[idx-1].QuoteAmount {
+			t.Errorf("asks should grow with price change %+v", depth.Asks)
+		}
+	}
+	// selling the bid amount must move the price by the level's percent
+	bid := depth.Bids[1]
+	newBase := poolPair.Token1PoolValue + bid.BaseAmount
+	newQuote := poolPair.Token1PoolValue * poolPair.Token2PoolValue / newBase
+	price := float64(newQuote) / float64(newBase)
+	if price < 3.59 || price > 3.61 {
+		t.Errorf("expected price around 3.6 after selling bid amount, got %v", price)
+	}
+}
@@ -0,0 +1,155 @@
+package blockchain
+
+import (
+	"encoding/json"
+	"sort"
+	"strconv"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/database"
+	"github.com/incognitochain/incognito-chain/database/lvdb"
+	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/incognitochain/incognito-chain/pubsub"
+)
+
+type pdePoolValues struct {
+	token1PoolValue uint64
+	token2PoolValue uint64
+}
+
+// snapshotPDEPoolValues keeps pool values before an instruction is processed,
+// so the pool changed by the instruction can be found out afterward
+func snapshotPDEPoolValues(currentPDEState *CurrentPDEState) map[string]pdePoolValues {
+	poolValues := make(map[string]pdePoolValues, len(currentPDEState.PDEPoolPairs))
+	for poolPairKey, poolPair := range currentPDEState.PDEPoolPairs {
+		if poolPair == nil {
+			continue
+		}
+		poolValues[poolPairKey] = pdePoolValues{
+			token1PoolValue: poolPair.Token1PoolValue,
+			token2PoolValue: poolPair.Token2PoolValue,
+		}
+	}
+	return poolValues
+}
+
+func absDiff(a uint64, b uint64) uint64 {
+	if a > b {
+		return a - b
+	}
+	return b - a
+}
+
+// buildPDEMarketEvents builds market events of an accepted trade, contribution or withdrawal instruction
+// by comparing pool values before and after the instruction is processed
+func buildPDEMarketEvents(
+	instruction []string,
+	beaconHeight uint64,
+	timestamp int64,
+	poolsBefore map[string]pdePoolValues,
+	currentPDEState *CurrentPDEState,
+) []*lvdb.PDEMarketEvent {
+	if len(instruction) != 4 || currentPDEState == nil {
+		return nil
+	}
+	var eventType string
+	var txReqID common.Hash
+	var tradeAcceptedContent metadata.PDETradeAcceptedContent
+	switch instruction[0] {
+	case strconv.Itoa(metadata.PDETradeRequestMeta):
+		if instruction[2] != common.PDETradeAcceptedChainStatus {
+			return nil
+		}
+		err := json.Unmarshal([]byte(instruction[3]), &tradeAcceptedContent)
+		if err != nil {
+			return nil
+		}
+		eventType = common.PDEMarketTradeEvent
+		txReqID = tradeAcceptedContent.RequestedTxID
+	case strconv.Itoa(metadata.PDEContributionMeta):
+		if instruction[2] != common.PDEContributionMatchedChainStatus &&
+			instruction[2] != common.PDEContributionMatchedNReturnedChainStatus {
+			return nil
+		}
+		var matchedContribution metadata.PDEMatchedContribution
+		err := json.Unmarshal([]byte(instruction[3]), &matchedContribution)
+		if err != nil {
+			return nil
+		}
+		eventType = common.PDEMarketContributionEvent
+		txReqID = matchedContribution.TxReqID
+	case strconv.Itoa(metadata.PDEWithdrawalRequestMeta):
+		if instruction[2] != common.PDEWithdrawalAcceptedChainStatus {
+			return nil
+		}
+		var wdAcceptedContent metadata.PDEWithdrawalAcceptedContent
+		err := json.Unmarshal([]byte(instruction[3]), &wdAcceptedContent)
+		if err != nil {
+			return nil
+		}
+		eventType = common.PDEMarketWithdrawalEvent
+		txReqID = wdAcceptedContent.TxReqID
+	default:
+		return nil
+	}
+
+	poolPairKeys := make([]string, 0, len(currentPDEState.PDEPoolPairs))
+	for poolPairKey := range currentPDEState.PDEPoolPairs {
+		poolPairKeys = append(poolPairKeys, poolPairKey)
+	}
+	sort.Strings(poolPairKeys)
+
+	events := []*lvdb.PDEMarketEvent{}
+	for _, poolPairKey := range poolPairKeys {
+		poolPair := currentPDEState.PDEPoolPairs[poolPairKey]
+		if poolPair == nil {
+			continue
+		}
+		before := poolsBefore[poolPairKey]
+		if before.token1PoolValue == poolPair.Token1PoolValue && before.token2PoolValue == poolPair.Token2PoolValue {
+			continue
+		}
+		event := &lvdb.PDEMarketEvent{
+			Type:            eventType,
+			BeaconHeight:    beaconHeight,
+			Timestamp:       timestamp,
+			Token1IDStr:     poolPair.Token1IDStr,
+			Token2IDStr:     poolPair.Token2IDStr,
+			Token1Amount:    absDiff(before.token1PoolValue, poolPair.Token1PoolValue),
+			Token2Amount:    absDiff(before.token2PoolValue, poolPair.Token2PoolValue),
+			Token1PoolValue: poolPair.Token1PoolValue,
+			Token2PoolValue: poolPair.Token2PoolValue,
+			TxReqID:         txReqID,
+		}
+		if eventType == common.PDEMarketTradeEvent {
+			event.TokenIDToBuyStr = tradeAcceptedContent.TokenIDToBuyStr
+		}
+		events = append(events, event)
+	}
+	return events
+}
+
+// storePDEMarketEvents puts events into the batch of the beacon block so they are stored along with the pde state
+func (blockchain *BlockChain) storePDEMarketEvents(
+	events []*lvdb.PDEMarketEvent,
+	bd *[]database.BatchData,
+) {
+	if len(events) == 0 {
+		return
+	}
+	db := blockchain.GetDatabase()
+	for idx, event := range events {
+		eventBytes, err := json.Marshal(event)
+		if err != nil {
+			Logger.log.Errorf("ERROR: an error occured while marshaling pde market event: %+v", err)
+			continue
+		}
+		err = db.StorePDEMarketEvent(event.Token1IDStr, event.Token2IDStr, event.Timestamp, event.BeaconHeight, idx, eventBytes, bd)
+		if err != nil {
+			Logger.log.Errorf("ERROR: an error occured while storing pde market event: %+v", err)
+		}
+	}
+	if blockchain.config.PubSubManager != nil {
+		go blockchain.config.PubSubManager.PublishMessage(pubsub.NewMessage(pubsub.PDEMarketEventTopic, events))
+	}
+}
@@ -0,0 +1,360 @@
+package blockchain
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/database"
+	"github.com/incognitochain/incognito-chain/database/lvdb"
+	"github.com/incognitochain/incognito-chain/metadata"
+)
+
+// pdeLiquidityPositionLedger keeps positions touched by a beacon block until they are stored
+type pdeLiquidityPositionLedger struct {
+	db        database.DatabaseInterface
+	positions map[string]*lvdb.PDELiquidityPosition
+}
+
+func newPDELiquidityPositionLedger(db database.DatabaseInterface) *pdeLiquidityPositionLedger {
+	return &pdeLiquidityPositionLedger{
+		db:        db,
+		positions: make(map[string]*lvdb.PDELiquidityPosition),
+	}
+}
+
+func (ledger *pdeLiquidityPositionLedger) getPosition(
+	contributorAddressStr string,
+	token1IDStr string,
+	token2IDStr string,
+) (*lvdb.PDELiquidityPosition, error) {
+	tokenIDStrs := []string{token1IDStr, token2IDStr}
+	sort.Strings(tokenIDStrs)
+	key := string(lvdb.BuildPDELiquidityPositionKey(contributorAddressStr, tokenIDStrs[0], tokenIDStrs[1]))
+	if position, found := ledger.positions[key]; found {
+		return position, nil
+	}
+	position := &lvdb.PDELiquidityPosition{
+		ContributorAddressStr: contributorAddressStr,
+		Token1IDStr:           tokenIDStrs[0],
+		Token2IDStr:           tokenIDStrs[1],
+	}
+	positionBytes, err := ledger.db.GetPDELiquidityPosition(contributorAddressStr, tokenIDStrs[0], tokenIDStrs[1])
+	if err != nil {
+		return nil, err
+	}
+	if len(positionBytes) > 0 {
+		err = json.Unmarshal(positionBytes, position)
+		if err != nil {
+			return nil, err
+		}
+	}
+	ledger.positions[key] = position
+	return position, nil
+}
+
+func (ledger *pdeLiquidityPositionLedger) store() error {
+	keys := make([]string, 0, len(ledger.positions))
+	for key := range ledger.positions {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		position := ledger.positions[key]
+		positionBytes, err := json.Marshal(position)
+		if err != nil {
+			return err
+		}
+		err = ledger.db.StorePDELiquidityPosition(position.ContributorAddressStr, position.Token1IDStr, position.Token2IDStr, positionBytes)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func snapshotPDEShares(currentPDEState *CurrentPDEState) map[string]uint64 {
+	shares := make(map[string]uint64, len(currentPDEState.PDEShares))
+	for shareKey, shareAmt := range currentPDEState.PDEShares {
+		shares[shareKey] = shareAmt
+	}
+	return shares
+}
+
+func scaleAmount(amount uint64, numerator uint64, denominator uint64) uint64 {
+	if denominator == 0 {
+		return 0
+	}
+	result := big.NewInt(0).Mul(new(big.Int).SetUint64(amount), new(big.Int).SetUint64(numerator))
+	result.Div(result, new(big.Int).SetUint64(denominator))
+	return result.Uint64()
+}
+
+func computePDELiquidity(token1Amount uint64, token2Amount uint64) uint64 {
+	product := big.NewInt(0).Mul(new(big.Int).SetUint64(token1Amount), new(big.Int).SetUint64(token2Amount))
+	return product.Sqrt(product).Uint64()
+}
+
+func applyPDELiquidityDeposit(
+	position *lvdb.PDELiquidityPosition,
+	token1Amount uint64,
+	token2Amount uint64,
+	sharesBefore uint64,
+	sharesAfter uint64,
+	beaconHeight uint64,
+) {
+	if position.Shares != sharesBefore {
+		position.HasUntrackedShares = true
+	}
+	if position.Shares == 0 {
+		position.OpenedBeaconHeight = beaconHeight
+	}
+	position.Token1Deposited += token1Amount
+	position.Token2Deposited += token2Amount
+	position.Token1Held += token1Amount
+	position.Token2Held += token2Amount
+	position.FeeFreeLiquidity += computePDELiquidity(token1Amount, token2Amount)
+	position.Shares = sharesAfter
+	position.LastUpdatedBeaconHeight = beaconHeight
+}
+
+// applyPDELiquidityWithdrawal records one withdrawn token, shares are deducted along with the first token of a withdrawal,
+// held amounts and fee free liquidity are cut down by the withdrawn part of shares
+func applyPDELiquidityWithdrawal(
+	position *lvdb.PDELiquidityPosition,
+	withdrawalTokenIDStr string,
+	amount uint64,
+	sharesBefore uint64,
+	sharesAfter uint64,
+	beaconHeight uint64,
+) {
+	if position.Shares != sharesBefore {
+		position.HasUntrackedShares = true
+	}
+	if withdrawalTokenIDStr == position.Token1IDStr {
+		position.Token1Withdrawn += amount
+	} else {
+		position.Token2Withdrawn += amount
+	}
+	if sharesBefore > sharesAfter {
+		position.Token1Held = scaleAmount(position.Token1Held, sharesAfter, sharesBefore)
+		position.Token2Held = scaleAmount(position.Token2Held, sharesAfter, sharesBefore)
+		position.FeeFreeLiquidity = scaleAmount(position.FeeFreeLiquidity, sharesAfter, sharesBefore)
+	}
+	position.Shares = sharesAfter
+	position.LastUpdatedBeaconHeight = beaconHeight
+}
+
+// applyPDEInstruction updates positions by an instruction which has just been processed,
+// events are market events built from the instruction
+func (ledger *pdeLiquidityPositionLedger) applyPDEInstruction(
+	instruction []string,
+	beaconHeight uint64,
+	events []*lvdb.PDEMarketEvent,
+	sharesBefore map[string]uint64,
+	currentPDEState *CurrentPDEState,
+) error {
+	if len(instruction) != 4 {
+		return nil
+	}
+	switch instruction[0] {
+	case strconv.Itoa(metadata.PDEContributionMeta):
+		for _, event := range events {
+			if event.Type != common.PDEMarketContributionEvent {
+				continue
+			}
+			pairSharesPrefix := string(lvdb.BuildPDESharesKeyV2(beaconHeight, event.Token1IDStr, event.Token2IDStr, ""))
+			shareKeys := []string{}
+			for shareKey, shareAmt := range currentPDEState.PDEShares {
+				if strings.HasPrefix(shareKey, pairSharesPrefix) && shareAmt > sharesBefore[shareKey] {
+					shareKeys = append(shareKeys, shareKey)
+				}
+			}
+			if len(shareKeys) == 0 {
+				continue
+			}
+			// shares of a contribution are given to one contributor
+			sort.Strings(shareKeys)
+			contributorAddressStr := strings.TrimPrefix(shareKeys[0], pairSharesPrefix)
+			position, err := ledger.getPosition(contributorAddressStr, event.Token1IDStr, event.Token2IDStr)
+			if err != nil {
+				return err
+			}
+			applyPDELiquidityDeposit(
+				position,
+				event.Token1Amount,
+				event.Token2Amount,
+				sharesBefore[shareKeys[0]],
+				currentPDEState.PDEShares[shareKeys[0]],
+				event.BeaconHeight,
+			)
+		}
+	case strconv.Itoa(metadata.PDEWithdrawalRequestMeta):
+		if instruction[2] != common.PDEWithdrawalAcceptedChainStatus {
+			return nil
+		}
+		var wdAcceptedContent metadata.PDEWithdrawalAcceptedContent
+		err := json.Unmarshal([]byte(instruction[3]), &wdAcceptedContent)
+		if err != nil {
+			return nil
+		}
+		position, err := ledger.getPosition(
+			wdAcceptedContent.WithdrawerAddressStr,
+			wdAcceptedContent.PairToken1IDStr,
+			wdAcceptedContent.PairToken2IDStr,
+		)
+		if err != nil {
+			return err
+		}
+		shareKey := string(lvdb.BuildPDESharesKeyV2(
+			beaconHeight,
+			wdAcceptedContent.PairToken1IDStr,
+			wdAcceptedContent.PairToken2IDStr,
+			wdAcceptedContent.WithdrawerAddressStr,
+		))
+		sharesAfter := currentPDEState.PDEShares[shareKey]
+		applyPDELiquidityWithdrawal(
+			position,
+			wdAcceptedContent.WithdrawalTokenIDStr,
+			wdAcceptedContent.DeductingPoolValue,
+			sharesAfter+wdAcceptedContent.DeductingShares,
+			sharesAfter,
+			beaconHeight+1,
+		)
+	}
+	return nil
+}
+
+// PDELiquidityPositionReport values amounts by the current pool price,
+// profit and impermanent loss are compared with holding the deposits not withdrawn yet
+type PDELiquidityPositionReport struct {
+	ContributorAddressStr  string
+	Token1IDStr            string
+	Token2IDStr            string
+	Shares                 uint64
+	TotalShares            uint64
+	SharePercent           float64
+	Token1PoolValue        uint64
+	Token2PoolValue        uint64
+	Token1Redeemable       uint64
+	Token2Redeemable       uint64
+	Token1Deposited        uint64
+	Token2Deposited        uint64
+	Token1Withdrawn        uint64
+	Token2Withdrawn        uint64
+	Token1Held             uint64
+	Token2Held             uint64
+	Token1AccruedFee       uint64
+	Token2AccruedFee       uint64
+	ImpermanentLossPercent float64
+	ProfitVsHoldPercent    float64
+	HasFullHistory         bool
+	OpenedBeaconHeight     uint64
+}
+
+// BuildPDELiquidityPositionReport builds report of a contributor on a pool pair,
+// position is nil if the contributor has no deposit history since market data is indexed
+func BuildPDELiquidityPositionReport(
+	contributorAddressStr string,
+	position *lvdb.PDELiquidityPosition,
+	shares uint64,
+	totalShares uint64,
+	poolPair *lvdb.PDEPoolForPair,
+) *PDELiquidityPositionReport {
+	report := &PDELiquidityPositionReport{
+		ContributorAddressStr: contributorAddressStr,
+		Shares:                shares,
+		TotalShares:           totalShares,
+	}
+	if position != nil {
+		report.Token1IDStr = position.Token1IDStr
+		report.Token2IDStr = position.Token2IDStr
+		report.Token1Deposited = position.Token1Deposited
+		report.Token2Deposited = position.Token2Deposited
+		report.Token1Withdrawn = position.Token1Withdrawn
+		report.Token2Withdrawn = position.Token2Withdrawn
+		report.Token1Held = position.Token1Held
+		report.Token2Held = position.Token2Held
+		report.OpenedBeaconHeight = position.OpenedBeaconHeight
+		report.HasFullHistory = !position.HasUntrackedShares && position.Shares == shares
+	}
+	if poolPair == nil {
+		return report
+	}
+	report.Token1IDStr = poolPair.Token1IDStr
+	report.Token2IDStr = poolPair.Token2IDStr
+	report.Token1PoolValue = poolPair.Token1PoolValue
+	report.Token2PoolValue = poolPair.Token2PoolValue
+	if totalShares == 0 || shares == 0 {
+		return report
+	}
+	report.SharePercent = float64(shares) / float64(totalShares) * 100
+	report.Token1Redeemable = scaleAmount(poolPair.Token1PoolValue, shares, totalShares)
+	report.Token2Redeemable = scaleAmount(poolPair.Token2PoolValue, shares, totalShares)
+	if !report.HasFullHistory || poolPair.Token1PoolValue == 0 {
+		return report
+	}
+
+	// liquidity of a pool only grows with trading fees, so the part of redeemable amounts over fee free liquidity is accrued fees
+	currentLiquidity := computePDELiquidity(report.Token1Redeemable, report.Token2Redeemable)
+	if currentLiquidity > position.FeeFreeLiquidity {
+		report.Token1AccruedFee = report.Token1Redeemable - scaleAmount(report.Token1Redeemable, position.FeeFreeLiquidity, currentLiquidity)
+		report.Token2AccruedFee = report.Token2Redeemable - scaleAmount(report.Token2Redeemable, position.FeeFreeLiquidity, currentLiquidity)
+	}
+
+	// values are in token 2, ratios of values do not depend on the token they are valued in
+	price := float64(poolPair.Token2PoolValue) / float64(poolPair.Token1PoolValue)
+	heldValue := float64(position.Token1Held)*price + float64(position.Token2Held)
+	if heldValue == 0 {
+		return report
+	}
+	redeemableValue := float64(report.Token1Redeemable)*price + float64(report.Token2Redeemable)
+	feeValue := float64(report.Token1AccruedFee)*price + float64(report.Token2AccruedFee)
+	report.ProfitVsHoldPercent = (redeemableValue/heldValue - 1) * 100
+	report.ImpermanentLossPercent = ((redeemableValue-feeValue)/heldValue - 1) * 100
+	return report
+}
+
+// PDESharesOfContributor is shares of a contributor on a pool pair
+type PDESharesOfContributor struct {
+	Token1IDStr string
+	Token2IDStr string
+	Shares      uint64
+	TotalShares uint64
+}
+
+// GetPDESharesOfContributor returns shares of a contributor and total shares by pool pair keys (token1-token2),
+// shares are from pde state at the beacon height
+func GetPDESharesOfContributor(
+	pdeShares map[string]uint64,
+	beaconHeight uint64,
+	contributorAddressStr string,
+) map[string]*PDESharesOfContributor {
+	totalShares := make(map[string]uint64)
+	result := make(map[string]*PDESharesOfContributor)
+	sharesPrefix := string(lvdb.PDESharePrefix) + fmt.Sprintf("%d-", beaconHeight)
+	for shareKey, shareAmt := range pdeShares {
+		parts := strings.Split(strings.TrimPrefix(shareKey, sharesPrefix), "-")
+		if len(parts) != 3 {
+			continue
+		}
+		pairKey := parts[0] + "-" + parts[1]
+		totalShares[pairKey] += shareAmt
+		if parts[2] != contributorAddressStr {
+			continue
+		}
+		result[pairKey] = &PDESharesOfContributor{
+			Token1IDStr: parts[0],
+			Token2IDStr: parts[1],
+			Shares:      shareAmt,
+		}
+	}
+	for pairKey, shares := range result {
+		shares.TotalShares = totalShares[pairKey]
+	}
+	return result
+}
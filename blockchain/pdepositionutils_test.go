@@ -0,0 +1,142 @@
+package blockchain
+
+import (
+	"math"
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/database/lvdb"
+)
+
+func TestPDELiquidityPositionReport(t *testing.T) {
+	token1IDStr := "0000000000000000000000000000000000000000000000000000000000000005"
+	token2IDStr := "0000000000000000000000000000000000000000000000000000000000000007"
+	position := &lvdb.PDELiquidityPosition{
+		ContributorAddressStr: "contributor",
+		Token1IDStr:           token1IDStr,
+		Token2IDStr:           token2IDStr,
+	}
+	applyPDELiquidityDeposit(position, 1000, 4000, 0, 1000, 10)
+	if position.HasUntrackedShares || position.FeeFreeLiquidity != 2000 || position.OpenedBeaconHeight != 10 {
+		t.Fatalf("wrong position after deposit %+v", position)
+	}
+
+	// pool grows by trading fees only
+	report := BuildPDELiquidityPositionReport("contributor", position, 1000, 1000, &lvdb.PDEPoolForPair{
+		Token1IDStr:     token1IDStr,
+		Token1PoolValue: 1010,
+		Token2IDStr:     token2IDStr,
+		Token2PoolValue: 4040,
+	})
+	if !report.HasFullHistory || report.Token1Redeemable != 1010 || report.Token2Redeemable != 4040 {
+		t.Fatalf("wrong report %+v", report)
+	}
+	if report.Token1AccruedFee != 10 || report.Token2AccruedFee != 40 {
+		t.Errorf("wrong accrued fees %d %d", report.Token1AccruedFee, report.Token2AccruedFee)
+	}
+	if math.Abs(report.ImpermanentLossPercent) > 0.001 || math.Abs(report.ProfitVsHoldPercent-1) > 0.001 {
+		t.Errorf("wrong loss %v or profit %v", report.ImpermanentLossPercent, report.ProfitVsHoldPercent)
+	}
+
+	// price of token 1 falls from 4 to 1 without any fee
+	report = BuildPDELiquidityPositionReport("contributor", position, 1000, 1000, &lvdb.PDEPoolForPair{
+		Token1IDStr:     token1IDStr,
+		Token1PoolValue: 2000,
+		Token2IDStr:     token2IDStr,
+		Token2PoolValue: 2000,
+	})
+	if report.Token1AccruedFee != 0 || report.Token2AccruedFee != 0 {
+		t.Errorf("no fee should be accrued %+v", report)
+	}
+	if math.Abs(report.ImpermanentLossPercent+20) > 0.001 || math.Abs(report.ProfitVsHoldPercent+20) > 0.001 {
+		t.Errorf("expected loss of 20%%, got %v and %v", report.ImpermanentLossPercent, report.ProfitVsHoldPercent)
+	}
+
+	// withdraw a half of shares
+	applyPDELiquidityWithdrawal(position, token1IDStr, 500, 1000, 500, 11)
+	applyPDELiquidityWithdrawal(position, token2IDStr, 2000, 500, 500, 11)
+	if position.HasUntrackedShares || position.Shares != 500 || position.Token1Held != 500 || position.Token2Held != 2000 || position.FeeFreeLiquidity != 1000 {
+		t.Errorf("wrong position after withdrawal %+v", position)
+	}
+	if position.Token1Withdrawn != 500 || position.Token2Withdrawn != 2000 {
+		t.Errorf("wrong withdrawn amounts %+v", position)
+	}
+
+	// shares from before the position was tracked
+	report = BuildPDELiquidityPositionReport("contributor", position, 700, 1000, &lvdb.PDEPoolForPair{
+		Token1IDStr:     token1IDStr,
+		Token1PoolValue: 2000,
+		Token2IDStr:     token2IDStr,
+		Token2PoolValue: 2000,
+	})
+	if report.HasFullHistory || report.Token1Redeemable != 1400 || report.ImpermanentLossPercent != 0 {
+		t.Errorf("wrong report of untracked shares %+v", report)
+	}
+}
+
+func TestGetPDESharesOfContributor(t *testing.T) {
+	token1IDStr := "0000000000000000000000000000000000000000000000000000000000000005"
+	token2IDStr := "0000000000000000000000000000000000000000000000000000000000000007"
+	pdeShares := map[string]uint64{
+		string(lvdb.BuildPDESharesKeyV2(10, token2IDStr, token1IDStr, "alice")): 300,
+		string(lvdb.BuildPDESharesKeyV2(10, token1IDStr, token2IDStr, "bob")):   700,
+	}
+	shares := GetPDESharesOfContributor(pdeShares, 10, "alice")
+	pairShares, found := shares[token1IDStr+"-"+token2IDStr]
+	if len(shares) != 1 || !found {
+		t.Fatalf("wrong shares %+v", shares)
+	}
+	if pairShares.Shares != 300 || pairShares.TotalShares != 1000 || pairShares.Token1IDStr != token1IDStr {
+		t.Errorf("wrong shares of pair %+v", pairShares)
+	}
+}
+
+func TestPDELiquidityPositionRevert(t *testing.T) {
+	_, db, cleanup := newTestStakingPoolChain(t)
+	defer cleanup()
+	token1IDStr := "0000000000000000000000000000000000000000000000000000000000000005"
+	token2IDStr := "0000000000000000000000000000000000000000000000000000000000000007"
+	storeBlock := func(update func(position *lvdb.PDELiquidityPosition)) {
+		if err := db.CleanBackup(true, 0); err != nil {
+			t.Fatal(err)
+		}
+		ledger := newPDELiquidityPositionLedger(db)
+		position, err := ledger.getPosition("contributor", token2IDStr, token1IDStr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		update(position)
+		if err := ledger.store(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	getPosition := func() *lvdb.PDELiquidityPosition {
+		position, err := newPDELiquidityPositionLedger(db).getPosition("contributor", token1IDStr, token2IDStr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return position
+	}
+
+	storeBlock(func(position *lvdb.PDELiquidityPosition) {
+		applyPDELiquidityDeposit(position, 1000, 4000, 0, 1000, 10)
+	})
+	// a reverted block gives back the position it changed, so the contribution is not counted twice when re-applied
+	deposit := func(position *lvdb.PDELiquidityPosition) {
+		applyPDELiquidityDeposit(position, 500, 2000, 1000, 1500, 11)
+	}
+	storeBlock(deposit)
+	if err := db.RestoreBeaconStates(); err != nil {
+		t.Fatal(err)
+	}
+	position := getPosition()
+	if position.Shares != 1000 || position.Token1Deposited != 1000 || position.Token2Deposited != 4000 || position.LastUpdatedBeaconHeight != 10 {
+		t.Fatalf("unexpected position after revert: %+v", position)
+	}
+
+	// the re-proposed block is applied again
+	storeBlock(deposit)
+	position = getPosition()
+	if position.HasUntrackedShares || position.Shares != 1500 || position.Token1Deposited != 1500 || position.Token2Deposited != 6000 {
+		t.Errorf("unexpected position after re-applying the block: %+v", position)
+	}
+}
@@ -0,0 +1,99 @@
+package blockchain
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/database/lvdb"
+	"github.com/incognitochain/incognito-chain/metadata"
+)
+
+func buildPDETradeReqContent(trade metadata.PDETradeRequest) string {
+	trade.MetadataBase = metadata.MetadataBase{Type: metadata.PDETradeRequestMeta}
+	actionContentBytes, _ := json.Marshal(metadata.PDETradeRequestAction{
+		Meta:    trade,
+		TxReqID: common.Hash{},
+		ShardID: 1,
+	})
+	return base64.StdEncoding.EncodeToString(actionContentBytes)
+}
+
+func TestQuotePDETradeMatchesBeacon(t *testing.T) {
+	token1IDStr := "0000000000000000000000000000000000000000000000000000000000000005"
+	token2IDStr := "0000000000000000000000000000000000000000000000000000000000000007"
+	beaconHeight := uint64(100)
+	poolPairKey := string(lvdb.BuildPDEPoolForPairKey(beaconHeight, token1IDStr, token2IDStr))
+	poolPair := lvdb.PDEPoolForPair{
+		Token1IDStr:     token1IDStr,
+		Token1PoolValue: 1000000,
+		Token2IDStr:     token2IDStr,
+		Token2PoolValue: 3000017,
+	}
+	pendingTrade := &metadata.PDETradeRequest{
+		TokenIDToBuyStr:  token1IDStr,
+		TokenIDToSellStr: token2IDStr,
+		SellAmount:       50000,
+		TradingFee:       500,
+	}
+	trade := &metadata.PDETradeRequest{
+		TokenIDToBuyStr:     token2IDStr,
+		TokenIDToSellStr:    token1IDStr,
+		SellAmount:          33333,
+		TradingFee:          100,
+		MinAcceptableAmount: 1,
+	}
+
+	quotePoolPair := poolPair
+	quote, err := metadata.QuotePDETrade(&quotePoolPair, trade, []*metadata.PDETradeRequest{pendingTrade}, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if quote.PendingTradesAhead != 1 {
+		t.Errorf("pending trade with higher fee rate must be ahead, got %+v", quote.PendingTradesAhead)
+	}
+
+	// execute the same trades on beacon
+	beaconPoolPair := poolPair
+	currentPDEState := &CurrentPDEState{
+		PDEPoolPairs: map[string]*lvdb.PDEPoolForPair{poolPairKey: &beaconPoolPair},
+	}
+	bc := &BlockChain{}
+	var receiveAmount uint64
+	for _, req := range []*metadata.PDETradeRequest{pendingTrade, trade} {
+		insts, err := bc.buildInstructionsForPDETrade(buildPDETradeReqContent(*req), 1, metadata.PDETradeRequestMeta, currentPDEState, beaconHeight)
+		if err != nil || len(insts) != 1 || insts[0][2] != common.PDETradeAcceptedChainStatus {
+			t.Fatalf("trade must be accepted by beacon, got %+v, err %+v", insts, err)
+		}
+		var acceptedContent metadata.PDETradeAcceptedContent
+		if err := json.Unmarshal([]byte(insts[0][3]), &acceptedContent); err != nil {
+			t.Fatal(err)
+		}
+		receiveAmount = acceptedContent.ReceiveAmount
+	}
+	if quote.IsRefunded || quote.ReceiveAmount != receiveAmount {
+		t.Errorf("quote receive amount %+v is different from beacon %+v", quote.ReceiveAmount, receiveAmount)
+	}
+	if quote.SuggestedMinAcceptableAmount != receiveAmount*98/100 {
+		t.Errorf("wrong suggested min acceptable amount %+v", quote.SuggestedMinAcceptableAmount)
+	}
+	if quote.PriceImpactPercent <= 0 {
+		t.Errorf("trade after a pending trade of the other side must have price impact, got %+v", quote.PriceImpactPercent)
+	}
+
+	// a pending trade with lower fee rate is executed after, min acceptable amount above the quote is refunded
+	pendingTrade.TradingFee = 0
+	trade.MinAcceptableAmount = 1000000
+	quotePoolPair = poolPair
+	quote, err = metadata.QuotePDETrade(&quotePoolPair, trade, []*metadata.PDETradeRequest{pendingTrade}, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if quote.PendingTradesAhead != 0 || !quote.IsRefunded {
+		t.Errorf("wrong quote %+v", quote)
+	}
+	if quotePoolPair != poolPair {
+		t.Errorf("quote must not update the pool pair")
+	}
+}
@@ -10,7 +10,6 @@ import (
 
 	"github.com/incognitochain/incognito-chain/database"
 	"github.com/incognitochain/incognito-chain/database/lvdb"
-	"github.com/pkg/errors"
 )
 
 type CurrentPDEState struct {
@@ -44,158 +43,77 @@ func replaceNewBCHeightInKeyStr(key string, newBeaconHeight uint64) string {
 	return newKey
 }
 
-func storePDEShares(
+// InitCurrentPDEStateFromDB load waiting contributions, pool pairs and shares at beaconHeight
+func InitCurrentPDEStateFromDB(
 	db database.DatabaseInterface,
 	beaconHeight uint64,
-	pdeShares map[string]uint64,
-) error {
-	for shareKey, shareAmt := range pdeShares {
-		newKey := replaceNewBCHeightInKeyStr(shareKey, beaconHeight)
-		buf := make([]byte, binary.MaxVarintLen64)
-		binary.LittleEndian.PutUint64(buf, shareAmt)
-		dbErr := db.Put([]byte(newKey), buf)
-		if dbErr != nil {
-			return database.NewDatabaseError(database.AddShareAmountUpError, errors.Wrap(dbErr, "db.lvdb.put"))
+) (*CurrentPDEState, error) {
+	state, err := db.GetPDEState(beaconHeight)
+	if err != nil {
+		return nil, err
+	}
+	currentPDEState := &CurrentPDEState{
+		WaitingPDEContributions: make(map[string]*lvdb.PDEContribution),
+		PDEPoolPairs:            make(map[string]*lvdb.PDEPoolForPair),
+		PDEShares:               make(map[string]uint64),
+	}
+	for key, value := range state {
+		switch {
+		case strings.HasPrefix(key, string(lvdb.WaitingPDEContributionPrefix)):
+			var waitingContrib lvdb.PDEContribution
+			err = json.Unmarshal(value, &waitingContrib)
+			if err != nil {
+				return nil, err
+			}
+			currentPDEState.WaitingPDEContributions[key] = &waitingContrib
+		case strings.HasPrefix(key, string(lvdb.PDEPoolPrefix)):
+			var padePoolPair lvdb.PDEPoolForPair
+			err = json.Unmarshal(value, &padePoolPair)
+			if err != nil {
+				return nil, err
+			}
+			currentPDEState.PDEPoolPairs[key] = &padePoolPair
+		case strings.HasPrefix(key, string(lvdb.PDESharePrefix)):
+			currentPDEState.PDEShares[key] = uint64(binary.LittleEndian.Uint64(value))
 		}
 	}
-	return nil
+	return currentPDEState, nil
 }
 
-func storeWaitingPDEContributions(
+// storePDEStateToDB store currentPDEState at beaconHeight, db keeps only records changed from the previous height
+// and prunes states more than retention heights before beaconHeight, retention 0 keeps all states
+func storePDEStateToDB(
 	db database.DatabaseInterface,
 	beaconHeight uint64,
-	waitingPDEContributions map[string]*lvdb.PDEContribution,
+	currentPDEState *CurrentPDEState,
+	retention uint64,
 ) error {
-	for contribKey, contribution := range waitingPDEContributions {
-		newKey := replaceNewBCHeightInKeyStr(contribKey, beaconHeight)
+	state := make(map[string][]byte)
+	for contribKey, contribution := range currentPDEState.WaitingPDEContributions {
 		contributionBytes, err := json.Marshal(contribution)
 		if err != nil {
 			return err
 		}
-		err = db.Put([]byte(newKey), contributionBytes)
-		if err != nil {
-			return database.NewDatabaseError(database.StoreWaitingPDEContributionError, errors.Wrap(err, "db.lvdb.put"))
-		}
+		state[replaceNewBCHeightInKeyStr(contribKey, beaconHeight)] = contributionBytes
 	}
-	return nil
-}
-
-func storePDEPoolPairs(
-	db database.DatabaseInterface,
-	beaconHeight uint64,
-	pdePoolPairs map[string]*lvdb.PDEPoolForPair,
-) error {
-	for poolPairKey, poolPair := range pdePoolPairs {
-		newKey := replaceNewBCHeightInKeyStr(poolPairKey, beaconHeight)
+	for poolPairKey, poolPair := range currentPDEState.PDEPoolPairs {
 		poolPairBytes, err := json.Marshal(poolPair)
 		if err != nil {
 			return err
 		}
-		err = db.Put([]byte(newKey), poolPairBytes)
-		if err != nil {
-			return database.NewDatabaseError(database.StorePDEPoolForPairError, errors.Wrap(err, "db.lvdb.put"))
-		}
-	}
-	return nil
-}
-
-func getWaitingPDEContributions(
-	db database.DatabaseInterface,
-	beaconHeight uint64,
-) (map[string]*lvdb.PDEContribution, error) {
-	waitingPDEContributions := make(map[string]*lvdb.PDEContribution)
-	waitingContribKeysBytes, waitingContribValuesBytes, err := db.GetAllRecordsByPrefix(beaconHeight, lvdb.WaitingPDEContributionPrefix)
-	if err != nil {
-		return nil, err
-	}
-	for idx, waitingContribKeyBytes := range waitingContribKeysBytes {
-		var waitingContrib lvdb.PDEContribution
-		err = json.Unmarshal(waitingContribValuesBytes[idx], &waitingContrib)
-		if err != nil {
-			return nil, err
-		}
-		waitingPDEContributions[string(waitingContribKeyBytes)] = &waitingContrib
-	}
-	return waitingPDEContributions, nil
-}
-
-func getPDEPoolPair(
-	db database.DatabaseInterface,
-	beaconHeight uint64,
-) (map[string]*lvdb.PDEPoolForPair, error) {
-	pdePoolPairs := make(map[string]*lvdb.PDEPoolForPair)
-	poolPairsKeysBytes, poolPairsValuesBytes, err := db.GetAllRecordsByPrefix(beaconHeight, lvdb.PDEPoolPrefix)
-	if err != nil {
-		return nil, err
-	}
-	for idx, poolPairsKeyBytes := range poolPairsKeysBytes {
-		var padePoolPair lvdb.PDEPoolForPair
-		err = json.Unmarshal(poolPairsValuesBytes[idx], &padePoolPair)
-		if err != nil {
-			return nil, err
-		}
-		pdePoolPairs[string(poolPairsKeyBytes)] = &padePoolPair
-	}
-	return pdePoolPairs, nil
-}
-
-func getPDEShares(
-	db database.DatabaseInterface,
-	beaconHeight uint64,
-) (map[string]uint64, error) {
-	pdeShares := make(map[string]uint64)
-	sharesKeysBytes, sharesValuesBytes, err := db.GetAllRecordsByPrefix(beaconHeight, lvdb.PDESharePrefix)
-	if err != nil {
-		return nil, err
-	}
-	for idx, sharesKeyBytes := range sharesKeysBytes {
-		shareAmt := uint64(binary.LittleEndian.Uint64(sharesValuesBytes[idx]))
-		pdeShares[string(sharesKeyBytes)] = shareAmt
-	}
-	return pdeShares, nil
-}
-
-func InitCurrentPDEStateFromDB(
-	db database.DatabaseInterface,
-	beaconHeight uint64,
-) (*CurrentPDEState, error) {
-	waitingPDEContributions, err := getWaitingPDEContributions(db, beaconHeight)
-	if err != nil {
-		return nil, err
+		state[replaceNewBCHeightInKeyStr(poolPairKey, beaconHeight)] = poolPairBytes
 	}
-	pdePoolPairs, err := getPDEPoolPair(db, beaconHeight)
-	if err != nil {
-		return nil, err
-	}
-	pdeShares, err := getPDEShares(db, beaconHeight)
-	if err != nil {
-		return nil, err
+	for shareKey, shareAmt := range currentPDEState.PDEShares {
+		buf := make([]byte, binary.MaxVarintLen64)
+		binary.LittleEndian.PutUint64(buf, shareAmt)
+		state[replaceNewBCHeightInKeyStr(shareKey, beaconHeight)] = buf
 	}
-	return &CurrentPDEState{
-		WaitingPDEContributions: waitingPDEContributions,
-		PDEPoolPairs:            pdePoolPairs,
-		PDEShares:               pdeShares,
-	}, nil
+	return db.StorePDEState(beaconHeight, state, retention)
 }
 
-func storePDEStateToDB(
-	db database.DatabaseInterface,
-	beaconHeight uint64,
-	currentPDEState *CurrentPDEState,
-) error {
-	err := storeWaitingPDEContributions(db, beaconHeight, currentPDEState.WaitingPDEContributions)
-	if err != nil {
-		return err
-	}
-	err = storePDEPoolPairs(db, beaconHeight, currentPDEState.PDEPoolPairs)
-	if err != nil {
-		return err
-	}
-	err = storePDEShares(db, beaconHeight, currentPDEState.PDEShares)
-	if err != nil {
-		return err
-	}
-	return nil
+// migratePDEStateToJournal replace pde state copies of every beacon height by latest state and journals
+func migratePDEStateToJournal(db database.DatabaseInterface, progress func(done uint64)) error {
+	return db.MigratePDEState(progress)
 }
 
 func addShareAmountUpV2(
@@ -0,0 +1,134 @@
+package blockchain
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/database"
+	"github.com/incognitochain/incognito-chain/database/lvdb"
+	"github.com/incognitochain/incognito-chain/metadata"
+)
+
+type CurrentPrivacyTokenRegistryState struct {
+	Registries    map[common.Hash]*lvdb.PrivacyTokenRegistry
+	touchedTokens map[common.Hash]bool
+}
+
+func InitCurrentPrivacyTokenRegistryStateFromDB(
+	db database.DatabaseInterface,
+) (*CurrentPrivacyTokenRegistryState, error) {
+	registriesBytes, err := db.GetAllPrivacyTokenRegistries()
+	if err != nil {
+		return nil, err
+	}
+	registries := make(map[common.Hash]*lvdb.PrivacyTokenRegistry)
+	for _, registryBytes := range registriesBytes {
+		var registry lvdb.PrivacyTokenRegistry
+		err = json.Unmarshal(registryBytes, &registry)
+		if err != nil {
+			return nil, err
+		}
+		registries[registry.TokenID] = &registry
+	}
+	return &CurrentPrivacyTokenRegistryState{
+		Registries:    registries,
+		touchedTokens: make(map[common.Hash]bool),
+	}, nil
+}
+
+// findTokenIDBySymbol returns id of the registered token which has the symbol, symbols are case insensitive
+func (state *CurrentPrivacyTokenRegistryState) findTokenIDBySymbol(symbol string) (common.Hash, bool) {
+	for tokenID, registry := range state.Registries {
+		if strings.EqualFold(registry.TokenSymbol, symbol) {
+			return tokenID, true
+		}
+	}
+	return common.Hash{}, false
+}
+
+/*
+	applyPrivacyTokenRegistration registers or updates a token in the state, it returns false if:
+	- token is registered by another issuer, tokens registered by the committee have no issuer
+	- registration is approved by the committee but its nonce is not the next nonce of the token
+	- symbol is used by another token
+	Created beacon height of a registered token is kept on updates,
+	signatures of the committee are verified by the caller
+*/
+func (state *CurrentPrivacyTokenRegistryState) applyPrivacyTokenRegistration(
+	registration metadata.PrivacyTokenRegistration,
+	txReqID common.Hash,
+	beaconHeight uint64,
+) bool {
+	tokenID, err := common.Hash{}.NewHashFromStr(registration.TokenID)
+	if err != nil {
+		return false
+	}
+	registry, found := state.Registries[*tokenID]
+	if found && registry.IssuerAddressStr != registration.IssuerAddressStr {
+		return false
+	}
+	nonce := uint64(0)
+	if found {
+		nonce = registry.Nonce
+	}
+	if registration.IsSignedByCommittee() {
+		if registration.Nonce != nonce {
+			return false
+		}
+		nonce++
+	}
+	if symbolTokenID, used := state.findTokenIDBySymbol(registration.TokenSymbol); used && !symbolTokenID.IsEqual(tokenID) {
+		return false
+	}
+	createdBeaconHeight := beaconHeight
+	if found {
+		createdBeaconHeight = registry.CreatedBeaconHeight
+	}
+	state.Registries[*tokenID] = &lvdb.PrivacyTokenRegistry{
+		TokenID:              *tokenID,
+		TokenName:            registration.TokenName,
+		TokenSymbol:          registration.TokenSymbol,
+		Decimals:             registration.Decimals,
+		Description:          registration.Description,
+		IconHash:             registration.IconHash,
+		ExternalNetwork:      registration.ExternalNetwork,
+		ExternalTokenID:      registration.ExternalTokenID,
+		IssuerAddressStr:     registration.IssuerAddressStr,
+		Nonce:                nonce,
+		CreatedBeaconHeight:  createdBeaconHeight,
+		UpdatedBeaconHeight:  beaconHeight,
+		LastRegistrationTxID: txReqID,
+	}
+	state.touchedTokens[*tokenID] = true
+	return true
+}
+
+func storePrivacyTokenRegistryStateToDB(
+	db database.DatabaseInterface,
+	currentPrivacyTokenRegistryState *CurrentPrivacyTokenRegistryState,
+) error {
+	var keys []common.Hash
+	for k := range currentPrivacyTokenRegistryState.touchedTokens {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].String() < keys[j].String()
+	})
+	for _, tokenID := range keys {
+		registry, found := currentPrivacyTokenRegistryState.Registries[tokenID]
+		if !found || registry == nil {
+			continue
+		}
+		registryBytes, err := json.Marshal(registry)
+		if err != nil {
+			return err
+		}
+		err = db.StorePrivacyTokenRegistry(tokenID, registryBytes)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,148 @@
+package blockchain
+
+import (
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/database/lvdb"
+	"github.com/incognitochain/incognito-chain/metadata"
+)
+
+func TestApplyPrivacyTokenRegistration(t *testing.T) {
+	state := &CurrentPrivacyTokenRegistryState{
+		Registries:    make(map[common.Hash]*lvdb.PrivacyTokenRegistry),
+		touchedTokens: make(map[common.Hash]bool),
+	}
+	tokenID := common.Hash{1}
+	registration := metadata.PrivacyTokenRegistration{
+		TokenID:          tokenID.String(),
+		TokenName:        "Token",
+		TokenSymbol:      "TKN",
+		IssuerAddressStr: "issuer",
+	}
+	if !state.applyPrivacyTokenRegistration(registration, common.Hash{10}, 5) {
+		t.Errorf("registration of a new token should be accepted")
+	}
+
+	// update by issuer keeps created beacon height
+	registration.TokenName = "New Token"
+	if !state.applyPrivacyTokenRegistration(registration, common.Hash{11}, 7) {
+		t.Errorf("update by issuer should be accepted")
+	}
+	registry := state.Registries[tokenID]
+	if registry.TokenName != "New Token" || registry.CreatedBeaconHeight != 5 || registry.UpdatedBeaconHeight != 7 {
+		t.Errorf("unexpected registry: %+v", registry)
+	}
+
+	// update by another issuer
+	registration.IssuerAddressStr = "another"
+	if state.applyPrivacyTokenRegistration(registration, common.Hash{12}, 8) {
+		t.Errorf("update by another issuer should be rejected")
+	}
+
+	// symbol of another token, case insensitive
+	otherRegistration := metadata.PrivacyTokenRegistration{
+		TokenID:          common.Hash{2}.String(),
+		TokenName:        "Other",
+		TokenSymbol:      "tkn",
+		IssuerAddressStr: "another",
+	}
+	if state.applyPrivacyTokenRegistration(otherRegistration, common.Hash{13}, 9) {
+		t.Errorf("registration with a used symbol should be rejected")
+	}
+	if len(state.Registries) != 1 || len(state.touchedTokens) != 1 {
+		t.Errorf("rejected registrations should not change the state: %+v", state.Registries)
+	}
+}
+
+func TestApplyPrivacyTokenRegistrationByCommittee(t *testing.T) {
+	state := &CurrentPrivacyTokenRegistryState{
+		Registries:    make(map[common.Hash]*lvdb.PrivacyTokenRegistry),
+		touchedTokens: make(map[common.Hash]bool),
+	}
+	tokenID := common.Hash{3}
+	registration := metadata.PrivacyTokenRegistration{
+		TokenID:             tokenID.String(),
+		TokenName:           "Bridge Token",
+		TokenSymbol:         "BTKN",
+		Nonce:               1,
+		CommitteeSignatures: []string{"signature"},
+	}
+	if state.applyPrivacyTokenRegistration(registration, common.Hash{20}, 5) {
+		t.Errorf("registration with a wrong nonce should be rejected")
+	}
+	registration.Nonce = 0
+	if !state.applyPrivacyTokenRegistration(registration, common.Hash{21}, 6) {
+		t.Errorf("registration approved by the committee should be accepted")
+	}
+	if state.Registries[tokenID].Nonce != 1 {
+		t.Errorf("nonce should be increased, got %d", state.Registries[tokenID].Nonce)
+	}
+
+	// replay of the same approval
+	if state.applyPrivacyTokenRegistration(registration, common.Hash{22}, 7) {
+		t.Errorf("replayed registration should be rejected")
+	}
+
+	// an issuer cannot take over a token registered by the committee
+	issuerRegistration := metadata.PrivacyTokenRegistration{
+		TokenID:          tokenID.String(),
+		TokenName:        "Fake Token",
+		TokenSymbol:      "BTKN",
+		IssuerAddressStr: "issuer",
+	}
+	if state.applyPrivacyTokenRegistration(issuerRegistration, common.Hash{23}, 8) {
+		t.Errorf("registration by an issuer should be rejected")
+	}
+	if state.Registries[tokenID].TokenName != "Bridge Token" {
+		t.Errorf("unexpected registry: %+v", state.Registries[tokenID])
+	}
+}
+
+func TestPrivacyTokenRegistryRevert(t *testing.T) {
+	_, db, cleanup := newTestStakingPoolChain(t)
+	defer cleanup()
+	tokenID := common.Hash{3}
+	registration := metadata.PrivacyTokenRegistration{
+		TokenID:             tokenID.String(),
+		TokenName:           "Bridge Token",
+		TokenSymbol:         "BTKN",
+		CommitteeSignatures: []string{"signature"},
+	}
+	storeBlock := func(txReqID common.Hash, beaconHeight uint64) {
+		if err := db.CleanBackup(true, 0); err != nil {
+			t.Fatal(err)
+		}
+		state, err := InitCurrentPrivacyTokenRegistryStateFromDB(db)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !state.applyPrivacyTokenRegistration(registration, txReqID, beaconHeight) {
+			t.Fatalf("registration with the next nonce should be accepted")
+		}
+		if err := storePrivacyTokenRegistryStateToDB(db, state); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	storeBlock(common.Hash{21}, 6)
+	// a reverted block gives back the nonce and the registry it updated
+	registration.Nonce = 1
+	registration.TokenName = "Renamed Token"
+	storeBlock(common.Hash{22}, 7)
+	if err := db.RestoreBeaconStates(); err != nil {
+		t.Fatal(err)
+	}
+	state, err := InitCurrentPrivacyTokenRegistryStateFromDB(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	registry := state.Registries[tokenID]
+	if registry == nil || registry.Nonce != 1 || registry.TokenName != "Bridge Token" ||
+		registry.UpdatedBeaconHeight != 6 || registry.LastRegistrationTxID != (common.Hash{21}) {
+		t.Fatalf("unexpected registry after revert: %+v", registry)
+	}
+
+	// the re-proposed block is applied again
+	storeBlock(common.Hash{22}, 7)
+}
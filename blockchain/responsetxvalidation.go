@@ -15,9 +15,11 @@ func (blockchain *BlockChain) verifyMinerCreatedTxBeforeGettingInBlock(
 	txsUsed := make([]int, len(txs))
 	invalidTxs := []metadata.Transaction{}
 	accumulatedValues := &metadata.AccumulatedValues{
-		UniqETHTxsUsed:   [][]byte{},
-		DBridgeTokenPair: map[string][]byte{},
-		CBridgeTokens:    []*common.Hash{},
+		UniqETHTxsUsed:       [][]byte{},
+		UniqBTCTxsUsed:       [][]byte{},
+		DBridgeTokenPair:     map[string][]byte{},
+		DBridgeTokenChainIDs: map[string]uint64{},
+		CBridgeTokens:        []*common.Hash{},
 	}
 	for _, tx := range txs {
 		ok, err := tx.VerifyMinerCreatedTxBeforeGettingInBlock(txs, txsUsed, insts, instUsed, shardID, blockchain, accumulatedValues)
@@ -1,7 +1,7 @@
 package blockchain
 
 import (
-	"encoding/json"
+	"fmt"
 
 	"github.com/incognitochain/incognito-chain/common"
 	"github.com/incognitochain/incognito-chain/database"
@@ -14,6 +14,11 @@ func (blockchain *BlockChain) GetStakingAmountShard() uint64 {
 	return blockchain.config.ChainParams.StakingAmountShard
 }
 
+// IsPDEMarketIndexerEnabled returns true if the node indexes market data of pde
+func (blockchain *BlockChain) IsPDEMarketIndexerEnabled() bool {
+	return blockchain.config.EnablePDEMarketIndexer
+}
+
 func (blockchain *BlockChain) GetDatabase() database.DatabaseInterface {
 	return blockchain.config.DataBase
 }
@@ -82,7 +87,7 @@ func (blockchain *BlockChain) GetAllCommitteeValidatorCandidate() (map[byte][]in
 	if err != nil {
 		return SC, SPV, []incognitokey.CommitteePublicKey{}, []incognitokey.CommitteePublicKey{}, []incognitokey.CommitteePublicKey{}, []incognitokey.CommitteePublicKey{}, []incognitokey.CommitteePublicKey{}, []incognitokey.CommitteePublicKey{}, nil
 	} else {
-		if err := json.Unmarshal(temp, &beaconBestState); err != nil {
+		if err := UnmarshalStoredBeaconBestState(temp, &beaconBestState); err != nil {
 			Logger.log.Error(err)
 			return SC, SPV, []incognitokey.CommitteePublicKey{}, []incognitokey.CommitteePublicKey{}, []incognitokey.CommitteePublicKey{}, []incognitokey.CommitteePublicKey{}, []incognitokey.CommitteePublicKey{}, []incognitokey.CommitteePublicKey{}, nil
 		}
@@ -108,7 +113,7 @@ func (blockchain *BlockChain) GetAllCommitteeValidatorCandidateFlattenListFromDa
 	if err != nil {
 		return nil, err
 	} else {
-		if err := json.Unmarshal(temp, &beaconBestState); err != nil {
+		if err := UnmarshalStoredBeaconBestState(temp, &beaconBestState); err != nil {
 			return nil, err
 		}
 	}
@@ -173,6 +178,10 @@ func (blockchain *BlockChain) GetAutoStakingList() map[string]bool {
 	return blockchain.BestState.Beacon.GetAutoStakingList()
 }
 
+func (blockchain *BlockChain) GetUnbondingStakes() map[string]uint64 {
+	return blockchain.BestState.Beacon.GetUnbondingStakes()
+}
+
 func (blockchain *BlockChain) GetCentralizedWebsitePaymentAddress() string {
 	return blockchain.config.ChainParams.CentralizedWebsitePaymentAddress
 }
@@ -181,6 +190,17 @@ func (blockchain *BlockChain) GetBeaconHeightBreakPointBurnAddr() uint64 {
 	return blockchain.config.ChainParams.BeaconHeightBreakPointBurnAddr
 }
 
+// GetEVMChain returns a chain of the registry of evm chains, requests without chain id are of DefaultEVMChainID
+func (blockchain *BlockChain) GetEVMChain(chainID uint64) (*metadata.EVMChain, error) {
+	for _, chain := range blockchain.config.ChainParams.EVMChains {
+		if chain.ChainID == chainID {
+			chain := chain
+			return &chain, nil
+		}
+	}
+	return nil, NewBlockChainError(GetEVMChainError, fmt.Errorf("chain %d is not in the registry of evm chains", chainID))
+}
+
 func (blockchain *BlockChain) GetBurningAddress(beaconHeight uint64) string {
 	breakPoint := blockchain.GetBeaconHeightBreakPointBurnAddr()
 	if beaconHeight == 0 {
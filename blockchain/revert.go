@@ -51,7 +51,7 @@ func (blockchain *BlockChain) ValidateBlockWithPrevShardBestState(block *ShardBl
 		return NewBlockChainError(DatabaseError, err)
 	}
 	parentBlock := ShardBlock{}
-	json.Unmarshal(parentBlockData, &parentBlock)
+	UnmarshalStoredShardBlock(parentBlockData, &parentBlock)
 	// Verify block height with parent block
 	if parentBlock.Header.Height+1 != block.Header.Height {
 		return NewBlockChainError(ShardStateError, errors.New("block height of new block should be :"+strconv.Itoa(int(block.Header.Height+1))))
@@ -124,6 +124,10 @@ func (blockchain *BlockChain) revertShardState(shardID byte) error {
 		}
 	}
 
+	if err := blockchain.deleteCrossShardTransactions(currentBestStateBlk); err != nil {
+		return NewBlockChainError(RevertStateError, err)
+	}
+
 	if err := blockchain.restoreFromTxViewPoint(currentBestStateBlk); err != nil {
 		return NewBlockChainError(RevertStateError, err)
 	}
@@ -242,6 +246,46 @@ func (blockchain *BlockChain) backupDatabaseFromBeaconInstruction(
 						}
 					}
 					continue
+
+				case metadata.StakingPoolRewardRequestMeta:
+					stakingPoolRewardInfo, err := metadata.NewStakingPoolRewardInfoFromStr(l[3])
+					if err != nil {
+						return err
+					}
+					for paymentAddressStr, rewards := range stakingPoolRewardInfo.Rewards {
+						keyWallet, err := wallet.Base58CheckDeserialize(paymentAddressStr)
+						if err != nil {
+							return err
+						}
+						for key := range rewards {
+							err = db.BackupCommitteeReward(keyWallet.KeySet.PaymentAddress.Pk, key)
+							if err != nil {
+								return err
+							}
+						}
+					}
+					continue
+
+				case metadata.DAOTreasuryPayoutMeta:
+					if l[2] != common.DAOGovernanceAcceptedChainStatus {
+						continue
+					}
+					var payoutContent metadata.DAOTreasuryPayoutContent
+					err := decodeContent(l[3], &payoutContent)
+					if err != nil {
+						return err
+					}
+					keyWallet, err := wallet.Base58CheckDeserialize(payoutContent.ReceiverAddress)
+					if err != nil {
+						return err
+					}
+					for key := range payoutContent.Amounts {
+						err = db.BackupCommitteeReward(keyWallet.KeySet.PaymentAddress.Pk, key)
+						if err != nil {
+							return err
+						}
+					}
+					continue
 				}
 			}
 			switch metaType {
@@ -458,6 +502,46 @@ func (blockchain *BlockChain) restoreDatabaseFromBeaconInstruction(beaconBlocks
 					}
 					continue
 
+				case metadata.StakingPoolRewardRequestMeta:
+					stakingPoolRewardInfo, err := metadata.NewStakingPoolRewardInfoFromStr(l[3])
+					if err != nil {
+						return err
+					}
+					for paymentAddressStr, rewards := range stakingPoolRewardInfo.Rewards {
+						keyWallet, err := wallet.Base58CheckDeserialize(paymentAddressStr)
+						if err != nil {
+							return err
+						}
+						for key := range rewards {
+							err = db.RestoreCommitteeReward(keyWallet.KeySet.PaymentAddress.Pk, key)
+							if err != nil {
+								return err
+							}
+						}
+					}
+					continue
+
+				case metadata.DAOTreasuryPayoutMeta:
+					if l[2] != common.DAOGovernanceAcceptedChainStatus {
+						continue
+					}
+					var payoutContent metadata.DAOTreasuryPayoutContent
+					err := decodeContent(l[3], &payoutContent)
+					if err != nil {
+						return err
+					}
+					keyWallet, err := wallet.Base58CheckDeserialize(payoutContent.ReceiverAddress)
+					if err != nil {
+						return err
+					}
+					for key := range payoutContent.Amounts {
+						err = db.RestoreCommitteeReward(keyWallet.KeySet.PaymentAddress.Pk, key)
+						if err != nil {
+							return err
+						}
+					}
+					continue
+
 				case metadata.ShardBlockRewardRequestMeta:
 					shardRewardInfo, err := metadata.NewShardBlockRewardInfoFromString(l[3])
 					if err != nil {
@@ -682,7 +766,7 @@ func (blockchain *BlockChain) ValidateBlockWithPrevBeaconBestState(block *Beacon
 		return NewBlockChainError(DatabaseError, err)
 	}
 	parentBlock := NewBeaconBlock()
-	err = json.Unmarshal(parentBlockBytes, parentBlock)
+	err = UnmarshalStoredBeaconBlock(parentBlockBytes, parentBlock)
 	if err != nil {
 
 	}
@@ -791,6 +875,10 @@ func (blockchain *BlockChain) revertBeaconState() error {
 			}
 		}
 	}
+	// restore stateful data (staking pools, bridge limiter, btc relaying, dao governance, mintable tokens, token registries, pde liquidity positions) written by the block
+	if err := blockchain.config.DataBase.RestoreBeaconStates(); err != nil {
+		return NewBlockChainError(RevertStateError, err)
+	}
 	err = blockchain.config.DataBase.DeleteBeaconBlock(currentBestStateBlk.Header.Hash(), currentBestStateBlk.Header.Height)
 	if err != nil {
 		return err
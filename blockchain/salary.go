@@ -46,6 +46,10 @@ func (blockGenerator *BlockGenerator) buildReturnStakingAmountTx(
 		return nil, NewBlockChainError(FetchShardBlockError, err)
 	}
 	txData := shardBlock.Body.Transactions[index]
+	// stake of a staking pool is held on beacon and returned by delegators' withdrawals
+	if txData.GetMetadataType() == metadata.StakingPoolCreationMeta {
+		return nil, nil
+	}
 	keyWallet, err := wallet.Base58CheckDeserialize(txData.GetMetadata().(*metadata.StakingMetadata).FunderPaymentAddress)
 	if err != nil {
 		Logger.log.Error("SA: cannot get payment address", txData.GetMetadata().(*metadata.StakingMetadata), committeeShardID)
@@ -94,6 +98,7 @@ func (blockchain *BlockChain) BuildRewardInstructionByEpoch(blkHeight, epoch uin
 	var instRewardForBeacons [][]string
 	var instRewardForIncDAO [][]string
 	var instRewardForShards [][]string
+	var instRewardForStakingPools [][]string
 	numberOfActiveShards := blockchain.BestState.Beacon.ActiveShards
 	allCoinID, err := blockchain.config.DataBase.GetAllTokenIDForReward(epoch)
 
@@ -105,6 +110,23 @@ func (blockchain *BlockChain) BuildRewardInstructionByEpoch(blkHeight, epoch uin
 	totalRewards := make([]map[common.Hash]uint64, numberOfActiveShards)
 	totalRewardForBeacon := map[common.Hash]uint64{}
 	totalRewardForIncDAO := map[common.Hash]uint64{}
+	totalRewardForStakingPools := map[string]map[common.Hash]uint64{}
+	poolCommitteeKeys := make([][]string, numberOfActiveShards)
+	currentStakingPoolState, err := InitCurrentStakingPoolStateFromDB(blockchain.GetDatabase())
+	if err != nil {
+		return nil, err
+	}
+	shardCommittees := make(map[byte][]incognitokey.CommitteePublicKey)
+	if len(currentStakingPoolState.StakingPools) > 0 {
+		committeeBytes, err := blockchain.GetDatabase().FetchShardCommitteeByHeight(epoch * blockchain.config.ChainParams.Epoch)
+		if err != nil {
+			return nil, err
+		}
+		err = json.Unmarshal(committeeBytes, &shardCommittees)
+		if err != nil {
+			return nil, err
+		}
+	}
 	for ID := 0; ID < numberOfActiveShards; ID++ {
 		if totalRewards[ID] == nil {
 			totalRewards[ID] = map[common.Hash]uint64{}
@@ -124,6 +146,10 @@ func (blockchain *BlockChain) BuildRewardInstructionByEpoch(blkHeight, epoch uin
 		}
 		mapPlusMap(rewardForBeacon, &totalRewardForBeacon)
 		mapPlusMap(rewardForIncDAO, &totalRewardForIncDAO)
+		poolCommitteeKeys[ID], err = splitRewardForStakingPools(&totalRewards[ID], shardCommittees[byte(ID)], currentStakingPoolState, totalRewardForStakingPools)
+		if err != nil {
+			return nil, err
+		}
 	}
 	if len(totalRewardForBeacon) > 0 {
 		instRewardForBeacons, err = blockchain.BuildInstRewardForBeacons(epoch, totalRewardForBeacon)
@@ -132,18 +158,29 @@ func (blockchain *BlockChain) BuildRewardInstructionByEpoch(blkHeight, epoch uin
 		}
 	}
 
-	instRewardForShards, err = blockchain.BuildInstRewardForShards(epoch, totalRewards)
+	instRewardForShards, err = blockchain.BuildInstRewardForShards(epoch, totalRewards, poolCommitteeKeys)
 	if err != nil {
 		return nil, err
 	}
 
+	if len(totalRewardForStakingPools) > 0 {
+		instRewardForStakingPools, err = blockchain.BuildInstRewardForStakingPools(epoch, totalRewardForStakingPools, currentStakingPoolState)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	if len(totalRewardForIncDAO) > 0 {
-		instRewardForIncDAO, err = blockchain.BuildInstRewardForIncDAO(epoch, totalRewardForIncDAO)
+		if isIncDAOGovernanceActive(blockchain.config.ChainParams.IncDAOGovernance, blkHeight) {
+			instRewardForIncDAO, err = blockchain.BuildInstRewardForIncDAOTreasury(epoch, totalRewardForIncDAO)
+		} else {
+			instRewardForIncDAO, err = blockchain.BuildInstRewardForIncDAO(epoch, totalRewardForIncDAO)
+		}
 		if err != nil {
 			return nil, err
 		}
 	}
-	resInst = common.AppendSliceString(instRewardForBeacons, instRewardForIncDAO, instRewardForShards)
+	resInst = common.AppendSliceString(instRewardForBeacons, instRewardForIncDAO, instRewardForShards, instRewardForStakingPools)
 	return resInst, nil
 }
 
@@ -207,6 +244,46 @@ func (blockchain *BlockChain) updateDatabaseFromBeaconInstructions(beaconBlocks
 						}
 					}
 					continue
+
+				case metadata.StakingPoolRewardRequestMeta:
+					stakingPoolRewardInfo, err := metadata.NewStakingPoolRewardInfoFromStr(l[3])
+					if err != nil {
+						return err
+					}
+					for paymentAddressStr, rewards := range stakingPoolRewardInfo.Rewards {
+						keyWallet, err := wallet.Base58CheckDeserialize(paymentAddressStr)
+						if err != nil {
+							return err
+						}
+						for key, value := range rewards {
+							err = db.AddCommitteeReward(keyWallet.KeySet.PaymentAddress.Pk, value, key)
+							if err != nil {
+								return err
+							}
+						}
+					}
+					continue
+
+				case metadata.DAOTreasuryPayoutMeta:
+					if l[2] != common.DAOGovernanceAcceptedChainStatus {
+						continue
+					}
+					var payoutContent metadata.DAOTreasuryPayoutContent
+					err := decodeContent(l[3], &payoutContent)
+					if err != nil {
+						return err
+					}
+					keyWallet, err := wallet.Base58CheckDeserialize(payoutContent.ReceiverAddress)
+					if err != nil {
+						return err
+					}
+					for key, value := range payoutContent.Amounts {
+						err = db.AddCommitteeReward(keyWallet.KeySet.PaymentAddress.Pk, value, key)
+						if err != nil {
+							return err
+						}
+					}
+					continue
 				}
 			}
 			switch metaType {
@@ -349,6 +426,44 @@ func splitReward(
 	return &rewardForBeacon, &rewardForIncDAO, nil
 }
 
+// splitRewardForStakingPools deducts shares of committee members bound to staked pools
+// out of the shard reward, the same share as getRewardAmountForUserOfShard pays to others
+func splitRewardForStakingPools(
+	totalReward *map[common.Hash]uint64,
+	committee []incognitokey.CommitteePublicKey,
+	currentStakingPoolState *CurrentStakingPoolState,
+	rewardForStakingPools map[string]map[common.Hash]uint64,
+) ([]string, error) {
+	poolCommitteeKeys := []string{}
+	committeeSize := uint64(len(committee))
+	if committeeSize == 0 || len(currentStakingPoolState.StakingPools) == 0 {
+		return poolCommitteeKeys, nil
+	}
+	shares := map[common.Hash]uint64{}
+	for key, value := range *totalReward {
+		shares[key] = value / committeeSize
+	}
+	for _, member := range committee {
+		memberStr, err := member.ToBase58()
+		if err != nil {
+			return nil, err
+		}
+		stakingPool, found := currentStakingPoolState.StakingPools[memberStr]
+		if !found || stakingPool == nil || !stakingPool.IsStaked {
+			continue
+		}
+		poolCommitteeKeys = append(poolCommitteeKeys, memberStr)
+		if _, ok := rewardForStakingPools[memberStr]; !ok {
+			rewardForStakingPools[memberStr] = map[common.Hash]uint64{}
+		}
+		for key, share := range shares {
+			rewardForStakingPools[memberStr][key] += share
+			(*totalReward)[key] -= share
+		}
+	}
+	return poolCommitteeKeys, nil
+}
+
 func getNoBlkPerYear(blockCreationTimeSeconds uint64) uint64 {
 	//31536000 =
 	return (365 * 24 * 60 * 60) / blockCreationTimeSeconds
@@ -373,10 +488,27 @@ func (blockchain *BlockChain) getRewardAmountForUserOfShard(
 	err error,
 ) {
 	committeeSize := len(committeeOfShardToProcess)
+	poolCommitteeKeys := rewardInfoShardToProcess.PoolCommitteeKeys
+	if len(poolCommitteeKeys) > 0 {
+		// shares of staking pools were already deducted and paid by staking pool reward instructions
+		committeeSize -= len(poolCommitteeKeys)
+		if committeeSize <= 0 {
+			return nil
+		}
+	}
 	// wg := sync.WaitGroup{}
 	// done := make(chan bool, 1)
 	// errChan := make(chan error, 1)
 	for _, candidate := range committeeOfShardToProcess {
+		if len(poolCommitteeKeys) > 0 {
+			candidateStr, err := candidate.ToBase58()
+			if err != nil {
+				return err
+			}
+			if common.IndexOfStr(candidateStr, poolCommitteeKeys) > -1 {
+				continue
+			}
+		}
 		// wg.Add(1)
 		// go func() {
 		// 	defer wg.Done()
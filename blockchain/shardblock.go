@@ -323,7 +323,7 @@ func (shardBlock *ShardBlock) CreateShardToBeaconBlock(bc *BlockChain) *ShardToB
 		return nil
 	}
 	previousShardBlock := ShardBlock{}
-	err = json.Unmarshal(previousShardBlockByte, &previousShardBlock)
+	err = UnmarshalStoredShardBlock(previousShardBlockByte, &previousShardBlock)
 	if err != nil {
 		Logger.log.Error(err)
 		return nil
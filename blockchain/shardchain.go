@@ -161,3 +161,7 @@ func (chain *ShardChain) UnmarshalBlock(blockString []byte) (common.BlockInterfa
 func (chain *ShardChain) ValidatePreSignBlock(block common.BlockInterface) error {
 	return chain.Blockchain.VerifyPreSignShardBlock(block.(*ShardBlock), chain.BestState.ShardID)
 }
+
+func (chain *ShardChain) IsCompactBlockActive(block common.BlockInterface) bool {
+	return chain.Blockchain.IsCompactShardBlockActive(block.(*ShardBlock))
+}
@@ -262,6 +262,24 @@ func (shardBestState *ShardBestState) updateNumOfBlocksByProducers(shardBlock *S
 	}
 }
 
+// removeReturnedStakingTx removes staking txs of validators whose stake is returned in shard block
+func (shardBestState *ShardBestState) removeReturnedStakingTx(beaconBlocks []*BeaconBlock, shardBlock *ShardBlock) {
+	for _, beaconBlock := range beaconBlocks {
+		for _, l := range beaconBlock.Body.Instructions {
+			if len(l) != 2 || l[0] != ReturnStakingAction {
+				continue
+			}
+			for _, publicKey := range strings.Split(l[1], ",") {
+				if txID, ok := shardBestState.StakingTx[publicKey]; ok {
+					if checkReturnStakingTxExistence(txID, shardBlock) {
+						delete(shardBestState.StakingTx, publicKey)
+					}
+				}
+			}
+		}
+	}
+}
+
 /* Verify Pre-prosessing data
 This function DOES NOT verify new block with best state
 DO NOT USE THIS with GENESIS BLOCK
@@ -326,7 +344,7 @@ func (blockchain *BlockChain) verifyPreProcessingShardBlock(shardBlock *ShardBlo
 		return NewBlockChainError(FetchPreviousBlockError, err)
 	}
 	previousShardBlock := ShardBlock{}
-	err = json.Unmarshal(previousShardBlockData, &previousShardBlock)
+	err = UnmarshalStoredShardBlock(previousShardBlockData, &previousShardBlock)
 	if err != nil {
 		return NewBlockChainError(UnmashallJsonShardBlockError, err)
 	}
@@ -695,6 +713,7 @@ func (shardBestState *ShardBestState) updateShardBestState(blockchain *BlockChai
 	for stakePublicKey, txHash := range stakingTx {
 		shardBestState.StakingTx[stakePublicKey] = txHash
 	}
+	shardBestState.removeReturnedStakingTx(beaconBlocks, shardBlock)
 	err = shardBestState.processShardBlockInstruction(blockchain, shardBlock)
 	if err != nil {
 		return err
@@ -781,11 +800,13 @@ func (shardBestState *ShardBestState) processShardBlockInstruction(blockchain *B
 			if len(l[2]) != 0 && l[2] != "" {
 				swapedCommittees = strings.Split(l[2], ",")
 			}
-
-			for _, v := range swapedCommittees {
-				if txId, ok := shardBestState.StakingTx[v]; ok {
-					if checkReturnStakingTxExistence(txId, shardBlock) {
-						delete(GetBestStateShard(shardBestState.ShardID).StakingTx, v)
+			// after unbonding is active, staking txs are removed when beacon returns their stake
+			if !blockchain.isUnbondingActive(shardBlock.Header.BeaconHeight) {
+				for _, v := range swapedCommittees {
+					if txId, ok := shardBestState.StakingTx[v]; ok {
+						if checkReturnStakingTxExistence(txId, shardBlock) {
+							delete(GetBestStateShard(shardBestState.ShardID).StakingTx, v)
+						}
 					}
 				}
 			}
@@ -949,6 +970,11 @@ func (blockchain *BlockChain) processStoreShardBlockAndUpdateDatabase(shardBlock
 	if err != nil {
 		return NewBlockChainError(StoreIncomingCrossShardError, err)
 	}
+	// Index outgoing cross shard txs to track their delivery
+	err = blockchain.storeCrossShardTransactions(shardBlock, &batchPutData)
+	if err != nil {
+		return NewBlockChainError(StoreCrossShardTransactionError, err)
+	}
 	// Save result of BurningConfirm instruction to get proof later
 	err = blockchain.storeBurningConfirm(shardBlock, &batchPutData)
 	if err != nil {
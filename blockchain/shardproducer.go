@@ -103,7 +103,7 @@ func (blockGenerator *BlockGenerator) NewBlockShard(shardID byte, round int, cro
 		return nil, err
 	}
 	beaconBlock := BeaconBlock{}
-	err = json.Unmarshal(beaconBlockBytes, &beaconBlock)
+	err = UnmarshalStoredBeaconBlock(beaconBlockBytes, &beaconBlock)
 	if err != nil {
 		return nil, err
 	}
@@ -415,27 +415,44 @@ func (blockGenerator *BlockGenerator) buildResponseTxsFromBeaconInstructions(bea
 	responsedHashTxs := []common.Hash{} // capture hash of responsed tx
 	errorInstructions := [][]string{}   // capture error instruction -> which instruction can not create tx
 	for _, beaconBlock := range beaconBlocks {
+		// before unbonding is active, stake is returned as soon as validators are swapped out
+		isUnbondingActive := blockGenerator.chain.isUnbondingActive(beaconBlock.Header.Height)
 		autoStaking := make(map[string]bool)
-		autoStakingBytes, err := blockGenerator.chain.config.DataBase.FetchAutoStakingByHeight(beaconBlock.Header.Height)
-		if err != nil {
-			return []metadata.Transaction{}, errorInstructions, NewBlockChainError(FetchAutoStakingByHeightError, err)
-		}
-		err = json.Unmarshal(autoStakingBytes, &autoStaking)
-		if err != nil {
-			return []metadata.Transaction{}, errorInstructions, NewBlockChainError(FetchAutoStakingByHeightError, err)
+		if !isUnbondingActive {
+			autoStakingBytes, err := blockGenerator.chain.config.DataBase.FetchAutoStakingByHeight(beaconBlock.Header.Height)
+			if err != nil {
+				return []metadata.Transaction{}, errorInstructions, NewBlockChainError(FetchAutoStakingByHeightError, err)
+			}
+			err = json.Unmarshal(autoStakingBytes, &autoStaking)
+			if err != nil {
+				return []metadata.Transaction{}, errorInstructions, NewBlockChainError(FetchAutoStakingByHeightError, err)
+			}
 		}
 		for _, l := range beaconBlock.Body.Instructions {
-			if l[0] == SwapAction {
-				for _, outPublicKeys := range strings.Split(l[2], ",") {
+			// after unbonding is active, stake is returned after unbonding period by beacon return staking instruction
+			returnedPublicKeys := []string{}
+			if l[0] == SwapAction && !isUnbondingActive {
+				for _, outPublicKey := range strings.Split(l[2], ",") {
 					// If out public key has auto staking then ignore this public key
-					if _, ok := autoStaking[outPublicKeys]; ok {
+					if _, ok := autoStaking[outPublicKey]; ok {
 						continue
 					}
+					returnedPublicKeys = append(returnedPublicKeys, outPublicKey)
+				}
+			}
+			if l[0] == ReturnStakingAction {
+				returnedPublicKeys = strings.Split(l[1], ",")
+			}
+			if len(returnedPublicKeys) > 0 {
+				for _, outPublicKeys := range returnedPublicKeys {
 					tx, err := blockGenerator.buildReturnStakingAmountTx(outPublicKeys, producerPrivateKey)
 					if err != nil {
 						Logger.log.Error(err)
 						continue
 					}
+					if tx == nil {
+						continue
+					}
 					txHash := *tx.Hash()
 					if ok, _ := common.SliceExists(responsedHashTxs, txHash); ok {
 						data, _ := json.Marshal(tx)
@@ -448,7 +465,7 @@ func (blockGenerator *BlockGenerator) buildResponseTxsFromBeaconInstructions(bea
 				}
 
 			}
-			if l[0] == StakeAction || l[0] == RandomAction || l[0] == AssignAction || l[0] == SwapAction {
+			if l[0] == StakeAction || l[0] == RandomAction || l[0] == AssignAction || l[0] == SwapAction || l[0] == UnbondingAction || l[0] == ReturnStakingAction {
 				continue
 			}
 			if len(l) <= 2 {
@@ -468,6 +485,10 @@ func (blockGenerator *BlockGenerator) buildResponseTxsFromBeaconInstructions(bea
 				if len(l) >= 4 && l[2] == "accepted" {
 					newTx, err = blockGenerator.buildIssuanceTx(l[3], producerPrivateKey, shardID)
 				}
+			case metadata.IssuingBTCRequestMeta:
+				if len(l) >= 4 && l[2] == "accepted" {
+					newTx, err = blockGenerator.buildBTCIssuanceTx(l[3], producerPrivateKey, shardID)
+				}
 			case metadata.PDETradeRequestMeta:
 				if len(l) >= 4 {
 					newTx, err = blockGenerator.buildPDETradeIssuanceTx(l[2], l[3], producerPrivateKey, shardID)
@@ -484,6 +505,18 @@ func (blockGenerator *BlockGenerator) buildResponseTxsFromBeaconInstructions(bea
 						newTx, err = blockGenerator.buildPDEMatchedNReturnedContributionTx(l[3], producerPrivateKey, shardID)
 					}
 				}
+			case metadata.StakingPoolDepositMeta:
+				if len(l) >= 4 && l[2] == common.StakingPoolRefundChainStatus {
+					newTx, err = blockGenerator.buildStakingPoolDepositRefundTx(l[3], producerPrivateKey, shardID)
+				}
+			case metadata.StakingPoolWithdrawalRequestMeta:
+				if len(l) >= 4 && (l[2] == common.StakingPoolAcceptedChainStatus || l[2] == common.StakingPoolReleasedChainStatus) {
+					newTx, err = blockGenerator.buildStakingPoolWithdrawalTx(l[2], l[3], producerPrivateKey, shardID)
+				}
+			case metadata.MintableTokenMintMeta:
+				if len(l) >= 4 && l[2] == common.MintableTokenAcceptedChainStatus {
+					newTx, err = blockGenerator.buildMintableTokenMintTx(l[3], producerPrivateKey, shardID)
+				}
 
 			default:
 				continue
@@ -44,7 +44,7 @@ func FetchBeaconBlockFromHeight(db database.DatabaseInterface, from uint64, to u
 			return beaconBlocks, err
 		}
 		beaconBlock := BeaconBlock{}
-		err = json.Unmarshal(beaconBlockByte, &beaconBlock)
+		err = UnmarshalStoredBeaconBlock(beaconBlockByte, &beaconBlock)
 		if err != nil {
 			return beaconBlocks, NewBlockChainError(UnmashallJsonShardBlockError, err)
 		}
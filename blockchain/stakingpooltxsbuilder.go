@@ -0,0 +1,90 @@
+package blockchain
+
+import (
+	"encoding/json"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/incognitochain/incognito-chain/privacy"
+	"github.com/incognitochain/incognito-chain/transaction"
+	"github.com/incognitochain/incognito-chain/wallet"
+)
+
+func (blockGenerator *BlockGenerator) buildStakingPoolResTx(
+	instStatus string,
+	receiverAddressStr string,
+	receiveAmt uint64,
+	requestedTxID common.Hash,
+	producerPrivateKey *privacy.PrivateKey,
+) (metadata.Transaction, error) {
+	meta := metadata.NewStakingPoolResponse(
+		instStatus,
+		requestedTxID,
+		metadata.StakingPoolResponseMeta,
+	)
+	keyWallet, err := wallet.Base58CheckDeserialize(receiverAddressStr)
+	if err != nil {
+		Logger.log.Errorf("ERROR: an error occured while deserializing delegator address string: %+v", err)
+		return nil, nil
+	}
+	receiverAddr := keyWallet.KeySet.PaymentAddress
+	resTx := new(transaction.Tx)
+	err = resTx.InitTxSalary(
+		receiveAmt,
+		&receiverAddr,
+		producerPrivateKey,
+		blockGenerator.chain.config.DataBase,
+		meta,
+	)
+	if err != nil {
+		return nil, NewBlockChainError(InitStakingPoolResponseTransactionError, err)
+	}
+	return resTx, nil
+}
+
+func (blockGenerator *BlockGenerator) buildStakingPoolDepositRefundTx(
+	contentStr string,
+	producerPrivateKey *privacy.PrivateKey,
+	shardID byte,
+) (metadata.Transaction, error) {
+	var depositContent metadata.StakingPoolDepositAcceptedContent
+	err := json.Unmarshal([]byte(contentStr), &depositContent)
+	if err != nil {
+		Logger.log.Errorf("ERROR: an error occured while unmarshaling staking pool deposit refund content: %+v", err)
+		return nil, nil
+	}
+	if depositContent.ShardID != shardID {
+		return nil, nil
+	}
+	return blockGenerator.buildStakingPoolResTx(
+		common.StakingPoolRefundChainStatus,
+		depositContent.DelegatorAddressStr,
+		depositContent.DepositAmount,
+		depositContent.TxReqID,
+		producerPrivateKey,
+	)
+}
+
+func (blockGenerator *BlockGenerator) buildStakingPoolWithdrawalTx(
+	instStatus string,
+	contentStr string,
+	producerPrivateKey *privacy.PrivateKey,
+	shardID byte,
+) (metadata.Transaction, error) {
+	var wdAcceptedContent metadata.StakingPoolWithdrawalAcceptedContent
+	err := json.Unmarshal([]byte(contentStr), &wdAcceptedContent)
+	if err != nil {
+		Logger.log.Errorf("ERROR: an error occured while unmarshaling staking pool withdrawal content: %+v", err)
+		return nil, nil
+	}
+	if wdAcceptedContent.ShardID != shardID {
+		return nil, nil
+	}
+	return blockGenerator.buildStakingPoolResTx(
+		instStatus,
+		wdAcceptedContent.DelegatorAddressStr,
+		wdAcceptedContent.WithdrawalAmount,
+		wdAcceptedContent.TxReqID,
+		producerPrivateKey,
+	)
+}
@@ -0,0 +1,130 @@
+package blockchain
+
+import (
+	"encoding/json"
+	"math/big"
+	"sort"
+
+	"github.com/incognitochain/incognito-chain/database"
+	"github.com/incognitochain/incognito-chain/database/lvdb"
+	"github.com/incognitochain/incognito-chain/metadata"
+)
+
+type CurrentStakingPoolState struct {
+	StakingPools map[string]*lvdb.StakingPool
+	Delegations  map[string]map[string]uint64 // committee public key => delegator address => amount
+	touchedPools map[string]bool
+}
+
+func InitCurrentStakingPoolStateFromDB(
+	db database.DatabaseInterface,
+) (*CurrentStakingPoolState, error) {
+	stakingPoolsBytes, err := db.GetAllStakingPools()
+	if err != nil {
+		return nil, err
+	}
+	stakingPools := make(map[string]*lvdb.StakingPool)
+	for _, stakingPoolBytes := range stakingPoolsBytes {
+		var stakingPool lvdb.StakingPool
+		err = json.Unmarshal(stakingPoolBytes, &stakingPool)
+		if err != nil {
+			return nil, err
+		}
+		stakingPools[stakingPool.CommitteePublicKey] = &stakingPool
+	}
+	return &CurrentStakingPoolState{
+		StakingPools: stakingPools,
+		Delegations:  make(map[string]map[string]uint64),
+		touchedPools: make(map[string]bool),
+	}, nil
+}
+
+// getDelegations lazily loads delegations of a staking pool from db
+func (state *CurrentStakingPoolState) getDelegations(
+	db database.DatabaseInterface,
+	committeePublicKey string,
+) (map[string]uint64, error) {
+	delegations, found := state.Delegations[committeePublicKey]
+	if found {
+		return delegations, nil
+	}
+	delegations, err := db.GetStakingPoolDelegations(committeePublicKey)
+	if err != nil {
+		return nil, err
+	}
+	state.Delegations[committeePublicKey] = delegations
+	return delegations, nil
+}
+
+func (state *CurrentStakingPoolState) markTouched(committeePublicKey string) {
+	state.touchedPools[committeePublicKey] = true
+}
+
+func storeStakingPoolStateToDB(
+	db database.DatabaseInterface,
+	currentStakingPoolState *CurrentStakingPoolState,
+) error {
+	var keys []string
+	for k := range currentStakingPoolState.touchedPools {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, committeePublicKey := range keys {
+		stakingPool, found := currentStakingPoolState.StakingPools[committeePublicKey]
+		if !found || stakingPool == nil {
+			continue
+		}
+		stakingPoolBytes, err := json.Marshal(stakingPool)
+		if err != nil {
+			return err
+		}
+		err = db.StoreStakingPool(committeePublicKey, stakingPoolBytes)
+		if err != nil {
+			return err
+		}
+		for delegatorAddressStr, amount := range currentStakingPoolState.Delegations[committeePublicKey] {
+			err = db.StoreStakingPoolDelegation(committeePublicKey, delegatorAddressStr, amount)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// splitStakingPoolReward pays the operator's commission first, the rest is split
+// among delegators pro rata to their delegations, rounding dust goes to the operator
+func splitStakingPoolReward(
+	stakingPool *lvdb.StakingPool,
+	delegations map[string]uint64,
+	reward uint64,
+) map[string]uint64 {
+	payouts := map[string]uint64{}
+	if reward == 0 {
+		return payouts
+	}
+	totalDelegation := uint64(0)
+	for _, amount := range delegations {
+		totalDelegation += amount
+	}
+	if totalDelegation == 0 {
+		payouts[stakingPool.OperatorAddressStr] = reward
+		return payouts
+	}
+	commissionBN := big.NewInt(0).Mul(new(big.Int).SetUint64(reward), new(big.Int).SetUint64(stakingPool.CommissionRate))
+	commission := commissionBN.Div(commissionBN, big.NewInt(metadata.MaxStakingPoolCommissionRate)).Uint64()
+	rest := reward - commission
+	paid := uint64(0)
+	for delegatorAddressStr, amount := range delegations {
+		// rest * amount could overflow uint64
+		payoutBN := big.NewInt(0).Mul(new(big.Int).SetUint64(rest), new(big.Int).SetUint64(amount))
+		payout := payoutBN.Div(payoutBN, new(big.Int).SetUint64(totalDelegation)).Uint64()
+		if payout == 0 {
+			continue
+		}
+		payouts[delegatorAddressStr] += payout
+		paid += payout
+	}
+	payouts[stakingPool.OperatorAddressStr] += reward - paid
+	return payouts
+}
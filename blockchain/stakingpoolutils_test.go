@@ -0,0 +1,371 @@
+package blockchain
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/database"
+	"github.com/incognitochain/incognito-chain/database/lvdb"
+	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/incognitochain/incognito-chain/privacy"
+	"github.com/incognitochain/incognito-chain/wallet"
+)
+
+const testStakingPoolCommitteeKey = "committee-key"
+
+func newTestStakingPoolChain(t *testing.T) (*BlockChain, database.DatabaseInterface, func()) {
+	dbPath, err := ioutil.TempDir(os.TempDir(), "test_stakingpool")
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %+v", err)
+	}
+	db, err := database.Open("leveldb", dbPath)
+	if err != nil {
+		os.RemoveAll(dbPath)
+		t.Fatal(err)
+	}
+	bc := &BlockChain{
+		BestState: &BestState{Beacon: &BeaconBestState{}},
+		config: Config{
+			ChainParams: &Params{StakingAmountShard: 1000},
+			DataBase:    db,
+		},
+	}
+	return bc, db, func() { os.RemoveAll(dbPath) }
+}
+
+func newTestPaymentAddress(t *testing.T, seed byte) string {
+	keyWallet := &wallet.KeyWallet{}
+	if err := keyWallet.KeySet.InitFromPrivateKeyByte(privacy.GeneratePrivateKey([]byte{seed})); err != nil {
+		t.Fatal(err)
+	}
+	return keyWallet.Base58CheckSerialize(wallet.PaymentAddressType)
+}
+
+func buildTestStakingPoolAction(t *testing.T, action interface{}) string {
+	actionBytes, err := json.Marshal(action)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return base64.StdEncoding.EncodeToString(actionBytes)
+}
+
+func buildTestStakingPoolCreation(t *testing.T, bc *BlockChain, state *CurrentStakingPoolState, txReqID byte) [][]string {
+	action := metadata.StakingPoolCreationAction{
+		Meta: metadata.StakingPoolCreation{
+			CommitteePublicKey: testStakingPoolCommitteeKey,
+			OperatorAddressStr: "operator",
+			CommissionRate:     1000,
+		},
+		TxReqID: common.Hash{txReqID},
+	}
+	insts, err := bc.buildInstructionsForStakingPoolCreation(buildTestStakingPoolAction(t, action), 0, metadata.StakingPoolCreationMeta, state, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return insts
+}
+
+func buildTestStakingPoolDeposit(t *testing.T, bc *BlockChain, state *CurrentStakingPoolState, delegator string, amount uint64) [][]string {
+	action := metadata.StakingPoolDepositAction{
+		Meta: metadata.StakingPoolDeposit{
+			CommitteePublicKey:  testStakingPoolCommitteeKey,
+			DelegatorAddressStr: delegator,
+			DepositAmount:       amount,
+		},
+	}
+	insts, err := bc.buildInstructionsForStakingPoolDeposit(buildTestStakingPoolAction(t, action), 0, metadata.StakingPoolDepositMeta, state)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return insts
+}
+
+func buildTestStakingPoolWithdrawal(t *testing.T, bc *BlockChain, state *CurrentStakingPoolState, delegator string, amount uint64) [][]string {
+	action := metadata.StakingPoolWithdrawalRequestAction{
+		Meta: metadata.StakingPoolWithdrawalRequest{
+			CommitteePublicKey:  testStakingPoolCommitteeKey,
+			DelegatorAddressStr: delegator,
+			WithdrawalAmount:    amount,
+		},
+		TxReqID: common.Hash{byte(amount)},
+	}
+	insts, err := bc.buildInstructionsForStakingPoolWithdrawal(buildTestStakingPoolAction(t, action), 0, metadata.StakingPoolWithdrawalRequestMeta, state, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return insts
+}
+
+func processTestStakingPoolBlock(t *testing.T, bc *BlockChain, insts [][]string) *CurrentStakingPoolState {
+	block := &BeaconBlock{
+		Header: BeaconHeader{Height: 10},
+		Body:   BeaconBody{Instructions: insts},
+	}
+	if err := bc.processStakingPoolInstructions(block, nil); err != nil {
+		t.Fatal(err)
+	}
+	state, err := InitCurrentStakingPoolStateFromDB(bc.GetDatabase())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return state
+}
+
+func TestStakingPoolProducer(t *testing.T) {
+	bc, _, cleanup := newTestStakingPoolChain(t)
+	defer cleanup()
+	state, err := InitCurrentStakingPoolStateFromDB(bc.GetDatabase())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	insts := buildTestStakingPoolDeposit(t, bc, state, "delegator", 100)
+	if len(insts) != 1 || insts[0][2] != common.StakingPoolRefundChainStatus {
+		t.Errorf("deposit to an unknown pool should be refunded: %+v", insts)
+	}
+	insts = buildTestStakingPoolCreation(t, bc, state, 1)
+	if len(insts) != 1 || insts[0][2] != common.StakingPoolAcceptedChainStatus {
+		t.Fatalf("unexpected creation instructions: %+v", insts)
+	}
+	insts = buildTestStakingPoolCreation(t, bc, state, 2)
+	if len(insts) != 1 || insts[0][2] != common.StakingPoolRejectedChainStatus {
+		t.Errorf("a committee key should not be bound to two pools: %+v", insts)
+	}
+
+	insts = buildTestStakingPoolDeposit(t, bc, state, "delegator1", 600)
+	if len(insts) != 1 || insts[0][2] != common.StakingPoolAcceptedChainStatus {
+		t.Errorf("unexpected deposit instructions: %+v", insts)
+	}
+	insts = buildTestStakingPoolWithdrawal(t, bc, state, "delegator1", 601)
+	if len(insts) != 1 || insts[0][2] != common.StakingPoolRejectedChainStatus {
+		t.Errorf("withdrawal of more than the delegation should be rejected: %+v", insts)
+	}
+	// the pool is staked once delegations cover the staking amount
+	insts = buildTestStakingPoolDeposit(t, bc, state, "delegator2", 400)
+	if len(insts) != 2 || insts[1][0] != StakeAction || insts[1][1] != testStakingPoolCommitteeKey {
+		t.Fatalf("unexpected stake instructions: %+v", insts)
+	}
+	if !state.StakingPools[testStakingPoolCommitteeKey].IsStaked || state.StakingPools[testStakingPoolCommitteeKey].TotalDelegation != 1000 {
+		t.Errorf("unexpected staking pool: %+v", state.StakingPools[testStakingPoolCommitteeKey])
+	}
+	insts = buildTestStakingPoolWithdrawal(t, bc, state, "delegator1", 1)
+	if len(insts) != 1 || insts[0][2] != common.StakingPoolRejectedChainStatus {
+		t.Errorf("staked pool should keep the staking amount: %+v", insts)
+	}
+	insts = buildTestStakingPoolDeposit(t, bc, state, "delegator1", 100)
+	if len(insts) != 1 {
+		t.Errorf("a staked pool should not be staked again: %+v", insts)
+	}
+	insts = buildTestStakingPoolWithdrawal(t, bc, state, "delegator1", 100)
+	if len(insts) != 1 || insts[0][2] != common.StakingPoolAcceptedChainStatus {
+		t.Errorf("withdrawal above the staking amount should be accepted: %+v", insts)
+	}
+}
+
+func TestStakingPoolProcessAndRevert(t *testing.T) {
+	bc, db, cleanup := newTestStakingPoolChain(t)
+	defer cleanup()
+	producerState, err := InitCurrentStakingPoolStateFromDB(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	insts := buildTestStakingPoolCreation(t, bc, producerState, 1)
+	insts = append(insts, buildTestStakingPoolDeposit(t, bc, producerState, "delegator1", 600)...)
+	insts = append(insts, buildTestStakingPoolDeposit(t, bc, producerState, "delegator2", 400)...)
+
+	// a reverted block removes the pool it created
+	if err := db.CleanBackup(true, 0); err != nil {
+		t.Fatal(err)
+	}
+	state := processTestStakingPoolBlock(t, bc, insts)
+	if len(state.StakingPools) != 1 {
+		t.Fatalf("unexpected staking pools: %+v", state.StakingPools)
+	}
+	if err := db.RestoreBeaconStates(); err != nil {
+		t.Fatal(err)
+	}
+	state, _ = InitCurrentStakingPoolStateFromDB(db)
+	if len(state.StakingPools) != 0 {
+		t.Errorf("staking pool created by a reverted block should be removed: %+v", state.StakingPools)
+	}
+
+	if err := db.CleanBackup(true, 0); err != nil {
+		t.Fatal(err)
+	}
+	state = processTestStakingPoolBlock(t, bc, insts)
+	stakingPool := state.StakingPools[testStakingPoolCommitteeKey]
+	if stakingPool == nil || !stakingPool.IsStaked || stakingPool.TotalDelegation != 1000 || stakingPool.OperatorAddressStr != "operator" {
+		t.Fatalf("unexpected staking pool: %+v", stakingPool)
+	}
+	delegations, _ := db.GetStakingPoolDelegations(testStakingPoolCommitteeKey)
+	if len(delegations) != 2 || delegations["delegator1"] != 600 || delegations["delegator2"] != 400 {
+		t.Errorf("unexpected delegations: %+v", delegations)
+	}
+
+	// a reverted withdrawal gives the delegation back
+	if err := db.CleanBackup(true, 0); err != nil {
+		t.Fatal(err)
+	}
+	producerState, _ = InitCurrentStakingPoolStateFromDB(db)
+	producerState.StakingPools[testStakingPoolCommitteeKey].IsStaked = false
+	insts = buildTestStakingPoolWithdrawal(t, bc, producerState, "delegator2", 400)
+	state = processTestStakingPoolBlock(t, bc, insts)
+	delegations, _ = db.GetStakingPoolDelegations(testStakingPoolCommitteeKey)
+	if state.StakingPools[testStakingPoolCommitteeKey].TotalDelegation != 600 || len(delegations) != 1 {
+		t.Fatalf("unexpected state after withdrawal: %+v %+v", state.StakingPools[testStakingPoolCommitteeKey], delegations)
+	}
+	if err := db.RestoreBeaconStates(); err != nil {
+		t.Fatal(err)
+	}
+	state, _ = InitCurrentStakingPoolStateFromDB(db)
+	delegations, _ = db.GetStakingPoolDelegations(testStakingPoolCommitteeKey)
+	if state.StakingPools[testStakingPoolCommitteeKey].TotalDelegation != 1000 || delegations["delegator2"] != 400 {
+		t.Errorf("unexpected state after revert: %+v %+v", state.StakingPools[testStakingPoolCommitteeKey], delegations)
+	}
+}
+
+func TestStakingPoolPartialUnstake(t *testing.T) {
+	bc, db, cleanup := newTestStakingPoolChain(t)
+	defer cleanup()
+	producerState, err := InitCurrentStakingPoolStateFromDB(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	insts := buildTestStakingPoolCreation(t, bc, producerState, 1)
+	insts = append(insts, buildTestStakingPoolDeposit(t, bc, producerState, "delegator1", 600)...)
+	insts = append(insts, buildTestStakingPoolDeposit(t, bc, producerState, "delegator2", 400)...)
+	processTestStakingPoolBlock(t, bc, insts)
+
+	// the pool stopped auto staking, delegators can unstake part of its stake
+	bc.BestState.Beacon.AutoStaking = map[string]bool{testStakingPoolCommitteeKey: false}
+	bc.config.ChainParams.BeaconHeightBreakPointUnbonding = 100
+	producerState, _ = InitCurrentStakingPoolStateFromDB(db)
+	insts = buildTestStakingPoolWithdrawal(t, bc, producerState, "delegator2", 50)
+	if len(insts) != 1 || insts[0][2] != common.StakingPoolRejectedChainStatus {
+		t.Errorf("partial unstake should be rejected before unbonding is active: %+v", insts)
+	}
+	bc.config.ChainParams.BeaconHeightBreakPointUnbonding = 0
+	insts = buildTestStakingPoolWithdrawal(t, bc, producerState, "delegator2", 50)
+	if len(insts) != 1 || insts[0][2] != common.StakingPoolUnbondingChainStatus {
+		t.Fatalf("partial unstake of a stopped pool should wait for unbonding: %+v", insts)
+	}
+	state := processTestStakingPoolBlock(t, bc, insts)
+	withdrawalTxReqID := common.Hash{50}
+	stakingPool := state.StakingPools[testStakingPoolCommitteeKey]
+	if stakingPool.TotalDelegation != 950 || len(stakingPool.UnbondingWithdrawals) != 1 || stakingPool.UnbondingWithdrawals[0].WithdrawalAmount != 50 {
+		t.Fatalf("unexpected staking pool after partial unstake: %+v", stakingPool)
+	}
+	status, _ := db.GetStakingPoolStatus(withdrawalTxReqID[:])
+	if status != common.StakingPoolUnbondingStatus {
+		t.Errorf("unexpected status of partial unstake: %d", status)
+	}
+	bc.BestState.Beacon.AutoStaking = map[string]bool{testStakingPoolCommitteeKey: true}
+	insts = buildTestStakingPoolWithdrawal(t, bc, state, "delegator2", 50)
+	if len(insts) != 1 || insts[0][2] != common.StakingPoolRejectedChainStatus {
+		t.Errorf("auto staking pool should keep the staking amount: %+v", insts)
+	}
+
+	// the partial unstake is paid once stake of the pool is released
+	bc.BestState.Beacon.UnbondingStakes = map[string]uint64{testStakingPoolCommitteeKey: 20}
+	releasedPublicKeys, err := bc.getReleasedUnbondingStakes(bc.BestState.Beacon, 19)
+	if err != nil || len(releasedPublicKeys) != 0 {
+		t.Errorf("stake should not be released before its release height: %+v %+v", releasedPublicKeys, err)
+	}
+	releasedPublicKeys, _ = bc.getReleasedUnbondingStakes(bc.BestState.Beacon, 20)
+	state, _ = InitCurrentStakingPoolStateFromDB(db)
+	insts = buildInstructionsForStakingPoolRelease(state, releasedPublicKeys)
+	if len(insts) != 1 || insts[0][2] != common.StakingPoolReleasedChainStatus {
+		t.Fatalf("unexpected release instructions: %+v", insts)
+	}
+	var releasedContent metadata.StakingPoolWithdrawalAcceptedContent
+	if err := json.Unmarshal([]byte(insts[0][3]), &releasedContent); err != nil || releasedContent.WithdrawalAmount != 50 || releasedContent.DelegatorAddressStr != "delegator2" {
+		t.Errorf("unexpected released content %+v: %+v", releasedContent, err)
+	}
+	state = processTestStakingPoolBlock(t, bc, insts)
+	delegations, _ := db.GetStakingPoolDelegations(testStakingPoolCommitteeKey)
+	if len(state.StakingPools[testStakingPoolCommitteeKey].UnbondingWithdrawals) != 0 || state.StakingPools[testStakingPoolCommitteeKey].TotalDelegation != 950 || delegations["delegator2"] != 350 {
+		t.Errorf("unexpected state after release: %+v %+v", state.StakingPools[testStakingPoolCommitteeKey], delegations)
+	}
+	status, _ = db.GetStakingPoolStatus(withdrawalTxReqID[:])
+	if status != common.StakingPoolAcceptedStatus {
+		t.Errorf("unexpected status of released partial unstake: %d", status)
+	}
+}
+
+func TestSplitStakingPoolReward(t *testing.T) {
+	stakingPool := &lvdb.StakingPool{
+		OperatorAddressStr: "operator",
+		CommissionRate:     1000, // 10%
+	}
+	payouts := splitStakingPoolReward(stakingPool, map[string]uint64{
+		"delegator1": 300,
+		"delegator2": 700,
+	}, 1001)
+	// commission is 100, 901 is split pro rata and the dust goes to the operator
+	if payouts["delegator1"] != 270 || payouts["delegator2"] != 630 || payouts["operator"] != 101 {
+		t.Errorf("unexpected payouts: %+v", payouts)
+	}
+
+	payouts = splitStakingPoolReward(stakingPool, map[string]uint64{}, 1000)
+	if len(payouts) != 1 || payouts["operator"] != 1000 {
+		t.Errorf("operator should receive the whole reward without delegations: %+v", payouts)
+	}
+}
+
+func TestBuildInstRewardForStakingPools(t *testing.T) {
+	bc, _, cleanup := newTestStakingPoolChain(t)
+	defer cleanup()
+	operator := newTestPaymentAddress(t, 1)
+	delegator1 := newTestPaymentAddress(t, 2)
+	delegator2 := newTestPaymentAddress(t, 3)
+	tokenID := common.Hash{1}
+	state := &CurrentStakingPoolState{
+		StakingPools: map[string]*lvdb.StakingPool{
+			testStakingPoolCommitteeKey: {
+				CommitteePublicKey: testStakingPoolCommitteeKey,
+				OperatorAddressStr: operator,
+				CommissionRate:     1000,
+				TotalDelegation:    1000,
+			},
+		},
+		Delegations: map[string]map[string]uint64{
+			testStakingPoolCommitteeKey: {delegator1: 300, delegator2: 700},
+		},
+		touchedPools: map[string]bool{},
+	}
+	insts, err := bc.BuildInstRewardForStakingPools(1, map[string]map[common.Hash]uint64{
+		testStakingPoolCommitteeKey: {common.PRVCoinID: 1001, tokenID: 10},
+	}, state)
+	if err != nil {
+		t.Fatal(err)
+	}
+	paid := map[string]map[common.Hash]uint64{}
+	for _, inst := range insts {
+		if inst[0] != strconv.Itoa(metadata.StakingPoolRewardRequestMeta) {
+			t.Fatalf("unexpected reward instruction: %+v", inst)
+		}
+		rewardInfo, err := metadata.NewStakingPoolRewardInfoFromStr(inst[3])
+		if err != nil {
+			t.Fatal(err)
+		}
+		for paymentAddressStr, rewards := range rewardInfo.Rewards {
+			keyWallet, _ := wallet.Base58CheckDeserialize(paymentAddressStr)
+			pk := keyWallet.KeySet.PaymentAddress.Pk
+			if strconv.Itoa(int(common.GetShardIDFromLastByte(pk[len(pk)-1]))) != inst[1] {
+				t.Errorf("reward of %s is paid in a wrong shard", paymentAddressStr)
+			}
+			paid[paymentAddressStr] = rewards
+		}
+	}
+	if paid[delegator1][common.PRVCoinID] != 270 || paid[delegator2][common.PRVCoinID] != 630 || paid[operator][common.PRVCoinID] != 101 {
+		t.Errorf("unexpected PRV rewards: %+v", paid)
+	}
+	if paid[delegator1][tokenID]+paid[delegator2][tokenID]+paid[operator][tokenID] != 10 {
+		t.Errorf("token rewards should be paid in full: %+v", paid)
+	}
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	rCommon "github.com/ethereum/go-ethereum/common"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/jessevdk/go-flags"
+	"github.com/pkg/errors"
+)
+
+var (
+	defaultDataDir = filepath.Join(common.AppDataDir("incognito", false), defaultDataDirname)
+)
+
+// See loadConfig for details on the configuration load process.
+type config struct {
+	DataDir      string        `short:"b" long:"datadir" description:"Directory to store the state of the relayer"`
+	PollInterval time.Duration `long:"pollinterval" description:"Time between two scans of both chains"`
+
+	// Incognito
+	IncognitoRPC        string   `long:"incognitorpc" description:"Url of the json rpc endpoint of an incognito fullnode, e.g. http://127.0.0.1:9334"`
+	IncognitoPrivateKey string   `long:"incognitoprivatekey" description:"Private key paying fees of issuing requests"`
+	BeaconStartHeight   uint64   `long:"beaconstartheight" description:"Beacon height to scan burns from on the first run"`
+	Tokens              []string `long:"token" description:"Token id on incognito of an external token not yet bridged, as <external address>:<incognito token id>, can be repeated"`
+
+	// EVM chain
+	ChainID          uint64 `long:"chainid" description:"Id of the evm chain in the bridge registry, 0 is the ethereum deployment"`
+	EthRPC           string `long:"ethrpc" description:"Url of the json rpc endpoint of a node of the evm chain"`
+	EthPrivateKey    string `long:"ethprivatekey" description:"Hex encoded private key paying gas of withdrawals"`
+	VaultAddress     string `long:"vault" description:"Address of the vault contract"`
+	Confirmations    uint64 `long:"confirmations" description:"Number of blocks from a deposit to the latest block, both included, before relaying it"`
+	EthStartBlock    uint64 `long:"ethstartblock" description:"Block number to scan deposits from on the first run"`
+	MaxBlockRange    uint64 `long:"maxblockrange" description:"Max number of blocks of the evm chain scanned in one round"`
+	WithdrawGasLimit uint64 `long:"withdrawgaslimit" description:"Gas limit of withdraw transactions"`
+
+	tokens map[rCommon.Address]common.Hash
+}
+
+// newConfigParser returns a new command line flags parser.
+func newConfigParser(cfg *config, options flags.Options) *flags.Parser {
+	parser := flags.NewParser(cfg, options)
+	return parser
+}
+
+// loadConfig
+// - set default config
+// - read config from cmd line params
+// - validate endpoints, keys and token mapping
+// - return config object
+func loadConfig() (*config, error) {
+	cfg := config{
+		DataDir:          defaultDataDir,
+		PollInterval:     defaultPollInterval,
+		ChainID:          metadata.DefaultEVMChainID,
+		Confirmations:    defaultConfirmations,
+		MaxBlockRange:    defaultMaxBlockRange,
+		WithdrawGasLimit: defaultWithdrawGasLimit,
+	}
+
+	parser := newConfigParser(&cfg, flags.Default)
+	_, err := parser.Parse()
+	if err != nil {
+		if e, ok := err.(*flags.Error); ok && e.Type == flags.ErrHelp {
+			fmt.Fprintln(os.Stderr, err)
+		}
+		return nil, err
+	}
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
+
+func (cfg *config) validate() error {
+	if cfg.IncognitoRPC == "" || cfg.EthRPC == "" {
+		return errors.New("both --incognitorpc and --ethrpc are required")
+	}
+	if cfg.IncognitoPrivateKey == "" || cfg.EthPrivateKey == "" {
+		return errors.New("both --incognitoprivatekey and --ethprivatekey are required")
+	}
+	if !rCommon.IsHexAddress(cfg.VaultAddress) {
+		return errors.Errorf("vault address %s is invalid", cfg.VaultAddress)
+	}
+	if cfg.MaxBlockRange == 0 {
+		return errors.New("max block range must be positive")
+	}
+	tokens, err := parseTokens(cfg.Tokens)
+	if err != nil {
+		return err
+	}
+	cfg.tokens = tokens
+	return nil
+}
+
+// parseTokens parses <external address>:<incognito token id> pairs
+func parseTokens(pairs []string) (map[rCommon.Address]common.Hash, error) {
+	tokens := map[rCommon.Address]common.Hash{}
+	for _, pair := range pairs {
+		parts := strings.Split(pair, ":")
+		if len(parts) != 2 || !rCommon.IsHexAddress(parts[0]) {
+			return nil, errors.Errorf("token %s is not <external address>:<incognito token id>", pair)
+		}
+		incTokenID, err := common.Hash{}.NewHashFromStr(parts[1])
+		if err != nil {
+			return nil, errors.Wrapf(err, "token %s", pair)
+		}
+		tokens[rCommon.HexToAddress(parts[0])] = *incTokenID
+	}
+	return tokens, nil
+}
@@ -0,0 +1,15 @@
+package main
+
+import "time"
+
+const (
+	version = "0.1.0"
+
+	defaultDataDirname      = "bridgerelayer"
+	defaultPollInterval     = 15 * time.Second
+	defaultConfirmations    = 15
+	defaultMaxBlockRange    = 5000
+	defaultWithdrawGasLimit = 1000000
+	defaultIncognitoTxFee   = -1 // let the node estimate fee of issuing requests
+	defaultIncognitoPrivacy = -1 // issuing requests have no privacy coins
+)
@@ -0,0 +1,180 @@
+package main
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	rCommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/light"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/incognitochain/incognito-chain/rpcserver/jsonresult"
+	"github.com/pkg/errors"
+)
+
+// ethBackend is the part of the json rpc api of an evm node used by the relayer, ethclient.Client implements it
+type ethBackend interface {
+	ChainID(ctx context.Context) (*big.Int, error)
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+	BlockByHash(ctx context.Context, hash rCommon.Hash) (*types.Block, error)
+	TransactionReceipt(ctx context.Context, txHash rCommon.Hash) (*types.Receipt, error)
+	FilterLogs(ctx context.Context, query ethereum.FilterQuery) ([]types.Log, error)
+	PendingNonceAt(ctx context.Context, account rCommon.Address) (uint64, error)
+	SuggestGasPrice(ctx context.Context) (*big.Int, error)
+	SendTransaction(ctx context.Context, tx *types.Transaction) error
+}
+
+// buildReceiptProof returns the merkle proof of the receipt of a tx in the receipt trie of its block,
+// in the form verified by IssuingETHRequest: base64 encoded nodes of the path to rlp(txIndex)
+func buildReceiptProof(ctx context.Context, backend ethBackend, blockHash rCommon.Hash, txIndex uint) ([]string, error) {
+	block, err := backend.BlockByHash(ctx, blockHash)
+	if err != nil {
+		return nil, errors.Wrapf(err, "get block %s", blockHash.String())
+	}
+	if int(txIndex) >= len(block.Transactions()) {
+		return nil, errors.Errorf("block %s has no tx %d", blockHash.String(), txIndex)
+	}
+
+	receipts := types.Receipts{}
+	for _, tx := range block.Transactions() {
+		receipt, err := backend.TransactionReceipt(ctx, tx.Hash())
+		if err != nil {
+			return nil, errors.Wrapf(err, "get receipt of tx %s", tx.Hash().String())
+		}
+		receipts = append(receipts, receipt)
+	}
+
+	receiptTrie := new(trie.Trie)
+	for i := range receipts {
+		key, err := rlp.EncodeToBytes(uint(i))
+		if err != nil {
+			return nil, err
+		}
+		receiptTrie.Update(key, receipts.GetRlp(i))
+	}
+	if receiptTrie.Hash() != block.ReceiptHash() {
+		return nil, errors.Errorf("receipts of block %s do not match its receipt root", blockHash.String())
+	}
+
+	key, err := rlp.EncodeToBytes(txIndex)
+	if err != nil {
+		return nil, err
+	}
+	nodeList := new(light.NodeList)
+	if err := receiptTrie.Prove(key, 0, nodeList); err != nil {
+		return nil, err
+	}
+	proofStrs := []string{}
+	for _, node := range *nodeList {
+		proofStrs = append(proofStrs, base64.StdEncoding.EncodeToString(node))
+	}
+	return proofStrs, nil
+}
+
+// withdrawArgs are the arguments of withdraw of the vault contract, index 0 is the proof on beacon, 1 on the bridge shard
+type withdrawArgs struct {
+	inst            []byte
+	heights         [2]*big.Int
+	instPaths       [2][][32]byte
+	instPathIsLefts [2][]bool
+	instRoots       [2][32]byte
+	blkData         [2][32]byte
+	sigIdxs         [2][]*big.Int
+	sigVs           [2][]uint8
+	sigRs           [2][][32]byte
+	sigSs           [2][][32]byte
+}
+
+func decodeHex32(s string) ([32]byte, error) {
+	var h [32]byte
+	// the sibling of the last node of a level with an odd number of nodes is empty, the vault takes it as zero
+	if s == "" {
+		return h, nil
+	}
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return h, err
+	}
+	if len(b) != 32 {
+		return h, errors.Errorf("%s is not 32 bytes", s)
+	}
+	copy(h[:], b)
+	return h, nil
+}
+
+func (args *withdrawArgs) setBlockProof(i int, height string, path []string, isLeft []bool, root string, blkData string, sigs []string, sigIdxs []int) error {
+	heightBytes, err := hex.DecodeString(height)
+	if err != nil {
+		return errors.Wrap(err, "height")
+	}
+	args.heights[i] = big.NewInt(0).SetBytes(heightBytes)
+	for _, node := range path {
+		h, err := decodeHex32(node)
+		if err != nil {
+			return errors.Wrap(err, "inst path")
+		}
+		args.instPaths[i] = append(args.instPaths[i], h)
+	}
+	args.instPathIsLefts[i] = isLeft
+	if args.instRoots[i], err = decodeHex32(root); err != nil {
+		return errors.Wrap(err, "inst root")
+	}
+	if args.blkData[i], err = decodeHex32(blkData); err != nil {
+		return errors.Wrap(err, "block data")
+	}
+	for _, sigStr := range sigs {
+		sig, err := hex.DecodeString(sigStr)
+		if err != nil {
+			return errors.Wrap(err, "sig")
+		}
+		if len(sig) != 65 {
+			return errors.Errorf("sig %s is not 65 bytes", sigStr)
+		}
+		var r, s [32]byte
+		copy(r[:], sig[:32])
+		copy(s[:], sig[32:64])
+		args.sigRs[i] = append(args.sigRs[i], r)
+		args.sigSs[i] = append(args.sigSs[i], s)
+		args.sigVs[i] = append(args.sigVs[i], sig[64]+27)
+	}
+	for _, idx := range sigIdxs {
+		args.sigIdxs[i] = append(args.sigIdxs[i], big.NewInt(int64(idx)))
+	}
+	return nil
+}
+
+// packWithdraw packs a call of withdraw of the vault contract from a proof returned by getburnproof
+func packWithdraw(vaultABI abi.ABI, proof *jsonresult.GetInstructionProof) ([]byte, error) {
+	args := &withdrawArgs{}
+	inst, err := hex.DecodeString(proof.Instruction)
+	if err != nil {
+		return nil, errors.Wrap(err, "instruction")
+	}
+	args.inst = inst
+	err = args.setBlockProof(0, proof.BeaconHeight, proof.BeaconInstPath, proof.BeaconInstPathIsLeft, proof.BeaconInstRoot, proof.BeaconBlkData, proof.BeaconSigs, proof.BeaconSigIdxs)
+	if err != nil {
+		return nil, errors.Wrap(err, "beacon proof")
+	}
+	err = args.setBlockProof(1, proof.BridgeHeight, proof.BridgeInstPath, proof.BridgeInstPathIsLeft, proof.BridgeInstRoot, proof.BridgeBlkData, proof.BridgeSigs, proof.BridgeSigIdxs)
+	if err != nil {
+		return nil, errors.Wrap(err, "bridge proof")
+	}
+	return vaultABI.Pack(
+		"withdraw",
+		args.inst,
+		args.heights,
+		args.instPaths,
+		args.instPathIsLefts,
+		args.instRoots,
+		args.blkData,
+		args.sigIdxs,
+		args.sigVs,
+		args.sigRs,
+		args.sigSs,
+	)
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"encoding/json"
+
+	"github.com/incognitochain/incognito-chain/database/lvdb"
+	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/incognitochain/incognito-chain/metadata/rpccaller"
+	"github.com/incognitochain/incognito-chain/rpcserver/jsonresult"
+	"github.com/pkg/errors"
+)
+
+// incognitoClient is the part of the json rpc api of a fullnode used by the relayer
+type incognitoClient interface {
+	GetAllBridgeTokens() ([]*lvdb.BridgeTokenInfo, error)
+	CheckETHHashIssued(req *metadata.IssuingETHRequest) (bool, error)
+	SendIssuingETHRequest(req *metadata.IssuingETHRequest) (string, error)
+	GetBeaconHeight() (uint64, error)
+	GetBeaconInstructions(height uint64) ([][]string, error)
+	GetBurnProof(burnTxID string, chainID uint64) (*jsonresult.GetInstructionProof, error)
+}
+
+type incognitoRPCError struct {
+	Code    int    `json:"Code"`
+	Message string `json:"Message"`
+}
+
+type incognitoRPCResponse struct {
+	Result json.RawMessage    `json:"Result"`
+	Error  *incognitoRPCError `json:"Error"`
+}
+
+type rpcIncognitoClient struct {
+	url        string
+	privateKey string
+}
+
+func newRPCIncognitoClient(url string, privateKey string) *rpcIncognitoClient {
+	return &rpcIncognitoClient{
+		url:        url,
+		privateKey: privateKey,
+	}
+}
+
+func (client *rpcIncognitoClient) call(method string, params []interface{}, result interface{}) error {
+	var res incognitoRPCResponse
+	// the url already has protocol and port
+	err := rpccaller.NewRPCClient().RPCCall("", client.url, "", method, params, &res)
+	if err != nil {
+		return errors.Wrap(err, method)
+	}
+	if res.Error != nil {
+		return errors.Errorf("%s: %d %s", method, res.Error.Code, res.Error.Message)
+	}
+	if err := json.Unmarshal(res.Result, result); err != nil {
+		return errors.Wrap(err, method)
+	}
+	return nil
+}
+
+func (client *rpcIncognitoClient) GetAllBridgeTokens() ([]*lvdb.BridgeTokenInfo, error) {
+	var tokens []*lvdb.BridgeTokenInfo
+	err := client.call("getallbridgetokens", []interface{}{}, &tokens)
+	return tokens, err
+}
+
+func issuingETHRequestMap(req *metadata.IssuingETHRequest) map[string]interface{} {
+	return map[string]interface{}{
+		"BlockHash":  req.BlockHash.String(),
+		"TxIndex":    req.TxIndex,
+		"ProofStrs":  req.ProofStrs,
+		"IncTokenID": req.IncTokenID.String(),
+		"ChainID":    req.ChainID,
+	}
+}
+
+func (client *rpcIncognitoClient) CheckETHHashIssued(req *metadata.IssuingETHRequest) (bool, error) {
+	var issued bool
+	err := client.call("checkethhashissued", []interface{}{issuingETHRequestMap(req)}, &issued)
+	return issued, err
+}
+
+// SendIssuingETHRequest creates and broadcasts a tx of an issuing request, returns its id
+func (client *rpcIncognitoClient) SendIssuingETHRequest(req *metadata.IssuingETHRequest) (string, error) {
+	params := []interface{}{
+		client.privateKey,
+		nil,
+		defaultIncognitoTxFee,
+		defaultIncognitoPrivacy,
+		issuingETHRequestMap(req),
+	}
+	var result jsonresult.CreateTransactionResult
+	if err := client.call("createandsendtxwithissuingethreq", params, &result); err != nil {
+		return "", err
+	}
+	return result.TxID, nil
+}
+
+func (client *rpcIncognitoClient) GetBeaconHeight() (uint64, error) {
+	var bestState struct {
+		BeaconHeight uint64 `json:"BeaconHeight"`
+	}
+	err := client.call("getbeaconbeststate", []interface{}{}, &bestState)
+	return bestState.BeaconHeight, err
+}
+
+func (client *rpcIncognitoClient) GetBeaconInstructions(height uint64) ([][]string, error) {
+	var block jsonresult.GetBlocksBeaconResult
+	err := client.call("retrievebeaconblockbyheight", []interface{}{height}, &block)
+	return block.Instructions, err
+}
+
+func (client *rpcIncognitoClient) GetBurnProof(burnTxID string, chainID uint64) (*jsonresult.GetInstructionProof, error) {
+	var proof jsonresult.GetInstructionProof
+	if err := client.call("getburnproof", []interface{}{burnTxID, chainID}, &proof); err != nil {
+		return nil, err
+	}
+	return &proof, nil
+}
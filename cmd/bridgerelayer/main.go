@@ -0,0 +1,66 @@
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// Bridge relayer watches deposits to the vault contract of an evm chain and submits their issuing requests to incognito,
+// and watches burns confirmed on incognito and submits their proofs to the vault
+func main() {
+	// Show Version at startup.
+	log.Printf("Version %s\n", version)
+
+	cfg, err := loadConfig()
+	if err != nil {
+		log.Println("Parse config error", err.Error())
+		os.Exit(1)
+	}
+
+	state, err := openStateDB(cfg.DataDir)
+	if err != nil {
+		log.Println("Open state db error", err.Error())
+		os.Exit(1)
+	}
+	defer state.Close()
+
+	eth, err := ethclient.Dial(cfg.EthRPC)
+	if err != nil {
+		log.Println("Dial evm node error", err.Error())
+		os.Exit(1)
+	}
+	defer eth.Close()
+
+	r, err := newRelayer(cfg, eth, newRPCIncognitoClient(cfg.IncognitoRPC, cfg.IncognitoPrivateKey), state)
+	if err != nil {
+		log.Println("Init relayer error", err.Error())
+		os.Exit(1)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, syscall.SIGINT, syscall.SIGTERM)
+	go func() {
+		<-interrupt
+		log.Println("Stopping relayer")
+		cancel()
+	}()
+
+	log.Printf("Relay chain %d, vault %s\n", cfg.ChainID, cfg.VaultAddress)
+	ticker := time.NewTicker(cfg.PollInterval)
+	defer ticker.Stop()
+	for {
+		r.relay(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
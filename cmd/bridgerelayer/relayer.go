@@ -0,0 +1,297 @@
+package main
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"log"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	rCommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/incognitochain/incognito-chain/blockchain"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/pkg/errors"
+)
+
+// relayer moves deposits of a vault contract to incognito and proofs of burns on incognito to the vault
+type relayer struct {
+	cfg      *config
+	eth      ethBackend
+	inc      incognitoClient
+	state    *stateDB
+	chain    metadata.EVMChain
+	vaultABI abi.ABI
+	vault    rCommon.Address
+	ethKey   *ecdsa.PrivateKey
+}
+
+func newRelayer(cfg *config, eth ethBackend, inc incognitoClient, state *stateDB) (*relayer, error) {
+	vaultABI, err := abi.JSON(strings.NewReader(common.AbiJson))
+	if err != nil {
+		return nil, err
+	}
+	ethKey, err := crypto.HexToECDSA(strings.TrimPrefix(cfg.EthPrivateKey, "0x"))
+	if err != nil {
+		return nil, errors.Wrap(err, "eth private key")
+	}
+	return &relayer{
+		cfg:   cfg,
+		eth:   eth,
+		inc:   inc,
+		state: state,
+		chain: metadata.EVMChain{
+			ChainID:            cfg.ChainID,
+			ContractAddressStr: cfg.VaultAddress,
+			AbiJson:            common.AbiJson,
+			Confirmations:      cfg.Confirmations,
+		},
+		vaultABI: vaultABI,
+		vault:    rCommon.HexToAddress(cfg.VaultAddress),
+		ethKey:   ethKey,
+	}, nil
+}
+
+// relayDeposits submits an issuing request for each Deposit event of the vault with enough confirmations,
+// the scanned block number only moves forward once all deposits up to it are submitted or already issued
+func (r *relayer) relayDeposits(ctx context.Context) error {
+	latest, err := r.eth.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, "get latest header")
+	}
+	latestNumber := latest.Number.Uint64()
+	if latestNumber+1 < r.cfg.Confirmations {
+		return nil
+	}
+	toBlock := latestNumber
+	if r.cfg.Confirmations > 0 {
+		toBlock = latestNumber + 1 - r.cfg.Confirmations
+	}
+	fromBlock := r.cfg.EthStartBlock
+	lastBlock, ok, err := r.state.GetLastETHBlock()
+	if err != nil {
+		return err
+	}
+	if ok {
+		fromBlock = lastBlock + 1
+	}
+	if fromBlock > toBlock {
+		return nil
+	}
+	if toBlock-fromBlock+1 > r.cfg.MaxBlockRange {
+		toBlock = fromBlock + r.cfg.MaxBlockRange - 1
+	}
+
+	logs, err := r.eth.FilterLogs(ctx, ethereum.FilterQuery{
+		FromBlock: big.NewInt(0).SetUint64(fromBlock),
+		ToBlock:   big.NewInt(0).SetUint64(toBlock),
+		Addresses: []rCommon.Address{r.vault},
+		Topics:    [][]rCommon.Hash{{r.vaultABI.Events["Deposit"].Id()}},
+	})
+	if err != nil {
+		return errors.Wrap(err, "filter deposit logs")
+	}
+	tokens, err := r.bridgeTokens()
+	if err != nil {
+		return err
+	}
+	for _, depositLog := range logs {
+		if depositLog.Removed {
+			continue
+		}
+		if err := r.relayDeposit(ctx, depositLog, tokens); err != nil {
+			return errors.Wrapf(err, "relay deposit in tx %s", depositLog.TxHash.String())
+		}
+	}
+	return r.state.StoreLastETHBlock(toBlock)
+}
+
+// bridgeTokens maps external tokens of the chain to their incognito token ids, bridged tokens win over configured ones
+func (r *relayer) bridgeTokens() (map[rCommon.Address]common.Hash, error) {
+	tokens := map[rCommon.Address]common.Hash{}
+	for addr, incTokenID := range r.cfg.tokens {
+		tokens[addr] = incTokenID
+	}
+	bridgeTokens, err := r.inc.GetAllBridgeTokens()
+	if err != nil {
+		return nil, errors.Wrap(err, "get bridge tokens")
+	}
+	for _, token := range bridgeTokens {
+		if token.IsCentralized || token.ChainID != r.cfg.ChainID || token.TokenID == nil {
+			continue
+		}
+		tokens[rCommon.BytesToAddress(token.ExternalTokenID)] = *token.TokenID
+	}
+	return tokens, nil
+}
+
+func (r *relayer) relayDeposit(ctx context.Context, depositLog types.Log, tokens map[rCommon.Address]common.Hash) error {
+	incTxID, ok, err := r.state.GetDeposit(depositLog.BlockHash, depositLog.TxIndex)
+	if err != nil {
+		return err
+	}
+	if ok {
+		log.Printf("Deposit %s:%d was relayed in tx %s", depositLog.BlockHash.String(), depositLog.TxIndex, incTxID)
+		return nil
+	}
+
+	logMap, err := r.chain.ParseDepositLogData(depositLog.Data)
+	if err != nil {
+		return err
+	}
+	token, ok := logMap["token"].(rCommon.Address)
+	if !ok {
+		return errors.New("deposit log has no token")
+	}
+	incTokenID, ok := tokens[token]
+	if !ok {
+		// skip deposits of unknown tokens rather than block deposits behind them, they can still be issued by hand
+		log.Printf("WARNING: deposit %s:%d is of token %s which is not bridged on chain %d, skip it", depositLog.BlockHash.String(), depositLog.TxIndex, token.Hex(), r.cfg.ChainID)
+		return nil
+	}
+
+	proofStrs, err := buildReceiptProof(ctx, r.eth, depositLog.BlockHash, depositLog.TxIndex)
+	if err != nil {
+		return err
+	}
+	req, err := metadata.NewIssuingETHRequest(
+		depositLog.BlockHash,
+		depositLog.TxIndex,
+		proofStrs,
+		incTokenID,
+		r.cfg.ChainID,
+		metadata.IssuingETHRequestMeta,
+	)
+	if err != nil {
+		return err
+	}
+	issued, err := r.inc.CheckETHHashIssued(req)
+	if err != nil {
+		return err
+	}
+	if issued {
+		return r.state.StoreDeposit(depositLog.BlockHash, depositLog.TxIndex, "")
+	}
+	incTxID, err = r.inc.SendIssuingETHRequest(req)
+	if err != nil {
+		return err
+	}
+	log.Printf("Relayed deposit %s:%d in tx %s", depositLog.BlockHash.String(), depositLog.TxIndex, incTxID)
+	return r.state.StoreDeposit(depositLog.BlockHash, depositLog.TxIndex, incTxID)
+}
+
+// relayBurns submits the proof of each burn confirmed for the chain on a beacon block to the vault,
+// the scanned beacon height stops at the first burn whose proof is not ready yet
+func (r *relayer) relayBurns(ctx context.Context) error {
+	bestHeight, err := r.inc.GetBeaconHeight()
+	if err != nil {
+		return errors.Wrap(err, "get beacon height")
+	}
+	height := r.cfg.BeaconStartHeight
+	lastHeight, ok, err := r.state.GetLastBeaconHeight()
+	if err != nil {
+		return err
+	}
+	if ok {
+		height = lastHeight + 1
+	}
+	for ; height <= bestHeight; height++ {
+		insts, err := r.inc.GetBeaconInstructions(height)
+		if err != nil {
+			return errors.Wrapf(err, "get beacon block %d", height)
+		}
+		for _, inst := range insts {
+			if err := r.relayBurn(ctx, inst); err != nil {
+				return errors.Wrapf(err, "relay burn of beacon block %d", height)
+			}
+		}
+		if err := r.state.StoreLastBeaconHeight(height); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func isBurningConfirmInst(inst []string) bool {
+	if len(inst) < 8 {
+		return false
+	}
+	return inst[0] == strconv.Itoa(metadata.BurningConfirmMeta) || inst[0] == strconv.Itoa(metadata.BurningConfirmForEVMChainMeta)
+}
+
+func (r *relayer) relayBurn(ctx context.Context, inst []string) error {
+	if !isBurningConfirmInst(inst) {
+		return nil
+	}
+	chainID, err := blockchain.GetBurningConfirmChainID(inst)
+	if err != nil {
+		return err
+	}
+	if chainID != r.cfg.ChainID {
+		return nil
+	}
+	burnTxID := inst[5]
+	ethTxHash, ok, err := r.state.GetWithdrawal(burnTxID)
+	if err != nil {
+		return err
+	}
+	if ok {
+		log.Printf("Burn %s was relayed in tx %s", burnTxID, ethTxHash)
+		return nil
+	}
+
+	proof, err := r.inc.GetBurnProof(burnTxID, chainID)
+	if err != nil {
+		return err
+	}
+	data, err := packWithdraw(r.vaultABI, proof)
+	if err != nil {
+		return err
+	}
+	tx, err := r.sendVaultTx(ctx, data)
+	if err != nil {
+		return err
+	}
+	log.Printf("Relayed burn %s in tx %s", burnTxID, tx.Hash().String())
+	return r.state.StoreWithdrawal(burnTxID, tx.Hash().String())
+}
+
+func (r *relayer) sendVaultTx(ctx context.Context, data []byte) (*types.Transaction, error) {
+	from := crypto.PubkeyToAddress(r.ethKey.PublicKey)
+	nonce, err := r.eth.PendingNonceAt(ctx, from)
+	if err != nil {
+		return nil, errors.Wrap(err, "get nonce")
+	}
+	gasPrice, err := r.eth.SuggestGasPrice(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "get gas price")
+	}
+	signingChainID, err := r.eth.ChainID(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "get chain id")
+	}
+	tx := types.NewTransaction(nonce, r.vault, big.NewInt(0), r.cfg.WithdrawGasLimit, gasPrice, data)
+	signedTx, err := types.SignTx(tx, types.NewEIP155Signer(signingChainID), r.ethKey)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.eth.SendTransaction(ctx, signedTx); err != nil {
+		return nil, errors.Wrap(err, "send withdraw tx")
+	}
+	return signedTx, nil
+}
+
+// relay runs one round of both directions, a failure of one direction does not hold the other
+func (r *relayer) relay(ctx context.Context) {
+	if err := r.relayDeposits(ctx); err != nil {
+		log.Println("Relay deposits error", err.Error())
+	}
+	if err := r.relayBurns(ctx); err != nil {
+		log.Println("Relay burns error", err.Error())
+	}
+}
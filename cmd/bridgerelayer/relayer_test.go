@@ -0,0 +1,444 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"encoding/base64"
+	"encoding/hex"
+	"math/big"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind/backends"
+	rCommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/light"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/common/base58"
+	"github.com/incognitochain/incognito-chain/database/lvdb"
+	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/incognitochain/incognito-chain/rpcserver/jsonresult"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+// simulatedBackend adds the calls of ethclient.Client which the simulated backend of go-ethereum lacks
+type simulatedBackend struct {
+	*backends.SimulatedBackend
+}
+
+func (sim *simulatedBackend) ChainID(ctx context.Context) (*big.Int, error) {
+	return sim.Blockchain().Config().ChainID, nil
+}
+
+func (sim *simulatedBackend) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	if number == nil {
+		return sim.Blockchain().CurrentHeader(), nil
+	}
+	header := sim.Blockchain().GetHeaderByNumber(number.Uint64())
+	if header == nil {
+		return nil, ethereum.NotFound
+	}
+	return header, nil
+}
+
+func (sim *simulatedBackend) BlockByHash(ctx context.Context, hash rCommon.Hash) (*types.Block, error) {
+	block := sim.Blockchain().GetBlockByHash(hash)
+	if block == nil {
+		return nil, ethereum.NotFound
+	}
+	return block, nil
+}
+
+// fakeIncognito verifies receipt proofs of issuing requests the way shard nodes do
+type fakeIncognito struct {
+	sim          *simulatedBackend
+	bridgeTokens []*lvdb.BridgeTokenInfo
+	issued       map[string]bool
+	requests     []*metadata.IssuingETHRequest
+	beaconInsts  [][][]string
+	burnProofs   map[string]*jsonresult.GetInstructionProof
+}
+
+func (inc *fakeIncognito) GetAllBridgeTokens() ([]*lvdb.BridgeTokenInfo, error) {
+	return inc.bridgeTokens, nil
+}
+
+func (inc *fakeIncognito) CheckETHHashIssued(req *metadata.IssuingETHRequest) (bool, error) {
+	return inc.issued[string(metadata.GetUniqETHTx(req.ChainID, req.BlockHash, req.TxIndex))], nil
+}
+
+func (inc *fakeIncognito) SendIssuingETHRequest(req *metadata.IssuingETHRequest) (string, error) {
+	header := inc.sim.Blockchain().GetHeaderByHash(req.BlockHash)
+	if header == nil {
+		return "", errors.New("unknown block")
+	}
+	key, _ := rlp.EncodeToBytes(req.TxIndex)
+	nodeList := new(light.NodeList)
+	for _, proofStr := range req.ProofStrs {
+		node, err := base64.StdEncoding.DecodeString(proofStr)
+		if err != nil {
+			return "", err
+		}
+		nodeList.Put([]byte{}, node)
+	}
+	if _, _, err := trie.VerifyProof(header.ReceiptHash, key, nodeList.NodeSet()); err != nil {
+		return "", err
+	}
+	inc.requests = append(inc.requests, req)
+	inc.issued[string(metadata.GetUniqETHTx(req.ChainID, req.BlockHash, req.TxIndex))] = true
+	return strconv.Itoa(len(inc.requests)), nil
+}
+
+func (inc *fakeIncognito) GetBeaconHeight() (uint64, error) {
+	return uint64(len(inc.beaconInsts)), nil
+}
+
+func (inc *fakeIncognito) GetBeaconInstructions(height uint64) ([][]string, error) {
+	return inc.beaconInsts[height-1], nil
+}
+
+func (inc *fakeIncognito) GetBurnProof(burnTxID string, chainID uint64) (*jsonresult.GetInstructionProof, error) {
+	proof, ok := inc.burnProofs[burnTxID]
+	if !ok {
+		return nil, errors.Errorf("no proof of burn %s", burnTxID)
+	}
+	return proof, nil
+}
+
+// evmAssembler builds evm bytecode with jumps to labels
+type evmAssembler struct {
+	code   []byte
+	labels map[string]int
+	jumps  map[int]string
+}
+
+func newEVMAssembler() *evmAssembler {
+	return &evmAssembler{labels: map[string]int{}, jumps: map[int]string{}}
+}
+
+func (a *evmAssembler) op(ops ...byte) *evmAssembler {
+	a.code = append(a.code, ops...)
+	return a
+}
+
+func (a *evmAssembler) push(data []byte) *evmAssembler {
+	a.code = append(a.code, byte(0x5f+len(data)))
+	a.code = append(a.code, data...)
+	return a
+}
+
+func (a *evmAssembler) pushLabel(label string) *evmAssembler {
+	a.jumps[len(a.code)+1] = label
+	return a.op(0x61, 0, 0)
+}
+
+func (a *evmAssembler) label(label string) *evmAssembler {
+	a.labels[label] = len(a.code)
+	return a.op(0x5b)
+}
+
+func (a *evmAssembler) bytecode() []byte {
+	for pos, label := range a.jumps {
+		dest := a.labels[label]
+		a.code[pos], a.code[pos+1] = byte(dest>>8), byte(dest)
+	}
+	return a.code
+}
+
+// vaultBytecode deploys the vault interface used by the relayer: deposit and depositERC20 emit Deposit as the vault does,
+// withdraw marks the hash of the instruction withdrawn and rejects it the second time, isWithdrawed reads the mark.
+// Proofs are not verified since that needs the incognito proxy and committee signatures.
+func vaultBytecode(vaultABI abi.ABI) []byte {
+	depositTopic := vaultABI.Events["Deposit"].Id().Bytes()
+	// the data of Deposit is (token, offset of incognito address, amount, incognito address),
+	// the incognito address is copied from the calldata from its length on
+	emitDeposit := func(a *evmAssembler, addressLengthPos byte) {
+		a.push([]byte{0x60}).push([]byte{0x20}).op(0x52) // mstore(0x20, 0x60)
+		a.push([]byte{addressLengthPos}).op(0x36, 0x03)  // calldatasize - addressLengthPos
+		a.op(0x80).push([]byte{addressLengthPos}).push([]byte{0x60}).op(0x37)
+		a.push([]byte{0x60}).op(0x01)
+		a.push(depositTopic).op(0x90).push([]byte{0}).op(0xa1, 0x00) // log1(0, 0x60 + size, topic)
+	}
+
+	a := newEVMAssembler()
+	a.push([]byte{0}).op(0x35).push([]byte{0xe0}).op(0x1c) // selector
+	for _, method := range []string{"deposit", "depositERC20", "withdraw", "isWithdrawed"} {
+		a.op(0x80).push(vaultABI.Methods[method].Id()).op(0x14).pushLabel(method).op(0x57)
+	}
+	a.push([]byte{0}).op(0x80, 0xfd)
+
+	// deposit(string incognitoAddress) payable
+	a.label("deposit")
+	a.op(0x34).push([]byte{0x40}).op(0x52) // mstore(0x40, callvalue)
+	emitDeposit(a, 0x24)
+
+	// depositERC20(address token, uint256 amount, string incognitoAddress)
+	a.label("depositERC20")
+	a.push([]byte{0x04}).op(0x35).push([]byte{0}).op(0x52)
+	a.push([]byte{0x24}).op(0x35).push([]byte{0x40}).op(0x52)
+	emitDeposit(a, 0x64)
+
+	// withdraw(bytes inst, ...)
+	a.label("withdraw")
+	a.push([]byte{0x04}).op(0x35).push([]byte{0x04}).op(0x01) // position of length of inst
+	a.op(0x80, 0x35, 0x90).push([]byte{0x20}).op(0x01, 0x81, 0x90).push([]byte{0}).op(0x37)
+	a.push([]byte{0}).op(0x20) // keccak256(inst)
+	a.op(0x80, 0x54, 0x15).pushLabel("notWithdrawn").op(0x57)
+	a.push([]byte{0}).op(0x80, 0xfd)
+	a.label("notWithdrawn")
+	a.push([]byte{1}).op(0x90, 0x55, 0x00)
+
+	// isWithdrawed(bytes32 hash) view
+	a.label("isWithdrawed")
+	a.push([]byte{0x04}).op(0x35, 0x54).push([]byte{0}).op(0x52)
+	a.push([]byte{0x20}).push([]byte{0}).op(0xf3)
+	runtime := a.bytecode()
+
+	// constructor returns the runtime code, its arguments are ignored
+	initCode := []byte{0x61, byte(len(runtime) >> 8), byte(len(runtime)), 0x80, 0x61, 0x00, 0x0d, 0x60, 0x00, 0x39, 0x60, 0x00, 0xf3}
+	return append(initCode, runtime...)
+}
+
+type relayerTestSuite struct {
+	sim        *simulatedBackend
+	inc        *fakeIncognito
+	state      *stateDB
+	r          *relayer
+	user       *ecdsa.PrivateKey
+	relayerKey *ecdsa.PrivateKey
+	vault      *bind.BoundContract
+	token      rCommon.Address
+	incToken   common.Hash
+}
+
+func setupRelayerTest(t *testing.T) *relayerTestSuite {
+	user, _ := crypto.GenerateKey()
+	relayerKey, _ := crypto.GenerateKey()
+	sim := &simulatedBackend{backends.NewSimulatedBackend(core.GenesisAlloc{
+		crypto.PubkeyToAddress(user.PublicKey):       {Balance: big.NewInt(1e18)},
+		crypto.PubkeyToAddress(relayerKey.PublicKey): {Balance: big.NewInt(1e18)},
+	}, 8000000)}
+	vaultABI, err := abi.JSON(strings.NewReader(common.AbiJson))
+	assert.Nil(t, err)
+	admin := crypto.PubkeyToAddress(user.PublicKey)
+	vaultAddress, _, vault, err := bind.DeployContract(bind.NewKeyedTransactor(user), vaultABI, vaultBytecode(vaultABI), sim, admin, rCommon.Address{}, rCommon.Address{})
+	assert.Nil(t, err)
+	sim.Commit()
+
+	cfg := &config{
+		ChainID:           metadata.DefaultEVMChainID,
+		EthPrivateKey:     hex.EncodeToString(crypto.FromECDSA(relayerKey)),
+		VaultAddress:      vaultAddress.Hex(),
+		Confirmations:     3,
+		MaxBlockRange:     defaultMaxBlockRange,
+		WithdrawGasLimit:  defaultWithdrawGasLimit,
+		BeaconStartHeight: 1,
+	}
+	// ether is not bridged yet, its token id is configured
+	tokens, err := parseTokens([]string{"0x0000000000000000000000000000000000000000:" + common.Hash{9}.String()})
+	assert.Nil(t, err)
+	cfg.tokens = tokens
+
+	state, err := openMemStateDB()
+	assert.Nil(t, err)
+	s := &relayerTestSuite{
+		sim:        sim,
+		inc:        &fakeIncognito{sim: sim, issued: map[string]bool{}, burnProofs: map[string]*jsonresult.GetInstructionProof{}},
+		state:      state,
+		user:       user,
+		relayerKey: relayerKey,
+		vault:      vault,
+		token:      rCommon.HexToAddress("0x00000000000000000000000000000000000000aa"),
+		incToken:   common.Hash{1, 2, 3},
+	}
+	s.inc.bridgeTokens = []*lvdb.BridgeTokenInfo{
+		{TokenID: &s.incToken, ExternalTokenID: s.token.Bytes()},
+		{TokenID: &common.Hash{4}, ExternalTokenID: rCommon.HexToAddress("0xbb").Bytes(), ChainID: 56},
+	}
+	s.r, err = newRelayer(cfg, sim, s.inc, state)
+	assert.Nil(t, err)
+	return s
+}
+
+func (s *relayerTestSuite) deposit(t *testing.T, token rCommon.Address, incAddress string, amount int64) {
+	auth := bind.NewKeyedTransactor(s.user)
+	var err error
+	if token == (rCommon.Address{}) {
+		auth.Value = big.NewInt(amount)
+		_, err = s.vault.Transact(auth, "deposit", incAddress)
+	} else {
+		_, err = s.vault.Transact(auth, "depositERC20", token, big.NewInt(amount), incAddress)
+	}
+	assert.Nil(t, err)
+}
+
+func (s *relayerTestSuite) commitBlocks(n int) {
+	for i := 0; i < n; i++ {
+		s.sim.Commit()
+	}
+}
+
+func TestRelayDeposits(t *testing.T) {
+	s := setupRelayerTest(t)
+	ctx := context.Background()
+
+	s.deposit(t, s.token, "incognito-address-1", 1000)
+	s.deposit(t, rCommon.Address{}, "incognito-address-2", 2000)
+	s.deposit(t, rCommon.HexToAddress("0xbb"), "incognito-address-3", 3000) // token of another chain
+	s.commitBlocks(1)
+	depositBlock := s.sim.Blockchain().CurrentBlock()
+	assert.Equal(t, 3, len(depositBlock.Transactions()))
+
+	// Not enough confirmations
+	assert.Nil(t, s.r.relayDeposits(ctx))
+	assert.Equal(t, 0, len(s.inc.requests))
+
+	s.commitBlocks(2)
+	assert.Nil(t, s.r.relayDeposits(ctx))
+	assert.Equal(t, 2, len(s.inc.requests))
+	assert.Equal(t, depositBlock.Hash(), s.inc.requests[0].BlockHash)
+	assert.Equal(t, uint(0), s.inc.requests[0].TxIndex)
+	assert.Equal(t, s.incToken, s.inc.requests[0].IncTokenID)
+	assert.Equal(t, uint(1), s.inc.requests[1].TxIndex)
+	assert.Equal(t, common.Hash{9}, s.inc.requests[1].IncTokenID)
+	lastBlock, ok, err := s.state.GetLastETHBlock()
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, depositBlock.NumberU64(), lastBlock)
+
+	// Rescanning the same blocks does not resubmit deposits
+	assert.Nil(t, s.state.StoreLastETHBlock(0))
+	assert.Nil(t, s.r.relayDeposits(ctx))
+	assert.Equal(t, 2, len(s.inc.requests))
+
+	// Neither does a fresh state db since the node knows they are issued
+	freshState, err := openMemStateDB()
+	assert.Nil(t, err)
+	s.r.state = freshState
+	assert.Nil(t, s.r.relayDeposits(ctx))
+	assert.Equal(t, 2, len(s.inc.requests))
+	incTxID, ok, err := freshState.GetDeposit(depositBlock.Hash(), 0)
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "", incTxID)
+}
+
+func fakeBurnProof(inst []byte, height int64) *jsonresult.GetInstructionProof {
+	h := rCommon.LeftPadBytes(big.NewInt(height).Bytes(), 32)
+	hash := hex.EncodeToString(bytes.Repeat([]byte{1}, 32))
+	sig := append(bytes.Repeat([]byte{2}, 64), 1)
+	return &jsonresult.GetInstructionProof{
+		Instruction:          hex.EncodeToString(inst),
+		BeaconHeight:         hex.EncodeToString(h),
+		BridgeHeight:         hex.EncodeToString(h),
+		BeaconInstPath:       []string{hash, hash},
+		BeaconInstPathIsLeft: []bool{true, false},
+		BeaconInstRoot:       hash,
+		BeaconBlkData:        hash,
+		BeaconSigs:           []string{hex.EncodeToString(sig)},
+		BeaconSigIdxs:        []int{0},
+		BridgeInstPath:       []string{hash},
+		BridgeInstPathIsLeft: []bool{true},
+		BridgeInstRoot:       hash,
+		BridgeBlkData:        hash,
+		BridgeSigs:           []string{hex.EncodeToString(sig)},
+		BridgeSigIdxs:        []int{1},
+	}
+}
+
+func burningConfirmInst(metaType int, txID string, chainID uint64) []string {
+	inst := []string{
+		strconv.Itoa(metaType),
+		"1",
+		base58.Base58Check{}.Encode(rCommon.HexToAddress("0xaa").Bytes(), 0x00),
+		"0x00000000000000000000000000000000000000cc",
+		base58.Base58Check{}.Encode(big.NewInt(100).Bytes(), 0x00),
+		txID,
+		base58.Base58Check{}.Encode([]byte{1, 2, 3}, 0x00),
+	}
+	if metaType == metadata.BurningConfirmForEVMChainMeta {
+		inst = append(inst, base58.Base58Check{}.Encode(big.NewInt(0).SetUint64(chainID).Bytes(), 0x00))
+	}
+	return append(inst, base58.Base58Check{}.Encode(big.NewInt(2).Bytes(), 0x00))
+}
+
+func TestRelayBurns(t *testing.T) {
+	s := setupRelayerTest(t)
+	ctx := context.Background()
+
+	burnTxID := common.HashH([]byte("burn")).String()
+	otherChainBurnTxID := common.HashH([]byte("burn on chain 56")).String()
+	s.inc.beaconInsts = [][][]string{
+		{},
+		{
+			{"37", "1", "not a burn"},
+			burningConfirmInst(metadata.BurningConfirmMeta, burnTxID, 0),
+			burningConfirmInst(metadata.BurningConfirmForEVMChainMeta, otherChainBurnTxID, 56),
+		},
+	}
+
+	// The proof is not ready yet, beacon height 2 must be scanned again
+	assert.NotNil(t, s.r.relayBurns(ctx))
+	lastHeight, ok, err := s.state.GetLastBeaconHeight()
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, uint64(1), lastHeight)
+
+	inst := []byte{72, 1, 3, 4}
+	s.inc.burnProofs[burnTxID] = fakeBurnProof(inst, 2)
+	assert.Nil(t, s.r.relayBurns(ctx))
+	ethTxHash, ok, err := s.state.GetWithdrawal(burnTxID)
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	_, ok, _ = s.state.GetWithdrawal(otherChainBurnTxID)
+	assert.False(t, ok)
+
+	s.commitBlocks(1)
+	receipt, err := s.sim.TransactionReceipt(ctx, rCommon.HexToHash(ethTxHash))
+	assert.Nil(t, err)
+	assert.Equal(t, types.ReceiptStatusSuccessful, receipt.Status)
+	var withdrawn bool
+	assert.Nil(t, s.vault.Call(nil, &withdrawn, "isWithdrawed", crypto.Keccak256Hash(inst)))
+	assert.True(t, withdrawn)
+	tx, _, err := s.sim.TransactionByHash(ctx, rCommon.HexToHash(ethTxHash))
+	assert.Nil(t, err)
+	assert.Equal(t, s.r.vault, *tx.To())
+	withdraw := s.r.vaultABI.Methods["withdraw"]
+	assert.Equal(t, withdraw.Id(), tx.Data()[:4])
+	args, err := withdraw.Inputs.UnpackValues(tx.Data()[4:])
+	assert.Nil(t, err)
+	assert.Equal(t, inst, args[0])
+	assert.Equal(t, [2]*big.Int{big.NewInt(2), big.NewInt(2)}, args[1])
+	assert.Equal(t, [2][]uint8{{28}, {28}}, args[7])
+
+	// Burns already relayed are not resubmitted
+	relayerAddress := crypto.PubkeyToAddress(s.relayerKey.PublicKey)
+	nonce, err := s.sim.PendingNonceAt(ctx, relayerAddress)
+	assert.Nil(t, err)
+	assert.Nil(t, s.state.StoreLastBeaconHeight(1))
+	assert.Nil(t, s.r.relayBurns(ctx))
+	pendingNonce, err := s.sim.PendingNonceAt(ctx, relayerAddress)
+	assert.Nil(t, err)
+	assert.Equal(t, nonce, pendingNonce)
+}
+
+func TestParseTokens(t *testing.T) {
+	tokens, err := parseTokens([]string{"0x00000000000000000000000000000000000000aa:" + common.PRVCoinID.String()})
+	assert.Nil(t, err)
+	assert.Equal(t, common.PRVCoinID, tokens[rCommon.HexToAddress("0xaa")])
+
+	_, err = parseTokens([]string{"0xaa"})
+	assert.NotNil(t, err)
+	_, err = parseTokens([]string{"notanaddress:" + common.PRVCoinID.String()})
+	assert.NotNil(t, err)
+}
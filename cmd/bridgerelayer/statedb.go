@@ -0,0 +1,115 @@
+package main
+
+import (
+	"strconv"
+
+	rCommon "github.com/ethereum/go-ethereum/common"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/pkg/errors"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/storage"
+)
+
+var (
+	lastETHBlockKey     = []byte("lastethblock")
+	lastBeaconHeightKey = []byte("lastbeaconheight")
+	depositPrefix       = []byte("deposit-")
+	withdrawalPrefix    = []byte("withdrawal-")
+)
+
+// stateDB keeps what the relayer has already scanned and submitted so a restart neither skips nor resubmits anything
+type stateDB struct {
+	lvdb *leveldb.DB
+}
+
+func openStateDB(dir string) (*stateDB, error) {
+	lvdb, err := leveldb.OpenFile(dir, nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "open state db at %s", dir)
+	}
+	return &stateDB{lvdb: lvdb}, nil
+}
+
+func openMemStateDB() (*stateDB, error) {
+	lvdb, err := leveldb.Open(storage.NewMemStorage(), nil)
+	if err != nil {
+		return nil, err
+	}
+	return &stateDB{lvdb: lvdb}, nil
+}
+
+func (db *stateDB) Close() error {
+	return db.lvdb.Close()
+}
+
+func (db *stateDB) getUint64(key []byte) (uint64, bool, error) {
+	value, err := db.lvdb.Get(key, nil)
+	if err == leveldb.ErrNotFound {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+	number, err := common.BytesToUint64(value)
+	if err != nil {
+		return 0, false, err
+	}
+	return number, true, nil
+}
+
+func (db *stateDB) getString(key []byte) (string, bool, error) {
+	value, err := db.lvdb.Get(key, nil)
+	if err == leveldb.ErrNotFound {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, err
+	}
+	return string(value), true, nil
+}
+
+// GetLastETHBlock returns the last block of the evm chain whose deposits are all relayed
+func (db *stateDB) GetLastETHBlock() (uint64, bool, error) {
+	return db.getUint64(lastETHBlockKey)
+}
+
+func (db *stateDB) StoreLastETHBlock(blockNumber uint64) error {
+	return db.lvdb.Put(lastETHBlockKey, common.Uint64ToBytes(blockNumber), nil)
+}
+
+// GetLastBeaconHeight returns the last beacon block whose burns are all relayed
+func (db *stateDB) GetLastBeaconHeight() (uint64, bool, error) {
+	return db.getUint64(lastBeaconHeightKey)
+}
+
+func (db *stateDB) StoreLastBeaconHeight(height uint64) error {
+	return db.lvdb.Put(lastBeaconHeightKey, common.Uint64ToBytes(height), nil)
+}
+
+func depositKey(blockHash rCommon.Hash, txIndex uint) []byte {
+	key := append([]byte{}, depositPrefix...)
+	key = append(key, blockHash.Bytes()...)
+	return append(key, []byte(strconv.Itoa(int(txIndex)))...)
+}
+
+// GetDeposit returns the incognito tx issuing a deposit
+func (db *stateDB) GetDeposit(blockHash rCommon.Hash, txIndex uint) (string, bool, error) {
+	return db.getString(depositKey(blockHash, txIndex))
+}
+
+func (db *stateDB) StoreDeposit(blockHash rCommon.Hash, txIndex uint, incTxID string) error {
+	return db.lvdb.Put(depositKey(blockHash, txIndex), []byte(incTxID), nil)
+}
+
+func withdrawalKey(burnTxID string) []byte {
+	return append(append([]byte{}, withdrawalPrefix...), []byte(burnTxID)...)
+}
+
+// GetWithdrawal returns the evm tx submitting the proof of a burn
+func (db *stateDB) GetWithdrawal(burnTxID string) (string, bool, error) {
+	return db.getString(withdrawalKey(burnTxID))
+}
+
+func (db *stateDB) StoreWithdrawal(burnTxID string, ethTxHash string) error {
+	return db.lvdb.Put(withdrawalKey(burnTxID), []byte(ethTxHash), nil)
+}
@@ -40,10 +40,13 @@ type params struct {
 	OutDataDir   string `long:"outdatadir" description:"Directory of Export Blockchain Data"`
 	FileName     string `long:"filename" description:"Filename of Backup Blockchin Data"`
 	// wallet
-	WalletName        string `long:"wallet" description:"Wallet Database Name file, default is 'wallet'"`
-	WalletPassphrase  string `long:"walletpassphrase" description:"Wallet passphrase"`
-	WalletAccountName string `long:"walletaccountname" description:"Wallet account name"`
-	ShardID           int8   `long:"shardid" description:"Process Shard Chain with ShardID"`
+	WalletName          string `long:"wallet" description:"Wallet Database Name file, default is 'wallet'"`
+	WalletPassphrase    string `long:"walletpassphrase" description:"Wallet passphrase"`
+	WalletAccountName   string `long:"walletaccountname" description:"Wallet account name"`
+	WalletNewPassphrase string `long:"walletnewpassphrase" description:"New wallet passphrase"`
+	WalletMnemonic      string `long:"walletmnemonic" description:"Mnemonic to restore wallet"`
+	WalletGapLimit      uint32 `long:"walletgaplimit" description:"Number of consecutive unused accounts of a shard to stop account discovery, default is 20"`
+	ShardID             int8   `long:"shardid" description:"Process Shard Chain with ShardID"`
 
 	// pToken
 	PNetwork string `long:"pNetwork" description:"Bridge network"`
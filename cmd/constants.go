@@ -5,9 +5,13 @@ const (
 	listWalletAccountCmd   = "listaccounts"
 	getWalletAccountCmd    = "getaccount"
 	createWalletAccountCmd = "createaccount"
+	changePassPhraseCmd    = "changepassphrase"
+	restoreWalletCmd       = "restorewallet"
 	getPrivacyTokenID      = "getprivacytokenid"
 	backupChain            = "backupchain"
 	restoreChain           = "restorechain"
+	migrateDB              = "migratedb"
+	verifyDB               = "verifydb"
 )
 
 var CmdList = []string{
@@ -15,7 +19,11 @@ var CmdList = []string{
 	listWalletAccountCmd,
 	getWalletAccountCmd,
 	createWalletAccountCmd,
+	changePassPhraseCmd,
+	restoreWalletCmd,
 	getPrivacyTokenID,
 	backupChain,
 	restoreChain,
+	migrateDB,
+	verifyDB,
 }
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"log"
+	"path/filepath"
+
+	"github.com/incognitochain/incognito-chain/blockchain"
+	"github.com/incognitochain/incognito-chain/database"
+	_ "github.com/incognitochain/incognito-chain/database/lvdb"
+	// wire registers the binary codec of stored blocks and best states
+	_ "github.com/incognitochain/incognito-chain/wire"
+)
+
+// migrateDatabase upgrade schema of stored blockchain database to current version
+func migrateDatabase(databaseDir string) error {
+	db, err := database.Open("leveldb", filepath.Join(databaseDir))
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	version, err := db.GetSchemaVersion()
+	if err != nil {
+		return err
+	}
+	log.Printf("Database schema version %+v, current version %+v", version, database.CurrentSchemaVersion)
+	migrations, err := database.PendingMigrations(db, blockchain.StorageMigrations(), database.CurrentSchemaVersion)
+	if err != nil {
+		return err
+	}
+	for _, migration := range migrations {
+		log.Printf("Migrate to schema version %+v: %+v", migration.Version, migration.Description)
+	}
+	err = database.RunMigrations(db, migrations, database.CurrentSchemaVersion, func(version int, done uint64) {
+		log.Printf("Schema version %+v: %+v records done", version, done)
+	})
+	if err != nil {
+		return err
+	}
+	log.Printf("Database is at schema version %+v", database.CurrentSchemaVersion)
+	return nil
+}
+
+// verifyDatabase decode all stored blocks and check their hash and body roots
+func verifyDatabase(databaseDir string) error {
+	db, err := database.Open("leveldb", filepath.Join(databaseDir))
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	version, err := db.GetSchemaVersion()
+	if err != nil {
+		return err
+	}
+	log.Printf("Database schema version %+v, current version %+v", version, database.CurrentSchemaVersion)
+	report, err := blockchain.VerifyStoredBlocks(db, func(checked uint64) {
+		log.Printf("%+v blocks checked", checked)
+	})
+	if err != nil {
+		return err
+	}
+	log.Printf("All blocks are valid: %+v json blocks, %+v binary blocks", report.JSONBlocks, report.BinaryBlocks)
+	return nil
+}
@@ -124,6 +124,36 @@ func processCmd() {
 			}
 			log.Println(string(result))
 		}
+	case changePassPhraseCmd:
+		{
+			if cfg.WalletPassphrase == "" || cfg.WalletName == "" || cfg.WalletNewPassphrase == "" {
+				log.Println("Wrong param")
+				return
+			}
+			err := changePassPhrase(cfg.WalletNewPassphrase)
+			if err != nil {
+				log.Println(err)
+				return
+			}
+		}
+	case restoreWalletCmd:
+		{
+			if cfg.WalletPassphrase == "" || cfg.WalletName == "" || cfg.WalletMnemonic == "" {
+				log.Println("Wrong param")
+				return
+			}
+			accounts, err := restoreWallet(cfg.WalletMnemonic, cfg.ChainDataDir, cfg.WalletGapLimit)
+			if err != nil {
+				log.Println(err)
+				return
+			}
+			result, err := parseToJsonString(accounts)
+			if err != nil {
+				log.Println(err)
+				return
+			}
+			log.Println(string(result))
+		}
 	case backupChain:
 		{
 			if cfg.Beacon == false && cfg.ShardIDs == "" {
@@ -213,5 +243,25 @@ func processCmd() {
 				}
 			}
 		}
+	case migrateDB:
+		{
+			if cfg.ChainDataDir == "" {
+				log.Println("No Expected Params")
+				return
+			}
+			if err := migrateDatabase(cfg.ChainDataDir); err != nil {
+				log.Printf("Database migration failed, err %+v", err)
+			}
+		}
+	case verifyDB:
+		{
+			if cfg.ChainDataDir == "" {
+				log.Println("No Expected Params")
+				return
+			}
+			if err := verifyDatabase(cfg.ChainDataDir); err != nil {
+				log.Printf("Database verification failed, err %+v", err)
+			}
+		}
 	}
 }
@@ -6,6 +6,8 @@ import (
 	"os"
 	"path/filepath"
 
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/database"
 	"github.com/incognitochain/incognito-chain/wallet"
 )
 
@@ -110,3 +112,65 @@ func createAccount(accountName string, shardID *byte) (interface{}, error) {
 	}
 	return nil, errors.New("Can not load wallet")
 }
+
+func changePassPhrase(newPassPhrase string) error {
+	walletObj, err := loadWallet()
+	if err != nil {
+		return err
+	}
+	err = walletObj.ChangePassPhrase(cfg.WalletPassphrase, newPassPhrase)
+	if err != nil {
+		return err
+	}
+	log.Printf("Change passphrase of wallet '%s' successfully", cfg.WalletName)
+	return nil
+}
+
+// restoreWallet creates wallet from mnemonic, if chainDataDir is set used accounts are discovered
+// by PRV output coins in stored blockchain database
+func restoreWallet(mnemonic string, chainDataDir string, gapLimit uint32) (interface{}, error) {
+	walletObj := &wallet.Wallet{}
+	walletObj.SetConfig(&wallet.WalletConfig{
+		DataDir:        cfg.DataDir,
+		DataFile:       cfg.WalletName,
+		DataPath:       filepath.Join(cfg.DataDir, cfg.WalletName),
+		IncrementalFee: 0,
+	})
+	if _, err := os.Stat(walletObj.GetConfig().DataPath); !os.IsNotExist(err) {
+		return nil, errors.New("Existed wallet")
+	}
+	err := walletObj.InitFromMnemonic(mnemonic, cfg.WalletPassphrase, cfg.WalletName)
+	if err != nil {
+		return nil, err
+	}
+	err = walletObj.Save(cfg.WalletPassphrase)
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("Restore wallet successfully with name: %s", cfg.WalletName)
+	if chainDataDir == "" {
+		return []wallet.DiscoveredAccount{}, nil
+	}
+
+	db, err := database.Open("leveldb", filepath.Join(chainDataDir))
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+	if gapLimit == 0 {
+		gapLimit = wallet.DefaultDiscoveryGapLimit
+	}
+	shardIDs := []byte{}
+	for shardID := 0; shardID < common.MaxShardNumber; shardID++ {
+		shardIDs = append(shardIDs, byte(shardID))
+	}
+	isUsed := func(pubKey []byte, shardID byte) (bool, error) {
+		outCoins, err := db.GetOutcoinsByPubkey(common.PRVCoinID, pubKey, shardID)
+		return len(outCoins) > 0, err
+	}
+	return walletObj.DiscoverAccounts(gapLimit, shardIDs, isUsed, func(scannedIndex uint32, accounts []wallet.DiscoveredAccount) {
+		if scannedIndex%100 == 0 {
+			log.Printf("Scanned %+v accounts, found %+v used accounts", scannedIndex, len(accounts))
+		}
+	})
+}
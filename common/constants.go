@@ -100,6 +100,7 @@ const (
 	BridgeRequestProcessingStatus = 1
 	BridgeRequestAcceptedStatus   = 2
 	BridgeRequestRejectedStatus   = 3
+	BridgeRequestQueuedStatus     = 4
 
 	PDENotFoundStatus = 0
 
@@ -114,6 +115,20 @@ const (
 	PDEWithdrawalAcceptedStatus = 1
 	PDEWithdrawalRejectedStatus = 2
 
+	StakingPoolNotFoundStatus  = 0
+	StakingPoolAcceptedStatus  = 1
+	StakingPoolRefundStatus    = 2
+	StakingPoolRejectedStatus  = 3
+	StakingPoolUnbondingStatus = 4
+
+	PrivacyTokenRegistryNotFoundStatus = 0
+	PrivacyTokenRegistryAcceptedStatus = 1
+	PrivacyTokenRegistryRejectedStatus = 2
+
+	MintableTokenNotFoundStatus = 0
+	MintableTokenAcceptedStatus = 1
+	MintableTokenRejectedStatus = 2
+
 	MinTxFeesOnTokenRequirement = 10000000000000 // 10000 prv
 )
 
@@ -130,3 +145,62 @@ const (
 	PDEWithdrawalAcceptedChainStatus = "accepted"
 	PDEWithdrawalRejectedChainStatus = "rejected"
 )
+
+// PDE market event types
+const (
+	PDEMarketTradeEvent        = "trade"
+	PDEMarketContributionEvent = "contribution"
+	PDEMarketWithdrawalEvent   = "withdrawal"
+)
+
+// Staking pool statuses for chain
+const (
+	StakingPoolAcceptedChainStatus = "accepted"
+	StakingPoolRefundChainStatus   = "refund"
+	StakingPoolRejectedChainStatus = "rejected"
+	// withdrawal from a stopped pool which is paid once stake of the pool is released from unbonding queue
+	StakingPoolUnbondingChainStatus = "unbonding"
+	StakingPoolReleasedChainStatus  = "released"
+)
+
+// Privacy token registry statuses for chain
+const (
+	PrivacyTokenRegistryAcceptedChainStatus = "accepted"
+	PrivacyTokenRegistryRejectedChainStatus = "rejected"
+)
+
+// Mintable token statuses for chain
+const (
+	MintableTokenAcceptedChainStatus = "accepted"
+	MintableTokenRejectedChainStatus = "rejected"
+)
+
+// BTC relaying statuses for chain
+const (
+	BTCRelayingHeadersAcceptedChainStatus = "accepted"
+	BTCRelayingHeadersRejectedChainStatus = "rejected"
+)
+
+// Bridge limiter statuses for chain
+const (
+	BridgeRequestQueuedChainStatus   = "queued"
+	BridgeControlAcceptedChainStatus = "accepted"
+	BridgeControlRejectedChainStatus = "rejected"
+)
+
+// Incognito DAO governance statuses of proposals
+const (
+	DAOProposalVotingStatus   = "voting"
+	DAOProposalPassedStatus   = "passed"
+	DAOProposalRejectedStatus = "rejected"
+	DAOProposalExecutedStatus = "executed"
+	DAOProposalFailedStatus   = "failed" // passed but the treasury could not pay it
+)
+
+// Incognito DAO governance statuses for chain
+const (
+	DAOGovernanceAcceptedChainStatus = "accepted"
+	DAOGovernanceRejectedChainStatus = "rejected"
+	DAOProposalPassedChainStatus     = "passed"
+	IncDAOTreasuryRewardChainStatus  = "treasuryRewardInst"
+)
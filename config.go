@@ -3,9 +3,11 @@ package main
 import (
 	"bufio"
 	"encoding/base64"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"math/rand"
 	"net"
 	"os"
@@ -13,9 +15,13 @@ import (
 	"runtime"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/davecgh/go-spew/spew"
+	"github.com/incognitochain/incognito-chain/blockchain"
 	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/incognitochain/incognito-chain/transaction"
 	"github.com/jessevdk/go-flags"
 )
 
@@ -46,12 +52,17 @@ const (
 	DefaultEnableMining                = true
 	DefaultTxPoolTTL                   = uint(15 * 60) // 15 minutes
 	DefaultTxPoolMaxTx                 = uint64(100000)
+	DefaultTxPoolStakingLaneMaxTx      = uint64(2000)
+	DefaultTxPoolBridgeLaneMaxTx       = uint64(5000)
+	DefaultTxPoolPDELaneMaxTx          = uint64(20000)
+	DefaultTxPoolMaxTxPerSender        = uint64(1000)
 	DefaultLimitFee                    = uint64(1) // 1 nano PRV = 10^-9 PRV
 	// For wallet
-	DefaultWalletName     = "wallet"
-	DefaultPersistMempool = false
-	DefaultBtcClient      = 0
-	DefaultBtcClientPort  = "8332"
+	DefaultWalletName                = "wallet"
+	DefaultWalletConsolidateInterval = 10 * time.Minute
+	DefaultPersistMempool            = false
+	DefaultBtcClient                 = 0
+	DefaultBtcClientPort             = "8332"
 )
 
 var (
@@ -128,12 +139,26 @@ type config struct {
 	WalletPassphrase string `long:"walletpassphrase" description:"Wallet passphrase"`
 	WalletAutoInit   bool   `long:"walletautoinit" description:"Init wallet automatically if not exist"`
 	WalletShardID    int    `long:"walletshardid" description:"ShardID which wallet use to create account"`
-
-	FastStartup bool `long:"faststartup" description:"Load existed shard/chain dependencies instead of rebuild from block data"`
-
-	TxPoolTTL   uint   `long:"txpoolttl" description:"Set Time To Live (TTL) Value for transaction that enter pool"`
-	TxPoolMaxTx uint64 `long:"txpoolmaxtx" description:"Set Maximum number of transaction in pool"`
-	LimitFee    uint64 `long:"limitfee" description:"Limited fee for tx(per Kb data), default is 0.00 PRV"`
+	WalletMnemonic   string `long:"walletmnemonic" description:"Restore wallet from mnemonic when it is auto init, use discoverwalletaccounts RPC to find its used accounts"`
+
+	CoinSelection             string        `long:"coinselection" description:"Strategy to choose input coins of wallet txs (default/mininputs/minchange/random/bnb)"`
+	WalletConsolidateMinCoins int           `long:"walletconsolidatemincoins" description:"Merge small coins of a wallet account in background when it has more than this number of small coins, 0 is disabled"`
+	WalletConsolidateMaxValue uint64        `long:"walletconsolidatemaxvalue" description:"Max value of coins which are merged by background consolidation"`
+	WalletConsolidateInterval time.Duration `long:"walletconsolidateinterval" description:"Interval of background consolidation of wallet accounts, default is 10m"`
+
+	FastStartup           bool   `long:"faststartup" description:"Load existed shard/chain dependencies instead of rebuild from block data"`
+	BackgroundDBMigration bool   `long:"backgrounddbmigration" description:"Upgrade database schema in background while node is running instead of before node starts"`
+	PDEStateRetention     uint64 `long:"pdestateretention" description:"Number of beacon heights before the best one whose PDE state is kept for getpdestate, 0 keeps all of them"`
+	PDEMarketIndexer      bool   `long:"pdemarketindexer" description:"Index trades, contributions, withdrawals and liquidity positions of PDE for market data RPCs"`
+	EVMHeaderSourcesFile  string `long:"evmheadersources" description:"Json file which maps chain ids of the registry of evm chains to nodes which headers of deposits are read from"`
+
+	TxPoolTTL              uint   `long:"txpoolttl" description:"Set Time To Live (TTL) Value for transaction that enter pool"`
+	TxPoolMaxTx            uint64 `long:"txpoolmaxtx" description:"Set Maximum number of transaction in pool, staking, bridge and pde lanes are reserved in it"`
+	TxPoolStakingLaneMaxTx uint64 `long:"txpoolstakinglanemaxtx" description:"Set Maximum number of staking transaction in pool"`
+	TxPoolBridgeLaneMaxTx  uint64 `long:"txpoolbridgelanemaxtx" description:"Set Maximum number of bridge transaction in pool"`
+	TxPoolPDELaneMaxTx     uint64 `long:"txpoolpdelanemaxtx" description:"Set Maximum number of pde transaction in pool"`
+	TxPoolMaxTxPerSender   uint64 `long:"txpoolmaxtxpersender" description:"Set Maximum number of non-privacy transaction in pool from one sender, 0 is unlimited"`
+	LimitFee               uint64 `long:"limitfee" description:"Limited fee for tx(per Kb data), default is 0.00 PRV"`
 
 	LoadMempool       bool   `long:"loadmempool" description:"Load transactions from Mempool database"`
 	PersistMempool    bool   `long:"persistmempool" description:"Persistence transaction in memepool database"`
@@ -320,6 +345,7 @@ func loadConfig() (*config, []string, error) {
 		RPCCert:                     defaultRPCCertFile,
 		WalletShardID:               -1,
 		WalletName:                  DefaultWalletName,
+		WalletConsolidateInterval:   DefaultWalletConsolidateInterval,
 		DisableTLS:                  DefaultDisableRpcTLS,
 		DisableRPC:                  false,
 		RPCDisableAuth:              false,
@@ -332,6 +358,10 @@ func loadConfig() (*config, []string, error) {
 		FastStartup:                 DefaultFastStartup,
 		TxPoolTTL:                   DefaultTxPoolTTL,
 		TxPoolMaxTx:                 DefaultTxPoolMaxTx,
+		TxPoolStakingLaneMaxTx:      DefaultTxPoolStakingLaneMaxTx,
+		TxPoolBridgeLaneMaxTx:       DefaultTxPoolBridgeLaneMaxTx,
+		TxPoolPDELaneMaxTx:          DefaultTxPoolPDELaneMaxTx,
+		TxPoolMaxTxPerSender:        DefaultTxPoolMaxTxPerSender,
 		PersistMempool:              DefaultPersistMempool,
 		LimitFee:                    DefaultLimitFee,
 		MetricUrl:                   DefaultMetricUrl,
@@ -494,6 +524,30 @@ func loadConfig() (*config, []string, error) {
 		cfg.Listener = net.JoinHostPort("", activeNetParams.DefaultPort)
 	}
 
+	if cfg.EVMHeaderSourcesFile != common.EmptyString {
+		if err := loadEVMHeaderSources(cfg.EVMHeaderSourcesFile, activeNetParams.Params); err != nil {
+			err := fmt.Errorf("%s: failed to load evm header sources: %v", funcName, err)
+			fmt.Fprintln(os.Stderr, err)
+			return nil, nil, err
+		}
+	}
+
+	if !transaction.IsValidCoinSelection(cfg.CoinSelection) {
+		str := "%s: --coinselection %s is not supported"
+		err := fmt.Errorf(str, funcName, cfg.CoinSelection)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, nil, err
+	}
+
+	if cfg.WalletConsolidateMinCoins > 0 && cfg.WalletConsolidateInterval <= 0 {
+		str := "%s: --walletconsolidateinterval must be greater than 0"
+		err := fmt.Errorf(str, funcName)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, nil, err
+	}
+
 	if !cfg.RPCDisableAuth {
 		if cfg.RPCUser == cfg.RPCLimitUser && cfg.RPCUser != "" {
 			str := "%s: --rpcuser and --rpclimituser must not specify the same username"
@@ -729,3 +783,17 @@ func parseAndSetDebugLevels(debugLevel string) error {
 	}
 	return nil
 }
+
+// loadEVMHeaderSources sets header sources of a json file, ex: {"56": {"protocol": "https", "host": "bsc-node", "port": ""}},
+// to chains of the registry of evm chains of chainParams
+func loadEVMHeaderSources(path string, chainParams *blockchain.Params) error {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	headerSources := map[uint64]metadata.EVMHeaderSource{}
+	if err := json.Unmarshal(data, &headerSources); err != nil {
+		return err
+	}
+	return chainParams.SetEVMHeaderSources(headerSources)
+}
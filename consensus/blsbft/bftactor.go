@@ -320,8 +320,11 @@ func (e *BLSBFT) enterProposePhase() {
 	e.RoundData.BlockHash = *block.Hash()
 	e.RoundData.BlockValidateData = validationData
 
-	blockData, _ := json.Marshal(e.RoundData.Block)
-	msg, _ := MakeBFTProposeMsg(blockData, e.ChainKey, e.UserKeySet)
+	msg, err := MakeBFTProposeMsg(e.RoundData.Block, e.ChainKey, e.UserKeySet, e.Chain.IsCompactBlockActive(e.RoundData.Block))
+	if err != nil {
+		e.logger.Error("can't make propose message", err)
+		return
+	}
 	// e.logger.Info("push block", time.Since(time1).Seconds())
 	go e.Node.PushMessageToChain(msg, e.Chain)
 	e.enterVotePhase()
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 
+	"github.com/incognitochain/incognito-chain/blockchain"
 	"github.com/incognitochain/incognito-chain/common"
 	"github.com/incognitochain/incognito-chain/consensus"
 	"github.com/incognitochain/incognito-chain/metadata"
@@ -16,7 +17,10 @@ const (
 )
 
 type BFTPropose struct {
-	Block json.RawMessage
+	Block json.RawMessage `json:",omitempty"`
+	// CompactBlock is set instead of Block for shard chain, txs are replaced by short ids
+	// and netsync reconstructs Block from mempool before the message reaches consensus
+	CompactBlock json.RawMessage `json:",omitempty"`
 }
 
 type BFTVote struct {
@@ -25,9 +29,19 @@ type BFTVote struct {
 	Vote      vote
 }
 
-func MakeBFTProposeMsg(block []byte, chainKey string, userKeySet *MiningKey) (wire.Message, error) {
+// MakeBFTProposeMsg send a shard block as compact block when isCompact is set,
+// other validators reconstruct it from their mempool
+func MakeBFTProposeMsg(block common.BlockInterface, chainKey string, userKeySet *MiningKey, isCompact bool) (wire.Message, error) {
 	var proposeCtn BFTPropose
-	proposeCtn.Block = block
+	var err error
+	if shardBlock, ok := block.(*blockchain.ShardBlock); ok && isCompact {
+		proposeCtn.CompactBlock, err = json.Marshal(blockchain.NewCompactShardBlock(shardBlock))
+	} else {
+		proposeCtn.Block, err = json.Marshal(block)
+	}
+	if err != nil {
+		return nil, consensus.NewConsensusError(consensus.UnExpectedError, err)
+	}
 	proposeCtnBytes, err := json.Marshal(proposeCtn)
 	if err != nil {
 		return nil, consensus.NewConsensusError(consensus.UnExpectedError, err)
@@ -65,6 +79,10 @@ func (e *BLSBFT) ProcessBFTMsg(msg *wire.MessageBFT) {
 			fmt.Println(err)
 			return
 		}
+		if len(msgPropose.Block) == 0 {
+			e.logger.Error("propose message has no block, compact block is not reconstructed")
+			return
+		}
 		e.ProposeMessageCh <- msgPropose
 	case MSG_VOTE:
 		var msgVote BFTVote
@@ -103,6 +103,78 @@ const (
 	DeduceShareError
 	TrackPDEStatusError
 	GetPDEStatusError
+
+	// staking pool
+	StoreStakingPoolError
+	GetStakingPoolError
+	StoreStakingPoolDelegationError
+	GetStakingPoolDelegationError
+	TrackStakingPoolStatusError
+	GetStakingPoolStatusError
+
+	// cross shard transaction
+	StoreCrossShardTransactionError
+	GetCrossShardTransactionError
+	DeleteCrossShardTransactionError
+
+	// schema
+	GetSchemaVersionError
+	StoreSchemaVersionError
+	IterateBlockError
+	StoreRawBlockError
+	MigrationError
+
+	// payout batch
+	StorePayoutBatchError
+	GetPayoutBatchError
+
+	// privacy token registry
+	StorePrivacyTokenRegistryError
+	GetPrivacyTokenRegistryError
+	TrackPrivacyTokenRegistryStatusError
+	GetPrivacyTokenRegistryStatusError
+
+	// mintable token
+	StoreMintableTokenError
+	GetMintableTokenError
+	TrackMintableTokenStatusError
+	GetMintableTokenStatusError
+
+	// pde market data
+	StorePDEMarketEventError
+	GetPDEMarketEventsError
+
+	// pde liquidity position
+	StorePDELiquidityPositionError
+	GetPDELiquidityPositionError
+
+	// pde state
+	StorePDEStateError
+	GetPDEStateError
+	PDEStatePrunedError
+	MigratePDEStateError
+
+	// btc relaying
+	StoreBTCHeaderError
+	GetBTCHeaderError
+	StoreBTCMainChainError
+	GetBTCMainChainError
+	InsertBTCTxIssuedError
+	IsBTCTxIssuedError
+
+	// bridge limiter
+	StoreBridgeTokenQuotaError
+	GetBridgeTokenQuotaError
+	StoreBridgeQueuedRequestError
+	GetBridgeQueuedRequestError
+
+	// incognito dao governance
+	StoreDAOProposalError
+	GetDAOProposalError
+	StoreDAOTreasuryError
+	GetDAOTreasuryError
+	StoreDAOGovernedParamsError
+	GetDAOGovernedParamsError
 )
 
 var ErrCodeMessage = map[int]struct {
@@ -206,6 +278,78 @@ var ErrCodeMessage = map[int]struct {
 	DeduceShareError:                       {-13012, "Deduce share error"},
 	TrackPDEStatusError:                    {-13013, "Track pde status error"},
 	GetPDEStatusError:                      {-13014, "Get pde status error"},
+
+	// -14xxx staking pool
+	StoreStakingPoolError:           {-14001, "Store staking pool error"},
+	GetStakingPoolError:             {-14002, "Get staking pool error"},
+	StoreStakingPoolDelegationError: {-14003, "Store staking pool delegation error"},
+	GetStakingPoolDelegationError:   {-14004, "Get staking pool delegation error"},
+	TrackStakingPoolStatusError:     {-14005, "Track staking pool status error"},
+	GetStakingPoolStatusError:       {-14006, "Get staking pool status error"},
+
+	// -15xxx cross shard transaction
+	StoreCrossShardTransactionError:  {-15001, "Store cross shard transaction error"},
+	GetCrossShardTransactionError:    {-15002, "Get cross shard transaction error"},
+	DeleteCrossShardTransactionError: {-15003, "Delete cross shard transaction error"},
+
+	// -16xxx schema
+	GetSchemaVersionError:   {-16001, "Get schema version error"},
+	StoreSchemaVersionError: {-16002, "Store schema version error"},
+	IterateBlockError:       {-16003, "Iterate block error"},
+	StoreRawBlockError:      {-16004, "Store raw block error"},
+	MigrationError:          {-16005, "Migration error"},
+
+	// -17xxx payout batch
+	StorePayoutBatchError: {-17001, "Store payout batch error"},
+	GetPayoutBatchError:   {-17002, "Get payout batch error"},
+
+	// -18xxx privacy token registry
+	StorePrivacyTokenRegistryError:       {-18001, "Store privacy token registry error"},
+	GetPrivacyTokenRegistryError:         {-18002, "Get privacy token registry error"},
+	TrackPrivacyTokenRegistryStatusError: {-18003, "Track privacy token registry status error"},
+	GetPrivacyTokenRegistryStatusError:   {-18004, "Get privacy token registry status error"},
+
+	// -19xxx mintable token
+	StoreMintableTokenError:       {-19001, "Store mintable token error"},
+	GetMintableTokenError:         {-19002, "Get mintable token error"},
+	TrackMintableTokenStatusError: {-19003, "Track mintable token status error"},
+	GetMintableTokenStatusError:   {-19004, "Get mintable token status error"},
+
+	// -20xxx pde market data
+	StorePDEMarketEventError: {-20001, "Store pde market event error"},
+	GetPDEMarketEventsError:  {-20002, "Get pde market events error"},
+
+	// -21xxx pde liquidity position
+	StorePDELiquidityPositionError: {-21001, "Store pde liquidity position error"},
+	GetPDELiquidityPositionError:   {-21002, "Get pde liquidity position error"},
+
+	// -22xxx pde state
+	StorePDEStateError:   {-22001, "Store pde state error"},
+	GetPDEStateError:     {-22002, "Get pde state error"},
+	PDEStatePrunedError:  {-22003, "Pde state of beacon height was pruned"},
+	MigratePDEStateError: {-22004, "Migrate pde state error"},
+
+	// -23xxx btc relaying
+	StoreBTCHeaderError:    {-23001, "Store btc header error"},
+	GetBTCHeaderError:      {-23002, "Get btc header error"},
+	StoreBTCMainChainError: {-23003, "Store btc main chain error"},
+	GetBTCMainChainError:   {-23004, "Get btc main chain error"},
+	InsertBTCTxIssuedError: {-23005, "Insert btc tx issued error"},
+	IsBTCTxIssuedError:     {-23006, "Check btc tx issued error"},
+
+	// -24xxx bridge limiter
+	StoreBridgeTokenQuotaError:    {-24001, "Store bridge token quota error"},
+	GetBridgeTokenQuotaError:      {-24002, "Get bridge token quota error"},
+	StoreBridgeQueuedRequestError: {-24003, "Store bridge queued request error"},
+	GetBridgeQueuedRequestError:   {-24004, "Get bridge queued request error"},
+
+	// -25xxx incognito dao governance
+	StoreDAOProposalError:       {-25001, "Store dao proposal error"},
+	GetDAOProposalError:         {-25002, "Get dao proposal error"},
+	StoreDAOTreasuryError:       {-25003, "Store dao treasury error"},
+	GetDAOTreasuryError:         {-25004, "Get dao treasury error"},
+	StoreDAOGovernedParamsError: {-25005, "Store dao governed params error"},
+	GetDAOGovernedParamsError:   {-25006, "Get dao governed params error"},
 }
 
 type DatabaseError struct {
@@ -50,6 +50,11 @@ type DatabaseInterface interface {
 	FetchCrossShardNextHeight(fromShard, toShard byte, curHeight uint64) (uint64, error)
 	RestoreCrossShardNextHeights(fromShard byte, toShard byte, curHeight uint64) error
 
+	// Cross shard transaction index
+	StoreCrossShardTransaction(txID common.Hash, crossShardTxBytes []byte, bd *[]BatchData) error
+	GetCrossShardTransaction(txID common.Hash) ([]byte, error)
+	DeleteCrossShardTransaction(txID common.Hash) error
+
 	// Block index
 	StoreShardBlockIndex(hash common.Hash, idx uint64, shardID byte, bd *[]BatchData) error
 	GetIndexOfBlock(hash common.Hash) (uint64, byte, error)
@@ -69,6 +74,7 @@ type DatabaseInterface interface {
 	StorePrevBestState(val []byte, isBeacon bool, shardID byte) error
 	FetchPrevBestState(isBeacon bool, shardID byte) ([]byte, error)
 	CleanBackup(isBeacon bool, shardID byte) error
+	RestoreBeaconStates() error
 
 	// Best state of shard chain
 	StoreShardBestState(v interface{}, shardID byte, bd *[]BatchData) error
@@ -138,6 +144,7 @@ type DatabaseInterface interface {
 	DeletePrivacyTokenTx(tokenID common.Hash, txIndex int32, shardID byte, blockHeight uint64) error
 	ListPrivacyToken() ([][]byte, error)                        // get list all privacy token which issued in network
 	PrivacyTokenIDExisted(tokenID common.Hash) bool             // check privacy tokenID existed in network
+	GetPrivacyTokenInitTx(tokenID common.Hash) ([]byte, error)  // get hash of init tx of privacy token, it is empty if token is not initialized in this shard
 	PrivacyTokenTxs(tokenID common.Hash) ([]common.Hash, error) // from token id get all privacy token txs
 
 	// Privacy token for Cross Shard
@@ -158,10 +165,11 @@ type DatabaseInterface interface {
 	IsBridgeTokenExistedByType(incTokenID common.Hash, isCentralized bool) (bool, error)
 	InsertETHTxHashIssued(uniqETHTx []byte) error
 	IsETHTxHashIssued(uniqETHTx []byte) (bool, error)
-	CanProcessTokenPair(externalTokenID []byte, incTokenID common.Hash) (bool, error)
+	CanProcessTokenPair(chainID uint64, externalTokenID []byte, incTokenID common.Hash) (bool, error)
 	CanProcessCIncToken(incTokenID common.Hash) (bool, error)
-	UpdateBridgeTokenInfo(incTokenID common.Hash, externalTokenID []byte, isCentralized bool, updatingAmt uint64, updateType string, bd *[]BatchData) error
+	UpdateBridgeTokenInfo(incTokenID common.Hash, chainID uint64, externalTokenID []byte, isCentralized bool, updatingAmt uint64, updateType string, bd *[]BatchData) error
 	GetAllBridgeTokens() ([]byte, error)
+	GetBridgeTokenChainID(incTokenID common.Hash) (uint64, bool, error)
 	TrackBridgeReqWithStatus(txReqID common.Hash, status byte, bd *[]BatchData) error
 	GetBridgeReqWithStatus(txReqID common.Hash) (byte, error)
 
@@ -195,8 +203,80 @@ type DatabaseInterface interface {
 	GetAllRecordsByPrefix(beaconHeight uint64, prefix []byte) ([][]byte, [][]byte, error)
 	DeductSharesForWithdrawal(beaconHeight uint64, token1IDStr string, token2IDStr string, targetingTokenIDStr string, withdrawerAddressStr string, amt uint64) error
 	GetLatestPDEPoolForPair(tokenIDToBuyStr string, tokenIDToSellStr string) ([]byte, error)
+	GetPDEState(beaconHeight uint64) (map[string][]byte, error)
+	StorePDEState(beaconHeight uint64, state map[string][]byte, retention uint64) error
+	MigratePDEState(progress func(done uint64)) error
 	TrackPDEStatus(prefix []byte, suffix []byte, status byte) error
 	GetPDEStatus(prefix []byte, suffix []byte) (byte, error)
 	TrackPDEContributionStatus(prefix []byte, suffix []byte, statusContent []byte) error
 	GetPDEContributionStatus(prefix []byte, suffix []byte) ([]byte, error)
+	StorePDEMarketEvent(token1IDStr string, token2IDStr string, timestamp int64, beaconHeight uint64, index int, eventBytes []byte, bd *[]BatchData) error
+	GetPDEMarketEvents(token1IDStr string, token2IDStr string, fromTime int64, toTime int64) ([][]byte, error)
+	StorePDELiquidityPosition(contributorAddressStr string, token1IDStr string, token2IDStr string, positionBytes []byte) error
+	GetPDELiquidityPosition(contributorAddressStr string, token1IDStr string, token2IDStr string) ([]byte, error)
+	GetPDELiquidityPositionsByContributor(contributorAddressStr string) ([][]byte, error)
+
+	// staking pool
+	StoreStakingPool(committeePublicKey string, stakingPoolBytes []byte) error
+	GetStakingPool(committeePublicKey string) ([]byte, error)
+	GetAllStakingPools() ([][]byte, error)
+	StoreStakingPoolDelegation(committeePublicKey string, delegatorAddressStr string, amount uint64) error
+	GetStakingPoolDelegations(committeePublicKey string) (map[string]uint64, error)
+	TrackStakingPoolStatus(txReqID []byte, status byte) error
+	GetStakingPoolStatus(txReqID []byte) (byte, error)
+
+	// privacy token registry
+	StorePrivacyTokenRegistry(tokenID common.Hash, registryBytes []byte) error
+	GetPrivacyTokenRegistry(tokenID common.Hash) ([]byte, error)
+	GetAllPrivacyTokenRegistries() ([][]byte, error)
+	TrackPrivacyTokenRegistryStatus(txReqID []byte, status byte) error
+	GetPrivacyTokenRegistryStatus(txReqID []byte) (byte, error)
+
+	// mintable token
+	StoreMintableToken(tokenID common.Hash, mintableTokenBytes []byte) error
+	GetMintableToken(tokenID common.Hash) ([]byte, error)
+	GetAllMintableTokens() ([][]byte, error)
+	IsMintableTokenExisted(tokenID common.Hash) (bool, error)
+	TrackMintableTokenStatus(txReqID []byte, status byte) error
+	GetMintableTokenStatus(txReqID []byte) (byte, error)
+
+	// btc relaying
+	StoreBTCHeader(hash []byte, headerInfoBytes []byte) error
+	GetBTCHeader(hash []byte) ([]byte, error)
+	StoreBTCMainChainHash(height uint64, hash []byte) error
+	GetBTCMainChainHash(height uint64) ([]byte, error)
+	DeleteBTCMainChainHash(height uint64) error
+	StoreBTCBestHeaderHash(hash []byte) error
+	GetBTCBestHeaderHash() ([]byte, error)
+	InsertBTCTxIssued(uniqBTCTx []byte) error
+	IsBTCTxIssued(uniqBTCTx []byte) (bool, error)
+
+	// bridge limiter
+	StoreBridgeTokenQuota(tokenID common.Hash, quotaBytes []byte) error
+	GetBridgeTokenQuota(tokenID common.Hash) ([]byte, error)
+	GetAllBridgeTokenQuotas() ([][]byte, error)
+	StoreBridgeQueuedRequest(beaconHeight uint64, index uint64, requestBytes []byte) error
+	DeleteBridgeQueuedRequest(beaconHeight uint64, index uint64) error
+	GetAllBridgeQueuedRequests() ([][]byte, error)
+
+	// incognito dao governance
+	StoreDAOProposal(proposalID common.Hash, proposalBytes []byte) error
+	GetDAOProposal(proposalID common.Hash) ([]byte, error)
+	GetAllDAOProposals() ([][]byte, error)
+	StoreDAOTreasury(treasuryBytes []byte) error
+	GetDAOTreasury() ([]byte, error)
+	StoreDAOGovernedParams(paramsBytes []byte) error
+	GetDAOGovernedParams() ([]byte, error)
+
+	// schema version and migration
+	GetSchemaVersion() (int, error)
+	StoreSchemaVersion(version int) error
+	ForEachShardBlock(shardID byte, fn func(hash common.Hash, value []byte) error) error
+	ForEachBeaconBlock(fn func(hash common.Hash, value []byte) error) error
+	StoreRawBlock(hash common.Hash, value []byte) error
+
+	// payout batch
+	StorePayoutBatch(idempotencyKey string, batchBytes []byte) error
+	GetPayoutBatch(idempotencyKey string) ([]byte, error)
+	GetAllPayoutBatches() ([][]byte, error)
 }
@@ -20,7 +20,7 @@ func (db *db) StoreBeaconBlock(v interface{}, hash common.Hash, bd *[]database.B
 	if ok, _ := db.HasValue(keyBeaconBlock); ok {
 		return database.NewDatabaseError(database.BlockExisted, errors.Errorf("block %+v already exists", hash))
 	}
-	val, err := json.Marshal(v)
+	val, err := marshalStorage(v)
 	if err != nil {
 		return database.NewDatabaseError(database.StoreBeaconBlockError, err)
 	}
@@ -137,7 +137,7 @@ func (db *db) DeleteBeaconBlock(hash common.Hash, idx uint64) error {
 }
 
 func (db *db) StoreBeaconBestState(v interface{}, bd *[]database.BatchData) error {
-	val, err := json.Marshal(v)
+	val, err := marshalStorage(v)
 	if err != nil {
 		return database.NewDatabaseError(database.StoreBeaconBestStateError, err)
 	}
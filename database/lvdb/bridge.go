@@ -19,6 +19,7 @@ type BridgeTokenInfo struct {
 	ExternalTokenID []byte       `json:"externalTokenId"`
 	Network         string       `json:"network"`
 	IsCentralized   bool         `json:"isCentralized"`
+	ChainID         uint64       `json:"chainId,omitempty"` // evm chain of decentralized bridge tokens, tokens stored before the registry of evm chains are of the default chain
 }
 
 func (db *db) InsertETHTxHashIssued(
@@ -66,10 +67,21 @@ func (db *db) CanProcessCIncToken(
 	if !cBridgeTokenExisted && (privacyCustomTokenExisted || privacyCustomTokenCrossShardExisted) {
 		return false, nil
 	}
+	// supply of a mintable token is issued by its minting authority only
+	mintableTokenExisted, err := db.IsMintableTokenExisted(incTokenID)
+	if err != nil {
+		return false, database.NewDatabaseError(database.BridgeUnexpectedError, err)
+	}
+	if mintableTokenExisted {
+		return false, nil
+	}
 	return true, nil
 }
 
+// CanProcessTokenPair checks that incTokenID is not bridged yet or is bridged from externalTokenID of chain chainID,
+// and externalTokenID of the chain is not bridged to another token
 func (db *db) CanProcessTokenPair(
+	chainID uint64,
 	externalTokenID []byte,
 	incTokenID common.Hash,
 ) (bool, error) {
@@ -97,6 +109,14 @@ func (db *db) CanProcessTokenPair(
 		fmt.Println("WARNING: failed at condition 1: ", dBridgeTokenExisted, privacyCustomTokenExisted, privacyCustomTokenCrossShardExisted)
 		return false, nil
 	}
+	mintableTokenExisted, err := db.IsMintableTokenExisted(incTokenID)
+	if err != nil {
+		return false, database.NewDatabaseError(database.BridgeUnexpectedError, err)
+	}
+	if mintableTokenExisted {
+		fmt.Println("WARNING: inc token was existed in mintable token set")
+		return false, nil
+	}
 
 	key := append(decentralizedBridgePrefix, incTokenID[:]...)
 	contentBytes, dbErr := db.lvdb.Get(key, nil)
@@ -109,10 +129,10 @@ func (db *db) CanProcessTokenPair(
 		if err != nil {
 			return false, database.NewDatabaseError(database.BridgeUnexpectedError, err)
 		}
-		if bytes.Equal(bridgeTokenInfo.ExternalTokenID[:], externalTokenID[:]) {
+		if bridgeTokenInfo.ChainID == chainID && bytes.Equal(bridgeTokenInfo.ExternalTokenID[:], externalTokenID[:]) {
 			return true, nil
 		}
-		fmt.Println("WARNING: failed at condition 2:", bridgeTokenInfo.ExternalTokenID[:], externalTokenID[:])
+		fmt.Println("WARNING: failed at condition 2:", bridgeTokenInfo.ChainID, bridgeTokenInfo.ExternalTokenID[:], chainID, externalTokenID[:])
 		return false, nil
 	}
 	// else: could not find incTokenID out
@@ -126,11 +146,12 @@ func (db *db) CanProcessTokenPair(
 		if err != nil {
 			return false, database.NewDatabaseError(database.BridgeUnexpectedError, err)
 		}
-		if !bytes.Equal(bridgeTokenInfo.ExternalTokenID, externalTokenID) {
+		if bridgeTokenInfo.ChainID != chainID || !bytes.Equal(bridgeTokenInfo.ExternalTokenID, externalTokenID) {
 			continue
 		}
 
-		fmt.Println("WARNING: failed at condition 3:", bridgeTokenInfo.ExternalTokenID[:], externalTokenID[:])
+		fmt.Println("WARNING: failed at condition 3:", bridgeTokenInfo.ChainID, bridgeTokenInfo.ExternalTokenID[:], externalTokenID[:])
+		iter.Release()
 		return false, nil
 	}
 
@@ -152,6 +173,7 @@ func getBridgePrefix(isCentralized bool) []byte {
 
 func (db *db) UpdateBridgeTokenInfo(
 	incTokenID common.Hash,
+	chainID uint64,
 	externalTokenID []byte,
 	isCentralized bool,
 	updatingAmt uint64,
@@ -171,6 +193,7 @@ func (db *db) UpdateBridgeTokenInfo(
 			TokenID:         &incTokenID,
 			ExternalTokenID: externalTokenID,
 			IsCentralized:   isCentralized,
+			ChainID:         chainID,
 		}
 		if updateType == "-" {
 			newBridgeTokenInfo.Amount = 0
@@ -187,6 +210,7 @@ func (db *db) UpdateBridgeTokenInfo(
 			TokenID:         existingBridgeTokenInfo.TokenID,
 			ExternalTokenID: existingBridgeTokenInfo.ExternalTokenID,
 			IsCentralized:   existingBridgeTokenInfo.IsCentralized,
+			ChainID:         existingBridgeTokenInfo.ChainID,
 		}
 		if updateType == "+" {
 			newBridgeTokenInfo.Amount = existingBridgeTokenInfo.Amount + updatingAmt
@@ -229,6 +253,23 @@ func (db *db) IsBridgeTokenExistedByType(
 	return true, nil
 }
 
+// GetBridgeTokenChainID returns the evm chain which a decentralized bridge token is bridged from
+func (db *db) GetBridgeTokenChainID(incTokenID common.Hash) (uint64, bool, error) {
+	key := append(decentralizedBridgePrefix, incTokenID[:]...)
+	tokenInfoBytes, dbErr := db.lvdb.Get(key, nil)
+	if dbErr != nil && dbErr != lvdberr.ErrNotFound {
+		return 0, false, database.NewDatabaseError(database.BridgeUnexpectedError, dbErr)
+	}
+	if len(tokenInfoBytes) == 0 {
+		return 0, false, nil
+	}
+	var bridgeTokenInfo BridgeTokenInfo
+	if err := json.Unmarshal(tokenInfoBytes, &bridgeTokenInfo); err != nil {
+		return 0, false, database.NewDatabaseError(database.BridgeUnexpectedError, err)
+	}
+	return bridgeTokenInfo.ChainID, true, nil
+}
+
 func (db *db) getBridgeTokensByType(isCentralized bool) ([]*BridgeTokenInfo, error) {
 	prefix := getBridgePrefix(isCentralized)
 	iter := db.lvdb.NewIterator(util.BytesPrefix(prefix), nil)
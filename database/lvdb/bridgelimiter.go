@@ -0,0 +1,129 @@
+package lvdb
+
+import (
+	"encoding/binary"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/database"
+	"github.com/pkg/errors"
+	lvdberr "github.com/syndtr/goleveldb/leveldb/errors"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+// BridgeTokenQuota is the usage of the per-epoch caps of a bridge token and whether its requests are paused.
+// The record of the zero token id stands for the whole bridge: its Paused holds requests of all tokens
+// and its ControlNonce is the nonce of the next bridge control request
+type BridgeTokenQuota struct {
+	TokenID      common.Hash
+	Epoch        uint64 // epoch of the counted amounts, they restart from zero in a new epoch
+	IssuedAmount uint64
+	BurnedAmount uint64
+	Paused       bool
+	ControlNonce uint64
+}
+
+// BridgeQueuedRequest is an issuing or burning request held by beacon because it is over the caps of its token
+// or the bridge is paused, it is released by a later beacon block once it fits in the caps
+type BridgeQueuedRequest struct {
+	TxReqID  common.Hash
+	MetaType int // IssuingETHRequestMeta, IssuingBTCRequestMeta or BurningRequestMeta
+	TokenID  common.Hash
+	Amount   uint64
+	ShardID  byte
+	// accepted issuing content of an issuing request, action content of a burning request
+	Content      string
+	BeaconHeight uint64 // height of the beacon block queueing the request
+	Index        uint64 // position of the request among the ones queued by the block
+}
+
+func BuildBridgeTokenQuotaKey(tokenID common.Hash) []byte {
+	return append(BridgeTokenQuotaPrefix, tokenID[:]...)
+}
+
+// BuildBridgeQueuedRequestKey orders queued requests by the block queueing them, then by their position in the block
+func BuildBridgeQueuedRequestKey(beaconHeight uint64, index uint64) []byte {
+	buf := make([]byte, 16)
+	binary.BigEndian.PutUint64(buf[:8], beaconHeight)
+	binary.BigEndian.PutUint64(buf[8:], index)
+	return append(BridgeQueuedRequestPrefix, buf...)
+}
+
+func (db *db) StoreBridgeTokenQuota(
+	tokenID common.Hash,
+	quotaBytes []byte,
+) error {
+	err := db.putBeaconState(BuildBridgeTokenQuotaKey(tokenID), quotaBytes)
+	if err != nil {
+		return database.NewDatabaseError(database.StoreBridgeTokenQuotaError, errors.Wrap(err, "db.lvdb.put"))
+	}
+	return nil
+}
+
+func (db *db) GetBridgeTokenQuota(
+	tokenID common.Hash,
+) ([]byte, error) {
+	quotaBytes, dbErr := db.lvdb.Get(BuildBridgeTokenQuotaKey(tokenID), nil)
+	if dbErr != nil && dbErr != lvdberr.ErrNotFound {
+		return []byte{}, database.NewDatabaseError(database.GetBridgeTokenQuotaError, dbErr)
+	}
+	return quotaBytes, nil
+}
+
+func (db *db) GetAllBridgeTokenQuotas() ([][]byte, error) {
+	values := [][]byte{}
+	iter := db.lvdb.NewIterator(util.BytesPrefix(BridgeTokenQuotaPrefix), nil)
+	for iter.Next() {
+		value := iter.Value()
+		valueBytes := make([]byte, len(value))
+		copy(valueBytes, value)
+		values = append(values, valueBytes)
+	}
+	iter.Release()
+	err := iter.Error()
+	if err != nil && err != lvdberr.ErrNotFound {
+		return values, database.NewDatabaseError(database.GetBridgeTokenQuotaError, err)
+	}
+	return values, nil
+}
+
+func (db *db) StoreBridgeQueuedRequest(
+	beaconHeight uint64,
+	index uint64,
+	requestBytes []byte,
+) error {
+	err := db.putBeaconState(BuildBridgeQueuedRequestKey(beaconHeight, index), requestBytes)
+	if err != nil {
+		return database.NewDatabaseError(database.StoreBridgeQueuedRequestError, errors.Wrap(err, "db.lvdb.put"))
+	}
+	return nil
+}
+
+// DeleteBridgeQueuedRequest is used when a queued request is released
+func (db *db) DeleteBridgeQueuedRequest(
+	beaconHeight uint64,
+	index uint64,
+) error {
+	err := db.deleteBeaconState(BuildBridgeQueuedRequestKey(beaconHeight, index))
+	if err != nil {
+		return database.NewDatabaseError(database.StoreBridgeQueuedRequestError, errors.Wrap(err, "db.lvdb.delete"))
+	}
+	return nil
+}
+
+// GetAllBridgeQueuedRequests returns queued requests in the order they were queued
+func (db *db) GetAllBridgeQueuedRequests() ([][]byte, error) {
+	values := [][]byte{}
+	iter := db.lvdb.NewIterator(util.BytesPrefix(BridgeQueuedRequestPrefix), nil)
+	for iter.Next() {
+		value := iter.Value()
+		valueBytes := make([]byte, len(value))
+		copy(valueBytes, value)
+		values = append(values, valueBytes)
+	}
+	iter.Release()
+	err := iter.Error()
+	if err != nil && err != lvdberr.ErrNotFound {
+		return values, database.NewDatabaseError(database.GetBridgeQueuedRequestError, err)
+	}
+	return values, nil
+}
@@ -0,0 +1,127 @@
+package lvdb
+
+import (
+	"encoding/binary"
+	"math/big"
+
+	"github.com/incognitochain/incognito-chain/database"
+	"github.com/pkg/errors"
+	lvdberr "github.com/syndtr/goleveldb/leveldb/errors"
+)
+
+// BTCHeaderInfo is a bitcoin header relayed to beacon with its position in the relayed header chain
+type BTCHeaderInfo struct {
+	Header []byte // 80 bytes serialization
+	Hash   string
+	Height uint64
+	// total work of headers from the checkpoint up to this one, the best header has the most
+	ChainWork *big.Int
+	// timestamp of the first header of the difficulty interval of this header
+	IntervalStartTime int64
+	// bits of the last header of the interval not using the min difficulty, only used by test networks
+	LastNonMinBits uint32
+}
+
+func BuildBTCHeaderKey(hash []byte) []byte {
+	return append(BTCHeaderPrefix, hash...)
+}
+
+func BuildBTCMainChainKey(height uint64) []byte {
+	buf := make([]byte, 8)
+	binary.BigEndian.PutUint64(buf, height)
+	return append(BTCMainChainPrefix, buf...)
+}
+
+func (db *db) StoreBTCHeader(
+	hash []byte,
+	headerInfoBytes []byte,
+) error {
+	err := db.putBeaconState(BuildBTCHeaderKey(hash), headerInfoBytes)
+	if err != nil {
+		return database.NewDatabaseError(database.StoreBTCHeaderError, errors.Wrap(err, "db.lvdb.put"))
+	}
+	return nil
+}
+
+func (db *db) GetBTCHeader(
+	hash []byte,
+) ([]byte, error) {
+	headerInfoBytes, dbErr := db.lvdb.Get(BuildBTCHeaderKey(hash), nil)
+	if dbErr != nil && dbErr != lvdberr.ErrNotFound {
+		return []byte{}, database.NewDatabaseError(database.GetBTCHeaderError, dbErr)
+	}
+	return headerInfoBytes, nil
+}
+
+// StoreBTCMainChainHash sets hash of the header at height of the best chain
+func (db *db) StoreBTCMainChainHash(
+	height uint64,
+	hash []byte,
+) error {
+	err := db.putBeaconState(BuildBTCMainChainKey(height), hash)
+	if err != nil {
+		return database.NewDatabaseError(database.StoreBTCMainChainError, errors.Wrap(err, "db.lvdb.put"))
+	}
+	return nil
+}
+
+func (db *db) GetBTCMainChainHash(
+	height uint64,
+) ([]byte, error) {
+	hash, dbErr := db.lvdb.Get(BuildBTCMainChainKey(height), nil)
+	if dbErr != nil && dbErr != lvdberr.ErrNotFound {
+		return []byte{}, database.NewDatabaseError(database.GetBTCMainChainError, dbErr)
+	}
+	return hash, nil
+}
+
+// DeleteBTCMainChainHash is used when the best chain is replaced by a shorter one with more work
+func (db *db) DeleteBTCMainChainHash(
+	height uint64,
+) error {
+	err := db.deleteBeaconState(BuildBTCMainChainKey(height))
+	if err != nil {
+		return database.NewDatabaseError(database.StoreBTCMainChainError, errors.Wrap(err, "db.lvdb.delete"))
+	}
+	return nil
+}
+
+func (db *db) StoreBTCBestHeaderHash(
+	hash []byte,
+) error {
+	err := db.putBeaconState(btcBestHeaderHashKey, hash)
+	if err != nil {
+		return database.NewDatabaseError(database.StoreBTCMainChainError, errors.Wrap(err, "db.lvdb.put"))
+	}
+	return nil
+}
+
+func (db *db) GetBTCBestHeaderHash() ([]byte, error) {
+	hash, dbErr := db.lvdb.Get(btcBestHeaderHashKey, nil)
+	if dbErr != nil && dbErr != lvdberr.ErrNotFound {
+		return []byte{}, database.NewDatabaseError(database.GetBTCMainChainError, dbErr)
+	}
+	return hash, nil
+}
+
+func (db *db) InsertBTCTxIssued(
+	uniqBTCTx []byte,
+) error {
+	key := append(BTCTxIssuedPrefix, uniqBTCTx...)
+	dbErr := db.putBeaconState(key, []byte{1})
+	if dbErr != nil {
+		return database.NewDatabaseError(database.InsertBTCTxIssuedError, errors.Wrap(dbErr, "db.lvdb.put"))
+	}
+	return nil
+}
+
+func (db *db) IsBTCTxIssued(
+	uniqBTCTx []byte,
+) (bool, error) {
+	key := append(BTCTxIssuedPrefix, uniqBTCTx...)
+	contentBytes, dbErr := db.lvdb.Get(key, nil)
+	if dbErr != nil && dbErr != lvdberr.ErrNotFound {
+		return false, database.NewDatabaseError(database.IsBTCTxIssuedError, errors.Wrap(dbErr, "db.lvdb.Get"))
+	}
+	return len(contentBytes) > 0, nil
+}
@@ -6,6 +6,7 @@ import "github.com/incognitochain/incognito-chain/common"
 var (
 	prevShardPrefix          = []byte("prevShd-")
 	prevBeaconPrefix         = []byte("prevBea-")
+	prevBeaconStatePrefix    = []byte("prevBea-state-")
 	beaconPrefix             = []byte("bea-")
 	beaconBestBlockkeyPrefix = []byte("bea-bestBlock")
 	committeePrefix          = []byte("com-")
@@ -17,6 +18,7 @@ var (
 	blockKeyIdxPrefix        = []byte("i-")
 	crossShardKeyPrefix      = []byte("csh-")
 	nextCrossShardKeyPrefix  = []byte("ncsh-")
+	crossShardTxKeyPrefix    = []byte("cstx-")
 	shardPrefix              = []byte("shd-")
 	autoStakingPrefix        = []byte("aust-")
 
@@ -58,6 +60,9 @@ var (
 	// slash
 	producersBlackListPrefix = []byte("producersblacklist-")
 
+	// schema version
+	schemaVersionKey = []byte("schema-version")
+
 	// PDE
 	WaitingPDEContributionPrefix = []byte("waitingpdecontribution-")
 	PDEPoolPrefix                = []byte("pdepool-")
@@ -66,6 +71,47 @@ var (
 	PDEContributionStatusPrefix  = []byte("pdecontributionstatus-")
 	PDETradeStatusPrefix         = []byte("pdetradestatus-")
 	PDEWithdrawalStatusPrefix    = []byte("pdewithdrawalstatus-")
+
+	// staking pool
+	StakingPoolPrefix           = []byte("stakingpool-")
+	StakingPoolDelegationPrefix = []byte("stakingpooldelegation-")
+	StakingPoolStatusPrefix     = []byte("stakingpoolstatus-")
+
+	// payout batch
+	PayoutBatchPrefix = []byte("payoutbatch-")
+
+	// privacy token registry
+	PrivacyTokenRegistryPrefix       = []byte("privacytokenregistry-")
+	PrivacyTokenRegistryStatusPrefix = []byte("privacytokenregistrystatus-")
+
+	// mintable token
+	MintableTokenPrefix       = []byte("mintabletoken-")
+	MintableTokenStatusPrefix = []byte("mintabletokenstatus-")
+
+	// pde market data
+	PDEMarketEventPrefix       = []byte("pdemarketevent-")
+	PDELiquidityPositionPrefix = []byte("pdeliquidityposition-")
+
+	// pde state: latest records and journals of changed records by beacon height
+	PDEStateLatestPrefix    = []byte("pdestatelatest-")
+	PDEStateJournalPrefix   = []byte("pdestatejournal-")
+	pdeStateLatestHeightKey = []byte("pdestatemeta-latestheight")
+	pdeStateOldestHeightKey = []byte("pdestatemeta-oldestheight")
+
+	// btc relaying: relayed headers, hashes of the best chain by height and issued deposits
+	BTCHeaderPrefix      = []byte("btcheader-")
+	BTCMainChainPrefix   = []byte("btcmainchain-")
+	BTCTxIssuedPrefix    = []byte("btctxissued-")
+	btcBestHeaderHashKey = []byte("btcrelayingmeta-besthash")
+
+	// bridge limiter: usage of per-epoch caps and pause flags of bridge tokens, requests held over the caps
+	BridgeTokenQuotaPrefix    = []byte("bridgetokenquota-")
+	BridgeQueuedRequestPrefix = []byte("bridgequeuedrequest-")
+
+	// incognito dao governance: proposals with their votes, balances of the treasury and chain params changed by proposals
+	DAOProposalPrefix    = []byte("daoproposal-")
+	daoTreasuryKey       = []byte("daogovernance-treasury")
+	daoGovernedParamsKey = []byte("daogovernance-params")
 )
 
 // value
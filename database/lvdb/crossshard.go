@@ -5,6 +5,7 @@ import (
 	"encoding/binary"
 	"github.com/incognitochain/incognito-chain/common"
 	"github.com/pkg/errors"
+	lvdberr "github.com/syndtr/goleveldb/leveldb/errors"
 
 	"github.com/incognitochain/incognito-chain/database"
 )
@@ -105,3 +106,27 @@ func (db *db) GetIncomingCrossShard(shardID byte, crossShardID byte, crossBlkHas
 	}
 	return idx, nil
 }
+
+//StoreCrossShardTransaction which store tx that send output to other shards with its source block information
+func (db *db) StoreCrossShardTransaction(txID common.Hash, crossShardTxBytes []byte, bd *[]database.BatchData) error {
+	// cstx-TxID : CrossShardTransaction
+	key := append(crossShardTxKeyPrefix, txID[:]...)
+	if bd != nil {
+		*bd = append(*bd, database.BatchData{key, crossShardTxBytes})
+		return nil
+	}
+	if err := db.Put(key, crossShardTxBytes); err != nil {
+		return database.NewDatabaseError(database.StoreCrossShardTransactionError, errors.Wrap(err, "db.lvdb.put"))
+	}
+	return nil
+}
+
+func (db *db) GetCrossShardTransaction(txID common.Hash) ([]byte, error) {
+	// cstx-TxID : CrossShardTransaction
+	key := append(crossShardTxKeyPrefix, txID[:]...)
+	crossShardTxBytes, err := db.lvdb.Get(key, nil)
+	if err != nil && err != lvdberr.ErrNotFound {
+		return []byte{}, database.NewDatabaseError(database.GetCrossShardTransactionError, errors.Wrap(err, "db.lvdb.Get"))
+	}
+	return crossShardTxBytes, nil
+}
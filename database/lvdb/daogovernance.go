@@ -0,0 +1,111 @@
+package lvdb
+
+import (
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/database"
+	"github.com/pkg/errors"
+	lvdberr "github.com/syndtr/goleveldb/leveldb/errors"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+// DAOProposal is a proposal spending the treasury of Incognito DAO or changing a governed chain param,
+// it is voted by holders of staked PRV and executed by beacon once it passes and its timelock is over
+type DAOProposal struct {
+	ProposalID      common.Hash // id of the tx submitting the proposal
+	Type            string
+	ProposerAddress string
+	Description     string
+	// treasury spend
+	ReceiverAddress string
+	Amounts         map[common.Hash]uint64
+	// chain param change, TokenID is set for params of a bridge token
+	ParamName  string
+	TokenID    common.Hash
+	ParamValue uint64
+
+	Status                string
+	CreatedBeaconHeight   uint64
+	VotingEndBeaconHeight uint64          // last beacon height votes are accepted at
+	Votes                 map[string]bool // voter payment address => approval
+	// weights of votes and staked PRV when the proposal is tallied
+	YesWeight             uint64
+	NoWeight              uint64
+	TotalWeight           uint64
+	ExecutionBeaconHeight uint64 // a passed proposal is executed from this height
+}
+
+func BuildDAOProposalKey(proposalID common.Hash) []byte {
+	return append(DAOProposalPrefix, proposalID[:]...)
+}
+
+func (db *db) StoreDAOProposal(
+	proposalID common.Hash,
+	proposalBytes []byte,
+) error {
+	err := db.putBeaconState(BuildDAOProposalKey(proposalID), proposalBytes)
+	if err != nil {
+		return database.NewDatabaseError(database.StoreDAOProposalError, errors.Wrap(err, "db.lvdb.put"))
+	}
+	return nil
+}
+
+func (db *db) GetDAOProposal(
+	proposalID common.Hash,
+) ([]byte, error) {
+	proposalBytes, dbErr := db.lvdb.Get(BuildDAOProposalKey(proposalID), nil)
+	if dbErr != nil && dbErr != lvdberr.ErrNotFound {
+		return []byte{}, database.NewDatabaseError(database.GetDAOProposalError, dbErr)
+	}
+	return proposalBytes, nil
+}
+
+func (db *db) GetAllDAOProposals() ([][]byte, error) {
+	values := [][]byte{}
+	iter := db.lvdb.NewIterator(util.BytesPrefix(DAOProposalPrefix), nil)
+	for iter.Next() {
+		value := iter.Value()
+		valueBytes := make([]byte, len(value))
+		copy(valueBytes, value)
+		values = append(values, valueBytes)
+	}
+	iter.Release()
+	err := iter.Error()
+	if err != nil && err != lvdberr.ErrNotFound {
+		return values, database.NewDatabaseError(database.GetDAOProposalError, err)
+	}
+	return values, nil
+}
+
+// StoreDAOTreasury stores balances of the treasury by token id
+func (db *db) StoreDAOTreasury(treasuryBytes []byte) error {
+	err := db.putBeaconState(daoTreasuryKey, treasuryBytes)
+	if err != nil {
+		return database.NewDatabaseError(database.StoreDAOTreasuryError, errors.Wrap(err, "db.lvdb.put"))
+	}
+	return nil
+}
+
+func (db *db) GetDAOTreasury() ([]byte, error) {
+	treasuryBytes, dbErr := db.lvdb.Get(daoTreasuryKey, nil)
+	if dbErr != nil && dbErr != lvdberr.ErrNotFound {
+		return []byte{}, database.NewDatabaseError(database.GetDAOTreasuryError, dbErr)
+	}
+	return treasuryBytes, nil
+}
+
+// StoreDAOGovernedParams stores values of chain params changed by proposals, they override the ones of chain params
+func (db *db) StoreDAOGovernedParams(paramsBytes []byte) error {
+	err := db.putBeaconState(daoGovernedParamsKey, paramsBytes)
+	if err != nil {
+		return database.NewDatabaseError(database.StoreDAOGovernedParamsError, errors.Wrap(err, "db.lvdb.put"))
+	}
+	return nil
+}
+
+func (db *db) GetDAOGovernedParams() ([]byte, error) {
+	paramsBytes, dbErr := db.lvdb.Get(daoGovernedParamsKey, nil)
+	if dbErr != nil && dbErr != lvdberr.ErrNotFound {
+		return []byte{}, database.NewDatabaseError(database.GetDAOGovernedParamsError, dbErr)
+	}
+	return paramsBytes, nil
+}
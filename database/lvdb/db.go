@@ -1,6 +1,8 @@
 package lvdb
 
 import (
+	"sync"
+
 	"github.com/incognitochain/incognito-chain/database"
 	"github.com/pkg/errors"
 	"github.com/syndtr/goleveldb/leveldb"
@@ -8,6 +10,8 @@ import (
 
 type db struct {
 	lvdb *leveldb.DB
+	// serialize writers of pde state, see pdestate.go
+	pdeStateLock sync.Mutex
 }
 
 func open(dbPath string) (database.DatabaseInterface, error) {
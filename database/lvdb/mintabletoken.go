@@ -0,0 +1,116 @@
+package lvdb
+
+import (
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/database"
+	"github.com/pkg/errors"
+	lvdberr "github.com/syndtr/goleveldb/leveldb/errors"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+// MintableToken is a custom token whose supply is issued by its minting authority and burned by its holders,
+// a mint request is approved by at least RequiredSignatures of AuthorityAddresses
+type MintableToken struct {
+	TokenID             common.Hash
+	TokenName           string
+	TokenSymbol         string
+	AuthorityAddresses  []string
+	RequiredSignatures  int
+	TotalMinted         uint64
+	TotalBurned         uint64
+	MintNonce           uint64 // nonce of the next mint request
+	CreatedBeaconHeight uint64
+	InitTxReqID         common.Hash
+}
+
+// CirculatingSupply is the amount of the token which is minted and not burned yet
+func (token MintableToken) CirculatingSupply() uint64 {
+	if token.TotalBurned > token.TotalMinted {
+		return 0
+	}
+	return token.TotalMinted - token.TotalBurned
+}
+
+func BuildMintableTokenKey(tokenID common.Hash) []byte {
+	return append(MintableTokenPrefix, tokenID[:]...)
+}
+
+func BuildMintableTokenStatusKey(txReqID []byte) []byte {
+	return append(MintableTokenStatusPrefix, txReqID...)
+}
+
+func (db *db) StoreMintableToken(
+	tokenID common.Hash,
+	mintableTokenBytes []byte,
+) error {
+	key := BuildMintableTokenKey(tokenID)
+	err := db.putBeaconState(key, mintableTokenBytes)
+	if err != nil {
+		return database.NewDatabaseError(database.StoreMintableTokenError, errors.Wrap(err, "db.lvdb.put"))
+	}
+	return nil
+}
+
+func (db *db) GetMintableToken(
+	tokenID common.Hash,
+) ([]byte, error) {
+	key := BuildMintableTokenKey(tokenID)
+	mintableTokenBytes, dbErr := db.lvdb.Get(key, nil)
+	if dbErr != nil && dbErr != lvdberr.ErrNotFound {
+		return []byte{}, database.NewDatabaseError(database.GetMintableTokenError, dbErr)
+	}
+	return mintableTokenBytes, nil
+}
+
+func (db *db) GetAllMintableTokens() ([][]byte, error) {
+	values := [][]byte{}
+	iter := db.lvdb.NewIterator(util.BytesPrefix(MintableTokenPrefix), nil)
+	for iter.Next() {
+		value := iter.Value()
+		valueBytes := make([]byte, len(value))
+		copy(valueBytes, value)
+		values = append(values, valueBytes)
+	}
+	iter.Release()
+	err := iter.Error()
+	if err != nil && err != lvdberr.ErrNotFound {
+		return values, database.NewDatabaseError(database.GetMintableTokenError, err)
+	}
+	return values, nil
+}
+
+func (db *db) IsMintableTokenExisted(
+	tokenID common.Hash,
+) (bool, error) {
+	existed, err := db.lvdb.Has(BuildMintableTokenKey(tokenID), nil)
+	if err != nil {
+		return false, database.NewDatabaseError(database.GetMintableTokenError, err)
+	}
+	return existed, nil
+}
+
+func (db *db) TrackMintableTokenStatus(
+	txReqID []byte,
+	status byte,
+) error {
+	key := BuildMintableTokenStatusKey(txReqID)
+	err := db.Put(key, []byte{status})
+	if err != nil {
+		return database.NewDatabaseError(database.TrackMintableTokenStatusError, errors.Wrap(err, "db.lvdb.put"))
+	}
+	return nil
+}
+
+func (db *db) GetMintableTokenStatus(
+	txReqID []byte,
+) (byte, error) {
+	key := BuildMintableTokenStatusKey(txReqID)
+	statusBytes, dbErr := db.lvdb.Get(key, nil)
+	if dbErr != nil && dbErr != lvdberr.ErrNotFound {
+		return common.MintableTokenNotFoundStatus, database.NewDatabaseError(database.GetMintableTokenStatusError, dbErr)
+	}
+	if len(statusBytes) == 0 {
+		return common.MintableTokenNotFoundStatus, nil
+	}
+	return statusBytes[0], nil
+}
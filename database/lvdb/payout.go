@@ -0,0 +1,48 @@
+package lvdb
+
+import (
+	"github.com/incognitochain/incognito-chain/database"
+	"github.com/pkg/errors"
+	lvdberr "github.com/syndtr/goleveldb/leveldb/errors"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+func BuildPayoutBatchKey(idempotencyKey string) []byte {
+	return append(PayoutBatchPrefix, []byte(idempotencyKey)...)
+}
+
+func (db *db) StorePayoutBatch(idempotencyKey string, batchBytes []byte) error {
+	key := BuildPayoutBatchKey(idempotencyKey)
+	err := db.Put(key, batchBytes)
+	if err != nil {
+		return database.NewDatabaseError(database.StorePayoutBatchError, errors.Wrap(err, "db.lvdb.put"))
+	}
+	return nil
+}
+
+// GetPayoutBatch returns empty value if there is no batch of idempotencyKey
+func (db *db) GetPayoutBatch(idempotencyKey string) ([]byte, error) {
+	key := BuildPayoutBatchKey(idempotencyKey)
+	batchBytes, dbErr := db.lvdb.Get(key, nil)
+	if dbErr != nil && dbErr != lvdberr.ErrNotFound {
+		return []byte{}, database.NewDatabaseError(database.GetPayoutBatchError, dbErr)
+	}
+	return batchBytes, nil
+}
+
+func (db *db) GetAllPayoutBatches() ([][]byte, error) {
+	values := [][]byte{}
+	iter := db.lvdb.NewIterator(util.BytesPrefix(PayoutBatchPrefix), nil)
+	for iter.Next() {
+		value := iter.Value()
+		valueBytes := make([]byte, len(value))
+		copy(valueBytes, value)
+		values = append(values, valueBytes)
+	}
+	iter.Release()
+	err := iter.Error()
+	if err != nil && err != lvdberr.ErrNotFound {
+		return values, database.NewDatabaseError(database.GetPayoutBatchError, err)
+	}
+	return values, nil
+}
@@ -336,9 +336,14 @@ func (db *db) GetPDEPoolForPair(
 	tokenIDToBuyStr string,
 	tokenIDToSellStr string,
 ) ([]byte, error) {
-	pdePoolForPairKey := BuildPDEPoolForPairKey(beaconHeight, tokenIDToBuyStr, tokenIDToSellStr)
-	pdePoolForPairBytes, err := db.lvdb.Get(pdePoolForPairKey, nil)
-	if err != nil && err != lvdberr.ErrNotFound {
+	recordID, _, _ := splitPDEStateKey(BuildPDEPoolForPairKey(beaconHeight, tokenIDToBuyStr, tokenIDToSellStr))
+	snapshot, err := db.lvdb.GetSnapshot()
+	if err != nil {
+		return []byte{}, database.NewDatabaseError(database.GetPDEPoolForPairKeyError, err)
+	}
+	defer snapshot.Release()
+	pdePoolForPairBytes, err := db.getPDEStateRecord(snapshot, recordID, beaconHeight)
+	if err != nil {
 		return []byte{}, database.NewDatabaseError(database.GetPDEPoolForPairKeyError, err)
 	}
 	return pdePoolForPairBytes, nil
@@ -348,6 +353,13 @@ func (db *db) GetLatestPDEPoolForPair(
 	tokenIDToBuyStr string,
 	tokenIDToSellStr string,
 ) ([]byte, error) {
+	latestHeight, _, found, err := getPDEStateHeights(db.lvdb)
+	if err != nil {
+		return []byte{}, database.NewDatabaseError(database.GetPDEPoolForPairKeyError, err)
+	}
+	if found {
+		return db.GetPDEPoolForPair(latestHeight, tokenIDToBuyStr, tokenIDToSellStr)
+	}
 	iter := db.lvdb.NewIterator(util.BytesPrefix(PDEPoolPrefix), nil)
 	ok := iter.Last()
 	if !ok {
@@ -357,7 +369,7 @@ func (db *db) GetLatestPDEPoolForPair(
 	keyBytes := make([]byte, len(key))
 	copy(keyBytes, key)
 	iter.Release()
-	err := iter.Error()
+	err = iter.Error()
 	if err != nil {
 		return []byte{}, err
 	}
@@ -0,0 +1,104 @@
+package lvdb
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/database"
+	"github.com/pkg/errors"
+	lvdberr "github.com/syndtr/goleveldb/leveldb/errors"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+// PDEMarketEvent is an accepted trade, contribution or withdrawal on a pde pool pair,
+// token 1 and token 2 follow the order of the pool pair
+type PDEMarketEvent struct {
+	Type            string
+	BeaconHeight    uint64
+	Timestamp       int64
+	Token1IDStr     string
+	Token2IDStr     string
+	Token1Amount    uint64 // amount of token 1 moved in or out of the pool
+	Token2Amount    uint64
+	TokenIDToBuyStr string // trade only, amount of the token sold includes trading fee which is added to the pool
+	Token1PoolValue uint64 // pool values after the event
+	Token2PoolValue uint64
+	TxReqID         common.Hash
+}
+
+func buildPDEMarketEventPairPrefix(
+	token1IDStr string,
+	token2IDStr string,
+) []byte {
+	tokenIDStrs := []string{token1IDStr, token2IDStr}
+	sort.Strings(tokenIDStrs)
+	return append(PDEMarketEventPrefix, []byte(tokenIDStrs[0]+"-"+tokenIDStrs[1]+"-")...)
+}
+
+// BuildPDEMarketEventKey orders events of a pair by time, then by beacon height and position in the beacon block
+func BuildPDEMarketEventKey(
+	token1IDStr string,
+	token2IDStr string,
+	timestamp int64,
+	beaconHeight uint64,
+	index int,
+) []byte {
+	pairPrefix := buildPDEMarketEventPairPrefix(token1IDStr, token2IDStr)
+	return append(pairPrefix, []byte(fmt.Sprintf("%020d-%020d-%05d", timestamp, beaconHeight, index))...)
+}
+
+func (db *db) StorePDEMarketEvent(
+	token1IDStr string,
+	token2IDStr string,
+	timestamp int64,
+	beaconHeight uint64,
+	index int,
+	eventBytes []byte,
+	bd *[]database.BatchData,
+) error {
+	key := BuildPDEMarketEventKey(token1IDStr, token2IDStr, timestamp, beaconHeight, index)
+	if bd != nil {
+		*bd = append(*bd, database.BatchData{key, eventBytes})
+		return nil
+	}
+	err := db.Put(key, eventBytes)
+	if err != nil {
+		return database.NewDatabaseError(database.StorePDEMarketEventError, errors.Wrap(err, "db.lvdb.put"))
+	}
+	return nil
+}
+
+// GetPDEMarketEvents returns events of a pair with fromTime <= timestamp < toTime in chronological order
+func (db *db) GetPDEMarketEvents(
+	token1IDStr string,
+	token2IDStr string,
+	fromTime int64,
+	toTime int64,
+) ([][]byte, error) {
+	values := [][]byte{}
+	if fromTime < 0 {
+		fromTime = 0
+	}
+	if toTime <= fromTime {
+		return values, nil
+	}
+	pairPrefix := buildPDEMarketEventPairPrefix(token1IDStr, token2IDStr)
+	eventRange := &util.Range{
+		Start: append(append([]byte{}, pairPrefix...), []byte(fmt.Sprintf("%020d-", fromTime))...),
+		Limit: append(append([]byte{}, pairPrefix...), []byte(fmt.Sprintf("%020d-", toTime))...),
+	}
+	iter := db.lvdb.NewIterator(eventRange, nil)
+	for iter.Next() {
+		value := iter.Value()
+		valueBytes := make([]byte, len(value))
+		copy(valueBytes, value)
+		values = append(values, valueBytes)
+	}
+	iter.Release()
+	err := iter.Error()
+	if err != nil && err != lvdberr.ErrNotFound {
+		return values, database.NewDatabaseError(database.GetPDEMarketEventsError, err)
+	}
+	return values, nil
+}
@@ -0,0 +1,93 @@
+package lvdb
+
+import (
+	"sort"
+
+	"github.com/incognitochain/incognito-chain/database"
+	"github.com/pkg/errors"
+	lvdberr "github.com/syndtr/goleveldb/leveldb/errors"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+// PDELiquidityPosition is the deposit history of a contributor on a pde pool pair,
+// token 1 and token 2 follow the order of the pool pair
+type PDELiquidityPosition struct {
+	ContributorAddressStr string
+	Token1IDStr           string
+	Token2IDStr           string
+	Shares                uint64
+	Token1Deposited       uint64
+	Token2Deposited       uint64
+	Token1Withdrawn       uint64
+	Token2Withdrawn       uint64
+	// amounts which would be held if the deposits not withdrawn yet were not provided to the pool
+	Token1Held uint64
+	Token2Held uint64
+	// sqrt(token1 * token2) of the deposits not withdrawn yet, unlike the liquidity of shares it does not grow with trading fees
+	FeeFreeLiquidity uint64
+	// shares which were added before the position was tracked, the deposit history is not complete
+	HasUntrackedShares      bool
+	OpenedBeaconHeight      uint64
+	LastUpdatedBeaconHeight uint64
+}
+
+func buildPDELiquidityPositionsByContributorPrefix(contributorAddressStr string) []byte {
+	return append(PDELiquidityPositionPrefix, []byte(contributorAddressStr+"-")...)
+}
+
+func BuildPDELiquidityPositionKey(
+	contributorAddressStr string,
+	token1IDStr string,
+	token2IDStr string,
+) []byte {
+	tokenIDStrs := []string{token1IDStr, token2IDStr}
+	sort.Strings(tokenIDStrs)
+	contributorPrefix := buildPDELiquidityPositionsByContributorPrefix(contributorAddressStr)
+	return append(contributorPrefix, []byte(tokenIDStrs[0]+"-"+tokenIDStrs[1])...)
+}
+
+func (db *db) StorePDELiquidityPosition(
+	contributorAddressStr string,
+	token1IDStr string,
+	token2IDStr string,
+	positionBytes []byte,
+) error {
+	key := BuildPDELiquidityPositionKey(contributorAddressStr, token1IDStr, token2IDStr)
+	err := db.putBeaconState(key, positionBytes)
+	if err != nil {
+		return database.NewDatabaseError(database.StorePDELiquidityPositionError, errors.Wrap(err, "db.lvdb.put"))
+	}
+	return nil
+}
+
+func (db *db) GetPDELiquidityPosition(
+	contributorAddressStr string,
+	token1IDStr string,
+	token2IDStr string,
+) ([]byte, error) {
+	key := BuildPDELiquidityPositionKey(contributorAddressStr, token1IDStr, token2IDStr)
+	positionBytes, dbErr := db.lvdb.Get(key, nil)
+	if dbErr != nil && dbErr != lvdberr.ErrNotFound {
+		return []byte{}, database.NewDatabaseError(database.GetPDELiquidityPositionError, dbErr)
+	}
+	return positionBytes, nil
+}
+
+func (db *db) GetPDELiquidityPositionsByContributor(
+	contributorAddressStr string,
+) ([][]byte, error) {
+	values := [][]byte{}
+	iter := db.lvdb.NewIterator(util.BytesPrefix(buildPDELiquidityPositionsByContributorPrefix(contributorAddressStr)), nil)
+	for iter.Next() {
+		value := iter.Value()
+		valueBytes := make([]byte, len(value))
+		copy(valueBytes, value)
+		values = append(values, valueBytes)
+	}
+	iter.Release()
+	err := iter.Error()
+	if err != nil && err != lvdberr.ErrNotFound {
+		return values, database.NewDatabaseError(database.GetPDELiquidityPositionError, err)
+	}
+	return values, nil
+}
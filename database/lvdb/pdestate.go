@@ -0,0 +1,566 @@
+package lvdb
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/database"
+	"github.com/pkg/errors"
+	"github.com/syndtr/goleveldb/leveldb"
+	lvdberr "github.com/syndtr/goleveldb/leveldb/errors"
+	"github.com/syndtr/goleveldb/leveldb/iterator"
+	"github.com/syndtr/goleveldb/leveldb/opt"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+/*
+	PDE state (waiting contributions, pool pairs and shares) is stored as:
+	- latest records: pdestatelatest-{record id}, record id is the old key without beacon height,
+	  e.g. pdepool-{token1}-{token2}
+	- journals: pdestatejournal-{beacon height}, values of records before they were changed at that height,
+	  a height which does not change any record has no journal
+	- latest beacon height and oldest beacon height whose state can be rebuilt
+	State of an older beacon height is rebuilt by rolling latest records back with journals of later heights.
+	Callers still get records keyed by beacon height like the old layout, which stored a full copy
+	of the state for every beacon height.
+*/
+
+const (
+	// max number of journals pruned when a state is stored
+	pdeStatePruneBatchSize = 1000
+	// number of beacon heights migrated between 2 progress reports
+	pdeStateMigrationProgressStep = 1000
+)
+
+var pdeStatePrefixes = [][]byte{WaitingPDEContributionPrefix, PDEPoolPrefix, PDESharePrefix}
+
+// pdeStateJournal keeps values of records before they were changed at a beacon height,
+// PrevAbsent are records which were added at that height
+type pdeStateJournal struct {
+	PrevValues map[string][]byte
+	PrevAbsent []string
+}
+
+// pdeStateReader is implemented by leveldb.DB and leveldb.Snapshot
+type pdeStateReader interface {
+	Get(key []byte, ro *opt.ReadOptions) ([]byte, error)
+	NewIterator(slice *util.Range, ro *opt.ReadOptions) iterator.Iterator
+}
+
+// splitPDEStateKey return record id and beacon height of key {prefix}{beaconHeight}-{suffix}
+func splitPDEStateKey(key []byte) (string, uint64, bool) {
+	for _, prefix := range pdeStatePrefixes {
+		if !bytes.HasPrefix(key, prefix) {
+			continue
+		}
+		rest := key[len(prefix):]
+		idx := bytes.IndexByte(rest, '-')
+		if idx <= 0 {
+			return "", 0, false
+		}
+		heightStr := string(rest[:idx])
+		beaconHeight, err := strconv.ParseUint(heightStr, 10, 64)
+		// keys of the first pde version start with a token id instead of beacon height
+		if err != nil || strconv.FormatUint(beaconHeight, 10) != heightStr {
+			return "", 0, false
+		}
+		return string(prefix) + string(rest[idx+1:]), beaconHeight, true
+	}
+	return "", 0, false
+}
+
+func buildPDEStateKey(recordID string, beaconHeight uint64) []byte {
+	for _, prefix := range pdeStatePrefixes {
+		if strings.HasPrefix(recordID, string(prefix)) {
+			return []byte(fmt.Sprintf("%s%d-%s", prefix, beaconHeight, recordID[len(prefix):]))
+		}
+	}
+	return []byte(recordID)
+}
+
+func buildPDEStateLatestKey(recordID string) []byte {
+	return append(append([]byte{}, PDEStateLatestPrefix...), []byte(recordID)...)
+}
+
+func buildPDEStateJournalKey(beaconHeight uint64) []byte {
+	return append(append([]byte{}, PDEStateJournalPrefix...), []byte(fmt.Sprintf("%020d", beaconHeight))...)
+}
+
+// getPDEStateHeights return latest and oldest beacon heights of stored pde state,
+// found is false if pde state has not been stored by journals yet
+func getPDEStateHeights(reader pdeStateReader) (uint64, uint64, bool, error) {
+	latestHeightBytes, err := reader.Get(pdeStateLatestHeightKey, nil)
+	if err == lvdberr.ErrNotFound {
+		return 0, 0, false, nil
+	}
+	if err != nil {
+		return 0, 0, false, err
+	}
+	oldestHeightBytes, err := reader.Get(pdeStateOldestHeightKey, nil)
+	if err != nil {
+		return 0, 0, false, err
+	}
+	latestHeight, err := common.BytesToUint64(latestHeightBytes)
+	if err != nil {
+		return 0, 0, false, err
+	}
+	oldestHeight, err := common.BytesToUint64(oldestHeightBytes)
+	if err != nil {
+		return 0, 0, false, err
+	}
+	return latestHeight, oldestHeight, true, nil
+}
+
+func getLatestPDEStateRecords(reader pdeStateReader) (map[string][]byte, error) {
+	records := map[string][]byte{}
+	iter := reader.NewIterator(util.BytesPrefix(PDEStateLatestPrefix), nil)
+	defer iter.Release()
+	for iter.Next() {
+		recordID := string(iter.Key()[len(PDEStateLatestPrefix):])
+		records[recordID] = append([]byte{}, iter.Value()...)
+	}
+	return records, iter.Error()
+}
+
+// getLegacyPDEStateRecords read records of beaconHeight stored with beacon height in their keys
+func getLegacyPDEStateRecords(reader pdeStateReader, beaconHeight uint64) (map[string][]byte, error) {
+	records := map[string][]byte{}
+	for _, prefix := range pdeStatePrefixes {
+		prefixByBeaconHeight := append(append([]byte{}, prefix...), []byte(fmt.Sprintf("%d-", beaconHeight))...)
+		iter := reader.NewIterator(util.BytesPrefix(prefixByBeaconHeight), nil)
+		for iter.Next() {
+			recordID := string(prefix) + string(iter.Key()[len(prefixByBeaconHeight):])
+			records[recordID] = append([]byte{}, iter.Value()...)
+		}
+		iter.Release()
+		if err := iter.Error(); err != nil {
+			return nil, err
+		}
+	}
+	return records, nil
+}
+
+// forEachPDEStateJournal call fn with journals of beacon heights in [fromHeight, toHeight] by ascending height
+func forEachPDEStateJournal(
+	reader pdeStateReader,
+	fromHeight uint64,
+	toHeight uint64,
+	fn func(beaconHeight uint64, journal *pdeStateJournal) error,
+) error {
+	if fromHeight > toHeight {
+		return nil
+	}
+	iter := reader.NewIterator(&util.Range{Start: buildPDEStateJournalKey(fromHeight), Limit: buildPDEStateJournalKey(toHeight + 1)}, nil)
+	defer iter.Release()
+	for iter.Next() {
+		beaconHeight, err := strconv.ParseUint(string(iter.Key()[len(PDEStateJournalPrefix):]), 10, 64)
+		if err != nil {
+			return err
+		}
+		journal := &pdeStateJournal{}
+		if err := json.Unmarshal(iter.Value(), journal); err != nil {
+			return err
+		}
+		if err := fn(beaconHeight, journal); err != nil {
+			return err
+		}
+	}
+	return iter.Error()
+}
+
+// rollBackPDEStateRecords turn records of latestHeight into records of beaconHeight,
+// the first journal after beaconHeight which has a record keeps its value at beaconHeight
+func rollBackPDEStateRecords(reader pdeStateReader, records map[string][]byte, beaconHeight uint64, latestHeight uint64) error {
+	rolledBack := map[string]bool{}
+	return forEachPDEStateJournal(reader, beaconHeight+1, latestHeight, func(_ uint64, journal *pdeStateJournal) error {
+		for recordID, prevValue := range journal.PrevValues {
+			if !rolledBack[recordID] {
+				records[recordID] = prevValue
+				rolledBack[recordID] = true
+			}
+		}
+		for _, recordID := range journal.PrevAbsent {
+			if !rolledBack[recordID] {
+				delete(records, recordID)
+				rolledBack[recordID] = true
+			}
+		}
+		return nil
+	})
+}
+
+// diffPDEStateRecords return journal to roll records back to prevRecords, nil if nothing was changed
+func diffPDEStateRecords(prevRecords map[string][]byte, records map[string][]byte) *pdeStateJournal {
+	journal := &pdeStateJournal{PrevValues: map[string][]byte{}}
+	for recordID, value := range records {
+		prevValue, found := prevRecords[recordID]
+		if !found {
+			journal.PrevAbsent = append(journal.PrevAbsent, recordID)
+			continue
+		}
+		if !bytes.Equal(prevValue, value) {
+			journal.PrevValues[recordID] = prevValue
+		}
+	}
+	for recordID, prevValue := range prevRecords {
+		if _, found := records[recordID]; !found {
+			journal.PrevValues[recordID] = prevValue
+		}
+	}
+	if len(journal.PrevValues) == 0 && len(journal.PrevAbsent) == 0 {
+		return nil
+	}
+	sort.Strings(journal.PrevAbsent)
+	return journal
+}
+
+func (db *db) isPDEStateMigrated() (bool, error) {
+	version, err := db.GetSchemaVersion()
+	if err != nil {
+		return false, err
+	}
+	return version >= database.SchemaVersionPDEStateJournal, nil
+}
+
+// isLegacyPDEStateHeight return true if state of beaconHeight is still stored with beacon height in keys,
+// error if it was pruned
+func (db *db) isLegacyPDEStateHeight(found bool, oldestHeight uint64, beaconHeight uint64) (bool, error) {
+	if !found {
+		return true, nil
+	}
+	if beaconHeight >= oldestHeight {
+		return false, nil
+	}
+	migrated, err := db.isPDEStateMigrated()
+	if err != nil {
+		return false, err
+	}
+	if !migrated {
+		return true, nil
+	}
+	return false, database.NewDatabaseError(database.PDEStatePrunedError, errors.Errorf("beacon height %+v, oldest beacon height %+v", beaconHeight, oldestHeight))
+}
+
+func (db *db) getPDEStateRecords(reader pdeStateReader, beaconHeight uint64) (map[string][]byte, error) {
+	latestHeight, oldestHeight, found, err := getPDEStateHeights(reader)
+	if err != nil {
+		return nil, database.NewDatabaseError(database.GetPDEStateError, err)
+	}
+	legacy, err := db.isLegacyPDEStateHeight(found, oldestHeight, beaconHeight)
+	if err != nil {
+		return nil, err
+	}
+	if legacy {
+		records, err := getLegacyPDEStateRecords(reader, beaconHeight)
+		if err != nil {
+			return nil, database.NewDatabaseError(database.GetPDEStateError, err)
+		}
+		return records, nil
+	}
+	if beaconHeight > latestHeight {
+		return map[string][]byte{}, nil
+	}
+	records, err := getLatestPDEStateRecords(reader)
+	if err != nil {
+		return nil, database.NewDatabaseError(database.GetPDEStateError, err)
+	}
+	err = rollBackPDEStateRecords(reader, records, beaconHeight, latestHeight)
+	if err != nil {
+		return nil, database.NewDatabaseError(database.GetPDEStateError, err)
+	}
+	return records, nil
+}
+
+func (db *db) getPDEStateRecord(reader pdeStateReader, recordID string, beaconHeight uint64) ([]byte, error) {
+	latestHeight, oldestHeight, found, err := getPDEStateHeights(reader)
+	if err != nil {
+		return nil, database.NewDatabaseError(database.GetPDEStateError, err)
+	}
+	legacy, err := db.isLegacyPDEStateHeight(found, oldestHeight, beaconHeight)
+	if err != nil {
+		return nil, err
+	}
+	key := buildPDEStateLatestKey(recordID)
+	if legacy {
+		key = buildPDEStateKey(recordID, beaconHeight)
+	} else if beaconHeight > latestHeight {
+		return nil, nil
+	} else {
+		var value []byte
+		rolledBack := false
+		err := forEachPDEStateJournal(reader, beaconHeight+1, latestHeight, func(_ uint64, journal *pdeStateJournal) error {
+			if rolledBack {
+				return nil
+			}
+			if prevValue, found := journal.PrevValues[recordID]; found {
+				value = prevValue
+				rolledBack = true
+				return nil
+			}
+			for _, absentRecordID := range journal.PrevAbsent {
+				if absentRecordID == recordID {
+					rolledBack = true
+					return nil
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, database.NewDatabaseError(database.GetPDEStateError, err)
+		}
+		if rolledBack {
+			return value, nil
+		}
+	}
+	value, err := reader.Get(key, nil)
+	if err != nil && err != lvdberr.ErrNotFound {
+		return nil, database.NewDatabaseError(database.GetPDEStateError, err)
+	}
+	return value, nil
+}
+
+// GetPDEState return waiting contributions, pool pairs and shares at beaconHeight,
+// records are keyed by their keys with beacon height (BuildPDEPoolForPairKey, BuildPDESharesKeyV2, ...)
+func (db *db) GetPDEState(beaconHeight uint64) (map[string][]byte, error) {
+	snapshot, err := db.lvdb.GetSnapshot()
+	if err != nil {
+		return nil, database.NewDatabaseError(database.GetPDEStateError, errors.Wrap(err, "db.lvdb.GetSnapshot"))
+	}
+	defer snapshot.Release()
+	records, err := db.getPDEStateRecords(snapshot, beaconHeight)
+	if err != nil {
+		return nil, err
+	}
+	state := make(map[string][]byte, len(records))
+	for recordID, value := range records {
+		state[string(buildPDEStateKey(recordID, beaconHeight))] = value
+	}
+	return state, nil
+}
+
+// StorePDEState store pde state of beaconHeight which is keyed like result of GetPDEState,
+// only records changed from state of the previous beacon height are written.
+// Journals of heights more than retention before beaconHeight are pruned, retention 0 keeps all of them
+func (db *db) StorePDEState(beaconHeight uint64, state map[string][]byte, retention uint64) error {
+	if beaconHeight == 0 {
+		return database.NewDatabaseError(database.StorePDEStateError, errors.New("pde state can not be stored at beacon height 0"))
+	}
+	records := make(map[string][]byte, len(state))
+	for key, value := range state {
+		recordID, _, ok := splitPDEStateKey([]byte(key))
+		if !ok {
+			return database.NewDatabaseError(database.StorePDEStateError, errors.Errorf("invalid pde state key %+v", key))
+		}
+		records[recordID] = value
+	}
+
+	db.pdeStateLock.Lock()
+	defer db.pdeStateLock.Unlock()
+	latestHeight, oldestHeight, found, err := getPDEStateHeights(db.lvdb)
+	if err != nil {
+		return database.NewDatabaseError(database.StorePDEStateError, err)
+	}
+	batch := new(leveldb.Batch)
+	storedRecords := map[string][]byte{}
+	var prevRecords map[string][]byte
+	if !found {
+		// previous state is still stored with beacon height in keys
+		prevRecords, err = getLegacyPDEStateRecords(db.lvdb, beaconHeight-1)
+		if err != nil {
+			return database.NewDatabaseError(database.StorePDEStateError, err)
+		}
+		oldestHeight = beaconHeight - 1
+	} else {
+		storedRecords, err = getLatestPDEStateRecords(db.lvdb)
+		if err != nil {
+			return database.NewDatabaseError(database.StorePDEStateError, err)
+		}
+		prevRecords = make(map[string][]byte, len(storedRecords))
+		for recordID, value := range storedRecords {
+			prevRecords[recordID] = value
+		}
+		if beaconHeight <= latestHeight {
+			// state of beaconHeight is stored again, it replaces states from beaconHeight to latestHeight
+			if beaconHeight-1 < oldestHeight {
+				return database.NewDatabaseError(database.PDEStatePrunedError, errors.Errorf("beacon height %+v, oldest beacon height %+v", beaconHeight-1, oldestHeight))
+			}
+			err = rollBackPDEStateRecords(db.lvdb, prevRecords, beaconHeight-1, latestHeight)
+			if err == nil {
+				err = forEachPDEStateJournal(db.lvdb, beaconHeight, latestHeight, func(journalHeight uint64, _ *pdeStateJournal) error {
+					batch.Delete(buildPDEStateJournalKey(journalHeight))
+					return nil
+				})
+			}
+			if err != nil {
+				return database.NewDatabaseError(database.StorePDEStateError, err)
+			}
+		}
+	}
+
+	if journal := diffPDEStateRecords(prevRecords, records); journal != nil {
+		journalBytes, err := json.Marshal(journal)
+		if err != nil {
+			return database.NewDatabaseError(database.StorePDEStateError, errors.Wrap(err, "marshal.to.bytes"))
+		}
+		batch.Put(buildPDEStateJournalKey(beaconHeight), journalBytes)
+	}
+	for recordID, value := range records {
+		if storedValue, found := storedRecords[recordID]; !found || !bytes.Equal(storedValue, value) {
+			batch.Put(buildPDEStateLatestKey(recordID), value)
+		}
+	}
+	for recordID := range storedRecords {
+		if _, found := records[recordID]; !found {
+			batch.Delete(buildPDEStateLatestKey(recordID))
+		}
+	}
+	if retention > 0 && beaconHeight > retention && beaconHeight-retention > oldestHeight {
+		// old states are kept until migration of the old layout is finished
+		migrated, err := db.isPDEStateMigrated()
+		if err != nil {
+			return database.NewDatabaseError(database.StorePDEStateError, err)
+		}
+		if migrated {
+			oldestHeight, err = prunePDEStateJournals(db.lvdb, batch, oldestHeight, beaconHeight-retention)
+			if err != nil {
+				return database.NewDatabaseError(database.StorePDEStateError, err)
+			}
+		}
+	}
+	batch.Put(pdeStateLatestHeightKey, common.Uint64ToBytes(beaconHeight))
+	batch.Put(pdeStateOldestHeightKey, common.Uint64ToBytes(oldestHeight))
+	if err := db.lvdb.Write(batch, nil); err != nil {
+		return database.NewDatabaseError(database.StorePDEStateError, errors.Wrap(err, "db.lvdb.Write"))
+	}
+	return nil
+}
+
+// prunePDEStateJournals delete at most pdeStatePruneBatchSize journals of heights in (oldestHeight, toHeight],
+// it returns the new oldest beacon height
+func prunePDEStateJournals(reader pdeStateReader, batch *leveldb.Batch, oldestHeight uint64, toHeight uint64) (uint64, error) {
+	iter := reader.NewIterator(&util.Range{Start: buildPDEStateJournalKey(oldestHeight + 1), Limit: buildPDEStateJournalKey(toHeight + 1)}, nil)
+	defer iter.Release()
+	for pruned := 0; iter.Next(); pruned++ {
+		if pruned == pdeStatePruneBatchSize {
+			beaconHeight, err := strconv.ParseUint(string(iter.Key()[len(PDEStateJournalPrefix):]), 10, 64)
+			if err != nil {
+				return oldestHeight, err
+			}
+			// the rest is pruned when next states are stored
+			return beaconHeight - 1, nil
+		}
+		batch.Delete(iter.Key())
+	}
+	if err := iter.Error(); err != nil {
+		return oldestHeight, err
+	}
+	return toHeight, nil
+}
+
+// getLegacyPDEStateHeights return min and max beacon heights of records stored with beacon height in keys
+func (db *db) getLegacyPDEStateHeights() (uint64, uint64, bool, error) {
+	minHeight, maxHeight, found := uint64(0), uint64(0), false
+	for _, prefix := range pdeStatePrefixes {
+		iter := db.lvdb.NewIterator(util.BytesPrefix(prefix), nil)
+		for iter.Next() {
+			_, beaconHeight, ok := splitPDEStateKey(iter.Key())
+			if !ok {
+				continue
+			}
+			if !found || beaconHeight < minHeight {
+				minHeight = beaconHeight
+			}
+			if !found || beaconHeight > maxHeight {
+				maxHeight = beaconHeight
+			}
+			found = true
+		}
+		iter.Release()
+		if err := iter.Error(); err != nil {
+			return 0, 0, false, err
+		}
+	}
+	return minHeight, maxHeight, found, nil
+}
+
+// MigratePDEState move pde state stored with a full copy for every beacon height to latest records and journals.
+// Heights are migrated from the latest one down, states which are not migrated yet are read from the old layout,
+// so it can run while beacon blocks are being inserted and can be resumed after being interrupted
+func (db *db) MigratePDEState(progress func(done uint64)) error {
+	minHeight, maxHeight, found, err := db.getLegacyPDEStateHeights()
+	if err != nil {
+		return database.NewDatabaseError(database.MigratePDEStateError, err)
+	}
+	if !found {
+		return nil
+	}
+	done := uint64(0)
+	for {
+		finished, err := db.migratePDEStateHeight(minHeight, maxHeight)
+		if err != nil {
+			return database.NewDatabaseError(database.MigratePDEStateError, err)
+		}
+		if finished {
+			break
+		}
+		done++
+		if done%pdeStateMigrationProgressStep == 0 {
+			progress(done)
+		}
+	}
+	progress(done)
+	return nil
+}
+
+// migratePDEStateHeight write journal of the oldest migrated beacon height and delete its records of the old layout
+func (db *db) migratePDEStateHeight(minHeight uint64, maxHeight uint64) (bool, error) {
+	db.pdeStateLock.Lock()
+	defer db.pdeStateLock.Unlock()
+	_, oldestHeight, found, err := getPDEStateHeights(db.lvdb)
+	if err != nil {
+		return false, err
+	}
+	batch := new(leveldb.Batch)
+	if !found {
+		records, err := getLegacyPDEStateRecords(db.lvdb, maxHeight)
+		if err != nil {
+			return false, err
+		}
+		for recordID, value := range records {
+			batch.Put(buildPDEStateLatestKey(recordID), value)
+		}
+		batch.Put(pdeStateLatestHeightKey, common.Uint64ToBytes(maxHeight))
+		batch.Put(pdeStateOldestHeightKey, common.Uint64ToBytes(maxHeight))
+		return false, db.lvdb.Write(batch, nil)
+	}
+	if oldestHeight < minHeight || oldestHeight == 0 {
+		// states before the first stored height are empty, they are rebuilt by the journal of that height
+		batch.Put(pdeStateOldestHeightKey, common.Uint64ToBytes(0))
+		return true, db.lvdb.Write(batch, nil)
+	}
+	records, err := getLegacyPDEStateRecords(db.lvdb, oldestHeight)
+	if err != nil {
+		return false, err
+	}
+	prevRecords, err := getLegacyPDEStateRecords(db.lvdb, oldestHeight-1)
+	if err != nil {
+		return false, err
+	}
+	if journal := diffPDEStateRecords(prevRecords, records); journal != nil {
+		journalBytes, err := json.Marshal(journal)
+		if err != nil {
+			return false, err
+		}
+		batch.Put(buildPDEStateJournalKey(oldestHeight), journalBytes)
+	}
+	for recordID := range records {
+		batch.Delete(buildPDEStateKey(recordID, oldestHeight))
+	}
+	batch.Put(pdeStateOldestHeightKey, common.Uint64ToBytes(oldestHeight-1))
+	return false, db.lvdb.Write(batch, nil)
+}
@@ -0,0 +1,137 @@
+package lvdb
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/database"
+)
+
+func newTestPDEState(beaconHeight uint64, poolValue uint64, shares map[string]uint64, waitingPairID string) map[string][]byte {
+	token1IDStr := "0000000000000000000000000000000000000000000000000000000000000004"
+	token2IDStr := "0000000000000000000000000000000000000000000000000000000000000007"
+	state := map[string][]byte{}
+	poolPairBytes, _ := json.Marshal(PDEPoolForPair{
+		Token1IDStr:     token1IDStr,
+		Token1PoolValue: poolValue,
+		Token2IDStr:     token2IDStr,
+		Token2PoolValue: poolValue * 2,
+	})
+	state[string(BuildPDEPoolForPairKey(beaconHeight, token1IDStr, token2IDStr))] = poolPairBytes
+	for contributorAddressStr, amount := range shares {
+		buf := make([]byte, binary.MaxVarintLen64)
+		binary.LittleEndian.PutUint64(buf, amount)
+		state[string(BuildPDESharesKeyV2(beaconHeight, token1IDStr, token2IDStr, contributorAddressStr))] = buf
+	}
+	if waitingPairID != "" {
+		contributionBytes, _ := json.Marshal(PDEContribution{ContributorAddressStr: "alice", TokenIDStr: token1IDStr, Amount: 10})
+		state[string(BuildWaitingPDEContributionKey(beaconHeight, waitingPairID))] = contributionBytes
+	}
+	return state
+}
+
+func TestPDEStateJournal(t *testing.T) {
+	testDB, err := openTestDB("TestPDEStateJournal")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer testDB.Close()
+	db := &db{lvdb: testDB}
+
+	// states stored by old nodes, a full copy for every beacon height
+	legacyStates := map[uint64]map[string][]byte{
+		5: newTestPDEState(5, 100, map[string]uint64{"alice": 100}, "pair1"),
+		6: newTestPDEState(6, 100, map[string]uint64{"alice": 100}, "pair1"),
+		7: newTestPDEState(7, 150, map[string]uint64{"alice": 100, "bob": 50}, ""),
+		8: newTestPDEState(8, 120, map[string]uint64{"alice": 100, "bob": 20}, "pair2"),
+	}
+	for _, state := range legacyStates {
+		for key, value := range state {
+			if err := db.Put([]byte(key), value); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	expectedStates := map[uint64]map[string][]byte{}
+	for beaconHeight := uint64(0); beaconHeight <= 10; beaconHeight++ {
+		state, err := db.GetPDEState(beaconHeight)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(state, legacyStates[beaconHeight]) && (len(state) != 0 || legacyStates[beaconHeight] != nil) {
+			t.Fatalf("wrong state of old layout at beacon height %+v", beaconHeight)
+		}
+		expectedStates[beaconHeight] = state
+	}
+
+	if err := db.MigratePDEState(func(uint64) {}); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.StoreSchemaVersion(database.SchemaVersionPDEStateJournal); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, found, _ := db.getLegacyPDEStateHeights(); found {
+		t.Errorf("records of old layout must be deleted by migration")
+	}
+	for beaconHeight, expectedState := range expectedStates {
+		state, err := db.GetPDEState(beaconHeight)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(state) != len(expectedState) || (len(state) > 0 && !reflect.DeepEqual(state, expectedState)) {
+			t.Errorf("migrated state at beacon height %+v is different, got %+v", beaconHeight, state)
+		}
+	}
+
+	// store next states then replace the last one
+	state9 := newTestPDEState(9, 120, map[string]uint64{"alice": 100, "bob": 20}, "pair2")
+	if err := db.StorePDEState(9, state9, 0); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.StorePDEState(10, newTestPDEState(10, 200, map[string]uint64{"alice": 100}, ""), 0); err != nil {
+		t.Fatal(err)
+	}
+	state10 := newTestPDEState(10, 300, map[string]uint64{"carol": 1}, "")
+	if err := db.StorePDEState(10, state10, 0); err != nil {
+		t.Fatal(err)
+	}
+	for beaconHeight, expectedState := range map[uint64]map[string][]byte{7: legacyStates[7], 9: state9, 10: state10} {
+		if state, err := db.GetPDEState(beaconHeight); err != nil || !reflect.DeepEqual(state, expectedState) {
+			t.Errorf("wrong state at beacon height %+v, got %+v, err %+v", beaconHeight, state, err)
+		}
+	}
+	token1IDStr := "0000000000000000000000000000000000000000000000000000000000000004"
+	token2IDStr := "0000000000000000000000000000000000000000000000000000000000000007"
+	for _, beaconHeight := range []uint64{4, 7, 10} {
+		poolPairBytes, err := db.GetPDEPoolForPair(beaconHeight, token2IDStr, token1IDStr)
+		expectedBytes := expectedStates[beaconHeight][string(BuildPDEPoolForPairKey(beaconHeight, token1IDStr, token2IDStr))]
+		if beaconHeight == 10 {
+			expectedBytes = state10[string(BuildPDEPoolForPairKey(beaconHeight, token1IDStr, token2IDStr))]
+		}
+		if err != nil || string(poolPairBytes) != string(expectedBytes) {
+			t.Errorf("wrong pool pair at beacon height %+v, got %s", beaconHeight, poolPairBytes)
+		}
+	}
+
+	// keep only 1 beacon height before the latest one
+	if err := db.StorePDEState(11, newTestPDEState(11, 400, map[string]uint64{"carol": 1}, ""), 1); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.GetPDEState(10); err != nil {
+		t.Errorf("state at beacon height 10 must be kept, err %+v", err)
+	}
+	_, err = db.GetPDEState(9)
+	if dbErr, ok := err.(*database.DatabaseError); !ok || dbErr.GetErrorCode() != database.ErrCodeMessage[database.PDEStatePrunedError].Code {
+		t.Errorf("expect pruned error at beacon height 9, got %+v", err)
+	}
+	journalHeights := []uint64{}
+	err = forEachPDEStateJournal(db.lvdb, 0, 11, func(beaconHeight uint64, _ *pdeStateJournal) error {
+		journalHeights = append(journalHeights, beaconHeight)
+		return nil
+	})
+	if err != nil || !reflect.DeepEqual(journalHeights, []uint64{11}) {
+		t.Errorf("expect only journal of beacon height 11 after pruning, got %+v, err %+v", journalHeights, err)
+	}
+}
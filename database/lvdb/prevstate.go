@@ -8,6 +8,7 @@ import (
 	"github.com/incognitochain/incognito-chain/common"
 	"github.com/incognitochain/incognito-chain/database"
 	"github.com/pkg/errors"
+	lvdberr "github.com/syndtr/goleveldb/leveldb/errors"
 	"github.com/syndtr/goleveldb/leveldb/util"
 )
 
@@ -328,6 +329,15 @@ func (db *db) DeleteIncomingCrossShard(shardID byte, crossShardID byte, crossBlk
 	return nil
 }
 
+func (db *db) DeleteCrossShardTransaction(txID common.Hash) error {
+	// cstx-TxID : CrossShardTransaction
+	key := append(crossShardTxKeyPrefix, txID[:]...)
+	if err := db.Delete(key); err != nil {
+		return database.NewDatabaseError(database.DeleteCrossShardTransactionError, err)
+	}
+	return nil
+}
+
 func (db *db) BackupBridgedTokenByTokenID(tokenID common.Hash) error {
 	key := append(centralizedBridgePrefix, tokenID[:]...)
 	backupKey := getPrevPrefix(true, 0)
@@ -431,3 +441,68 @@ func (db *db) RestoreCommitteeReward(committeeAddress []byte, tokenID common.Has
 
 	return nil
 }
+
+// backupBeaconState keeps the value of a beacon state key as it was before the beacon block being processed,
+// only the first write of the key in a block is backed up, the backups are removed by CleanBackup
+func (db *db) backupBeaconState(key []byte) error {
+	backupKey := append(append([]byte{}, prevBeaconStatePrefix...), key...)
+	hasBackup, err := db.lvdb.Has(backupKey, nil)
+	if err != nil {
+		return database.NewDatabaseError(database.UnexpectedError, errors.Wrap(err, "db.lvdb.Has"))
+	}
+	if hasBackup {
+		return nil
+	}
+	curValue, err := db.lvdb.Get(key, nil)
+	if err != nil && err != lvdberr.ErrNotFound {
+		return database.NewDatabaseError(database.UnexpectedError, errors.Wrap(err, "db.lvdb.Get"))
+	}
+	bakValue := []byte{0}
+	if err == nil {
+		bakValue = append([]byte{1}, curValue...)
+	}
+	if err := db.Put(backupKey, bakValue); err != nil {
+		return database.NewDatabaseError(database.UnexpectedError, errors.Wrap(err, "db.Put"))
+	}
+	return nil
+}
+
+func (db *db) putBeaconState(key []byte, value []byte) error {
+	if err := db.backupBeaconState(key); err != nil {
+		return err
+	}
+	return db.Put(key, value)
+}
+
+func (db *db) deleteBeaconState(key []byte) error {
+	if err := db.backupBeaconState(key); err != nil {
+		return err
+	}
+	return db.Delete(key)
+}
+
+// RestoreBeaconStates puts back every beacon state key written by the beacon block being reverted
+func (db *db) RestoreBeaconStates() error {
+	iter := db.lvdb.NewIterator(util.BytesPrefix(prevBeaconStatePrefix), nil)
+	defer iter.Release()
+	for iter.Next() {
+		key := append([]byte{}, iter.Key()[len(prevBeaconStatePrefix):]...)
+		bakValue := iter.Value()
+		if len(bakValue) == 0 {
+			continue
+		}
+		var err error
+		if bakValue[0] == 1 {
+			err = db.Put(key, append([]byte{}, bakValue[1:]...))
+		} else {
+			err = db.Delete(key)
+		}
+		if err != nil {
+			return database.NewDatabaseError(database.UnexpectedError, err)
+		}
+	}
+	if err := iter.Error(); err != nil {
+		return database.NewDatabaseError(database.UnexpectedError, errors.Wrap(err, "iter.Error"))
+	}
+	return nil
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/incognitochain/incognito-chain/database"
 	"github.com/incognitochain/incognito-chain/databasemp"
 	"github.com/pkg/errors"
+	lvdberr "github.com/syndtr/goleveldb/leveldb/errors"
 	"github.com/syndtr/goleveldb/leveldb/util"
 )
 
@@ -42,6 +43,16 @@ func (db *db) StorePrivacyTokenTx(tokenID common.Hash, shardID byte, blockHeight
 	return nil
 }
 
+// GetPrivacyTokenInitTx returns hash of tx which initialized tokenID, it is empty if token is not initialized in shards of db
+func (db *db) GetPrivacyTokenInitTx(tokenID common.Hash) ([]byte, error) {
+	key := addPrefixToKeyHash(string(privacyTokenInitPrefix), tokenID) // token-init-{tokenID}
+	data, err := db.lvdb.Get(key, nil)
+	if err != nil && err != lvdberr.ErrNotFound {
+		return nil, database.NewDatabaseError(database.UnexpectedError, err)
+	}
+	return data, nil
+}
+
 func (db *db) PrivacyTokenIDExisted(tokenID common.Hash) bool {
 	key := addPrefixToKeyHash(string(privacyTokenInitPrefix), tokenID) // token-init-{tokenID}
 	data, err := db.Get(key)
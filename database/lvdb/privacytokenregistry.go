@@ -0,0 +1,100 @@
+package lvdb
+
+import (
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/database"
+	"github.com/pkg/errors"
+	lvdberr "github.com/syndtr/goleveldb/leveldb/errors"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+// PrivacyTokenRegistry is registered metadata of a privacy custom token
+type PrivacyTokenRegistry struct {
+	TokenID              common.Hash
+	TokenName            string
+	TokenSymbol          string
+	Decimals             uint8
+	Description          string
+	IconHash             string
+	ExternalNetwork      string
+	ExternalTokenID      string
+	IssuerAddressStr     string // empty for bridge tokens which are registered with approval of the beacon committee
+	Nonce                uint64 // nonce of the next registration approved by the committee
+	CreatedBeaconHeight  uint64
+	UpdatedBeaconHeight  uint64
+	LastRegistrationTxID common.Hash
+}
+
+func BuildPrivacyTokenRegistryKey(tokenID common.Hash) []byte {
+	return append(PrivacyTokenRegistryPrefix, tokenID[:]...)
+}
+
+func BuildPrivacyTokenRegistryStatusKey(txReqID []byte) []byte {
+	return append(PrivacyTokenRegistryStatusPrefix, txReqID...)
+}
+
+func (db *db) StorePrivacyTokenRegistry(
+	tokenID common.Hash,
+	registryBytes []byte,
+) error {
+	key := BuildPrivacyTokenRegistryKey(tokenID)
+	err := db.putBeaconState(key, registryBytes)
+	if err != nil {
+		return database.NewDatabaseError(database.StorePrivacyTokenRegistryError, errors.Wrap(err, "db.lvdb.put"))
+	}
+	return nil
+}
+
+func (db *db) GetPrivacyTokenRegistry(
+	tokenID common.Hash,
+) ([]byte, error) {
+	key := BuildPrivacyTokenRegistryKey(tokenID)
+	registryBytes, dbErr := db.lvdb.Get(key, nil)
+	if dbErr != nil && dbErr != lvdberr.ErrNotFound {
+		return []byte{}, database.NewDatabaseError(database.GetPrivacyTokenRegistryError, dbErr)
+	}
+	return registryBytes, nil
+}
+
+func (db *db) GetAllPrivacyTokenRegistries() ([][]byte, error) {
+	values := [][]byte{}
+	iter := db.lvdb.NewIterator(util.BytesPrefix(PrivacyTokenRegistryPrefix), nil)
+	for iter.Next() {
+		value := iter.Value()
+		valueBytes := make([]byte, len(value))
+		copy(valueBytes, value)
+		values = append(values, valueBytes)
+	}
+	iter.Release()
+	err := iter.Error()
+	if err != nil && err != lvdberr.ErrNotFound {
+		return values, database.NewDatabaseError(database.GetPrivacyTokenRegistryError, err)
+	}
+	return values, nil
+}
+
+func (db *db) TrackPrivacyTokenRegistryStatus(
+	txReqID []byte,
+	status byte,
+) error {
+	key := BuildPrivacyTokenRegistryStatusKey(txReqID)
+	err := db.Put(key, []byte{status})
+	if err != nil {
+		return database.NewDatabaseError(database.TrackPrivacyTokenRegistryStatusError, errors.Wrap(err, "db.lvdb.put"))
+	}
+	return nil
+}
+
+func (db *db) GetPrivacyTokenRegistryStatus(
+	txReqID []byte,
+) (byte, error) {
+	key := BuildPrivacyTokenRegistryStatusKey(txReqID)
+	statusBytes, dbErr := db.lvdb.Get(key, nil)
+	if dbErr != nil && dbErr != lvdberr.ErrNotFound {
+		return common.PrivacyTokenRegistryNotFoundStatus, database.NewDatabaseError(database.GetPrivacyTokenRegistryStatusError, dbErr)
+	}
+	if len(statusBytes) == 0 {
+		return common.PrivacyTokenRegistryNotFoundStatus, nil
+	}
+	return statusBytes[0], nil
+}
@@ -0,0 +1,95 @@
+package lvdb
+
+import (
+	"encoding/json"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/database"
+	"github.com/pkg/errors"
+	lvdberr "github.com/syndtr/goleveldb/leveldb/errors"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+// GetSchemaVersion return version of data in db, db created before schema version key has SchemaVersionJSONBlock
+func (db *db) GetSchemaVersion() (int, error) {
+	value, err := db.lvdb.Get(schemaVersionKey, nil)
+	if err == lvdberr.ErrNotFound {
+		return database.SchemaVersionJSONBlock, nil
+	}
+	if err != nil {
+		return 0, database.NewDatabaseError(database.GetSchemaVersionError, errors.Wrap(err, "db.lvdb.Get"))
+	}
+	version, err := common.BytesToUint64(value)
+	if err != nil {
+		return 0, database.NewDatabaseError(database.GetSchemaVersionError, err)
+	}
+	return int(version), nil
+}
+
+func (db *db) StoreSchemaVersion(version int) error {
+	if err := db.Put(schemaVersionKey, common.Uint64ToBytes(uint64(version))); err != nil {
+		return database.NewDatabaseError(database.StoreSchemaVersionError, err)
+	}
+	return nil
+}
+
+// ForEachShardBlock call fn with hash and stored value of every block of shard, iteration stops at first error of fn
+func (db *db) ForEachShardBlock(shardID byte, fn func(hash common.Hash, value []byte) error) error {
+	// key: s-{shardID}b-{[blockhash]}
+	prefix := append(append(append([]byte{}, shardIDPrefix...), shardID), blockKeyPrefix...)
+	return db.forEachBlock(prefix, fn)
+}
+
+// ForEachBeaconBlock call fn with hash and stored value of every beacon block, iteration stops at first error of fn
+func (db *db) ForEachBeaconBlock(fn func(hash common.Hash, value []byte) error) error {
+	// key: bea-b-{[blockhash]}
+	prefix := append(append([]byte{}, beaconPrefix...), blockKeyPrefix...)
+	return db.forEachBlock(prefix, fn)
+}
+
+func (db *db) forEachBlock(prefix []byte, fn func(hash common.Hash, value []byte) error) error {
+	iter := db.lvdb.NewIterator(util.BytesPrefix(prefix), nil)
+	defer iter.Release()
+	for iter.Next() {
+		key := iter.Key()
+		if len(key) != len(prefix)+common.HashSize {
+			continue
+		}
+		hash := common.Hash{}
+		if err := hash.SetBytes(key[len(prefix):]); err != nil {
+			return database.NewDatabaseError(database.IterateBlockError, err)
+		}
+		value, err := db.lvdb.Get(iter.Value(), nil)
+		if err != nil {
+			return database.NewDatabaseError(database.IterateBlockError, errors.Wrapf(err, "block %+v", hash))
+		}
+		if err := fn(hash, value); err != nil {
+			return err
+		}
+	}
+	if err := iter.Error(); err != nil {
+		return database.NewDatabaseError(database.IterateBlockError, err)
+	}
+	return nil
+}
+
+// StoreRawBlock replace stored value of an existing block, it is used to re-encode blocks by migration
+func (db *db) StoreRawBlock(hash common.Hash, value []byte) error {
+	// key: b-{blockhash}
+	key := append(append([]byte{}, blockKeyPrefix...), hash[:]...)
+	if ok, _ := db.HasValue(key); !ok {
+		return database.NewDatabaseError(database.StoreRawBlockError, errors.Errorf("block %+v not found", hash))
+	}
+	if err := db.Put(key, value); err != nil {
+		return database.NewDatabaseError(database.StoreRawBlockError, err)
+	}
+	return nil
+}
+
+// marshalStorage encode block or best state by its own storage encoding if it has one, otherwise by json
+func marshalStorage(v interface{}) ([]byte, error) {
+	if marshaler, ok := v.(database.StorageMarshaler); ok {
+		return marshaler.MarshalStorage()
+	}
+	return json.Marshal(v)
+}
@@ -31,9 +31,9 @@ func (db *db) StoreShardBlock(v interface{}, hash common.Hash, shardID byte, bd
 	if ok, _ := db.HasValue(keyShardBlock); ok {
 		return database.NewDatabaseError(database.BlockExisted, errors.Errorf("block %s already exists", hash.String()))
 	}
-	val, err := json.Marshal(v)
+	val, err := marshalStorage(v)
 	if err != nil {
-		return database.NewDatabaseError(database.UnexpectedError, errors.Wrap(err, "json.Marshal"))
+		return database.NewDatabaseError(database.UnexpectedError, errors.Wrap(err, "marshalStorage"))
 	}
 
 	if bd != nil {
@@ -169,9 +169,9 @@ func (db *db) DeleteBlock(hash common.Hash, idx uint64, shardID byte) error {
 }
 
 func (db *db) StoreShardBestState(v interface{}, shardID byte, bd *[]database.BatchData) error {
-	val, err := json.Marshal(v)
+	val, err := marshalStorage(v)
 	if err != nil {
-		return database.NewDatabaseError(database.UnexpectedError, errors.Wrap(err, "json.Marshal"))
+		return database.NewDatabaseError(database.UnexpectedError, errors.Wrap(err, "marshalStorage"))
 	}
 	key := append(bestBlockKeyPrefix, shardID)
 
@@ -0,0 +1,154 @@
+package lvdb
+
+import (
+	"encoding/binary"
+	"strings"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/database"
+	"github.com/pkg/errors"
+	lvdberr "github.com/syndtr/goleveldb/leveldb/errors"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+type StakingPool struct {
+	CommitteePublicKey  string
+	OperatorAddressStr  string
+	CommissionRate      uint64
+	TotalDelegation     uint64
+	IsStaked            bool
+	IsClosed            bool
+	CreatedBeaconHeight uint64
+	CreationTxReqID     common.Hash
+	// withdrawals from the stopped pool which are paid once its stake is released from unbonding queue
+	UnbondingWithdrawals []StakingPoolWithdrawal
+}
+
+type StakingPoolWithdrawal struct {
+	DelegatorAddressStr string
+	WithdrawalAmount    uint64
+	TxReqID             common.Hash
+	ShardID             byte
+}
+
+func BuildStakingPoolKey(committeePublicKey string) []byte {
+	return append(StakingPoolPrefix, []byte(committeePublicKey)...)
+}
+
+func BuildStakingPoolDelegationPrefix(committeePublicKey string) []byte {
+	return append(StakingPoolDelegationPrefix, []byte(committeePublicKey+"-")...)
+}
+
+func BuildStakingPoolDelegationKey(committeePublicKey string, delegatorAddressStr string) []byte {
+	return append(BuildStakingPoolDelegationPrefix(committeePublicKey), []byte(delegatorAddressStr)...)
+}
+
+func BuildStakingPoolStatusKey(txReqID []byte) []byte {
+	return append(StakingPoolStatusPrefix, txReqID...)
+}
+
+func (db *db) StoreStakingPool(
+	committeePublicKey string,
+	stakingPoolBytes []byte,
+) error {
+	key := BuildStakingPoolKey(committeePublicKey)
+	err := db.putBeaconState(key, stakingPoolBytes)
+	if err != nil {
+		return database.NewDatabaseError(database.StoreStakingPoolError, errors.Wrap(err, "db.lvdb.put"))
+	}
+	return nil
+}
+
+func (db *db) GetStakingPool(
+	committeePublicKey string,
+) ([]byte, error) {
+	key := BuildStakingPoolKey(committeePublicKey)
+	stakingPoolBytes, dbErr := db.lvdb.Get(key, nil)
+	if dbErr != nil && dbErr != lvdberr.ErrNotFound {
+		return []byte{}, database.NewDatabaseError(database.GetStakingPoolError, dbErr)
+	}
+	return stakingPoolBytes, nil
+}
+
+func (db *db) GetAllStakingPools() ([][]byte, error) {
+	values := [][]byte{}
+	iter := db.lvdb.NewIterator(util.BytesPrefix(StakingPoolPrefix), nil)
+	for iter.Next() {
+		value := iter.Value()
+		valueBytes := make([]byte, len(value))
+		copy(valueBytes, value)
+		values = append(values, valueBytes)
+	}
+	iter.Release()
+	err := iter.Error()
+	if err != nil && err != lvdberr.ErrNotFound {
+		return values, database.NewDatabaseError(database.GetStakingPoolError, err)
+	}
+	return values, nil
+}
+
+func (db *db) StoreStakingPoolDelegation(
+	committeePublicKey string,
+	delegatorAddressStr string,
+	amount uint64,
+) error {
+	key := BuildStakingPoolDelegationKey(committeePublicKey, delegatorAddressStr)
+	if amount == 0 {
+		err := db.deleteBeaconState(key)
+		if err != nil {
+			return database.NewDatabaseError(database.StoreStakingPoolDelegationError, errors.Wrap(err, "db.lvdb.del"))
+		}
+		return nil
+	}
+	buf := make([]byte, binary.MaxVarintLen64)
+	binary.LittleEndian.PutUint64(buf, amount)
+	err := db.putBeaconState(key, buf)
+	if err != nil {
+		return database.NewDatabaseError(database.StoreStakingPoolDelegationError, errors.Wrap(err, "db.lvdb.put"))
+	}
+	return nil
+}
+
+func (db *db) GetStakingPoolDelegations(
+	committeePublicKey string,
+) (map[string]uint64, error) {
+	delegations := map[string]uint64{}
+	prefix := BuildStakingPoolDelegationPrefix(committeePublicKey)
+	iter := db.lvdb.NewIterator(util.BytesPrefix(prefix), nil)
+	for iter.Next() {
+		delegatorAddressStr := strings.TrimPrefix(string(iter.Key()), string(prefix))
+		delegations[delegatorAddressStr] = binary.LittleEndian.Uint64(iter.Value())
+	}
+	iter.Release()
+	err := iter.Error()
+	if err != nil && err != lvdberr.ErrNotFound {
+		return delegations, database.NewDatabaseError(database.GetStakingPoolDelegationError, err)
+	}
+	return delegations, nil
+}
+
+func (db *db) TrackStakingPoolStatus(
+	txReqID []byte,
+	status byte,
+) error {
+	key := BuildStakingPoolStatusKey(txReqID)
+	err := db.Put(key, []byte{status})
+	if err != nil {
+		return database.NewDatabaseError(database.TrackStakingPoolStatusError, errors.Wrap(err, "db.lvdb.put"))
+	}
+	return nil
+}
+
+func (db *db) GetStakingPoolStatus(
+	txReqID []byte,
+) (byte, error) {
+	key := BuildStakingPoolStatusKey(txReqID)
+	statusBytes, dbErr := db.lvdb.Get(key, nil)
+	if dbErr != nil && dbErr != lvdberr.ErrNotFound {
+		return common.StakingPoolNotFoundStatus, database.NewDatabaseError(database.GetStakingPoolStatusError, dbErr)
+	}
+	if len(statusBytes) == 0 {
+		return common.StakingPoolNotFoundStatus, nil
+	}
+	return statusBytes[0], nil
+}
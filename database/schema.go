@@ -0,0 +1,85 @@
+package database
+
+import (
+	"sort"
+
+	"github.com/pkg/errors"
+)
+
+// schema version of data stored in db, db without version key is SchemaVersionJSONBlock
+const (
+	SchemaVersionJSONBlock       = 1
+	SchemaVersionBinaryBlock     = 2
+	SchemaVersionPDEStateJournal = 3
+	SchemaVersionBinaryBestState = 4
+	CurrentSchemaVersion         = SchemaVersionBinaryBestState
+)
+
+// StorageMarshaler is implemented by values which have their own encoding in db,
+// other values passed to Store* functions are stored as json
+type StorageMarshaler interface {
+	MarshalStorage() ([]byte, error)
+}
+
+// MigrationProgress is called by migration with number of records migrated so far
+type MigrationProgress func(version int, done uint64)
+
+// Migration upgrades db from Version-1 to Version, it must be safe to run again after being interrupted
+type Migration struct {
+	Version     int
+	Description string
+	Migrate     func(db DatabaseInterface, progress func(done uint64)) error
+}
+
+// PendingMigrations return migrations which are needed to upgrade db to targetVersion, sorted by version
+func PendingMigrations(db DatabaseInterface, migrations []Migration, targetVersion int) ([]Migration, error) {
+	version, err := db.GetSchemaVersion()
+	if err != nil {
+		return nil, err
+	}
+	if version > targetVersion {
+		return nil, NewDatabaseError(MigrationError, errors.Errorf("db schema version %+v is newer than supported version %+v", version, targetVersion))
+	}
+	pending := []Migration{}
+	for _, migration := range migrations {
+		if migration.Version > version && migration.Version <= targetVersion {
+			pending = append(pending, migration)
+		}
+	}
+	sort.Slice(pending, func(i, j int) bool {
+		return pending[i].Version < pending[j].Version
+	})
+	for i, migration := range pending {
+		if migration.Version != version+i+1 {
+			return nil, NewDatabaseError(MigrationError, errors.Errorf("missing migration to schema version %+v", version+i+1))
+		}
+	}
+	if len(pending) == 0 && version < targetVersion {
+		return nil, NewDatabaseError(MigrationError, errors.Errorf("missing migration to schema version %+v", version+1))
+	}
+	return pending, nil
+}
+
+// RunMigrations upgrade db to targetVersion, schema version is stored after each migration
+// so an interrupted upgrade continues from the last finished migration
+func RunMigrations(db DatabaseInterface, migrations []Migration, targetVersion int, progress MigrationProgress) error {
+	pending, err := PendingMigrations(db, migrations, targetVersion)
+	if err != nil {
+		return err
+	}
+	for _, migration := range pending {
+		version := migration.Version
+		err := migration.Migrate(db, func(done uint64) {
+			if progress != nil {
+				progress(version, done)
+			}
+		})
+		if err != nil {
+			return NewDatabaseError(MigrationError, errors.Wrapf(err, "migrate to schema version %+v", version))
+		}
+		if err := db.StoreSchemaVersion(version); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -10,6 +10,7 @@ import (
 	"runtime/debug"
 	"strconv"
 
+	"github.com/incognitochain/incognito-chain/blockchain"
 	"github.com/incognitochain/incognito-chain/common"
 
 	"net/http"
@@ -66,6 +67,17 @@ func mainMaster(serverChan chan<- *Server) error {
 		Logger.log.Error(err)
 		panic(err)
 	}
+	// Upgrade db schema, stored blocks can be read in both old and new encoding so node can run while it is upgraded
+	if cfg.BackgroundDBMigration {
+		go func() {
+			if err := migrateDatabase(db); err != nil {
+				Logger.log.Error(err)
+			}
+		}()
+	} else if err := migrateDatabase(db); err != nil {
+		Logger.log.Error("could not upgrade database schema")
+		return err
+	}
 	// Create db for mempool and use it
 	dbmp, err := databasemp.Open("leveldbmempool", filepath.Join(cfg.DataDir, cfg.DatabaseMempoolDir))
 	if err != nil {
@@ -88,12 +100,28 @@ func mainMaster(serverChan chan<- *Server) error {
 			temp := byte(cfg.WalletShardID)
 			walletConf.ShardID = &temp
 		}
+		walletConf.CoinSelection = cfg.CoinSelection
+		if cfg.WalletConsolidateMinCoins > 0 {
+			walletConf.Consolidation = &wallet.ConsolidationPolicy{
+				MinCoins:     cfg.WalletConsolidateMinCoins,
+				MaxCoinValue: cfg.WalletConsolidateMaxValue,
+				Interval:     cfg.WalletConsolidateInterval,
+			}
+		}
 		walletObj.SetConfig(&walletConf)
 		err = walletObj.LoadWallet(cfg.WalletPassphrase)
 		if err != nil {
 			if cfg.WalletAutoInit {
 				Logger.log.Critical("\n **** Auto init wallet flag is TRUE ****\n")
-				walletObj.Init(cfg.WalletPassphrase, 0, cfg.WalletName)
+				if cfg.WalletMnemonic != "" {
+					err = walletObj.InitFromMnemonic(cfg.WalletMnemonic, cfg.WalletPassphrase, cfg.WalletName)
+					if err != nil {
+						Logger.log.Criticalf("Can not restore wallet from mnemonic: %+v", err)
+						return err
+					}
+				} else {
+					walletObj.Init(cfg.WalletPassphrase, 0, cfg.WalletName)
+				}
 				walletObj.Save(cfg.WalletPassphrase)
 			} else {
 				// write log and exit when can not load wallet
@@ -179,3 +207,16 @@ func main() {
 		os.Exit(common.ExitByOs)
 	}
 }
+
+func migrateDatabase(db database.DatabaseInterface) error {
+	migrations, err := database.PendingMigrations(db, blockchain.StorageMigrations(), database.CurrentSchemaVersion)
+	if err != nil {
+		return err
+	}
+	for _, migration := range migrations {
+		Logger.log.Infof("Database migration to schema version %+v: %+v", migration.Version, migration.Description)
+	}
+	return database.RunMigrations(db, migrations, database.CurrentSchemaVersion, func(version int, done uint64) {
+		Logger.log.Infof("Database migration to schema version %+v: %+v records done", version, done)
+	})
+}
@@ -444,7 +444,7 @@ func (crossShardPool *CrossShardPool) findBeaconHeightForCrossShardBlock(beaconH
 				return 0, NewBlockPoolError(FetchBeaconBlockFromDatabaseError, err)
 			}
 			beaconBlock := blockchain.NewBeaconBlock()
-			err = json.Unmarshal(beaconBlockBytes, beaconBlock)
+			err = blockchain.UnmarshalStoredBeaconBlock(beaconBlockBytes, beaconBlock)
 			if err != nil {
 				return 0, NewBlockPoolError(UnmarshalBeaconBlockError, err)
 			}
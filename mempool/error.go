@@ -45,6 +45,8 @@ const (
 	ValidateAggSignatureForCrossShardBlockError
 	DuplicateSerialNumbersHashError
 	CouldNotGetExchangeRateError
+	RejectTooManyAncestorsTx
+	RejectSenderQuotaTx
 )
 
 var ErrCodeMessage = map[int]struct {
@@ -87,6 +89,8 @@ var ErrCodeMessage = map[int]struct {
 	CouldNotGetExchangeRateError:                {-1032, "Could not get the exchange rate error"},
 	RejectSanityTxLocktime:                      {-1033, "Wrong tx locktime"},
 	RejectMetadataWithBlockchainTx:              {-1034, "Reject invalid metadata with blockchain"},
+	RejectTooManyAncestorsTx:                    {-1035, "Reject tx spending output coins of too many pending txs"},
+	RejectSenderQuotaTx:                         {-1036, "Reject tx from sender reaching max number of txs in pool"},
 }
 
 type MempoolTxError struct {
@@ -29,6 +29,7 @@ const (
 	defaultRoleInCommittees  = -1
 	defaultIsTest            = false
 	defaultReplaceFeeRatio   = 1.1
+	maxPendingTxAncestors    = 25
 )
 
 // config is a descriptor containing the memory pool configuration.
@@ -39,7 +40,11 @@ type Config struct {
 	ChainParams       *blockchain.Params
 	FeeEstimator      map[byte]*FeeEstimator // FeeEstimatator provides a feeEstimator. If it is not nil, the mempool records all new transactions it observes into the feeEstimator.
 	TxLifeTime        uint                   // Transaction life time in pool
-	MaxTx             uint64                 //Max transaction pool may have
+	MaxTx             uint64                 //Max transaction pool may have, including txs of staking, bridge and pde lanes
+	StakingLaneMaxTx  uint64                 //Max staking transaction pool may have
+	BridgeLaneMaxTx   uint64                 //Max bridge transaction pool may have
+	PDELaneMaxTx      uint64                 //Max pde transaction pool may have
+	MaxTxPerSender    uint64                 //Max non-privacy transaction pool may have from one sender, 0 is unlimited
 	IsLoadFromMempool bool                   //Reset mempool database when run node
 	PersistMempool    bool
 	RelayShards       []byte
@@ -57,24 +62,31 @@ type TxDesc struct {
 
 type TxPool struct {
 	// The following variables must only be used atomically.
-	config                    Config
-	lastUpdated               int64 // last time pool was updated
-	pool                      map[common.Hash]*TxDesc
-	poolSerialNumbersHashList map[common.Hash][]common.Hash // [txHash] -> list hash serialNumbers of input coin
-	poolSerialNumberHash      map[common.Hash]common.Hash   // [hash from list of serialNumber] -> txHash
-	mtx                       sync.RWMutex
-	poolCandidate             map[common.Hash]string //Candidate List in mempool
-	candidateMtx              sync.RWMutex
-	poolRequestStopStaking    map[common.Hash]string //request stop staking list in mempool
-	requestStopStakingMtx     sync.RWMutex
-	CPendingTxs               chan<- metadata.Transaction // channel to deliver txs to block gen
-	CRemoveTxs                chan<- metadata.Transaction // channel to deliver txs to block gen
-	RoleInCommittees          int                         //Current Role of Node
-	roleMtx                   sync.RWMutex
-	ScanTime                  time.Duration
-	IsBlockGenStarted         bool
-	IsUnlockMempool           bool
-	ReplaceFeeRatio           float64
+	config                      Config
+	lastUpdated                 int64 // last time pool was updated
+	pool                        map[common.Hash]*TxDesc
+	poolSerialNumbersHashList   map[common.Hash][]common.Hash       // [txHash] -> list hash serialNumbers of input coin
+	poolSerialNumberHash        map[common.Hash]common.Hash         // [hash from list of serialNumber] -> txHash
+	poolPendingOutputCoins      map[string][]PendingOutputCoin      // [base58 public key of owner] -> output coins of txs in pool
+	poolPendingCommitments      map[common.Hash]common.Hash         // [hash of tokenID and commitment] -> txHash creating the output coin
+	poolSpentPendingCommitments map[common.Hash]common.Hash         // [hash of tokenID and commitment] -> txHash spending the output coin
+	poolTxParents               map[common.Hash][]common.Hash       // [txHash] -> txHashes in pool whose output coins are spent by tx
+	poolTxChildren              map[common.Hash][]common.Hash       // [txHash] -> txHashes in pool spending output coins of tx
+	poolLaneTxs                 map[string]map[common.Hash]struct{} // [lane] -> txHashes in lane
+	poolSenderTxs               map[common.Hash]uint64              // [hash of public key of input coins] -> number of txs in pool
+	mtx                         sync.RWMutex
+	poolCandidate               map[common.Hash]string //Candidate List in mempool
+	candidateMtx                sync.RWMutex
+	poolRequestStopStaking      map[common.Hash]string //request stop staking list in mempool
+	requestStopStakingMtx       sync.RWMutex
+	CPendingTxs                 chan<- metadata.Transaction // channel to deliver txs to block gen
+	CRemoveTxs                  chan<- metadata.Transaction // channel to deliver txs to block gen
+	RoleInCommittees            int                         //Current Role of Node
+	roleMtx                     sync.RWMutex
+	ScanTime                    time.Duration
+	IsBlockGenStarted           bool
+	IsUnlockMempool             bool
+	ReplaceFeeRatio             float64
 
 	//for testing
 	IsTest       bool
@@ -89,6 +101,13 @@ func (tp *TxPool) Init(cfg *Config) {
 	tp.pool = make(map[common.Hash]*TxDesc)
 	tp.poolSerialNumbersHashList = make(map[common.Hash][]common.Hash)
 	tp.poolSerialNumberHash = make(map[common.Hash]common.Hash)
+	tp.poolPendingOutputCoins = make(map[string][]PendingOutputCoin)
+	tp.poolPendingCommitments = make(map[common.Hash]common.Hash)
+	tp.poolSpentPendingCommitments = make(map[common.Hash]common.Hash)
+	tp.poolTxParents = make(map[common.Hash][]common.Hash)
+	tp.poolTxChildren = make(map[common.Hash][]common.Hash)
+	tp.poolLaneTxs = make(map[string]map[common.Hash]struct{})
+	tp.poolSenderTxs = make(map[common.Hash]uint64)
 	tp.poolCandidate = make(map[common.Hash]string)
 	tp.poolRequestStopStaking = make(map[common.Hash]string)
 	tp.duplicateTxs = make(map[common.Hash]uint64)
@@ -182,6 +201,7 @@ func (tp *TxPool) MonitorPool() {
 		for _, txDesc := range txsToBeRemoved {
 			txHash := *txDesc.Desc.Tx.Hash()
 			startTime := txDesc.StartTime
+			childTxHashes := tp.getChildTxHashes(txHash)
 			tp.removeTx(txDesc.Desc.Tx)
 			tp.TriggerCRemoveTxs(txDesc.Desc.Tx)
 			tp.removeCandidateByTxHash(txHash)
@@ -191,6 +211,7 @@ func (tp *TxPool) MonitorPool() {
 				Logger.log.Errorf("MonitorPool: RemoveTransaction tx hash=%+v with error %+v", txDesc.Desc.Tx.Hash().String(), err)
 				Logger.log.Error(err)
 			}
+			tp.evictDescendants(childTxHashes)
 			txSize := txDesc.Desc.Tx.GetTxActualSize()
 			go metrics.AnalyzeTimeSeriesMetricData(map[string]interface{}{
 				metrics.Measurement:      metrics.TxPoolRemoveAfterLifeTime,
@@ -252,11 +273,15 @@ func (tp *TxPool) MaybeAcceptTransaction(tx metadata.Transaction, beaconHeight i
 		metrics.Tag:              metrics.TxTypeTag,
 		metrics.TagValue:         txType})
 	//==========
-	if uint64(len(tp.pool)) >= tp.config.MaxTx {
-		return nil, nil, NewMempoolTxError(MaxPoolSizeError, errors.New("Pool reach max number of transaction"))
+	evictedTxHash, err := tp.checkPoolCapacity(tx)
+	if err != nil {
+		return nil, nil, err
 	}
 	startAdd := time.Now()
 	hash, txDesc, err := tp.maybeAcceptTransaction(tx, tp.config.PersistMempool, true, beaconHeight)
+	if err == nil && evictedTxHash != nil {
+		tp.evictTxForCapacity(*evictedTxHash)
+	}
 	elapsed := float64(time.Since(startAdd).Seconds())
 	//==========
 	go metrics.AnalyzeTimeSeriesMetricData(map[string]interface{}{
@@ -291,7 +316,8 @@ func (tp *TxPool) MaybeAcceptTransaction(tx metadata.Transaction, beaconHeight i
 	if err != nil {
 		Logger.log.Error(err)
 	} else {
-		if tp.IsBlockGenStarted {
+		// tx spending output coins of other txs in pool is delivered after all of its parents are included in blocks
+		if tp.IsBlockGenStarted && len(tp.poolTxParents[*tx.Hash()]) == 0 {
 			if tp.IsUnlockMempool {
 				go func(tx metadata.Transaction) {
 					tp.CPendingTxs <- tx
@@ -478,6 +504,7 @@ In Param#2: isStore: store transaction to persistence storage only work for tran
 5.1 Check for Replacement or Cancel transaction
 6. Validate data in tx: privacy proof, metadata,...
 7. Validate tx with blockchain: douple spend, ...
+8. Spending output coins of other txs in pool: only with non-privacy proof and limited number of ancestors
 9. Staking Transaction: Check Duplicate stake public key in pool ONLY with staking transaction
 10. RequestStopAutoStaking
 */
@@ -598,10 +625,23 @@ func (tp *TxPool) validateTransaction(tx metadata.Transaction, beaconHeight int6
 			return NewMempoolTxError(RejectDoubleSpendWithMempoolTx, err)
 		}
 	}
+	// Condition 8: check output coins of other txs in pool spent by tx
+	now = time.Now()
+	parentTxHashes := tp.getParentTxHashes(tx)
+	go metrics.AnalyzeTimeSeriesMetricData(map[string]interface{}{
+		metrics.Measurement:      metrics.TxPoolValidationDetails,
+		metrics.MeasurementValue: float64(time.Since(now).Seconds()),
+		metrics.TagValue:         metrics.Condition8,
+		metrics.Tag:              metrics.ValidateConditionTag,
+	})
+	if len(parentTxHashes) > 0 && tp.countAncestors(parentTxHashes) >= maxPendingTxAncestors {
+		return NewMempoolTxError(RejectTooManyAncestorsTx, fmt.Errorf("transaction %+v spends output coins of more than %+v pending txs in chain", txHash.String(), maxPendingTxAncestors))
+	}
+	validationDB := tp.getValidationDatabase(parentTxHashes)
 	// Condition 6: ValidateTransaction tx by it self
 	shardID = common.GetShardIDFromLastByte(tx.GetSenderAddrLastByte())
 	now = time.Now()
-	validated, errValidateTxByItself := tx.ValidateTxByItself(tx.IsPrivacy(), tp.config.DataBase, tp.config.BlockChain, shardID)
+	validated, errValidateTxByItself := tx.ValidateTxByItself(tx.IsPrivacy(), validationDB, tp.config.BlockChain, shardID)
 	go metrics.AnalyzeTimeSeriesMetricData(map[string]interface{}{
 		metrics.Measurement:      metrics.TxPoolValidationDetails,
 		metrics.MeasurementValue: float64(time.Since(now).Seconds()),
@@ -614,7 +654,7 @@ func (tp *TxPool) validateTransaction(tx metadata.Transaction, beaconHeight int6
 
 	// Condition 7: validate tx with data of blockchain
 	now = time.Now()
-	err = tx.ValidateTxWithBlockChain(tp.config.BlockChain, shardID, tp.config.DataBase)
+	err = tx.ValidateTxWithBlockChain(tp.config.BlockChain, shardID, validationDB)
 	go metrics.AnalyzeTimeSeriesMetricData(map[string]interface{}{
 		metrics.Measurement:      metrics.TxPoolValidationDetails,
 		metrics.MeasurementValue: float64(time.Since(now).Seconds()),
@@ -740,9 +780,11 @@ func (tp *TxPool) validateTransactionReplacement(tx metadata.Transaction) (error
 			}
 			if isReplaced {
 				txToBeReplaced := txDescToBeReplaced.Desc.Tx
+				childTxHashes := tp.getChildTxHashes(*txToBeReplaced.Hash())
 				tp.removeTx(txToBeReplaced)
 				tp.TriggerCRemoveTxs(txToBeReplaced)
 				tp.removeRequestStopStakingByTxHash(*txToBeReplaced.Hash())
+				tp.evictDescendants(childTxHashes)
 				// send tx into channel of CRmoveTxs
 				tp.TriggerCRemoveTxs(tx)
 				return nil, true
@@ -791,6 +833,8 @@ func (tp *TxPool) addTx(txD *TxDesc, isStore bool) error {
 	serialNumberListHash := common.HashArrayOfHashArray(serialNumberList)
 	tp.poolSerialNumberHash[serialNumberListHash] = *txD.Desc.Tx.Hash()
 	tp.poolSerialNumbersHashList[*txHash] = serialNumberList
+	tp.addTxDependencies(tx)
+	tp.addTxToLane(tx)
 	atomic.StoreInt64(&tp.lastUpdated, time.Now().Unix())
 	// Record this tx for fee estimation if enabled, apply for normal tx and privacy token tx
 	if tp.config.FeeEstimator != nil {
@@ -900,8 +944,15 @@ func (tp *TxPool) RemoveTx(txs []metadata.Transaction, isInBlock bool) {
 			metrics.TagValue:         metrics.Condition1,
 		})
 		now = time.Now()
+		childTxHashes := tp.getChildTxHashes(*tx.Hash())
 		tp.removeTx(tx)
 		tp.TriggerCRemoveTxs(tx)
+		// output coins of tx in block become spendable in blockchain, otherwise children spend coins which never exist
+		if isInBlock {
+			tp.promoteChildTxs(childTxHashes)
+		} else {
+			tp.evictDescendants(childTxHashes)
+		}
 		go metrics.AnalyzeTimeSeriesMetricData(map[string]interface{}{
 			metrics.Measurement:      metrics.TxPoolRemovedTimeDetails,
 			metrics.MeasurementValue: float64(time.Since(now).Seconds()),
@@ -963,6 +1014,8 @@ func (tp *TxPool) removeTx(tx metadata.Transaction) {
 	//Logger.log.Infof((*tx).Hash().String())
 	if _, exists := tp.pool[*tx.Hash()]; exists {
 		delete(tp.pool, *tx.Hash())
+		tp.removeTxDependencies(tx)
+		tp.removeTxFromLane(tx)
 		atomic.StoreInt64(&tp.lastUpdated, time.Now().Unix())
 	}
 	if _, exists := tp.poolSerialNumbersHashList[*tx.Hash()]; exists {
@@ -1050,7 +1103,10 @@ func (tp *TxPool) RemoveRequestStopStakingList(requestStopStakings []string) {
 func (tp *TxPool) SendTransactionToBlockGen() {
 	tp.mtx.RLock()
 	defer tp.mtx.RUnlock()
-	for _, txdesc := range tp.pool {
+	for txHash, txdesc := range tp.pool {
+		if len(tp.poolTxParents[txHash]) > 0 {
+			continue
+		}
 		tp.CPendingTxs <- txdesc.Desc.Tx
 	}
 	tp.IsUnlockMempool = true
@@ -1082,14 +1138,17 @@ func (tp *TxPool) GetTx(txHash *common.Hash) (metadata.Transaction, error) {
 	return nil, err
 }
 
-// // MiningDescs returns a slice of mining descriptors for all the transactions
-// // in the pool.
+// MiningDescs returns a slice of mining descriptors for all the transactions
+// in the pool. Txs spending output coins of each other are grouped into packages,
+// packages are ordered by aggregate fee and parents are always before their children
 func (tp *TxPool) MiningDescs() []*metadata.TxDesc {
 	tp.mtx.Lock()
 	defer tp.mtx.Unlock()
 	descs := []*metadata.TxDesc{}
-	for _, desc := range tp.pool {
-		descs = append(descs, &desc.Desc)
+	for _, txDescs := range tp.miningPackages() {
+		for _, desc := range txDescs {
+			descs = append(descs, &desc.Desc)
+		}
 	}
 	return descs
 }
@@ -1226,6 +1285,13 @@ func (tp *TxPool) EmptyPool() bool {
 	tp.pool = make(map[common.Hash]*TxDesc)
 	tp.poolSerialNumbersHashList = make(map[common.Hash][]common.Hash)
 	tp.poolSerialNumberHash = make(map[common.Hash]common.Hash)
+	tp.poolPendingOutputCoins = make(map[string][]PendingOutputCoin)
+	tp.poolPendingCommitments = make(map[common.Hash]common.Hash)
+	tp.poolSpentPendingCommitments = make(map[common.Hash]common.Hash)
+	tp.poolTxParents = make(map[common.Hash][]common.Hash)
+	tp.poolTxChildren = make(map[common.Hash][]common.Hash)
+	tp.poolLaneTxs = make(map[string]map[common.Hash]struct{})
+	tp.poolSenderTxs = make(map[common.Hash]uint64)
 	tp.poolCandidate = make(map[common.Hash]string)
 	tp.poolRequestStopStaking = make(map[common.Hash]string)
 	if len(tp.pool) == 0 && len(tp.poolSerialNumbersHashList) == 0 && len(tp.poolSerialNumberHash) == 0 && len(tp.poolCandidate) == 0 && len(tp.poolRequestStopStaking) == 0 {
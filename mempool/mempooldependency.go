@@ -0,0 +1,388 @@
+package mempool
+
+import (
+	"bytes"
+	"sort"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/common/base58"
+	"github.com/incognitochain/incognito-chain/database"
+	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/incognitochain/incognito-chain/privacy"
+	zkp "github.com/incognitochain/incognito-chain/privacy/zeroknowledge"
+	"github.com/incognitochain/incognito-chain/transaction"
+)
+
+// PendingOutputCoin is an output coin of tx in pool which is not included in any block yet
+type PendingOutputCoin struct {
+	TxHash     common.Hash
+	TokenID    common.Hash
+	OutputCoin *privacy.OutputCoin
+}
+
+type txProofWithTokenID struct {
+	tokenID   common.Hash
+	proof     *zkp.PaymentProof
+	isPrivacy bool
+}
+
+// getProofsWithTokenID return proof of PRV and proof of privacy token (if any) of tx
+func getProofsWithTokenID(tx metadata.Transaction) []txProofWithTokenID {
+	proofs := []txProofWithTokenID{}
+	if tx.GetProof() != nil {
+		proofs = append(proofs, txProofWithTokenID{
+			tokenID:   common.PRVCoinID,
+			proof:     tx.GetProof(),
+			isPrivacy: tx.IsPrivacy(),
+		})
+	}
+	if tx.GetType() == common.TxCustomTokenPrivacyType {
+		customTokenPrivacyTx, ok := tx.(*transaction.TxCustomTokenPrivacy)
+		if ok && customTokenPrivacyTx.TxPrivacyTokenData.TxNormal.GetProof() != nil {
+			proofs = append(proofs, txProofWithTokenID{
+				tokenID:   customTokenPrivacyTx.TxPrivacyTokenData.PropertyID,
+				proof:     customTokenPrivacyTx.TxPrivacyTokenData.TxNormal.GetProof(),
+				isPrivacy: customTokenPrivacyTx.TxPrivacyTokenData.TxNormal.IsPrivacy(),
+			})
+		}
+	}
+	return proofs
+}
+
+func hashPendingCommitment(tokenID common.Hash, commitment []byte) common.Hash {
+	return common.HashH(append(tokenID[:], commitment...))
+}
+
+// collectPendingOutputCoins return output coins of tx which could be spent before tx is included in a block,
+// only outputs of non-privacy proof are tracked because their commitments and values are revealed.
+// Outputs of privacy proof are not tracked: their values are hidden so they can't be listed for owner,
+// and they could only be spent by a privacy proof whose one-of-many proof refers to commitment indices in blockchain,
+// which don't exist until the tx is included in a block
+func collectPendingOutputCoins(tx metadata.Transaction) []PendingOutputCoin {
+	outputCoins := []PendingOutputCoin{}
+	for _, proofWithTokenID := range getProofsWithTokenID(tx) {
+		if proofWithTokenID.isPrivacy {
+			continue
+		}
+		for _, outputCoin := range proofWithTokenID.proof.GetOutputCoins() {
+			outputCoins = append(outputCoins, PendingOutputCoin{
+				TxHash:     *tx.Hash(),
+				TokenID:    proofWithTokenID.tokenID,
+				OutputCoin: outputCoin,
+			})
+		}
+	}
+	return outputCoins
+}
+
+// collectInputCommitmentHashes return hash of commitments spent by non-privacy proofs of tx,
+// input coins of privacy proof are hidden in commitments of blockchain so they can't spend pending outputs
+func collectInputCommitmentHashes(tx metadata.Transaction) []common.Hash {
+	commitmentHashes := []common.Hash{}
+	for _, proofWithTokenID := range getProofsWithTokenID(tx) {
+		if proofWithTokenID.isPrivacy {
+			continue
+		}
+		for _, inputCoin := range proofWithTokenID.proof.GetInputCoins() {
+			if inputCoin.CoinDetails == nil || inputCoin.CoinDetails.GetCoinCommitment() == nil {
+				continue
+			}
+			commitmentHashes = append(commitmentHashes, hashPendingCommitment(proofWithTokenID.tokenID, inputCoin.CoinDetails.GetCoinCommitment().ToBytesS()))
+		}
+	}
+	return commitmentHashes
+}
+
+// getParentTxHashes return txs in pool whose output coins are spent by tx
+func (tp *TxPool) getParentTxHashes(tx metadata.Transaction) []common.Hash {
+	parentTxHashes := []common.Hash{}
+	found := make(map[common.Hash]bool)
+	for _, commitmentHash := range collectInputCommitmentHashes(tx) {
+		parentTxHash, ok := tp.poolPendingCommitments[commitmentHash]
+		if !ok || found[parentTxHash] {
+			continue
+		}
+		found[parentTxHash] = true
+		parentTxHashes = append(parentTxHashes, parentTxHash)
+	}
+	return parentTxHashes
+}
+
+func (tp *TxPool) getChildTxHashes(txHash common.Hash) []common.Hash {
+	return append([]common.Hash{}, tp.poolTxChildren[txHash]...)
+}
+
+// countAncestors return number of txs in pool which must be included in blocks before tx having these parents
+func (tp *TxPool) countAncestors(parentTxHashes []common.Hash) int {
+	visited := make(map[common.Hash]bool)
+	queue := append([]common.Hash{}, parentTxHashes...)
+	for len(queue) > 0 {
+		txHash := queue[0]
+		queue = queue[1:]
+		if visited[txHash] {
+			continue
+		}
+		visited[txHash] = true
+		queue = append(queue, tp.poolTxParents[txHash]...)
+	}
+	return len(visited)
+}
+
+// addTxDependencies index pending output coins of tx and link tx with its parents in pool
+func (tp *TxPool) addTxDependencies(tx metadata.Transaction) {
+	txHash := *tx.Hash()
+	for _, pendingOutputCoin := range collectPendingOutputCoins(tx) {
+		commitment := pendingOutputCoin.OutputCoin.CoinDetails.GetCoinCommitment().ToBytesS()
+		tp.poolPendingCommitments[hashPendingCommitment(pendingOutputCoin.TokenID, commitment)] = txHash
+		owner := base58.Base58Check{}.Encode(pendingOutputCoin.OutputCoin.CoinDetails.GetPublicKey().ToBytesS(), common.ZeroByte)
+		tp.poolPendingOutputCoins[owner] = append(tp.poolPendingOutputCoins[owner], pendingOutputCoin)
+	}
+	for _, commitmentHash := range collectInputCommitmentHashes(tx) {
+		if _, ok := tp.poolPendingCommitments[commitmentHash]; ok {
+			tp.poolSpentPendingCommitments[commitmentHash] = txHash
+		}
+	}
+	parentTxHashes := tp.getParentTxHashes(tx)
+	if len(parentTxHashes) == 0 {
+		return
+	}
+	tp.poolTxParents[txHash] = parentTxHashes
+	for _, parentTxHash := range parentTxHashes {
+		tp.poolTxChildren[parentTxHash] = append(tp.poolTxChildren[parentTxHash], txHash)
+	}
+}
+
+// removeTxDependencies remove pending output coins of tx and unlink tx with its parents and children
+func (tp *TxPool) removeTxDependencies(tx metadata.Transaction) {
+	txHash := *tx.Hash()
+	for _, pendingOutputCoin := range collectPendingOutputCoins(tx) {
+		commitment := pendingOutputCoin.OutputCoin.CoinDetails.GetCoinCommitment().ToBytesS()
+		commitmentHash := hashPendingCommitment(pendingOutputCoin.TokenID, commitment)
+		if tp.poolPendingCommitments[commitmentHash] == txHash {
+			delete(tp.poolPendingCommitments, commitmentHash)
+			delete(tp.poolSpentPendingCommitments, commitmentHash)
+		}
+		owner := base58.Base58Check{}.Encode(pendingOutputCoin.OutputCoin.CoinDetails.GetPublicKey().ToBytesS(), common.ZeroByte)
+		remainOutputCoins := []PendingOutputCoin{}
+		for _, outputCoin := range tp.poolPendingOutputCoins[owner] {
+			if !outputCoin.TxHash.IsEqual(&txHash) {
+				remainOutputCoins = append(remainOutputCoins, outputCoin)
+			}
+		}
+		if len(remainOutputCoins) == 0 {
+			delete(tp.poolPendingOutputCoins, owner)
+		} else {
+			tp.poolPendingOutputCoins[owner] = remainOutputCoins
+		}
+	}
+	for _, commitmentHash := range collectInputCommitmentHashes(tx) {
+		if tp.poolSpentPendingCommitments[commitmentHash] == txHash {
+			delete(tp.poolSpentPendingCommitments, commitmentHash)
+		}
+	}
+	for _, parentTxHash := range tp.poolTxParents[txHash] {
+		tp.poolTxChildren[parentTxHash] = removeHashFromList(tp.poolTxChildren[parentTxHash], txHash)
+		if len(tp.poolTxChildren[parentTxHash]) == 0 {
+			delete(tp.poolTxChildren, parentTxHash)
+		}
+	}
+	delete(tp.poolTxParents, txHash)
+	for _, childTxHash := range tp.poolTxChildren[txHash] {
+		tp.poolTxParents[childTxHash] = removeHashFromList(tp.poolTxParents[childTxHash], txHash)
+		if len(tp.poolTxParents[childTxHash]) == 0 {
+			delete(tp.poolTxParents, childTxHash)
+		}
+	}
+	delete(tp.poolTxChildren, txHash)
+}
+
+func removeHashFromList(hashes []common.Hash, hash common.Hash) []common.Hash {
+	result := []common.Hash{}
+	for _, h := range hashes {
+		if !h.IsEqual(&hash) {
+			result = append(result, h)
+		}
+	}
+	return result
+}
+
+// evictDescendants remove txs and all of their descendants out of pool,
+// they spend output coins which will never be included in any block
+func (tp *TxPool) evictDescendants(txHashes []common.Hash) {
+	for _, txHash := range txHashes {
+		txDesc, ok := tp.pool[txHash]
+		if !ok {
+			continue
+		}
+		tx := txDesc.Desc.Tx
+		childTxHashes := tp.getChildTxHashes(txHash)
+		Logger.log.Infof("Evict tx %+v and its descendants from pool", txHash.String())
+		if tp.config.PersistMempool {
+			err := tp.removeTransactionFromDatabaseMP(&txHash)
+			if err != nil {
+				Logger.log.Error(err)
+			}
+		}
+		tp.removeTx(tx)
+		tp.TriggerCRemoveTxs(tx)
+		tp.removeCandidateByTxHash(txHash)
+		tp.removeRequestStopStakingByTxHash(txHash)
+		tp.evictDescendants(childTxHashes)
+	}
+}
+
+// promoteChildTxs deliver txs to block generator once all of their parents are included in blocks
+func (tp *TxPool) promoteChildTxs(txHashes []common.Hash) {
+	for _, txHash := range txHashes {
+		txDesc, ok := tp.pool[txHash]
+		if !ok || len(tp.poolTxParents[txHash]) > 0 {
+			continue
+		}
+		if tp.IsBlockGenStarted && tp.IsUnlockMempool {
+			go func(tx metadata.Transaction) {
+				tp.CPendingTxs <- tx
+			}(txDesc.Desc.Tx)
+		}
+	}
+}
+
+// GetPendingOutputCoins return output coins of txs in pool which belong to public key
+// and are not spent by any other tx in pool yet
+func (tp *TxPool) GetPendingOutputCoins(publicKey []byte, tokenID common.Hash) []PendingOutputCoin {
+	tp.mtx.RLock()
+	defer tp.mtx.RUnlock()
+	owner := base58.Base58Check{}.Encode(publicKey, common.ZeroByte)
+	result := []PendingOutputCoin{}
+	for _, pendingOutputCoin := range tp.poolPendingOutputCoins[owner] {
+		if !pendingOutputCoin.TokenID.IsEqual(&tokenID) {
+			continue
+		}
+		if !bytes.Equal(pendingOutputCoin.OutputCoin.CoinDetails.GetPublicKey().ToBytesS(), publicKey) {
+			continue
+		}
+		commitment := pendingOutputCoin.OutputCoin.CoinDetails.GetCoinCommitment().ToBytesS()
+		if _, spent := tp.poolSpentPendingCommitments[hashPendingCommitment(tokenID, commitment)]; spent {
+			continue
+		}
+		result = append(result, pendingOutputCoin)
+	}
+	return result
+}
+
+// miningPackages group txs in pool into packages of txs linked by spending output coins of each other,
+// txs of a package are in topological order and packages are sorted by aggregate fee
+func (tp *TxPool) miningPackages() [][]*TxDesc {
+	visited := make(map[common.Hash]bool)
+	packages := [][]*TxDesc{}
+	packageFees := []uint64{}
+	txHashes := []common.Hash{}
+	for txHash := range tp.pool {
+		txHashes = append(txHashes, txHash)
+	}
+	sort.Slice(txHashes, func(i, j int) bool {
+		return txHashes[i].String() < txHashes[j].String()
+	})
+	for _, txHash := range txHashes {
+		if visited[txHash] {
+			continue
+		}
+		// collect all txs connected with this tx
+		members := []common.Hash{}
+		queue := []common.Hash{txHash}
+		for len(queue) > 0 {
+			memberHash := queue[0]
+			queue = queue[1:]
+			if visited[memberHash] {
+				continue
+			}
+			if _, ok := tp.pool[memberHash]; !ok {
+				continue
+			}
+			visited[memberHash] = true
+			members = append(members, memberHash)
+			queue = append(queue, tp.poolTxParents[memberHash]...)
+			queue = append(queue, tp.poolTxChildren[memberHash]...)
+		}
+		txDescs, fee := tp.sortPackageTopologically(members)
+		packages = append(packages, txDescs)
+		packageFees = append(packageFees, fee)
+	}
+	indices := make([]int, len(packages))
+	for i := range indices {
+		indices[i] = i
+	}
+	sort.SliceStable(indices, func(i, j int) bool {
+		return packageFees[indices[i]] > packageFees[indices[j]]
+	})
+	result := make([][]*TxDesc, len(packages))
+	for i, index := range indices {
+		result[i] = packages[index]
+	}
+	return result
+}
+
+// sortPackageTopologically order txs so that parents are always before their children
+func (tp *TxPool) sortPackageTopologically(members []common.Hash) ([]*TxDesc, uint64) {
+	inPackage := make(map[common.Hash]bool)
+	for _, txHash := range members {
+		inPackage[txHash] = true
+	}
+	numberOfParents := make(map[common.Hash]int)
+	ready := []common.Hash{}
+	for _, txHash := range members {
+		for _, parentTxHash := range tp.poolTxParents[txHash] {
+			if inPackage[parentTxHash] {
+				numberOfParents[txHash]++
+			}
+		}
+		if numberOfParents[txHash] == 0 {
+			ready = append(ready, txHash)
+		}
+	}
+	txDescs := []*TxDesc{}
+	fee := uint64(0)
+	for len(ready) > 0 {
+		sort.Slice(ready, func(i, j int) bool {
+			return ready[i].String() < ready[j].String()
+		})
+		txHash := ready[0]
+		ready = ready[1:]
+		txDesc := tp.pool[txHash]
+		txDescs = append(txDescs, txDesc)
+		fee += txDesc.Desc.Fee
+		for _, childTxHash := range tp.poolTxChildren[txHash] {
+			if !inPackage[childTxHash] {
+				continue
+			}
+			numberOfParents[childTxHash]--
+			if numberOfParents[childTxHash] == 0 {
+				ready = append(ready, childTxHash)
+			}
+		}
+	}
+	return txDescs, fee
+}
+
+// pendingCommitmentDatabase let tx spending output coins of its parents in pool pass commitment existence check
+type pendingCommitmentDatabase struct {
+	database.DatabaseInterface
+	pendingCommitments map[common.Hash]common.Hash
+}
+
+func (db pendingCommitmentDatabase) HasCommitment(tokenID common.Hash, commitment []byte, shardID byte) (bool, error) {
+	if _, ok := db.pendingCommitments[hashPendingCommitment(tokenID, commitment)]; ok {
+		return true, nil
+	}
+	return db.DatabaseInterface.HasCommitment(tokenID, commitment, shardID)
+}
+
+// getValidationDatabase return database used to validate tx, it also contains output coins of parents in pool
+func (tp *TxPool) getValidationDatabase(parentTxHashes []common.Hash) database.DatabaseInterface {
+	if len(parentTxHashes) == 0 {
+		return tp.config.DataBase
+	}
+	return pendingCommitmentDatabase{
+		DatabaseInterface:  tp.config.DataBase,
+		pendingCommitments: tp.poolPendingCommitments,
+	}
+}
@@ -0,0 +1,162 @@
+package mempool
+
+import (
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/incognitochain/incognito-chain/privacy"
+	zkp "github.com/incognitochain/incognito-chain/privacy/zeroknowledge"
+	"github.com/incognitochain/incognito-chain/pubsub"
+	"github.com/incognitochain/incognito-chain/transaction"
+)
+
+func newTestDependencyPool() *TxPool {
+	txPool := &TxPool{}
+	txPool.Init(&Config{PubSubManager: pubsub.NewPubSubManager()})
+	return txPool
+}
+
+func newTestCoin(publicKey *privacy.Point, value uint64) *privacy.Coin {
+	coin := new(privacy.Coin)
+	coin.SetPublicKey(publicKey)
+	coin.SetCoinCommitment(privacy.RandomPoint())
+	coin.SetSNDerivator(privacy.RandomScalar())
+	coin.SetRandomness(privacy.RandomScalar())
+	coin.SetSerialNumber(privacy.RandomPoint())
+	coin.SetValue(value)
+	return coin
+}
+
+// newTestNoPrivacyTx build a non-privacy tx spending input coins and creating output coins
+func newTestNoPrivacyTx(fee uint64, lockTime int64, inputCoins []*privacy.Coin, outputCoins []*privacy.Coin) *transaction.Tx {
+	proof := &zkp.PaymentProof{}
+	proof.Init()
+	inputs := []*privacy.InputCoin{}
+	for _, coin := range inputCoins {
+		inputs = append(inputs, &privacy.InputCoin{CoinDetails: coin})
+	}
+	outputs := []*privacy.OutputCoin{}
+	for _, coin := range outputCoins {
+		outputs = append(outputs, &privacy.OutputCoin{CoinDetails: coin})
+	}
+	proof.SetInputCoins(inputs)
+	proof.SetOutputCoins(outputs)
+	return &transaction.Tx{
+		Type:     common.TxNormalType,
+		Fee:      fee,
+		LockTime: lockTime,
+		Proof:    proof,
+	}
+}
+
+func addTestDependencyTx(txPool *TxPool, tx *transaction.Tx) {
+	txPool.addTx(&TxDesc{Desc: metadata.TxDesc{Tx: tx, Fee: tx.Fee}}, false)
+}
+
+// buildTestTxChain add parent -> child -> grandchild, each tx spends an output coin of the previous one
+func buildTestTxChain(t *testing.T, txPool *TxPool) ([]*transaction.Tx, *privacy.Point) {
+	owner := privacy.RandomPoint()
+	parentOutput := newTestCoin(owner, 100)
+	parent := newTestNoPrivacyTx(1, 1, []*privacy.Coin{newTestCoin(owner, 101)}, []*privacy.Coin{parentOutput, newTestCoin(privacy.RandomPoint(), 1)})
+	childOutput := newTestCoin(owner, 99)
+	child := newTestNoPrivacyTx(1, 2, []*privacy.Coin{parentOutput}, []*privacy.Coin{childOutput})
+	grandchild := newTestNoPrivacyTx(1, 3, []*privacy.Coin{childOutput}, []*privacy.Coin{newTestCoin(owner, 98)})
+	for _, tx := range []*transaction.Tx{parent, child, grandchild} {
+		addTestDependencyTx(txPool, tx)
+	}
+	if len(txPool.pool) != 3 {
+		t.Fatalf("expect 3 txs in pool but get %+v", len(txPool.pool))
+	}
+	return []*transaction.Tx{parent, child, grandchild}, owner
+}
+
+func TestTxPoolTxChaining(t *testing.T) {
+	txPool := newTestDependencyPool()
+	txs, owner := buildTestTxChain(t, txPool)
+	parent, child, grandchild := txs[0], txs[1], txs[2]
+
+	if parents := txPool.poolTxParents[*child.Hash()]; len(parents) != 1 || !parents[0].IsEqual(parent.Hash()) {
+		t.Errorf("expect parent of child is %+v but get %+v", parent.Hash().String(), parents)
+	}
+	if children := txPool.getChildTxHashes(*child.Hash()); len(children) != 1 || !children[0].IsEqual(grandchild.Hash()) {
+		t.Errorf("expect child of child is %+v but get %+v", grandchild.Hash().String(), children)
+	}
+	if len(txPool.poolTxParents[*parent.Hash()]) != 0 {
+		t.Errorf("expect parent has no parents in pool but get %+v", txPool.poolTxParents[*parent.Hash()])
+	}
+	if ancestors := txPool.countAncestors(txPool.getParentTxHashes(grandchild)); ancestors != 2 {
+		t.Errorf("expect 2 ancestors of grandchild but get %+v", ancestors)
+	}
+	// only output coin of grandchild is not spent by any tx in pool
+	pendingOutputCoins := txPool.GetPendingOutputCoins(owner.ToBytesS(), common.PRVCoinID)
+	if len(pendingOutputCoins) != 1 || !pendingOutputCoins[0].TxHash.IsEqual(grandchild.Hash()) {
+		t.Errorf("expect 1 pending output coin of grandchild but get %+v", pendingOutputCoins)
+	}
+	if len(txPool.GetPendingOutputCoins(owner.ToBytesS(), common.Hash{1})) != 0 {
+		t.Errorf("expect no pending output coin of other tokens")
+	}
+
+	// package of chained txs is ordered by aggregate fee, parents are before children
+	single := newTestNoPrivacyTx(2, 4, []*privacy.Coin{newTestCoin(privacy.RandomPoint(), 10)}, []*privacy.Coin{newTestCoin(privacy.RandomPoint(), 8)})
+	addTestDependencyTx(txPool, single)
+	descs := txPool.MiningDescs()
+	expected := []*transaction.Tx{parent, child, grandchild, single}
+	if len(descs) != len(expected) {
+		t.Fatalf("expect %+v mining descs but get %+v", len(expected), len(descs))
+	}
+	for i, tx := range expected {
+		if !descs[i].Tx.Hash().IsEqual(tx.Hash()) {
+			t.Errorf("expect tx %+v at %+v but get %+v", tx.Hash().String(), i, descs[i].Tx.Hash().String())
+		}
+	}
+}
+
+func TestTxPoolEvictDescendants(t *testing.T) {
+	txPool := newTestDependencyPool()
+	txs, owner := buildTestTxChain(t, txPool)
+
+	// children spend output coins which will never be in blockchain after parent is removed
+	txPool.RemoveTx([]metadata.Transaction{txs[0]}, false)
+	if len(txPool.pool) != 0 {
+		t.Errorf("expect descendants of parent are evicted but get %+v txs in pool", len(txPool.pool))
+	}
+	if len(txPool.poolTxParents) != 0 || len(txPool.poolTxChildren) != 0 || len(txPool.poolPendingCommitments) != 0 || len(txPool.poolSpentPendingCommitments) != 0 {
+		t.Errorf("expect dependencies are removed but get parents %+v, children %+v", txPool.poolTxParents, txPool.poolTxChildren)
+	}
+	if len(txPool.GetPendingOutputCoins(owner.ToBytesS(), common.PRVCoinID)) != 0 {
+		t.Errorf("expect no pending output coins after eviction")
+	}
+}
+
+func TestTxPoolRemoveTxInBlock(t *testing.T) {
+	txPool := newTestDependencyPool()
+	cPendingTxs := make(chan metadata.Transaction, 10)
+	txPool.CPendingTxs = cPendingTxs
+	txPool.IsBlockGenStarted = true
+	txPool.IsUnlockMempool = true
+	txs, _ := buildTestTxChain(t, txPool)
+	parent, child, grandchild := txs[0], txs[1], txs[2]
+
+	// output coins of parent are in blockchain once parent is included in a block
+	txPool.RemoveTx([]metadata.Transaction{parent}, true)
+	if len(txPool.pool) != 2 {
+		t.Fatalf("expect children of parent stay in pool but get %+v txs", len(txPool.pool))
+	}
+	if len(txPool.poolTxParents[*child.Hash()]) != 0 {
+		t.Errorf("expect child has no parents in pool but get %+v", txPool.poolTxParents[*child.Hash()])
+	}
+	if parents := txPool.poolTxParents[*grandchild.Hash()]; len(parents) != 1 || !parents[0].IsEqual(child.Hash()) {
+		t.Errorf("expect grandchild still waits for child but get %+v", parents)
+	}
+	// child is delivered to block generator, grandchild waits for child
+	promotedTx := <-cPendingTxs
+	if !promotedTx.Hash().IsEqual(child.Hash()) {
+		t.Errorf("expect child is delivered to block generator but get %+v", promotedTx.Hash().String())
+	}
+	select {
+	case tx := <-cPendingTxs:
+		t.Errorf("expect no other tx is delivered but get %+v", tx.Hash().String())
+	default:
+	}
+}
@@ -0,0 +1,190 @@
+package mempool
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/metadata"
+)
+
+// lanes split capacity of pool, so a flood of plain transfers can't keep staking, bridge or pde txs out of pool
+const (
+	TransferLane = "transfer"
+	StakingLane  = "staking"
+	BridgeLane   = "bridge"
+	PDELane      = "pde"
+)
+
+// LaneInfo is occupancy of a lane in pool
+type LaneInfo struct {
+	Name     string
+	Size     uint64
+	Capacity uint64
+}
+
+func getTxLane(tx metadata.Transaction) string {
+	if tx.GetMetadata() == nil {
+		return TransferLane
+	}
+	switch tx.GetMetadata().GetType() {
+	case metadata.ShardStakingMeta, metadata.BeaconStakingMeta, metadata.StopAutoStakingMeta,
+		metadata.StakingPoolCreationMeta, metadata.StakingPoolDepositMeta, metadata.StakingPoolWithdrawalRequestMeta, metadata.StakingPoolRewardRequestMeta:
+		return StakingLane
+	case metadata.IssuingRequestMeta, metadata.IssuingETHRequestMeta, metadata.ContractingRequestMeta, metadata.BurningRequestMeta,
+		metadata.RelayingBTCHeadersMeta, metadata.IssuingBTCRequestMeta:
+		return BridgeLane
+	case metadata.PDEContributionMeta, metadata.PDETradeRequestMeta, metadata.PDEWithdrawalRequestMeta:
+		return PDELane
+	default:
+		return TransferLane
+	}
+}
+
+// getLaneCapacity return max number of txs in lane, capacity of staking, bridge and pde lanes is reserved in MaxTx of config
+// and plain transfers take the rest, so pool never has more than MaxTx txs
+func (tp *TxPool) getLaneCapacity(lane string) uint64 {
+	switch lane {
+	case StakingLane:
+		return tp.config.StakingLaneMaxTx
+	case BridgeLane:
+		return tp.config.BridgeLaneMaxTx
+	case PDELane:
+		return tp.config.PDELaneMaxTx
+	default:
+		reservedCapacity := tp.config.StakingLaneMaxTx + tp.config.BridgeLaneMaxTx + tp.config.PDELaneMaxTx
+		if reservedCapacity >= tp.config.MaxTx {
+			return 0
+		}
+		return tp.config.MaxTx - reservedCapacity
+	}
+}
+
+// isLaneFull tells if a new tx of lane needs to take place of another tx in lane
+func (tp *TxPool) isLaneFull(lane string) bool {
+	return uint64(len(tp.poolLaneTxs[lane])) >= tp.getLaneCapacity(lane) || uint64(len(tp.pool)) >= tp.config.MaxTx
+}
+
+// getTxSender return hash of public key owning input coins of non-privacy proofs.
+// Sig public key is not used because it is randomized for every privacy tx, a spammer could sign each tx with a new key.
+// Input coins of privacy proofs are hidden so these txs have no sender, they are only limited by capacity of their lane
+func getTxSender(tx metadata.Transaction) (common.Hash, bool) {
+	for _, proofWithTokenID := range getProofsWithTokenID(tx) {
+		if proofWithTokenID.isPrivacy {
+			continue
+		}
+		for _, inputCoin := range proofWithTokenID.proof.GetInputCoins() {
+			if inputCoin.CoinDetails == nil || inputCoin.CoinDetails.GetPublicKey() == nil {
+				continue
+			}
+			return common.HashH(inputCoin.CoinDetails.GetPublicKey().ToBytesS()), true
+		}
+	}
+	return common.Hash{}, false
+}
+
+func (tp *TxPool) addTxToLane(tx metadata.Transaction) {
+	lane := getTxLane(tx)
+	if _, ok := tp.poolLaneTxs[lane]; !ok {
+		tp.poolLaneTxs[lane] = make(map[common.Hash]struct{})
+	}
+	tp.poolLaneTxs[lane][*tx.Hash()] = struct{}{}
+	if sender, ok := getTxSender(tx); ok {
+		tp.poolSenderTxs[sender]++
+	}
+}
+
+func (tp *TxPool) removeTxFromLane(tx metadata.Transaction) {
+	delete(tp.poolLaneTxs[getTxLane(tx)], *tx.Hash())
+	if sender, ok := getTxSender(tx); ok {
+		if tp.poolSenderTxs[sender] <= 1 {
+			delete(tp.poolSenderTxs, sender)
+		} else {
+			tp.poolSenderTxs[sender]--
+		}
+	}
+}
+
+// hasLowerFeeRate compare PRV fee per kilobyte of txs without division,
+// fee of txs in pool and of new tx is always read from tx so they are compared in the same way
+func hasLowerFeeRate(tx metadata.Transaction, otherTx metadata.Transaction) bool {
+	feeRate := new(big.Int).Mul(new(big.Int).SetUint64(tx.GetTxFee()), new(big.Int).SetUint64(otherTx.GetTxActualSize()))
+	otherFeeRate := new(big.Int).Mul(new(big.Int).SetUint64(otherTx.GetTxFee()), new(big.Int).SetUint64(tx.GetTxActualSize()))
+	return feeRate.Cmp(otherFeeRate) < 0
+}
+
+// getLowestFeeTxInLane return tx with lowest fee rate in lane,
+// txs having children in pool are skipped so a package is always evicted from its last descendant
+func (tp *TxPool) getLowestFeeTxInLane(lane string) (*TxDesc, bool) {
+	var lowestTxDesc *TxDesc
+	for txHash := range tp.poolLaneTxs[lane] {
+		txDesc, ok := tp.pool[txHash]
+		if !ok || len(tp.poolTxChildren[txHash]) > 0 {
+			continue
+		}
+		if lowestTxDesc == nil || hasLowerFeeRate(txDesc.Desc.Tx, lowestTxDesc.Desc.Tx) {
+			lowestTxDesc = txDesc
+		}
+	}
+	return lowestTxDesc, lowestTxDesc != nil
+}
+
+/*
+	checkPoolCapacity return tx which must be evicted to give place for new tx:
+	- replacement tx takes place of replaced tx, capacity is unchanged
+	- sender must not reach MaxTxPerSender (0 is unlimited)
+	- when lane or pool (MaxTx) is full, new tx must pay higher fee rate than the cheapest tx in lane
+*/
+func (tp *TxPool) checkPoolCapacity(tx metadata.Transaction) (*common.Hash, error) {
+	serialNumberListHash := common.HashArrayOfHashArray(tx.ListSerialNumbersHashH())
+	if _, ok := tp.poolSerialNumberHash[serialNumberListHash]; ok {
+		return nil, nil
+	}
+	if sender, ok := getTxSender(tx); ok && tp.config.MaxTxPerSender > 0 && tp.poolSenderTxs[sender] >= tp.config.MaxTxPerSender {
+		return nil, NewMempoolTxError(RejectSenderQuotaTx, fmt.Errorf("Sender of tx %+v has %+v txs in pool", tx.Hash().String(), tp.poolSenderTxs[sender]))
+	}
+	lane := getTxLane(tx)
+	if !tp.isLaneFull(lane) {
+		return nil, nil
+	}
+	lowestTxDesc, ok := tp.getLowestFeeTxInLane(lane)
+	if !ok || !hasLowerFeeRate(lowestTxDesc.Desc.Tx, tx) {
+		return nil, NewMempoolTxError(MaxPoolSizeError, errors.New("Pool reach max number of transaction in lane "+lane))
+	}
+	return lowestTxDesc.Desc.Tx.Hash(), nil
+}
+
+// evictTxForCapacity remove tx (with its descendants) only if lane or pool is still over capacity after new tx entered pool
+func (tp *TxPool) evictTxForCapacity(txHash common.Hash) {
+	txDesc, ok := tp.pool[txHash]
+	if !ok {
+		return
+	}
+	lane := getTxLane(txDesc.Desc.Tx)
+	if uint64(len(tp.poolLaneTxs[lane])) <= tp.getLaneCapacity(lane) && uint64(len(tp.pool)) <= tp.config.MaxTx {
+		return
+	}
+	Logger.log.Infof("Evict tx %+v with lowest fee rate in full lane %+v", txHash.String(), lane)
+	tp.evictDescendants([]common.Hash{txHash})
+}
+
+// GetLanesInfo return number of txs and capacity of each lane in pool
+func (tp *TxPool) GetLanesInfo() []LaneInfo {
+	tp.mtx.RLock()
+	defer tp.mtx.RUnlock()
+	lanesInfo := []LaneInfo{}
+	for _, lane := range []string{TransferLane, StakingLane, BridgeLane, PDELane} {
+		lanesInfo = append(lanesInfo, LaneInfo{
+			Name:     lane,
+			Size:     uint64(len(tp.poolLaneTxs[lane])),
+			Capacity: tp.getLaneCapacity(lane),
+		})
+	}
+	return lanesInfo
+}
+
+// GetMaxTxPerSender return max number of txs in pool spending coins of the same key
+func (tp *TxPool) GetMaxTxPerSender() uint64 {
+	return tp.config.MaxTxPerSender
+}
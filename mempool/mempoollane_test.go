@@ -0,0 +1,137 @@
+package mempool
+
+import (
+	"math"
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/incognitochain/incognito-chain/privacy"
+	"github.com/incognitochain/incognito-chain/pubsub"
+	"github.com/incognitochain/incognito-chain/transaction"
+)
+
+func newTestLanePool(maxTx uint64, stakingLaneMaxTx uint64, maxTxPerSender uint64) *TxPool {
+	txPool := &TxPool{}
+	txPool.Init(&Config{
+		PubSubManager:    pubsub.NewPubSubManager(),
+		MaxTx:            maxTx,
+		StakingLaneMaxTx: stakingLaneMaxTx,
+		MaxTxPerSender:   maxTxPerSender,
+	})
+	return txPool
+}
+
+func newTestLaneTx(fee uint64, lockTime int64, owner *privacy.Point) *transaction.Tx {
+	return newTestNoPrivacyTx(fee, lockTime, []*privacy.Coin{newTestCoin(owner, fee+1)}, []*privacy.Coin{newTestCoin(privacy.RandomPoint(), 1)})
+}
+
+func newTestStakingLaneTx(fee uint64, lockTime int64) *transaction.Tx {
+	tx := newTestLaneTx(fee, lockTime, privacy.RandomPoint())
+	tx.Metadata = &metadata.StopAutoStakingMetadata{MetadataBase: metadata.MetadataBase{Type: metadata.StopAutoStakingMeta}}
+	return tx
+}
+
+// acceptTestLaneTx add tx into pool the same way as MaybeAcceptTransaction after it passed validation
+func acceptTestLaneTx(txPool *TxPool, tx *transaction.Tx) error {
+	evictedTxHash, err := txPool.checkPoolCapacity(tx)
+	if err != nil {
+		return err
+	}
+	addTestDependencyTx(txPool, tx)
+	if evictedTxHash != nil {
+		txPool.evictTxForCapacity(*evictedTxHash)
+	}
+	return nil
+}
+
+func TestHasLowerFeeRate(t *testing.T) {
+	cheapTx := newTestLaneTx(math.MaxUint64-1, 1, privacy.RandomPoint())
+	expensiveTx := newTestLaneTx(math.MaxUint64, 2, privacy.RandomPoint())
+	// fee multiplied by size must not overflow
+	if !hasLowerFeeRate(cheapTx, expensiveTx) || hasLowerFeeRate(expensiveTx, cheapTx) {
+		t.Errorf("expect fee rate %+v lower than %+v", cheapTx.Fee, expensiveTx.Fee)
+	}
+	if hasLowerFeeRate(cheapTx, cheapTx) {
+		t.Errorf("expect the same fee rate is not lower")
+	}
+}
+
+func TestTxPoolLaneCapacity(t *testing.T) {
+	// 1 of 3 txs is reserved for staking lane
+	txPool := newTestLanePool(3, 1, 0)
+	if capacity := txPool.getLaneCapacity(TransferLane); capacity != 2 {
+		t.Errorf("expect capacity of transfer lane is 2 but get %+v", capacity)
+	}
+	cheapTx := newTestLaneTx(1, 1, privacy.RandomPoint())
+	for _, tx := range []*transaction.Tx{cheapTx, newTestLaneTx(3, 2, privacy.RandomPoint())} {
+		if err := acceptTestLaneTx(txPool, tx); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := acceptTestLaneTx(txPool, newTestLaneTx(1, 3, privacy.RandomPoint())); err == nil {
+		t.Errorf("expect tx not paying higher fee rate is rejected from full lane")
+	}
+	if err := acceptTestLaneTx(txPool, newTestStakingLaneTx(1, 4)); err != nil {
+		t.Errorf("expect staking tx enters its reserved lane but get %+v", err)
+	}
+	if err := acceptTestLaneTx(txPool, newTestStakingLaneTx(1, 5)); err == nil {
+		t.Errorf("expect staking tx is rejected from full staking lane")
+	}
+	// tx paying higher fee rate takes place of the cheapest tx in lane
+	if err := acceptTestLaneTx(txPool, newTestLaneTx(2, 6, privacy.RandomPoint())); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := txPool.pool[*cheapTx.Hash()]; ok || len(txPool.pool) != 3 {
+		t.Errorf("expect the cheapest tx is evicted and pool keeps 3 txs but get %+v txs", len(txPool.pool))
+	}
+	for _, laneInfo := range txPool.GetLanesInfo() {
+		if laneInfo.Size > laneInfo.Capacity {
+			t.Errorf("expect lane %+v is not over capacity: %+v", laneInfo.Name, laneInfo)
+		}
+	}
+}
+
+func TestTxPoolGlobalCapacity(t *testing.T) {
+	// reserved capacity of lanes over MaxTx doesn't let pool grow over MaxTx
+	txPool := newTestLanePool(2, 5, 0)
+	if capacity := txPool.getLaneCapacity(TransferLane); capacity != 0 {
+		t.Errorf("expect no capacity left for transfer lane but get %+v", capacity)
+	}
+	for i := int64(1); i <= 2; i++ {
+		if err := acceptTestLaneTx(txPool, newTestStakingLaneTx(1, i)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := acceptTestLaneTx(txPool, newTestStakingLaneTx(1, 3)); err == nil {
+		t.Errorf("expect tx is rejected from full pool")
+	}
+	if err := acceptTestLaneTx(txPool, newTestStakingLaneTx(2, 4)); err != nil {
+		t.Fatal(err)
+	}
+	if len(txPool.pool) != 2 {
+		t.Errorf("expect pool keeps MaxTx txs but get %+v", len(txPool.pool))
+	}
+}
+
+func TestTxPoolSenderQuota(t *testing.T) {
+	txPool := newTestLanePool(10, 0, 1)
+	owner := privacy.RandomPoint()
+	firstTx := newTestLaneTx(1, 1, owner)
+	if err := acceptTestLaneTx(txPool, firstTx); err != nil {
+		t.Fatal(err)
+	}
+	// sender is owner of input coins, a new sig public key doesn't bypass quota
+	tx := newTestLaneTx(1, 2, owner)
+	tx.SigPubKey = privacy.RandomPoint().ToBytesS()
+	if err := acceptTestLaneTx(txPool, tx); err == nil {
+		t.Errorf("expect tx over quota of sender is rejected")
+	}
+	if err := acceptTestLaneTx(txPool, newTestLaneTx(1, 3, privacy.RandomPoint())); err != nil {
+		t.Errorf("expect tx of other sender is accepted but get %+v", err)
+	}
+	// quota is released once tx of sender leaves pool
+	txPool.removeTx(firstTx)
+	if err := acceptTestLaneTx(txPool, tx); err != nil {
+		t.Errorf("expect tx of sender is accepted after quota is released but get %+v", err)
+	}
+}
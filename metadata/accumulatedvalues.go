@@ -8,11 +8,15 @@ import (
 
 type AccumulatedValues struct {
 	UniqETHTxsUsed   [][]byte
+	UniqBTCTxsUsed   [][]byte
 	DBridgeTokenPair map[string][]byte
-	CBridgeTokens    []*common.Hash
+	// evm chain of external tokens of DBridgeTokenPair, tokens not in the map are of the default chain
+	DBridgeTokenChainIDs map[string]uint64
+	CBridgeTokens        []*common.Hash
 }
 
 func (ac AccumulatedValues) CanProcessTokenPair(
+	chainID uint64,
 	externalTokenID []byte,
 	incTokenID common.Hash,
 ) (bool, error) {
@@ -24,13 +28,13 @@ func (ac AccumulatedValues) CanProcessTokenPair(
 	}
 	bridgeTokenPair := ac.DBridgeTokenPair
 	if existedExtTokenID, found := bridgeTokenPair[incTokenIDStr]; found {
-		if bytes.Equal(existedExtTokenID, externalTokenID) {
+		if ac.DBridgeTokenChainIDs[incTokenIDStr] == chainID && bytes.Equal(existedExtTokenID, externalTokenID) {
 			return true, nil
 		}
 		return false, nil
 	}
-	for _, existedExtTokenID := range bridgeTokenPair {
-		if !bytes.Equal(existedExtTokenID, externalTokenID) {
+	for existedIncTokenIDStr, existedExtTokenID := range bridgeTokenPair {
+		if ac.DBridgeTokenChainIDs[existedIncTokenIDStr] != chainID || !bytes.Equal(existedExtTokenID, externalTokenID) {
 			continue
 		}
 		return false, nil
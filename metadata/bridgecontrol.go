@@ -0,0 +1,197 @@
+package metadata
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/common/base58"
+	"github.com/incognitochain/incognito-chain/consensus/signatureschemes/bridgesig"
+	"github.com/incognitochain/incognito-chain/database"
+	"github.com/incognitochain/incognito-chain/database/lvdb"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+)
+
+// Actions of bridge control requests
+const (
+	BridgeControlPauseAction   = "pause"
+	BridgeControlUnpauseAction = "unpause"
+	BridgeControlReleaseAction = "release"
+)
+
+// BridgeControlRequest - pause or unpause issuing and burning requests of a bridge token (the zero TokenID stands for all tokens),
+// or release a queued request regardless of the caps of its token.
+// Whoever can send this type of tx but it must carry signatures of more than 2/3 of the beacon committee by their bridge keys,
+// Nonce must be the next control nonce so that signatures can not be replayed
+type BridgeControlRequest struct {
+	Action          string
+	TokenID         common.Hash
+	ReleasedTxReqID common.Hash // queued request to release
+	Nonce           uint64
+	Signatures      []string // base58 check encoded signatures of bridge keys on HashForSigning
+	MetadataBase
+}
+
+type BridgeControlAction struct {
+	Meta    BridgeControlRequest
+	TxReqID common.Hash
+	ShardID byte
+}
+
+func NewBridgeControlRequest(
+	action string,
+	tokenID common.Hash,
+	releasedTxReqID common.Hash,
+	nonce uint64,
+	signatures []string,
+	metaType int,
+) (*BridgeControlRequest, error) {
+	metadataBase := MetadataBase{
+		Type: metaType,
+	}
+	controlReq := &BridgeControlRequest{
+		Action:          action,
+		TokenID:         tokenID,
+		ReleasedTxReqID: releasedTxReqID,
+		Nonce:           nonce,
+		Signatures:      signatures,
+	}
+	controlReq.MetadataBase = metadataBase
+	return controlReq, nil
+}
+
+// HashForSigning is the data which is signed by bridge keys of the beacon committee
+func (controlReq BridgeControlRequest) HashForSigning() common.Hash {
+	record := controlReq.Action
+	record += controlReq.TokenID.String()
+	record += controlReq.ReleasedTxReqID.String()
+	record += strconv.FormatUint(controlReq.Nonce, 10)
+	return common.HashH([]byte(record))
+}
+
+// CountApprovals returns number of members of committee which signed the request by their bridge keys
+func (controlReq BridgeControlRequest) CountApprovals(committee []incognitokey.CommitteePublicKey) int {
+	return countCommitteeApprovals(controlReq.HashForSigning(), controlReq.Signatures, committee)
+}
+
+// IsApprovedByCommittee returns true if more than 2/3 of committee signed the request, the same threshold of bridge contracts
+func (controlReq BridgeControlRequest) IsApprovedByCommittee(committee []incognitokey.CommitteePublicKey) bool {
+	return isApprovedByCommittee(controlReq.HashForSigning(), controlReq.Signatures, committee)
+}
+
+// countCommitteeApprovals returns number of members of committee which signed hash by their bridge keys,
+// signatures are base58 check encoded
+func countCommitteeApprovals(hash common.Hash, signatures []string, committee []incognitokey.CommitteePublicKey) int {
+	approvals := 0
+	for _, member := range committee {
+		briPubKey, found := member.MiningPubKey[common.BridgeConsensus]
+		if !found {
+			continue
+		}
+		for _, sigStr := range signatures {
+			sig, _, err := base58.Base58Check{}.Decode(sigStr)
+			if err != nil {
+				continue
+			}
+			if ok, _ := bridgesig.Verify(briPubKey, hash[:], sig); ok {
+				approvals++
+				break
+			}
+		}
+	}
+	return approvals
+}
+
+func isApprovedByCommittee(hash common.Hash, signatures []string, committee []incognitokey.CommitteePublicKey) bool {
+	return len(committee) > 0 && countCommitteeApprovals(hash, signatures, committee) > len(committee)*2/3
+}
+
+// GetBridgeControlNonce returns nonce of the next bridge control request, it is kept in the quota record of the zero token id
+func GetBridgeControlNonce(db database.DatabaseInterface) (uint64, error) {
+	quotaBytes, err := db.GetBridgeTokenQuota(common.Hash{})
+	if err != nil {
+		return 0, err
+	}
+	if len(quotaBytes) == 0 {
+		return 0, nil
+	}
+	var quota lvdb.BridgeTokenQuota
+	if err := json.Unmarshal(quotaBytes, &quota); err != nil {
+		return 0, err
+	}
+	return quota.ControlNonce, nil
+}
+
+/*
+Validate Condition to Request Bridge Control With Blockchain
+- Nonce is not used yet, the exact nonce is checked by beacon because requests of different shards can race
+- Signatures are verified by beacon because the beacon committee may change before the request is processed
+*/
+func (controlReq BridgeControlRequest) ValidateTxWithBlockChain(
+	txr Transaction,
+	bcr BlockchainRetriever,
+	shardID byte,
+	db database.DatabaseInterface,
+) (bool, error) {
+	nonce, err := GetBridgeControlNonce(db)
+	if err != nil {
+		return false, err
+	}
+	if controlReq.Nonce < nonce {
+		return false, NewMetadataTxError(BridgeControlInvalidNonceError, fmt.Errorf("Next bridge control nonce is %+v", nonce))
+	}
+	return true, nil
+}
+
+func (controlReq BridgeControlRequest) ValidateSanityData(bcr BlockchainRetriever, txr Transaction) (bool, bool, error) {
+	switch controlReq.Action {
+	case BridgeControlPauseAction, BridgeControlUnpauseAction:
+	case BridgeControlReleaseAction:
+		if controlReq.ReleasedTxReqID.IsEqual(&common.Hash{}) {
+			return false, false, NewMetadataTxError(BridgeControlRequestValidateSanityDataError, errors.New("ReleasedTxReqID is required to release a queued request"))
+		}
+	default:
+		return false, false, NewMetadataTxError(BridgeControlRequestValidateSanityDataError, fmt.Errorf("Action %+v is invalid", controlReq.Action))
+	}
+	if len(controlReq.Signatures) == 0 || len(controlReq.Signatures) > MaxBridgeControlSignatures {
+		return false, false, NewMetadataTxError(BridgeControlRequestValidateSanityDataError, fmt.Errorf("Bridge control request should have 1 to %d signatures", MaxBridgeControlSignatures))
+	}
+	return true, true, nil
+}
+
+func (controlReq BridgeControlRequest) ValidateMetadataByItself() bool {
+	return controlReq.Type == BridgeControlRequestMeta
+}
+
+func (controlReq BridgeControlRequest) Hash() *common.Hash {
+	record := controlReq.MetadataBase.Hash().String()
+	record += controlReq.HashForSigning().String()
+	for _, sigStr := range controlReq.Signatures {
+		record += sigStr
+	}
+	// final hash
+	hash := common.HashH([]byte(record))
+	return &hash
+}
+
+func (controlReq *BridgeControlRequest) BuildReqActions(tx Transaction, bcr BlockchainRetriever, shardID byte) ([][]string, error) {
+	actionContent := BridgeControlAction{
+		Meta:    *controlReq,
+		TxReqID: *tx.Hash(),
+		ShardID: shardID,
+	}
+	actionContentBytes, err := json.Marshal(actionContent)
+	if err != nil {
+		return [][]string{}, err
+	}
+	actionContentBase64Str := base64.StdEncoding.EncodeToString(actionContentBytes)
+	action := []string{strconv.Itoa(BridgeControlRequestMeta), actionContentBase64Str}
+	return [][]string{action}, nil
+}
+
+func (controlReq *BridgeControlRequest) CalculateSize() uint64 {
+	return calculateSize(controlReq)
+}
@@ -6,6 +6,7 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"reflect"
 	"strconv"
 
@@ -21,6 +22,7 @@ type BurningRequest struct {
 	TokenID       common.Hash
 	TokenName     string
 	RemoteAddress string
+	ChainID       uint64 `json:",omitempty"` // chain in the registry of evm chains which the token is released on
 	MetadataBase
 }
 
@@ -30,6 +32,7 @@ func NewBurningRequest(
 	tokenID common.Hash,
 	tokenName string,
 	remoteAddress string,
+	chainID uint64,
 	metaType int,
 ) (*BurningRequest, error) {
 	metadataBase := MetadataBase{
@@ -41,6 +44,7 @@ func NewBurningRequest(
 		TokenID:       tokenID,
 		TokenName:     tokenName,
 		RemoteAddress: remoteAddress,
+		ChainID:       chainID,
 	}
 	burningReq.MetadataBase = metadataBase
 	return burningReq, nil
@@ -52,13 +56,19 @@ func (bReq BurningRequest) ValidateTxWithBlockChain(
 	shardID byte,
 	db database.DatabaseInterface,
 ) (bool, error) {
-	bridgeTokenExisted, err := db.IsBridgeTokenExistedByType(bReq.TokenID, false)
+	if _, err := bcr.GetEVMChain(bReq.ChainID); err != nil {
+		return false, err
+	}
+	tokenChainID, bridgeTokenExisted, err := db.GetBridgeTokenChainID(bReq.TokenID)
 	if err != nil {
 		return false, err
 	}
 	if !bridgeTokenExisted {
 		return false, errors.New("the burning token is not existed in bridge tokens")
 	}
+	if tokenChainID != bReq.ChainID {
+		return false, fmt.Errorf("the burning token is bridged from chain %d, not chain %d", tokenChainID, bReq.ChainID)
+	}
 	return true, nil
 }
 
@@ -103,6 +113,10 @@ func (bReq BurningRequest) Hash() *common.Hash {
 	record += strconv.FormatUint(bReq.BurningAmount, 10)
 	record += bReq.TokenName
 	record += bReq.RemoteAddress
+	// requests of the default chain keep their hash of before the registry of evm chains
+	if bReq.ChainID != DefaultEVMChainID {
+		record += strconv.FormatUint(bReq.ChainID, 10)
+	}
 
 	// final hash
 	hash := common.HashH([]byte(record))
@@ -69,6 +69,36 @@ func ParseMetadata(meta interface{}) (Metadata, error) {
 		md = &PDEWithdrawalResponse{}
 	case PDEContributionResponseMeta:
 		md = &PDEContributionResponse{}
+	case StakingPoolCreationMeta:
+		md = &StakingPoolCreation{}
+	case StakingPoolDepositMeta:
+		md = &StakingPoolDeposit{}
+	case StakingPoolWithdrawalRequestMeta:
+		md = &StakingPoolWithdrawalRequest{}
+	case StakingPoolResponseMeta:
+		md = &StakingPoolResponse{}
+	case PrivacyTokenRegistrationMeta:
+		md = &PrivacyTokenRegistration{}
+	case MintableTokenInitMeta:
+		md = &MintableTokenInit{}
+	case MintableTokenMintMeta:
+		md = &MintableTokenMintRequest{}
+	case MintableTokenBurnMeta:
+		md = &MintableTokenBurnRequest{}
+	case MintableTokenResponseMeta:
+		md = &MintableTokenResponse{}
+	case RelayingBTCHeadersMeta:
+		md = &RelayingBTCHeaders{}
+	case IssuingBTCRequestMeta:
+		md = &IssuingBTCRequest{}
+	case IssuingBTCResponseMeta:
+		md = &IssuingBTCResponse{}
+	case BridgeControlRequestMeta:
+		md = &BridgeControlRequest{}
+	case DAOProposalMeta:
+		md = &DAOProposalRequest{}
+	case DAOVoteMeta:
+		md = &DAOVoteRequest{}
 	default:
 		Logger.log.Debug("[db] parse meta err: %+v\n", meta)
 		return nil, errors.Errorf("Could not parse metadata with type: %d", int(mtTemp["Type"].(float64)))
@@ -85,6 +115,7 @@ var bridgeMetas = []string{
 	strconv.Itoa(BeaconSwapConfirmMeta),
 	strconv.Itoa(BridgeSwapConfirmMeta),
 	strconv.Itoa(BurningConfirmMeta),
+	strconv.Itoa(BurningConfirmForEVMChainMeta),
 }
 
 func HasBridgeInstructions(instructions [][]string) bool {
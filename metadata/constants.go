@@ -33,6 +33,9 @@ const (
 	BridgeSwapConfirmMeta = 71
 	BurningConfirmMeta    = 72
 
+	// Incognito -> EVM sidechains bridge
+	BurningConfirmForEVMChainMeta = 73
+
 	// pde
 	PDEContributionMeta         = 90
 	PDETradeRequestMeta         = 91
@@ -40,6 +43,37 @@ const (
 	PDEWithdrawalRequestMeta    = 93
 	PDEWithdrawalResponseMeta   = 94
 	PDEContributionResponseMeta = 95
+
+	// staking pool
+	StakingPoolCreationMeta          = 140
+	StakingPoolDepositMeta           = 141
+	StakingPoolWithdrawalRequestMeta = 142
+	StakingPoolResponseMeta          = 143
+	StakingPoolRewardRequestMeta     = 144
+
+	// privacy token registry
+	PrivacyTokenRegistrationMeta = 150
+
+	// mintable token
+	MintableTokenInitMeta     = 160
+	MintableTokenMintMeta     = 161
+	MintableTokenBurnMeta     = 162
+	MintableTokenResponseMeta = 163
+
+	// trustless bitcoin bridge
+	RelayingBTCHeadersMeta = 170
+	IssuingBTCRequestMeta  = 171
+	IssuingBTCResponseMeta = 172
+
+	// bridge limiter
+	BridgeControlRequestMeta = 180
+
+	// incognito dao governance
+	DAOProposalMeta       = 190
+	DAOVoteMeta           = 191
+	DAOProposalTallyMeta  = 192
+	DAOTreasuryPayoutMeta = 193
+	DAOParamChangeMeta    = 194
 )
 
 var minerCreatedMetaTypes = []int{
@@ -52,6 +86,9 @@ var minerCreatedMetaTypes = []int{
 	PDETradeResponseMeta,
 	PDEWithdrawalResponseMeta,
 	PDEContributionResponseMeta,
+	StakingPoolResponseMeta,
+	MintableTokenResponseMeta,
+	IssuingBTCResponseMeta,
 }
 
 // Special rules for shardID: stored as 2nd param of instruction of BeaconBlock
@@ -75,6 +112,31 @@ var (
 //)
 const (
 	StopAutoStakingAmount = 0
+
+	// commission rate of staking pools is expressed in basis points
+	MaxStakingPoolCommissionRate = 10000
+
+	// limits of privacy token registry
+	MaxPrivacyTokenNameLength        = 64
+	MaxPrivacyTokenSymbolLength      = 16
+	MaxPrivacyTokenDecimals          = 18
+	MaxPrivacyTokenDescriptionLength = 256
+
+	// max number of keys of a minting authority
+	MaxMintableTokenAuthorities = 16
+
+	// max number of bitcoin headers relayed by a tx
+	MaxRelayingBTCHeaders = 100
+
+	// max number of signatures of a bridge control request, it is signed by the beacon committee
+	MaxBridgeControlSignatures = 64
+
+	// limits of proposals of incognito dao
+	MaxDAOProposalDescriptionLength = 1024
+	MaxDAOProposalTreasuryTokens    = 16
+
+	// chain id of the ethereum deployment the bridge was launched with, bridge requests without chain id target it
+	DefaultEVMChainID = 0
 )
 
 var AcceptedWithdrawRewardRequestVersion = []int{0, 1}
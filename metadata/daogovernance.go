@@ -0,0 +1,365 @@
+package metadata
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/database"
+	"github.com/incognitochain/incognito-chain/database/lvdb"
+	"github.com/incognitochain/incognito-chain/wallet"
+)
+
+// Types of proposals of Incognito DAO
+const (
+	DAOTreasurySpendProposal = "treasuryspend"
+	DAOParamChangeProposal   = "paramchange"
+)
+
+// Chain params which can be changed by proposals of Incognito DAO
+const (
+	DAOVotingPeriodParam  = "VotingPeriod"
+	DAOQuorumPercentParam = "QuorumPercent"
+	DAOPassPercentParam   = "PassPercent"
+	DAOTimelockParam      = "Timelock"
+
+	// caps of a bridge token, proposals changing them have the token id
+	BridgeMaxIssuingPerTxParam    = "MaxIssuingPerTx"
+	BridgeMaxIssuingPerEpochParam = "MaxIssuingPerEpoch"
+	BridgeMaxBurningPerTxParam    = "MaxBurningPerTx"
+	BridgeMaxBurningPerEpochParam = "MaxBurningPerEpoch"
+)
+
+// IsBridgeTokenLimitParam returns true if the param is a cap of a bridge token
+func IsBridgeTokenLimitParam(paramName string) bool {
+	switch paramName {
+	case BridgeMaxIssuingPerTxParam, BridgeMaxIssuingPerEpochParam, BridgeMaxBurningPerTxParam, BridgeMaxBurningPerEpochParam:
+		return true
+	}
+	return false
+}
+
+func isDAOGovernanceParam(paramName string) bool {
+	switch paramName {
+	case DAOVotingPeriodParam, DAOQuorumPercentParam, DAOPassPercentParam, DAOTimelockParam:
+		return true
+	}
+	return false
+}
+
+// BuildDAOGovernedParamKey returns the key of a param among the ones changed by proposals, caps of bridge tokens are keyed by their tokens
+func BuildDAOGovernedParamKey(paramName string, tokenID common.Hash) string {
+	if IsBridgeTokenLimitParam(paramName) {
+		return paramName + "-" + tokenID.String()
+	}
+	return paramName
+}
+
+// DAOProposalRequest - propose to pay Amounts from the treasury of Incognito DAO to ReceiverAddress,
+// or to change a governed chain param to ParamValue. The proposal is voted by holders of staked PRV,
+// ProposerAddress must be the sender of the tx and hold staked PRV when beacon accepts the proposal
+type DAOProposalRequest struct {
+	Type            string
+	ProposerAddress string
+	Description     string
+	ReceiverAddress string
+	Amounts         map[common.Hash]uint64
+	ParamName       string
+	TokenID         common.Hash
+	ParamValue      uint64
+	MetadataBase
+}
+
+type DAOProposalAction struct {
+	Meta    DAOProposalRequest
+	TxReqID common.Hash
+	ShardID byte
+}
+
+// DAOVoteRequest - vote for or against a proposal in voting, a later vote of the same voter replaces the earlier one.
+// Weight of the vote is staked PRV of VoterAddress when the proposal is tallied
+type DAOVoteRequest struct {
+	ProposalID   common.Hash
+	VoterAddress string
+	Approve      bool
+	MetadataBase
+}
+
+type DAOVoteAction struct {
+	Meta    DAOVoteRequest
+	TxReqID common.Hash
+	ShardID byte
+}
+
+// DAOProposalTallyContent is the result of a proposal at the end of its voting
+type DAOProposalTallyContent struct {
+	ProposalID            common.Hash
+	YesWeight             uint64
+	NoWeight              uint64
+	TotalWeight           uint64
+	ExecutionBeaconHeight uint64
+}
+
+// DAOTreasuryPayoutContent pays a passed treasury spend, amounts are credited to rewards of the receiver on its shard
+type DAOTreasuryPayoutContent struct {
+	ProposalID      common.Hash
+	ReceiverAddress string
+	Amounts         map[common.Hash]uint64
+}
+
+// DAOParamChangeContent applies a passed chain param change
+type DAOParamChangeContent struct {
+	ProposalID common.Hash
+	ParamName  string
+	TokenID    common.Hash
+	ParamValue uint64
+}
+
+func NewDAOProposalRequest(
+	proposalType string,
+	proposerAddress string,
+	description string,
+	receiverAddress string,
+	amounts map[common.Hash]uint64,
+	paramName string,
+	tokenID common.Hash,
+	paramValue uint64,
+	metaType int,
+) (*DAOProposalRequest, error) {
+	metadataBase := MetadataBase{
+		Type: metaType,
+	}
+	proposalReq := &DAOProposalRequest{
+		Type:            proposalType,
+		ProposerAddress: proposerAddress,
+		Description:     description,
+		ReceiverAddress: receiverAddress,
+		Amounts:         amounts,
+		ParamName:       paramName,
+		TokenID:         tokenID,
+		ParamValue:      paramValue,
+	}
+	proposalReq.MetadataBase = metadataBase
+	return proposalReq, nil
+}
+
+func NewDAOVoteRequest(
+	proposalID common.Hash,
+	voterAddress string,
+	approve bool,
+	metaType int,
+) (*DAOVoteRequest, error) {
+	metadataBase := MetadataBase{
+		Type: metaType,
+	}
+	voteReq := &DAOVoteRequest{
+		ProposalID:   proposalID,
+		VoterAddress: voterAddress,
+		Approve:      approve,
+	}
+	voteReq.MetadataBase = metadataBase
+	return voteReq, nil
+}
+
+// GetDAOProposal returns nil if the proposal is not found
+func GetDAOProposal(db database.DatabaseInterface, proposalID common.Hash) (*lvdb.DAOProposal, error) {
+	proposalBytes, err := db.GetDAOProposal(proposalID)
+	if err != nil {
+		return nil, err
+	}
+	if len(proposalBytes) == 0 {
+		return nil, nil
+	}
+	var proposal lvdb.DAOProposal
+	if err := json.Unmarshal(proposalBytes, &proposal); err != nil {
+		return nil, err
+	}
+	return &proposal, nil
+}
+
+// validateDAOSender checks the address is the sender of the tx
+func validateDAOSender(addressStr string, txr Transaction) error {
+	keyWallet, err := wallet.Base58CheckDeserialize(addressStr)
+	if err != nil || len(keyWallet.KeySet.PaymentAddress.Pk) == 0 {
+		return NewMetadataTxError(DAOGovernanceRequestFromMapError, errors.New("Payment address is invalid"))
+	}
+	if !bytes.Equal(txr.GetSigPubKey()[:], keyWallet.KeySet.PaymentAddress.Pk[:]) {
+		return NewMetadataTxError(DAOGovernanceInvalidSenderError, errors.New("Payment address is not the sender of the tx"))
+	}
+	return nil
+}
+
+func (proposalReq DAOProposalRequest) ValidateTxWithBlockChain(
+	txr Transaction,
+	bcr BlockchainRetriever,
+	shardID byte,
+	db database.DatabaseInterface,
+) (bool, error) {
+	// NOTE: stake of the proposer and the treasury are checked on beacon
+	return true, nil
+}
+
+func (proposalReq DAOProposalRequest) ValidateSanityData(bcr BlockchainRetriever, txr Transaction) (bool, bool, error) {
+	if err := validateDAOSender(proposalReq.ProposerAddress, txr); err != nil {
+		return false, false, err
+	}
+	if len(proposalReq.Description) > MaxDAOProposalDescriptionLength {
+		return false, false, NewMetadataTxError(DAOProposalValidateSanityDataError, fmt.Errorf("Description should not be longer than %d", MaxDAOProposalDescriptionLength))
+	}
+	switch proposalReq.Type {
+	case DAOTreasurySpendProposal:
+		keyWallet, err := wallet.Base58CheckDeserialize(proposalReq.ReceiverAddress)
+		if err != nil || len(keyWallet.KeySet.PaymentAddress.Pk) == 0 {
+			return false, false, NewMetadataTxError(DAOProposalValidateSanityDataError, errors.New("ReceiverAddress is invalid"))
+		}
+		if len(proposalReq.Amounts) == 0 || len(proposalReq.Amounts) > MaxDAOProposalTreasuryTokens {
+			return false, false, NewMetadataTxError(DAOProposalValidateSanityDataError, fmt.Errorf("Treasury spend should have 1 to %d amounts", MaxDAOProposalTreasuryTokens))
+		}
+		for tokenID, amount := range proposalReq.Amounts {
+			if amount == 0 {
+				return false, false, NewMetadataTxError(DAOProposalValidateSanityDataError, fmt.Errorf("Amount of token %s should be larger than 0", tokenID.String()))
+			}
+		}
+
+	case DAOParamChangeProposal:
+		if IsBridgeTokenLimitParam(proposalReq.ParamName) {
+			if proposalReq.TokenID.IsEqual(&common.Hash{}) {
+				return false, false, NewMetadataTxError(DAOProposalValidateSanityDataError, errors.New("TokenID is required to change caps of a bridge token"))
+			}
+			break
+		}
+		if !isDAOGovernanceParam(proposalReq.ParamName) {
+			return false, false, NewMetadataTxError(DAOProposalValidateSanityDataError, fmt.Errorf("Param %+v can not be changed by proposals", proposalReq.ParamName))
+		}
+		switch proposalReq.ParamName {
+		case DAOVotingPeriodParam:
+			if proposalReq.ParamValue == 0 {
+				return false, false, NewMetadataTxError(DAOProposalValidateSanityDataError, errors.New("VotingPeriod should be larger than 0"))
+			}
+		case DAOQuorumPercentParam, DAOPassPercentParam:
+			if proposalReq.ParamValue == 0 || proposalReq.ParamValue > 100 {
+				return false, false, NewMetadataTxError(DAOProposalValidateSanityDataError, fmt.Errorf("%s should be from 1 to 100", proposalReq.ParamName))
+			}
+		}
+
+	default:
+		return false, false, NewMetadataTxError(DAOProposalValidateSanityDataError, fmt.Errorf("Proposal type %+v is invalid", proposalReq.Type))
+	}
+	return true, true, nil
+}
+
+func (proposalReq DAOProposalRequest) ValidateMetadataByItself() bool {
+	return proposalReq.MetadataBase.Type == DAOProposalMeta
+}
+
+func (proposalReq DAOProposalRequest) Hash() *common.Hash {
+	record := proposalReq.MetadataBase.Hash().String()
+	record += proposalReq.Type
+	record += proposalReq.ProposerAddress
+	record += proposalReq.Description
+	record += proposalReq.ReceiverAddress
+	tokenIDs := make([]string, 0, len(proposalReq.Amounts))
+	amounts := make(map[string]uint64, len(proposalReq.Amounts))
+	for tokenID, amount := range proposalReq.Amounts {
+		tokenIDs = append(tokenIDs, tokenID.String())
+		amounts[tokenID.String()] = amount
+	}
+	sort.Strings(tokenIDs)
+	for _, tokenID := range tokenIDs {
+		record += tokenID
+		record += strconv.FormatUint(amounts[tokenID], 10)
+	}
+	record += proposalReq.ParamName
+	record += proposalReq.TokenID.String()
+	record += strconv.FormatUint(proposalReq.ParamValue, 10)
+	// final hash
+	hash := common.HashH([]byte(record))
+	return &hash
+}
+
+func (proposalReq *DAOProposalRequest) BuildReqActions(tx Transaction, bcr BlockchainRetriever, shardID byte) ([][]string, error) {
+	actionContent := DAOProposalAction{
+		Meta:    *proposalReq,
+		TxReqID: *tx.Hash(),
+		ShardID: shardID,
+	}
+	actionContentBytes, err := json.Marshal(actionContent)
+	if err != nil {
+		return [][]string{}, err
+	}
+	actionContentBase64Str := base64.StdEncoding.EncodeToString(actionContentBytes)
+	action := []string{strconv.Itoa(DAOProposalMeta), actionContentBase64Str}
+	return [][]string{action}, nil
+}
+
+func (proposalReq *DAOProposalRequest) CalculateSize() uint64 {
+	return calculateSize(proposalReq)
+}
+
+/*
+Validate Condition to Vote With Blockchain
+- Proposal exists, whether it is still in voting is checked by beacon
+*/
+func (voteReq DAOVoteRequest) ValidateTxWithBlockChain(
+	txr Transaction,
+	bcr BlockchainRetriever,
+	shardID byte,
+	db database.DatabaseInterface,
+) (bool, error) {
+	proposal, err := GetDAOProposal(db, voteReq.ProposalID)
+	if err != nil {
+		return false, err
+	}
+	if proposal == nil {
+		return false, NewMetadataTxError(DAOProposalNotFoundError, fmt.Errorf("No proposal found for id %+v", voteReq.ProposalID.String()))
+	}
+	return true, nil
+}
+
+func (voteReq DAOVoteRequest) ValidateSanityData(bcr BlockchainRetriever, txr Transaction) (bool, bool, error) {
+	if err := validateDAOSender(voteReq.VoterAddress, txr); err != nil {
+		return false, false, err
+	}
+	if voteReq.ProposalID.IsEqual(&common.Hash{}) {
+		return false, false, NewMetadataTxError(DAOGovernanceRequestFromMapError, errors.New("ProposalID is required"))
+	}
+	return true, true, nil
+}
+
+func (voteReq DAOVoteRequest) ValidateMetadataByItself() bool {
+	return voteReq.MetadataBase.Type == DAOVoteMeta
+}
+
+func (voteReq DAOVoteRequest) Hash() *common.Hash {
+	record := voteReq.MetadataBase.Hash().String()
+	record += voteReq.ProposalID.String()
+	record += voteReq.VoterAddress
+	record += strconv.FormatBool(voteReq.Approve)
+	// final hash
+	hash := common.HashH([]byte(record))
+	return &hash
+}
+
+func (voteReq *DAOVoteRequest) BuildReqActions(tx Transaction, bcr BlockchainRetriever, shardID byte) ([][]string, error) {
+	actionContent := DAOVoteAction{
+		Meta:    *voteReq,
+		TxReqID: *tx.Hash(),
+		ShardID: shardID,
+	}
+	actionContentBytes, err := json.Marshal(actionContent)
+	if err != nil {
+		return [][]string{}, err
+	}
+	actionContentBase64Str := base64.StdEncoding.EncodeToString(actionContentBytes)
+	action := []string{strconv.Itoa(DAOVoteMeta), actionContentBase64Str}
+	return [][]string{action}, nil
+}
+
+func (voteReq *DAOVoteRequest) CalculateSize() uint64 {
+	return calculateSize(voteReq)
+}
@@ -38,6 +38,25 @@ func BuildInstForIncDAOReward(reward map[common.Hash]uint64, incDAOAddress strin
 	return returnedInst, nil
 }
 
+// BuildInstForIncDAOTreasuryReward builds the dao reward once governance is on, it is kept in the treasury by beacon
+// instead of being paid to the dao address so no shard processes it
+func BuildInstForIncDAOTreasuryReward(reward map[common.Hash]uint64) ([]string, error) {
+	devRewardInfo := IncDAORewardInfo{
+		IncDAOReward: reward,
+	}
+	contentStr, err := json.Marshal(devRewardInfo)
+	if err != nil {
+		return nil, err
+	}
+	returnedInst := []string{
+		strconv.Itoa(IncDAORewardRequestMeta),
+		strconv.Itoa(BeaconOnly),
+		common.IncDAOTreasuryRewardChainStatus,
+		string(contentStr),
+	}
+	return returnedInst, nil
+}
+
 func NewIncDAORewardInfoFromStr(inst string) (*IncDAORewardInfo, error) {
 	Ins := &IncDAORewardInfo{}
 	err := json.Unmarshal([]byte(inst), Ins)
@@ -41,6 +41,46 @@ const (
 	PDEWithdrawalRequestFromMapError
 	CouldNotGetExchangeRateError
 	RejectInvalidFee
+
+	// staking pool
+	StakingPoolRequestFromMapError
+	StakingPoolRequestAlreadyStakedError
+	StakingPoolRequestNotFoundError
+	StakingPoolRequestInvalidSenderError
+	StakingPoolRequestInvalidSignatureError
+
+	// privacy token registry
+	PrivacyTokenRegistrationFromMapError
+	PrivacyTokenRegistrationInvalidSenderError
+	PrivacyTokenRegistrationTokenNotFoundError
+	PrivacyTokenRegistrationExternalTokenError
+	PrivacyTokenRegistrationInvalidSignaturesError
+	PrivacyTokenRegistrationInvalidNonceError
+
+	// mintable token
+	MintableTokenRequestFromMapError
+	MintableTokenNotFoundError
+	MintableTokenInvalidSignaturesError
+	MintableTokenInvalidNonceError
+
+	// trustless bitcoin bridge
+	RelayingBTCHeadersFromMapError
+	RelayingBTCHeadersValidateSanityDataError
+	IssuingBTCRequestFromMapError
+	IssuingBTCRequestValidateSanityDataError
+	IssuingBTCRequestValidateTxWithBlockChainError
+	IssuingBTCRequestDecodeInstructionError
+
+	// bridge limiter
+	BridgeControlRequestFromMapError
+	BridgeControlRequestValidateSanityDataError
+	BridgeControlInvalidNonceError
+
+	// incognito dao governance
+	DAOGovernanceRequestFromMapError
+	DAOGovernanceInvalidSenderError
+	DAOProposalValidateSanityDataError
+	DAOProposalNotFoundError
 )
 
 var ErrCodeMessage = map[int]struct {
@@ -86,6 +126,46 @@ var ErrCodeMessage = map[int]struct {
 	PDEWithdrawalRequestFromMapError: {-6001, "PDE withdrawal request Error"},
 	CouldNotGetExchangeRateError:     {-6002, "Could not get the exchange rate error"},
 	RejectInvalidFee:                 {-6003, "Reject invalid fee"},
+
+	// -7xxx staking pool
+	StakingPoolRequestFromMapError:          {-7001, "Staking pool request error"},
+	StakingPoolRequestAlreadyStakedError:    {-7002, "Committee public key is already staked or bound to a staking pool"},
+	StakingPoolRequestNotFoundError:         {-7003, "Staking pool not found"},
+	StakingPoolRequestInvalidSenderError:    {-7004, "Staking pool request invalid transaction sender"},
+	StakingPoolRequestInvalidSignatureError: {-7005, "Staking pool request invalid committee key signature"},
+
+	// -8xxx privacy token registry
+	PrivacyTokenRegistrationFromMapError:           {-8001, "Privacy token registration error"},
+	PrivacyTokenRegistrationInvalidSenderError:     {-8002, "Privacy token registration is not sent by issuer of token"},
+	PrivacyTokenRegistrationTokenNotFoundError:     {-8003, "Privacy token of registration not found"},
+	PrivacyTokenRegistrationExternalTokenError:     {-8004, "External token of registration does not match bridge token"},
+	PrivacyTokenRegistrationInvalidSignaturesError: {-8005, "Registration of bridge token is not signed by the beacon committee"},
+	PrivacyTokenRegistrationInvalidNonceError:      {-8006, "Nonce of bridge token registration is already used"},
+
+	// -9xxx mintable token
+	MintableTokenRequestFromMapError:    {-9001, "Mintable token request error"},
+	MintableTokenNotFoundError:          {-9002, "Mintable token not found"},
+	MintableTokenInvalidSignaturesError: {-9003, "Mint request is not approved by enough keys of minting authority"},
+	MintableTokenInvalidNonceError:      {-9004, "Nonce of mint request is already used"},
+
+	// -10xxx trustless bitcoin bridge
+	RelayingBTCHeadersFromMapError:                 {-10001, "Relaying btc headers error"},
+	RelayingBTCHeadersValidateSanityDataError:      {-10002, "Relayed btc headers are invalid"},
+	IssuingBTCRequestFromMapError:                  {-10003, "Issuing btc request error"},
+	IssuingBTCRequestValidateSanityDataError:       {-10004, "Proof of btc deposit is invalid"},
+	IssuingBTCRequestValidateTxWithBlockChainError: {-10005, "Btc deposit is already issued"},
+	IssuingBTCRequestDecodeInstructionError:        {-10006, "Can not decode instruction of issuing btc request"},
+
+	// -11xxx bridge limiter
+	BridgeControlRequestFromMapError:            {-11001, "Bridge control request error"},
+	BridgeControlRequestValidateSanityDataError: {-11002, "Bridge control request is invalid"},
+	BridgeControlInvalidNonceError:              {-11003, "Nonce of bridge control request is already used"},
+
+	// -12xxx incognito dao governance
+	DAOGovernanceRequestFromMapError:   {-12001, "Dao governance request error"},
+	DAOGovernanceInvalidSenderError:    {-12002, "Dao governance request is not sent by its proposer or voter"},
+	DAOProposalValidateSanityDataError: {-12003, "Dao proposal is invalid"},
+	DAOProposalNotFoundError:           {-12004, "Dao proposal not found"},
 }
 
 type MetadataTxError struct {
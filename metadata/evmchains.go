@@ -0,0 +1,162 @@
+package metadata
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	rCommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/metadata/rpccaller"
+	"github.com/pkg/errors"
+)
+
+// EVMHeaderSource is the json rpc endpoint of a node of an EVM chain, shard nodes read headers of deposits from it
+type EVMHeaderSource struct {
+	Protocol string `json:"protocol"`
+	Host     string `json:"host"`
+	Port     string `json:"port"`
+}
+
+// EVMChain is a deployment of the bridge contracts on an EVM chain.
+// ChainID is the EIP-155 chain id of the chain, the ethereum deployment the bridge was launched with
+// keeps DefaultEVMChainID so requests, instructions and bridge tokens stored before the registry still refer to it
+type EVMChain struct {
+	ChainID            uint64          `json:"chainId"`
+	Name               string          `json:"name"`
+	ContractAddressStr string          `json:"contractAddress"`
+	AbiJson            string          `json:"abi"`
+	Confirmations      uint64          `json:"confirmations"` // number of blocks from the block of a deposit to the latest one, both included
+	HeaderSource       EVMHeaderSource `json:"headerSource"`
+}
+
+type GetBlockNumberRes struct {
+	rpccaller.RPCBaseRes
+	Result *hexutil.Uint64 `json:"result"`
+}
+
+// NewDefaultEVMChain returns the ethereum deployment of a network, its header source is set by GETH_* envs
+func NewDefaultEVMChain(name string, contractAddressStr string, confirmations uint64) EVMChain {
+	return EVMChain{
+		ChainID:            DefaultEVMChainID,
+		Name:               name,
+		ContractAddressStr: contractAddressStr,
+		AbiJson:            common.AbiJson,
+		Confirmations:      confirmations,
+		HeaderSource: EVMHeaderSource{
+			Protocol: EthereumLightNodeProtocol,
+			Host:     EthereumLightNodeHost,
+			Port:     EthereumLightNodePort,
+		},
+	}
+}
+
+// IsDefault returns true for the ethereum deployment the bridge was launched with
+func (chain EVMChain) IsDefault() bool {
+	return chain.ChainID == DefaultEVMChainID
+}
+
+func (chain EVMChain) rpcCall(method string, params []interface{}, rpcResponse interface{}) error {
+	rpcClient := rpccaller.NewRPCClient()
+	return rpcClient.RPCCall(
+		chain.HeaderSource.Protocol,
+		chain.HeaderSource.Host,
+		chain.HeaderSource.Port,
+		method,
+		params,
+		rpcResponse,
+	)
+}
+
+// GetHeader returns nil header if the node of header source does not know the block
+func (chain EVMChain) GetHeader(blockHash rCommon.Hash) (*types.Header, error) {
+	var getBlockByHashRes GetBlockByNumberRes
+	err := chain.rpcCall("eth_getBlockByHash", []interface{}{blockHash, false}, &getBlockByHashRes)
+	if err != nil {
+		return nil, err
+	}
+	if getBlockByHashRes.RPCError != nil {
+		Logger.log.Debugf("WARNING: an error occured during calling eth_getBlockByHash of chain %d: %s", chain.ChainID, getBlockByHashRes.RPCError.Message)
+		return nil, nil
+	}
+	return getBlockByHashRes.Result, nil
+}
+
+// GetLatestBlockNumber returns number of the latest block known by the node of header source
+func (chain EVMChain) GetLatestBlockNumber() (uint64, error) {
+	var getBlockNumberRes GetBlockNumberRes
+	err := chain.rpcCall("eth_blockNumber", []interface{}{}, &getBlockNumberRes)
+	if err != nil {
+		return 0, err
+	}
+	if getBlockNumberRes.RPCError != nil {
+		return 0, errors.Errorf("eth_blockNumber of chain %d: %s", chain.ChainID, getBlockNumberRes.RPCError.Message)
+	}
+	if getBlockNumberRes.Result == nil {
+		return 0, errors.Errorf("eth_blockNumber of chain %d returns no block number", chain.ChainID)
+	}
+	return uint64(*getBlockNumberRes.Result), nil
+}
+
+// CheckConfirmations returns error if the block of header is not deep enough in the chain
+func (chain EVMChain) CheckConfirmations(header *types.Header) error {
+	if chain.Confirmations == 0 {
+		return nil
+	}
+	latestNumber, err := chain.GetLatestBlockNumber()
+	if err != nil {
+		return err
+	}
+	blockNumber := header.Number.Uint64()
+	if latestNumber < blockNumber || latestNumber-blockNumber+1 < chain.Confirmations {
+		return errors.Errorf("block %d of chain %d has not got %d confirmations, latest block is %d", blockNumber, chain.ChainID, chain.Confirmations, latestNumber)
+	}
+	return nil
+}
+
+// ParseDepositLogData unpacks data of a Deposit event of the bridge contract of chain
+func (chain EVMChain) ParseDepositLogData(data []byte) (map[string]interface{}, error) {
+	abiIns, err := abi.JSON(strings.NewReader(chain.AbiJson))
+	if err != nil {
+		return nil, err
+	}
+	dataMap := map[string]interface{}{}
+	if err = abiIns.UnpackIntoMap(dataMap, "Deposit", data); err != nil {
+		return nil, NewMetadataTxError(UnexpectedError, err)
+	}
+	return dataMap, nil
+}
+
+// PickAndParseDepositLogMap returns nil map if the receipt has no log of the bridge contract of chain
+func (chain EVMChain) PickAndParseDepositLogMap(constructedReceipt *types.Receipt) (map[string]interface{}, error) {
+	logData := []byte{}
+	if len(constructedReceipt.Logs) == 0 {
+		Logger.log.Debug("WARNING: LOG data is invalid.")
+		return nil, nil
+	}
+	contractAddr := rCommon.HexToAddress(chain.ContractAddressStr)
+	for _, log := range constructedReceipt.Logs {
+		if bytes.Equal(contractAddr.Bytes(), log.Address.Bytes()) {
+			logData = log.Data
+			break
+		}
+	}
+	if len(logData) == 0 {
+		return nil, nil
+	}
+	return chain.ParseDepositLogData(logData)
+}
+
+// GetUniqETHTx returns the key of a deposit which is marked issued, deposits of the default chain keep the key used before the registry
+func GetUniqETHTx(chainID uint64, blockHash rCommon.Hash, txIndex uint) []byte {
+	// NOTE: since TxHash from constructedReceipt is always '0x0000000000000000000000000000000000000000000000000000000000000000'
+	// so must build unique eth tx as combination of block hash and tx index.
+	uniqETHTx := append(blockHash.Bytes(), []byte(strconv.Itoa(int(txIndex)))...)
+	if chainID == DefaultEVMChainID {
+		return uniqETHTx
+	}
+	return append(common.Uint64ToBytes(chainID), uniqETHTx...)
+}
@@ -0,0 +1,256 @@
+package metadata
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/incognitochain/incognito-chain/blockchain/btc"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/database"
+	"github.com/incognitochain/incognito-chain/wallet"
+)
+
+// IssuingBTCRequest - request pBTC for a bitcoin deposit, anyone can send this type of tx.
+// The deposit is output OutputIndex of TxStr which pays to the committee controlled script, the tx must also have
+// an OP_RETURN output of GetBTCDepositCommitment(ReceiverAddressStr) so that its proof is only valid for the receiver.
+// TxStr is proved by a merkle path to block BlockHash, which must be on the relayed best chain with enough confirmations
+type IssuingBTCRequest struct {
+	BlockHash          string // like bitcoin explorers
+	TxStr              string // hex of the raw tx
+	TxIndex            uint   // position of the tx in its block
+	MerkleProofStrs    []string
+	OutputIndex        uint32
+	ReceiverAddressStr string
+	MetadataBase
+}
+
+type IssuingBTCReqAction struct {
+	Meta    IssuingBTCRequest `json:"meta"`
+	TxReqID common.Hash       `json:"txReqId"`
+	ShardID byte              `json:"shardId"`
+}
+
+type IssuingBTCAcceptedInst struct {
+	ShardID         byte        `json:"shardId"`
+	IssuingAmount   uint64      `json:"issuingAmount"`
+	ReceiverAddrStr string      `json:"receiverAddrStr"`
+	IncTokenID      common.Hash `json:"incTokenId"`
+	TxReqID         common.Hash `json:"txReqId"`
+	UniqBTCTx       []byte      `json:"uniqBTCTx"`
+	ExternalTokenID []byte      `json:"externalTokenId"`
+}
+
+func NewIssuingBTCRequest(
+	blockHash string,
+	txStr string,
+	txIndex uint,
+	merkleProofStrs []string,
+	outputIndex uint32,
+	receiverAddressStr string,
+	metaType int,
+) (*IssuingBTCRequest, error) {
+	metadataBase := MetadataBase{
+		Type: metaType,
+	}
+	issuingBTCReq := &IssuingBTCRequest{
+		BlockHash:          blockHash,
+		TxStr:              txStr,
+		TxIndex:            txIndex,
+		MerkleProofStrs:    merkleProofStrs,
+		OutputIndex:        outputIndex,
+		ReceiverAddressStr: receiverAddressStr,
+	}
+	issuingBTCReq.MetadataBase = metadataBase
+	return issuingBTCReq, nil
+}
+
+func NewIssuingBTCRequestFromMap(
+	data map[string]interface{},
+) (*IssuingBTCRequest, error) {
+	blockHash, ok := data["BlockHash"].(string)
+	if !ok {
+		return nil, NewMetadataTxError(IssuingBTCRequestFromMapError, errors.New("BlockHash is invalid"))
+	}
+	txStr, ok := data["TxStr"].(string)
+	if !ok {
+		return nil, NewMetadataTxError(IssuingBTCRequestFromMapError, errors.New("TxStr is invalid"))
+	}
+	txIndex, ok := data["TxIndex"].(float64)
+	if !ok || txIndex < 0 {
+		return nil, NewMetadataTxError(IssuingBTCRequestFromMapError, errors.New("TxIndex is invalid"))
+	}
+	proofsRaw, ok := data["MerkleProofStrs"].([]interface{})
+	if !ok {
+		return nil, NewMetadataTxError(IssuingBTCRequestFromMapError, errors.New("MerkleProofStrs is invalid"))
+	}
+	merkleProofStrs := []string{}
+	for _, item := range proofsRaw {
+		proofStr, ok := item.(string)
+		if !ok {
+			return nil, NewMetadataTxError(IssuingBTCRequestFromMapError, errors.New("MerkleProofStrs is invalid"))
+		}
+		merkleProofStrs = append(merkleProofStrs, proofStr)
+	}
+	outputIndex, ok := data["OutputIndex"].(float64)
+	if !ok || outputIndex < 0 {
+		return nil, NewMetadataTxError(IssuingBTCRequestFromMapError, errors.New("OutputIndex is invalid"))
+	}
+	receiverAddressStr, ok := data["ReceiverAddressStr"].(string)
+	if !ok {
+		return nil, NewMetadataTxError(IssuingBTCRequestFromMapError, errors.New("ReceiverAddressStr is invalid"))
+	}
+	return NewIssuingBTCRequest(
+		blockHash,
+		txStr,
+		uint(txIndex),
+		merkleProofStrs,
+		uint32(outputIndex),
+		receiverAddressStr,
+		IssuingBTCRequestMeta,
+	)
+}
+
+// GetBTCDepositCommitment returns data of the OP_RETURN output a deposit for receiverAddressStr must have
+func GetBTCDepositCommitment(receiverAddressStr string) []byte {
+	return common.HashB([]byte(receiverAddressStr))
+}
+
+// GetUniqBTCTx identifies a deposit by its tx hash and output index so that it is issued once
+func GetUniqBTCTx(txHash chainhash.Hash, outputIndex uint32) []byte {
+	outputIndexBytes := make([]byte, 4)
+	binary.BigEndian.PutUint32(outputIndexBytes, outputIndex)
+	return append(txHash.CloneBytes(), outputIndexBytes...)
+}
+
+// ParseBTCDepositProof decodes block hash, tx and merkle path of the request, it checks that the tx commits to
+// the receiver, the merkle root is checked with the relayed header by beacon
+func (iReq IssuingBTCRequest) ParseBTCDepositProof() (*chainhash.Hash, *wire.MsgTx, []chainhash.Hash, error) {
+	blockHash, err := chainhash.NewHashFromStr(iReq.BlockHash)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	tx, err := btc.ParseBTCTx(iReq.TxStr)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if int(iReq.OutputIndex) >= len(tx.TxOut) {
+		return nil, nil, nil, fmt.Errorf("output %d is not found in the tx", iReq.OutputIndex)
+	}
+	commitment, found := btc.GetBTCDepositCommitment(tx)
+	if !found || !bytes.Equal(commitment, GetBTCDepositCommitment(iReq.ReceiverAddressStr)) {
+		return nil, nil, nil, errors.New("the tx does not commit to the receiver address")
+	}
+	proof, err := btc.ParseMerkleProof(iReq.MerkleProofStrs)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if iReq.TxIndex>>uint(len(proof)) != 0 {
+		return nil, nil, nil, fmt.Errorf("tx index %d is out of the merkle proof", iReq.TxIndex)
+	}
+	return blockHash, tx, proof, nil
+}
+
+func (iReq IssuingBTCRequest) ValidateTxWithBlockChain(
+	txr Transaction,
+	bcr BlockchainRetriever,
+	shardID byte,
+	db database.DatabaseInterface,
+) (bool, error) {
+	_, tx, _, err := iReq.ParseBTCDepositProof()
+	if err != nil {
+		return false, NewMetadataTxError(IssuingBTCRequestValidateSanityDataError, err)
+	}
+	isIssued, err := db.IsBTCTxIssued(GetUniqBTCTx(tx.TxHash(), iReq.OutputIndex))
+	if err != nil {
+		return false, NewMetadataTxError(IssuingBTCRequestValidateTxWithBlockChainError, err)
+	}
+	if isIssued {
+		return false, NewMetadataTxError(IssuingBTCRequestValidateTxWithBlockChainError, fmt.Errorf("output %d of btc tx %s is already issued", iReq.OutputIndex, tx.TxHash().String()))
+	}
+	return true, nil
+}
+
+func (iReq IssuingBTCRequest) ValidateSanityData(bcr BlockchainRetriever, txr Transaction) (bool, bool, error) {
+	keyWallet, err := wallet.Base58CheckDeserialize(iReq.ReceiverAddressStr)
+	if err != nil || len(keyWallet.KeySet.PaymentAddress.Pk) == 0 {
+		return false, false, NewMetadataTxError(IssuingBTCRequestValidateSanityDataError, errors.New("Wrong request info's receiver address"))
+	}
+	_, _, _, err = iReq.ParseBTCDepositProof()
+	if err != nil {
+		return false, false, NewMetadataTxError(IssuingBTCRequestValidateSanityDataError, err)
+	}
+	return true, true, nil
+}
+
+func (iReq IssuingBTCRequest) ValidateMetadataByItself() bool {
+	return iReq.Type == IssuingBTCRequestMeta
+}
+
+func (iReq IssuingBTCRequest) Hash() *common.Hash {
+	record := iReq.MetadataBase.Hash().String()
+	record += iReq.BlockHash
+	record += iReq.TxStr
+	record += strconv.FormatUint(uint64(iReq.TxIndex), 10)
+	for _, proofStr := range iReq.MerkleProofStrs {
+		record += proofStr
+	}
+	record += strconv.FormatUint(uint64(iReq.OutputIndex), 10)
+	record += iReq.ReceiverAddressStr
+	// final hash
+	hash := common.HashH([]byte(record))
+	return &hash
+}
+
+func (iReq *IssuingBTCRequest) BuildReqActions(tx Transaction, bcr BlockchainRetriever, shardID byte) ([][]string, error) {
+	txReqID := *(tx.Hash())
+	actionContent := IssuingBTCReqAction{
+		Meta:    *iReq,
+		TxReqID: txReqID,
+		ShardID: shardID,
+	}
+	actionContentBytes, err := json.Marshal(actionContent)
+	if err != nil {
+		return [][]string{}, err
+	}
+	actionContentBase64Str := base64.StdEncoding.EncodeToString(actionContentBytes)
+	action := []string{strconv.Itoa(IssuingBTCRequestMeta), actionContentBase64Str}
+
+	err = bcr.GetDatabase().TrackBridgeReqWithStatus(txReqID, byte(common.BridgeRequestProcessingStatus), nil)
+	if err != nil {
+		return [][]string{}, err
+	}
+	return [][]string{action}, nil
+}
+
+func (iReq *IssuingBTCRequest) CalculateSize() uint64 {
+	return calculateSize(iReq)
+}
+
+func ParseBTCIssuingInstContent(instContentStr string) (*IssuingBTCReqAction, error) {
+	contentBytes, err := base64.StdEncoding.DecodeString(instContentStr)
+	if err != nil {
+		return nil, NewMetadataTxError(IssuingBTCRequestDecodeInstructionError, err)
+	}
+	var issuingBTCReqAction IssuingBTCReqAction
+	err = json.Unmarshal(contentBytes, &issuingBTCReqAction)
+	if err != nil {
+		return nil, NewMetadataTxError(IssuingBTCRequestDecodeInstructionError, err)
+	}
+	return &issuingBTCReqAction, nil
+}
+
+func IsBTCTxUsedInBlock(uniqBTCTx []byte, uniqBTCTxsUsed [][]byte) bool {
+	for _, item := range uniqBTCTxsUsed {
+		if bytes.Equal(uniqBTCTx, item) {
+			return true
+		}
+	}
+	return false
+}
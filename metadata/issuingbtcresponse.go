@@ -0,0 +1,139 @@
+package metadata
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/database"
+	"github.com/incognitochain/incognito-chain/wallet"
+)
+
+type IssuingBTCResponse struct {
+	MetadataBase
+	RequestedTxID   common.Hash
+	UniqBTCTx       []byte
+	ExternalTokenID []byte
+}
+
+type IssuingBTCResAction struct {
+	Meta       *IssuingBTCResponse `json:"meta"`
+	IncTokenID *common.Hash        `json:"incTokenID"`
+}
+
+func NewIssuingBTCResponse(
+	requestedTxID common.Hash,
+	uniqBTCTx []byte,
+	externalTokenID []byte,
+	metaType int,
+) *IssuingBTCResponse {
+	metadataBase := MetadataBase{
+		Type: metaType,
+	}
+	return &IssuingBTCResponse{
+		RequestedTxID:   requestedTxID,
+		UniqBTCTx:       uniqBTCTx,
+		ExternalTokenID: externalTokenID,
+		MetadataBase:    metadataBase,
+	}
+}
+
+func (iRes IssuingBTCResponse) CheckTransactionFee(tr Transaction, minFee uint64, beaconHeight int64, db database.DatabaseInterface) bool {
+	// no need to have fee for this tx
+	return true
+}
+
+func (iRes IssuingBTCResponse) ValidateTxWithBlockChain(txr Transaction, bcr BlockchainRetriever, shardID byte, db database.DatabaseInterface) (bool, error) {
+	// no need to validate tx with blockchain, just need to validate with requested tx (via RequestedTxID) in current block
+	return false, nil
+}
+
+func (iRes IssuingBTCResponse) ValidateSanityData(bcr BlockchainRetriever, txr Transaction) (bool, bool, error) {
+	return false, true, nil
+}
+
+func (iRes IssuingBTCResponse) ValidateMetadataByItself() bool {
+	// The validation just need to check at tx level, so returning true here
+	return true
+}
+
+func (iRes IssuingBTCResponse) Hash() *common.Hash {
+	record := iRes.RequestedTxID.String()
+	record += string(iRes.UniqBTCTx)
+	record += string(iRes.ExternalTokenID)
+	record += iRes.MetadataBase.Hash().String()
+
+	// final hash
+	hash := common.HashH([]byte(record))
+	return &hash
+}
+
+func (iRes *IssuingBTCResponse) CalculateSize() uint64 {
+	return calculateSize(iRes)
+}
+
+func (iRes IssuingBTCResponse) VerifyMinerCreatedTxBeforeGettingInBlock(
+	txsInBlock []Transaction,
+	txsUsed []int,
+	insts [][]string,
+	instUsed []int,
+	shardID byte,
+	tx Transaction,
+	bcr BlockchainRetriever,
+	ac *AccumulatedValues,
+) (bool, error) {
+	idx := -1
+	for i, inst := range insts {
+		if len(inst) < 4 { // this is not IssuingBTCRequest instruction
+			continue
+		}
+		instMetaType := inst[0]
+		if instUsed[i] > 0 ||
+			instMetaType != strconv.Itoa(IssuingBTCRequestMeta) {
+			continue
+		}
+
+		contentBytes, err := base64.StdEncoding.DecodeString(inst[3])
+		if err != nil {
+			Logger.log.Error("WARNING - VALIDATION: an error occured while parsing instruction content: ", err)
+			continue
+		}
+		var issuingBTCAcceptedInst IssuingBTCAcceptedInst
+		err = json.Unmarshal(contentBytes, &issuingBTCAcceptedInst)
+		if err != nil {
+			Logger.log.Error("WARNING - VALIDATION: an error occured while parsing instruction content: ", err)
+			continue
+		}
+
+		if !bytes.Equal(iRes.RequestedTxID[:], issuingBTCAcceptedInst.TxReqID[:]) ||
+			!bytes.Equal(iRes.UniqBTCTx, issuingBTCAcceptedInst.UniqBTCTx) ||
+			!bytes.Equal(iRes.ExternalTokenID, issuingBTCAcceptedInst.ExternalTokenID) ||
+			shardID != issuingBTCAcceptedInst.ShardID {
+			continue
+		}
+
+		addressStr := issuingBTCAcceptedInst.ReceiverAddrStr
+		key, err := wallet.Base58CheckDeserialize(addressStr)
+		if err != nil {
+			Logger.log.Info("WARNING - VALIDATION: an error occured while deserializing receiver address string: ", err)
+			continue
+		}
+		_, pk, paidAmount, assetID := tx.GetTransferData()
+		if !bytes.Equal(key.KeySet.PaymentAddress.Pk[:], pk[:]) ||
+			issuingBTCAcceptedInst.IssuingAmount != paidAmount ||
+			!bytes.Equal(issuingBTCAcceptedInst.IncTokenID[:], assetID[:]) {
+			continue
+		}
+		idx = i
+		break
+	}
+	if idx == -1 { // not found the issuance request tx for this response
+		return false, errors.New(fmt.Sprintf("no IssuingBTCRequest tx found for IssuingBTCResponse tx %s", tx.Hash().String()))
+	}
+	instUsed[idx] = 1
+	return true, nil
+}
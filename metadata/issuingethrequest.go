@@ -7,9 +7,7 @@ import (
 	"fmt"
 	"github.com/incognitochain/incognito-chain/metadata/rpccaller"
 	"strconv"
-	"strings"
 
-	"github.com/ethereum/go-ethereum/accounts/abi"
 	rCommon "github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/light"
@@ -25,6 +23,7 @@ type IssuingETHRequest struct {
 	TxIndex    uint
 	ProofStrs  []string
 	IncTokenID common.Hash
+	ChainID    uint64 `json:",omitempty"` // chain of the deposit in the registry of evm chains
 	MetadataBase
 }
 
@@ -42,6 +41,7 @@ type IssuingETHAcceptedInst struct {
 	TxReqID         common.Hash `json:"txReqId"`
 	UniqETHTx       []byte      `json:"uniqETHTx"`
 	ExternalTokenID []byte      `json:"externalTokenId"`
+	ChainID         uint64      `json:"chainId,omitempty"`
 }
 
 type GetBlockByNumberRes struct {
@@ -67,6 +67,7 @@ func NewIssuingETHRequest(
 	txIndex uint,
 	proofStrs []string,
 	incTokenID common.Hash,
+	chainID uint64,
 	metaType int,
 ) (*IssuingETHRequest, error) {
 	metadataBase := MetadataBase{
@@ -77,6 +78,7 @@ func NewIssuingETHRequest(
 		TxIndex:    txIndex,
 		ProofStrs:  proofStrs,
 		IncTokenID: incTokenID,
+		ChainID:    chainID,
 	}
 	issuingETHReq.MetadataBase = metadataBase
 	return issuingETHReq, nil
@@ -97,12 +99,17 @@ func NewIssuingETHRequestFromMap(
 	if err != nil {
 		return nil, NewMetadataTxError(IssuingEthRequestNewIssuingETHRequestFromMapEror, errors.Errorf("TokenID incorrect"))
 	}
+	chainID := uint64(DefaultEVMChainID)
+	if chainIDParam, ok := data["ChainID"].(float64); ok {
+		chainID = uint64(chainIDParam)
+	}
 
 	req, _ := NewIssuingETHRequest(
 		blockHash,
 		txIdx,
 		proofStrs,
 		*incTokenID,
+		chainID,
 		IssuingETHRequestMeta,
 	)
 	return req, nil
@@ -114,7 +121,7 @@ func (iReq IssuingETHRequest) ValidateTxWithBlockChain(
 	shardID byte,
 	db database.DatabaseInterface,
 ) (bool, error) {
-	ethReceipt, err := iReq.verifyProofAndParseReceipt()
+	ethReceipt, err := iReq.verifyProofAndParseReceipt(bcr)
 	if err != nil {
 		return false, NewMetadataTxError(IssuingEthRequestValidateTxWithBlockChainError, err)
 	}
@@ -147,6 +154,10 @@ func (iReq IssuingETHRequest) Hash() *common.Hash {
 	}
 	record += iReq.MetadataBase.Hash().String()
 	record += iReq.IncTokenID.String()
+	// requests of the default chain keep their hash of before the registry of evm chains
+	if iReq.ChainID != DefaultEVMChainID {
+		record += strconv.FormatUint(iReq.ChainID, 10)
+	}
 
 	// final hash
 	hash := common.HashH([]byte(record))
@@ -154,7 +165,7 @@ func (iReq IssuingETHRequest) Hash() *common.Hash {
 }
 
 func (iReq *IssuingETHRequest) BuildReqActions(tx Transaction, bcr BlockchainRetriever, shardID byte) ([][]string, error) {
-	ethReceipt, err := iReq.verifyProofAndParseReceipt()
+	ethReceipt, err := iReq.verifyProofAndParseReceipt(bcr)
 	if err != nil {
 		return [][]string{}, NewMetadataTxError(IssuingEthRequestBuildReqActionsError, err)
 	}
@@ -186,8 +197,12 @@ func (iReq *IssuingETHRequest) CalculateSize() uint64 {
 	return calculateSize(iReq)
 }
 
-func (iReq *IssuingETHRequest) verifyProofAndParseReceipt() (*types.Receipt, error) {
-	ethHeader, err := GetETHHeader(iReq.BlockHash)
+func (iReq *IssuingETHRequest) verifyProofAndParseReceipt(bcr BlockchainRetriever) (*types.Receipt, error) {
+	chain, err := bcr.GetEVMChain(iReq.ChainID)
+	if err != nil {
+		return nil, NewMetadataTxError(IssuingEthRequestVerifyProofAndParseReceipt, err)
+	}
+	ethHeader, err := chain.GetHeader(iReq.BlockHash)
 	if err != nil {
 		return nil, NewMetadataTxError(IssuingEthRequestVerifyProofAndParseReceipt, err)
 	}
@@ -195,6 +210,9 @@ func (iReq *IssuingETHRequest) verifyProofAndParseReceipt() (*types.Receipt, err
 		Logger.log.Info("WARNING: Could not find out the ETH block header with the hash: ", iReq.BlockHash)
 		return nil, NewMetadataTxError(IssuingEthRequestVerifyProofAndParseReceipt, errors.Errorf("WARNING: Could not find out the ETH block header with the hash: %s", iReq.BlockHash.String()))
 	}
+	if err := chain.CheckConfirmations(ethHeader); err != nil {
+		return nil, NewMetadataTxError(IssuingEthRequestVerifyProofAndParseReceipt, err)
+	}
 	keybuf := new(bytes.Buffer)
 	keybuf.Reset()
 	rlp.Encode(keybuf, iReq.TxIndex)
@@ -222,18 +240,6 @@ func (iReq *IssuingETHRequest) verifyProofAndParseReceipt() (*types.Receipt, err
 	return constructedReceipt, nil
 }
 
-func ParseETHLogData(data []byte) (map[string]interface{}, error) {
-	abiIns, err := abi.JSON(strings.NewReader(common.AbiJson))
-	if err != nil {
-		return nil, err
-	}
-	dataMap := map[string]interface{}{}
-	if err = abiIns.UnpackIntoMap(dataMap, "Deposit", data); err != nil {
-		return nil, NewMetadataTxError(UnexpectedError, err)
-	}
-	return dataMap, nil
-}
-
 func IsETHTxHashUsedInBlock(uniqETHTx []byte, uniqETHTxsUsed [][]byte) bool {
 	for _, item := range uniqETHTxsUsed {
 		if bytes.Equal(uniqETHTx, item) {
@@ -266,22 +272,3 @@ func GetETHHeader(
 	}
 	return getBlockByNumberRes.Result, nil
 }
-
-func PickAndParseLogMapFromReceipt(constructedReceipt *types.Receipt, ethContractAddressStr string) (map[string]interface{}, error) {
-	logData := []byte{}
-	logLen := len(constructedReceipt.Logs)
-	if logLen == 0 {
-		Logger.log.Debug("WARNING: LOG data is invalid.")
-		return nil, nil
-	}
-	for _, log := range constructedReceipt.Logs {
-		if bytes.Equal(rCommon.HexToAddress(ethContractAddressStr).Bytes(), log.Address.Bytes()) {
-			logData = log.Data
-			break
-		}
-	}
-	if len(logData) == 0 {
-		return nil, nil
-	}
-	return ParseETHLogData(logData)
-}
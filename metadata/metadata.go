@@ -63,6 +63,7 @@ type BlockchainRetriever interface {
 	GetAllCommitteeValidatorCandidateFlattenListFromDatabase() ([]string, error)
 	GetStakingTx(byte) map[string]string
 	GetAutoStakingList() map[string]bool
+	GetUnbondingStakes() map[string]uint64
 	GetDatabase() database.DatabaseInterface
 	GetTxValue(txid string) (uint64, error)
 	GetShardIDFromTx(txid string) (byte, error)
@@ -70,6 +71,7 @@ type BlockchainRetriever interface {
 	GetAllCoinID() ([]common.Hash, error)
 	GetBeaconHeightBreakPointBurnAddr() uint64
 	GetBurningAddress(blockHeight uint64) string
+	GetEVMChain(chainID uint64) (*EVMChain, error)
 }
 
 // Interface for all type of transaction
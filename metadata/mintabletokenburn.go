@@ -0,0 +1,129 @@
+package metadata
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strconv"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/database"
+	"github.com/incognitochain/incognito-chain/privacy"
+)
+
+// MintableTokenBurnRequest - burn a mintable token, whoever holds the token can send this type of tx
+type MintableTokenBurnRequest struct {
+	BurnerAddress privacy.PaymentAddress
+	BurnedAmount  uint64 // must be equal to vout value
+	TokenID       common.Hash
+	MetadataBase
+}
+
+type MintableTokenBurnAction struct {
+	Meta    MintableTokenBurnRequest
+	TxReqID common.Hash
+	ShardID byte
+}
+
+func NewMintableTokenBurnRequest(
+	burnerAddress privacy.PaymentAddress,
+	burnedAmount uint64,
+	tokenID common.Hash,
+	metaType int,
+) (*MintableTokenBurnRequest, error) {
+	metadataBase := MetadataBase{
+		Type: metaType,
+	}
+	burnReq := &MintableTokenBurnRequest{
+		BurnerAddress: burnerAddress,
+		BurnedAmount:  burnedAmount,
+		TokenID:       tokenID,
+	}
+	burnReq.MetadataBase = metadataBase
+	return burnReq, nil
+}
+
+func (burnReq MintableTokenBurnRequest) ValidateTxWithBlockChain(
+	txr Transaction,
+	bcr BlockchainRetriever,
+	shardID byte,
+	db database.DatabaseInterface,
+) (bool, error) {
+	mintableTokenExisted, err := db.IsMintableTokenExisted(burnReq.TokenID)
+	if err != nil {
+		return false, err
+	}
+	if !mintableTokenExisted {
+		return false, NewMetadataTxError(MintableTokenNotFoundError, errors.New("the burning token is not existed in mintable tokens"))
+	}
+	return true, nil
+}
+
+func (burnReq MintableTokenBurnRequest) ValidateSanityData(bcr BlockchainRetriever, txr Transaction) (bool, bool, error) {
+	// Note: the metadata was already verified with *transaction.TxCustomTokenPrivacy level so no need to verify with *transaction.Tx level again
+	if reflect.TypeOf(txr).String() == "*transaction.Tx" {
+		return true, true, nil
+	}
+
+	if burnReq.Type != MintableTokenBurnMeta {
+		return false, false, errors.New("Wrong request info's meta type")
+	}
+	if len(burnReq.BurnerAddress.Pk) == 0 {
+		return false, false, errors.New("Wrong request info's burner address")
+	}
+	if burnReq.BurnedAmount == 0 {
+		return false, false, errors.New("Wrong request info's burned amount")
+	}
+	if txr.GetTokenID() == nil || !txr.GetTokenID().IsEqual(&burnReq.TokenID) {
+		return false, false, errors.New("Wrong request info's token id")
+	}
+	if !txr.IsCoinsBurning(bcr) {
+		return false, false, errors.New("Must send coin to burning address")
+	}
+	if burnReq.BurnedAmount != txr.CalculateTxValue() {
+		return false, false, errors.New("BurnedAmount incorrect")
+	}
+	if !bytes.Equal(txr.GetSigPubKey()[:], burnReq.BurnerAddress.Pk[:]) {
+		return false, false, errors.New("BurnerAddress incorrect")
+	}
+	return true, true, nil
+}
+
+func (burnReq MintableTokenBurnRequest) ValidateMetadataByItself() bool {
+	return burnReq.Type == MintableTokenBurnMeta
+}
+
+func (burnReq MintableTokenBurnRequest) Hash() *common.Hash {
+	record := burnReq.MetadataBase.Hash().String()
+	record += burnReq.BurnerAddress.String()
+	record += burnReq.TokenID.String()
+	record += strconv.FormatUint(burnReq.BurnedAmount, 10)
+	// final hash
+	hash := common.HashH([]byte(record))
+	return &hash
+}
+
+func (burnReq *MintableTokenBurnRequest) BuildReqActions(tx Transaction, bcr BlockchainRetriever, shardID byte) ([][]string, error) {
+	// a PRV tx can carry the metadata too, only a tx of the token burns its supply
+	if tx.GetTokenID() == nil || !tx.GetTokenID().IsEqual(&burnReq.TokenID) {
+		return [][]string{}, nil
+	}
+	actionContent := MintableTokenBurnAction{
+		Meta:    *burnReq,
+		TxReqID: *tx.Hash(),
+		ShardID: shardID,
+	}
+	actionContentBytes, err := json.Marshal(actionContent)
+	if err != nil {
+		return [][]string{}, err
+	}
+	actionContentBase64Str := base64.StdEncoding.EncodeToString(actionContentBytes)
+	action := []string{strconv.Itoa(MintableTokenBurnMeta), actionContentBase64Str}
+	return [][]string{action}, nil
+}
+
+func (burnReq *MintableTokenBurnRequest) CalculateSize() uint64 {
+	return calculateSize(burnReq)
+}
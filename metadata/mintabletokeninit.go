@@ -0,0 +1,130 @@
+package metadata
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/database"
+	"github.com/incognitochain/incognito-chain/wallet"
+)
+
+// MintableTokenInit - init a custom token whose supply is issued by a minting authority,
+// the authority is a single key or a committee of keys which approves a mint request by RequiredSignatures signatures
+type MintableTokenInit struct {
+	TokenName          string
+	TokenSymbol        string
+	AuthorityAddresses []string
+	RequiredSignatures int
+	MetadataBase
+}
+
+type MintableTokenInitAction struct {
+	Meta    MintableTokenInit
+	TokenID common.Hash
+	TxReqID common.Hash
+	ShardID byte
+}
+
+func NewMintableTokenInit(
+	tokenName string,
+	tokenSymbol string,
+	authorityAddresses []string,
+	requiredSignatures int,
+	metaType int,
+) (*MintableTokenInit, error) {
+	metadataBase := MetadataBase{
+		Type: metaType,
+	}
+	tokenInit := &MintableTokenInit{
+		TokenName:          tokenName,
+		TokenSymbol:        tokenSymbol,
+		AuthorityAddresses: authorityAddresses,
+		RequiredSignatures: requiredSignatures,
+	}
+	tokenInit.MetadataBase = metadataBase
+	return tokenInit, nil
+}
+
+// GetMintableTokenID returns id of the mintable token which is initialized by tx txReqID
+func GetMintableTokenID(txReqID common.Hash) common.Hash {
+	return common.HashH(append([]byte("mintabletoken-"), txReqID[:]...))
+}
+
+func (tokenInit MintableTokenInit) ValidateTxWithBlockChain(
+	txr Transaction,
+	bcr BlockchainRetriever,
+	shardID byte,
+	db database.DatabaseInterface,
+) (bool, error) {
+	// token id is derived from hash of the tx so it is always new
+	return true, nil
+}
+
+func (tokenInit MintableTokenInit) ValidateSanityData(bcr BlockchainRetriever, txr Transaction) (bool, bool, error) {
+	if tokenInit.TokenName == "" || len(tokenInit.TokenName) > MaxPrivacyTokenNameLength {
+		return false, false, fmt.Errorf("Token name should not be empty or longer than %d", MaxPrivacyTokenNameLength)
+	}
+	if !IsValidPrivacyTokenSymbol(tokenInit.TokenSymbol) {
+		return false, false, fmt.Errorf("Token symbol should have 1 to %d letters or digits", MaxPrivacyTokenSymbolLength)
+	}
+	if len(tokenInit.AuthorityAddresses) == 0 || len(tokenInit.AuthorityAddresses) > MaxMintableTokenAuthorities {
+		return false, false, fmt.Errorf("Minting authority should have 1 to %d keys", MaxMintableTokenAuthorities)
+	}
+	authorityPks := make(map[string]bool)
+	for _, addressStr := range tokenInit.AuthorityAddresses {
+		keyWallet, err := wallet.Base58CheckDeserialize(addressStr)
+		if err != nil || len(keyWallet.KeySet.PaymentAddress.Pk) == 0 {
+			return false, false, NewMetadataTxError(MintableTokenRequestFromMapError, errors.New("AuthorityAddresses incorrect"))
+		}
+		pkStr := string(keyWallet.KeySet.PaymentAddress.Pk)
+		if authorityPks[pkStr] {
+			return false, false, errors.New("Minting authority has duplicated keys")
+		}
+		authorityPks[pkStr] = true
+	}
+	if tokenInit.RequiredSignatures <= 0 || tokenInit.RequiredSignatures > len(tokenInit.AuthorityAddresses) {
+		return false, false, errors.New("Required signatures should be between 1 and number of authority keys")
+	}
+	return true, true, nil
+}
+
+func (tokenInit MintableTokenInit) ValidateMetadataByItself() bool {
+	return tokenInit.Type == MintableTokenInitMeta
+}
+
+func (tokenInit MintableTokenInit) Hash() *common.Hash {
+	record := tokenInit.MetadataBase.Hash().String()
+	record += tokenInit.TokenName
+	record += tokenInit.TokenSymbol
+	for _, addressStr := range tokenInit.AuthorityAddresses {
+		record += addressStr
+	}
+	record += strconv.Itoa(tokenInit.RequiredSignatures)
+	// final hash
+	hash := common.HashH([]byte(record))
+	return &hash
+}
+
+func (tokenInit *MintableTokenInit) BuildReqActions(tx Transaction, bcr BlockchainRetriever, shardID byte) ([][]string, error) {
+	actionContent := MintableTokenInitAction{
+		Meta:    *tokenInit,
+		TokenID: GetMintableTokenID(*tx.Hash()),
+		TxReqID: *tx.Hash(),
+		ShardID: shardID,
+	}
+	actionContentBytes, err := json.Marshal(actionContent)
+	if err != nil {
+		return [][]string{}, err
+	}
+	actionContentBase64Str := base64.StdEncoding.EncodeToString(actionContentBytes)
+	action := []string{strconv.Itoa(MintableTokenInitMeta), actionContentBase64Str}
+	return [][]string{action}, nil
+}
+
+func (tokenInit *MintableTokenInit) CalculateSize() uint64 {
+	return calculateSize(tokenInit)
+}
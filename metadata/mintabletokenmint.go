@@ -0,0 +1,194 @@
+package metadata
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/common/base58"
+	"github.com/incognitochain/incognito-chain/database"
+	"github.com/incognitochain/incognito-chain/database/lvdb"
+	"github.com/incognitochain/incognito-chain/wallet"
+)
+
+// MintableTokenMintRequest - issue Amount of a mintable token to ReceiverAddressStr,
+// whoever can send this type of tx but it must carry signatures of the minting authority,
+// Nonce must be the next mint nonce of the token so that signatures can not be replayed
+type MintableTokenMintRequest struct {
+	TokenID            common.Hash
+	Amount             uint64
+	ReceiverAddressStr string
+	Nonce              uint64
+	Signatures         []string // base58 check encoded signatures of authority keys on HashForSigning
+	MetadataBase
+}
+
+type MintableTokenMintAction struct {
+	Meta    MintableTokenMintRequest
+	TxReqID common.Hash
+	ShardID byte
+}
+
+type MintableTokenMintAcceptedContent struct {
+	TokenID            common.Hash
+	TokenName          string
+	Amount             uint64
+	ReceiverAddressStr string
+	Nonce              uint64
+	TxReqID            common.Hash
+	ShardID            byte // shard of receiver
+}
+
+func NewMintableTokenMintRequest(
+	tokenID common.Hash,
+	amount uint64,
+	receiverAddressStr string,
+	nonce uint64,
+	signatures []string,
+	metaType int,
+) (*MintableTokenMintRequest, error) {
+	metadataBase := MetadataBase{
+		Type: metaType,
+	}
+	mintReq := &MintableTokenMintRequest{
+		TokenID:            tokenID,
+		Amount:             amount,
+		ReceiverAddressStr: receiverAddressStr,
+		Nonce:              nonce,
+		Signatures:         signatures,
+	}
+	mintReq.MetadataBase = metadataBase
+	return mintReq, nil
+}
+
+// HashForSigning is the data which is signed by keys of the minting authority
+func (mintReq MintableTokenMintRequest) HashForSigning() common.Hash {
+	record := mintReq.TokenID.String()
+	record += strconv.FormatUint(mintReq.Amount, 10)
+	record += mintReq.ReceiverAddressStr
+	record += strconv.FormatUint(mintReq.Nonce, 10)
+	return common.HashH([]byte(record))
+}
+
+// CountApprovals returns number of authority keys of token which signed the request
+func (mintReq MintableTokenMintRequest) CountApprovals(token *lvdb.MintableToken) int {
+	hash := mintReq.HashForSigning()
+	approvals := 0
+	for _, addressStr := range token.AuthorityAddresses {
+		keyWallet, err := wallet.Base58CheckDeserialize(addressStr)
+		if err != nil {
+			continue
+		}
+		for _, sigStr := range mintReq.Signatures {
+			sig, _, err := base58.Base58Check{}.Decode(sigStr)
+			if err != nil {
+				continue
+			}
+			if ok, _ := keyWallet.KeySet.Verify(hash[:], sig); ok {
+				approvals++
+				break
+			}
+		}
+	}
+	return approvals
+}
+
+// GetMintableToken returns the mintable token of tokenID, it returns nil if the token is not found
+func GetMintableToken(db database.DatabaseInterface, tokenID common.Hash) (*lvdb.MintableToken, error) {
+	tokenBytes, err := db.GetMintableToken(tokenID)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokenBytes) == 0 {
+		return nil, nil
+	}
+	var token lvdb.MintableToken
+	if err := json.Unmarshal(tokenBytes, &token); err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+/*
+	Validate Condition to Request Mint With Blockchain
+	- Token is a mintable token
+	- Nonce is not used yet, the exact nonce is checked by beacon because requests of different shards can race
+	- Request is signed by enough keys of the minting authority
+	- Supply does not overflow
+*/
+func (mintReq MintableTokenMintRequest) ValidateTxWithBlockChain(
+	txr Transaction,
+	bcr BlockchainRetriever,
+	shardID byte,
+	db database.DatabaseInterface,
+) (bool, error) {
+	token, err := GetMintableToken(db, mintReq.TokenID)
+	if err != nil {
+		return false, err
+	}
+	if token == nil {
+		return false, NewMetadataTxError(MintableTokenNotFoundError, fmt.Errorf("Token %+v is not a mintable token", mintReq.TokenID.String()))
+	}
+	if mintReq.Nonce < token.MintNonce {
+		return false, NewMetadataTxError(MintableTokenInvalidNonceError, fmt.Errorf("Next mint nonce of token %+v is %+v", mintReq.TokenID.String(), token.MintNonce))
+	}
+	if mintReq.CountApprovals(token) < token.RequiredSignatures {
+		return false, NewMetadataTxError(MintableTokenInvalidSignaturesError, fmt.Errorf("Token %+v requires %+v signatures", mintReq.TokenID.String(), token.RequiredSignatures))
+	}
+	if token.TotalMinted > math.MaxUint64-mintReq.Amount {
+		return false, errors.New("Total minted amount is out of range")
+	}
+	return true, nil
+}
+
+func (mintReq MintableTokenMintRequest) ValidateSanityData(bcr BlockchainRetriever, txr Transaction) (bool, bool, error) {
+	if mintReq.Amount == 0 {
+		return false, false, errors.New("Wrong request info's amount")
+	}
+	keyWallet, err := wallet.Base58CheckDeserialize(mintReq.ReceiverAddressStr)
+	if err != nil || len(keyWallet.KeySet.PaymentAddress.Pk) == 0 {
+		return false, false, NewMetadataTxError(MintableTokenRequestFromMapError, errors.New("ReceiverAddressStr incorrect"))
+	}
+	if len(mintReq.Signatures) == 0 || len(mintReq.Signatures) > MaxMintableTokenAuthorities {
+		return false, false, fmt.Errorf("Mint request should have 1 to %d signatures", MaxMintableTokenAuthorities)
+	}
+	return true, true, nil
+}
+
+func (mintReq MintableTokenMintRequest) ValidateMetadataByItself() bool {
+	return mintReq.Type == MintableTokenMintMeta
+}
+
+func (mintReq MintableTokenMintRequest) Hash() *common.Hash {
+	record := mintReq.MetadataBase.Hash().String()
+	record += mintReq.HashForSigning().String()
+	for _, sigStr := range mintReq.Signatures {
+		record += sigStr
+	}
+	// final hash
+	hash := common.HashH([]byte(record))
+	return &hash
+}
+
+func (mintReq *MintableTokenMintRequest) BuildReqActions(tx Transaction, bcr BlockchainRetriever, shardID byte) ([][]string, error) {
+	actionContent := MintableTokenMintAction{
+		Meta:    *mintReq,
+		TxReqID: *tx.Hash(),
+		ShardID: shardID,
+	}
+	actionContentBytes, err := json.Marshal(actionContent)
+	if err != nil {
+		return [][]string{}, err
+	}
+	actionContentBase64Str := base64.StdEncoding.EncodeToString(actionContentBytes)
+	action := []string{strconv.Itoa(MintableTokenMintMeta), actionContentBase64Str}
+	return [][]string{action}, nil
+}
+
+func (mintReq *MintableTokenMintRequest) CalculateSize() uint64 {
+	return calculateSize(mintReq)
+}
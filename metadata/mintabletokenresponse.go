@@ -0,0 +1,111 @@
+package metadata
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/database"
+	"github.com/incognitochain/incognito-chain/wallet"
+)
+
+// MintableTokenResponse - issuance of an accepted mint request
+type MintableTokenResponse struct {
+	MetadataBase
+	RequestedTxID common.Hash
+}
+
+func NewMintableTokenResponse(requestedTxID common.Hash, metaType int) *MintableTokenResponse {
+	metadataBase := MetadataBase{
+		Type: metaType,
+	}
+	return &MintableTokenResponse{
+		RequestedTxID: requestedTxID,
+		MetadataBase:  metadataBase,
+	}
+}
+
+func (iRes MintableTokenResponse) CheckTransactionFee(tr Transaction, minFee uint64, beaconHeight int64, db database.DatabaseInterface) bool {
+	// no need to have fee for this tx
+	return true
+}
+
+func (iRes MintableTokenResponse) ValidateTxWithBlockChain(txr Transaction, bcr BlockchainRetriever, shardID byte, db database.DatabaseInterface) (bool, error) {
+	// no need to validate tx with blockchain, just need to validate with requested tx (via RequestedTxID)
+	return false, nil
+}
+
+func (iRes MintableTokenResponse) ValidateSanityData(bcr BlockchainRetriever, txr Transaction) (bool, bool, error) {
+	return false, true, nil
+}
+
+func (iRes MintableTokenResponse) ValidateMetadataByItself() bool {
+	// The validation just need to check at tx level, so returning true here
+	return iRes.Type == MintableTokenResponseMeta
+}
+
+func (iRes MintableTokenResponse) Hash() *common.Hash {
+	record := iRes.RequestedTxID.String()
+	record += iRes.MetadataBase.Hash().String()
+
+	// final hash
+	hash := common.HashH([]byte(record))
+	return &hash
+}
+
+func (iRes *MintableTokenResponse) CalculateSize() uint64 {
+	return calculateSize(iRes)
+}
+
+func (iRes MintableTokenResponse) VerifyMinerCreatedTxBeforeGettingInBlock(
+	txsInBlock []Transaction,
+	txsUsed []int,
+	insts [][]string,
+	instUsed []int,
+	shardID byte,
+	tx Transaction,
+	bcr BlockchainRetriever,
+	ac *AccumulatedValues,
+) (bool, error) {
+	idx := -1
+	for i, inst := range insts {
+		if len(inst) < 4 { // this is not mint instruction
+			continue
+		}
+		if instUsed[i] > 0 ||
+			inst[0] != strconv.Itoa(MintableTokenMintMeta) ||
+			inst[2] != common.MintableTokenAcceptedChainStatus {
+			continue
+		}
+		var mintContent MintableTokenMintAcceptedContent
+		err := json.Unmarshal([]byte(inst[3]), &mintContent)
+		if err != nil {
+			Logger.log.Error("WARNING - VALIDATION: an error occured while parsing mintable token mint content: ", err)
+			continue
+		}
+		if !bytes.Equal(iRes.RequestedTxID[:], mintContent.TxReqID[:]) ||
+			shardID != mintContent.ShardID {
+			continue
+		}
+		key, err := wallet.Base58CheckDeserialize(mintContent.ReceiverAddressStr)
+		if err != nil {
+			Logger.log.Info("WARNING - VALIDATION: an error occured while deserializing receiver address string: ", err)
+			continue
+		}
+		_, pk, amount, assetID := tx.GetTransferData()
+		if !bytes.Equal(key.KeySet.PaymentAddress.Pk[:], pk[:]) ||
+			mintContent.Amount != amount ||
+			!bytes.Equal(mintContent.TokenID[:], assetID[:]) {
+			continue
+		}
+		idx = i
+		break
+	}
+	if idx == -1 { // not found the mint request tx for this response
+		return false, fmt.Errorf("no MintableTokenMintRequest tx found for the MintableTokenResponse tx %s", tx.Hash().String())
+	}
+	instUsed[idx] = 1
+	return true, nil
+}
@@ -0,0 +1,167 @@
+package metadata
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"sort"
+
+	"github.com/incognitochain/incognito-chain/database/lvdb"
+)
+
+// DefaultPDETradeSlippagePercent is used for suggested min acceptable amount of a quote if no slippage is given
+const DefaultPDETradeSlippagePercent = float64(1)
+
+// PDETradeQuote is the expected result of a trade request if it is executed in the next beacon block
+type PDETradeQuote struct {
+	TokenIDToBuyStr  string
+	TokenIDToSellStr string
+	SellAmount       uint64
+	TradingFee       uint64
+	// true if beacon would refund the trade, receive amount is still filled if the pool can fill it
+	IsRefunded    bool
+	ReceiveAmount uint64
+	// number of pending trades of the pool pair which are executed before this trade
+	PendingTradesAhead int
+	// amount of token to buy for one token to sell before the pending trades
+	SpotPrice      float64
+	ExecutionPrice float64
+	// decrease of execution price from spot price, including the effect of pending trades ahead
+	PriceImpactPercent           float64
+	SuggestedMinAcceptableAmount uint64
+}
+
+// CalculatePDETradeAmounts price a trade by constant product of the pool pair like beacon does,
+// it returns receive amount and pool values after the trade, trading fee is added to the pool of token to sell.
+// ok is false if the pool can not fill the trade
+func CalculatePDETradeAmounts(
+	tokenPoolValueToBuy uint64,
+	tokenPoolValueToSell uint64,
+	sellAmount uint64,
+	tradingFee uint64,
+) (receiveAmount uint64, newTokenPoolValueToBuy uint64, newTokenPoolValueToSell uint64, ok bool) {
+	invariant := big.NewInt(0)
+	invariant.Mul(big.NewInt(int64(tokenPoolValueToSell)), big.NewInt(int64(tokenPoolValueToBuy)))
+	newTokenPoolValueToSellBig := big.NewInt(0)
+	newTokenPoolValueToSellBig.Add(big.NewInt(int64(tokenPoolValueToSell)), big.NewInt(int64(sellAmount)))
+
+	newTokenPoolValueToBuy = big.NewInt(0).Div(invariant, newTokenPoolValueToSellBig).Uint64()
+	modValue := big.NewInt(0).Mod(invariant, newTokenPoolValueToSellBig)
+	if modValue.Cmp(big.NewInt(0)) != 0 {
+		newTokenPoolValueToBuy++
+	}
+	if tokenPoolValueToBuy <= newTokenPoolValueToBuy {
+		return 0, 0, 0, false
+	}
+	newTokenPoolValueToSellBig.Add(newTokenPoolValueToSellBig, big.NewInt(int64(tradingFee)))
+	return tokenPoolValueToBuy - newTokenPoolValueToBuy, newTokenPoolValueToBuy, newTokenPoolValueToSellBig.Uint64(), true
+}
+
+// HasHigherPDETradingFeeRate return true if trading fee per sell amount of the first trade is higher,
+// beacon executes trades of a pool pair from the highest rate
+func HasHigherPDETradingFeeRate(tradingFee1 uint64, sellAmount1 uint64, tradingFee2 uint64, sellAmount2 uint64) bool {
+	// comparing a/b to c/d is equivalent with comparing a*d to c*b
+	firstItemProportion := big.NewInt(0)
+	firstItemProportion.Mul(
+		big.NewInt(int64(tradingFee1)),
+		big.NewInt(int64(sellAmount2)),
+	)
+	secondItemProportion := big.NewInt(0)
+	secondItemProportion.Mul(
+		big.NewInt(int64(tradingFee2)),
+		big.NewInt(int64(sellAmount1)),
+	)
+	return firstItemProportion.Cmp(secondItemProportion) == 1
+}
+
+// SortPDETradeRequestsByFee sort trades of a pool pair in the order beacon executes them
+func SortPDETradeRequestsByFee(trades []*PDETradeRequest) {
+	sort.Slice(trades, func(i, j int) bool {
+		return HasHigherPDETradingFeeRate(trades[i].TradingFee, trades[i].SellAmount, trades[j].TradingFee, trades[j].SellAmount)
+	})
+}
+
+func isPDETradeOnPoolPair(trade *PDETradeRequest, poolPair *lvdb.PDEPoolForPair) bool {
+	return (trade.TokenIDToBuyStr == poolPair.Token1IDStr && trade.TokenIDToSellStr == poolPair.Token2IDStr) ||
+		(trade.TokenIDToBuyStr == poolPair.Token2IDStr && trade.TokenIDToSellStr == poolPair.Token1IDStr)
+}
+
+// QuotePDETrade simulate trade on poolPair with the pricing of beacon, pending trades of the pool pair with
+// a higher trading fee rate are executed first, those with the same rate are assumed to be executed first too.
+// Suggested min acceptable amount is receive amount decreased by slippagePercent
+func QuotePDETrade(
+	poolPair *lvdb.PDEPoolForPair,
+	trade *PDETradeRequest,
+	pendingTrades []*PDETradeRequest,
+	slippagePercent float64,
+) (*PDETradeQuote, error) {
+	if poolPair == nil {
+		return nil, errors.New("pool pair is not found")
+	}
+	if trade == nil || !isPDETradeOnPoolPair(trade, poolPair) {
+		return nil, errors.New("trade request is not on the pool pair")
+	}
+	if trade.SellAmount == 0 {
+		return nil, errors.New("sell amount must be greater than 0")
+	}
+	if slippagePercent < 0 || slippagePercent > 100 {
+		return nil, errors.New("slippage percent must be in [0, 100]")
+	}
+	poolValues := map[string]uint64{
+		poolPair.Token1IDStr: poolPair.Token1PoolValue,
+		poolPair.Token2IDStr: poolPair.Token2PoolValue,
+	}
+	quote := &PDETradeQuote{
+		TokenIDToBuyStr:  trade.TokenIDToBuyStr,
+		TokenIDToSellStr: trade.TokenIDToSellStr,
+		SellAmount:       trade.SellAmount,
+		TradingFee:       trade.TradingFee,
+	}
+	if poolValues[trade.TokenIDToSellStr] == 0 || poolValues[trade.TokenIDToBuyStr] == 0 {
+		quote.IsRefunded = true
+		return quote, nil
+	}
+	quote.SpotPrice = float64(poolValues[trade.TokenIDToBuyStr]) / float64(poolValues[trade.TokenIDToSellStr])
+
+	tradesAhead := []*PDETradeRequest{}
+	for _, pendingTrade := range pendingTrades {
+		if pendingTrade != nil && isPDETradeOnPoolPair(pendingTrade, poolPair) &&
+			!HasHigherPDETradingFeeRate(trade.TradingFee, trade.SellAmount, pendingTrade.TradingFee, pendingTrade.SellAmount) {
+			tradesAhead = append(tradesAhead, pendingTrade)
+		}
+	}
+	SortPDETradeRequestsByFee(tradesAhead)
+	quote.PendingTradesAhead = len(tradesAhead)
+	for _, pendingTrade := range tradesAhead {
+		receiveAmount, newTokenPoolValueToBuy, newTokenPoolValueToSell, ok := CalculatePDETradeAmounts(
+			poolValues[pendingTrade.TokenIDToBuyStr],
+			poolValues[pendingTrade.TokenIDToSellStr],
+			pendingTrade.SellAmount,
+			pendingTrade.TradingFee,
+		)
+		if !ok || pendingTrade.MinAcceptableAmount > receiveAmount {
+			continue
+		}
+		poolValues[pendingTrade.TokenIDToBuyStr] = newTokenPoolValueToBuy
+		poolValues[pendingTrade.TokenIDToSellStr] = newTokenPoolValueToSell
+	}
+
+	receiveAmount, _, _, ok := CalculatePDETradeAmounts(
+		poolValues[trade.TokenIDToBuyStr],
+		poolValues[trade.TokenIDToSellStr],
+		trade.SellAmount,
+		trade.TradingFee,
+	)
+	if !ok {
+		quote.IsRefunded = true
+		return quote, nil
+	}
+	quote.IsRefunded = trade.MinAcceptableAmount > receiveAmount
+	quote.ReceiveAmount = receiveAmount
+	quote.ExecutionPrice = float64(receiveAmount) / float64(trade.SellAmount)
+	quote.PriceImpactPercent = (quote.SpotPrice - quote.ExecutionPrice) / quote.SpotPrice * 100
+	slippageBasisPoints := int64(math.Round(slippagePercent * 100))
+	suggestedMinAmount := big.NewInt(0).Mul(new(big.Int).SetUint64(receiveAmount), big.NewInt(10000-slippageBasisPoints))
+	quote.SuggestedMinAcceptableAmount = suggestedMinAmount.Div(suggestedMinAmount, big.NewInt(10000)).Uint64()
+	return quote, nil
+}
@@ -0,0 +1,351 @@
+package metadata
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/database"
+	"github.com/incognitochain/incognito-chain/database/lvdb"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+	"github.com/incognitochain/incognito-chain/wallet"
+)
+
+/*
+PrivacyTokenRegistration - register metadata of a privacy custom token or update it
+  - a token initialized by a tx (privacy token or mintable token) is registered by the signer of its init tx,
+    the first accepted registration binds the token to its issuer address and only the issuer can update it later
+  - a bridge token has no issuer, its registration must carry signatures of more than 2/3 of the beacon committee
+    by their bridge keys, Nonce must be the next registration nonce of the token so that signatures can not be replayed
+*/
+type PrivacyTokenRegistration struct {
+	TokenID             string
+	TokenName           string
+	TokenSymbol         string
+	Decimals            uint8
+	Description         string
+	IconHash            string // hex encoded sha256 hash of token icon
+	ExternalNetwork     string // network of the external token which the token maps to, ex: ETH
+	ExternalTokenID     string // hex encoded contract address of external token
+	IssuerAddressStr    string // empty for bridge tokens
+	Nonce               uint64
+	CommitteeSignatures []string // base58 check encoded signatures of bridge keys on HashForSigning, only for bridge tokens
+	MetadataBase
+}
+
+type PrivacyTokenRegistrationAction struct {
+	Meta    PrivacyTokenRegistration
+	TxReqID common.Hash
+	ShardID byte
+}
+
+func NewPrivacyTokenRegistration(
+	tokenID string,
+	tokenName string,
+	tokenSymbol string,
+	decimals uint8,
+	description string,
+	iconHash string,
+	externalNetwork string,
+	externalTokenID string,
+	issuerAddressStr string,
+	nonce uint64,
+	committeeSignatures []string,
+	metaType int,
+) (*PrivacyTokenRegistration, error) {
+	metadataBase := MetadataBase{
+		Type: metaType,
+	}
+	registration := &PrivacyTokenRegistration{
+		TokenID:             tokenID,
+		TokenName:           tokenName,
+		TokenSymbol:         tokenSymbol,
+		Decimals:            decimals,
+		Description:         description,
+		IconHash:            iconHash,
+		ExternalNetwork:     externalNetwork,
+		ExternalTokenID:     externalTokenID,
+		IssuerAddressStr:    issuerAddressStr,
+		Nonce:               nonce,
+		CommitteeSignatures: committeeSignatures,
+	}
+	registration.MetadataBase = metadataBase
+	return registration, nil
+}
+
+// IsSignedByCommittee returns true if registration is of a bridge token which is approved by signatures of the beacon committee
+func (registration PrivacyTokenRegistration) IsSignedByCommittee() bool {
+	return len(registration.CommitteeSignatures) > 0
+}
+
+// HashForSigning is the data which is signed by bridge keys of the beacon committee
+func (registration PrivacyTokenRegistration) HashForSigning() common.Hash {
+	record := registration.TokenID
+	record += registration.TokenName
+	record += registration.TokenSymbol
+	record += strconv.Itoa(int(registration.Decimals))
+	record += registration.Description
+	record += registration.IconHash
+	record += registration.ExternalNetwork
+	record += registration.ExternalTokenID
+	record += strconv.FormatUint(registration.Nonce, 10)
+	return common.HashH([]byte(record))
+}
+
+// IsApprovedByCommittee returns true if more than 2/3 of committee signed the registration
+func (registration PrivacyTokenRegistration) IsApprovedByCommittee(committee []incognitokey.CommitteePublicKey) bool {
+	return isApprovedByCommittee(registration.HashForSigning(), registration.CommitteeSignatures, committee)
+}
+
+/*
+Validate Condition to Request Privacy Token Registration With Blockchain
+  - Token is issued on network
+  - A bridge token is registered with signatures of the committee and its external token must be the one of bridge,
+    signatures and the exact nonce are checked by beacon because the committee may change before the request is processed
+  - Otherwise the first registration must be sent by the signer of init tx of the token,
+    init tx is in the shard of its signer which is also the shard of the registration
+  - A registered token can only be updated by its issuer
+
+Symbol conflicts are checked by beacon because registrations of different shards can race
+*/
+func (registration PrivacyTokenRegistration) ValidateTxWithBlockChain(
+	txr Transaction,
+	bcr BlockchainRetriever,
+	shardID byte,
+	db database.DatabaseInterface,
+) (bool, error) {
+	tokenID, err := common.Hash{}.NewHashFromStr(registration.TokenID)
+	if err != nil {
+		return false, NewMetadataTxError(PrivacyTokenRegistrationFromMapError, err)
+	}
+	bridgeToken, err := GetBridgeTokenInfo(db, *tokenID)
+	if err != nil {
+		return false, err
+	}
+	var registry *lvdb.PrivacyTokenRegistry
+	registryBytes, err := db.GetPrivacyTokenRegistry(*tokenID)
+	if err != nil {
+		return false, err
+	}
+	if len(registryBytes) > 0 {
+		registry = new(lvdb.PrivacyTokenRegistry)
+		if err := json.Unmarshal(registryBytes, registry); err != nil {
+			return false, err
+		}
+	}
+
+	if bridgeToken != nil {
+		if !registration.IsSignedByCommittee() {
+			return false, NewMetadataTxError(PrivacyTokenRegistrationInvalidSignaturesError, fmt.Errorf("Token %+v is a bridge token", registration.TokenID))
+		}
+		if registration.ExternalTokenID != "" && !IsSameExternalTokenID(bridgeToken.ExternalTokenID, registration.ExternalTokenID) {
+			return false, NewMetadataTxError(PrivacyTokenRegistrationExternalTokenError, fmt.Errorf("External token of bridge token %+v is %+v", registration.TokenID, hex.EncodeToString(bridgeToken.ExternalTokenID)))
+		}
+		if registry != nil && registration.Nonce < registry.Nonce {
+			return false, NewMetadataTxError(PrivacyTokenRegistrationInvalidNonceError, fmt.Errorf("Next registration nonce of token %+v is %+v", registration.TokenID, registry.Nonce))
+		}
+		return true, nil
+	}
+	if registration.IsSignedByCommittee() {
+		return false, NewMetadataTxError(PrivacyTokenRegistrationInvalidSenderError, fmt.Errorf("Token %+v is not a bridge token, it must be registered by its issuer", registration.TokenID))
+	}
+
+	if registry != nil {
+		if registry.IssuerAddressStr != registration.IssuerAddressStr {
+			return false, NewMetadataTxError(PrivacyTokenRegistrationInvalidSenderError, fmt.Errorf("Token %+v is registered by another issuer", registration.TokenID))
+		}
+		return true, nil
+	}
+	initTxSigner, err := getTokenInitTxSigner(bcr, db, *tokenID)
+	if err != nil {
+		return false, err
+	}
+	keyWallet, err := wallet.Base58CheckDeserialize(registration.IssuerAddressStr)
+	if err != nil {
+		return false, NewMetadataTxError(PrivacyTokenRegistrationFromMapError, errors.New("IssuerAddressStr incorrect"))
+	}
+	if !bytes.Equal(initTxSigner, keyWallet.KeySet.PaymentAddress.Pk) {
+		return false, NewMetadataTxError(PrivacyTokenRegistrationInvalidSenderError, fmt.Errorf("Issuer of token %+v is not the signer of its init tx", registration.TokenID))
+	}
+	return true, nil
+}
+
+// getTokenInitTxSigner returns public key which signed init tx of a privacy token or a mintable token,
+// it returns error if init tx is not in shards of this node
+func getTokenInitTxSigner(bcr BlockchainRetriever, db database.DatabaseInterface, tokenID common.Hash) ([]byte, error) {
+	initTxHash := common.Hash{}
+	initTxBytes, err := db.GetPrivacyTokenInitTx(tokenID)
+	if err != nil {
+		return nil, err
+	}
+	if len(initTxBytes) > 0 {
+		if err := initTxHash.SetBytes(initTxBytes); err != nil {
+			return nil, err
+		}
+	} else {
+		mintableTokenBytes, err := db.GetMintableToken(tokenID)
+		if err != nil {
+			return nil, err
+		}
+		if len(mintableTokenBytes) == 0 {
+			return nil, NewMetadataTxError(PrivacyTokenRegistrationTokenNotFoundError, fmt.Errorf("Token %+v is not initialized in this shard", tokenID.String()))
+		}
+		var mintableToken lvdb.MintableToken
+		if err := json.Unmarshal(mintableTokenBytes, &mintableToken); err != nil {
+			return nil, err
+		}
+		initTxHash = mintableToken.InitTxReqID
+	}
+	_, _, _, initTx, err := bcr.GetTransactionByHash(initTxHash)
+	if err != nil {
+		return nil, NewMetadataTxError(PrivacyTokenRegistrationTokenNotFoundError, fmt.Errorf("Init tx %+v of token %+v is not in this shard", initTxHash.String(), tokenID.String()))
+	}
+	return initTx.GetSigPubKey(), nil
+}
+
+func (registration PrivacyTokenRegistration) ValidateSanityData(bcr BlockchainRetriever, txr Transaction) (bool, bool, error) {
+	if txr.IsPrivacy() {
+		return false, false, errors.New("Privacy Token Registration Transaction Is No Privacy Transaction")
+	}
+	if registration.IsSignedByCommittee() {
+		// whoever can send registration of a bridge token, it is approved by the committee
+		if registration.IssuerAddressStr != "" {
+			return false, false, NewMetadataTxError(PrivacyTokenRegistrationFromMapError, errors.New("Bridge token has no issuer"))
+		}
+		if len(registration.CommitteeSignatures) > MaxBridgeControlSignatures {
+			return false, false, NewMetadataTxError(PrivacyTokenRegistrationFromMapError, fmt.Errorf("Registration should have at most %d signatures", MaxBridgeControlSignatures))
+		}
+	} else {
+		keyWallet, err := wallet.Base58CheckDeserialize(registration.IssuerAddressStr)
+		if err != nil {
+			return false, false, NewMetadataTxError(PrivacyTokenRegistrationFromMapError, errors.New("IssuerAddressStr incorrect"))
+		}
+		issuerAddr := keyWallet.KeySet.PaymentAddress
+		if len(issuerAddr.Pk) == 0 {
+			return false, false, errors.New("Wrong request info's issuer address")
+		}
+		// tx is signed by issuer
+		if !bytes.Equal(txr.GetSigPubKey()[:], issuerAddr.Pk[:]) {
+			return false, false, NewMetadataTxError(PrivacyTokenRegistrationInvalidSenderError, errors.New("IssuerAddress incorrect"))
+		}
+	}
+	tokenID, err := common.Hash{}.NewHashFromStr(registration.TokenID)
+	if err != nil {
+		return false, false, NewMetadataTxError(PrivacyTokenRegistrationFromMapError, errors.New("TokenID incorrect"))
+	}
+	if tokenID.IsEqual(&common.PRVCoinID) {
+		return false, false, errors.New("PRV can not be registered")
+	}
+	if registration.TokenName == "" || len(registration.TokenName) > MaxPrivacyTokenNameLength {
+		return false, false, fmt.Errorf("Token name should not be empty or longer than %d", MaxPrivacyTokenNameLength)
+	}
+	if !IsValidPrivacyTokenSymbol(registration.TokenSymbol) {
+		return false, false, fmt.Errorf("Token symbol should have 1 to %d letters or digits", MaxPrivacyTokenSymbolLength)
+	}
+	if registration.Decimals > MaxPrivacyTokenDecimals {
+		return false, false, fmt.Errorf("Decimals should not be larger than %d", MaxPrivacyTokenDecimals)
+	}
+	if len(registration.Description) > MaxPrivacyTokenDescriptionLength {
+		return false, false, fmt.Errorf("Description should not be longer than %d", MaxPrivacyTokenDescriptionLength)
+	}
+	if registration.IconHash != "" {
+		iconHash, err := hex.DecodeString(registration.IconHash)
+		if err != nil || len(iconHash) != common.HashSize {
+			return false, false, errors.New("Icon hash should be a hex encoded sha256 hash")
+		}
+	}
+	if (registration.ExternalNetwork == "") != (registration.ExternalTokenID == "") {
+		return false, false, errors.New("External network and external token id should be set together")
+	}
+	if registration.ExternalTokenID != "" {
+		if _, err := hex.DecodeString(strings.TrimPrefix(registration.ExternalTokenID, "0x")); err != nil {
+			return false, false, errors.New("External token id should be hex encoded")
+		}
+	}
+	return true, true, nil
+}
+
+func (registration PrivacyTokenRegistration) ValidateMetadataByItself() bool {
+	return registration.Type == PrivacyTokenRegistrationMeta
+}
+
+func (registration PrivacyTokenRegistration) Hash() *common.Hash {
+	record := registration.MetadataBase.Hash().String()
+	record += registration.TokenID
+	record += registration.TokenName
+	record += registration.TokenSymbol
+	record += strconv.Itoa(int(registration.Decimals))
+	record += registration.Description
+	record += registration.IconHash
+	record += registration.ExternalNetwork
+	record += registration.ExternalTokenID
+	record += registration.IssuerAddressStr
+	record += strconv.FormatUint(registration.Nonce, 10)
+	for _, sigStr := range registration.CommitteeSignatures {
+		record += sigStr
+	}
+	// final hash
+	hash := common.HashH([]byte(record))
+	return &hash
+}
+
+func (registration *PrivacyTokenRegistration) BuildReqActions(tx Transaction, bcr BlockchainRetriever, shardID byte) ([][]string, error) {
+	actionContent := PrivacyTokenRegistrationAction{
+		Meta:    *registration,
+		TxReqID: *tx.Hash(),
+		ShardID: shardID,
+	}
+	actionContentBytes, err := json.Marshal(actionContent)
+	if err != nil {
+		return [][]string{}, err
+	}
+	actionContentBase64Str := base64.StdEncoding.EncodeToString(actionContentBytes)
+	action := []string{strconv.Itoa(PrivacyTokenRegistrationMeta), actionContentBase64Str}
+	return [][]string{action}, nil
+}
+
+func (registration *PrivacyTokenRegistration) CalculateSize() uint64 {
+	return calculateSize(registration)
+}
+
+// IsValidPrivacyTokenSymbol checks symbol has 1 to MaxPrivacyTokenSymbolLength ascii letters or digits
+func IsValidPrivacyTokenSymbol(symbol string) bool {
+	if len(symbol) == 0 || len(symbol) > MaxPrivacyTokenSymbolLength {
+		return false
+	}
+	for _, c := range symbol {
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9') {
+			return false
+		}
+	}
+	return true
+}
+
+// GetBridgeTokenInfo returns bridge info of tokenID, it returns nil if tokenID is not a bridge token
+func GetBridgeTokenInfo(db database.DatabaseInterface, tokenID common.Hash) (*lvdb.BridgeTokenInfo, error) {
+	allBridgeTokensBytes, err := db.GetAllBridgeTokens()
+	if err != nil {
+		return nil, err
+	}
+	var allBridgeTokens []*lvdb.BridgeTokenInfo
+	if err := json.Unmarshal(allBridgeTokensBytes, &allBridgeTokens); err != nil {
+		return nil, err
+	}
+	for _, token := range allBridgeTokens {
+		if token.TokenID != nil && token.TokenID.IsEqual(&tokenID) {
+			return token, nil
+		}
+	}
+	return nil, nil
+}
+
+// IsSameExternalTokenID compares external token id of bridge with a hex string, with or without 0x prefix
+func IsSameExternalTokenID(externalTokenID []byte, externalTokenIDStr string) bool {
+	return strings.EqualFold(hex.EncodeToString(externalTokenID), strings.TrimPrefix(externalTokenIDStr, "0x"))
+}
@@ -0,0 +1,139 @@
+package metadata
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/btcsuite/btcd/wire"
+	"github.com/incognitochain/incognito-chain/blockchain/btc"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/database"
+)
+
+// RelayingBTCHeaders - relay bitcoin headers to beacon, anyone can send this type of tx.
+// Headers are in hex of their 80 bytes serialization, each header is the parent of the next one.
+// Shards check proof of work of each header, beacon checks that the headers extend the relayed header chain
+// with the required difficulty
+type RelayingBTCHeaders struct {
+	HeaderStrs []string
+	MetadataBase
+}
+
+type RelayingBTCHeadersAction struct {
+	Meta    RelayingBTCHeaders
+	TxReqID common.Hash
+	ShardID byte
+}
+
+func NewRelayingBTCHeaders(
+	headerStrs []string,
+	metaType int,
+) (*RelayingBTCHeaders, error) {
+	metadataBase := MetadataBase{
+		Type: metaType,
+	}
+	relayingHeaders := &RelayingBTCHeaders{
+		HeaderStrs: headerStrs,
+	}
+	relayingHeaders.MetadataBase = metadataBase
+	return relayingHeaders, nil
+}
+
+func NewRelayingBTCHeadersFromMap(
+	data map[string]interface{},
+) (*RelayingBTCHeaders, error) {
+	headersRaw, ok := data["HeaderStrs"].([]interface{})
+	if !ok {
+		return nil, NewMetadataTxError(RelayingBTCHeadersFromMapError, errors.New("HeaderStrs is invalid"))
+	}
+	headerStrs := []string{}
+	for _, item := range headersRaw {
+		headerStr, ok := item.(string)
+		if !ok {
+			return nil, NewMetadataTxError(RelayingBTCHeadersFromMapError, errors.New("HeaderStrs is invalid"))
+		}
+		headerStrs = append(headerStrs, headerStr)
+	}
+	return NewRelayingBTCHeaders(headerStrs, RelayingBTCHeadersMeta)
+}
+
+func (relayingHeaders RelayingBTCHeaders) ValidateTxWithBlockChain(
+	txr Transaction,
+	bcr BlockchainRetriever,
+	shardID byte,
+	db database.DatabaseInterface,
+) (bool, error) {
+	// headers are validated with the relayed header chain by beacon
+	return true, nil
+}
+
+func (relayingHeaders RelayingBTCHeaders) ValidateSanityData(bcr BlockchainRetriever, txr Transaction) (bool, bool, error) {
+	if len(relayingHeaders.HeaderStrs) == 0 || len(relayingHeaders.HeaderStrs) > MaxRelayingBTCHeaders {
+		return false, false, NewMetadataTxError(RelayingBTCHeadersValidateSanityDataError, fmt.Errorf("number of headers must be in [1, %d]", MaxRelayingBTCHeaders))
+	}
+	_, err := ParseRelayingBTCHeaders(relayingHeaders.HeaderStrs)
+	if err != nil {
+		return false, false, NewMetadataTxError(RelayingBTCHeadersValidateSanityDataError, err)
+	}
+	return true, true, nil
+}
+
+func (relayingHeaders RelayingBTCHeaders) ValidateMetadataByItself() bool {
+	return relayingHeaders.Type == RelayingBTCHeadersMeta
+}
+
+func (relayingHeaders RelayingBTCHeaders) Hash() *common.Hash {
+	record := relayingHeaders.MetadataBase.Hash().String()
+	for _, headerStr := range relayingHeaders.HeaderStrs {
+		record += headerStr
+	}
+	// final hash
+	hash := common.HashH([]byte(record))
+	return &hash
+}
+
+func (relayingHeaders *RelayingBTCHeaders) BuildReqActions(tx Transaction, bcr BlockchainRetriever, shardID byte) ([][]string, error) {
+	actionContent := RelayingBTCHeadersAction{
+		Meta:    *relayingHeaders,
+		TxReqID: *tx.Hash(),
+		ShardID: shardID,
+	}
+	actionContentBytes, err := json.Marshal(actionContent)
+	if err != nil {
+		return [][]string{}, err
+	}
+	actionContentBase64Str := base64.StdEncoding.EncodeToString(actionContentBytes)
+	action := []string{strconv.Itoa(RelayingBTCHeadersMeta), actionContentBase64Str}
+	return [][]string{action}, nil
+}
+
+func (relayingHeaders *RelayingBTCHeaders) CalculateSize() uint64 {
+	return calculateSize(relayingHeaders)
+}
+
+// ParseRelayingBTCHeaders decodes relayed headers and checks proof of work of each one with its own bits
+// and that each header is the parent of the next one
+func ParseRelayingBTCHeaders(headerStrs []string) ([]*wire.BlockHeader, error) {
+	headers := []*wire.BlockHeader{}
+	for i, headerStr := range headerStrs {
+		header, err := btc.ParseBTCHeader(headerStr)
+		if err != nil {
+			return nil, err
+		}
+		err = btc.CheckProofOfWork(header, nil)
+		if err != nil {
+			return nil, err
+		}
+		if i > 0 {
+			parentHash := headers[i-1].BlockHash()
+			if !header.PrevBlock.IsEqual(&parentHash) {
+				return nil, fmt.Errorf("header %d is not a child of header %d", i, i-1)
+			}
+		}
+		headers = append(headers, header)
+	}
+	return headers, nil
+}
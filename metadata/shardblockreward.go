@@ -12,6 +12,9 @@ import (
 type ShardBlockRewardInfo struct {
 	ShardReward map[common.Hash]uint64
 	Epoch       uint64
+	// committee keys bound to staking pools, their shares were already
+	// deducted from ShardReward and are paid by staking pool reward instructions
+	PoolCommitteeKeys []string `json:",omitempty"`
 }
 
 type AcceptedBlockRewardInfo struct {
@@ -37,11 +40,12 @@ type AcceptedBlockRewardInfo struct {
 // 	}
 // }
 
-func BuildInstForShardReward(reward map[common.Hash]uint64, epoch uint64, shardID byte) ([][]string, error) {
+func BuildInstForShardReward(reward map[common.Hash]uint64, epoch uint64, shardID byte, poolCommitteeKeys []string) ([][]string, error) {
 	resIns := [][]string{}
 	shardBlockRewardInfo := ShardBlockRewardInfo{
-		Epoch:       epoch,
-		ShardReward: reward,
+		Epoch:             epoch,
+		ShardReward:       reward,
+		PoolCommitteeKeys: poolCommitteeKeys,
 	}
 
 	contentStr, err := json.Marshal(shardBlockRewardInfo)
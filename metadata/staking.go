@@ -102,6 +102,16 @@ func (stakingMetadata StakingMetadata) ValidateTxWithBlockChain(
 	if len(tempStaker) == 0 {
 		return false, errors.New("invalid Staker, This pubkey may staked already")
 	}
+	if _, ok := bcr.GetUnbondingStakes()[stakingMetadata.CommitteePublicKey]; ok {
+		return false, errors.New("invalid Staker, Stake of this pubkey is unbonding")
+	}
+	stakingPoolBytes, err := db.GetStakingPool(stakingMetadata.CommitteePublicKey)
+	if err != nil {
+		return false, err
+	}
+	if len(stakingPoolBytes) > 0 {
+		return false, errors.New("invalid Staker, This pubkey is bound to a staking pool")
+	}
 	return true, nil
 }
 
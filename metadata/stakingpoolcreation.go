@@ -0,0 +1,174 @@
+package metadata
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/common/base58"
+	"github.com/incognitochain/incognito-chain/consensus/signatureschemes/bridgesig"
+	"github.com/incognitochain/incognito-chain/database"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+	"github.com/incognitochain/incognito-chain/wallet"
+)
+
+// StakingPoolCreation - create a staking pool bound to a committee public key,
+// the key will be staked by beacon once delegations reach the shard staking amount
+type StakingPoolCreation struct {
+	CommitteePublicKey    string
+	OperatorAddressStr    string
+	CommissionRate        uint64 // in basis points
+	CommitteeKeySignature string // base58 check encoded signature of the bridge key of the committee key on HashForSigning
+	MetadataBase
+}
+
+type StakingPoolCreationAction struct {
+	Meta    StakingPoolCreation
+	TxReqID common.Hash
+	ShardID byte
+}
+
+type StakingPoolCreationAcceptedContent struct {
+	CommitteePublicKey string
+	OperatorAddressStr string
+	CommissionRate     uint64
+	TxReqID            common.Hash
+	ShardID            byte
+}
+
+func NewStakingPoolCreation(
+	committeePublicKey string,
+	operatorAddressStr string,
+	commissionRate uint64,
+	committeeKeySignature string,
+	metaType int,
+) (*StakingPoolCreation, error) {
+	metadataBase := MetadataBase{
+		Type: metaType,
+	}
+	stakingPoolCreation := &StakingPoolCreation{
+		CommitteePublicKey:    committeePublicKey,
+		OperatorAddressStr:    operatorAddressStr,
+		CommissionRate:        commissionRate,
+		CommitteeKeySignature: committeeKeySignature,
+	}
+	stakingPoolCreation.MetadataBase = metadataBase
+	return stakingPoolCreation, nil
+}
+
+/*
+	Validate Condition to Request Staking Pool Creation With Blockchain
+	- Requested Committee Publickey is not in any committee or candidate list
+	- Requested Committee Publickey is not bound to any staking pool
+*/
+func (sp StakingPoolCreation) ValidateTxWithBlockChain(
+	txr Transaction,
+	bcr BlockchainRetriever,
+	shardID byte,
+	db database.DatabaseInterface,
+) (bool, error) {
+	committees, err := bcr.GetAllCommitteeValidatorCandidateFlattenListFromDatabase()
+	if err != nil {
+		return false, err
+	}
+	if common.IndexOfStr(sp.CommitteePublicKey, committees) > -1 {
+		return false, NewMetadataTxError(StakingPoolRequestAlreadyStakedError, fmt.Errorf("Committee Publickey %+v already staked", sp.CommitteePublicKey))
+	}
+	poolBytes, err := db.GetStakingPool(sp.CommitteePublicKey)
+	if err != nil {
+		return false, err
+	}
+	if len(poolBytes) > 0 {
+		return false, NewMetadataTxError(StakingPoolRequestAlreadyStakedError, fmt.Errorf("Committee Publickey %+v already bound to a staking pool", sp.CommitteePublicKey))
+	}
+	return true, nil
+}
+
+func (sp StakingPoolCreation) ValidateSanityData(bcr BlockchainRetriever, txr Transaction) (bool, bool, error) {
+	if txr.IsPrivacy() {
+		return false, false, errors.New("Staking Pool Creation Transaction Is No Privacy Transaction")
+	}
+	keyWallet, err := wallet.Base58CheckDeserialize(sp.OperatorAddressStr)
+	if err != nil {
+		return false, false, NewMetadataTxError(StakingPoolRequestFromMapError, errors.New("OperatorAddressStr incorrect"))
+	}
+	operatorAddr := keyWallet.KeySet.PaymentAddress
+	if len(operatorAddr.Pk) == 0 {
+		return false, false, errors.New("Wrong request info's operator address")
+	}
+	if !bytes.Equal(txr.GetSigPubKey()[:], operatorAddr.Pk[:]) {
+		return false, false, NewMetadataTxError(StakingPoolRequestInvalidSenderError, errors.New("OperatorAddress incorrect"))
+	}
+	if !txr.IsCoinsBurning(bcr) {
+		return false, false, errors.New("Must send coin to burning address")
+	}
+	if txr.CalculateTxValue() != StopAutoStakingAmount {
+		return false, false, errors.New("Staking pool creation should burn zero amount")
+	}
+	if sp.CommissionRate > MaxStakingPoolCommissionRate {
+		return false, false, fmt.Errorf("Commission rate should not be larger than %d", MaxStakingPoolCommissionRate)
+	}
+	committeePublicKey := new(incognitokey.CommitteePublicKey)
+	err = committeePublicKey.FromString(sp.CommitteePublicKey)
+	if err != nil {
+		return false, false, err
+	}
+	if !committeePublicKey.CheckSanityData() {
+		return false, false, errors.New("Invalid Commitee Public Key of staking pool")
+	}
+	// the operator has to prove that it controls the committee key, otherwise anyone could collect its commission
+	sig, _, err := base58.Base58Check{}.Decode(sp.CommitteeKeySignature)
+	if err != nil {
+		return false, false, NewMetadataTxError(StakingPoolRequestInvalidSignatureError, err)
+	}
+	hash := sp.HashForSigning()
+	if ok, _ := bridgesig.Verify(committeePublicKey.MiningPubKey[common.BridgeConsensus], hash[:], sig); !ok {
+		return false, false, NewMetadataTxError(StakingPoolRequestInvalidSignatureError, errors.New("Committee key signature of staking pool is invalid"))
+	}
+	return true, true, nil
+}
+
+func (sp StakingPoolCreation) ValidateMetadataByItself() bool {
+	return sp.Type == StakingPoolCreationMeta
+}
+
+// HashForSigning is the data which is signed by the bridge key of the committee key
+func (sp StakingPoolCreation) HashForSigning() common.Hash {
+	record := strconv.Itoa(sp.Type)
+	record += sp.CommitteePublicKey
+	record += sp.OperatorAddressStr
+	record += strconv.FormatUint(sp.CommissionRate, 10)
+	return common.HashH([]byte(record))
+}
+
+func (sp StakingPoolCreation) Hash() *common.Hash {
+	record := sp.MetadataBase.Hash().String()
+	record += sp.HashForSigning().String()
+	record += sp.CommitteeKeySignature
+	// final hash
+	hash := common.HashH([]byte(record))
+	return &hash
+}
+
+func (sp *StakingPoolCreation) BuildReqActions(tx Transaction, bcr BlockchainRetriever, shardID byte) ([][]string, error) {
+	actionContent := StakingPoolCreationAction{
+		Meta:    *sp,
+		TxReqID: *tx.Hash(),
+		ShardID: shardID,
+	}
+	actionContentBytes, err := json.Marshal(actionContent)
+	if err != nil {
+		return [][]string{}, err
+	}
+	actionContentBase64Str := base64.StdEncoding.EncodeToString(actionContentBytes)
+	action := []string{strconv.Itoa(StakingPoolCreationMeta), actionContentBase64Str}
+	return [][]string{action}, nil
+}
+
+func (sp *StakingPoolCreation) CalculateSize() uint64 {
+	return calculateSize(sp)
+}
@@ -0,0 +1,134 @@
+package metadata
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/database"
+	"github.com/incognitochain/incognito-chain/wallet"
+)
+
+// StakingPoolDeposit - delegate an arbitrary amount of PRV to a staking pool
+type StakingPoolDeposit struct {
+	CommitteePublicKey  string
+	DelegatorAddressStr string
+	DepositAmount       uint64
+	MetadataBase
+}
+
+type StakingPoolDepositAction struct {
+	Meta    StakingPoolDeposit
+	TxReqID common.Hash
+	ShardID byte
+}
+
+type StakingPoolDepositAcceptedContent struct {
+	CommitteePublicKey  string
+	DelegatorAddressStr string
+	DepositAmount       uint64
+	TxReqID             common.Hash
+	ShardID             byte
+}
+
+func NewStakingPoolDeposit(
+	committeePublicKey string,
+	delegatorAddressStr string,
+	depositAmount uint64,
+	metaType int,
+) (*StakingPoolDeposit, error) {
+	metadataBase := MetadataBase{
+		Type: metaType,
+	}
+	stakingPoolDeposit := &StakingPoolDeposit{
+		CommitteePublicKey:  committeePublicKey,
+		DelegatorAddressStr: delegatorAddressStr,
+		DepositAmount:       depositAmount,
+	}
+	stakingPoolDeposit.MetadataBase = metadataBase
+	return stakingPoolDeposit, nil
+}
+
+func (sp StakingPoolDeposit) ValidateTxWithBlockChain(
+	txr Transaction,
+	bcr BlockchainRetriever,
+	shardID byte,
+	db database.DatabaseInterface,
+) (bool, error) {
+	poolBytes, err := db.GetStakingPool(sp.CommitteePublicKey)
+	if err != nil {
+		return false, err
+	}
+	if len(poolBytes) == 0 {
+		return false, NewMetadataTxError(StakingPoolRequestNotFoundError, fmt.Errorf("No staking pool found for committee publickey %+v", sp.CommitteePublicKey))
+	}
+	// NOTE: a closed pool is checked on beacon, the deposit will be refunded
+	return true, nil
+}
+
+func (sp StakingPoolDeposit) ValidateSanityData(bcr BlockchainRetriever, txr Transaction) (bool, bool, error) {
+	if txr.GetType() != common.TxNormalType {
+		return false, false, errors.New("Staking pool deposit should be a PRV transaction")
+	}
+	keyWallet, err := wallet.Base58CheckDeserialize(sp.DelegatorAddressStr)
+	if err != nil {
+		return false, false, NewMetadataTxError(StakingPoolRequestFromMapError, errors.New("DelegatorAddressStr incorrect"))
+	}
+	delegatorAddr := keyWallet.KeySet.PaymentAddress
+	if len(delegatorAddr.Pk) == 0 {
+		return false, false, errors.New("Wrong request info's delegator address")
+	}
+	if !bytes.Equal(txr.GetSigPubKey()[:], delegatorAddr.Pk[:]) {
+		return false, false, NewMetadataTxError(StakingPoolRequestInvalidSenderError, errors.New("DelegatorAddress incorrect"))
+	}
+	if !txr.IsCoinsBurning(bcr) {
+		return false, false, errors.New("Must send coin to burning address")
+	}
+	if sp.DepositAmount == 0 {
+		return false, false, errors.New("Deposit Amount should be larger than 0")
+	}
+	if sp.DepositAmount != txr.CalculateTxValue() {
+		return false, false, errors.New("Deposit Amount should be equal to the tx value")
+	}
+	if sp.CommitteePublicKey == "" {
+		return false, false, errors.New("Committee public key of staking pool should not be empty")
+	}
+	return true, true, nil
+}
+
+func (sp StakingPoolDeposit) ValidateMetadataByItself() bool {
+	return sp.Type == StakingPoolDepositMeta
+}
+
+func (sp StakingPoolDeposit) Hash() *common.Hash {
+	record := sp.MetadataBase.Hash().String()
+	record += sp.CommitteePublicKey
+	record += sp.DelegatorAddressStr
+	record += strconv.FormatUint(sp.DepositAmount, 10)
+	// final hash
+	hash := common.HashH([]byte(record))
+	return &hash
+}
+
+func (sp *StakingPoolDeposit) BuildReqActions(tx Transaction, bcr BlockchainRetriever, shardID byte) ([][]string, error) {
+	actionContent := StakingPoolDepositAction{
+		Meta:    *sp,
+		TxReqID: *tx.Hash(),
+		ShardID: shardID,
+	}
+	actionContentBytes, err := json.Marshal(actionContent)
+	if err != nil {
+		return [][]string{}, err
+	}
+	actionContentBase64Str := base64.StdEncoding.EncodeToString(actionContentBytes)
+	action := []string{strconv.Itoa(StakingPoolDepositMeta), actionContentBase64Str}
+	return [][]string{action}, nil
+}
+
+func (sp *StakingPoolDeposit) CalculateSize() uint64 {
+	return calculateSize(sp)
+}
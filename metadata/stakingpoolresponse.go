@@ -0,0 +1,142 @@
+package metadata
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/database"
+	"github.com/incognitochain/incognito-chain/wallet"
+)
+
+// StakingPoolResponse - refund of a rejected deposit or payout of an accepted withdrawal
+type StakingPoolResponse struct {
+	MetadataBase
+	ResponseStatus string
+	RequestedTxID  common.Hash
+}
+
+func NewStakingPoolResponse(
+	responseStatus string,
+	requestedTxID common.Hash,
+	metaType int,
+) *StakingPoolResponse {
+	metadataBase := MetadataBase{
+		Type: metaType,
+	}
+	return &StakingPoolResponse{
+		ResponseStatus: responseStatus,
+		RequestedTxID:  requestedTxID,
+		MetadataBase:   metadataBase,
+	}
+}
+
+func (iRes StakingPoolResponse) CheckTransactionFee(tr Transaction, minFee uint64, beaconHeight int64, db database.DatabaseInterface) bool {
+	// no need to have fee for this tx
+	return true
+}
+
+func (iRes StakingPoolResponse) ValidateTxWithBlockChain(txr Transaction, bcr BlockchainRetriever, shardID byte, db database.DatabaseInterface) (bool, error) {
+	// no need to validate tx with blockchain, just need to validate with requested tx (via RequestedTxID)
+	return false, nil
+}
+
+func (iRes StakingPoolResponse) ValidateSanityData(bcr BlockchainRetriever, txr Transaction) (bool, bool, error) {
+	return false, true, nil
+}
+
+func (iRes StakingPoolResponse) ValidateMetadataByItself() bool {
+	// The validation just need to check at tx level, so returning true here
+	return iRes.Type == StakingPoolResponseMeta
+}
+
+func (iRes StakingPoolResponse) Hash() *common.Hash {
+	record := iRes.RequestedTxID.String()
+	record += iRes.ResponseStatus
+	record += iRes.MetadataBase.Hash().String()
+
+	// final hash
+	hash := common.HashH([]byte(record))
+	return &hash
+}
+
+func (iRes *StakingPoolResponse) CalculateSize() uint64 {
+	return calculateSize(iRes)
+}
+
+func (iRes StakingPoolResponse) VerifyMinerCreatedTxBeforeGettingInBlock(
+	txsInBlock []Transaction,
+	txsUsed []int,
+	insts [][]string,
+	instUsed []int,
+	shardID byte,
+	tx Transaction,
+	bcr BlockchainRetriever,
+	ac *AccumulatedValues,
+) (bool, error) {
+	idx := -1
+	for i, inst := range insts {
+		if len(inst) < 4 { // this is not staking pool instruction
+			continue
+		}
+		if instUsed[i] > 0 || inst[2] != iRes.ResponseStatus {
+			continue
+		}
+		instMetaType := inst[0]
+		var shardIDFromInst byte
+		var txReqIDFromInst common.Hash
+		var receiverAddrStrFromInst string
+		var receivingAmtFromInst uint64
+		if instMetaType == strconv.Itoa(StakingPoolDepositMeta) && inst[2] == common.StakingPoolRefundChainStatus {
+			var depositContent StakingPoolDepositAcceptedContent
+			err := json.Unmarshal([]byte(inst[3]), &depositContent)
+			if err != nil {
+				Logger.log.Error("WARNING - VALIDATION: an error occured while parsing staking pool deposit refund content: ", err)
+				continue
+			}
+			shardIDFromInst = depositContent.ShardID
+			txReqIDFromInst = depositContent.TxReqID
+			receiverAddrStrFromInst = depositContent.DelegatorAddressStr
+			receivingAmtFromInst = depositContent.DepositAmount
+		} else if instMetaType == strconv.Itoa(StakingPoolWithdrawalRequestMeta) &&
+			(inst[2] == common.StakingPoolAcceptedChainStatus || inst[2] == common.StakingPoolReleasedChainStatus) {
+			var withdrawalContent StakingPoolWithdrawalAcceptedContent
+			err := json.Unmarshal([]byte(inst[3]), &withdrawalContent)
+			if err != nil {
+				Logger.log.Error("WARNING - VALIDATION: an error occured while parsing staking pool withdrawal accepted content: ", err)
+				continue
+			}
+			shardIDFromInst = withdrawalContent.ShardID
+			txReqIDFromInst = withdrawalContent.TxReqID
+			receiverAddrStrFromInst = withdrawalContent.DelegatorAddressStr
+			receivingAmtFromInst = withdrawalContent.WithdrawalAmount
+		} else {
+			continue
+		}
+
+		if !bytes.Equal(iRes.RequestedTxID[:], txReqIDFromInst[:]) ||
+			shardID != shardIDFromInst {
+			continue
+		}
+		key, err := wallet.Base58CheckDeserialize(receiverAddrStrFromInst)
+		if err != nil {
+			Logger.log.Info("WARNING - VALIDATION: an error occured while deserializing receiver address string: ", err)
+			continue
+		}
+		_, pk, paidAmount, assetID := tx.GetTransferData()
+		if !bytes.Equal(key.KeySet.PaymentAddress.Pk[:], pk[:]) ||
+			receivingAmtFromInst != paidAmount ||
+			assetID.String() != common.PRVCoinID.String() {
+			continue
+		}
+		idx = i
+		break
+	}
+	if idx == -1 { // not found the request tx for this response
+		return false, fmt.Errorf(fmt.Sprintf("no staking pool instruction found for StakingPoolResponse tx %s", tx.Hash().String()))
+	}
+	instUsed[idx] = 1
+	return true, nil
+}
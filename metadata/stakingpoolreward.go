@@ -0,0 +1,93 @@
+package metadata
+
+import (
+	"encoding/json"
+	"strconv"
+
+	"github.com/incognitochain/incognito-chain/common"
+)
+
+// StakingPoolRewardInfo - rewards of a staking pool committee key paid to
+// delegators (and the operator's commission) belonging to one shard
+type StakingPoolRewardInfo struct {
+	CommitteePublicKey string
+	Epoch              uint64
+	Rewards            map[string]map[common.Hash]uint64 // payment address => reward
+}
+
+// stakingPoolRewardInfoJSON keys rewards by token id strings,
+// common.Hash map keys can not be unmarshaled from json
+type stakingPoolRewardInfoJSON struct {
+	CommitteePublicKey string
+	Epoch              uint64
+	Rewards            map[string]map[string]uint64
+}
+
+func (info StakingPoolRewardInfo) MarshalJSON() ([]byte, error) {
+	infoJSON := stakingPoolRewardInfoJSON{
+		CommitteePublicKey: info.CommitteePublicKey,
+		Epoch:              info.Epoch,
+		Rewards:            make(map[string]map[string]uint64, len(info.Rewards)),
+	}
+	for paymentAddressStr, rewards := range info.Rewards {
+		infoJSON.Rewards[paymentAddressStr] = make(map[string]uint64, len(rewards))
+		for tokenID, amount := range rewards {
+			infoJSON.Rewards[paymentAddressStr][tokenID.String()] = amount
+		}
+	}
+	return json.Marshal(infoJSON)
+}
+
+func (info *StakingPoolRewardInfo) UnmarshalJSON(data []byte) error {
+	var infoJSON stakingPoolRewardInfoJSON
+	if err := json.Unmarshal(data, &infoJSON); err != nil {
+		return err
+	}
+	info.CommitteePublicKey = infoJSON.CommitteePublicKey
+	info.Epoch = infoJSON.Epoch
+	info.Rewards = make(map[string]map[common.Hash]uint64, len(infoJSON.Rewards))
+	for paymentAddressStr, rewards := range infoJSON.Rewards {
+		info.Rewards[paymentAddressStr] = make(map[common.Hash]uint64, len(rewards))
+		for tokenIDStr, amount := range rewards {
+			tokenID, err := common.Hash{}.NewHashFromStr(tokenIDStr)
+			if err != nil {
+				return err
+			}
+			info.Rewards[paymentAddressStr][*tokenID] = amount
+		}
+	}
+	return nil
+}
+
+func BuildInstForStakingPoolReward(
+	committeePublicKey string,
+	epoch uint64,
+	shardID byte,
+	rewards map[string]map[common.Hash]uint64,
+) ([]string, error) {
+	stakingPoolRewardInfo := StakingPoolRewardInfo{
+		CommitteePublicKey: committeePublicKey,
+		Epoch:              epoch,
+		Rewards:            rewards,
+	}
+	contentStr, err := json.Marshal(stakingPoolRewardInfo)
+	if err != nil {
+		return nil, err
+	}
+	returnedInst := []string{
+		strconv.Itoa(StakingPoolRewardRequestMeta),
+		strconv.Itoa(int(shardID)),
+		"stakingPoolRewardInst",
+		string(contentStr),
+	}
+	return returnedInst, nil
+}
+
+func NewStakingPoolRewardInfoFromStr(inst string) (*StakingPoolRewardInfo, error) {
+	Ins := &StakingPoolRewardInfo{}
+	err := json.Unmarshal([]byte(inst), Ins)
+	if err != nil {
+		return nil, err
+	}
+	return Ins, nil
+}
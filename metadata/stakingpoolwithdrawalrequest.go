@@ -0,0 +1,125 @@
+package metadata
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/database"
+	"github.com/incognitochain/incognito-chain/wallet"
+)
+
+// StakingPoolWithdrawalRequest - withdraw a part of delegation from a staking pool
+type StakingPoolWithdrawalRequest struct {
+	CommitteePublicKey  string
+	DelegatorAddressStr string
+	WithdrawalAmount    uint64
+	MetadataBase
+}
+
+type StakingPoolWithdrawalRequestAction struct {
+	Meta    StakingPoolWithdrawalRequest
+	TxReqID common.Hash
+	ShardID byte
+}
+
+type StakingPoolWithdrawalAcceptedContent struct {
+	CommitteePublicKey  string
+	DelegatorAddressStr string
+	WithdrawalAmount    uint64
+	TxReqID             common.Hash
+	ShardID             byte
+}
+
+func NewStakingPoolWithdrawalRequest(
+	committeePublicKey string,
+	delegatorAddressStr string,
+	withdrawalAmount uint64,
+	metaType int,
+) (*StakingPoolWithdrawalRequest, error) {
+	metadataBase := MetadataBase{
+		Type: metaType,
+	}
+	stakingPoolWithdrawalRequest := &StakingPoolWithdrawalRequest{
+		CommitteePublicKey:  committeePublicKey,
+		DelegatorAddressStr: delegatorAddressStr,
+		WithdrawalAmount:    withdrawalAmount,
+	}
+	stakingPoolWithdrawalRequest.MetadataBase = metadataBase
+	return stakingPoolWithdrawalRequest, nil
+}
+
+func (sp StakingPoolWithdrawalRequest) ValidateTxWithBlockChain(
+	txr Transaction,
+	bcr BlockchainRetriever,
+	shardID byte,
+	db database.DatabaseInterface,
+) (bool, error) {
+	poolBytes, err := db.GetStakingPool(sp.CommitteePublicKey)
+	if err != nil {
+		return false, err
+	}
+	if len(poolBytes) == 0 {
+		return false, NewMetadataTxError(StakingPoolRequestNotFoundError, fmt.Errorf("No staking pool found for committee publickey %+v", sp.CommitteePublicKey))
+	}
+	// NOTE: delegation amount and pool status are checked on beacon
+	return true, nil
+}
+
+func (sp StakingPoolWithdrawalRequest) ValidateSanityData(bcr BlockchainRetriever, txr Transaction) (bool, bool, error) {
+	keyWallet, err := wallet.Base58CheckDeserialize(sp.DelegatorAddressStr)
+	if err != nil {
+		return false, false, NewMetadataTxError(StakingPoolRequestFromMapError, errors.New("DelegatorAddressStr incorrect"))
+	}
+	delegatorAddr := keyWallet.KeySet.PaymentAddress
+	if len(delegatorAddr.Pk) == 0 {
+		return false, false, errors.New("Wrong request info's delegator address")
+	}
+	if !bytes.Equal(txr.GetSigPubKey()[:], delegatorAddr.Pk[:]) {
+		return false, false, NewMetadataTxError(StakingPoolRequestInvalidSenderError, errors.New("DelegatorAddress incorrect"))
+	}
+	if sp.WithdrawalAmount == 0 {
+		return false, false, NewMetadataTxError(StakingPoolRequestFromMapError, errors.New("WithdrawalAmount should be large than 0"))
+	}
+	if sp.CommitteePublicKey == "" {
+		return false, false, errors.New("Committee public key of staking pool should not be empty")
+	}
+	return true, true, nil
+}
+
+func (sp StakingPoolWithdrawalRequest) ValidateMetadataByItself() bool {
+	return sp.Type == StakingPoolWithdrawalRequestMeta
+}
+
+func (sp StakingPoolWithdrawalRequest) Hash() *common.Hash {
+	record := sp.MetadataBase.Hash().String()
+	record += sp.CommitteePublicKey
+	record += sp.DelegatorAddressStr
+	record += strconv.FormatUint(sp.WithdrawalAmount, 10)
+	// final hash
+	hash := common.HashH([]byte(record))
+	return &hash
+}
+
+func (sp *StakingPoolWithdrawalRequest) BuildReqActions(tx Transaction, bcr BlockchainRetriever, shardID byte) ([][]string, error) {
+	actionContent := StakingPoolWithdrawalRequestAction{
+		Meta:    *sp,
+		TxReqID: *tx.Hash(),
+		ShardID: shardID,
+	}
+	actionContentBytes, err := json.Marshal(actionContent)
+	if err != nil {
+		return [][]string{}, err
+	}
+	actionContentBase64Str := base64.StdEncoding.EncodeToString(actionContentBytes)
+	action := []string{strconv.Itoa(StakingPoolWithdrawalRequestMeta), actionContentBase64Str}
+	return [][]string{action}, nil
+}
+
+func (sp *StakingPoolWithdrawalRequest) CalculateSize() uint64 {
+	return calculateSize(sp)
+}
@@ -145,20 +145,20 @@ func (_m *DatabaseInterface) CanProcessCIncToken(incTokenID common.Hash) (bool,
 	return r0, r1
 }
 
-// CanProcessTokenPair provides a mock function with given fields: externalTokenID, incTokenID
-func (_m *DatabaseInterface) CanProcessTokenPair(externalTokenID []byte, incTokenID common.Hash) (bool, error) {
-	ret := _m.Called(externalTokenID, incTokenID)
+// CanProcessTokenPair provides a mock function with given fields: chainID, externalTokenID, incTokenID
+func (_m *DatabaseInterface) CanProcessTokenPair(chainID uint64, externalTokenID []byte, incTokenID common.Hash) (bool, error) {
+	ret := _m.Called(chainID, externalTokenID, incTokenID)
 
 	var r0 bool
-	if rf, ok := ret.Get(0).(func([]byte, common.Hash) bool); ok {
-		r0 = rf(externalTokenID, incTokenID)
+	if rf, ok := ret.Get(0).(func(uint64, []byte, common.Hash) bool); ok {
+		r0 = rf(chainID, externalTokenID, incTokenID)
 	} else {
 		r0 = ret.Get(0).(bool)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func([]byte, common.Hash) error); ok {
-		r1 = rf(externalTokenID, incTokenID)
+	if rf, ok := ret.Get(1).(func(uint64, []byte, common.Hash) error); ok {
+		r1 = rf(chainID, externalTokenID, incTokenID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -348,6 +348,20 @@ func (_m *DatabaseInterface) DeleteAcceptedShardToBeacon(shardID byte, shardBlkH
 	return r0
 }
 
+// DeleteBTCMainChainHash provides a mock function with given fields: height
+func (_m *DatabaseInterface) DeleteBTCMainChainHash(height uint64) error {
+	ret := _m.Called(height)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint64) error); ok {
+		r0 = rf(height)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DeleteBeaconBlock provides a mock function with given fields: hash, idx
 func (_m *DatabaseInterface) DeleteBeaconBlock(hash common.Hash, idx uint64) error {
 	ret := _m.Called(hash, idx)
@@ -376,6 +390,20 @@ func (_m *DatabaseInterface) DeleteBlock(hash common.Hash, idx uint64, shardID b
 	return r0
 }
 
+// DeleteBridgeQueuedRequest provides a mock function with given fields: beaconHeight, index
+func (_m *DatabaseInterface) DeleteBridgeQueuedRequest(beaconHeight uint64, index uint64) error {
+	ret := _m.Called(beaconHeight, index)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint64, uint64) error); ok {
+		r0 = rf(beaconHeight, index)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DeleteCommitteeByHeight provides a mock function with given fields: blkEpoch
 func (_m *DatabaseInterface) DeleteCommitteeByHeight(blkEpoch uint64) error {
 	ret := _m.Called(blkEpoch)
@@ -390,6 +418,20 @@ func (_m *DatabaseInterface) DeleteCommitteeByHeight(blkEpoch uint64) error {
 	return r0
 }
 
+// DeleteCrossShardTransaction provides a mock function with given fields: txID
+func (_m *DatabaseInterface) DeleteCrossShardTransaction(txID common.Hash) error {
+	ret := _m.Called(txID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(common.Hash) error); ok {
+		r0 = rf(txID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DeleteIncomingCrossShard provides a mock function with given fields: shardID, crossShardID, crossBlkHash
 func (_m *DatabaseInterface) DeleteIncomingCrossShard(shardID byte, crossShardID byte, crossBlkHash common.Hash) error {
 	ret := _m.Called(shardID, crossShardID, crossBlkHash)
@@ -716,6 +758,34 @@ func (_m *DatabaseInterface) FetchShardCommitteeByHeight(height uint64) ([]byte,
 	return r0, r1
 }
 
+// ForEachBeaconBlock provides a mock function with given fields: fn
+func (_m *DatabaseInterface) ForEachBeaconBlock(fn func(common.Hash, []byte) error) error {
+	ret := _m.Called(fn)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(func(common.Hash, []byte) error) error); ok {
+		r0 = rf(fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ForEachShardBlock provides a mock function with given fields: shardID, fn
+func (_m *DatabaseInterface) ForEachShardBlock(shardID byte, fn func(common.Hash, []byte) error) error {
+	ret := _m.Called(shardID, fn)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(byte, func(common.Hash, []byte) error) error); ok {
+		r0 = rf(shardID, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Get provides a mock function with given fields: key
 func (_m *DatabaseInterface) Get(key []byte) ([]byte, error) {
 	ret := _m.Called(key)
@@ -760,16 +830,16 @@ func (_m *DatabaseInterface) GetAcceptedShardToBeacon(shardID byte, shardBlkHash
 	return r0, r1
 }
 
-// GetAllBridgeTokens provides a mock function with given fields:
-func (_m *DatabaseInterface) GetAllBridgeTokens() ([]byte, error) {
+// GetAllBridgeQueuedRequests provides a mock function with given fields:
+func (_m *DatabaseInterface) GetAllBridgeQueuedRequests() ([][]byte, error) {
 	ret := _m.Called()
 
-	var r0 []byte
-	if rf, ok := ret.Get(0).(func() []byte); ok {
+	var r0 [][]byte
+	if rf, ok := ret.Get(0).(func() [][]byte); ok {
 		r0 = rf()
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]byte)
+			r0 = ret.Get(0).([][]byte)
 		}
 	}
 
@@ -783,54 +853,45 @@ func (_m *DatabaseInterface) GetAllBridgeTokens() ([]byte, error) {
 	return r0, r1
 }
 
-// GetAllRecordsByPrefix provides a mock function with given fields: beaconHeight, prefix
-func (_m *DatabaseInterface) GetAllRecordsByPrefix(beaconHeight uint64, prefix []byte) ([][]byte, [][]byte, error) {
-	ret := _m.Called(beaconHeight, prefix)
+// GetAllBridgeTokenQuotas provides a mock function with given fields:
+func (_m *DatabaseInterface) GetAllBridgeTokenQuotas() ([][]byte, error) {
+	ret := _m.Called()
 
 	var r0 [][]byte
-	if rf, ok := ret.Get(0).(func(uint64, []byte) [][]byte); ok {
-		r0 = rf(beaconHeight, prefix)
+	if rf, ok := ret.Get(0).(func() [][]byte); ok {
+		r0 = rf()
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([][]byte)
 		}
 	}
 
-	var r1 [][]byte
-	if rf, ok := ret.Get(1).(func(uint64, []byte) [][]byte); ok {
-		r1 = rf(beaconHeight, prefix)
-	} else {
-		if ret.Get(1) != nil {
-			r1 = ret.Get(1).([][]byte)
-		}
-	}
-
-	var r2 error
-	if rf, ok := ret.Get(2).(func(uint64, []byte) error); ok {
-		r2 = rf(beaconHeight, prefix)
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
 	} else {
-		r2 = ret.Error(2)
+		r1 = ret.Error(1)
 	}
 
-	return r0, r1, r2
+	return r0, r1
 }
 
-// GetAllTokenIDForReward provides a mock function with given fields: epoch
-func (_m *DatabaseInterface) GetAllTokenIDForReward(epoch uint64) ([]common.Hash, error) {
-	ret := _m.Called(epoch)
+// GetAllBridgeTokens provides a mock function with given fields:
+func (_m *DatabaseInterface) GetAllBridgeTokens() ([]byte, error) {
+	ret := _m.Called()
 
-	var r0 []common.Hash
-	if rf, ok := ret.Get(0).(func(uint64) []common.Hash); ok {
-		r0 = rf(epoch)
+	var r0 []byte
+	if rf, ok := ret.Get(0).(func() []byte); ok {
+		r0 = rf()
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]common.Hash)
+			r0 = ret.Get(0).([]byte)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(uint64) error); ok {
-		r1 = rf(epoch)
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -838,22 +899,22 @@ func (_m *DatabaseInterface) GetAllTokenIDForReward(epoch uint64) ([]common.Hash
 	return r0, r1
 }
 
-// GetBeaconBlockHashByIndex provides a mock function with given fields: idx
-func (_m *DatabaseInterface) GetBeaconBlockHashByIndex(idx uint64) (common.Hash, error) {
-	ret := _m.Called(idx)
+// GetAllDAOProposals provides a mock function with given fields:
+func (_m *DatabaseInterface) GetAllDAOProposals() ([][]byte, error) {
+	ret := _m.Called()
 
-	var r0 common.Hash
-	if rf, ok := ret.Get(0).(func(uint64) common.Hash); ok {
-		r0 = rf(idx)
+	var r0 [][]byte
+	if rf, ok := ret.Get(0).(func() [][]byte); ok {
+		r0 = rf()
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(common.Hash)
+			r0 = ret.Get(0).([][]byte)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(uint64) error); ok {
-		r1 = rf(idx)
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -861,22 +922,22 @@ func (_m *DatabaseInterface) GetBeaconBlockHashByIndex(idx uint64) (common.Hash,
 	return r0, r1
 }
 
-// GetBlockByIndex provides a mock function with given fields: idx, shardID
-func (_m *DatabaseInterface) GetBlockByIndex(idx uint64, shardID byte) (common.Hash, error) {
-	ret := _m.Called(idx, shardID)
+// GetAllMintableTokens provides a mock function with given fields:
+func (_m *DatabaseInterface) GetAllMintableTokens() ([][]byte, error) {
+	ret := _m.Called()
 
-	var r0 common.Hash
-	if rf, ok := ret.Get(0).(func(uint64, byte) common.Hash); ok {
-		r0 = rf(idx, shardID)
+	var r0 [][]byte
+	if rf, ok := ret.Get(0).(func() [][]byte); ok {
+		r0 = rf()
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(common.Hash)
+			r0 = ret.Get(0).([][]byte)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(uint64, byte) error); ok {
-		r1 = rf(idx, shardID)
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -884,20 +945,22 @@ func (_m *DatabaseInterface) GetBlockByIndex(idx uint64, shardID byte) (common.H
 	return r0, r1
 }
 
-// GetBridgeReqWithStatus provides a mock function with given fields: txReqID
-func (_m *DatabaseInterface) GetBridgeReqWithStatus(txReqID common.Hash) (byte, error) {
-	ret := _m.Called(txReqID)
+// GetAllPayoutBatches provides a mock function with given fields:
+func (_m *DatabaseInterface) GetAllPayoutBatches() ([][]byte, error) {
+	ret := _m.Called()
 
-	var r0 byte
-	if rf, ok := ret.Get(0).(func(common.Hash) byte); ok {
-		r0 = rf(txReqID)
+	var r0 [][]byte
+	if rf, ok := ret.Get(0).(func() [][]byte); ok {
+		r0 = rf()
 	} else {
-		r0 = ret.Get(0).(byte)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([][]byte)
+		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(common.Hash) error); ok {
-		r1 = rf(txReqID)
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -905,20 +968,22 @@ func (_m *DatabaseInterface) GetBridgeReqWithStatus(txReqID common.Hash) (byte,
 	return r0, r1
 }
 
-// GetBurningConfirm provides a mock function with given fields: txID
-func (_m *DatabaseInterface) GetBurningConfirm(txID common.Hash) (uint64, error) {
-	ret := _m.Called(txID)
+// GetAllPrivacyTokenRegistries provides a mock function with given fields:
+func (_m *DatabaseInterface) GetAllPrivacyTokenRegistries() ([][]byte, error) {
+	ret := _m.Called()
 
-	var r0 uint64
-	if rf, ok := ret.Get(0).(func(common.Hash) uint64); ok {
-		r0 = rf(txID)
+	var r0 [][]byte
+	if rf, ok := ret.Get(0).(func() [][]byte); ok {
+		r0 = rf()
 	} else {
-		r0 = ret.Get(0).(uint64)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([][]byte)
+		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(common.Hash) error); ok {
-		r1 = rf(txID)
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -926,45 +991,54 @@ func (_m *DatabaseInterface) GetBurningConfirm(txID common.Hash) (uint64, error)
 	return r0, r1
 }
 
-// GetCommitmentByIndex provides a mock function with given fields: tokenID, commitmentIndex, shardID
-func (_m *DatabaseInterface) GetCommitmentByIndex(tokenID common.Hash, commitmentIndex uint64, shardID byte) ([]byte, error) {
-	ret := _m.Called(tokenID, commitmentIndex, shardID)
+// GetAllRecordsByPrefix provides a mock function with given fields: beaconHeight, prefix
+func (_m *DatabaseInterface) GetAllRecordsByPrefix(beaconHeight uint64, prefix []byte) ([][]byte, [][]byte, error) {
+	ret := _m.Called(beaconHeight, prefix)
 
-	var r0 []byte
-	if rf, ok := ret.Get(0).(func(common.Hash, uint64, byte) []byte); ok {
-		r0 = rf(tokenID, commitmentIndex, shardID)
+	var r0 [][]byte
+	if rf, ok := ret.Get(0).(func(uint64, []byte) [][]byte); ok {
+		r0 = rf(beaconHeight, prefix)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]byte)
+			r0 = ret.Get(0).([][]byte)
 		}
 	}
 
-	var r1 error
-	if rf, ok := ret.Get(1).(func(common.Hash, uint64, byte) error); ok {
-		r1 = rf(tokenID, commitmentIndex, shardID)
+	var r1 [][]byte
+	if rf, ok := ret.Get(1).(func(uint64, []byte) [][]byte); ok {
+		r1 = rf(beaconHeight, prefix)
 	} else {
-		r1 = ret.Error(1)
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).([][]byte)
+		}
 	}
 
-	return r0, r1
+	var r2 error
+	if rf, ok := ret.Get(2).(func(uint64, []byte) error); ok {
+		r2 = rf(beaconHeight, prefix)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
 }
 
-// GetCommitmentIndex provides a mock function with given fields: tokenID, commitment, shardID
-func (_m *DatabaseInterface) GetCommitmentIndex(tokenID common.Hash, commitment []byte, shardID byte) (*big.Int, error) {
-	ret := _m.Called(tokenID, commitment, shardID)
+// GetAllStakingPools provides a mock function with given fields:
+func (_m *DatabaseInterface) GetAllStakingPools() ([][]byte, error) {
+	ret := _m.Called()
 
-	var r0 *big.Int
-	if rf, ok := ret.Get(0).(func(common.Hash, []byte, byte) *big.Int); ok {
-		r0 = rf(tokenID, commitment, shardID)
+	var r0 [][]byte
+	if rf, ok := ret.Get(0).(func() [][]byte); ok {
+		r0 = rf()
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*big.Int)
+			r0 = ret.Get(0).([][]byte)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(common.Hash, []byte, byte) error); ok {
-		r1 = rf(tokenID, commitment, shardID)
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -972,22 +1046,22 @@ func (_m *DatabaseInterface) GetCommitmentIndex(tokenID common.Hash, commitment
 	return r0, r1
 }
 
-// GetCommitmentLength provides a mock function with given fields: tokenID, shardID
-func (_m *DatabaseInterface) GetCommitmentLength(tokenID common.Hash, shardID byte) (*big.Int, error) {
-	ret := _m.Called(tokenID, shardID)
+// GetAllTokenIDForReward provides a mock function with given fields: epoch
+func (_m *DatabaseInterface) GetAllTokenIDForReward(epoch uint64) ([]common.Hash, error) {
+	ret := _m.Called(epoch)
 
-	var r0 *big.Int
-	if rf, ok := ret.Get(0).(func(common.Hash, byte) *big.Int); ok {
-		r0 = rf(tokenID, shardID)
+	var r0 []common.Hash
+	if rf, ok := ret.Get(0).(func(uint64) []common.Hash); ok {
+		r0 = rf(epoch)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*big.Int)
+			r0 = ret.Get(0).([]common.Hash)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(common.Hash, byte) error); ok {
-		r1 = rf(tokenID, shardID)
+	if rf, ok := ret.Get(1).(func(uint64) error); ok {
+		r1 = rf(epoch)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -995,20 +1069,572 @@ func (_m *DatabaseInterface) GetCommitmentLength(tokenID common.Hash, shardID by
 	return r0, r1
 }
 
-// GetCommitteeReward provides a mock function with given fields: committeeAddress, tokenID
-func (_m *DatabaseInterface) GetCommitteeReward(committeeAddress []byte, tokenID common.Hash) (uint64, error) {
-	ret := _m.Called(committeeAddress, tokenID)
+// GetBTCBestHeaderHash provides a mock function with given fields:
+func (_m *DatabaseInterface) GetBTCBestHeaderHash() ([]byte, error) {
+	ret := _m.Called()
 
-	var r0 uint64
-	if rf, ok := ret.Get(0).(func([]byte, common.Hash) uint64); ok {
-		r0 = rf(committeeAddress, tokenID)
+	var r0 []byte
+	if rf, ok := ret.Get(0).(func() []byte); ok {
+		r0 = rf()
 	} else {
-		r0 = ret.Get(0).(uint64)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func([]byte, common.Hash) error); ok {
-		r1 = rf(committeeAddress, tokenID)
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetBTCHeader provides a mock function with given fields: hash
+func (_m *DatabaseInterface) GetBTCHeader(hash []byte) ([]byte, error) {
+	ret := _m.Called(hash)
+
+	var r0 []byte
+	if rf, ok := ret.Get(0).(func([]byte) []byte); ok {
+		r0 = rf(hash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func([]byte) error); ok {
+		r1 = rf(hash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetBTCMainChainHash provides a mock function with given fields: height
+func (_m *DatabaseInterface) GetBTCMainChainHash(height uint64) ([]byte, error) {
+	ret := _m.Called(height)
+
+	var r0 []byte
+	if rf, ok := ret.Get(0).(func(uint64) []byte); ok {
+		r0 = rf(height)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(uint64) error); ok {
+		r1 = rf(height)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetBeaconBlockHashByIndex provides a mock function with given fields: idx
+func (_m *DatabaseInterface) GetBeaconBlockHashByIndex(idx uint64) (common.Hash, error) {
+	ret := _m.Called(idx)
+
+	var r0 common.Hash
+	if rf, ok := ret.Get(0).(func(uint64) common.Hash); ok {
+		r0 = rf(idx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(common.Hash)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(uint64) error); ok {
+		r1 = rf(idx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetBlockByIndex provides a mock function with given fields: idx, shardID
+func (_m *DatabaseInterface) GetBlockByIndex(idx uint64, shardID byte) (common.Hash, error) {
+	ret := _m.Called(idx, shardID)
+
+	var r0 common.Hash
+	if rf, ok := ret.Get(0).(func(uint64, byte) common.Hash); ok {
+		r0 = rf(idx, shardID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(common.Hash)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(uint64, byte) error); ok {
+		r1 = rf(idx, shardID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetBridgeReqWithStatus provides a mock function with given fields: txReqID
+func (_m *DatabaseInterface) GetBridgeReqWithStatus(txReqID common.Hash) (byte, error) {
+	ret := _m.Called(txReqID)
+
+	var r0 byte
+	if rf, ok := ret.Get(0).(func(common.Hash) byte); ok {
+		r0 = rf(txReqID)
+	} else {
+		r0 = ret.Get(0).(byte)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(common.Hash) error); ok {
+		r1 = rf(txReqID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetBridgeTokenChainID provides a mock function with given fields: incTokenID
+func (_m *DatabaseInterface) GetBridgeTokenChainID(incTokenID common.Hash) (uint64, bool, error) {
+	ret := _m.Called(incTokenID)
+
+	var r0 uint64
+	if rf, ok := ret.Get(0).(func(common.Hash) uint64); ok {
+		r0 = rf(incTokenID)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	var r1 bool
+	if rf, ok := ret.Get(1).(func(common.Hash) bool); ok {
+		r1 = rf(incTokenID)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(common.Hash) error); ok {
+		r2 = rf(incTokenID)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetBridgeTokenQuota provides a mock function with given fields: tokenID
+func (_m *DatabaseInterface) GetBridgeTokenQuota(tokenID common.Hash) ([]byte, error) {
+	ret := _m.Called(tokenID)
+
+	var r0 []byte
+	if rf, ok := ret.Get(0).(func(common.Hash) []byte); ok {
+		r0 = rf(tokenID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(common.Hash) error); ok {
+		r1 = rf(tokenID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetBurningConfirm provides a mock function with given fields: txID
+func (_m *DatabaseInterface) GetBurningConfirm(txID common.Hash) (uint64, error) {
+	ret := _m.Called(txID)
+
+	var r0 uint64
+	if rf, ok := ret.Get(0).(func(common.Hash) uint64); ok {
+		r0 = rf(txID)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(common.Hash) error); ok {
+		r1 = rf(txID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetCommitmentByIndex provides a mock function with given fields: tokenID, commitmentIndex, shardID
+func (_m *DatabaseInterface) GetCommitmentByIndex(tokenID common.Hash, commitmentIndex uint64, shardID byte) ([]byte, error) {
+	ret := _m.Called(tokenID, commitmentIndex, shardID)
+
+	var r0 []byte
+	if rf, ok := ret.Get(0).(func(common.Hash, uint64, byte) []byte); ok {
+		r0 = rf(tokenID, commitmentIndex, shardID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(common.Hash, uint64, byte) error); ok {
+		r1 = rf(tokenID, commitmentIndex, shardID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetCommitmentIndex provides a mock function with given fields: tokenID, commitment, shardID
+func (_m *DatabaseInterface) GetCommitmentIndex(tokenID common.Hash, commitment []byte, shardID byte) (*big.Int, error) {
+	ret := _m.Called(tokenID, commitment, shardID)
+
+	var r0 *big.Int
+	if rf, ok := ret.Get(0).(func(common.Hash, []byte, byte) *big.Int); ok {
+		r0 = rf(tokenID, commitment, shardID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*big.Int)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(common.Hash, []byte, byte) error); ok {
+		r1 = rf(tokenID, commitment, shardID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetCommitmentLength provides a mock function with given fields: tokenID, shardID
+func (_m *DatabaseInterface) GetCommitmentLength(tokenID common.Hash, shardID byte) (*big.Int, error) {
+	ret := _m.Called(tokenID, shardID)
+
+	var r0 *big.Int
+	if rf, ok := ret.Get(0).(func(common.Hash, byte) *big.Int); ok {
+		r0 = rf(tokenID, shardID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*big.Int)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(common.Hash, byte) error); ok {
+		r1 = rf(tokenID, shardID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetCommitteeReward provides a mock function with given fields: committeeAddress, tokenID
+func (_m *DatabaseInterface) GetCommitteeReward(committeeAddress []byte, tokenID common.Hash) (uint64, error) {
+	ret := _m.Called(committeeAddress, tokenID)
+
+	var r0 uint64
+	if rf, ok := ret.Get(0).(func([]byte, common.Hash) uint64); ok {
+		r0 = rf(committeeAddress, tokenID)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func([]byte, common.Hash) error); ok {
+		r1 = rf(committeeAddress, tokenID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetCrossShardTransaction provides a mock function with given fields: txID
+func (_m *DatabaseInterface) GetCrossShardTransaction(txID common.Hash) ([]byte, error) {
+	ret := _m.Called(txID)
+
+	var r0 []byte
+	if rf, ok := ret.Get(0).(func(common.Hash) []byte); ok {
+		r0 = rf(txID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(common.Hash) error); ok {
+		r1 = rf(txID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetDAOGovernedParams provides a mock function with given fields:
+func (_m *DatabaseInterface) GetDAOGovernedParams() ([]byte, error) {
+	ret := _m.Called()
+
+	var r0 []byte
+	if rf, ok := ret.Get(0).(func() []byte); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetDAOProposal provides a mock function with given fields: proposalID
+func (_m *DatabaseInterface) GetDAOProposal(proposalID common.Hash) ([]byte, error) {
+	ret := _m.Called(proposalID)
+
+	var r0 []byte
+	if rf, ok := ret.Get(0).(func(common.Hash) []byte); ok {
+		r0 = rf(proposalID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(common.Hash) error); ok {
+		r1 = rf(proposalID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetDAOTreasury provides a mock function with given fields:
+func (_m *DatabaseInterface) GetDAOTreasury() ([]byte, error) {
+	ret := _m.Called()
+
+	var r0 []byte
+	if rf, ok := ret.Get(0).(func() []byte); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetFeeEstimator provides a mock function with given fields: shardID
+func (_m *DatabaseInterface) GetFeeEstimator(shardID byte) ([]byte, error) {
+	ret := _m.Called(shardID)
+
+	var r0 []byte
+	if rf, ok := ret.Get(0).(func(byte) []byte); ok {
+		r0 = rf(shardID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(byte) error); ok {
+		r1 = rf(shardID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetIncomingCrossShard provides a mock function with given fields: shardID, crossShardID, crossBlkHash
+func (_m *DatabaseInterface) GetIncomingCrossShard(shardID byte, crossShardID byte, crossBlkHash common.Hash) (uint64, error) {
+	ret := _m.Called(shardID, crossShardID, crossBlkHash)
+
+	var r0 uint64
+	if rf, ok := ret.Get(0).(func(byte, byte, common.Hash) uint64); ok {
+		r0 = rf(shardID, crossShardID, crossBlkHash)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(byte, byte, common.Hash) error); ok {
+		r1 = rf(shardID, crossShardID, crossBlkHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetIndexOfBeaconBlock provides a mock function with given fields: hash
+func (_m *DatabaseInterface) GetIndexOfBeaconBlock(hash common.Hash) (uint64, error) {
+	ret := _m.Called(hash)
+
+	var r0 uint64
+	if rf, ok := ret.Get(0).(func(common.Hash) uint64); ok {
+		r0 = rf(hash)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(common.Hash) error); ok {
+		r1 = rf(hash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetIndexOfBlock provides a mock function with given fields: hash
+func (_m *DatabaseInterface) GetIndexOfBlock(hash common.Hash) (uint64, byte, error) {
+	ret := _m.Called(hash)
+
+	var r0 uint64
+	if rf, ok := ret.Get(0).(func(common.Hash) uint64); ok {
+		r0 = rf(hash)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	var r1 byte
+	if rf, ok := ret.Get(1).(func(common.Hash) byte); ok {
+		r1 = rf(hash)
+	} else {
+		r1 = ret.Get(1).(byte)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(common.Hash) error); ok {
+		r2 = rf(hash)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetLatestPDEPoolForPair provides a mock function with given fields: tokenIDToBuyStr, tokenIDToSellStr
+func (_m *DatabaseInterface) GetLatestPDEPoolForPair(tokenIDToBuyStr string, tokenIDToSellStr string) ([]byte, error) {
+	ret := _m.Called(tokenIDToBuyStr, tokenIDToSellStr)
+
+	var r0 []byte
+	if rf, ok := ret.Get(0).(func(string, string) []byte); ok {
+		r0 = rf(tokenIDToBuyStr, tokenIDToSellStr)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(tokenIDToBuyStr, tokenIDToSellStr)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetMintableToken provides a mock function with given fields: tokenID
+func (_m *DatabaseInterface) GetMintableToken(tokenID common.Hash) ([]byte, error) {
+	ret := _m.Called(tokenID)
+
+	var r0 []byte
+	if rf, ok := ret.Get(0).(func(common.Hash) []byte); ok {
+		r0 = rf(tokenID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(common.Hash) error); ok {
+		r1 = rf(tokenID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetMintableTokenStatus provides a mock function with given fields: txReqID
+func (_m *DatabaseInterface) GetMintableTokenStatus(txReqID []byte) (byte, error) {
+	ret := _m.Called(txReqID)
+
+	var r0 byte
+	if rf, ok := ret.Get(0).(func([]byte) byte); ok {
+		r0 = rf(txReqID)
+	} else {
+		r0 = ret.Get(0).(byte)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func([]byte) error); ok {
+		r1 = rf(txReqID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetOutcoinsByPubkey provides a mock function with given fields: tokenID, pubkey, shardID
+func (_m *DatabaseInterface) GetOutcoinsByPubkey(tokenID common.Hash, pubkey []byte, shardID byte) ([][]byte, error) {
+	ret := _m.Called(tokenID, pubkey, shardID)
+
+	var r0 [][]byte
+	if rf, ok := ret.Get(0).(func(common.Hash, []byte, byte) [][]byte); ok {
+		r0 = rf(tokenID, pubkey, shardID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([][]byte)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(common.Hash, []byte, byte) error); ok {
+		r1 = rf(tokenID, pubkey, shardID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1016,13 +1642,13 @@ func (_m *DatabaseInterface) GetCommitteeReward(committeeAddress []byte, tokenID
 	return r0, r1
 }
 
-// GetFeeEstimator provides a mock function with given fields: shardID
-func (_m *DatabaseInterface) GetFeeEstimator(shardID byte) ([]byte, error) {
-	ret := _m.Called(shardID)
+// GetPDEContributionStatus provides a mock function with given fields: prefix, suffix
+func (_m *DatabaseInterface) GetPDEContributionStatus(prefix []byte, suffix []byte) ([]byte, error) {
+	ret := _m.Called(prefix, suffix)
 
 	var r0 []byte
-	if rf, ok := ret.Get(0).(func(byte) []byte); ok {
-		r0 = rf(shardID)
+	if rf, ok := ret.Get(0).(func([]byte, []byte) []byte); ok {
+		r0 = rf(prefix, suffix)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]byte)
@@ -1030,8 +1656,8 @@ func (_m *DatabaseInterface) GetFeeEstimator(shardID byte) ([]byte, error) {
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(byte) error); ok {
-		r1 = rf(shardID)
+	if rf, ok := ret.Get(1).(func([]byte, []byte) error); ok {
+		r1 = rf(prefix, suffix)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1039,20 +1665,22 @@ func (_m *DatabaseInterface) GetFeeEstimator(shardID byte) ([]byte, error) {
 	return r0, r1
 }
 
-// GetIncomingCrossShard provides a mock function with given fields: shardID, crossShardID, crossBlkHash
-func (_m *DatabaseInterface) GetIncomingCrossShard(shardID byte, crossShardID byte, crossBlkHash common.Hash) (uint64, error) {
-	ret := _m.Called(shardID, crossShardID, crossBlkHash)
+// GetPDELiquidityPosition provides a mock function with given fields: contributorAddressStr, token1IDStr, token2IDStr
+func (_m *DatabaseInterface) GetPDELiquidityPosition(contributorAddressStr string, token1IDStr string, token2IDStr string) ([]byte, error) {
+	ret := _m.Called(contributorAddressStr, token1IDStr, token2IDStr)
 
-	var r0 uint64
-	if rf, ok := ret.Get(0).(func(byte, byte, common.Hash) uint64); ok {
-		r0 = rf(shardID, crossShardID, crossBlkHash)
+	var r0 []byte
+	if rf, ok := ret.Get(0).(func(string, string, string) []byte); ok {
+		r0 = rf(contributorAddressStr, token1IDStr, token2IDStr)
 	} else {
-		r0 = ret.Get(0).(uint64)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(byte, byte, common.Hash) error); ok {
-		r1 = rf(shardID, crossShardID, crossBlkHash)
+	if rf, ok := ret.Get(1).(func(string, string, string) error); ok {
+		r1 = rf(contributorAddressStr, token1IDStr, token2IDStr)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1060,20 +1688,22 @@ func (_m *DatabaseInterface) GetIncomingCrossShard(shardID byte, crossShardID by
 	return r0, r1
 }
 
-// GetIndexOfBeaconBlock provides a mock function with given fields: hash
-func (_m *DatabaseInterface) GetIndexOfBeaconBlock(hash common.Hash) (uint64, error) {
-	ret := _m.Called(hash)
+// GetPDELiquidityPositionsByContributor provides a mock function with given fields: contributorAddressStr
+func (_m *DatabaseInterface) GetPDELiquidityPositionsByContributor(contributorAddressStr string) ([][]byte, error) {
+	ret := _m.Called(contributorAddressStr)
 
-	var r0 uint64
-	if rf, ok := ret.Get(0).(func(common.Hash) uint64); ok {
-		r0 = rf(hash)
+	var r0 [][]byte
+	if rf, ok := ret.Get(0).(func(string) [][]byte); ok {
+		r0 = rf(contributorAddressStr)
 	} else {
-		r0 = ret.Get(0).(uint64)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([][]byte)
+		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(common.Hash) error); ok {
-		r1 = rf(hash)
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(contributorAddressStr)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1081,41 +1711,36 @@ func (_m *DatabaseInterface) GetIndexOfBeaconBlock(hash common.Hash) (uint64, er
 	return r0, r1
 }
 
-// GetIndexOfBlock provides a mock function with given fields: hash
-func (_m *DatabaseInterface) GetIndexOfBlock(hash common.Hash) (uint64, byte, error) {
-	ret := _m.Called(hash)
-
-	var r0 uint64
-	if rf, ok := ret.Get(0).(func(common.Hash) uint64); ok {
-		r0 = rf(hash)
-	} else {
-		r0 = ret.Get(0).(uint64)
-	}
+// GetPDEMarketEvents provides a mock function with given fields: token1IDStr, token2IDStr, fromTime, toTime
+func (_m *DatabaseInterface) GetPDEMarketEvents(token1IDStr string, token2IDStr string, fromTime int64, toTime int64) ([][]byte, error) {
+	ret := _m.Called(token1IDStr, token2IDStr, fromTime, toTime)
 
-	var r1 byte
-	if rf, ok := ret.Get(1).(func(common.Hash) byte); ok {
-		r1 = rf(hash)
+	var r0 [][]byte
+	if rf, ok := ret.Get(0).(func(string, string, int64, int64) [][]byte); ok {
+		r0 = rf(token1IDStr, token2IDStr, fromTime, toTime)
 	} else {
-		r1 = ret.Get(1).(byte)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([][]byte)
+		}
 	}
 
-	var r2 error
-	if rf, ok := ret.Get(2).(func(common.Hash) error); ok {
-		r2 = rf(hash)
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string, int64, int64) error); ok {
+		r1 = rf(token1IDStr, token2IDStr, fromTime, toTime)
 	} else {
-		r2 = ret.Error(2)
+		r1 = ret.Error(1)
 	}
 
-	return r0, r1, r2
+	return r0, r1
 }
 
-// GetLatestPDEPoolForPair provides a mock function with given fields: tokenIDToBuyStr, tokenIDToSellStr
-func (_m *DatabaseInterface) GetLatestPDEPoolForPair(tokenIDToBuyStr string, tokenIDToSellStr string) ([]byte, error) {
-	ret := _m.Called(tokenIDToBuyStr, tokenIDToSellStr)
+// GetPDEPoolForPair provides a mock function with given fields: beaconHeight, tokenIDToBuyStr, tokenIDToSellStr
+func (_m *DatabaseInterface) GetPDEPoolForPair(beaconHeight uint64, tokenIDToBuyStr string, tokenIDToSellStr string) ([]byte, error) {
+	ret := _m.Called(beaconHeight, tokenIDToBuyStr, tokenIDToSellStr)
 
 	var r0 []byte
-	if rf, ok := ret.Get(0).(func(string, string) []byte); ok {
-		r0 = rf(tokenIDToBuyStr, tokenIDToSellStr)
+	if rf, ok := ret.Get(0).(func(uint64, string, string) []byte); ok {
+		r0 = rf(beaconHeight, tokenIDToBuyStr, tokenIDToSellStr)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]byte)
@@ -1123,8 +1748,8 @@ func (_m *DatabaseInterface) GetLatestPDEPoolForPair(tokenIDToBuyStr string, tok
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string, string) error); ok {
-		r1 = rf(tokenIDToBuyStr, tokenIDToSellStr)
+	if rf, ok := ret.Get(1).(func(uint64, string, string) error); ok {
+		r1 = rf(beaconHeight, tokenIDToBuyStr, tokenIDToSellStr)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1132,22 +1757,22 @@ func (_m *DatabaseInterface) GetLatestPDEPoolForPair(tokenIDToBuyStr string, tok
 	return r0, r1
 }
 
-// GetOutcoinsByPubkey provides a mock function with given fields: tokenID, pubkey, shardID
-func (_m *DatabaseInterface) GetOutcoinsByPubkey(tokenID common.Hash, pubkey []byte, shardID byte) ([][]byte, error) {
-	ret := _m.Called(tokenID, pubkey, shardID)
+// GetPDEState provides a mock function with given fields: beaconHeight
+func (_m *DatabaseInterface) GetPDEState(beaconHeight uint64) (map[string][]byte, error) {
+	ret := _m.Called(beaconHeight)
 
-	var r0 [][]byte
-	if rf, ok := ret.Get(0).(func(common.Hash, []byte, byte) [][]byte); ok {
-		r0 = rf(tokenID, pubkey, shardID)
+	var r0 map[string][]byte
+	if rf, ok := ret.Get(0).(func(uint64) map[string][]byte); ok {
+		r0 = rf(beaconHeight)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([][]byte)
+			r0 = ret.Get(0).(map[string][]byte)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(common.Hash, []byte, byte) error); ok {
-		r1 = rf(tokenID, pubkey, shardID)
+	if rf, ok := ret.Get(1).(func(uint64) error); ok {
+		r1 = rf(beaconHeight)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1155,13 +1780,34 @@ func (_m *DatabaseInterface) GetOutcoinsByPubkey(tokenID common.Hash, pubkey []b
 	return r0, r1
 }
 
-// GetPDEContributionStatus provides a mock function with given fields: prefix, suffix
-func (_m *DatabaseInterface) GetPDEContributionStatus(prefix []byte, suffix []byte) ([]byte, error) {
+// GetPDEStatus provides a mock function with given fields: prefix, suffix
+func (_m *DatabaseInterface) GetPDEStatus(prefix []byte, suffix []byte) (byte, error) {
 	ret := _m.Called(prefix, suffix)
 
-	var r0 []byte
-	if rf, ok := ret.Get(0).(func([]byte, []byte) []byte); ok {
+	var r0 byte
+	if rf, ok := ret.Get(0).(func([]byte, []byte) byte); ok {
 		r0 = rf(prefix, suffix)
+	} else {
+		r0 = ret.Get(0).(byte)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func([]byte, []byte) error); ok {
+		r1 = rf(prefix, suffix)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPayoutBatch provides a mock function with given fields: idempotencyKey
+func (_m *DatabaseInterface) GetPayoutBatch(idempotencyKey string) ([]byte, error) {
+	ret := _m.Called(idempotencyKey)
+
+	var r0 []byte
+	if rf, ok := ret.Get(0).(func(string) []byte); ok {
+		r0 = rf(idempotencyKey)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]byte)
@@ -1169,8 +1815,8 @@ func (_m *DatabaseInterface) GetPDEContributionStatus(prefix []byte, suffix []by
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func([]byte, []byte) error); ok {
-		r1 = rf(prefix, suffix)
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(idempotencyKey)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1178,13 +1824,13 @@ func (_m *DatabaseInterface) GetPDEContributionStatus(prefix []byte, suffix []by
 	return r0, r1
 }
 
-// GetPDEPoolForPair provides a mock function with given fields: beaconHeight, tokenIDToBuyStr, tokenIDToSellStr
-func (_m *DatabaseInterface) GetPDEPoolForPair(beaconHeight uint64, tokenIDToBuyStr string, tokenIDToSellStr string) ([]byte, error) {
-	ret := _m.Called(beaconHeight, tokenIDToBuyStr, tokenIDToSellStr)
+// GetPrivacyTokenInitTx provides a mock function with given fields: tokenID
+func (_m *DatabaseInterface) GetPrivacyTokenInitTx(tokenID common.Hash) ([]byte, error) {
+	ret := _m.Called(tokenID)
 
 	var r0 []byte
-	if rf, ok := ret.Get(0).(func(uint64, string, string) []byte); ok {
-		r0 = rf(beaconHeight, tokenIDToBuyStr, tokenIDToSellStr)
+	if rf, ok := ret.Get(0).(func(common.Hash) []byte); ok {
+		r0 = rf(tokenID)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]byte)
@@ -1192,8 +1838,8 @@ func (_m *DatabaseInterface) GetPDEPoolForPair(beaconHeight uint64, tokenIDToBuy
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(uint64, string, string) error); ok {
-		r1 = rf(beaconHeight, tokenIDToBuyStr, tokenIDToSellStr)
+	if rf, ok := ret.Get(1).(func(common.Hash) error); ok {
+		r1 = rf(tokenID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1201,20 +1847,43 @@ func (_m *DatabaseInterface) GetPDEPoolForPair(beaconHeight uint64, tokenIDToBuy
 	return r0, r1
 }
 
-// GetPDEStatus provides a mock function with given fields: prefix, suffix
-func (_m *DatabaseInterface) GetPDEStatus(prefix []byte, suffix []byte) (byte, error) {
-	ret := _m.Called(prefix, suffix)
+// GetPrivacyTokenRegistry provides a mock function with given fields: tokenID
+func (_m *DatabaseInterface) GetPrivacyTokenRegistry(tokenID common.Hash) ([]byte, error) {
+	ret := _m.Called(tokenID)
+
+	var r0 []byte
+	if rf, ok := ret.Get(0).(func(common.Hash) []byte); ok {
+		r0 = rf(tokenID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(common.Hash) error); ok {
+		r1 = rf(tokenID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPrivacyTokenRegistryStatus provides a mock function with given fields: txReqID
+func (_m *DatabaseInterface) GetPrivacyTokenRegistryStatus(txReqID []byte) (byte, error) {
+	ret := _m.Called(txReqID)
 
 	var r0 byte
-	if rf, ok := ret.Get(0).(func([]byte, []byte) byte); ok {
-		r0 = rf(prefix, suffix)
+	if rf, ok := ret.Get(0).(func([]byte) byte); ok {
+		r0 = rf(txReqID)
 	} else {
 		r0 = ret.Get(0).(byte)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func([]byte, []byte) error); ok {
-		r1 = rf(prefix, suffix)
+	if rf, ok := ret.Get(1).(func([]byte) error); ok {
+		r1 = rf(txReqID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1266,6 +1935,27 @@ func (_m *DatabaseInterface) GetRewardOfShardByEpoch(epoch uint64, shardID byte,
 	return r0, r1
 }
 
+// GetSchemaVersion provides a mock function with given fields:
+func (_m *DatabaseInterface) GetSchemaVersion() (int, error) {
+	ret := _m.Called()
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func() int); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetSharesOfContributorForTokenIDOnAPair provides a mock function with given fields: token1IDStr, token2IDStr, contributedTokenIDStr, contributorAddrStr
 func (_m *DatabaseInterface) GetSharesOfContributorForTokenIDOnAPair(token1IDStr string, token2IDStr string, contributedTokenIDStr string, contributorAddrStr string) (uint64, error) {
 	ret := _m.Called(token1IDStr, token2IDStr, contributedTokenIDStr, contributorAddrStr)
@@ -1287,6 +1977,73 @@ func (_m *DatabaseInterface) GetSharesOfContributorForTokenIDOnAPair(token1IDStr
 	return r0, r1
 }
 
+// GetStakingPool provides a mock function with given fields: committeePublicKey
+func (_m *DatabaseInterface) GetStakingPool(committeePublicKey string) ([]byte, error) {
+	ret := _m.Called(committeePublicKey)
+
+	var r0 []byte
+	if rf, ok := ret.Get(0).(func(string) []byte); ok {
+		r0 = rf(committeePublicKey)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(committeePublicKey)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetStakingPoolDelegations provides a mock function with given fields: committeePublicKey
+func (_m *DatabaseInterface) GetStakingPoolDelegations(committeePublicKey string) (map[string]uint64, error) {
+	ret := _m.Called(committeePublicKey)
+
+	var r0 map[string]uint64
+	if rf, ok := ret.Get(0).(func(string) map[string]uint64); ok {
+		r0 = rf(committeePublicKey)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]uint64)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(committeePublicKey)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetStakingPoolStatus provides a mock function with given fields: txReqID
+func (_m *DatabaseInterface) GetStakingPoolStatus(txReqID []byte) (byte, error) {
+	ret := _m.Called(txReqID)
+
+	var r0 byte
+	if rf, ok := ret.Get(0).(func([]byte) byte); ok {
+		r0 = rf(txReqID)
+	} else {
+		r0 = ret.Get(0).(byte)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func([]byte) error); ok {
+		r1 = rf(txReqID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetTotalSharesForTokenIDOnAPair provides a mock function with given fields: token1IDStr, token2IDStr, contributedTokenIDStr
 func (_m *DatabaseInterface) GetTotalSharesForTokenIDOnAPair(token1IDStr string, token2IDStr string, contributedTokenIDStr string) (uint64, error) {
 	ret := _m.Called(token1IDStr, token2IDStr, contributedTokenIDStr)
@@ -1557,6 +2314,20 @@ func (_m *DatabaseInterface) HasValue(key []byte) (bool, error) {
 	return r0, r1
 }
 
+// InsertBTCTxIssued provides a mock function with given fields: uniqBTCTx
+func (_m *DatabaseInterface) InsertBTCTxIssued(uniqBTCTx []byte) error {
+	ret := _m.Called(uniqBTCTx)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func([]byte) error); ok {
+		r0 = rf(uniqBTCTx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // InsertETHTxHashIssued provides a mock function with given fields: uniqETHTx
 func (_m *DatabaseInterface) InsertETHTxHashIssued(uniqETHTx []byte) error {
 	ret := _m.Called(uniqETHTx)
@@ -1565,10 +2336,31 @@ func (_m *DatabaseInterface) InsertETHTxHashIssued(uniqETHTx []byte) error {
 	if rf, ok := ret.Get(0).(func([]byte) error); ok {
 		r0 = rf(uniqETHTx)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IsBTCTxIssued provides a mock function with given fields: uniqBTCTx
+func (_m *DatabaseInterface) IsBTCTxIssued(uniqBTCTx []byte) (bool, error) {
+	ret := _m.Called(uniqBTCTx)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func([]byte) bool); ok {
+		r0 = rf(uniqBTCTx)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func([]byte) error); ok {
+		r1 = rf(uniqBTCTx)
+	} else {
+		r1 = ret.Error(1)
 	}
 
-	return r0
+	return r0, r1
 }
 
 // IsBridgeTokenExistedByType provides a mock function with given fields: incTokenID, isCentralized
@@ -1613,6 +2405,27 @@ func (_m *DatabaseInterface) IsETHTxHashIssued(uniqETHTx []byte) (bool, error) {
 	return r0, r1
 }
 
+// IsMintableTokenExisted provides a mock function with given fields: tokenID
+func (_m *DatabaseInterface) IsMintableTokenExisted(tokenID common.Hash) (bool, error) {
+	ret := _m.Called(tokenID)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(common.Hash) bool); ok {
+		r0 = rf(tokenID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(common.Hash) error); ok {
+		r1 = rf(tokenID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ListCommitment provides a mock function with given fields: tokenID, shardID
 func (_m *DatabaseInterface) ListCommitment(tokenID common.Hash, shardID byte) (map[string]uint64, error) {
 	ret := _m.Called(tokenID, shardID)
@@ -1767,6 +2580,20 @@ func (_m *DatabaseInterface) ListSerialNumber(tokenID common.Hash, shardID byte)
 	return r0, r1
 }
 
+// MigratePDEState provides a mock function with given fields: progress
+func (_m *DatabaseInterface) MigratePDEState(progress func(uint64)) error {
+	ret := _m.Called(progress)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(func(uint64)) error); ok {
+		r0 = rf(progress)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // PrivacyTokenIDCrossShardExisted provides a mock function with given fields: tokenID
 func (_m *DatabaseInterface) PrivacyTokenIDCrossShardExisted(tokenID common.Hash) bool {
 	ret := _m.Called(tokenID)
@@ -1860,6 +2687,20 @@ func (_m *DatabaseInterface) RemoveCommitteeReward(committeeAddress []byte, amou
 	return r0
 }
 
+// RestoreBeaconStates provides a mock function with given fields:
+func (_m *DatabaseInterface) RestoreBeaconStates() error {
+	ret := _m.Called()
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // RestoreBridgedTokenByTokenID provides a mock function with given fields: tokenID
 func (_m *DatabaseInterface) RestoreBridgedTokenByTokenID(tokenID common.Hash) error {
 	ret := _m.Called(tokenID)
@@ -1972,6 +2813,48 @@ func (_m *DatabaseInterface) StoreAutoStakingByHeight(height uint64, v interface
 	return r0
 }
 
+// StoreBTCBestHeaderHash provides a mock function with given fields: hash
+func (_m *DatabaseInterface) StoreBTCBestHeaderHash(hash []byte) error {
+	ret := _m.Called(hash)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func([]byte) error); ok {
+		r0 = rf(hash)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// StoreBTCHeader provides a mock function with given fields: hash, headerInfoBytes
+func (_m *DatabaseInterface) StoreBTCHeader(hash []byte, headerInfoBytes []byte) error {
+	ret := _m.Called(hash, headerInfoBytes)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func([]byte, []byte) error); ok {
+		r0 = rf(hash, headerInfoBytes)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// StoreBTCMainChainHash provides a mock function with given fields: height, hash
+func (_m *DatabaseInterface) StoreBTCMainChainHash(height uint64, hash []byte) error {
+	ret := _m.Called(height, hash)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint64, []byte) error); ok {
+		r0 = rf(height, hash)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // StoreBeaconBestState provides a mock function with given fields: v, bd
 func (_m *DatabaseInterface) StoreBeaconBestState(v interface{}, bd *[]database.BatchData) error {
 	ret := _m.Called(v, bd)
@@ -2028,6 +2911,34 @@ func (_m *DatabaseInterface) StoreBeaconCommitteeByHeight(height uint64, v inter
 	return r0
 }
 
+// StoreBridgeQueuedRequest provides a mock function with given fields: beaconHeight, index, requestBytes
+func (_m *DatabaseInterface) StoreBridgeQueuedRequest(beaconHeight uint64, index uint64, requestBytes []byte) error {
+	ret := _m.Called(beaconHeight, index, requestBytes)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint64, uint64, []byte) error); ok {
+		r0 = rf(beaconHeight, index, requestBytes)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// StoreBridgeTokenQuota provides a mock function with given fields: tokenID, quotaBytes
+func (_m *DatabaseInterface) StoreBridgeTokenQuota(tokenID common.Hash, quotaBytes []byte) error {
+	ret := _m.Called(tokenID, quotaBytes)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(common.Hash, []byte) error); ok {
+		r0 = rf(tokenID, quotaBytes)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // StoreBurningConfirm provides a mock function with given fields: txID, height, bd
 func (_m *DatabaseInterface) StoreBurningConfirm(txID common.Hash, height uint64, bd *[]database.BatchData) error {
 	ret := _m.Called(txID, height, bd)
@@ -2070,6 +2981,62 @@ func (_m *DatabaseInterface) StoreCrossShardNextHeight(fromShard byte, toShard b
 	return r0
 }
 
+// StoreCrossShardTransaction provides a mock function with given fields: txID, crossShardTxBytes, bd
+func (_m *DatabaseInterface) StoreCrossShardTransaction(txID common.Hash, crossShardTxBytes []byte, bd *[]database.BatchData) error {
+	ret := _m.Called(txID, crossShardTxBytes, bd)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(common.Hash, []byte, *[]database.BatchData) error); ok {
+		r0 = rf(txID, crossShardTxBytes, bd)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// StoreDAOGovernedParams provides a mock function with given fields: paramsBytes
+func (_m *DatabaseInterface) StoreDAOGovernedParams(paramsBytes []byte) error {
+	ret := _m.Called(paramsBytes)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func([]byte) error); ok {
+		r0 = rf(paramsBytes)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// StoreDAOProposal provides a mock function with given fields: proposalID, proposalBytes
+func (_m *DatabaseInterface) StoreDAOProposal(proposalID common.Hash, proposalBytes []byte) error {
+	ret := _m.Called(proposalID, proposalBytes)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(common.Hash, []byte) error); ok {
+		r0 = rf(proposalID, proposalBytes)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// StoreDAOTreasury provides a mock function with given fields: treasuryBytes
+func (_m *DatabaseInterface) StoreDAOTreasury(treasuryBytes []byte) error {
+	ret := _m.Called(treasuryBytes)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func([]byte) error); ok {
+		r0 = rf(treasuryBytes)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // StoreFeeEstimator provides a mock function with given fields: val, shardID
 func (_m *DatabaseInterface) StoreFeeEstimator(val []byte, shardID byte) error {
 	ret := _m.Called(val, shardID)
@@ -2098,6 +3065,20 @@ func (_m *DatabaseInterface) StoreIncomingCrossShard(shardID byte, crossShardID
 	return r0
 }
 
+// StoreMintableToken provides a mock function with given fields: tokenID, mintableTokenBytes
+func (_m *DatabaseInterface) StoreMintableToken(tokenID common.Hash, mintableTokenBytes []byte) error {
+	ret := _m.Called(tokenID, mintableTokenBytes)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(common.Hash, []byte) error); ok {
+		r0 = rf(tokenID, mintableTokenBytes)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // StoreOutputCoins provides a mock function with given fields: tokenID, publicKey, outputCoinArr, shardID
 func (_m *DatabaseInterface) StoreOutputCoins(tokenID common.Hash, publicKey []byte, outputCoinArr [][]byte, shardID byte) error {
 	ret := _m.Called(tokenID, publicKey, outputCoinArr, shardID)
@@ -2112,6 +3093,62 @@ func (_m *DatabaseInterface) StoreOutputCoins(tokenID common.Hash, publicKey []b
 	return r0
 }
 
+// StorePDELiquidityPosition provides a mock function with given fields: contributorAddressStr, token1IDStr, token2IDStr, positionBytes
+func (_m *DatabaseInterface) StorePDELiquidityPosition(contributorAddressStr string, token1IDStr string, token2IDStr string, positionBytes []byte) error {
+	ret := _m.Called(contributorAddressStr, token1IDStr, token2IDStr, positionBytes)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, string, []byte) error); ok {
+		r0 = rf(contributorAddressStr, token1IDStr, token2IDStr, positionBytes)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// StorePDEMarketEvent provides a mock function with given fields: token1IDStr, token2IDStr, timestamp, beaconHeight, index, eventBytes, bd
+func (_m *DatabaseInterface) StorePDEMarketEvent(token1IDStr string, token2IDStr string, timestamp int64, beaconHeight uint64, index int, eventBytes []byte, bd *[]database.BatchData) error {
+	ret := _m.Called(token1IDStr, token2IDStr, timestamp, beaconHeight, index, eventBytes, bd)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, int64, uint64, int, []byte, *[]database.BatchData) error); ok {
+		r0 = rf(token1IDStr, token2IDStr, timestamp, beaconHeight, index, eventBytes, bd)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// StorePDEState provides a mock function with given fields: beaconHeight, state, retention
+func (_m *DatabaseInterface) StorePDEState(beaconHeight uint64, state map[string][]byte, retention uint64) error {
+	ret := _m.Called(beaconHeight, state, retention)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint64, map[string][]byte, uint64) error); ok {
+		r0 = rf(beaconHeight, state, retention)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// StorePayoutBatch provides a mock function with given fields: idempotencyKey, batchBytes
+func (_m *DatabaseInterface) StorePayoutBatch(idempotencyKey string, batchBytes []byte) error {
+	ret := _m.Called(idempotencyKey, batchBytes)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, []byte) error); ok {
+		r0 = rf(idempotencyKey, batchBytes)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // StorePrevBestState provides a mock function with given fields: val, isBeacon, shardID
 func (_m *DatabaseInterface) StorePrevBestState(val []byte, isBeacon bool, shardID byte) error {
 	ret := _m.Called(val, isBeacon, shardID)
@@ -2154,6 +3191,20 @@ func (_m *DatabaseInterface) StorePrivacyTokenCrossShard(tokenID common.Hash, to
 	return r0
 }
 
+// StorePrivacyTokenRegistry provides a mock function with given fields: tokenID, registryBytes
+func (_m *DatabaseInterface) StorePrivacyTokenRegistry(tokenID common.Hash, registryBytes []byte) error {
+	ret := _m.Called(tokenID, registryBytes)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(common.Hash, []byte) error); ok {
+		r0 = rf(tokenID, registryBytes)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // StorePrivacyTokenTx provides a mock function with given fields: tokenID, shardID, blockHeight, txIndex, txHash
 func (_m *DatabaseInterface) StorePrivacyTokenTx(tokenID common.Hash, shardID byte, blockHeight uint64, txIndex int32, txHash []byte) error {
 	ret := _m.Called(tokenID, shardID, blockHeight, txIndex, txHash)
@@ -2182,6 +3233,20 @@ func (_m *DatabaseInterface) StoreProducersBlackList(beaconHeight uint64, produc
 	return r0
 }
 
+// StoreRawBlock provides a mock function with given fields: hash, value
+func (_m *DatabaseInterface) StoreRawBlock(hash common.Hash, value []byte) error {
+	ret := _m.Called(hash, value)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(common.Hash, []byte) error); ok {
+		r0 = rf(hash, value)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // StoreRewardReceiverByHeight provides a mock function with given fields: height, v
 func (_m *DatabaseInterface) StoreRewardReceiverByHeight(height uint64, v interface{}) error {
 	ret := _m.Called(height, v)
@@ -2210,6 +3275,20 @@ func (_m *DatabaseInterface) StoreSNDerivators(tokenID common.Hash, sndArray [][
 	return r0
 }
 
+// StoreSchemaVersion provides a mock function with given fields: version
+func (_m *DatabaseInterface) StoreSchemaVersion(version int) error {
+	ret := _m.Called(version)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int) error); ok {
+		r0 = rf(version)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // StoreSerialNumbers provides a mock function with given fields: tokenID, serialNumber, shardID
 func (_m *DatabaseInterface) StoreSerialNumbers(tokenID common.Hash, serialNumber [][]byte, shardID byte) error {
 	ret := _m.Called(tokenID, serialNumber, shardID)
@@ -2280,6 +3359,34 @@ func (_m *DatabaseInterface) StoreShardCommitteeByHeight(height uint64, v interf
 	return r0
 }
 
+// StoreStakingPool provides a mock function with given fields: committeePublicKey, stakingPoolBytes
+func (_m *DatabaseInterface) StoreStakingPool(committeePublicKey string, stakingPoolBytes []byte) error {
+	ret := _m.Called(committeePublicKey, stakingPoolBytes)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, []byte) error); ok {
+		r0 = rf(committeePublicKey, stakingPoolBytes)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// StoreStakingPoolDelegation provides a mock function with given fields: committeePublicKey, delegatorAddressStr, amount
+func (_m *DatabaseInterface) StoreStakingPoolDelegation(committeePublicKey string, delegatorAddressStr string, amount uint64) error {
+	ret := _m.Called(committeePublicKey, delegatorAddressStr, amount)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, uint64) error); ok {
+		r0 = rf(committeePublicKey, delegatorAddressStr, amount)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // StoreTransactionIndex provides a mock function with given fields: txId, blockHash, indexInBlock, bd
 func (_m *DatabaseInterface) StoreTransactionIndex(txId common.Hash, blockHash common.Hash, indexInBlock int, bd *[]database.BatchData) error {
 	ret := _m.Called(txId, blockHash, indexInBlock, bd)
@@ -2322,6 +3429,20 @@ func (_m *DatabaseInterface) TrackBridgeReqWithStatus(txReqID common.Hash, statu
 	return r0
 }
 
+// TrackMintableTokenStatus provides a mock function with given fields: txReqID, status
+func (_m *DatabaseInterface) TrackMintableTokenStatus(txReqID []byte, status byte) error {
+	ret := _m.Called(txReqID, status)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func([]byte, byte) error); ok {
+		r0 = rf(txReqID, status)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // TrackPDEContributionStatus provides a mock function with given fields: prefix, suffix, statusContent
 func (_m *DatabaseInterface) TrackPDEContributionStatus(prefix []byte, suffix []byte, statusContent []byte) error {
 	ret := _m.Called(prefix, suffix, statusContent)
@@ -2350,13 +3471,41 @@ func (_m *DatabaseInterface) TrackPDEStatus(prefix []byte, suffix []byte, status
 	return r0
 }
 
-// UpdateBridgeTokenInfo provides a mock function with given fields: incTokenID, externalTokenID, isCentralized, updatingAmt, updateType, bd
-func (_m *DatabaseInterface) UpdateBridgeTokenInfo(incTokenID common.Hash, externalTokenID []byte, isCentralized bool, updatingAmt uint64, updateType string, bd *[]database.BatchData) error {
-	ret := _m.Called(incTokenID, externalTokenID, isCentralized, updatingAmt, updateType, bd)
+// TrackPrivacyTokenRegistryStatus provides a mock function with given fields: txReqID, status
+func (_m *DatabaseInterface) TrackPrivacyTokenRegistryStatus(txReqID []byte, status byte) error {
+	ret := _m.Called(txReqID, status)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func([]byte, byte) error); ok {
+		r0 = rf(txReqID, status)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// TrackStakingPoolStatus provides a mock function with given fields: txReqID, status
+func (_m *DatabaseInterface) TrackStakingPoolStatus(txReqID []byte, status byte) error {
+	ret := _m.Called(txReqID, status)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func([]byte, byte) error); ok {
+		r0 = rf(txReqID, status)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateBridgeTokenInfo provides a mock function with given fields: incTokenID, chainID, externalTokenID, isCentralized, updatingAmt, updateType, bd
+func (_m *DatabaseInterface) UpdateBridgeTokenInfo(incTokenID common.Hash, chainID uint64, externalTokenID []byte, isCentralized bool, updatingAmt uint64, updateType string, bd *[]database.BatchData) error {
+	ret := _m.Called(incTokenID, chainID, externalTokenID, isCentralized, updatingAmt, updateType, bd)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(common.Hash, []byte, bool, uint64, string, *[]database.BatchData) error); ok {
-		r0 = rf(incTokenID, externalTokenID, isCentralized, updatingAmt, updateType, bd)
+	if rf, ok := ret.Get(0).(func(common.Hash, uint64, []byte, bool, uint64, string, *[]database.BatchData) error); ok {
+		r0 = rf(incTokenID, chainID, externalTokenID, isCentralized, updatingAmt, updateType, bd)
 	} else {
 		r0 = ret.Error(0)
 	}
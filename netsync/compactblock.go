@@ -0,0 +1,276 @@
+package netsync
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/incognitochain/incognito-chain/blockchain"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/consensus/blsbft"
+	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/incognitochain/incognito-chain/wire"
+	libp2p "github.com/libp2p/go-libp2p-peer"
+)
+
+// pendingCompactBlock is a compact shard block which is waiting for missing txs from peers
+type pendingCompactBlock struct {
+	builder *blockchain.CompactShardBlockBuilder
+	shardID byte
+	// bftMsg is set when compact block comes from a propose message of consensus
+	bftMsg  *wire.MessageBFT
+	timer   *time.Timer
+	retries int
+}
+
+type compactBlockPool struct {
+	pending map[string]*pendingCompactBlock
+	// recent keeps last reconstructed blocks, proposed blocks are not in db yet
+	// so validators serve missing txs of a proposal from here
+	recent      map[common.Hash]*blockchain.ShardBlock
+	recentOrder []common.Hash
+	mtx         sync.Mutex
+}
+
+func newCompactBlockPool() *compactBlockPool {
+	return &compactBlockPool{
+		pending: make(map[string]*pendingCompactBlock),
+		recent:  make(map[common.Hash]*blockchain.ShardBlock),
+	}
+}
+
+func (pool *compactBlockPool) addRecentBlock(shardBlock *blockchain.ShardBlock) {
+	pool.mtx.Lock()
+	defer pool.mtx.Unlock()
+	blockHash := shardBlock.Header.Hash()
+	if _, ok := pool.recent[blockHash]; ok {
+		return
+	}
+	if len(pool.recentOrder) >= compactBlockCacheSize {
+		delete(pool.recent, pool.recentOrder[0])
+		pool.recentOrder = pool.recentOrder[1:]
+	}
+	pool.recent[blockHash] = shardBlock
+	pool.recentOrder = append(pool.recentOrder, blockHash)
+}
+
+func (pool *compactBlockPool) getRecentBlock(blockHash common.Hash) *blockchain.ShardBlock {
+	pool.mtx.Lock()
+	defer pool.mtx.Unlock()
+	return pool.recent[blockHash]
+}
+
+func (netSync *NetSync) handleMessageCompactBlockShard(msg *wire.MessageCompactBlockShard) {
+	Logger.log.Debug("Handling new message CompactBlockShard")
+	if err := msg.VerifyMsgSanity(); err != nil {
+		Logger.log.Error(err)
+		return
+	}
+	blockHash := msg.Block.Header.Hash()
+	if isAdded := netSync.handleCacheBlock("s" + blockHash.String()); isAdded {
+		return
+	}
+	netSync.reconstructCompactBlock("s"+blockHash.String(), msg.Block, nil)
+}
+
+// handleCompactProposeMsg reconstruct block of a propose message which carries a compact block,
+// consensus only receives propose message with full block
+func (netSync *NetSync) handleCompactProposeMsg(msg *wire.MessageBFT, propose *blsbft.BFTPropose) {
+	compactBlock := &blockchain.CompactShardBlock{}
+	if err := json.Unmarshal(propose.CompactBlock, compactBlock); err != nil {
+		Logger.log.Error(err)
+		return
+	}
+	netSync.reconstructCompactBlock("p"+compactBlock.Hash().String(), compactBlock, msg)
+}
+
+func (netSync *NetSync) reconstructCompactBlock(key string, compactBlock *blockchain.CompactShardBlock, bftMsg *wire.MessageBFT) {
+	builder, err := blockchain.NewCompactShardBlockBuilder(compactBlock)
+	if err != nil {
+		Logger.log.Error(err)
+		netSync.failCompactBlock(compactBlock.Header.ShardID, compactBlock.Header.Hash(), bftMsg)
+		return
+	}
+	builder.AddTxs(netSync.config.TxMemPool.ListTxsDetail())
+	if builder.IsComplete() {
+		netSync.finishCompactBlock(builder, compactBlock.Header.ShardID, bftMsg)
+		return
+	}
+
+	netSync.compactBlocks.mtx.Lock()
+	if _, ok := netSync.compactBlocks.pending[key]; ok {
+		netSync.compactBlocks.mtx.Unlock()
+		return
+	}
+	pending := &pendingCompactBlock{
+		builder: builder,
+		shardID: compactBlock.Header.ShardID,
+		bftMsg:  bftMsg,
+	}
+	pending.timer = time.AfterFunc(compactBlockTimeout, func() {
+		netSync.retryCompactBlock(key)
+	})
+	netSync.compactBlocks.pending[key] = pending
+	missingShortTxIDs := builder.MissingShortTxIDs()
+	netSync.compactBlocks.mtx.Unlock()
+
+	netSync.requestBlockTxn(compactBlock.Header.Hash(), compactBlock.Header.ShardID, missingShortTxIDs)
+}
+
+// retryCompactBlock request missing txs again when they don't arrive in time,
+// after compactBlockMaxRetries full block is fetched by hash or propose message is dropped
+func (netSync *NetSync) retryCompactBlock(key string) {
+	netSync.compactBlocks.mtx.Lock()
+	pending, ok := netSync.compactBlocks.pending[key]
+	if !ok {
+		netSync.compactBlocks.mtx.Unlock()
+		return
+	}
+	blockHash := pending.builder.BlockHash()
+	missingShortTxIDs := pending.builder.MissingShortTxIDs()
+	if pending.retries >= compactBlockMaxRetries {
+		delete(netSync.compactBlocks.pending, key)
+		netSync.compactBlocks.mtx.Unlock()
+		Logger.log.Infof("Compact block %+v timeout, missing %+v txs", blockHash, len(missingShortTxIDs))
+		netSync.failCompactBlock(pending.shardID, blockHash, pending.bftMsg)
+		return
+	}
+	pending.retries++
+	pending.timer.Reset(compactBlockTimeout)
+	netSync.compactBlocks.mtx.Unlock()
+
+	netSync.requestBlockTxn(blockHash, pending.shardID, missingShortTxIDs)
+}
+
+func (netSync *NetSync) requestBlockTxn(blockHash common.Hash, shardID byte, shortTxIDs []uint64) {
+	msg, err := wire.MakeEmptyMessage(wire.CmdGetBlockTxn)
+	if err != nil {
+		Logger.log.Error(err)
+		return
+	}
+	msg.(*wire.MessageGetBlockTxn).BlockHash = blockHash
+	msg.(*wire.MessageGetBlockTxn).ShardID = shardID
+	msg.(*wire.MessageGetBlockTxn).ShortTxIDs = shortTxIDs
+	if err := netSync.config.Server.PushMessageToShard(msg, shardID, map[libp2p.ID]bool{}); err != nil {
+		Logger.log.Error(err)
+	}
+}
+
+func (netSync *NetSync) handleMessageGetBlockTxn(msg *wire.MessageGetBlockTxn) {
+	Logger.log.Debug("Handling new message - " + wire.CmdGetBlockTxn)
+	if len(msg.ShortTxIDs) == 0 {
+		return
+	}
+	wanted := make(map[uint64]struct{}, len(msg.ShortTxIDs))
+	for _, shortTxID := range msg.ShortTxIDs {
+		wanted[shortTxID] = struct{}{}
+	}
+	txs := []metadata.Transaction{}
+	pickTxs := func(candidates []metadata.Transaction) {
+		for _, tx := range candidates {
+			shortTxID := blockchain.ShortTxID(msg.BlockHash, *tx.Hash())
+			if _, ok := wanted[shortTxID]; ok {
+				txs = append(txs, tx)
+				delete(wanted, shortTxID)
+			}
+		}
+	}
+	// proposer serves txs of its proposal from mempool, other nodes also serve them from blocks they reconstructed
+	pickTxs(netSync.config.TxMemPool.ListTxsDetail())
+	if len(wanted) > 0 {
+		if shardBlock := netSync.compactBlocks.getRecentBlock(msg.BlockHash); shardBlock != nil {
+			pickTxs(shardBlock.Body.Transactions)
+		}
+	}
+	if len(wanted) > 0 {
+		// txs are removed from mempool after block is inserted
+		if shardBlock, _, err := netSync.config.BlockChain.GetShardBlockByHash(msg.BlockHash); err == nil {
+			pickTxs(shardBlock.Body.Transactions)
+		}
+	}
+	if len(txs) == 0 {
+		return
+	}
+	msgTxn, err := wire.MakeEmptyMessage(wire.CmdBlockTxn)
+	if err != nil {
+		Logger.log.Error(err)
+		return
+	}
+	msgTxn.(*wire.MessageBlockTxn).BlockHash = msg.BlockHash
+	msgTxn.(*wire.MessageBlockTxn).ShardID = msg.ShardID
+	msgTxn.(*wire.MessageBlockTxn).Txs = txs
+	if err := netSync.config.Server.PushMessageToShard(msgTxn, msg.ShardID, map[libp2p.ID]bool{}); err != nil {
+		Logger.log.Error(err)
+	}
+}
+
+func (netSync *NetSync) handleMessageBlockTxn(msg *wire.MessageBlockTxn) {
+	Logger.log.Debug("Handling new message - " + wire.CmdBlockTxn)
+	for _, prefix := range []string{"s", "p"} {
+		key := prefix + msg.BlockHash.String()
+		isComplete := false
+		netSync.compactBlocks.mtx.Lock()
+		pending, ok := netSync.compactBlocks.pending[key]
+		if ok {
+			pending.builder.AddTxs(msg.Txs)
+			if isComplete = pending.builder.IsComplete(); isComplete {
+				pending.timer.Stop()
+				delete(netSync.compactBlocks.pending, key)
+			}
+		}
+		netSync.compactBlocks.mtx.Unlock()
+		if isComplete {
+			netSync.finishCompactBlock(pending.builder, pending.shardID, pending.bftMsg)
+		}
+	}
+}
+
+func (netSync *NetSync) removePendingCompactBlock(key string) *pendingCompactBlock {
+	netSync.compactBlocks.mtx.Lock()
+	defer netSync.compactBlocks.mtx.Unlock()
+	pending, ok := netSync.compactBlocks.pending[key]
+	if !ok {
+		return nil
+	}
+	delete(netSync.compactBlocks.pending, key)
+	return pending
+}
+
+func (netSync *NetSync) finishCompactBlock(builder *blockchain.CompactShardBlockBuilder, shardID byte, bftMsg *wire.MessageBFT) {
+	shardBlock, err := builder.Build()
+	if err != nil {
+		Logger.log.Error(err)
+		netSync.failCompactBlock(shardID, builder.BlockHash(), bftMsg)
+		return
+	}
+	netSync.compactBlocks.addRecentBlock(shardBlock)
+	if bftMsg == nil {
+		netSync.config.BlockChain.OnBlockShardReceived(shardBlock)
+		return
+	}
+	blockBytes, err := json.Marshal(shardBlock)
+	if err != nil {
+		Logger.log.Error(err)
+		return
+	}
+	content, err := json.Marshal(blsbft.BFTPropose{Block: blockBytes})
+	if err != nil {
+		Logger.log.Error(err)
+		return
+	}
+	msg := *bftMsg
+	msg.Content = content
+	netSync.config.Consensus.OnBFTMsg(&msg)
+}
+
+// failCompactBlock fall back to fetch full block by hash, a proposed block is not in db of any node
+// so propose message is dropped after its missing txs can't be fetched
+func (netSync *NetSync) failCompactBlock(shardID byte, blockHash common.Hash, bftMsg *wire.MessageBFT) {
+	if bftMsg != nil {
+		Logger.log.Errorf("Can not reconstruct proposed block %+v, drop propose message", blockHash)
+		return
+	}
+	// allow full block of this hash to be processed when it arrives
+	netSync.removeCacheBlock("s" + blockHash.String())
+	netSync.config.BlockChain.Synker.SyncBlkShard(shardID, true, false, false, []common.Hash{blockHash}, nil, 0, 0, "")
+}
@@ -0,0 +1,91 @@
+package netsync
+
+import (
+	"testing"
+	"time"
+
+	"github.com/incognitochain/incognito-chain/blockchain"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/wire"
+	libp2p "github.com/libp2p/go-libp2p-peer"
+)
+
+type compactTestServer struct {
+	msgs []wire.Message
+}
+
+func (server *compactTestServer) PushMessageToPeer(wire.Message, libp2p.ID) error {
+	return nil
+}
+
+func (server *compactTestServer) PushMessageToAll(wire.Message) error {
+	return nil
+}
+
+func (server *compactTestServer) PushMessageToShard(msg wire.Message, shardID byte, exclusivePeerIDs map[libp2p.ID]bool) error {
+	server.msgs = append(server.msgs, msg)
+	return nil
+}
+
+func TestRetryCompactProposeBlock(t *testing.T) {
+	Logger.Init(common.NewBackend(nil).Logger("test", true))
+	server := &compactTestServer{}
+	netSync := &NetSync{
+		config:        &NetSyncConfig{Server: server},
+		compactBlocks: newCompactBlockPool(),
+	}
+	compactBlock := &blockchain.CompactShardBlock{
+		Header:       blockchain.ShardHeader{ShardID: 1, Height: 10},
+		ShortTxIDs:   []uint64{1, 2},
+		PrefilledTxs: []blockchain.PrefilledTx{},
+	}
+	builder, err := blockchain.NewCompactShardBlockBuilder(compactBlock)
+	if err != nil {
+		t.Fatal(err)
+	}
+	key := "p" + compactBlock.Hash().String()
+	netSync.compactBlocks.pending[key] = &pendingCompactBlock{
+		builder: builder,
+		shardID: 1,
+		bftMsg:  &wire.MessageBFT{},
+		timer:   time.AfterFunc(time.Hour, func() {}),
+	}
+	defer netSync.compactBlocks.pending[key].timer.Stop()
+
+	for i := 1; i <= compactBlockMaxRetries; i++ {
+		netSync.retryCompactBlock(key)
+		if len(server.msgs) != i {
+			t.Fatalf("missing txs must be requested again at retry %+v", i)
+		}
+		msg := server.msgs[i-1].(*wire.MessageGetBlockTxn)
+		if msg.BlockHash != *compactBlock.Hash() || len(msg.ShortTxIDs) != 2 {
+			t.Fatalf("wrong request of missing txs %+v", msg)
+		}
+	}
+	pending := netSync.compactBlocks.pending[key]
+	netSync.retryCompactBlock(key)
+	if _, ok := netSync.compactBlocks.pending[key]; ok || len(server.msgs) != compactBlockMaxRetries {
+		t.Error("propose message must be dropped after max retries")
+	}
+	pending.timer.Stop()
+}
+
+func TestCompactBlockPoolRecentBlocks(t *testing.T) {
+	pool := newCompactBlockPool()
+	hashes := []common.Hash{}
+	for i := 0; i <= compactBlockCacheSize; i++ {
+		shardBlock := &blockchain.ShardBlock{Header: blockchain.ShardHeader{Height: uint64(i + 1)}}
+		pool.addRecentBlock(shardBlock)
+		pool.addRecentBlock(shardBlock)
+		hashes = append(hashes, shardBlock.Header.Hash())
+	}
+	if len(pool.recent) != compactBlockCacheSize || len(pool.recentOrder) != compactBlockCacheSize {
+		t.Fatalf("expect %+v recent blocks, got %+v", compactBlockCacheSize, len(pool.recent))
+	}
+	if pool.getRecentBlock(hashes[0]) != nil {
+		t.Error("oldest block must be evicted")
+	}
+	if shardBlock := pool.getRecentBlock(hashes[compactBlockCacheSize]); shardBlock == nil || shardBlock.Header.Height != uint64(compactBlockCacheSize+1) {
+		t.Error("latest block must be kept")
+	}
+}
@@ -6,6 +6,9 @@ const (
 	workers                = 5
 	messageLiveTime        = 40 * time.Second  // in second
 	messageCleanupInterval = 300 * time.Second //in second
+	compactBlockTimeout    = 3 * time.Second   // wait for missing txs of compact block before requesting them again
+	compactBlockMaxRetries = 3                 // times missing txs are requested before fetching full block or dropping propose message
+	compactBlockCacheSize  = 16                // number of reconstructed blocks kept to serve missing txs to other nodes
 )
 
 // block type
@@ -1,6 +1,7 @@
 package netsync
 
 import (
+	"encoding/json"
 	"errors"
 	"sync"
 	"sync/atomic"
@@ -8,6 +9,7 @@ import (
 
 	"github.com/incognitochain/incognito-chain/blockchain"
 	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/consensus/blsbft"
 	"github.com/incognitochain/incognito-chain/mempool"
 	"github.com/incognitochain/incognito-chain/metadata"
 	"github.com/incognitochain/incognito-chain/peer"
@@ -31,8 +33,9 @@ type NetSync struct {
 	cMessage chan interface{}
 	cQuit    chan struct{}
 
-	config *NetSyncConfig
-	cache  *NetSyncCache
+	config        *NetSyncConfig
+	cache         *NetSyncCache
+	compactBlocks *compactBlockPool
 }
 
 type NetSyncConfig struct {
@@ -53,6 +56,7 @@ type NetSyncConfig struct {
 		// list functions callback which are assigned from Server struct
 		PushMessageToPeer(wire.Message, libp2p.ID) error
 		PushMessageToAll(wire.Message) error
+		PushMessageToShard(wire.Message, byte, map[libp2p.ID]bool) error
 	}
 	Consensus interface {
 		OnBFTMsg(*wire.MessageBFT)
@@ -78,6 +82,7 @@ func (netSync *NetSync) Init(cfg *NetSyncConfig) {
 		txCache:    txCache,
 		blockCache: blockCache,
 	}
+	netSync.compactBlocks = newCompactBlockPool()
 
 	// register pubsub channel
 	_, subChanTx, err := netSync.config.PubSubManager.RegisterNewSubscriber(pubsub.TransactionHashEnterNodeTopic)
@@ -209,6 +214,18 @@ out:
 						{
 							netSync.handleMessagePeerState(msg)
 						}
+					case *wire.MessageCompactBlockShard:
+						{
+							netSync.handleMessageCompactBlockShard(msg)
+						}
+					case *wire.MessageGetBlockTxn:
+						{
+							netSync.handleMessageGetBlockTxn(msg)
+						}
+					case *wire.MessageBlockTxn:
+						{
+							netSync.handleMessageBlockTxn(msg)
+						}
 					default:
 						Logger.log.Debugf("Invalid message type in block "+"handler: %T", msg)
 					}
@@ -414,6 +431,13 @@ func (netSync *NetSync) handleMessageBFTMsg(msg *wire.MessageBFT) {
 		Logger.log.Error(err)
 		return
 	}
+	if msg.Type == blsbft.MSG_PROPOSE {
+		var propose blsbft.BFTPropose
+		if err := json.Unmarshal(msg.Content, &propose); err == nil && len(propose.Block) == 0 && len(propose.CompactBlock) > 0 {
+			netSync.handleCompactProposeMsg(msg, &propose)
+			return
+		}
+	}
 	netSync.config.Consensus.OnBFTMsg(msg)
 	// go metrics.AnalyzeTimeSeriesMetricData(map[string]interface{}{
 	// 	metrics.Measurement:      metrics.HandleMessageBFTMsgTime,
@@ -527,6 +551,12 @@ func (netSync *NetSync) handleMessageGetCrossShard(msg *wire.MessageGetCrossShar
 	}
 }
 
+func (netSync *NetSync) removeCacheBlock(blockHash string) {
+	netSync.cache.blockCacheMtx.Lock()
+	defer netSync.cache.blockCacheMtx.Unlock()
+	netSync.cache.blockCache.Delete(blockHash)
+}
+
 func (netSync *NetSync) handleCacheBlock(blockHash string) bool {
 	netSync.cache.blockCacheMtx.Lock()
 	defer netSync.cache.blockCacheMtx.Unlock()
@@ -206,6 +206,10 @@ func (server *Server) PushMessageToAll(wire.Message) error {
 	return nil
 }
 
+func (server *Server) PushMessageToShard(wire.Message, byte, map[libp2p.ID]bool) error {
+	return nil
+}
+
 var _ = func() (_ struct{}) {
 	fmt.Println("This runs before init()!")
 	bc.Init(&blockchain.Config{})
@@ -28,6 +28,8 @@ const (
 	ParseJsonMessageError
 	CacheMessageHashError
 	UnhandleMessageTypeError
+	DecodeBinaryMessageError
+	EncodeMessageError
 )
 
 var ErrCodeMessage = map[int]struct {
@@ -56,6 +58,8 @@ var ErrCodeMessage = map[int]struct {
 	ParseJsonMessageError:      {-2010, "Can not parse struct from json message"},
 	CacheMessageHashError:      {-2011, "Cache messagse hash error"},
 	UnhandleMessageTypeError:   {-2012, "Received unhandled message of type"},
+	DecodeBinaryMessageError:   {-2013, "Can not decode binary message"},
+	EncodeMessageError:         {-2014, "Can not encode message"},
 }
 
 type PeerError struct {
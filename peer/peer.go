@@ -109,6 +109,11 @@ type MessageListeners struct {
 	PushRawBytesToShard  func(p *PeerConn, msgBytes *[]byte, shard byte) error
 	PushRawBytesToBeacon func(p *PeerConn, msgBytes *[]byte) error
 	GetCurrentRoleShard  func() (string, *byte)
+
+	// compact block relay
+	OnCompactBlockShard func(p *PeerConn, msg *wire.MessageCompactBlockShard)
+	OnGetBlockTxn       func(p *PeerConn, msg *wire.MessageGetBlockTxn)
+	OnBlockTxn          func(p *PeerConn, msg *wire.MessageBlockTxn)
 }
 
 func (peerObj Peer) GetHost() host.Host {
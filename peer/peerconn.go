@@ -51,6 +51,8 @@ type PeerConn struct {
 	remoteRawAddress string
 	listenerPeer     *Peer
 	verValid         bool
+	wireCodec        int // codec negotiated in version handshake, legacy codec until remote peer announces its codec
+	wireCodecMtx     sync.RWMutex
 
 	HandleConnected    func(peerConn *PeerConn)
 	HandleDisconnected func(peerConn *PeerConn)
@@ -139,6 +141,21 @@ func (p *PeerConn) SetVerValid(v bool) {
 	p.verValid = v
 }
 
+func (p *PeerConn) GetWireCodec() int {
+	p.wireCodecMtx.RLock()
+	defer p.wireCodecMtx.RUnlock()
+	if p.wireCodec == 0 {
+		return wire.LegacyCodec
+	}
+	return p.wireCodec
+}
+
+func (p *PeerConn) SetWireCodec(codec int) {
+	p.wireCodecMtx.Lock()
+	defer p.wireCodecMtx.Unlock()
+	p.wireCodec = codec
+}
+
 // end GET/SET func
 
 // readString - read data from received message on stream
@@ -186,6 +203,17 @@ func (peerConn *PeerConn) processInMessageString(msgStr string) error {
 			return NewPeerError(HashToPoolError, err, nil)
 		}
 	}
+	// binary frame is not zipped, it can be decoded directly
+	if wire.IsBinaryFrame(jsonDecodeBytesRaw) {
+		message, err := wire.DecodeMessage(jsonDecodeBytesRaw)
+		if err != nil {
+			Logger.log.Error("Can not decode binary message")
+			Logger.log.Error(err)
+			return NewPeerError(DecodeBinaryMessageError, err, nil)
+		}
+		return peerConn.processInMessage(message)
+	}
+
 	// unzip data before process
 	jsonDecodeBytes, err := common.GZipToBytes(jsonDecodeBytesRaw)
 	if err != nil {
@@ -255,6 +283,11 @@ func (peerConn *PeerConn) processInMessageString(msgStr string) error {
 		Logger.log.Error(err)
 		return NewPeerError(ParseJsonMessageError, err, nil)
 	}
+	return peerConn.processInMessage(message)
+}
+
+// processInMessage - cache hash of decoded message and process it with corresponding message type
+func (peerConn *PeerConn) processInMessage(message wire.Message) error {
 	realType := reflect.TypeOf(message)
 	Logger.log.Debugf("Cmd message type of struct %s", realType.String())
 
@@ -347,6 +380,18 @@ func (peerConn *PeerConn) processMessageForEachType(messageType reflect.Type, me
 		if peerConn.config.MessageListeners.OnPeerState != nil {
 			peerConn.config.MessageListeners.OnPeerState(peerConn, message.(*wire.MessagePeerState))
 		}
+	case reflect.TypeOf(&wire.MessageCompactBlockShard{}):
+		if peerConn.config.MessageListeners.OnCompactBlockShard != nil {
+			peerConn.config.MessageListeners.OnCompactBlockShard(peerConn, message.(*wire.MessageCompactBlockShard))
+		}
+	case reflect.TypeOf(&wire.MessageGetBlockTxn{}):
+		if peerConn.config.MessageListeners.OnGetBlockTxn != nil {
+			peerConn.config.MessageListeners.OnGetBlockTxn(peerConn, message.(*wire.MessageGetBlockTxn))
+		}
+	case reflect.TypeOf(&wire.MessageBlockTxn{}):
+		if peerConn.config.MessageListeners.OnBlockTxn != nil {
+			peerConn.config.MessageListeners.OnBlockTxn(peerConn, message.(*wire.MessageBlockTxn))
+		}
 	case reflect.TypeOf(&wire.MessageMsgCheck{}):
 		err1 := peerConn.handleMsgCheck(message.(*wire.MessageMsgCheck))
 		if err1 != nil {
@@ -425,6 +470,19 @@ func (peerConn *PeerConn) outMessageHandler(rw *bufio.ReadWriter) {
 					message += delimMessageStr
 					sendString = message
 					Logger.log.Debugf("Send a messageHex raw bytes to %s", peerConn.remotePeer.GetPeerID().Pretty())
+				} else if peerConn.GetWireCodec() == wire.BinaryCodec {
+					// remote peer supports binary codec, frame is hex encoded to keep stream delimited by '\n'
+					forwardValue := byte(0)
+					if outMsg.forwardValue != nil {
+						forwardValue = *outMsg.forwardValue
+					}
+					messageBytes, err := wire.EncodeMessage(outMsg.message, wire.BinaryCodec, outMsg.forwardType, forwardValue)
+					if err != nil {
+						Logger.log.Error(NewPeerError(EncodeMessageError, err, nil))
+						continue
+					}
+					Logger.log.Debugf("Send a binary messageHex %s to %s", outMsg.message.MessageType(), peerConn.remotePeer.GetPeerID().Pretty())
+					sendString = hex.EncodeToString(messageBytes) + delimMessageStr
 				} else {
 					// Create and send messageHex
 					messageBytes, err := outMsg.message.JsonSerialize()
@@ -1,6 +1,11 @@
 package peerv2
 
-import "github.com/libp2p/go-libp2p-core/protocol"
+import (
+	"strconv"
+
+	"github.com/incognitochain/incognito-chain/wire"
+	"github.com/libp2p/go-libp2p-core/protocol"
+)
 
 func (s Host) GetProxyStreamProtocolID() protocol.ID {
 	return protocol.ID("proxy/" + s.Version)
@@ -9,3 +14,9 @@ func (s Host) GetProxyStreamProtocolID() protocol.ID {
 func (s Host) GetDirectProtocolID() protocol.ID {
 	return protocol.ID("direct/" + s.Version)
 }
+
+// GetBinaryCodecProtocolID is announced to highway (by libp2p identify) when node can decode binary frames on pubsub,
+// highway only gives topics versioned by BinaryCodecTopicSuffix to nodes supporting this protocol
+func GetBinaryCodecProtocolID() protocol.ID {
+	return protocol.ID("wirecodec/" + strconv.Itoa(wire.BinaryCodec))
+}
@@ -85,7 +85,7 @@ func (cm *ConnManager) PublishMessage(msg wire.Message) error {
 }
 
 func (cm *ConnManager) PublishMessageToShard(msg wire.Message, shardID byte) error {
-	publishable := []string{wire.CmdBlockShard, wire.CmdCrossShard, wire.CmdBFT}
+	publishable := []string{wire.CmdBlockShard, wire.CmdCrossShard, wire.CmdBFT, wire.CmdCompactBlockShard, wire.CmdGetBlockTxn, wire.CmdBlockTxn}
 	msgType := msg.MessageType()
 	subs := cm.subscriber.GetMsgToTopics()
 	for _, p := range publishable {
@@ -102,7 +102,8 @@ func (cm *ConnManager) PublishMessageToShard(msg wire.Message, shardID byte) err
 	}
 
 	Logger.Warn("Cannot publish message", msgType)
-	return nil
+	// caller can fall back to another message type (e.g. full block instead of compact block)
+	return errors.New("Can not find topic of this message type " + msgType + " for publish to shard")
 }
 
 func (cm *ConnManager) Start(ns NetSync) {
@@ -458,16 +459,29 @@ func encodeMessage(msg wire.Message) (string, error) {
 	return messageHex, nil
 }
 
-func broadcastMessage(msg wire.Message, topic string, ps *pubsub.PubSub) error {
-	// Encode message to string first
+// encodeMessageForTopic encode message with codec of topic,
+// binary frames are published as raw bytes while legacy messages are hex encoded
+func encodeMessageForTopic(msg wire.Message, topic string) ([]byte, error) {
+	if getTopicCodec(topic) == wire.BinaryCodec {
+		return wire.EncodeMessage(msg, wire.BinaryCodec, byte('s'), byte(0))
+	}
 	messageHex, err := encodeMessage(msg)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(messageHex), nil
+}
+
+func broadcastMessage(msg wire.Message, topic string, ps *pubsub.PubSub) error {
+	// Encode message with codec of topic first
+	data, err := encodeMessageForTopic(msg, topic)
 	if err != nil {
 		return err
 	}
 
 	// Broadcast
 	Logger.Infof("Publishing to topic %s", topic)
-	return ps.Publish(topic, []byte(messageHex))
+	return ps.Publish(topic, data)
 }
 
 type HighwayDiscoverer interface {
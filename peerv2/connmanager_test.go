@@ -6,8 +6,10 @@ import (
 	"time"
 
 	"github.com/incognitochain/incognito-chain/common"
+	peerv1 "github.com/incognitochain/incognito-chain/peer"
 	"github.com/incognitochain/incognito-chain/peerv2/mocks"
 	"github.com/incognitochain/incognito-chain/peerv2/rpcclient"
+	"github.com/incognitochain/incognito-chain/wire"
 	"github.com/libp2p/go-libp2p-core/network"
 	"github.com/libp2p/go-libp2p-core/peer"
 	"github.com/stretchr/testify/assert"
@@ -268,3 +270,22 @@ func setupConnectedness(net *mocks.Network, values []network.Connectedness) {
 func init() {
 	Logger.Init(common.NewBackend(nil).Logger("test", true))
 }
+
+func TestEncodeMessageForTopic(t *testing.T) {
+	msg := &wire.MessageBFT{Type: "vote", Content: []byte{1, 2, 3}, ChainKey: "shard-0", Timestamp: 10}
+	var received wire.Message
+	disp := &Dispatcher{
+		MessageListeners: &MessageListeners{
+			OnBFTMsg: func(p *peerv1.PeerConn, msg wire.Message) { received = msg },
+		},
+	}
+	for _, topic := range []string{"bft-0-pubsub", "bft-0-pubsub" + BinaryCodecTopicSuffix} {
+		data, err := encodeMessageForTopic(msg, topic)
+		assert.Nil(t, err)
+		// binary frames are raw bytes, legacy messages are hex encoded
+		assert.Equal(t, getTopicCodec(topic) == wire.BinaryCodec, wire.IsBinaryFrame(data), topic)
+		received = nil
+		assert.Nil(t, disp.processInMessageString(string(data)))
+		assert.Equal(t, msg, received, topic)
+	}
+}
@@ -21,3 +21,7 @@ const (
 	defaultMaxBlkReqPerPeer   = 100
 	defaultMaxBlkReqPerTime   = 100
 )
+
+// BinaryCodecTopicSuffix versions pubsub topics carrying messages of binary codec,
+// messages on other topics keep legacy codec so nodes of old version still understand them
+const BinaryCodecTopicSuffix = "-codec2"
@@ -22,6 +22,16 @@ type Dispatcher struct {
 // after receiving a good message from stream,
 // we need analyze it and process with corresponding message type
 func (d *Dispatcher) processInMessageString(msgStr string) error {
+	// binary frames of versioned topics are published as raw bytes,
+	// they never start with a hex character so they can't be mistaken for legacy messages
+	if wire.IsBinaryFrame([]byte(msgStr)) {
+		message, err := wire.DecodeMessage([]byte(msgStr))
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		return errors.WithStack(d.processMessageForEachType(reflect.TypeOf(message), message))
+	}
+
 	// NOTE: copy from peerConn.processInMessageString
 	// Parse Message header from last 24 bytes header message
 	jsonDecodeBytesRaw, err := hex.DecodeString(msgStr)
@@ -197,6 +207,18 @@ func (d *Dispatcher) processMessageForEachType(messageType reflect.Type, message
 		if d.MessageListeners.OnPeerState != nil {
 			d.MessageListeners.OnPeerState(peerConn, message.(*wire.MessagePeerState))
 		}
+	case reflect.TypeOf(&wire.MessageCompactBlockShard{}):
+		if d.MessageListeners.OnCompactBlockShard != nil {
+			d.MessageListeners.OnCompactBlockShard(peerConn, message.(*wire.MessageCompactBlockShard))
+		}
+	case reflect.TypeOf(&wire.MessageGetBlockTxn{}):
+		if d.MessageListeners.OnGetBlockTxn != nil {
+			d.MessageListeners.OnGetBlockTxn(peerConn, message.(*wire.MessageGetBlockTxn))
+		}
+	case reflect.TypeOf(&wire.MessageBlockTxn{}):
+		if d.MessageListeners.OnBlockTxn != nil {
+			d.MessageListeners.OnBlockTxn(peerConn, message.(*wire.MessageBlockTxn))
+		}
 
 	// case reflect.TypeOf(&wire.MessageMsgCheck{}):
 	// 	err1 := peerConn.handleMsgCheck(message.(*wire.MessageMsgCheck))
@@ -233,4 +255,9 @@ type MessageListeners struct {
 	//PBFT
 	OnBFTMsg    func(p *peer.PeerConn, msg wire.Message)
 	OnPeerState func(p *peer.PeerConn, msg *wire.MessagePeerState)
+
+	// compact block relay
+	OnCompactBlockShard func(p *peer.PeerConn, msg *wire.MessageCompactBlockShard)
+	OnGetBlockTxn       func(p *peer.PeerConn, msg *wire.MessageGetBlockTxn)
+	OnBlockTxn          func(p *peer.PeerConn, msg *wire.MessageBlockTxn)
 }
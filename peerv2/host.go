@@ -11,6 +11,7 @@ import (
 	core "github.com/libp2p/go-libp2p-core"
 	"github.com/libp2p/go-libp2p-core/crypto"
 	"github.com/libp2p/go-libp2p-core/host"
+	"github.com/libp2p/go-libp2p-core/network"
 	"github.com/libp2p/go-libp2p-core/peer"
 	"github.com/multiformats/go-multiaddr"
 )
@@ -71,6 +72,11 @@ func NewHost(version string, pubIP string, port int, privateKey string) *Host {
 		catchError(err)
 	}
 
+	// announce binary codec support, no data is exchanged on this protocol
+	p2pHost.SetStreamHandler(GetBinaryCodecProtocolID(), func(s network.Stream) {
+		s.Reset()
+	})
+
 	selfPeer := &Peer{
 		PeerID:        p2pHost.ID(),
 		IP:            pubIP,
@@ -289,6 +289,9 @@ func getMessagesForLayer(mode, layer string, shardID []byte) []string {
 				wire.CmdTx,
 				wire.CmdPrivacyCustomToken,
 				wire.CmdCustomToken,
+				wire.CmdCompactBlockShard,
+				wire.CmdGetBlockTxn,
+				wire.CmdBlockTxn,
 			}
 		} else if layer == common.BeaconRole {
 			return []string{
@@ -322,7 +325,7 @@ func getMessagesForLayer(mode, layer string, shardID []byte) []string {
 			wire.CmdCustomToken,
 		}
 		if containShard {
-			msgs = append(msgs, wire.CmdBlockShard)
+			msgs = append(msgs, wire.CmdBlockShard, wire.CmdCompactBlockShard, wire.CmdGetBlockTxn, wire.CmdBlockTxn)
 		}
 		return msgs
 	}
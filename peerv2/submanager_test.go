@@ -133,7 +133,7 @@ func TestGetMessage(t *testing.T) {
 			desc:  "Nodemode auto, shard role",
 			mode:  common.NodeModeAuto,
 			layer: common.ShardRole,
-			out:   []string{wire.CmdBlockBeacon, wire.CmdBlockShard, wire.CmdBlkShardToBeacon, wire.CmdCrossShard, wire.CmdTx, wire.CmdCustomToken, wire.CmdPrivacyCustomToken, wire.CmdBFT, wire.CmdPeerState},
+			out:   []string{wire.CmdBlockBeacon, wire.CmdBlockShard, wire.CmdBlkShardToBeacon, wire.CmdCrossShard, wire.CmdTx, wire.CmdCustomToken, wire.CmdPrivacyCustomToken, wire.CmdBFT, wire.CmdPeerState, wire.CmdCompactBlockShard, wire.CmdGetBlockTxn, wire.CmdBlockTxn},
 		},
 		{
 			desc:  "Nodemode auto, beacon role",
@@ -159,7 +159,7 @@ func TestGetMessage(t *testing.T) {
 			mode:    common.NodeModeRelay,
 			layer:   "",
 			shardID: []byte{1, 2, 3},
-			out:     []string{wire.CmdBlockBeacon, wire.CmdBlockShard, wire.CmdTx, wire.CmdCustomToken, wire.CmdPrivacyCustomToken, wire.CmdPeerState},
+			out:     []string{wire.CmdBlockBeacon, wire.CmdBlockShard, wire.CmdTx, wire.CmdCustomToken, wire.CmdPrivacyCustomToken, wire.CmdPeerState, wire.CmdCompactBlockShard, wire.CmdGetBlockTxn, wire.CmdBlockTxn},
 		},
 	}
 
@@ -5,6 +5,8 @@ import (
 	"strconv"
 	"strings"
 	"time"
+
+	"github.com/incognitochain/incognito-chain/wire"
 )
 
 func ParseListenner(s, defaultIP string, defaultPort int) (string, int) {
@@ -49,6 +51,14 @@ func GetCommitteeIDOfTopic(topic string) int {
 	return cID
 }
 
+// getTopicCodec return codec of messages published on topic
+func getTopicCodec(topic string) int {
+	if strings.HasSuffix(topic, BinaryCodecTopicSuffix) {
+		return wire.BinaryCodec
+	}
+	return wire.LegacyCodec
+}
+
 func batchingBlkForSync(
 	batchlen int,
 	info syncBlkInfo,
@@ -3,6 +3,8 @@ package peerv2
 import (
 	"reflect"
 	"testing"
+
+	"github.com/incognitochain/incognito-chain/wire"
 )
 
 func TestBatchingBlkHeightsForSync(t *testing.T) {
@@ -221,3 +223,15 @@ func TestBatchingBlkForSync(t *testing.T) {
 		})
 	}
 }
+
+func TestGetTopicCodec(t *testing.T) {
+	legacyTopic := "blockshard-1-pubsub"
+	binaryTopic := legacyTopic + BinaryCodecTopicSuffix
+	if getTopicCodec(legacyTopic) != wire.LegacyCodec || getTopicCodec(binaryTopic) != wire.BinaryCodec {
+		t.Errorf("unexpected codec of topics %v %v", legacyTopic, binaryTopic)
+	}
+	// version suffix doesn't change committee of topic
+	if GetCommitteeIDOfTopic(binaryTopic) != 1 {
+		t.Errorf("unexpected committee of topic %v", binaryTopic)
+	}
+}
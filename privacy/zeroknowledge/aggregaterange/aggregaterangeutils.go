@@ -14,6 +14,9 @@ const (
 	maxOutputNumberParam = 256
 )
 
+// MaxOutputNumber is max number of output coins which an aggregated range proof can prove
+const MaxOutputNumber = maxOutputNumber
+
 // bulletproofParams includes all generator for aggregated range proof
 type bulletproofParams struct {
 	g  []*privacy.Point
@@ -16,6 +16,7 @@ const (
 	RequestShardBlockByHeightTopic  = "requestshardblockbyheighttopic"
 	RequestBeaconBlockByHeightTopic = "requestbeaconblockbyheighttopic"
 	RequestBeaconBlockByHashTopic   = "requestbeaconblockbyhashtopic"
+	PDEMarketEventTopic             = "pdemarketeventtopic"
 	TestTopic                       = "testtopic"
 )
 
@@ -33,4 +34,5 @@ var Topics = []string{
 	RequestShardBlockByHeightTopic,
 	RequestShardBlockByHashTopic,
 	ShardBeststateTopic,
+	PDEMarketEventTopic,
 }
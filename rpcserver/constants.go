@@ -25,6 +25,7 @@ const (
 	getRawMempool                 = "getrawmempool"
 	getNumberOfTxsInMempool       = "getnumberoftxsinmempool"
 	getMempoolEntry               = "getmempoolentry"
+	listPendingOutputCoins        = "listpendingoutputcoins"
 	removeTxInMempool             = "removetxinmempool"
 	getBeaconPoolState            = "getbeaconpoolstate"
 	getShardPoolState             = "getshardpoolstate"
@@ -68,6 +69,7 @@ const (
 	listUnspentCustomToken                     = "listunspentcustomtoken"
 	getBalanceCustomToken                      = "getbalancecustomtoken"
 	getTransactionByHash                       = "gettransactionbyhash"
+	getCrossShardTransactionStatus             = "getcrossshardtransactionstatus"
 	gettransactionhashbyreceiver               = "gettransactionhashbyreceiver"
 	gettransactionbyreceiver                   = "gettransactionbyreceiver"
 	listCustomToken                            = "listcustomtoken"
@@ -90,6 +92,9 @@ const (
 	listCommitmentIndices                      = "listcommitmentindices"
 	createAndSendStakingTransaction            = "createandsendstakingtransaction"
 	createAndSendStopAutoStakingTransaction    = "createandsendstopautostakingtransaction"
+	createAndSendPayoutBatch                   = "createandsendpayoutbatch"
+	getPayoutBatch                             = "getpayoutbatch"
+	listPayoutBatches                          = "listpayoutbatches"
 
 	//===========For Testing and Benchmark==============
 	getAndSendTxsFromFile   = "getandsendtxsfromfile"
@@ -101,6 +106,7 @@ const (
 	getShardBestStateDetail  = "getshardbeststatedetail"
 	getBeaconBestState       = "getbeaconbeststate"
 	getBeaconBestStateDetail = "getbeaconbeststatedetail"
+	getUnbondingStakes       = "getunbondingstakes"
 
 	// Wallet rpc cmd
 	listAccounts               = "listaccounts"
@@ -116,6 +122,12 @@ const (
 	getBalanceByPaymentAddress = "getbalancebypaymentaddress"
 	getReceivedByAccount       = "getreceivedbyaccount"
 	setTxFee                   = "settxfee"
+	setCoinSelection           = "setcoinselection"
+	changeWalletPassPhrase     = "changewalletpassphrase"
+	importWatchOnlyAccount     = "importwatchonlyaccount"
+	getWatchOnlyBalance        = "getwatchonlybalance"
+	discoverWalletAccounts     = "discoverwalletaccounts"
+	getWalletDiscoveryProgress = "getwalletdiscoveryprogress"
 
 	// walletsta
 	getPublicKeyFromPaymentAddress = "getpublickeyfrompaymentaddress"
@@ -181,6 +193,73 @@ const (
 	convertPDEPrices                      = "convertpdeprices"
 	extractPDEInstsFromBeaconBlock        = "extractpdeinstsfrombeaconblock"
 
+	// staking pool
+	createRawStakingPoolCreationTransaction       = "createrawstakingpoolcreationtransaction"
+	createAndSendStakingPoolCreationTransaction   = "createandsendstakingpoolcreationtransaction"
+	signStakingPoolCreation                       = "signstakingpoolcreation"
+	createRawStakingPoolDepositTransaction        = "createrawstakingpooldeposittransaction"
+	createAndSendStakingPoolDepositTransaction    = "createandsendstakingpooldeposittransaction"
+	createRawStakingPoolWithdrawalTransaction     = "createrawstakingpoolwithdrawaltransaction"
+	createAndSendStakingPoolWithdrawalTransaction = "createandsendstakingpoolwithdrawaltransaction"
+	getStakingPool                                = "getstakingpool"
+	getStakingPoolStatus                          = "getstakingpoolstatus"
+
+	// privacy token registry
+	createRawPrivacyTokenRegistrationTransaction     = "createrawprivacytokenregistrationtransaction"
+	createAndSendPrivacyTokenRegistrationTransaction = "createandsendprivacytokenregistrationtransaction"
+	getPrivacyTokenRegistrationStatus                = "getprivacytokenregistrationstatus"
+	getPrivacyTokenInfo                              = "getprivacytokeninfo"
+	searchPrivacyTokens                              = "searchprivacytokens"
+	signPrivacyTokenRegistration                     = "signprivacytokenregistration"
+
+	// mintable token
+	createRawMintableTokenInitTransaction     = "createrawmintabletokeninittransaction"
+	createAndSendMintableTokenInitTransaction = "createandsendmintabletokeninittransaction"
+	signMintableTokenMintRequest              = "signmintabletokenmintrequest"
+	createRawMintableTokenMintTransaction     = "createrawmintabletokenminttransaction"
+	createAndSendMintableTokenMintTransaction = "createandsendmintabletokenminttransaction"
+	createRawMintableTokenBurnTransaction     = "createrawmintabletokenburntransaction"
+	createAndSendMintableTokenBurnTransaction = "createandsendmintabletokenburntransaction"
+	getMintableToken                          = "getmintabletoken"
+	getMintableTokenStatus                    = "getmintabletokenstatus"
+
+	// pde market data
+	getPDETradeHistory   = "getpdetradehistory"
+	getPDECandles        = "getpdecandles"
+	getPDEMarketSummary  = "getpdemarketsummary"
+	getPDELiquidityDepth = "getpdeliquiditydepth"
+
+	// pde liquidity position
+	getPDELiquidityPositions = "getpdeliquiditypositions"
+
+	// pde trade quote
+	getPDETradeQuote = "getpdetradequote"
+
+	// btc relaying
+	createRawTxWithRelayingBTCHeaders     = "createrawtxwithrelayingbtcheaders"
+	createAndSendTxWithRelayingBTCHeaders = "createandsendtxwithrelayingbtcheaders"
+	createRawTxWithIssuingBTCReq          = "createrawtxwithissuingbtcreq"
+	createAndSendTxWithIssuingBTCReq      = "createandsendtxwithissuingbtcreq"
+	getBTCRelayingBestHeader              = "getbtcrelayingbestheader"
+	getBTCDepositCommitment               = "getbtcdepositcommitment"
+	checkBTCDepositIssued                 = "checkbtcdepositissued"
+
+	// bridge limiter
+	getBridgeTokenQuota                   = "getbridgetokenquota"
+	signBridgeControlRequest              = "signbridgecontrolrequest"
+	createRawBridgeControlTransaction     = "createrawbridgecontroltransaction"
+	createAndSendBridgeControlTransaction = "createandsendbridgecontroltransaction"
+
+	// incognito dao governance
+	createRawDAOProposalTransaction     = "createrawdaoproposaltransaction"
+	createAndSendDAOProposalTransaction = "createandsenddaoproposaltransaction"
+	createRawDAOVoteTransaction         = "createrawdaovotetransaction"
+	createAndSendDAOVoteTransaction     = "createandsenddaovotetransaction"
+	getDAOProposal                      = "getdaoproposal"
+	listDAOProposals                    = "listdaoproposals"
+	getDAOTreasury                      = "getdaotreasury"
+	getDAOVotingWeight                  = "getdaovotingweight"
+
 	// get burning address
 	getBurningAddress = "getburningaddress"
 )
@@ -190,6 +269,7 @@ const (
 	subcribeNewShardBlock                       = "subcribenewshardblock"
 	subcribeNewBeaconBlock                      = "subcribenewbeaconblock"
 	subcribePendingTransaction                  = "subcribependingtransaction"
+	subcribeCrossShardTransactionStatus         = "subcribecrossshardtransactionstatus"
 	subcribeShardCandidateByPublickey           = "subcribeshardcandidatebypublickey"
 	subcribeShardPendingValidatorByPublickey    = "subcribeshardpendingvalidatorbypublickey"
 	subcribeShardCommitteeByPublickey           = "subcribeshardcommitteebypublickey"
@@ -204,4 +284,5 @@ const (
 	subcribeBeaconBestState                     = "subcribebeaconbeststate"
 	subcribeBeaconPoolBeststate                 = "subcribebeaconpoolbeststate"
 	subcribeShardPoolBeststate                  = "subcribeshardpoolbeststate"
+	subcribePDEMarketEvents                     = "subcribepdemarketevents"
 )
@@ -17,6 +17,7 @@ import (
 
 	"github.com/incognitochain/incognito-chain/common"
 	"github.com/incognitochain/incognito-chain/rpcserver/rpcservice"
+	"github.com/incognitochain/incognito-chain/wallet"
 )
 
 type HttpServer struct {
@@ -31,7 +32,9 @@ type HttpServer struct {
 	authSHA          []byte
 	limitAuthSHA     []byte
 	// channel
-	cRequestProcessShutdown chan struct{}
+	cRequestProcessShutdown  chan struct{}
+	cWalletConsolidationQuit chan struct{}
+	cPayoutQuit              chan struct{}
 
 	// service
 	blockService      *rpcservice.BlockService
@@ -42,6 +45,7 @@ type HttpServer struct {
 	poolStateService  *rpcservice.PoolStateService
 	txService         *rpcservice.TxService
 	walletService     *rpcservice.WalletService
+	payoutService     *rpcservice.PayoutService
 }
 
 func (httpServer *HttpServer) Init(config *RpcServerConfig) {
@@ -86,8 +90,12 @@ func (httpServer *HttpServer) Init(config *RpcServerConfig) {
 	httpServer.walletService = &rpcservice.WalletService{
 		Wallet:     httpServer.config.Wallet,
 		BlockChain: httpServer.config.BlockChain,
+		Discovery:  &wallet.AccountDiscovery{},
 	}
 	httpServer.poolStateService = &rpcservice.PoolStateService{}
+	httpServer.payoutService = &rpcservice.PayoutService{
+		DB: httpServer.config.Database,
+	}
 }
 
 // Start is used by rpcserver.go to start the rpc listener.
@@ -118,6 +126,11 @@ func (httpServer *HttpServer) Start() error {
 			Logger.log.Infof("RPC Http listener done for %s", listen.Addr())
 		}(listen)
 	}
+	if httpServer.config.Wallet != nil && httpServer.config.Wallet.GetConfig().Consolidation != nil {
+		httpServer.cWalletConsolidationQuit = make(chan struct{})
+		go httpServer.consolidateWalletAccounts(*httpServer.config.Wallet.GetConfig().Consolidation, httpServer.cWalletConsolidationQuit)
+	}
+	httpServer.cPayoutQuit = make(chan struct{})
 	atomic.StoreInt32(&httpServer.started, 1)
 	return nil
 }
@@ -135,6 +148,14 @@ func (httpServer *HttpServer) Stop() {
 	for _, listen := range httpServer.config.HttpListenters {
 		listen.Close()
 	}
+	if httpServer.cWalletConsolidationQuit != nil {
+		close(httpServer.cWalletConsolidationQuit)
+		httpServer.cWalletConsolidationQuit = nil
+	}
+	if httpServer.cPayoutQuit != nil {
+		close(httpServer.cPayoutQuit)
+		httpServer.cPayoutQuit = nil
+	}
 	Logger.log.Warn("RPC server shutdown complete")
 	atomic.StoreInt32(&httpServer.started, 0)
 	atomic.StoreInt32(&httpServer.shutdown, 1)
@@ -122,6 +122,20 @@ func (httpServer *HttpServer) handleCanPubkeyStake(params interface{}, closeChan
 	return result, nil
 }
 
+// handleGetUnbondingStakes - return stakes held in unbonding queue with their release beacon height
+func (httpServer *HttpServer) handleGetUnbondingStakes(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	Logger.log.Debugf("handleGetUnbondingStakes params: %+v", params)
+
+	beacon, err := httpServer.blockService.GetBeaconBestState()
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.GetClonedBeaconBestStateError, err)
+	}
+
+	result := jsonresult.NewGetUnbondingStakesResult(beacon.BeaconHeight, beacon.GetUnbondingStakes())
+	Logger.log.Debugf("handleGetUnbondingStakes result: %+v", result)
+	return result, nil
+}
+
 func (httpServer *HttpServer) handleGetTotalTransaction(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
 	Logger.log.Debugf("handleGetTotalTransaction params: %+v", params)
 	arrayParams := common.InterfaceSlice(params)
@@ -158,7 +158,13 @@ func (httpServer *HttpServer) handleCreateRawTxWithBurningReq(params interface{}
 		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("remote address is invalid"))
 	}
 
-	meta, err := rpcservice.NewBurningRequestMetadata(senderPrivateKeyParam, tokenReceivers, tokenID, tokenName, remoteAddress)
+	// tokens are released on the default ethereum deployment if chain id is not set
+	chainID := uint64(metadata.DefaultEVMChainID)
+	if chainIDParam, ok := tokenParamsRaw["ChainID"].(float64); ok {
+		chainID = uint64(chainIDParam)
+	}
+
+	meta, err := rpcservice.NewBurningRequestMetadata(senderPrivateKeyParam, tokenReceivers, tokenID, tokenName, remoteAddress, chainID)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,145 @@
+package rpcserver
+
+import (
+	"errors"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/common/base58"
+	"github.com/incognitochain/incognito-chain/consensus/signatureschemes/bridgesig"
+	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/incognitochain/incognito-chain/rpcserver/jsonresult"
+	"github.com/incognitochain/incognito-chain/rpcserver/rpcservice"
+)
+
+// handleGetBridgeTokenQuota - return caps of a bridge token, their remaining quota in the current epoch and queued requests of the token
+// Param #1: incognito token id
+func (httpServer *HttpServer) handleGetBridgeTokenQuota(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	arrayParams := common.InterfaceSlice(params)
+	if len(arrayParams) < 1 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Token ID is invalid"))
+	}
+	tokenIDStr, ok := arrayParams[0].(string)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Token ID is invalid"))
+	}
+	tokenID, err := common.Hash{}.NewHashFromStr(tokenIDStr)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
+	}
+	state, err := httpServer.config.BlockChain.GetBridgeLimiterState()
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.GetBridgeLimiterError, err)
+	}
+	return jsonresult.NewBridgeTokenQuotaResult(state, *tokenID), nil
+}
+
+// newBridgeControlRequestFromParam parses {"Action", "TokenID", "ReleasedTxReqID", "Nonce", "Signatures"},
+// TokenID, ReleasedTxReqID and signatures are optional
+func newBridgeControlRequestFromParam(param interface{}) (*metadata.BridgeControlRequest, *rpcservice.RPCError) {
+	data, ok := param.(map[string]interface{})
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata is invalid"))
+	}
+	action, ok := data["Action"].(string)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata is invalid"))
+	}
+	tokenID := common.Hash{}
+	if tokenIDStr, ok := data["TokenID"].(string); ok && tokenIDStr != "" {
+		hash, err := common.Hash{}.NewHashFromStr(tokenIDStr)
+		if err != nil {
+			return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
+		}
+		tokenID = *hash
+	}
+	releasedTxReqID := common.Hash{}
+	if releasedTxReqIDStr, ok := data["ReleasedTxReqID"].(string); ok && releasedTxReqIDStr != "" {
+		hash, err := common.Hash{}.NewHashFromStr(releasedTxReqIDStr)
+		if err != nil {
+			return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
+		}
+		releasedTxReqID = *hash
+	}
+	nonceData, ok := data["Nonce"].(float64)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata is invalid"))
+	}
+	signatures := []string{}
+	if signaturesData, ok := data["Signatures"].([]interface{}); ok {
+		for _, sigData := range signaturesData {
+			sig, ok := sigData.(string)
+			if !ok {
+				return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata is invalid"))
+			}
+			signatures = append(signatures, sig)
+		}
+	}
+	meta, err := metadata.NewBridgeControlRequest(
+		action,
+		tokenID,
+		releasedTxReqID,
+		uint64(nonceData),
+		signatures,
+		metadata.BridgeControlRequestMeta,
+	)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
+	}
+	return meta, nil
+}
+
+/*
+handleSignBridgeControlRequest - sign a bridge control request by the bridge key of a beacon committee member,
+signatures are collected off chain and sent in "Signatures" of the control request
+Param #1: private seed of the mining key
+Param #2: {"Action", "TokenID", "ReleasedTxReqID", "Nonce"}
+*/
+func (httpServer *HttpServer) handleSignBridgeControlRequest(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	arrayParams := common.InterfaceSlice(params)
+	if len(arrayParams) < 2 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("param must be an array at least 2 elements"))
+	}
+	privateSeed, ok := arrayParams[0].(string)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("private seed is invalid"))
+	}
+	privateSeedBytes, _, err := base58.Base58Check{}.Decode(privateSeed)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
+	}
+	meta, rpcErr := newBridgeControlRequestFromParam(arrayParams[1])
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	bridgePriKey, _ := bridgesig.KeyGen(privateSeedBytes)
+	hash := meta.HashForSigning()
+	signature, err := bridgesig.Sign(bridgesig.SKBytes(&bridgePriKey), hash[:])
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err)
+	}
+	return base58.Base58Check{}.Encode(signature, common.ZeroByte), nil
+}
+
+/*
+handleCreateRawTxWithBridgeControl - create a raw tx which pauses or unpauses a bridge token, or releases a queued bridge request
+Param #5: {"Action", "TokenID", "ReleasedTxReqID", "Nonce", "Signatures"}
+*/
+func (httpServer *HttpServer) handleCreateRawTxWithBridgeControl(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	arrayParams := common.InterfaceSlice(params)
+	if len(arrayParams) < 5 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("param must be an array at least 5 elements"))
+	}
+	meta, rpcErr := newBridgeControlRequestFromParam(arrayParams[4])
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	return httpServer.buildRawTxWithStakingPoolMeta(params, meta)
+}
+
+func (httpServer *HttpServer) handleCreateAndSendTxWithBridgeControl(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	data, err := httpServer.handleCreateRawTxWithBridgeControl(params, closeChan)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err)
+	}
+	return httpServer.sendRawTxWithStakingPoolMeta(data, closeChan)
+}
@@ -0,0 +1,162 @@
+package rpcserver
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+
+	"github.com/incognitochain/incognito-chain/blockchain/btc"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/common/base58"
+	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/incognitochain/incognito-chain/rpcserver/bean"
+	"github.com/incognitochain/incognito-chain/rpcserver/jsonresult"
+	"github.com/incognitochain/incognito-chain/rpcserver/rpcservice"
+	"github.com/incognitochain/incognito-chain/wallet"
+)
+
+// buildRawTxWithBTCRelayingMeta builds a raw tx carrying meta, params are the ones of createrawtransaction
+func (httpServer *HttpServer) buildRawTxWithBTCRelayingMeta(params interface{}, meta metadata.Metadata) (interface{}, *rpcservice.RPCError) {
+	createRawTxParam, errNewParam := bean.NewCreateRawTxParam(params)
+	if errNewParam != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errNewParam)
+	}
+	tx, err := httpServer.txService.BuildRawTransaction(createRawTxParam, meta, *httpServer.config.Database)
+	if err != nil {
+		Logger.log.Error(err)
+		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err)
+	}
+	byteArrays, err1 := json.Marshal(tx)
+	if err1 != nil {
+		Logger.log.Error(err1)
+		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err1)
+	}
+	result := jsonresult.CreateTransactionResult{
+		TxID:            tx.Hash().String(),
+		Base58CheckData: base58.Base58Check{}.Encode(byteArrays, 0x00),
+	}
+	return result, nil
+}
+
+func (httpServer *HttpServer) sendRawTxWithBTCRelayingMeta(data interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	tx := data.(jsonresult.CreateTransactionResult)
+	newParam := make([]interface{}, 0)
+	newParam = append(newParam, tx.Base58CheckData)
+	sendResult, err := httpServer.handleSendRawTransaction(newParam, closeChan)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err)
+	}
+	result := jsonresult.NewCreateTransactionResult(nil, sendResult.(jsonresult.CreateTransactionResult).TxID, nil, sendResult.(jsonresult.CreateTransactionResult).ShardID)
+	return result, nil
+}
+
+/*
+handleCreateRawTxWithRelayingBTCHeaders - relay bitcoin headers to beacon
+Param #1 - #4: params of createrawtransaction
+Param #5: {"HeaderStrs": hex of 80 bytes headers, each one is the parent of the next one}
+*/
+func (httpServer *HttpServer) handleCreateRawTxWithRelayingBTCHeaders(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	arrayParams := common.InterfaceSlice(params)
+	if arrayParams == nil || len(arrayParams) < 5 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("param must be an array at least 5 elements"))
+	}
+	data, ok := arrayParams[4].(map[string]interface{})
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata is invalid"))
+	}
+	meta, err := metadata.NewRelayingBTCHeadersFromMap(data)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
+	}
+	return httpServer.buildRawTxWithBTCRelayingMeta(params, meta)
+}
+
+func (httpServer *HttpServer) handleCreateAndSendTxWithRelayingBTCHeaders(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	data, err := httpServer.handleCreateRawTxWithRelayingBTCHeaders(params, closeChan)
+	if err != nil {
+		return nil, err
+	}
+	return httpServer.sendRawTxWithBTCRelayingMeta(data, closeChan)
+}
+
+/*
+handleCreateRawTxWithIssuingBTCReq - request pBTC for a bitcoin deposit
+Param #1 - #4: params of createrawtransaction
+Param #5: {"BlockHash", "TxStr", "TxIndex", "MerkleProofStrs", "OutputIndex", "ReceiverAddressStr"}
+*/
+func (httpServer *HttpServer) handleCreateRawTxWithIssuingBTCReq(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	arrayParams := common.InterfaceSlice(params)
+	if arrayParams == nil || len(arrayParams) < 5 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("param must be an array at least 5 elements"))
+	}
+	data, ok := arrayParams[4].(map[string]interface{})
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata is invalid"))
+	}
+	meta, err := metadata.NewIssuingBTCRequestFromMap(data)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
+	}
+	return httpServer.buildRawTxWithBTCRelayingMeta(params, meta)
+}
+
+func (httpServer *HttpServer) handleCreateAndSendTxWithIssuingBTCReq(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	data, err := httpServer.handleCreateRawTxWithIssuingBTCReq(params, closeChan)
+	if err != nil {
+		return nil, err
+	}
+	return httpServer.sendRawTxWithBTCRelayingMeta(data, closeChan)
+}
+
+// handleGetBTCRelayingBestHeader returns the best relayed bitcoin header, null if nothing is relayed
+func (httpServer *HttpServer) handleGetBTCRelayingBestHeader(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	headerInfo, err := httpServer.databaseService.GetBTCRelayingBestHeader()
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.GetBTCRelayingError, err)
+	}
+	if headerInfo == nil {
+		return nil, nil
+	}
+	return jsonresult.NewBTCHeaderResult(headerInfo), nil
+}
+
+// handleGetBTCDepositCommitment returns the OP_RETURN output a bitcoin deposit for the receiver must have
+func (httpServer *HttpServer) handleGetBTCDepositCommitment(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	arrayParams := common.InterfaceSlice(params)
+	if len(arrayParams) < 1 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Receiver address is invalid"))
+	}
+	receiverAddressStr, ok := arrayParams[0].(string)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Receiver address is invalid"))
+	}
+	keyWallet, err := wallet.Base58CheckDeserialize(receiverAddressStr)
+	if err != nil || len(keyWallet.KeySet.PaymentAddress.Pk) == 0 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Receiver address is invalid"))
+	}
+	commitment := metadata.GetBTCDepositCommitment(receiverAddressStr)
+	commitmentScript := append([]byte{0x6a, btc.BTCDepositCommitmentSize}, commitment...)
+	return jsonresult.BTCDepositCommitmentResult{
+		ReceiverAddressStr: receiverAddressStr,
+		Commitment:         hex.EncodeToString(commitment),
+		CommitmentScript:   hex.EncodeToString(commitmentScript),
+		DepositScript:      httpServer.config.ChainParams.BTCRelaying.DepositScriptStr,
+	}, nil
+}
+
+// handleCheckBTCDepositIssued - Param #1: {"TxHash", "OutputIndex"}
+func (httpServer *HttpServer) handleCheckBTCDepositIssued(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	arrayParams := common.InterfaceSlice(params)
+	if len(arrayParams) < 1 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("param must be an array at least 1 element"))
+	}
+	data, ok := arrayParams[0].(map[string]interface{})
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Payload is invalid"))
+	}
+	issued, err := httpServer.databaseService.CheckBTCDepositIssued(data)
+	if err != nil {
+		return false, rpcservice.NewRPCError(rpcservice.GetBTCRelayingError, err)
+	}
+	return issued, nil
+}
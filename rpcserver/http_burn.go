@@ -31,6 +31,16 @@ func (httpServer *HttpServer) handleGetBurnProof(params interface{}, closeChan <
 		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
 	}
 
+	// proofs are of burns on the default ethereum deployment if chain id is not set
+	chainID := uint64(metadata.DefaultEVMChainID)
+	if len(listParams) > 1 {
+		chainIDParam, ok := listParams[1].(float64)
+		if !ok || chainIDParam < 0 {
+			return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("chain id invalid"))
+		}
+		chainID = uint64(chainIDParam)
+	}
+
 	bc := httpServer.config.BlockChain
 	db := *httpServer.config.Database
 
@@ -47,7 +57,7 @@ func (httpServer *HttpServer) handleGetBurnProof(params interface{}, closeChan <
 	}
 
 	// Get proof of instruction on bridge
-	bridgeInstProof, err := getBurnProofOnBridge(txID, bridgeBlock, db, httpServer.config.ConsensusEngine)
+	bridgeInstProof, err := getBurnProofOnBridge(txID, chainID, bridgeBlock, db, httpServer.config.ConsensusEngine)
 	if err != nil {
 		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err)
 	}
@@ -68,14 +78,15 @@ func (httpServer *HttpServer) handleGetBurnProof(params interface{}, closeChan <
 // getBurnProofOnBridge finds a beacon committee swap instruction in a given bridge block and returns its proof
 func getBurnProofOnBridge(
 	txID *common.Hash,
+	chainID uint64,
 	bridgeBlock *blockchain.ShardBlock,
 	db database.DatabaseInterface,
 	ce ConsensusEngine,
 ) (*swapProof, error) {
 	insts := bridgeBlock.Body.Instructions
-	_, instID := findBurnConfirmInst(insts, txID)
+	_, instID := findBurnConfirmInst(insts, txID, chainID)
 	if instID < 0 {
-		return nil, fmt.Errorf("cannot find burning instruction in bridge block")
+		return nil, fmt.Errorf("cannot find burning instruction of chain %d in bridge block", chainID)
 	}
 
 	block := &shardBlock{ShardBlock: bridgeBlock}
@@ -124,11 +135,17 @@ func findBeaconBlockWithBurnInst(beaconBlocks []*blockchain.BeaconBlock, inst []
 	return nil, -1
 }
 
-// findBurnConfirmInst finds a BurningConfirm instruction in a list, returns it along with its index
-func findBurnConfirmInst(insts [][]string, txID *common.Hash) ([]string, int) {
-	instType := strconv.Itoa(metadata.BurningConfirmMeta)
+// findBurnConfirmInst finds a BurningConfirm instruction of an evm chain in a list, returns it along with its index
+func findBurnConfirmInst(insts [][]string, txID *common.Hash, chainID uint64) ([]string, int) {
 	for i, inst := range insts {
-		if inst[0] != instType || len(inst) < 5 {
+		if inst[0] != strconv.Itoa(metadata.BurningConfirmMeta) && inst[0] != strconv.Itoa(metadata.BurningConfirmForEVMChainMeta) {
+			continue
+		}
+		if len(inst) < 8 {
+			continue
+		}
+		instChainID, err := blockchain.GetBurningConfirmChainID(inst)
+		if err != nil || instChainID != chainID {
 			continue
 		}
 
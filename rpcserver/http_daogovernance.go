@@ -0,0 +1,200 @@
+package rpcserver
+
+import (
+	"errors"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/incognitochain/incognito-chain/rpcserver/jsonresult"
+	"github.com/incognitochain/incognito-chain/rpcserver/rpcservice"
+)
+
+/*
+handleCreateRawTxWithDAOProposal - create a raw tx submitting a proposal to Incognito DAO, the sender must hold staked PRV
+Param #5: {"Type", "ProposerAddress", "Description", "ReceiverAddress", "Amounts", "ParamName", "TokenID", "ParamValue"},
+"ReceiverAddress" and "Amounts" ({token id: amount}) are for a treasury spend, "ParamName", "TokenID" and "ParamValue" are for a param change
+*/
+func (httpServer *HttpServer) handleCreateRawTxWithDAOProposal(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	arrayParams := common.InterfaceSlice(params)
+	if len(arrayParams) < 5 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("param must be an array at least 5 elements"))
+	}
+
+	// get meta data from params
+	data, ok := arrayParams[4].(map[string]interface{})
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata is invalid"))
+	}
+	proposalType, ok := data["Type"].(string)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata is invalid"))
+	}
+	proposerAddress, ok := data["ProposerAddress"].(string)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata is invalid"))
+	}
+	description, _ := data["Description"].(string)
+	receiverAddress, _ := data["ReceiverAddress"].(string)
+	amounts := map[common.Hash]uint64{}
+	if amountsData, ok := data["Amounts"].(map[string]interface{}); ok {
+		for tokenIDStr, amountData := range amountsData {
+			tokenID, err := common.Hash{}.NewHashFromStr(tokenIDStr)
+			if err != nil {
+				return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
+			}
+			amount, ok := amountData.(float64)
+			if !ok {
+				return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata is invalid"))
+			}
+			amounts[*tokenID] = uint64(amount)
+		}
+	}
+	paramName, _ := data["ParamName"].(string)
+	tokenID := common.Hash{}
+	if tokenIDStr, ok := data["TokenID"].(string); ok && tokenIDStr != "" {
+		hash, err := common.Hash{}.NewHashFromStr(tokenIDStr)
+		if err != nil {
+			return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
+		}
+		tokenID = *hash
+	}
+	paramValue, _ := data["ParamValue"].(float64)
+	meta, err := metadata.NewDAOProposalRequest(
+		proposalType,
+		proposerAddress,
+		description,
+		receiverAddress,
+		amounts,
+		paramName,
+		tokenID,
+		uint64(paramValue),
+		metadata.DAOProposalMeta,
+	)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err)
+	}
+	return httpServer.buildRawTxWithStakingPoolMeta(params, meta)
+}
+
+func (httpServer *HttpServer) handleCreateAndSendTxWithDAOProposal(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	data, err := httpServer.handleCreateRawTxWithDAOProposal(params, closeChan)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err)
+	}
+	return httpServer.sendRawTxWithStakingPoolMeta(data, closeChan)
+}
+
+/*
+handleCreateRawTxWithDAOVote - create a raw tx voting on a proposal of Incognito DAO, a later vote replaces an earlier one
+Param #5: {"ProposalID", "VoterAddress", "Approve"}
+*/
+func (httpServer *HttpServer) handleCreateRawTxWithDAOVote(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	arrayParams := common.InterfaceSlice(params)
+	if len(arrayParams) < 5 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("param must be an array at least 5 elements"))
+	}
+
+	// get meta data from params
+	data, ok := arrayParams[4].(map[string]interface{})
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata is invalid"))
+	}
+	proposalIDStr, ok := data["ProposalID"].(string)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata is invalid"))
+	}
+	proposalID, err := common.Hash{}.NewHashFromStr(proposalIDStr)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
+	}
+	voterAddress, ok := data["VoterAddress"].(string)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata is invalid"))
+	}
+	approve, ok := data["Approve"].(bool)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata is invalid"))
+	}
+	meta, err := metadata.NewDAOVoteRequest(*proposalID, voterAddress, approve, metadata.DAOVoteMeta)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err)
+	}
+	return httpServer.buildRawTxWithStakingPoolMeta(params, meta)
+}
+
+func (httpServer *HttpServer) handleCreateAndSendTxWithDAOVote(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	data, err := httpServer.handleCreateRawTxWithDAOVote(params, closeChan)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err)
+	}
+	return httpServer.sendRawTxWithStakingPoolMeta(data, closeChan)
+}
+
+// handleGetDAOProposal - return a proposal of Incognito DAO with its votes
+// Param #1: proposal id, the id of the tx submitting it
+func (httpServer *HttpServer) handleGetDAOProposal(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	arrayParams := common.InterfaceSlice(params)
+	if len(arrayParams) < 1 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Proposal ID is invalid"))
+	}
+	proposalIDStr, ok := arrayParams[0].(string)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Proposal ID is invalid"))
+	}
+	proposalID, err := common.Hash{}.NewHashFromStr(proposalIDStr)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
+	}
+	proposal, err := httpServer.config.BlockChain.GetDAOProposal(*proposalID)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.GetDAOGovernanceError, err)
+	}
+	if proposal == nil {
+		return nil, rpcservice.NewRPCError(rpcservice.GetDAOGovernanceError, errors.New("Proposal is not found"))
+	}
+	return jsonresult.NewDAOProposalResult(proposal), nil
+}
+
+// handleListDAOProposals - return proposals of Incognito DAO of every status in the order they were created
+func (httpServer *HttpServer) handleListDAOProposals(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	proposals, err := httpServer.config.BlockChain.GetAllDAOProposals()
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.GetDAOGovernanceError, err)
+	}
+	result := []*jsonresult.DAOProposalResult{}
+	for _, proposal := range proposals {
+		result = append(result, jsonresult.NewDAOProposalResult(proposal))
+	}
+	return result, nil
+}
+
+// handleGetDAOTreasury - return balances of the treasury of Incognito DAO and params governing it
+func (httpServer *HttpServer) handleGetDAOTreasury(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	state, err := httpServer.config.BlockChain.GetDAOGovernanceState()
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.GetDAOGovernanceError, err)
+	}
+	return jsonresult.NewDAOTreasuryResult(state, httpServer.config.BlockChain.GetIncDAOGovernanceParams()), nil
+}
+
+// handleGetDAOVotingWeight - return staked PRV of an account which its proposals and votes are weighted by
+// Param #1: payment address
+func (httpServer *HttpServer) handleGetDAOVotingWeight(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	arrayParams := common.InterfaceSlice(params)
+	if len(arrayParams) < 1 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Payment address is invalid"))
+	}
+	paymentAddress, ok := arrayParams[0].(string)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Payment address is invalid"))
+	}
+	weight, totalWeight, err := httpServer.config.BlockChain.GetDAOVotingWeight(paymentAddress)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.GetDAOGovernanceError, err)
+	}
+	return jsonresult.DAOVotingWeightResult{
+		PaymentAddress: paymentAddress,
+		Weight:         weight,
+		TotalWeight:    totalWeight,
+	}, nil
+}
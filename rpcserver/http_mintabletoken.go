@@ -0,0 +1,292 @@
+package rpcserver
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/common/base58"
+	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/incognitochain/incognito-chain/rpcserver/jsonresult"
+	"github.com/incognitochain/incognito-chain/rpcserver/rpcservice"
+)
+
+/*
+handleCreateRawTxWithMintableTokenInit - create a raw tx which inits a mintable token, id of the token is derived from id of the tx
+Param #5: {"TokenName", "TokenSymbol", "AuthorityAddresses", "RequiredSignatures"}
+*/
+func (httpServer *HttpServer) handleCreateRawTxWithMintableTokenInit(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	arrayParams := common.InterfaceSlice(params)
+	if len(arrayParams) < 5 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("param must be an array at least 5 elements"))
+	}
+
+	// get meta data from params
+	data, ok := arrayParams[4].(map[string]interface{})
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata is invalid"))
+	}
+	tokenName, ok := data["TokenName"].(string)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata is invalid"))
+	}
+	tokenSymbol, ok := data["TokenSymbol"].(string)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata is invalid"))
+	}
+	authorityAddressesData, ok := data["AuthorityAddresses"].([]interface{})
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata is invalid"))
+	}
+	authorityAddresses := make([]string, 0, len(authorityAddressesData))
+	for _, addressData := range authorityAddressesData {
+		address, ok := addressData.(string)
+		if !ok {
+			return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata is invalid"))
+		}
+		authorityAddresses = append(authorityAddresses, address)
+	}
+	requiredSignaturesData, ok := data["RequiredSignatures"].(float64)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata is invalid"))
+	}
+	meta, err := metadata.NewMintableTokenInit(
+		tokenName,
+		tokenSymbol,
+		authorityAddresses,
+		int(requiredSignaturesData),
+		metadata.MintableTokenInitMeta,
+	)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
+	}
+	return httpServer.buildRawTxWithStakingPoolMeta(params, meta)
+}
+
+func (httpServer *HttpServer) handleCreateAndSendTxWithMintableTokenInit(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	data, err := httpServer.handleCreateRawTxWithMintableTokenInit(params, closeChan)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err)
+	}
+	return httpServer.sendRawTxWithStakingPoolMeta(data, closeChan)
+}
+
+// newMintableTokenMintRequestFromParam parses {"TokenID", "Amount", "ReceiverAddressStr", "Nonce", "Signatures"}, signatures are optional
+func newMintableTokenMintRequestFromParam(param interface{}) (*metadata.MintableTokenMintRequest, *rpcservice.RPCError) {
+	data, ok := param.(map[string]interface{})
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata is invalid"))
+	}
+	tokenIDStr, ok := data["TokenID"].(string)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata is invalid"))
+	}
+	tokenID, err := common.Hash{}.NewHashFromStr(tokenIDStr)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
+	}
+	amountData, ok := data["Amount"].(float64)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata is invalid"))
+	}
+	receiverAddressStr, ok := data["ReceiverAddressStr"].(string)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata is invalid"))
+	}
+	nonceData, ok := data["Nonce"].(float64)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata is invalid"))
+	}
+	signatures := []string{}
+	if signaturesData, ok := data["Signatures"].([]interface{}); ok {
+		for _, sigData := range signaturesData {
+			sig, ok := sigData.(string)
+			if !ok {
+				return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata is invalid"))
+			}
+			signatures = append(signatures, sig)
+		}
+	}
+	meta, err := metadata.NewMintableTokenMintRequest(
+		*tokenID,
+		uint64(amountData),
+		receiverAddressStr,
+		uint64(nonceData),
+		signatures,
+		metadata.MintableTokenMintMeta,
+	)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
+	}
+	return meta, nil
+}
+
+/*
+handleSignMintableTokenMintRequest - sign a mint request by a key of the minting authority,
+signatures of the authority keys are collected off chain and sent in "Signatures" of the mint request
+Param #1: private key
+Param #2: {"TokenID", "Amount", "ReceiverAddressStr", "Nonce"}
+*/
+func (httpServer *HttpServer) handleSignMintableTokenMintRequest(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	arrayParams := common.InterfaceSlice(params)
+	if len(arrayParams) < 2 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("param must be an array at least 2 elements"))
+	}
+	privateKeyStr, ok := arrayParams[0].(string)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("private key is invalid"))
+	}
+	keySet, _, err := rpcservice.GetKeySetFromPrivateKeyParams(privateKeyStr)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
+	}
+	meta, rpcErr := newMintableTokenMintRequestFromParam(arrayParams[1])
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	hash := meta.HashForSigning()
+	signature, err := keySet.SignDataInBase58CheckEncode(hash[:])
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err)
+	}
+	return signature, nil
+}
+
+/*
+handleCreateRawTxWithMintableTokenMint - create a raw tx which requests to mint a mintable token
+Param #5: {"TokenID", "Amount", "ReceiverAddressStr", "Nonce", "Signatures"}
+*/
+func (httpServer *HttpServer) handleCreateRawTxWithMintableTokenMint(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	arrayParams := common.InterfaceSlice(params)
+	if len(arrayParams) < 5 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("param must be an array at least 5 elements"))
+	}
+	meta, rpcErr := newMintableTokenMintRequestFromParam(arrayParams[4])
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	return httpServer.buildRawTxWithStakingPoolMeta(params, meta)
+}
+
+func (httpServer *HttpServer) handleCreateAndSendTxWithMintableTokenMint(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	data, err := httpServer.handleCreateRawTxWithMintableTokenMint(params, closeChan)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err)
+	}
+	return httpServer.sendRawTxWithStakingPoolMeta(data, closeChan)
+}
+
+/*
+handleCreateRawTxWithMintableTokenBurn - create a raw privacy token tx which burns a mintable token,
+token receivers must be the burning address
+Param #5: {"TokenID", "TokenReceivers", ...} as params of a privacy token tx
+*/
+func (httpServer *HttpServer) handleCreateRawTxWithMintableTokenBurn(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	arrayParams := common.InterfaceSlice(params)
+	if arrayParams == nil || len(arrayParams) < 5 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("param must be an array at least 5 elements"))
+	}
+
+	senderPrivateKeyParam, ok := arrayParams[0].(string)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("private key is invalid"))
+	}
+	tokenParamsRaw, ok := arrayParams[4].(map[string]interface{})
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("token param is invalid"))
+	}
+	tokenReceivers, ok := tokenParamsRaw["TokenReceivers"].(interface{})
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("token receivers is invalid"))
+	}
+	tokenID, ok := tokenParamsRaw["TokenID"].(string)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("token ID is invalid"))
+	}
+
+	meta, rpcErr := rpcservice.NewMintableTokenBurnRequestMetadata(senderPrivateKeyParam, tokenReceivers, tokenID)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+
+	customTokenTx, rpcErr := httpServer.txService.BuildRawPrivacyCustomTokenTransaction(params, meta, *httpServer.config.Database)
+	if rpcErr != nil {
+		Logger.log.Error(rpcErr)
+		return nil, rpcErr
+	}
+
+	byteArrays, err := json.Marshal(customTokenTx)
+	if err != nil {
+		Logger.log.Error(err)
+		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err)
+	}
+	result := jsonresult.CreateTransactionResult{
+		TxID:            customTokenTx.Hash().String(),
+		Base58CheckData: base58.Base58Check{}.Encode(byteArrays, 0x00),
+	}
+	return result, nil
+}
+
+func (httpServer *HttpServer) handleCreateAndSendTxWithMintableTokenBurn(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	data, err := httpServer.handleCreateRawTxWithMintableTokenBurn(params, closeChan)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err)
+	}
+
+	tx := data.(jsonresult.CreateTransactionResult)
+	newParam := []interface{}{tx.Base58CheckData}
+	sendResult, err := httpServer.handleSendRawPrivacyCustomTokenTransaction(newParam, closeChan)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err)
+	}
+	return sendResult, nil
+}
+
+// handleGetMintableToken - return a mintable token with its minting authority and circulating supply
+// Param #1: token id
+func (httpServer *HttpServer) handleGetMintableToken(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	arrayParams := common.InterfaceSlice(params)
+	if len(arrayParams) < 1 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Token ID is invalid"))
+	}
+	tokenIDStr, ok := arrayParams[0].(string)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Token ID is invalid"))
+	}
+	tokenID, err := common.Hash{}.NewHashFromStr(tokenIDStr)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
+	}
+	token, err := httpServer.databaseService.GetMintableToken(*tokenID)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.GetMintableTokenError, err)
+	}
+	if token == nil {
+		return nil, nil
+	}
+	return jsonresult.NewMintableTokenResult(token), nil
+}
+
+func (httpServer *HttpServer) handleGetMintableTokenStatus(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	arrayParams := common.InterfaceSlice(params)
+	if len(arrayParams) < 1 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Payload is invalid"))
+	}
+	data, ok := arrayParams[0].(map[string]interface{})
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Payload is invalid"))
+	}
+	txRequestIDStr, ok := data["TxRequestIDStr"].(string)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Payload is invalid"))
+	}
+	txIDHash, err := common.Hash{}.NewHashFromStr(txRequestIDStr)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
+	}
+	status, err := httpServer.databaseService.GetMintableTokenStatus(txIDHash[:])
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.GetMintableTokenError, err)
+	}
+	return status, nil
+}
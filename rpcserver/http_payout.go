@@ -0,0 +1,322 @@
+package rpcserver
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/rpcserver/bean"
+	"github.com/incognitochain/incognito-chain/rpcserver/jsonresult"
+	"github.com/incognitochain/incognito-chain/rpcserver/rpcservice"
+	"github.com/incognitochain/incognito-chain/transaction"
+	"github.com/incognitochain/incognito-chain/wallet"
+)
+
+const (
+	// status of a chunk is checked every payoutRetryInterval until its tx is in a block,
+	// a chunk which fails is retried after the same interval
+	payoutRetryInterval = 10 * time.Second
+	payoutMaxAttempts   = 30
+)
+
+/*
+handleCreateAndSendPayoutBatch - RPC plans payout entries into txs and sends them in background
+- Param #1: private key of sender
+- Param #2: list of entries {"PaymentAddress": string, "Amount": number, "TokenID": string}, TokenID is PRV if it is empty
+- Param #3: idempotency key, sending a request with a used key returns the stored batch or resumes it if it is failed or interrupted
+- Param #4: estimation fee nano P per kb, -1 to estimate by recent blocks
+- Param #5: hasPrivacy flag: 1 or -1
+*/
+func (httpServer *HttpServer) handleCreateAndSendPayoutBatch(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	arrayParams := common.InterfaceSlice(params)
+	if arrayParams == nil || len(arrayParams) < 5 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("param must be an array at least 5 elements"))
+	}
+	privateKey, ok := arrayParams[0].(string)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("private key is invalid"))
+	}
+	senderKeySet, _, err := bean.GetKeySetFromPrivateKeyParams(privateKey)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.InvalidSenderPrivateKeyError, err)
+	}
+	entriesParam, ok := arrayParams[1].([]interface{})
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("payout entries are invalid"))
+	}
+	entries := make([]transaction.PayoutEntry, 0, len(entriesParam))
+	for i, entryParam := range entriesParam {
+		entry, err := newPayoutEntryFromParam(entryParam)
+		if err != nil {
+			return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, fmt.Errorf("payout entry %+v: %+v", i, err))
+		}
+		entries = append(entries, *entry)
+	}
+	idempotencyKey, ok := arrayParams[2].(string)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("idempotency key is invalid"))
+	}
+	fee, ok := arrayParams[3].(float64)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("estimate fee coin per kb is invalid"))
+	}
+	hasPrivacy, ok := arrayParams[4].(float64)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("has privacy for tx is invalid"))
+	}
+
+	senderKeyWallet := wallet.KeyWallet{KeySet: *senderKeySet}
+	senderAddress := senderKeyWallet.Base58CheckSerialize(wallet.PaymentAddressType)
+	batch, rpcErr := httpServer.payoutService.NewPayoutBatch(idempotencyKey, senderAddress, entries, int64(fee), int(hasPrivacy) > 0)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	batch, isStarted, rpcErr := httpServer.payoutService.StartPayoutBatch(batch)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	result := newPayoutBatchResult(batch, true)
+	if isStarted {
+		go httpServer.sendPayoutBatch(batch, privateKey, httpServer.cPayoutQuit)
+	}
+	return result, nil
+}
+
+/*
+handleGetPayoutBatch - RPC returns status of a payout batch and tx of each entry
+- Param #1: idempotency key
+*/
+func (httpServer *HttpServer) handleGetPayoutBatch(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	arrayParams := common.InterfaceSlice(params)
+	if arrayParams == nil || len(arrayParams) < 1 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("param must be an array at least 1 element"))
+	}
+	idempotencyKey, ok := arrayParams[0].(string)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("idempotency key is invalid"))
+	}
+	batch, rpcErr := httpServer.payoutService.GetPayoutBatch(idempotencyKey)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	if batch == nil {
+		return nil, rpcservice.NewRPCError(rpcservice.PayoutBatchError, fmt.Errorf("payout batch %+v is not found", idempotencyKey))
+	}
+	return newPayoutBatchResult(batch, true), nil
+}
+
+// handleListPayoutBatches - RPC returns status of all payout batches without their entries
+func (httpServer *HttpServer) handleListPayoutBatches(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	batches, rpcErr := httpServer.payoutService.ListPayoutBatches()
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	result := make([]jsonresult.PayoutBatchResult, 0, len(batches))
+	for _, batch := range batches {
+		result = append(result, newPayoutBatchResult(batch, false))
+	}
+	return result, nil
+}
+
+func newPayoutEntryFromParam(param interface{}) (*transaction.PayoutEntry, error) {
+	data, ok := param.(map[string]interface{})
+	if !ok {
+		return nil, errors.New("entry is not an object")
+	}
+	paymentAddress, ok := data["PaymentAddress"].(string)
+	if !ok {
+		return nil, errors.New("payment address is invalid")
+	}
+	amount, ok := data["Amount"].(float64)
+	if !ok || amount <= 0 {
+		return nil, errors.New("amount is invalid")
+	}
+	entry := &transaction.PayoutEntry{
+		PaymentAddress: paymentAddress,
+		Amount:         uint64(amount),
+		TokenID:        common.PRVCoinID,
+	}
+	if tokenIDStr, ok := data["TokenID"].(string); ok && tokenIDStr != "" {
+		tokenID, err := common.Hash{}.NewHashFromStr(tokenIDStr)
+		if err != nil {
+			return nil, errors.New("token id is invalid")
+		}
+		entry.TokenID = *tokenID
+	}
+	return entry, nil
+}
+
+func newPayoutBatchResult(batch *rpcservice.PayoutBatch, withRecipients bool) jsonresult.PayoutBatchResult {
+	result := jsonresult.PayoutBatchResult{
+		IdempotencyKey: batch.IdempotencyKey,
+		Status:         batch.Status,
+		Error:          batch.Error,
+		NumTxs:         len(batch.Chunks),
+		CreatedTime:    batch.CreatedTime,
+		UpdatedTime:    batch.UpdatedTime,
+	}
+	for _, chunk := range batch.Chunks {
+		if chunk.Status == rpcservice.PayoutChunkSent {
+			result.NumSentTxs++
+		}
+	}
+	if !withRecipients {
+		return result
+	}
+	result.Recipients = make([]jsonresult.PayoutRecipient, len(batch.Entries))
+	for i, entry := range batch.Entries {
+		result.Recipients[i] = jsonresult.PayoutRecipient{
+			PaymentAddress: entry.PaymentAddress,
+			Amount:         entry.Amount,
+			TokenID:        entry.TokenID.String(),
+			Status:         rpcservice.PayoutChunkPending,
+		}
+	}
+	for _, chunk := range batch.Chunks {
+		for _, index := range chunk.EntryIndices {
+			result.Recipients[index].TxID = chunk.TxID
+			result.Recipients[index].Status = chunk.Status
+		}
+	}
+	return result
+}
+
+/*
+sendPayoutBatch sends txs of batch one by one, the next tx is sent after tx of the previous chunk is in a block
+because it spends change coin of the previous tx. Batch fails if a chunk fails payoutMaxAttempts times
+*/
+func (httpServer *HttpServer) sendPayoutBatch(batch *rpcservice.PayoutBatch, privateKey string, quit <-chan struct{}) {
+	defer httpServer.payoutService.FinishPayoutBatch(batch.IdempotencyKey)
+	Logger.log.Infof("Send payout batch %+v with %+v txs", batch.IdempotencyKey, len(batch.Chunks))
+	for i := range batch.Chunks {
+		chunk := &batch.Chunks[i]
+		for chunk.Status != rpcservice.PayoutChunkSent {
+			err := httpServer.sendPayoutChunk(batch, chunk, privateKey)
+			if err != nil {
+				chunk.Attempts++
+				chunk.Error = err.Error()
+				Logger.log.Errorf("Send tx %+v of payout batch %+v error %+v", i, batch.IdempotencyKey, err)
+				if chunk.Attempts >= payoutMaxAttempts {
+					batch.Status = rpcservice.PayoutBatchFailed
+					batch.Error = fmt.Sprintf("tx %+v: %+v", i, err)
+				}
+				if rpcErr := httpServer.payoutService.StorePayoutBatch(batch); rpcErr != nil {
+					Logger.log.Errorf("Store payout batch %+v error %+v", batch.IdempotencyKey, rpcErr)
+				}
+				if batch.Status == rpcservice.PayoutBatchFailed {
+					return
+				}
+			}
+			if chunk.Status == rpcservice.PayoutChunkSent {
+				break
+			}
+			select {
+			case <-quit:
+				// batch is kept as running and resumed by the next request with its idempotency key
+				return
+			case <-time.After(payoutRetryInterval):
+			}
+		}
+	}
+	batch.Status = rpcservice.PayoutBatchCompleted
+	if rpcErr := httpServer.payoutService.StorePayoutBatch(batch); rpcErr != nil {
+		Logger.log.Errorf("Store payout batch %+v error %+v", batch.IdempotencyKey, rpcErr)
+	}
+	Logger.log.Infof("Payout batch %+v is completed", batch.IdempotencyKey)
+}
+
+/*
+sendPayoutChunk moves chunk one step to a block:
+- tx of chunk is created, signed and stored with chunk before it is broadcast
+- chunk is sent when its tx is in a block
+- stored tx is broadcast again if it is neither in a block nor in mempool, e.g. after node restarts
+A chunk never gets a second tx, so it is never paid twice
+*/
+func (httpServer *HttpServer) sendPayoutChunk(batch *rpcservice.PayoutBatch, chunk *rpcservice.PayoutBatchChunk, privateKey string) error {
+	isToken := !chunk.TokenID.IsEqual(&common.PRVCoinID)
+	if chunk.RawTx == "" {
+		txID, base58CheckData, err := httpServer.createPayoutChunkTx(batch, chunk, privateKey)
+		if err != nil {
+			return err
+		}
+		chunk.Status = rpcservice.PayoutChunkSending
+		chunk.TxID = txID
+		chunk.RawTx = base58CheckData
+		if err := httpServer.storePayoutBatch(batch); err != nil {
+			return err
+		}
+	}
+
+	txHash, err := common.Hash{}.NewHashFromStr(chunk.TxID)
+	if err != nil {
+		return err
+	}
+	if _, _, _, _, err := httpServer.config.BlockChain.GetTransactionByHash(*txHash); err == nil {
+		chunk.Status = rpcservice.PayoutChunkSent
+		chunk.Error = ""
+		Logger.log.Infof("Tx %+v of payout batch %+v to %+v receivers is in block", chunk.TxID, batch.IdempotencyKey, len(chunk.Receivers))
+		return httpServer.storePayoutBatch(batch)
+	}
+	if httpServer.config.TxMemPool.HaveTransaction(txHash) {
+		return nil
+	}
+
+	var rpcErr *rpcservice.RPCError
+	if !isToken {
+		_, rpcErr = httpServer.handleSendRawTransaction([]interface{}{chunk.RawTx}, nil)
+	} else {
+		_, rpcErr = httpServer.handleSendRawPrivacyCustomTokenTransaction([]interface{}{chunk.RawTx}, nil)
+	}
+	if rpcErr != nil {
+		return rpcErr
+	}
+	Logger.log.Infof("Broadcast tx %+v of payout batch %+v to %+v receivers", chunk.TxID, batch.IdempotencyKey, len(chunk.Receivers))
+	return nil
+}
+
+// createPayoutChunkTx creates and signs tx which pays receivers of chunk, it returns tx id and base58 check data of tx
+func (httpServer *HttpServer) createPayoutChunkTx(batch *rpcservice.PayoutBatch, chunk *rpcservice.PayoutBatchChunk, privateKey string) (string, string, error) {
+	receivers := make(map[string]interface{})
+	amount := uint64(0)
+	for _, receiver := range chunk.Receivers {
+		receivers[receiver.PaymentAddress] = float64(receiver.Amount)
+		amount += receiver.Amount
+	}
+	hasPrivacy := float64(-1)
+	if batch.HasPrivacy {
+		hasPrivacy = 1
+	}
+
+	if chunk.TokenID.IsEqual(&common.PRVCoinID) {
+		params := []interface{}{privateKey, receivers, float64(batch.Fee), hasPrivacy}
+		data, rpcErr := httpServer.handleCreateRawTransaction(params, nil)
+		if rpcErr != nil {
+			return "", "", rpcErr
+		}
+		return data.(jsonresult.CreateTransactionResult).TxID, data.(jsonresult.CreateTransactionResult).Base58CheckData, nil
+	}
+	tokenParams := map[string]interface{}{
+		"Privacy":        true,
+		"TokenID":        chunk.TokenID.String(),
+		"TokenName":      "",
+		"TokenSymbol":    "",
+		"TokenTxType":    float64(transaction.CustomTokenTransfer),
+		"TokenAmount":    float64(amount),
+		"TokenFee":       float64(0),
+		"TokenReceivers": receivers,
+	}
+	params := []interface{}{privateKey, nil, float64(batch.Fee), hasPrivacy, tokenParams, nil, hasPrivacy}
+	data, rpcErr := httpServer.handleCreateRawPrivacyCustomTokenTransaction(params, nil)
+	if rpcErr != nil {
+		return "", "", rpcErr
+	}
+	return data.(jsonresult.CreateTransactionTokenResult).TxID, data.(jsonresult.CreateTransactionTokenResult).Base58CheckData, nil
+}
+
+func (httpServer *HttpServer) storePayoutBatch(batch *rpcservice.PayoutBatch) error {
+	if rpcErr := httpServer.payoutService.StorePayoutBatch(batch); rpcErr != nil {
+		return rpcErr
+	}
+	return nil
+}
@@ -0,0 +1,299 @@
+package rpcserver
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/incognitochain/incognito-chain/blockchain"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/database/lvdb"
+	"github.com/incognitochain/incognito-chain/rpcserver/rpcservice"
+	"github.com/incognitochain/incognito-chain/wallet"
+)
+
+const (
+	pdeMarketSummaryPeriod     = int64(24 * 60 * 60)
+	pdeMinCandleInterval       = int64(60)
+	pdeDefaultCandleInterval   = int64(60 * 60)
+	pdeDefaultCandleCount      = int64(100)
+	pdeMaxCandleCount          = int64(1000)
+	pdeDefaultTradeHistorySize = 100
+	pdeMaxTradeHistorySize     = 1000
+)
+
+func getPDEMarketPairFromParams(params interface{}) (map[string]interface{}, string, string, *rpcservice.RPCError) {
+	arrayParams := common.InterfaceSlice(params)
+	if len(arrayParams) < 1 {
+		return nil, "", "", rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Payload data is invalid"))
+	}
+	data, ok := arrayParams[0].(map[string]interface{})
+	if !ok {
+		return nil, "", "", rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Payload data is invalid"))
+	}
+	baseTokenIDStr, ok := data["BaseTokenID"].(string)
+	if !ok || baseTokenIDStr == "" {
+		return nil, "", "", rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("BaseTokenID is invalid"))
+	}
+	quoteTokenIDStr, ok := data["QuoteTokenID"].(string)
+	if !ok || quoteTokenIDStr == "" || quoteTokenIDStr == baseTokenIDStr {
+		return nil, "", "", rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("QuoteTokenID is invalid"))
+	}
+	return data, baseTokenIDStr, quoteTokenIDStr, nil
+}
+
+// checkPDEMarketIndexer returns error if the node does not index market data of pde
+func checkPDEMarketIndexer(bc *blockchain.BlockChain) *rpcservice.RPCError {
+	if bc == nil || !bc.IsPDEMarketIndexerEnabled() {
+		return rpcservice.NewRPCError(rpcservice.GetPDEMarketDataError, errors.New("PDE market indexer is not enabled on this node, run it with --pdemarketindexer"))
+	}
+	return nil
+}
+
+// getPDEMarketTimeParam returns the value of an optional unix time param or the default one
+func getPDEMarketTimeParam(data map[string]interface{}, key string, defaultValue int64) (int64, *rpcservice.RPCError) {
+	value, found := data[key]
+	if !found {
+		return defaultValue, nil
+	}
+	valueFloat, ok := value.(float64)
+	if !ok || valueFloat < 0 {
+		return 0, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, fmt.Errorf("%s is invalid", key))
+	}
+	return int64(valueFloat), nil
+}
+
+// getPDEMarketEndTime returns the time right after the best beacon block and its height,
+// market data follows chain time instead of local time
+func (httpServer *HttpServer) getPDEMarketEndTime() (int64, uint64, *rpcservice.RPCError) {
+	beaconBestBlock, err := httpServer.blockService.GetBeaconBestBlock()
+	if err != nil {
+		return 0, 0, rpcservice.NewRPCError(rpcservice.GetPDEMarketDataError, err)
+	}
+	return beaconBestBlock.Header.Timestamp + 1, beaconBestBlock.Header.Height, nil
+}
+
+/*
+handleGetPDETradeHistory - get accepted trades of a pool pair in [FromTime, ToTime), prices are amounts of quote token for one base token
+Param #1: {"BaseTokenID", "QuoteTokenID", "FromTime" (optional, default 24h before ToTime), "ToTime" (optional, default now), "Limit" (optional, latest trades are kept)}
+*/
+func (httpServer *HttpServer) handleGetPDETradeHistory(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	if rpcErr := checkPDEMarketIndexer(httpServer.config.BlockChain); rpcErr != nil {
+		return nil, rpcErr
+	}
+	data, baseTokenIDStr, quoteTokenIDStr, rpcErr := getPDEMarketPairFromParams(params)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	endTime, _, rpcErr := httpServer.getPDEMarketEndTime()
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	toTime, rpcErr := getPDEMarketTimeParam(data, "ToTime", endTime)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	fromTime, rpcErr := getPDEMarketTimeParam(data, "FromTime", toTime-pdeMarketSummaryPeriod)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	limit := pdeDefaultTradeHistorySize
+	if limitParam, found := data["Limit"]; found {
+		limitFloat, ok := limitParam.(float64)
+		if !ok || limitFloat <= 0 || int(limitFloat) > pdeMaxTradeHistorySize {
+			return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, fmt.Errorf("Limit must be from 1 to %d", pdeMaxTradeHistorySize))
+		}
+		limit = int(limitFloat)
+	}
+
+	events, err := httpServer.databaseService.GetPDEMarketEvents(baseTokenIDStr, quoteTokenIDStr, fromTime, toTime)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.GetPDEMarketDataError, err)
+	}
+	trades := []*blockchain.PDEMarketTrade{}
+	for _, event := range events {
+		trade := blockchain.NewPDEMarketTrade(event, baseTokenIDStr)
+		if trade != nil {
+			trades = append(trades, trade)
+		}
+	}
+	if len(trades) > limit {
+		trades = trades[len(trades)-limit:]
+	}
+	return trades, nil
+}
+
+/*
+handleGetPDECandles - get OHLC candles of a pool pair in [FromTime, ToTime), candles start at multiples of Interval
+Param #1: {"BaseTokenID", "QuoteTokenID", "Interval" (seconds, optional, default 3600), "FromTime" (optional, default 100 intervals before ToTime), "ToTime" (optional, default now)}
+*/
+func (httpServer *HttpServer) handleGetPDECandles(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	if rpcErr := checkPDEMarketIndexer(httpServer.config.BlockChain); rpcErr != nil {
+		return nil, rpcErr
+	}
+	data, baseTokenIDStr, quoteTokenIDStr, rpcErr := getPDEMarketPairFromParams(params)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	interval, rpcErr := getPDEMarketTimeParam(data, "Interval", pdeDefaultCandleInterval)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	if interval < pdeMinCandleInterval || interval%pdeMinCandleInterval != 0 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, fmt.Errorf("Interval must be a multiple of %d seconds", pdeMinCandleInterval))
+	}
+	endTime, _, rpcErr := httpServer.getPDEMarketEndTime()
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	toTime, rpcErr := getPDEMarketTimeParam(data, "ToTime", endTime)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	fromTime, rpcErr := getPDEMarketTimeParam(data, "FromTime", toTime-interval*pdeDefaultCandleCount)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	fromTime -= fromTime % interval
+	if toTime <= fromTime || (toTime-fromTime)/interval > pdeMaxCandleCount {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, fmt.Errorf("Time range must cover from 1 to %d candles", pdeMaxCandleCount))
+	}
+
+	events, err := httpServer.databaseService.GetPDEMarketEvents(baseTokenIDStr, quoteTokenIDStr, fromTime, toTime)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.GetPDEMarketDataError, err)
+	}
+	return blockchain.BuildPDECandles(events, baseTokenIDStr, interval, fromTime, toTime), nil
+}
+
+/*
+handleGetPDEMarketSummary - get price, volume and pool values of a pool pair for the last 24 hours
+Param #1: {"BaseTokenID", "QuoteTokenID"}
+*/
+func (httpServer *HttpServer) handleGetPDEMarketSummary(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	if rpcErr := checkPDEMarketIndexer(httpServer.config.BlockChain); rpcErr != nil {
+		return nil, rpcErr
+	}
+	_, baseTokenIDStr, quoteTokenIDStr, rpcErr := getPDEMarketPairFromParams(params)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	toTime, beaconHeight, rpcErr := httpServer.getPDEMarketEndTime()
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	fromTime := toTime - pdeMarketSummaryPeriod
+	events, err := httpServer.databaseService.GetPDEMarketEvents(baseTokenIDStr, quoteTokenIDStr, fromTime, toTime)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.GetPDEMarketDataError, err)
+	}
+	poolPair, err := httpServer.databaseService.GetPDEPoolForPair(beaconHeight, baseTokenIDStr, quoteTokenIDStr)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.GetPDEMarketDataError, err)
+	}
+	return blockchain.BuildPDEMarketSummary(events, poolPair, baseTokenIDStr, quoteTokenIDStr, fromTime, toTime), nil
+}
+
+/*
+handleGetPDELiquidityDepth - get amounts which can be traded on a pool pair until its price moves by given percents
+Param #1: {"BaseTokenID", "QuoteTokenID", "Percents" (optional, default [0.5, 1, 2, 5, 10])}
+*/
+func (httpServer *HttpServer) handleGetPDELiquidityDepth(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	data, baseTokenIDStr, quoteTokenIDStr, rpcErr := getPDEMarketPairFromParams(params)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	percents := blockchain.DefaultPDELiquidityDepthPercents
+	if percentsParam, found := data["Percents"]; found {
+		percentsRaw, ok := percentsParam.([]interface{})
+		if !ok || len(percentsRaw) == 0 {
+			return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Percents is invalid"))
+		}
+		percents = make([]float64, 0, len(percentsRaw))
+		for _, percentRaw := range percentsRaw {
+			percent, ok := percentRaw.(float64)
+			if !ok || percent <= 0 {
+				return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Percents must be positive numbers"))
+			}
+			percents = append(percents, percent)
+		}
+	}
+	_, beaconHeight, rpcErr := httpServer.getPDEMarketEndTime()
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	poolPair, err := httpServer.databaseService.GetPDEPoolForPair(beaconHeight, baseTokenIDStr, quoteTokenIDStr)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.GetPDEMarketDataError, err)
+	}
+	return blockchain.BuildPDELiquidityDepth(poolPair, baseTokenIDStr, quoteTokenIDStr, percents), nil
+}
+
+/*
+handleGetPDELiquidityPositions - get liquidity positions of a contributor on all pool pairs, including the withdrawn ones,
+with redeemable amounts, accrued trading fees and impermanent loss at the best beacon height
+Param #1: {"PaymentAddress"}
+*/
+func (httpServer *HttpServer) handleGetPDELiquidityPositions(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	if rpcErr := checkPDEMarketIndexer(httpServer.config.BlockChain); rpcErr != nil {
+		return nil, rpcErr
+	}
+	arrayParams := common.InterfaceSlice(params)
+	if len(arrayParams) < 1 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Payload data is invalid"))
+	}
+	data, ok := arrayParams[0].(map[string]interface{})
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Payload data is invalid"))
+	}
+	paymentAddressStr, ok := data["PaymentAddress"].(string)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("PaymentAddress is invalid"))
+	}
+	if _, err := wallet.Base58CheckDeserialize(paymentAddressStr); err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
+	}
+	_, beaconHeight, rpcErr := httpServer.getPDEMarketEndTime()
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	pdeState, err := blockchain.InitCurrentPDEStateFromDB(httpServer.config.BlockChain.GetDatabase(), beaconHeight)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.GetPDELiquidityPositionError, err)
+	}
+	positions, err := httpServer.databaseService.GetPDELiquidityPositionsByContributor(paymentAddressStr)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.GetPDELiquidityPositionError, err)
+	}
+
+	sharesByPair := blockchain.GetPDESharesOfContributor(pdeState.PDEShares, beaconHeight, paymentAddressStr)
+	positionsByPair := make(map[string]*lvdb.PDELiquidityPosition)
+	pairKeys := []string{}
+	for pairKey := range sharesByPair {
+		pairKeys = append(pairKeys, pairKey)
+	}
+	for _, position := range positions {
+		pairKey := position.Token1IDStr + "-" + position.Token2IDStr
+		positionsByPair[pairKey] = position
+		if _, found := sharesByPair[pairKey]; !found {
+			pairKeys = append(pairKeys, pairKey)
+		}
+	}
+	sort.Strings(pairKeys)
+
+	reports := []*blockchain.PDELiquidityPositionReport{}
+	for _, pairKey := range pairKeys {
+		position := positionsByPair[pairKey]
+		shares, totalShares := uint64(0), uint64(0)
+		token1IDStr, token2IDStr := "", ""
+		if pairShares, found := sharesByPair[pairKey]; found {
+			shares, totalShares = pairShares.Shares, pairShares.TotalShares
+			token1IDStr, token2IDStr = pairShares.Token1IDStr, pairShares.Token2IDStr
+		} else {
+			token1IDStr, token2IDStr = position.Token1IDStr, position.Token2IDStr
+		}
+		poolPair := pdeState.PDEPoolPairs[string(lvdb.BuildPDEPoolForPairKey(beaconHeight, token1IDStr, token2IDStr))]
+		reports = append(reports, blockchain.BuildPDELiquidityPositionReport(paymentAddressStr, position, shares, totalShares, poolPair))
+	}
+	return reports, nil
+}
@@ -0,0 +1,108 @@
+package rpcserver
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/incognitochain/incognito-chain/rpcserver/rpcservice"
+)
+
+// getPDETradeQuoteAmountParam returns the value of an amount param, optional ones are 0 by default
+func getPDETradeQuoteAmountParam(data map[string]interface{}, key string, required bool) (uint64, *rpcservice.RPCError) {
+	value, found := data[key]
+	if !found && !required {
+		return 0, nil
+	}
+	valueFloat, ok := value.(float64)
+	if !ok || valueFloat < 0 {
+		return 0, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, fmt.Errorf("%s is invalid", key))
+	}
+	return uint64(valueFloat), nil
+}
+
+/*
+handleGetPDETradeQuote - simulate a trade request with the pricing of beacon, trades of the same pool pair
+in mempool of this node are executed before it if their trading fee rate is not lower
+Param #1: {"TokenIDToBuyStr", "TokenIDToSellStr", "SellAmount", "TradingFee", "MinAcceptableAmount" (optional),
+"BeaconHeight" (optional, default the best one), "SlippagePercent" (optional, default 1), "IncludePendingTrades" (optional, default true)}
+*/
+func (httpServer *HttpServer) handleGetPDETradeQuote(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	arrayParams := common.InterfaceSlice(params)
+	if len(arrayParams) < 1 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Payload data is invalid"))
+	}
+	data, ok := arrayParams[0].(map[string]interface{})
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Payload data is invalid"))
+	}
+	tokenIDToBuyStr, ok := data["TokenIDToBuyStr"].(string)
+	if !ok || tokenIDToBuyStr == "" {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("TokenIDToBuyStr is invalid"))
+	}
+	tokenIDToSellStr, ok := data["TokenIDToSellStr"].(string)
+	if !ok || tokenIDToSellStr == "" || tokenIDToSellStr == tokenIDToBuyStr {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("TokenIDToSellStr is invalid"))
+	}
+	sellAmount, rpcErr := getPDETradeQuoteAmountParam(data, "SellAmount", true)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	tradingFee, rpcErr := getPDETradeQuoteAmountParam(data, "TradingFee", true)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	minAcceptableAmount, rpcErr := getPDETradeQuoteAmountParam(data, "MinAcceptableAmount", false)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	slippagePercent := metadata.DefaultPDETradeSlippagePercent
+	if value, found := data["SlippagePercent"]; found {
+		slippagePercent, ok = value.(float64)
+		if !ok {
+			return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("SlippagePercent is invalid"))
+		}
+	}
+	includePendingTrades := true
+	if value, found := data["IncludePendingTrades"]; found {
+		includePendingTrades, ok = value.(bool)
+		if !ok {
+			return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("IncludePendingTrades is invalid"))
+		}
+	}
+	_, beaconHeight, rpcErr := httpServer.getPDEMarketEndTime()
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	if _, found := data["BeaconHeight"]; found {
+		beaconHeight, rpcErr = getPDETradeQuoteAmountParam(data, "BeaconHeight", true)
+		if rpcErr != nil {
+			return nil, rpcErr
+		}
+	}
+
+	poolPair, err := httpServer.databaseService.GetPDEPoolForPair(beaconHeight, tokenIDToBuyStr, tokenIDToSellStr)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.GetPDETradeQuoteError, err)
+	}
+	if poolPair == nil {
+		return nil, rpcservice.NewRPCError(rpcservice.GetPDETradeQuoteError, fmt.Errorf("pool pair %+v - %+v is not found at beacon height %+v", tokenIDToBuyStr, tokenIDToSellStr, beaconHeight))
+	}
+	pendingTrades := []*metadata.PDETradeRequest{}
+	if includePendingTrades {
+		pendingTrades = httpServer.txMemPoolService.GetPendingPDETradeRequests()
+	}
+	trade := &metadata.PDETradeRequest{
+		TokenIDToBuyStr:     tokenIDToBuyStr,
+		TokenIDToSellStr:    tokenIDToSellStr,
+		SellAmount:          sellAmount,
+		MinAcceptableAmount: minAcceptableAmount,
+		TradingFee:          tradingFee,
+	}
+	quote, err := metadata.QuotePDETrade(poolPair, trade, pendingTrades, slippagePercent)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
+	}
+	return quote, nil
+}
@@ -0,0 +1,304 @@
+package rpcserver
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"sort"
+	"strings"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/common/base58"
+	"github.com/incognitochain/incognito-chain/consensus/signatureschemes/bridgesig"
+	"github.com/incognitochain/incognito-chain/database/lvdb"
+	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/incognitochain/incognito-chain/rpcserver/jsonresult"
+	"github.com/incognitochain/incognito-chain/rpcserver/rpcservice"
+)
+
+func newPrivacyTokenRegistrationFromParam(param interface{}) (*metadata.PrivacyTokenRegistration, *rpcservice.RPCError) {
+	data, ok := param.(map[string]interface{})
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata is invalid"))
+	}
+	tokenID, ok := data["TokenID"].(string)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata is invalid"))
+	}
+	tokenName, ok := data["TokenName"].(string)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata is invalid"))
+	}
+	tokenSymbol, ok := data["TokenSymbol"].(string)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata is invalid"))
+	}
+	decimalsData, ok := data["Decimals"].(float64)
+	if !ok || decimalsData < 0 || decimalsData > metadata.MaxPrivacyTokenDecimals {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata is invalid"))
+	}
+	// optional fields, registrations of bridge tokens have no issuer but signatures of the beacon committee
+	issuerAddressStr, _ := data["IssuerAddressStr"].(string)
+	description, _ := data["Description"].(string)
+	iconHash, _ := data["IconHash"].(string)
+	externalNetwork, _ := data["ExternalNetwork"].(string)
+	externalTokenID, _ := data["ExternalTokenID"].(string)
+	nonceData, _ := data["Nonce"].(float64)
+	committeeSignatures := []string{}
+	if signaturesData, ok := data["CommitteeSignatures"].([]interface{}); ok {
+		for _, sigData := range signaturesData {
+			sig, ok := sigData.(string)
+			if !ok {
+				return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata is invalid"))
+			}
+			committeeSignatures = append(committeeSignatures, sig)
+		}
+	}
+	meta, err := metadata.NewPrivacyTokenRegistration(
+		tokenID,
+		tokenName,
+		tokenSymbol,
+		uint8(decimalsData),
+		description,
+		iconHash,
+		externalNetwork,
+		externalTokenID,
+		issuerAddressStr,
+		uint64(nonceData),
+		committeeSignatures,
+		metadata.PrivacyTokenRegistrationMeta,
+	)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
+	}
+	return meta, nil
+}
+
+/*
+handleSignPrivacyTokenRegistration - sign a registration of a bridge token by the bridge key of a beacon committee member,
+signatures are collected off chain and sent in "CommitteeSignatures" of the registration
+Param #1: private seed of the mining key
+Param #2: {"TokenID", "TokenName", "TokenSymbol", "Decimals", "Description", "IconHash", "ExternalNetwork", "ExternalTokenID", "Nonce"}
+*/
+func (httpServer *HttpServer) handleSignPrivacyTokenRegistration(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	arrayParams := common.InterfaceSlice(params)
+	if len(arrayParams) < 2 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("param must be an array at least 2 elements"))
+	}
+	privateSeed, ok := arrayParams[0].(string)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("private seed is invalid"))
+	}
+	privateSeedBytes, _, err := base58.Base58Check{}.Decode(privateSeed)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
+	}
+	meta, rpcErr := newPrivacyTokenRegistrationFromParam(arrayParams[1])
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	bridgePriKey, _ := bridgesig.KeyGen(privateSeedBytes)
+	hash := meta.HashForSigning()
+	signature, err := bridgesig.Sign(bridgesig.SKBytes(&bridgePriKey), hash[:])
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err)
+	}
+	return base58.Base58Check{}.Encode(signature, common.ZeroByte), nil
+}
+
+/*
+handleCreateRawTxWithPrivacyTokenRegistration - create a raw tx which registers metadata of a privacy token or updates it,
+the tx must be sent by the issuer of the token, a bridge token is registered with signatures of the beacon committee instead
+Param #5: {"TokenID", "TokenName", "TokenSymbol", "Decimals", "Description", "IconHash", "ExternalNetwork", "ExternalTokenID", "IssuerAddressStr", "Nonce", "CommitteeSignatures"}
+*/
+func (httpServer *HttpServer) handleCreateRawTxWithPrivacyTokenRegistration(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	arrayParams := common.InterfaceSlice(params)
+	if len(arrayParams) < 5 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("param must be an array at least 5 elements"))
+	}
+	meta, rpcErr := newPrivacyTokenRegistrationFromParam(arrayParams[4])
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	return httpServer.buildRawTxWithStakingPoolMeta(params, meta)
+}
+
+func (httpServer *HttpServer) handleCreateAndSendTxWithPrivacyTokenRegistration(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	data, err := httpServer.handleCreateRawTxWithPrivacyTokenRegistration(params, closeChan)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err)
+	}
+	return httpServer.sendRawTxWithStakingPoolMeta(data, closeChan)
+}
+
+func (httpServer *HttpServer) handleGetPrivacyTokenRegistrationStatus(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	arrayParams := common.InterfaceSlice(params)
+	if len(arrayParams) < 1 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Payload is invalid"))
+	}
+	data, ok := arrayParams[0].(map[string]interface{})
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Payload is invalid"))
+	}
+	txRequestIDStr, ok := data["TxRequestIDStr"].(string)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Payload is invalid"))
+	}
+	txIDHash, err := common.Hash{}.NewHashFromStr(txRequestIDStr)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
+	}
+	status, err := httpServer.databaseService.GetPrivacyTokenRegistryStatus(txIDHash[:])
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.GetPrivacyTokenRegistryError, err)
+	}
+	return status, nil
+}
+
+// handleGetPrivacyTokenInfo - return init data and registered metadata of a privacy token
+// Param #1: token id
+func (httpServer *HttpServer) handleGetPrivacyTokenInfo(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	arrayParams := common.InterfaceSlice(params)
+	if len(arrayParams) < 1 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Token ID is invalid"))
+	}
+	tokenIDStr, ok := arrayParams[0].(string)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Token ID is invalid"))
+	}
+	tokenID, err := common.Hash{}.NewHashFromStr(tokenIDStr)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
+	}
+	tokens, rpcErr := httpServer.listPrivacyTokenInfo()
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	token, found := tokens[*tokenID]
+	if !found {
+		return nil, nil
+	}
+	return token, nil
+}
+
+/*
+handleSearchPrivacyTokens - return privacy tokens whose id, name or symbol contains the query, case insensitive,
+registered and verified tokens are listed first so that fake tokens which copy a name do not shadow the real one
+Param #1: query
+*/
+func (httpServer *HttpServer) handleSearchPrivacyTokens(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	arrayParams := common.InterfaceSlice(params)
+	if len(arrayParams) < 1 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Query is invalid"))
+	}
+	query, ok := arrayParams[0].(string)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Query is invalid"))
+	}
+	query = strings.ToLower(query)
+	tokens, rpcErr := httpServer.listPrivacyTokenInfo()
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	result := jsonresult.ListPrivacyTokenInfo{ListPrivacyTokenInfo: []jsonresult.PrivacyTokenInfo{}}
+	for _, token := range tokens {
+		if strings.Contains(strings.ToLower(token.TokenID), query) ||
+			strings.Contains(strings.ToLower(token.Name), query) ||
+			strings.Contains(strings.ToLower(token.Symbol), query) {
+			result.ListPrivacyTokenInfo = append(result.ListPrivacyTokenInfo, *token)
+		}
+	}
+	sort.Slice(result.ListPrivacyTokenInfo, func(i, j int) bool {
+		tokenI, tokenJ := result.ListPrivacyTokenInfo[i], result.ListPrivacyTokenInfo[j]
+		if tokenI.IsVerified != tokenJ.IsVerified {
+			return tokenI.IsVerified
+		}
+		if tokenI.IsRegistered != tokenJ.IsRegistered {
+			return tokenI.IsRegistered
+		}
+		return tokenI.TokenID < tokenJ.TokenID
+	})
+	return result, nil
+}
+
+// listPrivacyTokenInfo merges init data of privacy tokens, mintable tokens, bridge tokens and registered metadata
+func (httpServer *HttpServer) listPrivacyTokenInfo() (map[common.Hash]*jsonresult.PrivacyTokenInfo, *rpcservice.RPCError) {
+	listPrivacyToken, listPrivacyTokenCrossShard, err := httpServer.blockService.ListPrivacyCustomTokenCached()
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err)
+	}
+	tokens := make(map[common.Hash]*jsonresult.PrivacyTokenInfo)
+	for tokenID, token := range listPrivacyToken {
+		tokens[tokenID] = &jsonresult.PrivacyTokenInfo{
+			TokenID: tokenID.String(),
+			Name:    token.TxPrivacyTokenData.PropertyName,
+			Symbol:  token.TxPrivacyTokenData.PropertySymbol,
+			Amount:  token.TxPrivacyTokenData.Amount,
+		}
+	}
+	for tokenID, token := range listPrivacyTokenCrossShard {
+		if _, found := tokens[tokenID]; found {
+			continue
+		}
+		tokens[tokenID] = &jsonresult.PrivacyTokenInfo{
+			TokenID: tokenID.String(),
+			Name:    token.PropertyName,
+			Symbol:  token.PropertySymbol,
+			Amount:  token.Amount,
+		}
+	}
+
+	// supply of mintable tokens is issued by response txs so they are not listed as privacy tokens
+	mintableTokens, err := httpServer.databaseService.GetAllMintableTokens()
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.GetMintableTokenError, err)
+	}
+	for _, mintableToken := range mintableTokens {
+		tokens[mintableToken.TokenID] = &jsonresult.PrivacyTokenInfo{
+			TokenID: mintableToken.TokenID.String(),
+			Name:    mintableToken.TokenName,
+			Symbol:  mintableToken.TokenSymbol,
+			Amount:  mintableToken.CirculatingSupply(),
+		}
+	}
+
+	registries, err := httpServer.databaseService.GetAllPrivacyTokenRegistries()
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.GetPrivacyTokenRegistryError, err)
+	}
+	for tokenID, registry := range registries {
+		token, found := tokens[tokenID]
+		if !found {
+			continue
+		}
+		token.SetRegistry(registry)
+	}
+
+	allBridgeTokensBytes, err := httpServer.databaseService.GetAllBridgeTokens()
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err)
+	}
+	var allBridgeTokens []*lvdb.BridgeTokenInfo
+	err = json.Unmarshal(allBridgeTokensBytes, &allBridgeTokens)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err)
+	}
+	for _, bridgeToken := range allBridgeTokens {
+		if bridgeToken.TokenID == nil {
+			continue
+		}
+		token, found := tokens[*bridgeToken.TokenID]
+		if !found {
+			token = &jsonresult.PrivacyTokenInfo{TokenID: bridgeToken.TokenID.String()}
+			tokens[*bridgeToken.TokenID] = token
+			if registry, found := registries[*bridgeToken.TokenID]; found {
+				token.SetRegistry(registry)
+			}
+		}
+		// external token of a bridge token is taken from the bridge, not from its registry
+		token.IsVerified = true
+		token.ExternalNetwork = bridgeToken.Network
+		token.ExternalTokenID = hex.EncodeToString(bridgeToken.ExternalTokenID)
+	}
+	return tokens, nil
+}
@@ -0,0 +1,295 @@
+package rpcserver
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/incognitochain/incognito-chain/blockchain"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/common/base58"
+	"github.com/incognitochain/incognito-chain/consensus/signatureschemes/bridgesig"
+	"github.com/incognitochain/incognito-chain/database/lvdb"
+	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/incognitochain/incognito-chain/rpcserver/bean"
+	"github.com/incognitochain/incognito-chain/rpcserver/jsonresult"
+	"github.com/incognitochain/incognito-chain/rpcserver/rpcservice"
+)
+
+type StakingPoolInfo struct {
+	*lvdb.StakingPool
+	Delegations map[string]uint64
+}
+
+func (httpServer *HttpServer) buildRawTxWithStakingPoolMeta(params interface{}, meta metadata.Metadata) (interface{}, *rpcservice.RPCError) {
+	// create new param to build raw tx from param interface
+	createRawTxParam, errNewParam := bean.NewCreateRawTxParam(params)
+	if errNewParam != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errNewParam)
+	}
+
+	tx, err1 := httpServer.txService.BuildRawTransaction(createRawTxParam, meta, *httpServer.config.Database)
+	if err1 != nil {
+		Logger.log.Error(err1)
+		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err1)
+	}
+
+	byteArrays, err2 := json.Marshal(tx)
+	if err2 != nil {
+		Logger.log.Error(err2)
+		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err2)
+	}
+	result := jsonresult.CreateTransactionResult{
+		TxID:            tx.Hash().String(),
+		Base58CheckData: base58.Base58Check{}.Encode(byteArrays, 0x00),
+	}
+	return result, nil
+}
+
+func (httpServer *HttpServer) sendRawTxWithStakingPoolMeta(data interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	tx := data.(jsonresult.CreateTransactionResult)
+	base58CheckData := tx.Base58CheckData
+	newParam := make([]interface{}, 0)
+	newParam = append(newParam, base58CheckData)
+	sendResult, err := httpServer.handleSendRawTransaction(newParam, closeChan)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err)
+	}
+	result := jsonresult.NewCreateTransactionResult(nil, sendResult.(jsonresult.CreateTransactionResult).TxID, nil, sendResult.(jsonresult.CreateTransactionResult).ShardID)
+	return result, nil
+}
+
+func newStakingPoolCreationFromParam(param interface{}) (*metadata.StakingPoolCreation, *rpcservice.RPCError) {
+	data, ok := param.(map[string]interface{})
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata is invalid"))
+	}
+	committeePublicKey, ok := data["CommitteePublicKey"].(string)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata is invalid"))
+	}
+	operatorAddressStr, ok := data["OperatorAddressStr"].(string)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata is invalid"))
+	}
+	commissionRateData, ok := data["CommissionRate"].(float64)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata is invalid"))
+	}
+	// the signature is only missing when the request is being signed
+	committeeKeySignature := ""
+	if signatureData, found := data["CommitteeKeySignature"]; found {
+		committeeKeySignature, ok = signatureData.(string)
+		if !ok {
+			return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("committee key signature is invalid"))
+		}
+	}
+	meta, err := metadata.NewStakingPoolCreation(
+		committeePublicKey,
+		operatorAddressStr,
+		uint64(commissionRateData),
+		committeeKeySignature,
+		metadata.StakingPoolCreationMeta,
+	)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
+	}
+	return meta, nil
+}
+
+/*
+handleSignStakingPoolCreation - sign a staking pool creation request by the bridge key of its committee key,
+this proves that the operator of the pool controls the committee key
+Param #1: private seed of the committee key
+Param #2: {"CommitteePublicKey", "OperatorAddressStr", "CommissionRate"}
+*/
+func (httpServer *HttpServer) handleSignStakingPoolCreation(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	arrayParams := common.InterfaceSlice(params)
+	if len(arrayParams) < 2 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("param must be an array at least 2 elements"))
+	}
+	privateSeed, ok := arrayParams[0].(string)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("private seed is invalid"))
+	}
+	privateSeedBytes, _, err := base58.Base58Check{}.Decode(privateSeed)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
+	}
+	meta, rpcErr := newStakingPoolCreationFromParam(arrayParams[1])
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	bridgePriKey, _ := bridgesig.KeyGen(privateSeedBytes)
+	hash := meta.HashForSigning()
+	signature, err := bridgesig.Sign(bridgesig.SKBytes(&bridgePriKey), hash[:])
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err)
+	}
+	return base58.Base58Check{}.Encode(signature, common.ZeroByte), nil
+}
+
+/*
+handleCreateRawTxWithStakingPoolCreation - create a raw tx which creates a staking pool
+Param #5: {"CommitteePublicKey", "OperatorAddressStr", "CommissionRate", "CommitteeKeySignature"}
+*/
+func (httpServer *HttpServer) handleCreateRawTxWithStakingPoolCreation(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	arrayParams := common.InterfaceSlice(params)
+	if len(arrayParams) < 5 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("param must be an array at least 5 elements"))
+	}
+	meta, rpcErr := newStakingPoolCreationFromParam(arrayParams[4])
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	return httpServer.buildRawTxWithStakingPoolMeta(params, meta)
+}
+
+func (httpServer *HttpServer) handleCreateAndSendTxWithStakingPoolCreation(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	data, err := httpServer.handleCreateRawTxWithStakingPoolCreation(params, closeChan)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err)
+	}
+	return httpServer.sendRawTxWithStakingPoolMeta(data, closeChan)
+}
+
+func (httpServer *HttpServer) handleCreateRawTxWithStakingPoolDeposit(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	arrayParams := common.InterfaceSlice(params)
+	if len(arrayParams) < 5 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("param must be an array at least 5 elements"))
+	}
+
+	// get meta data from params
+	data, ok := arrayParams[4].(map[string]interface{})
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata is invalid"))
+	}
+	committeePublicKey, ok := data["CommitteePublicKey"].(string)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata is invalid"))
+	}
+	delegatorAddressStr, ok := data["DelegatorAddressStr"].(string)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata is invalid"))
+	}
+	depositAmountData, ok := data["DepositAmount"].(float64)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata is invalid"))
+	}
+	meta, err := metadata.NewStakingPoolDeposit(
+		committeePublicKey,
+		delegatorAddressStr,
+		uint64(depositAmountData),
+		metadata.StakingPoolDepositMeta,
+	)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
+	}
+	return httpServer.buildRawTxWithStakingPoolMeta(params, meta)
+}
+
+func (httpServer *HttpServer) handleCreateAndSendTxWithStakingPoolDeposit(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	data, err := httpServer.handleCreateRawTxWithStakingPoolDeposit(params, closeChan)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err)
+	}
+	return httpServer.sendRawTxWithStakingPoolMeta(data, closeChan)
+}
+
+func (httpServer *HttpServer) handleCreateRawTxWithStakingPoolWithdrawal(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	arrayParams := common.InterfaceSlice(params)
+	if len(arrayParams) < 5 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("param must be an array at least 5 elements"))
+	}
+
+	// get meta data from params
+	data, ok := arrayParams[4].(map[string]interface{})
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata is invalid"))
+	}
+	committeePublicKey, ok := data["CommitteePublicKey"].(string)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata is invalid"))
+	}
+	delegatorAddressStr, ok := data["DelegatorAddressStr"].(string)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata is invalid"))
+	}
+	withdrawalAmountData, ok := data["WithdrawalAmount"].(float64)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("metadata is invalid"))
+	}
+	meta, err := metadata.NewStakingPoolWithdrawalRequest(
+		committeePublicKey,
+		delegatorAddressStr,
+		uint64(withdrawalAmountData),
+		metadata.StakingPoolWithdrawalRequestMeta,
+	)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
+	}
+	return httpServer.buildRawTxWithStakingPoolMeta(params, meta)
+}
+
+func (httpServer *HttpServer) handleCreateAndSendTxWithStakingPoolWithdrawal(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	data, err := httpServer.handleCreateRawTxWithStakingPoolWithdrawal(params, closeChan)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err)
+	}
+	return httpServer.sendRawTxWithStakingPoolMeta(data, closeChan)
+}
+
+func (httpServer *HttpServer) handleGetStakingPool(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	arrayParams := common.InterfaceSlice(params)
+	if len(arrayParams) < 1 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Payload is invalid"))
+	}
+	data, ok := arrayParams[0].(map[string]interface{})
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Payload is invalid"))
+	}
+	committeePublicKey, ok := data["CommitteePublicKey"].(string)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Committee public key is invalid"))
+	}
+	stakingPoolState, err := blockchain.InitCurrentStakingPoolStateFromDB(httpServer.config.BlockChain.GetDatabase())
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.GetStakingPoolStateError, err)
+	}
+	stakingPool, found := stakingPoolState.StakingPools[committeePublicKey]
+	if !found {
+		return nil, nil
+	}
+	delegations, err := httpServer.config.BlockChain.GetDatabase().GetStakingPoolDelegations(committeePublicKey)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.GetStakingPoolStateError, err)
+	}
+	result := StakingPoolInfo{
+		StakingPool: stakingPool,
+		Delegations: delegations,
+	}
+	return result, nil
+}
+
+func (httpServer *HttpServer) handleGetStakingPoolStatus(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	arrayParams := common.InterfaceSlice(params)
+	if len(arrayParams) < 1 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Payload is invalid"))
+	}
+	data, ok := arrayParams[0].(map[string]interface{})
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Payload is invalid"))
+	}
+	txRequestIDStr, ok := data["TxRequestIDStr"].(string)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Payload is invalid"))
+	}
+	txIDHash, err := common.Hash{}.NewHashFromStr(txRequestIDStr)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.GetStakingPoolStateError, err)
+	}
+	status, err := httpServer.databaseService.GetStakingPoolStatus(txIDHash[:])
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.GetStakingPoolStateError, err)
+	}
+	return status, nil
+}
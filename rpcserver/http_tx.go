@@ -165,6 +165,22 @@ func (httpServer *HttpServer) handleGetTransactionByHash(params interface{}, clo
 	return httpServer.txService.GetTransactionByHash(txHashStr)
 }
 
+// handleGetCrossShardTransactionStatus - return delivery status of cross shard tx on each destination shard
+func (httpServer *HttpServer) handleGetCrossShardTransactionStatus(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	Logger.log.Debugf("handleGetCrossShardTransactionStatus params: %+v", params)
+	arrayParams := common.InterfaceSlice(params)
+	if arrayParams == nil || len(arrayParams) < 1 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("param must be an array at least 1 element"))
+	}
+
+	// param #1: transaction Hash
+	txHashStr, ok := arrayParams[0].(string)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Tx hash is invalid"))
+	}
+	return httpServer.txService.GetCrossShardTransactionStatus(txHashStr)
+}
+
 // handleGetListPrivacyCustomTokenBalance - return list privacy token + balance for one account payment address
 func (httpServer *HttpServer) handleGetListPrivacyCustomTokenBalance(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
 	Logger.log.Debugf("handleGetListPrivacyCustomTokenBalance params: %+v", params)
@@ -90,3 +90,28 @@ func (httpServer *HttpServer) handleRemoveTxInMempool(params interface{}, closeC
 	}
 	return result, nil
 }
+
+// handleListPendingOutputCoins - return output coins of a payment address created by txs still in mempool,
+// these coins can be spent by child txs before their parent tx is included in a block
+func (httpServer *HttpServer) handleListPendingOutputCoins(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	arrayParams := common.InterfaceSlice(params)
+	if len(arrayParams) < 1 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("param must be an array at least 1 element"))
+	}
+	paymentAddressStr, ok := arrayParams[0].(string)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("payment address is invalid"))
+	}
+	tokenIDStr := ""
+	if len(arrayParams) > 1 {
+		tokenIDStr, ok = arrayParams[1].(string)
+		if !ok {
+			return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("token id is invalid"))
+		}
+	}
+	pendingOutputCoins, err := httpServer.txMemPoolService.GetPendingOutputCoins(paymentAddressStr, tokenIDStr)
+	if err != nil {
+		return nil, err
+	}
+	return jsonresult.NewListPendingOutputCoinsResult(pendingOutputCoins), nil
+}
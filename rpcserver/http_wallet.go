@@ -10,6 +10,8 @@ import (
 	"github.com/incognitochain/incognito-chain/database/lvdb"
 	"github.com/incognitochain/incognito-chain/rpcserver/jsonresult"
 	"github.com/incognitochain/incognito-chain/rpcserver/rpcservice"
+	"github.com/incognitochain/incognito-chain/transaction"
+	"github.com/incognitochain/incognito-chain/wallet"
 )
 
 /*
@@ -140,6 +142,50 @@ func (httpServer *HttpServer) handleImportAccount(params interface{}, closeChan
 	return result, nil
 }
 
+/*
+handleImportWatchOnlyAccount - import a watch-only account by payment address and readonly key,
+account can scan its incoming coins but can't spend them
+- Param #1: payment address string
+- Param #2: readonly key string
+- Param #3: account name
+- Param #4: passPhrase of wallet
+*/
+func (httpServer *HttpServer) handleImportWatchOnlyAccount(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	Logger.log.Debugf("handleImportWatchOnlyAccount params: %+v", params)
+	arrayParams := common.InterfaceSlice(params)
+	if arrayParams == nil || len(arrayParams) < 4 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("param must be an array at least 4 elements"))
+	}
+
+	paymentAddress, ok := arrayParams[0].(string)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("paymentAddress is invalid"))
+	}
+
+	readonlyKey, ok := arrayParams[1].(string)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("readonlyKey is invalid"))
+	}
+
+	accountName, ok := arrayParams[2].(string)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("accountName is invalid"))
+	}
+
+	passPhrase, ok := arrayParams[3].(string)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("passPhrase is invalid"))
+	}
+
+	result, err := httpServer.walletService.ImportWatchOnlyAccount(paymentAddress, readonlyKey, accountName, passPhrase)
+	if err != nil {
+		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err)
+	}
+
+	Logger.log.Debugf("handleImportWatchOnlyAccount result: %+v", result)
+	return result, nil
+}
+
 func (httpServer *HttpServer) handleRemoveAccount(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
 	Logger.log.Debugf("handleRemoveAccount params: %+v", params)
 	arrayParams := common.InterfaceSlice(params)
@@ -230,13 +276,45 @@ func (httpServer *HttpServer) handleGetBalance(params interface{}, closeChan <-c
 		return uint64(0), rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("passPhrase is invalid"))
 	}
 
-	if passPhrase != httpServer.config.Wallet.PassPhrase {
+	if !httpServer.config.Wallet.CheckPassPhrase(passPhrase) {
 		return uint64(0), rpcservice.NewRPCError(rpcservice.UnexpectedError, errors.New("password phrase is wrong for local wallet"))
 	}
 
 	return httpServer.walletService.GetBalance(accountName)
 }
 
+/*
+handleGetWatchOnlyBalance - RPC returns total amount of incoming coins per token of a watch-only account
+- Param #1: account name
+- Param #2: passPhrase of wallet
+*/
+func (httpServer *HttpServer) handleGetWatchOnlyBalance(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	if httpServer.config.Wallet == nil {
+		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, errors.New("wallet is not existed"))
+	}
+
+	arrayParams := common.InterfaceSlice(params)
+	if arrayParams == nil || len(arrayParams) < 2 {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("param must be an array at least 2 elements"))
+	}
+
+	accountName, ok := arrayParams[0].(string)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("accountName is invalid"))
+	}
+
+	passPhrase, ok := arrayParams[1].(string)
+	if !ok {
+		return nil, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("passPhrase is invalid"))
+	}
+
+	if !httpServer.config.Wallet.CheckPassPhrase(passPhrase) {
+		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, errors.New("password phrase is wrong for local wallet"))
+	}
+
+	return httpServer.walletService.GetWatchOnlyBalance(accountName)
+}
+
 /*
 handleGetReceivedByAccount -  RPC returns the total amount received by addresses in a
 particular account from transactions with the specified number of confirmations. It does not count salary transactions.
@@ -276,7 +354,7 @@ func (httpServer *HttpServer) handleGetReceivedByAccount(params interface{}, clo
 		return balance, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("passPhrase is invalid"))
 	}
 
-	if passPhrase != httpServer.config.Wallet.PassPhrase {
+	if !httpServer.config.Wallet.CheckPassPhrase(passPhrase) {
 		return balance, rpcservice.NewRPCError(rpcservice.UnexpectedError, errors.New("password phrase is wrong for local wallet"))
 	}
 
@@ -293,10 +371,120 @@ func (httpServer *HttpServer) handleSetTxFee(params interface{}, closeChan <-cha
 	}
 
 	httpServer.config.Wallet.GetConfig().IncrementalFee = uint64(paramTmp)
-	err := httpServer.config.Wallet.Save(httpServer.config.Wallet.PassPhrase)
+	err := httpServer.config.Wallet.Save("")
 	return err == nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, err)
 }
 
+/*
+handleSetCoinSelection - RPC sets the strategy which is used to choose input coins of transactions created by this wallet
+- Param #1: strategy (default/mininputs/minchange/random/bnb)
+*/
+func (httpServer *HttpServer) handleSetCoinSelection(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	if httpServer.config.Wallet == nil {
+		return false, rpcservice.NewRPCError(rpcservice.UnexpectedError, errors.New("wallet is not existed"))
+	}
+	arrayParams := common.InterfaceSlice(params)
+	if arrayParams == nil || len(arrayParams) < 1 {
+		return false, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("param must be an array at least 1 element"))
+	}
+	strategy, ok := arrayParams[0].(string)
+	if !ok || !transaction.IsValidCoinSelection(strategy) {
+		return false, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("coin selection strategy is invalid"))
+	}
+
+	httpServer.config.Wallet.GetConfig().CoinSelection = strategy
+	return true, nil
+}
+
+/*
+handleChangeWalletPassPhrase - RPC re-encrypts local wallet of node with a new passphrase
+- Param #1: current passphrase of wallet
+- Param #2: new passphrase of wallet
+*/
+func (httpServer *HttpServer) handleChangeWalletPassPhrase(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	arrayParams := common.InterfaceSlice(params)
+	if arrayParams == nil || len(arrayParams) < 2 {
+		return false, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("param must be an array at least 2 elements"))
+	}
+
+	oldPassPhrase, ok := arrayParams[0].(string)
+	if !ok {
+		return false, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("oldPassPhrase is invalid"))
+	}
+
+	newPassPhrase, ok := arrayParams[1].(string)
+	if !ok {
+		return false, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("newPassPhrase is invalid"))
+	}
+
+	return httpServer.walletService.ChangePassPhrase(oldPassPhrase, newPassPhrase)
+}
+
+/*
+handleDiscoverWalletAccounts - RPC starts scanning child accounts of wallet on chain in background,
+used accounts are added to wallet, use getwalletdiscoveryprogress to follow it
+- Param #1: passphrase of wallet
+- Param #2 (optional): gap limit, number of consecutive unused accounts of a shard to stop scanning, default is 20
+- Param #3 (optional): list of shard ids to scan, default is all active shards
+*/
+func (httpServer *HttpServer) handleDiscoverWalletAccounts(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	if httpServer.config.Wallet == nil {
+		return false, rpcservice.NewRPCError(rpcservice.UnexpectedError, errors.New("wallet is not existed"))
+	}
+	arrayParams := common.InterfaceSlice(params)
+	if arrayParams == nil || len(arrayParams) < 1 {
+		return false, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("param must be an array at least 1 element"))
+	}
+
+	passPhrase, ok := arrayParams[0].(string)
+	if !ok {
+		return false, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("passPhrase is invalid"))
+	}
+	if !httpServer.config.Wallet.CheckPassPhrase(passPhrase) {
+		return false, rpcservice.NewRPCError(rpcservice.UnexpectedError, errors.New("password phrase is wrong for local wallet"))
+	}
+
+	gapLimit := uint32(wallet.DefaultDiscoveryGapLimit)
+	if len(arrayParams) > 1 {
+		gapLimitParam, ok := arrayParams[1].(float64)
+		if !ok || gapLimitParam < 1 {
+			return false, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("gapLimit is invalid"))
+		}
+		gapLimit = uint32(gapLimitParam)
+	}
+
+	shardIDs := []byte{}
+	if len(arrayParams) > 2 {
+		shardIDsParam, ok := arrayParams[2].([]interface{})
+		if !ok {
+			return false, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("shardIDs is invalid"))
+		}
+		for _, item := range shardIDsParam {
+			shardID, ok := item.(float64)
+			if !ok || shardID < 0 || int(shardID) >= common.MaxShardNumber {
+				return false, rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("shardID is invalid"))
+			}
+			shardIDs = append(shardIDs, byte(shardID))
+		}
+	} else {
+		for shardID := 0; shardID < httpServer.config.BlockChain.GetActiveShardNumber(); shardID++ {
+			shardIDs = append(shardIDs, byte(shardID))
+		}
+	}
+
+	return httpServer.walletService.DiscoverAccounts(gapLimit, shardIDs)
+}
+
+/*
+handleGetWalletDiscoveryProgress - RPC returns progress of the last account discovery of wallet
+*/
+func (httpServer *HttpServer) handleGetWalletDiscoveryProgress(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
+	if httpServer.config.Wallet == nil {
+		return nil, rpcservice.NewRPCError(rpcservice.UnexpectedError, errors.New("wallet is not existed"))
+	}
+	return httpServer.walletService.GetAccountDiscoveryProgress()
+}
+
 func (httpServer *HttpServer) handleListPrivacyCustomToken(params interface{}, closeChan <-chan struct{}) (interface{}, *rpcservice.RPCError) {
 	listPrivacyToken, listPrivacyTokenCrossShard, err := httpServer.blockService.ListPrivacyCustomTokenCached()
 	if err != nil {
@@ -0,0 +1,69 @@
+package jsonresult
+
+import (
+	"github.com/incognitochain/incognito-chain/blockchain"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/database/lvdb"
+	"github.com/incognitochain/incognito-chain/metadata"
+)
+
+type BridgeQueuedRequestResult struct {
+	TxReqID      string `json:"TxReqID"`
+	MetaType     int    `json:"MetaType"`
+	Amount       uint64 `json:"Amount"`
+	ShardID      byte   `json:"ShardID"`
+	BeaconHeight uint64 `json:"BeaconHeight"`
+}
+
+// BridgeTokenQuotaResult - caps of a bridge token and their usage in the current epoch, a zero cap is not enforced
+type BridgeTokenQuotaResult struct {
+	TokenID                string                      `json:"TokenID"`
+	Epoch                  uint64                      `json:"Epoch"`
+	Paused                 bool                        `json:"Paused"` // true if the token or the whole bridge is paused
+	MaxIssuingPerTx        uint64                      `json:"MaxIssuingPerTx"`
+	MaxIssuingPerEpoch     uint64                      `json:"MaxIssuingPerEpoch"`
+	IssuedAmount           uint64                      `json:"IssuedAmount"`
+	RemainingIssuingAmount uint64                      `json:"RemainingIssuingAmount"`
+	MaxBurningPerTx        uint64                      `json:"MaxBurningPerTx"`
+	MaxBurningPerEpoch     uint64                      `json:"MaxBurningPerEpoch"`
+	BurnedAmount           uint64                      `json:"BurnedAmount"`
+	RemainingBurningAmount uint64                      `json:"RemainingBurningAmount"`
+	QueuedRequests         []BridgeQueuedRequestResult `json:"QueuedRequests"`
+	NextBridgeControlNonce uint64                      `json:"NextBridgeControlNonce"`
+}
+
+func NewBridgeTokenQuotaResult(state *blockchain.CurrentBridgeLimiterState, tokenID common.Hash) *BridgeTokenQuotaResult {
+	limit := state.GetLimit(tokenID)
+	quota := state.GetQuota(tokenID)
+	queuedRequests := []BridgeQueuedRequestResult{}
+	for _, request := range state.QueuedRequests {
+		if request.TokenID.IsEqual(&tokenID) {
+			queuedRequests = append(queuedRequests, newBridgeQueuedRequestResult(request))
+		}
+	}
+	return &BridgeTokenQuotaResult{
+		TokenID:                tokenID.String(),
+		Epoch:                  quota.Epoch,
+		Paused:                 state.IsPaused(tokenID),
+		MaxIssuingPerTx:        limit.MaxIssuingPerTx,
+		MaxIssuingPerEpoch:     limit.MaxIssuingPerEpoch,
+		IssuedAmount:           quota.IssuedAmount,
+		RemainingIssuingAmount: state.GetRemainingQuota(metadata.IssuingETHRequestMeta, tokenID),
+		MaxBurningPerTx:        limit.MaxBurningPerTx,
+		MaxBurningPerEpoch:     limit.MaxBurningPerEpoch,
+		BurnedAmount:           quota.BurnedAmount,
+		RemainingBurningAmount: state.GetRemainingQuota(metadata.BurningRequestMeta, tokenID),
+		QueuedRequests:         queuedRequests,
+		NextBridgeControlNonce: state.GetQuota(common.Hash{}).ControlNonce,
+	}
+}
+
+func newBridgeQueuedRequestResult(request *lvdb.BridgeQueuedRequest) BridgeQueuedRequestResult {
+	return BridgeQueuedRequestResult{
+		TxReqID:      request.TxReqID.String(),
+		MetaType:     request.MetaType,
+		Amount:       request.Amount,
+		ShardID:      request.ShardID,
+		BeaconHeight: request.BeaconHeight,
+	}
+}
@@ -0,0 +1,31 @@
+package jsonresult
+
+import (
+	"encoding/hex"
+
+	"github.com/incognitochain/incognito-chain/database/lvdb"
+)
+
+type BTCHeaderResult struct {
+	Hash      string `json:"Hash"`
+	Height    uint64 `json:"Height"`
+	ChainWork string `json:"ChainWork"`
+	HeaderStr string `json:"HeaderStr"`
+}
+
+func NewBTCHeaderResult(headerInfo *lvdb.BTCHeaderInfo) *BTCHeaderResult {
+	return &BTCHeaderResult{
+		Hash:      headerInfo.Hash,
+		Height:    headerInfo.Height,
+		ChainWork: headerInfo.ChainWork.String(),
+		HeaderStr: hex.EncodeToString(headerInfo.Header),
+	}
+}
+
+type BTCDepositCommitmentResult struct {
+	ReceiverAddressStr string `json:"ReceiverAddressStr"`
+	Commitment         string `json:"Commitment"`
+	// script of the OP_RETURN output a deposit must have
+	CommitmentScript string `json:"CommitmentScript"`
+	DepositScript    string `json:"DepositScript"`
+}
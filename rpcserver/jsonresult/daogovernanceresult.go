@@ -0,0 +1,97 @@
+package jsonresult
+
+import (
+	"sort"
+
+	"github.com/incognitochain/incognito-chain/blockchain"
+	"github.com/incognitochain/incognito-chain/database/lvdb"
+)
+
+// DAOProposalResult - a proposal of Incognito DAO, weights are set once the proposal is tallied
+type DAOProposalResult struct {
+	ProposalID            string            `json:"ProposalID"`
+	Type                  string            `json:"Type"`
+	ProposerAddress       string            `json:"ProposerAddress"`
+	Description           string            `json:"Description"`
+	ReceiverAddress       string            `json:"ReceiverAddress"`
+	Amounts               map[string]uint64 `json:"Amounts"`
+	ParamName             string            `json:"ParamName"`
+	TokenID               string            `json:"TokenID"`
+	ParamValue            uint64            `json:"ParamValue"`
+	Status                string            `json:"Status"`
+	CreatedBeaconHeight   uint64            `json:"CreatedBeaconHeight"`
+	VotingEndBeaconHeight uint64            `json:"VotingEndBeaconHeight"`
+	Votes                 map[string]bool   `json:"Votes"`
+	YesWeight             uint64            `json:"YesWeight"`
+	NoWeight              uint64            `json:"NoWeight"`
+	TotalWeight           uint64            `json:"TotalWeight"`
+	ExecutionBeaconHeight uint64            `json:"ExecutionBeaconHeight"`
+}
+
+func NewDAOProposalResult(proposal *lvdb.DAOProposal) *DAOProposalResult {
+	amounts := map[string]uint64{}
+	for tokenID, amount := range proposal.Amounts {
+		amounts[tokenID.String()] = amount
+	}
+	return &DAOProposalResult{
+		ProposalID:            proposal.ProposalID.String(),
+		Type:                  proposal.Type,
+		ProposerAddress:       proposal.ProposerAddress,
+		Description:           proposal.Description,
+		ReceiverAddress:       proposal.ReceiverAddress,
+		Amounts:               amounts,
+		ParamName:             proposal.ParamName,
+		TokenID:               proposal.TokenID.String(),
+		ParamValue:            proposal.ParamValue,
+		Status:                proposal.Status,
+		CreatedBeaconHeight:   proposal.CreatedBeaconHeight,
+		VotingEndBeaconHeight: proposal.VotingEndBeaconHeight,
+		Votes:                 proposal.Votes,
+		YesWeight:             proposal.YesWeight,
+		NoWeight:              proposal.NoWeight,
+		TotalWeight:           proposal.TotalWeight,
+		ExecutionBeaconHeight: proposal.ExecutionBeaconHeight,
+	}
+}
+
+// DAOTreasuryResult - balances of the treasury of Incognito DAO and params governing it
+type DAOTreasuryResult struct {
+	Treasury          map[string]uint64 `json:"Treasury"`
+	StartBeaconHeight uint64            `json:"StartBeaconHeight"`
+	VotingPeriod      uint64            `json:"VotingPeriod"`
+	QuorumPercent     uint64            `json:"QuorumPercent"`
+	PassPercent       uint64            `json:"PassPercent"`
+	Timelock          uint64            `json:"Timelock"`
+	GovernedParams    map[string]uint64 `json:"GovernedParams"` // chain params changed by proposals
+	ActiveProposalIDs []string          `json:"ActiveProposalIDs"`
+}
+
+func NewDAOTreasuryResult(state *blockchain.CurrentDAOGovernanceState, chainParams blockchain.IncDAOGovernanceParams) *DAOTreasuryResult {
+	treasury := map[string]uint64{}
+	for tokenID, amount := range state.Treasury {
+		treasury[tokenID.String()] = amount
+	}
+	activeProposalIDs := []string{}
+	for proposalID := range state.Proposals {
+		activeProposalIDs = append(activeProposalIDs, proposalID.String())
+	}
+	sort.Strings(activeProposalIDs)
+	params := state.GetEffectiveParams(chainParams)
+	return &DAOTreasuryResult{
+		Treasury:          treasury,
+		StartBeaconHeight: params.StartBeaconHeight,
+		VotingPeriod:      params.VotingPeriod,
+		QuorumPercent:     params.QuorumPercent,
+		PassPercent:       params.PassPercent,
+		Timelock:          params.Timelock,
+		GovernedParams:    state.GovernedParams,
+		ActiveProposalIDs: activeProposalIDs,
+	}
+}
+
+// DAOVotingWeightResult - staked PRV of an account which its votes are weighted by, out of all staked PRV
+type DAOVotingWeightResult struct {
+	PaymentAddress string `json:"PaymentAddress"`
+	Weight         uint64 `json:"Weight"`
+	TotalWeight    uint64 `json:"TotalWeight"`
+}
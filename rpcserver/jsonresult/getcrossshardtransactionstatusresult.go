@@ -0,0 +1,39 @@
+package jsonresult
+
+import "github.com/incognitochain/incognito-chain/blockchain"
+
+type CrossShardDeliveryStatus struct {
+	ShardID             byte   `json:"ShardID"`
+	Status              string `json:"Status"`
+	CrossShardBlockHash string `json:"CrossShardBlockHash"`
+	AcceptedBlockHeight uint64 `json:"AcceptedBlockHeight"`
+}
+
+type GetCrossShardTransactionStatusResult struct {
+	TxID              string                     `json:"TxID"`
+	SourceShardID     byte                       `json:"SourceShardID"`
+	SourceBlockHash   string                     `json:"SourceBlockHash"`
+	SourceBlockHeight uint64                     `json:"SourceBlockHeight"`
+	Destinations      []CrossShardDeliveryStatus `json:"Destinations"`
+	IsDelivered       bool                       `json:"IsDelivered"`
+}
+
+func NewGetCrossShardTransactionStatusResult(status *blockchain.CrossShardTransactionStatus) *GetCrossShardTransactionStatusResult {
+	result := &GetCrossShardTransactionStatusResult{
+		TxID:              status.TxID.String(),
+		SourceShardID:     status.SourceShardID,
+		SourceBlockHash:   status.SourceBlockHash.String(),
+		SourceBlockHeight: status.SourceBlockHeight,
+		Destinations:      []CrossShardDeliveryStatus{},
+		IsDelivered:       status.IsDelivered,
+	}
+	for _, destination := range status.Destinations {
+		result.Destinations = append(result.Destinations, CrossShardDeliveryStatus{
+			ShardID:             destination.ShardID,
+			Status:              destination.Status,
+			CrossShardBlockHash: destination.CrossShardBlockHash.String(),
+			AcceptedBlockHeight: destination.AcceptedBlockHeight,
+		})
+	}
+	return result
+}
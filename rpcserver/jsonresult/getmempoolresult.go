@@ -8,20 +8,24 @@ import (
 )
 
 type GetMempoolInfo struct {
-	Size          int                `json:"Size"`
-	Bytes         uint64             `json:"Bytes"`
-	Usage         uint64             `json:"Usage"`
-	MaxMempool    uint64             `json:"MaxMempool"`
-	MempoolMinFee uint64             `json:"MempoolMinFee"`
-	MempoolMaxFee uint64             `json:"MempoolMaxFee"`
-	ListTxs       []GetMempoolInfoTx `json:"ListTxs"`
+	Size           int                `json:"Size"`
+	Bytes          uint64             `json:"Bytes"`
+	Usage          uint64             `json:"Usage"`
+	MaxMempool     uint64             `json:"MaxMempool"`
+	MempoolMinFee  uint64             `json:"MempoolMinFee"`
+	MempoolMaxFee  uint64             `json:"MempoolMaxFee"`
+	Lanes          []mempool.LaneInfo `json:"Lanes"`
+	MaxTxPerSender uint64             `json:"MaxTxPerSender"`
+	ListTxs        []GetMempoolInfoTx `json:"ListTxs"`
 }
 
 func NewGetMempoolInfo(txMempool *mempool.TxPool) *GetMempoolInfo {
 	result := &GetMempoolInfo{
-		Size:          txMempool.Count(),
-		Bytes:         txMempool.Size(),
-		MempoolMaxFee: txMempool.MaxFee(),
+		Size:           txMempool.Count(),
+		Bytes:          txMempool.Size(),
+		MempoolMaxFee:  txMempool.MaxFee(),
+		Lanes:          txMempool.GetLanesInfo(),
+		MaxTxPerSender: txMempool.GetMaxTxPerSender(),
 	}
 	// get list data from mempool
 	listTxsDetail := txMempool.ListTxsDetail()
@@ -0,0 +1,33 @@
+package jsonresult
+
+import "sort"
+
+type UnbondingStake struct {
+	CommitteePublicKey  string `json:"CommitteePublicKey"`
+	ReleaseBeaconHeight uint64 `json:"ReleaseBeaconHeight"`
+}
+
+type GetUnbondingStakesResult struct {
+	BeaconHeight    uint64           `json:"BeaconHeight"`
+	UnbondingStakes []UnbondingStake `json:"UnbondingStakes"`
+}
+
+func NewGetUnbondingStakesResult(beaconHeight uint64, unbondingStakes map[string]uint64) *GetUnbondingStakesResult {
+	result := &GetUnbondingStakesResult{
+		BeaconHeight:    beaconHeight,
+		UnbondingStakes: []UnbondingStake{},
+	}
+	for committeePublicKey, releaseBeaconHeight := range unbondingStakes {
+		result.UnbondingStakes = append(result.UnbondingStakes, UnbondingStake{
+			CommitteePublicKey:  committeePublicKey,
+			ReleaseBeaconHeight: releaseBeaconHeight,
+		})
+	}
+	sort.Slice(result.UnbondingStakes, func(i, j int) bool {
+		if result.UnbondingStakes[i].ReleaseBeaconHeight == result.UnbondingStakes[j].ReleaseBeaconHeight {
+			return result.UnbondingStakes[i].CommitteePublicKey < result.UnbondingStakes[j].CommitteePublicKey
+		}
+		return result.UnbondingStakes[i].ReleaseBeaconHeight < result.UnbondingStakes[j].ReleaseBeaconHeight
+	})
+	return result
+}
@@ -3,4 +3,7 @@ package jsonresult
 type ListAccounts struct {
 	WalletName string            `json:"WalletName"`
 	Accounts   map[string]uint64 `json:"Accounts"`
+	// WatchOnlyAccounts is accounts without private key, their amount is total of incoming coins
+	// which can't be spent, so it is not counted in Accounts
+	WatchOnlyAccounts map[string]uint64 `json:"WatchOnlyAccounts,omitempty"`
 }
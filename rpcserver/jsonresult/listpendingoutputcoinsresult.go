@@ -0,0 +1,27 @@
+package jsonresult
+
+import (
+	"github.com/incognitochain/incognito-chain/mempool"
+)
+
+type PendingOutCoin struct {
+	OutCoin
+	TxID    string `json:"TxID"`
+	TokenID string `json:"TokenID"`
+}
+
+type ListPendingOutputCoinsResult struct {
+	Outputs []PendingOutCoin `json:"Outputs"`
+}
+
+func NewListPendingOutputCoinsResult(pendingOutputCoins []mempool.PendingOutputCoin) *ListPendingOutputCoinsResult {
+	result := &ListPendingOutputCoinsResult{Outputs: []PendingOutCoin{}}
+	for _, pendingOutputCoin := range pendingOutputCoins {
+		result.Outputs = append(result.Outputs, PendingOutCoin{
+			OutCoin: NewOutCoin(pendingOutputCoin.OutputCoin),
+			TxID:    pendingOutputCoin.TxHash.String(),
+			TokenID: pendingOutputCoin.TokenID.String(),
+		})
+	}
+	return result
+}
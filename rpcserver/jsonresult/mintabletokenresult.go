@@ -0,0 +1,35 @@
+package jsonresult
+
+import (
+	"github.com/incognitochain/incognito-chain/database/lvdb"
+)
+
+type MintableTokenResult struct {
+	TokenID             string   `json:"TokenID"`
+	TokenName           string   `json:"TokenName"`
+	TokenSymbol         string   `json:"TokenSymbol"`
+	AuthorityAddresses  []string `json:"AuthorityAddresses"`
+	RequiredSignatures  int      `json:"RequiredSignatures"`
+	TotalMinted         uint64   `json:"TotalMinted"`
+	TotalBurned         uint64   `json:"TotalBurned"`
+	CirculatingSupply   uint64   `json:"CirculatingSupply"`
+	NextMintNonce       uint64   `json:"NextMintNonce"`
+	CreatedBeaconHeight uint64   `json:"CreatedBeaconHeight"`
+	InitTxReqID         string   `json:"InitTxReqID"`
+}
+
+func NewMintableTokenResult(token *lvdb.MintableToken) *MintableTokenResult {
+	return &MintableTokenResult{
+		TokenID:             token.TokenID.String(),
+		TokenName:           token.TokenName,
+		TokenSymbol:         token.TokenSymbol,
+		AuthorityAddresses:  token.AuthorityAddresses,
+		RequiredSignatures:  token.RequiredSignatures,
+		TotalMinted:         token.TotalMinted,
+		TotalBurned:         token.TotalBurned,
+		CirculatingSupply:   token.CirculatingSupply(),
+		NextMintNonce:       token.MintNonce,
+		CreatedBeaconHeight: token.CreatedBeaconHeight,
+		InitTxReqID:         token.InitTxReqID.String(),
+	}
+}
@@ -0,0 +1,22 @@
+package jsonresult
+
+// PayoutRecipient is an entry of payout batch and the tx which pays it,
+// TxID is empty until tx of the entry is created
+type PayoutRecipient struct {
+	PaymentAddress string `json:"PaymentAddress"`
+	Amount         uint64 `json:"Amount"`
+	TokenID        string `json:"TokenID"`
+	TxID           string `json:"TxID"`
+	Status         string `json:"Status"`
+}
+
+type PayoutBatchResult struct {
+	IdempotencyKey string            `json:"IdempotencyKey"`
+	Status         string            `json:"Status"`
+	Error          string            `json:"Error,omitempty"`
+	NumTxs         int               `json:"NumTxs"`
+	NumSentTxs     int               `json:"NumSentTxs"`
+	Recipients     []PayoutRecipient `json:"Recipients,omitempty"`
+	CreatedTime    int64             `json:"CreatedTime"`
+	UpdatedTime    int64             `json:"UpdatedTime"`
+}
@@ -0,0 +1,44 @@
+package jsonresult
+
+import (
+	"github.com/incognitochain/incognito-chain/database/lvdb"
+)
+
+// PrivacyTokenInfo is a privacy token with its registered metadata,
+// IsVerified is true if the token is a bridge token, its external token is taken from the bridge
+// and its registry can only be approved by the beacon committee
+type PrivacyTokenInfo struct {
+	TokenID          string `json:"TokenID"`
+	Name             string `json:"Name"`
+	Symbol           string `json:"Symbol"`
+	Decimals         uint8  `json:"Decimals"`
+	Description      string `json:"Description"`
+	IconHash         string `json:"IconHash"`
+	ExternalNetwork  string `json:"ExternalNetwork"`
+	ExternalTokenID  string `json:"ExternalTokenID"`
+	IssuerAddress    string `json:"IssuerAddress"`
+	Amount           uint64 `json:"Amount"`
+	IsRegistered     bool   `json:"IsRegistered"`
+	IsVerified       bool   `json:"IsVerified"`
+	RegisteredHeight uint64 `json:"RegisteredHeight"`
+	UpdatedHeight    uint64 `json:"UpdatedHeight"`
+}
+
+// SetRegistry overrides name and symbol of init tx by registered metadata
+func (info *PrivacyTokenInfo) SetRegistry(registry *lvdb.PrivacyTokenRegistry) {
+	info.Name = registry.TokenName
+	info.Symbol = registry.TokenSymbol
+	info.Decimals = registry.Decimals
+	info.Description = registry.Description
+	info.IconHash = registry.IconHash
+	info.ExternalNetwork = registry.ExternalNetwork
+	info.ExternalTokenID = registry.ExternalTokenID
+	info.IssuerAddress = registry.IssuerAddressStr
+	info.IsRegistered = true
+	info.RegisteredHeight = registry.CreatedBeaconHeight
+	info.UpdatedHeight = registry.UpdatedBeaconHeight
+}
+
+type ListPrivacyTokenInfo struct {
+	ListPrivacyTokenInfo []PrivacyTokenInfo `json:"ListPrivacyTokenInfo"`
+}
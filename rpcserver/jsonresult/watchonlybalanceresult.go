@@ -0,0 +1,9 @@
+package jsonresult
+
+// WatchOnlyBalance is balance of a watch-only account per token id,
+// spent coins can't be detected without private key so it is total of incoming coins
+type WatchOnlyBalance struct {
+	AccountName    string            `json:"AccountName"`
+	PaymentAddress string            `json:"PaymentAddress"`
+	Balances       map[string]uint64 `json:"Balances"`
+}
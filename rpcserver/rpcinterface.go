@@ -34,6 +34,7 @@ var HttpHandler = map[string]httpHandler{
 	removeTxInMempool:       (*HttpServer).handleRemoveTxInMempool,
 	getMempoolInfo:          (*HttpServer).handleGetMempoolInfo,
 	getPendingTxsInBlockgen: (*HttpServer).handleGetPendingTxsInBlockgen,
+	listPendingOutputCoins:  (*HttpServer).handleListPendingOutputCoins,
 
 	// block pool ver.2
 	getShardToBeaconPoolStateV2: (*HttpServer).handleGetShardToBeaconPoolStateV2,
@@ -66,6 +67,7 @@ var HttpHandler = map[string]httpHandler{
 	sendRawTransaction:                      (*HttpServer).handleSendRawTransaction,
 	createAndSendTransaction:                (*HttpServer).handleCreateAndSendTx,
 	getTransactionByHash:                    (*HttpServer).handleGetTransactionByHash,
+	getCrossShardTransactionStatus:          (*HttpServer).handleGetCrossShardTransactionStatus,
 	gettransactionhashbyreceiver:            (*HttpServer).handleGetTransactionHashByReceiver,
 	gettransactionbyreceiver:                (*HttpServer).handleGetTransactionByReceiver,
 	createAndSendStakingTransaction:         (*HttpServer).handleCreateAndSendStakingTx,
@@ -76,6 +78,9 @@ var HttpHandler = map[string]httpHandler{
 	listSerialNumbers:                       (*HttpServer).handleListSerialNumbers,
 	listCommitments:                         (*HttpServer).handleListCommitments,
 	listCommitmentIndices:                   (*HttpServer).handleListCommitmentIndices,
+	createAndSendPayoutBatch:                (*HttpServer).handleCreateAndSendPayoutBatch,
+	getPayoutBatch:                          (*HttpServer).handleGetPayoutBatch,
+	listPayoutBatches:                       (*HttpServer).handleListPayoutBatches,
 
 	//======Testing and Benchmark======
 	getAndSendTxsFromFile:   (*HttpServer).handleGetAndSendTxsFromFile,
@@ -95,6 +100,7 @@ var HttpHandler = map[string]httpHandler{
 	getShardPoolLatestValidHeight: (*HttpServer).handleGetShardPoolLatestValidHeight,
 	canPubkeyStake:                (*HttpServer).handleCanPubkeyStake,
 	getTotalTransaction:           (*HttpServer).handleGetTotalTransaction,
+	getUnbondingStakes:            (*HttpServer).handleGetUnbondingStakes,
 
 	// custom token which support privacy
 	createRawPrivacyCustomTokenTransaction:     (*HttpServer).handleCreateRawPrivacyCustomTokenTransaction,
@@ -166,6 +172,73 @@ var HttpHandler = map[string]httpHandler{
 	convertPDEPrices:                      (*HttpServer).handleConvertPDEPrices,
 	extractPDEInstsFromBeaconBlock:        (*HttpServer).handleExtractPDEInstsFromBeaconBlock,
 
+	// staking pool
+	createRawStakingPoolCreationTransaction:       (*HttpServer).handleCreateRawTxWithStakingPoolCreation,
+	createAndSendStakingPoolCreationTransaction:   (*HttpServer).handleCreateAndSendTxWithStakingPoolCreation,
+	signStakingPoolCreation:                       (*HttpServer).handleSignStakingPoolCreation,
+	createRawStakingPoolDepositTransaction:        (*HttpServer).handleCreateRawTxWithStakingPoolDeposit,
+	createAndSendStakingPoolDepositTransaction:    (*HttpServer).handleCreateAndSendTxWithStakingPoolDeposit,
+	createRawStakingPoolWithdrawalTransaction:     (*HttpServer).handleCreateRawTxWithStakingPoolWithdrawal,
+	createAndSendStakingPoolWithdrawalTransaction: (*HttpServer).handleCreateAndSendTxWithStakingPoolWithdrawal,
+	getStakingPool:       (*HttpServer).handleGetStakingPool,
+	getStakingPoolStatus: (*HttpServer).handleGetStakingPoolStatus,
+
+	// privacy token registry
+	createRawPrivacyTokenRegistrationTransaction:     (*HttpServer).handleCreateRawTxWithPrivacyTokenRegistration,
+	createAndSendPrivacyTokenRegistrationTransaction: (*HttpServer).handleCreateAndSendTxWithPrivacyTokenRegistration,
+	getPrivacyTokenRegistrationStatus:                (*HttpServer).handleGetPrivacyTokenRegistrationStatus,
+	getPrivacyTokenInfo:                              (*HttpServer).handleGetPrivacyTokenInfo,
+	searchPrivacyTokens:                              (*HttpServer).handleSearchPrivacyTokens,
+	signPrivacyTokenRegistration:                     (*HttpServer).handleSignPrivacyTokenRegistration,
+
+	// mintable token
+	createRawMintableTokenInitTransaction:     (*HttpServer).handleCreateRawTxWithMintableTokenInit,
+	createAndSendMintableTokenInitTransaction: (*HttpServer).handleCreateAndSendTxWithMintableTokenInit,
+	signMintableTokenMintRequest:              (*HttpServer).handleSignMintableTokenMintRequest,
+	createRawMintableTokenMintTransaction:     (*HttpServer).handleCreateRawTxWithMintableTokenMint,
+	createAndSendMintableTokenMintTransaction: (*HttpServer).handleCreateAndSendTxWithMintableTokenMint,
+	createRawMintableTokenBurnTransaction:     (*HttpServer).handleCreateRawTxWithMintableTokenBurn,
+	createAndSendMintableTokenBurnTransaction: (*HttpServer).handleCreateAndSendTxWithMintableTokenBurn,
+	getMintableToken:                          (*HttpServer).handleGetMintableToken,
+	getMintableTokenStatus:                    (*HttpServer).handleGetMintableTokenStatus,
+
+	// pde market data
+	getPDETradeHistory:   (*HttpServer).handleGetPDETradeHistory,
+	getPDECandles:        (*HttpServer).handleGetPDECandles,
+	getPDEMarketSummary:  (*HttpServer).handleGetPDEMarketSummary,
+	getPDELiquidityDepth: (*HttpServer).handleGetPDELiquidityDepth,
+
+	// pde liquidity position
+	getPDELiquidityPositions: (*HttpServer).handleGetPDELiquidityPositions,
+
+	// pde trade quote
+	getPDETradeQuote: (*HttpServer).handleGetPDETradeQuote,
+
+	// btc relaying
+	createRawTxWithRelayingBTCHeaders:     (*HttpServer).handleCreateRawTxWithRelayingBTCHeaders,
+	createAndSendTxWithRelayingBTCHeaders: (*HttpServer).handleCreateAndSendTxWithRelayingBTCHeaders,
+	createRawTxWithIssuingBTCReq:          (*HttpServer).handleCreateRawTxWithIssuingBTCReq,
+	createAndSendTxWithIssuingBTCReq:      (*HttpServer).handleCreateAndSendTxWithIssuingBTCReq,
+	getBTCRelayingBestHeader:              (*HttpServer).handleGetBTCRelayingBestHeader,
+	getBTCDepositCommitment:               (*HttpServer).handleGetBTCDepositCommitment,
+	checkBTCDepositIssued:                 (*HttpServer).handleCheckBTCDepositIssued,
+
+	// bridge limiter
+	getBridgeTokenQuota:                   (*HttpServer).handleGetBridgeTokenQuota,
+	signBridgeControlRequest:              (*HttpServer).handleSignBridgeControlRequest,
+	createRawBridgeControlTransaction:     (*HttpServer).handleCreateRawTxWithBridgeControl,
+	createAndSendBridgeControlTransaction: (*HttpServer).handleCreateAndSendTxWithBridgeControl,
+
+	// incognito dao governance
+	createRawDAOProposalTransaction:     (*HttpServer).handleCreateRawTxWithDAOProposal,
+	createAndSendDAOProposalTransaction: (*HttpServer).handleCreateAndSendTxWithDAOProposal,
+	createRawDAOVoteTransaction:         (*HttpServer).handleCreateRawTxWithDAOVote,
+	createAndSendDAOVoteTransaction:     (*HttpServer).handleCreateAndSendTxWithDAOVote,
+	getDAOProposal:                      (*HttpServer).handleGetDAOProposal,
+	listDAOProposals:                    (*HttpServer).handleListDAOProposals,
+	getDAOTreasury:                      (*HttpServer).handleGetDAOTreasury,
+	getDAOVotingWeight:                  (*HttpServer).handleGetDAOVotingWeight,
+
 	getBurningAddress: (*HttpServer).handleGetBurningAddress,
 }
 
@@ -185,6 +258,12 @@ var LimitedHttpHandler = map[string]httpHandler{
 	getBalanceByPaymentAddress:       (*HttpServer).handleGetBalanceByPaymentAddress,
 	getReceivedByAccount:             (*HttpServer).handleGetReceivedByAccount,
 	setTxFee:                         (*HttpServer).handleSetTxFee,
+	setCoinSelection:                 (*HttpServer).handleSetCoinSelection,
+	changeWalletPassPhrase:           (*HttpServer).handleChangeWalletPassPhrase,
+	importWatchOnlyAccount:           (*HttpServer).handleImportWatchOnlyAccount,
+	getWatchOnlyBalance:              (*HttpServer).handleGetWatchOnlyBalance,
+	discoverWalletAccounts:           (*HttpServer).handleDiscoverWalletAccounts,
+	getWalletDiscoveryProgress:       (*HttpServer).handleGetWalletDiscoveryProgress,
 	convertNativeTokenToPrivacyToken: (*HttpServer).handleConvertNativeTokenToPrivacyToken,
 	convertPrivacyTokenToNativeToken: (*HttpServer).handleConvertPrivacyTokenToNativeToken,
 }
@@ -194,6 +273,7 @@ var WsHandler = map[string]wsHandler{
 	subcribeNewShardBlock:                       (*WsServer).handleSubscribeNewShardBlock,
 	subcribeNewBeaconBlock:                      (*WsServer).handleSubscribeNewBeaconBlock,
 	subcribePendingTransaction:                  (*WsServer).handleSubscribePendingTransaction,
+	subcribeCrossShardTransactionStatus:         (*WsServer).handleSubscribeCrossShardTransactionStatus,
 	subcribeShardCandidateByPublickey:           (*WsServer).handleSubcribeShardCandidateByPublickey,
 	subcribeShardCommitteeByPublickey:           (*WsServer).handleSubcribeShardCommitteeByPublickey,
 	subcribeShardPendingValidatorByPublickey:    (*WsServer).handleSubcribeShardPendingValidatorByPublickey,
@@ -207,4 +287,5 @@ var WsHandler = map[string]wsHandler{
 	subcribeBeaconBestState:                     (*WsServer).handleSubscribeBeaconBestState,
 	subcribeBeaconPoolBeststate:                 (*WsServer).handleSubscribeBeaconPoolBestState,
 	subcribeShardPoolBeststate:                  (*WsServer).handleSubscribeShardPoolBeststate,
+	subcribePDEMarketEvents:                     (*WsServer).handleSubscribePDEMarketEvents,
 }
@@ -43,7 +43,7 @@ func NewContractingRequestMetadata(senderPrivateKeyStr string, tokenReceivers in
 	return meta, nil
 }
 
-func NewBurningRequestMetadata(senderPrivateKeyStr string, tokenReceivers interface{}, tokenID string, tokenName string, remoteAddress string) (*metadata.BurningRequest, *RPCError) {
+func NewBurningRequestMetadata(senderPrivateKeyStr string, tokenReceivers interface{}, tokenID string, tokenName string, remoteAddress string, chainID uint64) (*metadata.BurningRequest, *RPCError) {
 	senderKey, err := wallet.Base58CheckDeserialize(senderPrivateKeyStr)
 	if err != nil {
 		return nil, NewRPCError(UnexpectedError, err)
@@ -69,6 +69,7 @@ func NewBurningRequestMetadata(senderPrivateKeyStr string, tokenReceivers interf
 		*tokenIDHash,
 		tokenName,
 		remoteAddress,
+		chainID,
 		metadata.BurningRequestMeta,
 	)
 	if err != nil {
@@ -78,6 +79,39 @@ func NewBurningRequestMetadata(senderPrivateKeyStr string, tokenReceivers interf
 	return meta, nil
 }
 
+func NewMintableTokenBurnRequestMetadata(senderPrivateKeyStr string, tokenReceivers interface{}, tokenID string) (*metadata.MintableTokenBurnRequest, *RPCError) {
+	senderKey, err := wallet.Base58CheckDeserialize(senderPrivateKeyStr)
+	if err != nil {
+		return nil, NewRPCError(UnexpectedError, err)
+	}
+	err = senderKey.KeySet.InitFromPrivateKey(&senderKey.KeySet.PrivateKey)
+	if err != nil {
+		return nil, NewRPCError(UnexpectedError, err)
+	}
+	paymentAddr := senderKey.KeySet.PaymentAddress
+
+	_, voutsAmount, err := transaction.CreateCustomTokenPrivacyReceiverArray(tokenReceivers)
+	if err != nil {
+		return nil, NewRPCError(RPCInvalidParamsError, err)
+	}
+	tokenIDHash, err := common.Hash{}.NewHashFromStr(tokenID)
+	if err != nil {
+		return nil, NewRPCError(UnexpectedError, err)
+	}
+
+	meta, err := metadata.NewMintableTokenBurnRequest(
+		paymentAddr,
+		uint64(voutsAmount),
+		*tokenIDHash,
+		metadata.MintableTokenBurnMeta,
+	)
+	if err != nil {
+		return nil, NewRPCError(UnexpectedError, err)
+	}
+
+	return meta, nil
+}
+
 func GetETHHeaderByHash(ethBlockHash string) (*types.Header, error) {
 	return metadata.GetETHHeader(rCommon.HexToHash(ethBlockHash))
 }
@@ -3,14 +3,15 @@ package rpcservice
 import (
 	"encoding/json"
 	"math/big"
-	"strconv"
 
 	"github.com/pkg/errors"
 
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
 	rCommon "github.com/ethereum/go-ethereum/common"
 	"github.com/incognitochain/incognito-chain/common"
 	"github.com/incognitochain/incognito-chain/common/base58"
 	"github.com/incognitochain/incognito-chain/database"
+	"github.com/incognitochain/incognito-chain/database/lvdb"
 	"github.com/incognitochain/incognito-chain/metadata"
 )
 
@@ -30,12 +31,33 @@ func (dbService DatabaseService) CheckETHHashIssued(data map[string]interface{})
 		return false, errors.New("Tx index param is invalid")
 	}
 	txIdx := uint(txIdxParam)
-	uniqETHTx := append(blockHash[:], []byte(strconv.Itoa(int(txIdx)))...)
+	chainID := uint64(metadata.DefaultEVMChainID)
+	if chainIDParam, ok := data["ChainID"].(float64); ok {
+		chainID = uint64(chainIDParam)
+	}
+	uniqETHTx := metadata.GetUniqETHTx(chainID, blockHash, txIdx)
 
 	issued, err := (*dbService.DB).IsETHTxHashIssued(uniqETHTx)
 	return issued, err
 }
 
+func (dbService DatabaseService) CheckBTCDepositIssued(data map[string]interface{}) (bool, error) {
+	txHashParam, ok := data["TxHash"].(string)
+	if !ok {
+		return false, errors.New("Tx hash param is invalid")
+	}
+	txHash, err := chainhash.NewHashFromStr(txHashParam)
+	if err != nil {
+		return false, errors.New("Tx hash param is invalid")
+	}
+	outputIndexParam, ok := data["OutputIndex"].(float64)
+	if !ok || outputIndexParam < 0 {
+		return false, errors.New("Output index param is invalid")
+	}
+	uniqBTCTx := metadata.GetUniqBTCTx(*txHash, uint32(outputIndexParam))
+	return (*dbService.DB).IsBTCTxIssued(uniqBTCTx)
+}
+
 func (dbService DatabaseService) GetAllBridgeTokens() ([]byte, error) {
 	allBridgeTokensBytes, err := (*dbService.DB).GetAllBridgeTokens()
 	return allBridgeTokensBytes, err
@@ -151,6 +173,119 @@ func (dbService DatabaseService) GetPDEStatus(pdePrefix []byte, pdeSuffix []byte
 	return (*dbService.DB).GetPDEStatus(pdePrefix, pdeSuffix)
 }
 
+func (dbService DatabaseService) GetStakingPoolStatus(txReqID []byte) (byte, error) {
+	return (*dbService.DB).GetStakingPoolStatus(txReqID)
+}
+
+func (dbService DatabaseService) GetPrivacyTokenRegistryStatus(txReqID []byte) (byte, error) {
+	return (*dbService.DB).GetPrivacyTokenRegistryStatus(txReqID)
+}
+
+func (dbService DatabaseService) GetAllPrivacyTokenRegistries() (map[common.Hash]*lvdb.PrivacyTokenRegistry, error) {
+	registriesBytes, err := (*dbService.DB).GetAllPrivacyTokenRegistries()
+	if err != nil {
+		return nil, err
+	}
+	registries := make(map[common.Hash]*lvdb.PrivacyTokenRegistry)
+	for _, registryBytes := range registriesBytes {
+		registry := new(lvdb.PrivacyTokenRegistry)
+		if err := json.Unmarshal(registryBytes, registry); err != nil {
+			return nil, err
+		}
+		registries[registry.TokenID] = registry
+	}
+	return registries, nil
+}
+
+func (dbService DatabaseService) GetMintableTokenStatus(txReqID []byte) (byte, error) {
+	return (*dbService.DB).GetMintableTokenStatus(txReqID)
+}
+
+func (dbService DatabaseService) GetAllMintableTokens() ([]*lvdb.MintableToken, error) {
+	tokensBytes, err := (*dbService.DB).GetAllMintableTokens()
+	if err != nil {
+		return nil, err
+	}
+	tokens := make([]*lvdb.MintableToken, 0, len(tokensBytes))
+	for _, tokenBytes := range tokensBytes {
+		token := new(lvdb.MintableToken)
+		if err := json.Unmarshal(tokenBytes, token); err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, token)
+	}
+	return tokens, nil
+}
+
+func (dbService DatabaseService) GetMintableToken(tokenID common.Hash) (*lvdb.MintableToken, error) {
+	return metadata.GetMintableToken(*dbService.DB, tokenID)
+}
+
+// GetBTCRelayingBestHeader returns nil if no bitcoin header is relayed
+func (dbService DatabaseService) GetBTCRelayingBestHeader() (*lvdb.BTCHeaderInfo, error) {
+	db := *dbService.DB
+	bestHashBytes, err := db.GetBTCBestHeaderHash()
+	if err != nil || len(bestHashBytes) == 0 {
+		return nil, err
+	}
+	headerInfoBytes, err := db.GetBTCHeader(bestHashBytes)
+	if err != nil || len(headerInfoBytes) == 0 {
+		return nil, err
+	}
+	headerInfo := new(lvdb.BTCHeaderInfo)
+	if err := json.Unmarshal(headerInfoBytes, headerInfo); err != nil {
+		return nil, err
+	}
+	return headerInfo, nil
+}
+
+func (dbService DatabaseService) GetPDEMarketEvents(token1IDStr string, token2IDStr string, fromTime int64, toTime int64) ([]*lvdb.PDEMarketEvent, error) {
+	eventsBytes, err := (*dbService.DB).GetPDEMarketEvents(token1IDStr, token2IDStr, fromTime, toTime)
+	if err != nil {
+		return nil, err
+	}
+	events := make([]*lvdb.PDEMarketEvent, 0, len(eventsBytes))
+	for _, eventBytes := range eventsBytes {
+		event := new(lvdb.PDEMarketEvent)
+		if err := json.Unmarshal(eventBytes, event); err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+func (dbService DatabaseService) GetPDEPoolForPair(beaconHeight uint64, token1IDStr string, token2IDStr string) (*lvdb.PDEPoolForPair, error) {
+	poolPairBytes, err := (*dbService.DB).GetPDEPoolForPair(beaconHeight, token1IDStr, token2IDStr)
+	if err != nil {
+		return nil, err
+	}
+	if len(poolPairBytes) == 0 {
+		return nil, nil
+	}
+	poolPair := new(lvdb.PDEPoolForPair)
+	if err := json.Unmarshal(poolPairBytes, poolPair); err != nil {
+		return nil, err
+	}
+	return poolPair, nil
+}
+
+func (dbService DatabaseService) GetPDELiquidityPositionsByContributor(contributorAddressStr string) ([]*lvdb.PDELiquidityPosition, error) {
+	positionsBytes, err := (*dbService.DB).GetPDELiquidityPositionsByContributor(contributorAddressStr)
+	if err != nil {
+		return nil, err
+	}
+	positions := make([]*lvdb.PDELiquidityPosition, 0, len(positionsBytes))
+	for _, positionBytes := range positionsBytes {
+		position := new(lvdb.PDELiquidityPosition)
+		if err := json.Unmarshal(positionBytes, position); err != nil {
+			return nil, err
+		}
+		positions = append(positions, position)
+	}
+	return positions, nil
+}
+
 func (dbService DatabaseService) GetPDEContributionStatus(pdePrefix []byte, pdeSuffix []byte) (*metadata.PDEContributionStatus, error) {
 	pdeStatusContentBytes, err := (*dbService.DB).GetPDEContributionStatus(pdePrefix, pdeSuffix)
 	if err != nil {
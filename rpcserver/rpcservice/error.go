@@ -49,6 +49,18 @@ const (
 	NoSwapConfirmInst
 	GetKeySetFromPrivateKeyError
 	GetPDEStateError
+	GetStakingPoolStateError
+	GetCrossShardTransactionStatusError
+	PayoutBatchError
+	PayoutBatchConflictError
+	GetPrivacyTokenRegistryError
+	GetMintableTokenError
+	GetPDEMarketDataError
+	GetPDELiquidityPositionError
+	GetPDETradeQuoteError
+	GetBTCRelayingError
+	GetBridgeLimiterError
+	GetDAOGovernanceError
 
 	// reject tx
 	RejectInvalidTxFeeError
@@ -109,10 +121,11 @@ var ErrCodeMessage = map[int]struct {
 	GetClonedShardBestStateError:  {-3001, "Get Cloned Shard Best State Error"},
 
 	// tx -4xxx
-	CreateTxDataError:          {-4001, "Can not create tx"},
-	SendTxDataError:            {-4002, "Can not send tx"},
-	Base58ChedkDataOfTxInvalid: {-4003, "Base58Check encode data of tx is invalid, can not decode"},
-	JsonDataOfTxInvalid:        {-4004, "Json string data of tx is invalid, can not unmarshal"},
+	CreateTxDataError:                   {-4001, "Can not create tx"},
+	SendTxDataError:                     {-4002, "Can not send tx"},
+	Base58ChedkDataOfTxInvalid:          {-4003, "Base58Check encode data of tx is invalid, can not decode"},
+	JsonDataOfTxInvalid:                 {-4004, "Json string data of tx is invalid, can not unmarshal"},
+	GetCrossShardTransactionStatusError: {-4005, "Get cross shard transaction status error"},
 
 	// socket/subcribe -5xxx
 	SubcribeError:   {-5000, "Failed to subcribe"},
@@ -135,6 +148,37 @@ var ErrCodeMessage = map[int]struct {
 
 	// pde
 	GetPDEStateError: {-8000, "Get pde state error"},
+
+	// staking pool
+	GetStakingPoolStateError: {-9000, "Get staking pool state error"},
+
+	// payout batch
+	PayoutBatchError:         {-10000, "Payout batch error"},
+	PayoutBatchConflictError: {-10001, "Idempotency key is already used by a different payout batch"},
+
+	// privacy token registry
+	GetPrivacyTokenRegistryError: {-11000, "Get privacy token registry error"},
+
+	// mintable token
+	GetMintableTokenError: {-12000, "Get mintable token error"},
+
+	// pde market data
+	GetPDEMarketDataError: {-13000, "Get pde market data error"},
+
+	// pde liquidity position
+	GetPDELiquidityPositionError: {-14000, "Get pde liquidity position error"},
+
+	// pde trade quote
+	GetPDETradeQuoteError: {-15000, "Get pde trade quote error"},
+
+	// btc relaying
+	GetBTCRelayingError: {-16000, "Get btc relaying error"},
+
+	// bridge limiter
+	GetBridgeLimiterError: {-17000, "Get bridge limiter error"},
+
+	// dao governance
+	GetDAOGovernanceError: {-18000, "Get dao governance error"},
 }
 
 // RPCError represents an error that is used as a part of a JSON-RPC JsonResponse
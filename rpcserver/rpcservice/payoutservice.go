@@ -0,0 +1,184 @@
+package rpcservice
+
+import (
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/database"
+	"github.com/incognitochain/incognito-chain/transaction"
+)
+
+// payout batch status
+const (
+	PayoutBatchRunning   = "running"
+	PayoutBatchCompleted = "completed"
+	PayoutBatchFailed    = "failed"
+)
+
+// payout chunk status
+const (
+	PayoutChunkPending = "pending"
+	PayoutChunkSending = "sending" // tx of chunk is signed and broadcast, it is not in a block yet
+	PayoutChunkSent    = "sent"    // tx of chunk is in a block
+)
+
+/*
+	PayoutBatchChunk is a tx of payout batch and its sending status.
+	Signed tx is stored with the chunk before it is broadcast, a resumed batch broadcasts the same tx again
+	instead of creating a new one, so a chunk is never paid twice
+*/
+type PayoutBatchChunk struct {
+	transaction.PayoutChunk
+	Status   string
+	TxID     string
+	RawTx    string `json:",omitempty"` // base58 check data of signed tx
+	Attempts int
+	Error    string
+}
+
+/*
+	PayoutBatch is a payout request which is planned into txs, it is stored by its idempotency key.
+	Private key of sender is never stored, a failed or interrupted batch is resumed
+	by sending the same request with the same idempotency key again
+*/
+type PayoutBatch struct {
+	IdempotencyKey string
+	RequestHash    common.Hash
+	SenderAddress  string
+	Fee            int64
+	HasPrivacy     bool
+	Entries        []transaction.PayoutEntry
+	Chunks         []PayoutBatchChunk
+	Status         string
+	Error          string
+	CreatedTime    int64
+	UpdatedTime    int64
+}
+
+type PayoutService struct {
+	DB *database.DatabaseInterface
+
+	lock    sync.Mutex
+	running map[string]bool // idempotency keys of batches which are being sent by this node
+}
+
+// NewPayoutBatch plans entries into txs, a token tx has the same privacy as PRV tx
+func (payoutService *PayoutService) NewPayoutBatch(idempotencyKey string, senderAddress string, entries []transaction.PayoutEntry, fee int64, hasPrivacy bool) (*PayoutBatch, *RPCError) {
+	if idempotencyKey == "" {
+		return nil, NewRPCError(RPCInvalidParamsError, errors.New("idempotency key is empty"))
+	}
+	chunks, err := transaction.PlanPayouts(entries, func(isToken bool) int {
+		return transaction.MaxPayoutsPerTx(hasPrivacy, isToken)
+	})
+	if err != nil {
+		return nil, NewRPCError(RPCInvalidParamsError, err)
+	}
+	batch := &PayoutBatch{
+		IdempotencyKey: idempotencyKey,
+		SenderAddress:  senderAddress,
+		Fee:            fee,
+		HasPrivacy:     hasPrivacy,
+		Entries:        entries,
+		Chunks:         make([]PayoutBatchChunk, 0, len(chunks)),
+		Status:         PayoutBatchRunning,
+		CreatedTime:    time.Now().Unix(),
+	}
+	for _, chunk := range chunks {
+		batch.Chunks = append(batch.Chunks, PayoutBatchChunk{PayoutChunk: chunk, Status: PayoutChunkPending})
+	}
+	request, err := json.Marshal([]interface{}{senderAddress, entries, fee, hasPrivacy})
+	if err != nil {
+		return nil, NewRPCError(JsonError, err)
+	}
+	batch.RequestHash = common.HashH(request)
+	return batch, nil
+}
+
+/*
+	StartPayoutBatch stores batch if its idempotency key is new and marks it as being sent.
+	If the key is used by a stored batch:
+	- it returns error if stored batch is of a different request
+	- it returns stored batch and false if stored batch is completed or being sent
+	- otherwise stored batch is resumed, its chunks which are not sent yet are sent again
+	Caller must call FinishPayoutBatch when it stops sending a started batch
+*/
+func (payoutService *PayoutService) StartPayoutBatch(batch *PayoutBatch) (*PayoutBatch, bool, *RPCError) {
+	payoutService.lock.Lock()
+	defer payoutService.lock.Unlock()
+	storedBatch, rpcErr := payoutService.GetPayoutBatch(batch.IdempotencyKey)
+	if rpcErr != nil {
+		return nil, false, rpcErr
+	}
+	if storedBatch != nil {
+		if !storedBatch.RequestHash.IsEqual(&batch.RequestHash) {
+			return nil, false, NewRPCError(PayoutBatchConflictError, nil)
+		}
+		if storedBatch.Status == PayoutBatchCompleted || payoutService.running[batch.IdempotencyKey] {
+			return storedBatch, false, nil
+		}
+		batch = storedBatch
+		batch.Status = PayoutBatchRunning
+		batch.Error = ""
+	}
+	if err := payoutService.StorePayoutBatch(batch); err != nil {
+		return nil, false, err
+	}
+	if payoutService.running == nil {
+		payoutService.running = make(map[string]bool)
+	}
+	payoutService.running[batch.IdempotencyKey] = true
+	return batch, true, nil
+}
+
+func (payoutService *PayoutService) FinishPayoutBatch(idempotencyKey string) {
+	payoutService.lock.Lock()
+	defer payoutService.lock.Unlock()
+	delete(payoutService.running, idempotencyKey)
+}
+
+func (payoutService *PayoutService) StorePayoutBatch(batch *PayoutBatch) *RPCError {
+	batch.UpdatedTime = time.Now().Unix()
+	batchBytes, err := json.Marshal(batch)
+	if err != nil {
+		return NewRPCError(JsonError, err)
+	}
+	if err := (*payoutService.DB).StorePayoutBatch(batch.IdempotencyKey, batchBytes); err != nil {
+		return NewRPCError(PayoutBatchError, err)
+	}
+	return nil
+}
+
+// GetPayoutBatch returns nil if there is no batch of idempotencyKey
+func (payoutService *PayoutService) GetPayoutBatch(idempotencyKey string) (*PayoutBatch, *RPCError) {
+	batchBytes, err := (*payoutService.DB).GetPayoutBatch(idempotencyKey)
+	if err != nil {
+		return nil, NewRPCError(PayoutBatchError, err)
+	}
+	if len(batchBytes) == 0 {
+		return nil, nil
+	}
+	batch := new(PayoutBatch)
+	if err := json.Unmarshal(batchBytes, batch); err != nil {
+		return nil, NewRPCError(JsonError, err)
+	}
+	return batch, nil
+}
+
+func (payoutService *PayoutService) ListPayoutBatches() ([]*PayoutBatch, *RPCError) {
+	batchesBytes, err := (*payoutService.DB).GetAllPayoutBatches()
+	if err != nil {
+		return nil, NewRPCError(PayoutBatchError, err)
+	}
+	batches := make([]*PayoutBatch, 0, len(batchesBytes))
+	for _, batchBytes := range batchesBytes {
+		batch := new(PayoutBatch)
+		if err := json.Unmarshal(batchBytes, batch); err != nil {
+			return nil, NewRPCError(JsonError, err)
+		}
+		batches = append(batches, batch)
+	}
+	return batches, nil
+}
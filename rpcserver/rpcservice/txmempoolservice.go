@@ -1,10 +1,13 @@
 package rpcservice
 
 import (
+	"errors"
+
 	"github.com/incognitochain/incognito-chain/common"
 	"github.com/incognitochain/incognito-chain/mempool"
 	"github.com/incognitochain/incognito-chain/metadata"
 	"github.com/incognitochain/incognito-chain/privacy"
+	"github.com/incognitochain/incognito-chain/wallet"
 )
 
 type TxMemPoolService struct {
@@ -65,4 +68,38 @@ func (txMemPoolService * TxMemPoolService) RemoveTxInMempool(txIDString string)
 	return true, nil
 }
 
+func (txMemPoolService TxMemPoolService) GetPendingOutputCoins(paymentAddressStr string, tokenIDStr string) ([]mempool.PendingOutputCoin, *RPCError) {
+	keyWallet, err := wallet.Base58CheckDeserialize(paymentAddressStr)
+	if err != nil {
+		return nil, NewRPCError(RPCInvalidParamsError, err)
+	}
+	if len(keyWallet.KeySet.PaymentAddress.Pk) == 0 {
+		return nil, NewRPCError(RPCInvalidParamsError, errors.New("payment address is invalid"))
+	}
+	tokenID := common.PRVCoinID
+	if tokenIDStr != "" {
+		tokenIDHash, err := common.Hash{}.NewHashFromStr(tokenIDStr)
+		if err != nil {
+			return nil, NewRPCError(RPCInvalidParamsError, err)
+		}
+		tokenID = *tokenIDHash
+	}
+	return txMemPoolService.TxMemPool.GetPendingOutputCoins(keyWallet.KeySet.PaymentAddress.Pk, tokenID), nil
+}
 
+// GetPendingPDETradeRequests return pde trade requests which are waiting in mempool
+func (txMemPoolService TxMemPoolService) GetPendingPDETradeRequests() []*metadata.PDETradeRequest {
+	trades := []*metadata.PDETradeRequest{}
+	if txMemPoolService.TxMemPool == nil {
+		return trades
+	}
+	for _, tx := range txMemPoolService.TxMemPool.ListTxsDetail() {
+		if tx.GetMetadataType() != metadata.PDETradeRequestMeta {
+			continue
+		}
+		if trade, ok := tx.GetMetadata().(*metadata.PDETradeRequest); ok {
+			trades = append(trades, trade)
+		}
+	}
+	return trades
+}
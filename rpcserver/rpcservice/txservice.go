@@ -34,55 +34,30 @@ type TxService struct {
 	TxMemPool    *mempool.TxPool
 }
 
-// chooseBestOutCoinsToSpent returns list of unspent coins for spending with amount
-func (txService TxService) chooseBestOutCoinsToSpent(outCoins []*privacy.OutputCoin, amount uint64) (resultOutputCoins []*privacy.OutputCoin, remainOutputCoins []*privacy.OutputCoin, totalResultOutputCoinAmount uint64, err error) {
-	resultOutputCoins = make([]*privacy.OutputCoin, 0)
-	remainOutputCoins = make([]*privacy.OutputCoin, 0)
-	totalResultOutputCoinAmount = uint64(0)
-
-	// either take the smallest coins, or a single largest one
-	var outCoinOverLimit *privacy.OutputCoin
-	outCoinsUnderLimit := make([]*privacy.OutputCoin, 0)
-
-	for _, outCoin := range outCoins {
-		if outCoin.CoinDetails.GetValue() < amount {
-			outCoinsUnderLimit = append(outCoinsUnderLimit, outCoin)
-		} else if outCoinOverLimit == nil {
-			outCoinOverLimit = outCoin
-		} else if outCoinOverLimit.CoinDetails.GetValue() > outCoin.CoinDetails.GetValue() {
-			remainOutputCoins = append(remainOutputCoins, outCoin)
-		} else {
-			remainOutputCoins = append(remainOutputCoins, outCoinOverLimit)
-			outCoinOverLimit = outCoin
-		}
+// coinSelector returns coin selector of strategy in wallet config, or default selector
+func (txService TxService) coinSelector() transaction.CoinSelector {
+	strategy := ""
+	if txService.Wallet != nil && txService.Wallet.GetConfig() != nil {
+		strategy = txService.Wallet.GetConfig().CoinSelection
 	}
-
-	sort.Slice(outCoinsUnderLimit, func(i, j int) bool {
-		return outCoinsUnderLimit[i].CoinDetails.GetValue() < outCoinsUnderLimit[j].CoinDetails.GetValue()
-	})
-
-	for _, outCoin := range outCoinsUnderLimit {
-		if totalResultOutputCoinAmount < amount {
-			totalResultOutputCoinAmount += outCoin.CoinDetails.GetValue()
-			resultOutputCoins = append(resultOutputCoins, outCoin)
-		} else {
-			remainOutputCoins = append(remainOutputCoins, outCoin)
-		}
+	selector, err := transaction.NewCoinSelector(strategy)
+	if err != nil {
+		Logger.log.Errorf("Coin selection %+v of wallet is invalid, use default strategy", strategy)
+		selector, _ = transaction.NewCoinSelector(transaction.CoinSelectionDefault)
 	}
+	return selector
+}
 
-	if outCoinOverLimit != nil && (outCoinOverLimit.CoinDetails.GetValue() > 2*amount || totalResultOutputCoinAmount < amount) {
-		remainOutputCoins = append(remainOutputCoins, resultOutputCoins...)
-		resultOutputCoins = []*privacy.OutputCoin{outCoinOverLimit}
-		totalResultOutputCoinAmount = outCoinOverLimit.CoinDetails.GetValue()
-	} else if outCoinOverLimit != nil {
-		remainOutputCoins = append(remainOutputCoins, outCoinOverLimit)
+// chooseBestOutCoinsToSpent returns list of unspent coins for spending with amount, at most maxInputs coins are chosen
+func (txService TxService) chooseBestOutCoinsToSpent(outCoins []*privacy.OutputCoin, amount uint64, maxInputs int) (resultOutputCoins []*privacy.OutputCoin, totalResultOutputCoinAmount uint64, err error) {
+	resultOutputCoins, err = txService.coinSelector().SelectCoins(outCoins, amount, maxInputs)
+	if err != nil {
+		return nil, 0, err
 	}
-
-	if totalResultOutputCoinAmount < amount {
-		return resultOutputCoins, remainOutputCoins, totalResultOutputCoinAmount, errors.New("Not enough coin")
-	} else {
-		return resultOutputCoins, remainOutputCoins, totalResultOutputCoinAmount, nil
+	for _, outCoin := range resultOutputCoins {
+		totalResultOutputCoinAmount += outCoin.CoinDetails.GetValue()
 	}
+	return resultOutputCoins, totalResultOutputCoinAmount, nil
 }
 
 func (txService TxService) filterMemPoolOutcoinsToSpent(outCoins []*privacy.OutputCoin) ([]*privacy.OutputCoin, error) {
@@ -132,60 +107,51 @@ func (txService TxService) chooseOutsCoinByKeyset(
 	if len(outCoins) == 0 && totalAmmount > 0 {
 		return nil, 0, NewRPCError(GetOutputCoinError, errors.New("not enough output coin"))
 	}
-	// Use Knapsack to get candiate output coin
-	candidateOutputCoins, outCoins, candidateOutputCoinAmount, err := txService.chooseBestOutCoinsToSpent(outCoins, totalAmmount)
+	beaconState, err := txService.BlockChain.BestState.GetClonedBeaconBestState()
 	if err != nil {
-		return nil, 0, NewRPCError(GetOutputCoinError, err)
-	}
-	// refund out put for sender
-	overBalanceAmount := candidateOutputCoinAmount - totalAmmount
-	if overBalanceAmount > 0 {
-		// add more into output for estimate fee
-		paymentInfos = append(paymentInfos, &privacy.PaymentInfo{
-			PaymentAddress: keySet.PaymentAddress,
-			Amount:         overBalanceAmount,
-		})
+		return nil, 0, NewRPCError(GetClonedBeaconBestStateError, err)
 	}
-
-	// check real fee(nano PRV) per tx
-
-	beaconState, err := txService.BlockChain.BestState.GetClonedBeaconBestState()
 	beaconHeight := beaconState.BeaconHeight
-	realFee, _, _, err := txService.EstimateFee(unitFeeNativeToken, false, candidateOutputCoins,
-		paymentInfos, shardIDSender, numBlock, hasPrivacy,
-		metadataParam,
-		privacyCustomTokenParams, db, int64(beaconHeight))
-	if err != nil {
-		return nil, 0, NewRPCError(RejectInvalidTxFeeError, err)
+	estimateFee := func(numInputs int, numPayments int) (uint64, error) {
+		realFee, _, _, err := txService.estimateFee(unitFeeNativeToken, false, numInputs, numPayments, shardIDSender, numBlock, hasPrivacy,
+			metadataParam,
+			privacyCustomTokenParams, db, int64(beaconHeight))
+		return realFee, err
 	}
 
-	if totalAmmount == 0 && realFee == 0 {
-		if metadataParam != nil {
-			metadataType := metadataParam.GetType()
-			switch metadataType {
-			case metadata.WithDrawRewardRequestMeta:
-				{
-					return nil, realFee, nil
+	if totalAmmount == 0 {
+		// check real fee(nano PRV) of tx without input coins
+		realFee, err := estimateFee(0, len(paymentInfos))
+		if err != nil {
+			return nil, 0, NewRPCError(RejectInvalidTxFeeError, err)
+		}
+		if realFee == 0 {
+			if metadataParam != nil {
+				metadataType := metadataParam.GetType()
+				switch metadataType {
+				case metadata.WithDrawRewardRequestMeta:
+					{
+						return nil, realFee, nil
+					}
 				}
+				return nil, realFee, NewRPCError(RejectInvalidTxFeeError, errors.New(fmt.Sprintf("totalAmmount: %+v, realFee: %+v", totalAmmount, realFee)))
+			}
+			if privacyCustomTokenParams != nil {
+				// for privacy token
+				return nil, 0, nil
 			}
-			return nil, realFee, NewRPCError(RejectInvalidTxFeeError, errors.New(fmt.Sprintf("totalAmmount: %+v, realFee: %+v", totalAmmount, realFee)))
-		}
-		if privacyCustomTokenParams != nil {
-			// for privacy token
-			return nil, 0, nil
 		}
 	}
 
-	needToPayFee := int64((totalAmmount + realFee) - candidateOutputCoinAmount)
-	// if not enough to pay fee
-	if needToPayFee > 0 {
-		if len(outCoins) > 0 {
-			candidateOutputCoinsForFee, _, _, err1 := txService.chooseBestOutCoinsToSpent(outCoins, uint64(needToPayFee))
-			if err != nil {
-				return nil, 0, NewRPCError(GetOutputCoinError, err1)
-			}
-			candidateOutputCoins = append(candidateOutputCoins, candidateOutputCoinsForFee...)
-		}
+	// choose coins which pay amount and fee, number of coins is limited by max size of tx
+	limitFee := uint64(0)
+	if feeEstimator, ok := txService.FeeEstimator[shardIDSender]; ok {
+		limitFee = feeEstimator.GetLimitFeeForNativeToken()
+	}
+	maxInputs := transaction.MaxInputCoins(len(paymentInfos)+1, hasPrivacy, metadataParam, privacyCustomTokenParams, limitFee)
+	candidateOutputCoins, _, realFee, err := transaction.SelectCoinsWithFee(txService.coinSelector(), outCoins, totalAmmount, len(paymentInfos), maxInputs, estimateFee)
+	if err != nil {
+		return nil, 0, NewRPCError(GetOutputCoinError, err)
 	}
 	// convert to inputcoins
 	inputCoins := transaction.ConvertOutputCoinToInputCoin(candidateOutputCoins)
@@ -205,6 +171,21 @@ func (txService TxService) EstimateFee(
 	privacyCustomTokenParams *transaction.CustomTokenPrivacyParamTx,
 	db database.DatabaseInterface,
 	beaconHeight int64) (uint64, uint64, uint64, error) {
+	return txService.estimateFee(defaultFee, isGetPTokenFee, len(candidateOutputCoins), len(paymentInfos), shardID, numBlock, hasPrivacy,
+		metadata, privacyCustomTokenParams, db, beaconHeight)
+}
+
+// estimateFee - estimate fee of tx with numInputCoins input coins and numPayments outputs
+func (txService TxService) estimateFee(
+	defaultFee int64,
+	isGetPTokenFee bool,
+	numInputCoins int,
+	numPayments int, shardID byte,
+	numBlock uint64, hasPrivacy bool,
+	metadata metadata.Metadata,
+	privacyCustomTokenParams *transaction.CustomTokenPrivacyParamTx,
+	db database.DatabaseInterface,
+	beaconHeight int64) (uint64, uint64, uint64, error) {
 	if numBlock == 0 {
 		numBlock = 1000
 	}
@@ -235,7 +216,7 @@ func (txService TxService) EstimateFee(
 	if feeEstimator, ok := txService.FeeEstimator[shardID]; ok {
 		limitFee = feeEstimator.GetLimitFeeForNativeToken()
 	}
-	estimateTxSizeInKb = transaction.EstimateTxSize(transaction.NewEstimateTxSizeParam(len(candidateOutputCoins), len(paymentInfos), hasPrivacy, metadata, privacyCustomTokenParams, limitFee))
+	estimateTxSizeInKb = transaction.EstimateTxSize(transaction.NewEstimateTxSizeParam(numInputCoins, numPayments, hasPrivacy, metadata, privacyCustomTokenParams, limitFee))
 
 	realFee = uint64(estimateFeeCoinPerKb) * uint64(estimateTxSizeInKb)
 	return realFee, estimateFeeCoinPerKb, estimateTxSizeInKb, nil
@@ -533,7 +514,7 @@ func (txService TxService) BuildPrivacyCustomTokenParam(tokenParamsRaw map[strin
 			if err != nil {
 				return nil, nil, nil, NewRPCError(GetOutputCoinError, err)
 			}
-			candidateOutputTokens, _, _, err := txService.chooseBestOutCoinsToSpent(outputTokens, uint64(voutsAmount))
+			candidateOutputTokens, _, err := txService.chooseBestOutCoinsToSpent(outputTokens, uint64(voutsAmount), transaction.MaxInputCoinNumber)
 			if err != nil {
 				return nil, nil, nil, NewRPCError(GetOutputCoinError, err)
 			}
@@ -670,6 +651,21 @@ func (txService TxService) GetTransactionByHash(txHashStr string) (*jsonresult.T
 	return result, nil
 }
 
+func (txService TxService) GetCrossShardTransactionStatus(txHashStr string) (*jsonresult.GetCrossShardTransactionStatusResult, *RPCError) {
+	txHash, err := common.Hash{}.NewHashFromStr(txHashStr)
+	if err != nil {
+		return nil, NewRPCError(RPCInvalidParamsError, errors.New("tx hash is invalid"))
+	}
+	status, err := txService.BlockChain.GetCrossShardTransactionStatus(*txHash)
+	if err != nil {
+		return nil, NewRPCError(GetCrossShardTransactionStatusError, err)
+	}
+	if status == nil {
+		return nil, NewRPCError(GetCrossShardTransactionStatusError, errors.New("Tx is not a cross shard transaction or not existed in block"))
+	}
+	return jsonresult.NewGetCrossShardTransactionStatusResult(status), nil
+}
+
 func (txService TxService) GetListPrivacyCustomTokenBalance(privateKey string) (jsonresult.ListCustomTokenBalance, *RPCError) {
 	result := jsonresult.ListCustomTokenBalance{ListCustomTokenBalance: []jsonresult.CustomTokenBalance{}}
 	account, err := wallet.Base58CheckDeserialize(privateKey)
@@ -1064,12 +1060,12 @@ func (txService TxService) BuildRawDefragmentAccountTransaction(params interface
 		return nil, NewRPCError(InvalidSenderPrivateKeyError, err)
 	}
 
-	prvCoinID := &common.Hash{}
-	err1 := prvCoinID.SetBytes(common.PRVCoinID[:])
-	if err1 != nil {
-		return nil, NewRPCError(TokenIsInvalidError, err1)
-	}
-	outCoins, err := txService.BlockChain.GetListOutputCoinsByKeyset(senderKeySet, shardIDSender, prvCoinID)
+	return txService.BuildDefragmentTransaction(senderKeySet, shardIDSender, maxVal, estimateFeeCoinPerKb, hasPrivacyCoin, meta, db)
+}
+
+// ListSpendableOutputCoins returns output coins of keySet which are not spent by txs in mempool
+func (txService TxService) ListSpendableOutputCoins(keySet *incognitokey.KeySet, shardID byte, tokenID *common.Hash) ([]*privacy.OutputCoin, *RPCError) {
+	outCoins, err := txService.BlockChain.GetListOutputCoinsByKeyset(keySet, shardID, tokenID)
 	if err != nil {
 		return nil, NewRPCError(GetOutputCoinError, err)
 	}
@@ -1078,7 +1074,27 @@ func (txService TxService) BuildRawDefragmentAccountTransaction(params interface
 	if err != nil {
 		return nil, NewRPCError(GetOutputCoinError, err)
 	}
-	outCoins, amount := txService.calculateOutputCoinsByMinValue(outCoins, maxVal)
+	return outCoins, nil
+}
+
+// BuildDefragmentTransaction merges PRV coins of sender with value at most maxVal into one coin,
+// the smallest coins are merged first and number of merged coins is limited by max size of tx
+func (txService TxService) BuildDefragmentTransaction(senderKeySet *incognitokey.KeySet, shardIDSender byte, maxVal uint64, estimateFeeCoinPerKb int64, hasPrivacyCoin bool, meta metadata.Metadata, db database.DatabaseInterface) (*transaction.Tx, *RPCError) {
+	prvCoinID := &common.Hash{}
+	err1 := prvCoinID.SetBytes(common.PRVCoinID[:])
+	if err1 != nil {
+		return nil, NewRPCError(TokenIsInvalidError, err1)
+	}
+	outCoins, err := txService.ListSpendableOutputCoins(senderKeySet, shardIDSender, prvCoinID)
+	if err != nil {
+		return nil, err
+	}
+	limitFee := uint64(0)
+	if feeEstimator, ok := txService.FeeEstimator[shardIDSender]; ok {
+		limitFee = feeEstimator.GetLimitFeeForNativeToken()
+	}
+	maxInputs := transaction.MaxInputCoins(1, hasPrivacyCoin, meta, nil, limitFee)
+	outCoins, amount := txService.calculateOutputCoinsByMinValue(outCoins, maxVal, maxInputs)
 	if len(outCoins) == 0 {
 		return nil, NewRPCError(GetOutputCoinError, nil)
 	}
@@ -1091,7 +1107,10 @@ func (txService TxService) BuildRawDefragmentAccountTransaction(params interface
 	// check real fee(nano PRV) per tx
 	isGetPTokenFee := false
 
-	beaconState, err := txService.BlockChain.BestState.GetClonedBeaconBestState()
+	beaconState, err1 := txService.BlockChain.BestState.GetClonedBeaconBestState()
+	if err1 != nil {
+		return nil, NewRPCError(GetClonedBeaconBestStateError, err1)
+	}
 	beaconHeight := beaconState.BeaconHeight
 	realFee, _, _, _ := txService.EstimateFee(
 		estimateFeeCoinPerKb, isGetPTokenFee, outCoins, paymentInfos, shardIDSender, 8, hasPrivacyCoin,
@@ -1101,7 +1120,7 @@ func (txService TxService) BuildRawDefragmentAccountTransaction(params interface
 	}
 
 	if uint64(amount) < realFee {
-		return nil, NewRPCError(GetOutputCoinError, err)
+		return nil, NewRPCError(GetOutputCoinError, errors.New("amount of coins is not enough to pay fee"))
 	}
 	paymentInfo.Amount = uint64(amount) - realFee
 
@@ -1112,7 +1131,7 @@ func (txService TxService) BuildRawDefragmentAccountTransaction(params interface
 	// missing flag for privacy
 	// false by default
 	tx := transaction.Tx{}
-	err = tx.Init(
+	err1 = tx.Init(
 		transaction.NewTxPrivacyInitParams(&senderKeySet.PrivateKey,
 			paymentInfos,
 			inputCoins,
@@ -1123,23 +1142,31 @@ func (txService TxService) BuildRawDefragmentAccountTransaction(params interface
 			meta, nil))
 	// END create tx
 
-	if err != nil {
-		return nil, NewRPCError(CreateTxDataError, err)
+	if err1 != nil {
+		return nil, NewRPCError(CreateTxDataError, err1)
 	}
 
 	return &tx, nil
 }
 
-//calculateOutputCoinsByMinValue
-func (txService TxService) calculateOutputCoinsByMinValue(outCoins []*privacy.OutputCoin, maxVal uint64) ([]*privacy.OutputCoin, uint64) {
+//calculateOutputCoinsByMinValue returns at most maxInputs smallest coins with value at most maxVal
+func (txService TxService) calculateOutputCoinsByMinValue(outCoins []*privacy.OutputCoin, maxVal uint64, maxInputs int) ([]*privacy.OutputCoin, uint64) {
 	outCoinsTmp := make([]*privacy.OutputCoin, 0)
-	amount := uint64(0)
 	for _, outCoin := range outCoins {
 		if outCoin.CoinDetails.GetValue() <= maxVal {
 			outCoinsTmp = append(outCoinsTmp, outCoin)
-			amount += outCoin.CoinDetails.GetValue()
 		}
 	}
+	sort.Slice(outCoinsTmp, func(i, j int) bool {
+		return outCoinsTmp[i].CoinDetails.GetValue() < outCoinsTmp[j].CoinDetails.GetValue()
+	})
+	if len(outCoinsTmp) > maxInputs {
+		outCoinsTmp = outCoinsTmp[:maxInputs]
+	}
+	amount := uint64(0)
+	for _, outCoin := range outCoinsTmp {
+		amount += outCoin.CoinDetails.GetValue()
+	}
 	return outCoinsTmp, amount
 }
 
@@ -15,6 +15,7 @@ import (
 type WalletService struct {
 	Wallet     *wallet.Wallet
 	BlockChain *blockchain.BlockChain
+	Discovery  *wallet.AccountDiscovery
 }
 
 func (walletService WalletService) ListAccounts() (jsonresult.ListAccounts, *RPCError) {
@@ -24,27 +25,47 @@ func (walletService WalletService) ListAccounts() (jsonresult.ListAccounts, *RPC
 	}
 	accounts := walletService.Wallet.ListAccounts()
 	for accountName, account := range accounts {
-		lastByte := account.Key.KeySet.PaymentAddress.Pk[len(account.Key.KeySet.PaymentAddress.Pk)-1]
-		shardIDSender := common.GetShardIDFromLastByte(lastByte)
-		prvCoinID := &common.Hash{}
-		err := prvCoinID.SetBytes(common.PRVCoinID[:])
+		amount, err := walletService.getPRVAmountOfAccount(account)
 		if err != nil {
-			return jsonresult.ListAccounts{}, NewRPCError(TokenIsInvalidError, err)
+			return jsonresult.ListAccounts{}, err
 		}
-		outCoins, err := walletService.BlockChain.GetListOutputCoinsByKeyset(&account.Key.KeySet, shardIDSender, prvCoinID)
+		result.Accounts[accountName] = amount
+	}
+	watchOnlyAccounts := walletService.Wallet.ListWatchOnlyAccounts()
+	if len(watchOnlyAccounts) > 0 {
+		result.WatchOnlyAccounts = make(map[string]uint64)
+	}
+	for accountName, account := range watchOnlyAccounts {
+		amount, err := walletService.getPRVAmountOfAccount(account)
 		if err != nil {
-			return jsonresult.ListAccounts{}, NewRPCError(UnexpectedError, err)
-		}
-		amount := uint64(0)
-		for _, out := range outCoins {
-			amount += out.CoinDetails.GetValue()
+			return jsonresult.ListAccounts{}, err
 		}
-		result.Accounts[accountName] = amount
+		result.WatchOnlyAccounts[accountName] = amount
 	}
 
 	return result, nil
 }
 
+// getPRVAmountOfAccount returns total value of PRV output coins of account
+func (walletService WalletService) getPRVAmountOfAccount(account wallet.AccountWallet) (uint64, *RPCError) {
+	lastByte := account.Key.KeySet.PaymentAddress.Pk[len(account.Key.KeySet.PaymentAddress.Pk)-1]
+	shardIDSender := common.GetShardIDFromLastByte(lastByte)
+	prvCoinID := &common.Hash{}
+	err := prvCoinID.SetBytes(common.PRVCoinID[:])
+	if err != nil {
+		return 0, NewRPCError(TokenIsInvalidError, err)
+	}
+	outCoins, err := walletService.BlockChain.GetListOutputCoinsByKeyset(&account.Key.KeySet, shardIDSender, prvCoinID)
+	if err != nil {
+		return 0, NewRPCError(UnexpectedError, err)
+	}
+	amount := uint64(0)
+	for _, out := range outCoins {
+		amount += out.CoinDetails.GetValue()
+	}
+	return amount, nil
+}
+
 func (walletService WalletService) GetAccount(paymentAddrStr string) (string, error) {
 	if paymentAddrStr == ""{
 		return "", NewRPCError(RPCInvalidParamsError, errors.New("payment address is invalid"))
@@ -107,6 +128,17 @@ func (walletService *WalletService) RemoveAccount(privateKey string, passPhrase
 	return true, nil
 }
 
+func (walletService *WalletService) ChangePassPhrase(oldPassPhrase string, newPassPhrase string) (bool, *RPCError) {
+	if walletService.Wallet == nil {
+		return false, NewRPCError(UnexpectedError, errors.New("wallet is not existed"))
+	}
+	err := walletService.Wallet.ChangePassPhrase(oldPassPhrase, newPassPhrase)
+	if err != nil {
+		return false, NewRPCError(UnexpectedError, err)
+	}
+	return true, nil
+}
+
 func (walletService WalletService) GetBalanceByPrivateKey(privateKey string) (uint64, *RPCError) {
 	keySet, shardIDSender, err := GetKeySetFromPrivateKeyParams(privateKey)
 	if err != nil {
@@ -162,6 +194,8 @@ func (walletService WalletService) GetBalanceByPaymentAddress(paymentAddress str
 	return balance, nil
 }
 
+// GetBalance returns spendable PRV balance of accountName, "*" is all accounts with private key,
+// incoming coins of watch-only accounts are reported by GetWatchOnlyBalance
 func (walletService WalletService) GetBalance(accountName string) (uint64, *RPCError) {
 	prvCoinID := &common.Hash{}
 	err1 := prvCoinID.SetBytes(common.PRVCoinID[:])
@@ -173,6 +207,9 @@ func (walletService WalletService) GetBalance(accountName string) (uint64, *RPCE
 	if accountName == "*" {
 		// get balance for all accounts in wallet
 		for _, account := range walletService.Wallet.MasterAccount.Child {
+			if account.IsWatchOnly {
+				continue
+			}
 			lastByte := account.Key.KeySet.PaymentAddress.Pk[len(account.Key.KeySet.PaymentAddress.Pk)-1]
 			shardIDSender := common.GetShardIDFromLastByte(lastByte)
 			outCoins, err := walletService.BlockChain.GetListOutputCoinsByKeyset(&account.Key.KeySet, shardIDSender, prvCoinID)
@@ -186,6 +223,9 @@ func (walletService WalletService) GetBalance(accountName string) (uint64, *RPCE
 	} else {
 		for _, account := range walletService.Wallet.MasterAccount.Child {
 			if account.Name == accountName {
+				if account.IsWatchOnly {
+					return uint64(0), NewRPCError(RPCInvalidParamsError, errors.New("account is watch-only, its balance is reported by getwatchonlybalance"))
+				}
 				// get balance for accountName in wallet
 				lastByte := account.Key.KeySet.PaymentAddress.Pk[len(account.Key.KeySet.PaymentAddress.Pk)-1]
 				shardIDSender := common.GetShardIDFromLastByte(lastByte)
@@ -228,3 +268,91 @@ func (walletService WalletService) GetReceivedByAccount(accountName string) (uin
 	}
 	return balance, nil
 }
+
+func (walletService *WalletService) ImportWatchOnlyAccount(paymentAddress string, readonlyKey string, accountName string, passPhrase string) (wallet.KeySerializedData, error) {
+	account, err := walletService.Wallet.ImportWatchOnlyAccount(paymentAddress, readonlyKey, accountName, passPhrase)
+	if err != nil {
+		return wallet.KeySerializedData{}, err
+	}
+	result := wallet.KeySerializedData{
+		PaymentAddress: account.Key.Base58CheckSerialize(wallet.PaymentAddressType),
+		Pubkey:         hex.EncodeToString(account.Key.KeySet.PaymentAddress.Pk),
+		ReadonlyKey:    account.Key.Base58CheckSerialize(wallet.ReadonlyKeyType),
+	}
+	return result, nil
+}
+
+// GetWatchOnlyBalance scans incoming output coins of watch-only account for PRV and all privacy tokens,
+// amount of coins is decrypted by readonly key of account
+func (walletService WalletService) GetWatchOnlyBalance(accountName string) (*jsonresult.WatchOnlyBalance, *RPCError) {
+	var account *wallet.AccountWallet
+	for i := range walletService.Wallet.MasterAccount.Child {
+		if walletService.Wallet.MasterAccount.Child[i].Name == accountName {
+			account = &walletService.Wallet.MasterAccount.Child[i]
+			break
+		}
+	}
+	if account == nil {
+		return nil, NewRPCError(RPCInvalidParamsError, errors.New("account is not found"))
+	}
+	if !account.IsWatchOnly {
+		return nil, NewRPCError(RPCInvalidParamsError, errors.New("account is not watch-only"))
+	}
+
+	tokenIDs := []common.Hash{common.PRVCoinID}
+	listPrivacyToken, listPrivacyTokenCrossShard, err := walletService.BlockChain.ListPrivacyCustomToken()
+	if err != nil {
+		return nil, NewRPCError(UnexpectedError, err)
+	}
+	for tokenID := range listPrivacyToken {
+		tokenIDs = append(tokenIDs, tokenID)
+	}
+	for tokenID := range listPrivacyTokenCrossShard {
+		if _, ok := listPrivacyToken[tokenID]; !ok {
+			tokenIDs = append(tokenIDs, tokenID)
+		}
+	}
+
+	result := &jsonresult.WatchOnlyBalance{
+		AccountName:    account.Name,
+		PaymentAddress: account.Key.Base58CheckSerialize(wallet.PaymentAddressType),
+		Balances:       make(map[string]uint64),
+	}
+	lastByte := account.Key.KeySet.PaymentAddress.Pk[len(account.Key.KeySet.PaymentAddress.Pk)-1]
+	shardID := common.GetShardIDFromLastByte(lastByte)
+	for i := range tokenIDs {
+		outCoins, err := walletService.BlockChain.GetListOutputCoinsByKeyset(&account.Key.KeySet, shardID, &tokenIDs[i])
+		if err != nil {
+			return nil, NewRPCError(UnexpectedError, err)
+		}
+		amount := uint64(0)
+		for _, out := range outCoins {
+			amount += out.CoinDetails.GetValue()
+		}
+		if amount > 0 || tokenIDs[i] == common.PRVCoinID {
+			result.Balances[tokenIDs[i].String()] = amount
+		}
+	}
+	return result, nil
+}
+
+// DiscoverAccounts starts discovery of used accounts of wallet in background,
+// accounts which are found on chain are added to wallet
+func (walletService WalletService) DiscoverAccounts(gapLimit uint32, shardIDs []byte) (bool, *RPCError) {
+	if walletService.Wallet == nil {
+		return false, NewRPCError(UnexpectedError, errors.New("wallet is not existed"))
+	}
+	isUsed, err := walletService.BlockChain.NewAccountUsageChecker()
+	if err != nil {
+		return false, NewRPCError(UnexpectedError, err)
+	}
+	err = walletService.Discovery.Start(walletService.Wallet, gapLimit, shardIDs, isUsed)
+	if err != nil {
+		return false, NewRPCError(UnexpectedError, err)
+	}
+	return true, nil
+}
+
+func (walletService WalletService) GetAccountDiscoveryProgress() (wallet.AccountDiscoveryProgress, *RPCError) {
+	return walletService.Discovery.Progress(), nil
+}
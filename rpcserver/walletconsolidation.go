@@ -0,0 +1,71 @@
+package rpcserver
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/common/base58"
+	"github.com/incognitochain/incognito-chain/rpcserver/jsonresult"
+	"github.com/incognitochain/incognito-chain/wallet"
+)
+
+// consolidateWalletAccounts merges small coins of wallet accounts by policy every policy.Interval until quit is closed
+func (httpServer *HttpServer) consolidateWalletAccounts(policy wallet.ConsolidationPolicy, quit <-chan struct{}) {
+	Logger.log.Infof("Consolidate wallet accounts with more than %+v coins of value at most %+v every %+v", policy.MinCoins, policy.MaxCoinValue, policy.Interval)
+	ticker := time.NewTicker(policy.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-quit:
+			return
+		case <-ticker.C:
+			for name, account := range httpServer.config.Wallet.ListAccounts() {
+				txID, err := httpServer.consolidateWalletAccount(account, policy)
+				if err != nil {
+					Logger.log.Errorf("Consolidate wallet account %+v error %+v", name, err)
+					continue
+				}
+				if txID != "" {
+					Logger.log.Infof("Consolidate wallet account %+v with tx %+v", name, txID)
+				}
+			}
+		}
+	}
+}
+
+// consolidateWalletAccount sends a defragment tx for account if it has more than policy.MinCoins small coins,
+// it returns empty tx id if account doesn't need to be consolidated
+func (httpServer *HttpServer) consolidateWalletAccount(account wallet.AccountWallet, policy wallet.ConsolidationPolicy) (string, error) {
+	keySet := account.Key.KeySet
+	lastByte := keySet.PaymentAddress.Pk[len(keySet.PaymentAddress.Pk)-1]
+	shardID := common.GetShardIDFromLastByte(lastByte)
+	outCoins, rpcErr := httpServer.txService.ListSpendableOutputCoins(&keySet, shardID, &common.PRVCoinID)
+	if rpcErr != nil {
+		return "", rpcErr
+	}
+	numSmallCoins := 0
+	for _, outCoin := range outCoins {
+		if outCoin.CoinDetails.GetValue() <= policy.MaxCoinValue {
+			numSmallCoins++
+		}
+	}
+	if numSmallCoins <= policy.MinCoins {
+		return "", nil
+	}
+
+	// estimate fee by recent blocks, coins are merged into a coin of the same account with privacy
+	tx, rpcErr := httpServer.txService.BuildDefragmentTransaction(&keySet, shardID, policy.MaxCoinValue, -1, true, nil, *httpServer.config.Database)
+	if rpcErr != nil {
+		return "", rpcErr
+	}
+	byteArrays, err := json.Marshal(tx)
+	if err != nil {
+		return "", err
+	}
+	sendResult, rpcErr := httpServer.handleSendRawTransaction([]interface{}{base58.Base58Check{}.Encode(byteArrays, 0x00)}, nil)
+	if rpcErr != nil {
+		return "", rpcErr
+	}
+	return sendResult.(jsonresult.CreateTransactionResult).TxID, nil
+}
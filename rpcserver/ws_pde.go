@@ -0,0 +1,72 @@
+package rpcserver
+
+import (
+	"errors"
+	"reflect"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/database/lvdb"
+	"github.com/incognitochain/incognito-chain/pubsub"
+	"github.com/incognitochain/incognito-chain/rpcserver/jsonresult"
+	"github.com/incognitochain/incognito-chain/rpcserver/rpcservice"
+)
+
+// handleSubscribePDEMarketEvents - push accepted trades, contributions and withdrawals of pde,
+// params are empty for all pool pairs or 2 token ids of a pool pair
+func (wsServer *WsServer) handleSubscribePDEMarketEvents(params interface{}, subcription string, cResult chan RpcSubResult, closeChan <-chan struct{}) {
+	Logger.log.Info("Handle Subscribe PDE Market Events", params, subcription)
+	if err := checkPDEMarketIndexer(wsServer.config.BlockChain); err != nil {
+		cResult <- RpcSubResult{Error: err}
+		return
+	}
+	arrayParams := common.InterfaceSlice(params)
+	if len(arrayParams) != 0 && len(arrayParams) != 2 {
+		err := rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Methods should contain NO params or 2 token ids"))
+		cResult <- RpcSubResult{Error: err}
+		return
+	}
+	tokenIDStrs := map[string]bool{}
+	for _, param := range arrayParams {
+		tokenIDStr, ok := param.(string)
+		if !ok || tokenIDStr == "" {
+			err := rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Token id is invalid"))
+			cResult <- RpcSubResult{Error: err}
+			return
+		}
+		tokenIDStrs[tokenIDStr] = true
+	}
+	subId, subChan, err := wsServer.config.PubSubManager.RegisterNewSubscriber(pubsub.PDEMarketEventTopic)
+	if err != nil {
+		err := rpcservice.NewRPCError(rpcservice.SubcribeError, err)
+		cResult <- RpcSubResult{Error: err}
+		return
+	}
+	defer func() {
+		Logger.log.Info("Finish Subscribe PDE Market Events")
+		wsServer.config.PubSubManager.Unsubscribe(pubsub.PDEMarketEventTopic, subId)
+		close(cResult)
+	}()
+	for {
+		select {
+		case msg := <-subChan:
+			{
+				events, ok := msg.Value.([]*lvdb.PDEMarketEvent)
+				if !ok {
+					Logger.log.Errorf("Wrong Message Type from Pubsub Manager, wanted []*lvdb.PDEMarketEvent, have %+v", reflect.TypeOf(msg.Value))
+					continue
+				}
+				for _, event := range events {
+					if len(tokenIDStrs) > 0 && (!tokenIDStrs[event.Token1IDStr] || !tokenIDStrs[event.Token2IDStr]) {
+						continue
+					}
+					cResult <- RpcSubResult{Result: *event, Error: nil}
+				}
+			}
+		case <-closeChan:
+			{
+				cResult <- RpcSubResult{Result: jsonresult.UnsubcribeResult{Message: "Unsubscribe PDE Market Events"}}
+				return
+			}
+		}
+	}
+}
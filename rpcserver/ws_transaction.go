@@ -82,3 +82,84 @@ func (wsServer *WsServer) handleSubscribePendingTransaction(params interface{},
 		}
 	}
 }
+
+func (wsServer *WsServer) handleSubscribeCrossShardTransactionStatus(params interface{}, subcription string, cResult chan RpcSubResult, closeChan <-chan struct{}) {
+	Logger.log.Info("Handle Subcribe Cross Shard Transaction Status", params, subcription)
+	arrayParams := common.InterfaceSlice(params)
+	if len(arrayParams) != 1 {
+		err := rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Methods should only contain 1 params"))
+		cResult <- RpcSubResult{Error: err}
+		return
+	}
+	txHashTemp, ok := arrayParams[0].(string)
+	if !ok || txHashTemp == "" {
+		err := rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, errors.New("Invalid Tx Hash"))
+		cResult <- RpcSubResult{Error: err}
+		return
+	}
+	txHash, err := common.Hash{}.NewHashFromStr(txHashTemp)
+	if err != nil {
+		err1 := rpcservice.NewRPCError(rpcservice.RPCInvalidParamsError, err)
+		cResult <- RpcSubResult{Error: err1}
+		return
+	}
+	// status changes when tx is included in source block, cross shard block is confirmed by beacon
+	// or destination shard block accepts cross shard block
+	shardSubId, shardSubChan, err := wsServer.config.PubSubManager.RegisterNewSubscriber(pubsub.NewShardblockTopic)
+	if err != nil {
+		err := rpcservice.NewRPCError(rpcservice.SubcribeError, err)
+		cResult <- RpcSubResult{Error: err}
+		return
+	}
+	beaconSubId, beaconSubChan, err := wsServer.config.PubSubManager.RegisterNewSubscriber(pubsub.NewBeaconBlockTopic)
+	if err != nil {
+		wsServer.config.PubSubManager.Unsubscribe(pubsub.NewShardblockTopic, shardSubId)
+		err := rpcservice.NewRPCError(rpcservice.SubcribeError, err)
+		cResult <- RpcSubResult{Error: err}
+		return
+	}
+	defer func() {
+		Logger.log.Info("Finish Subscribe Cross Shard Transaction Status ", txHashTemp)
+		wsServer.config.PubSubManager.Unsubscribe(pubsub.NewShardblockTopic, shardSubId)
+		wsServer.config.PubSubManager.Unsubscribe(pubsub.NewBeaconBlockTopic, beaconSubId)
+		close(cResult)
+	}()
+	var lastResult *jsonresult.GetCrossShardTransactionStatusResult
+	// notify client whenever status changed, return after tx is delivered to all destination shards
+	notifyStatus := func() bool {
+		status, err := wsServer.config.BlockChain.GetCrossShardTransactionStatus(*txHash)
+		if err != nil {
+			cResult <- RpcSubResult{Error: rpcservice.NewRPCError(rpcservice.GetCrossShardTransactionStatusError, err)}
+			return true
+		}
+		if status == nil {
+			return false
+		}
+		result := jsonresult.NewGetCrossShardTransactionStatusResult(status)
+		if !reflect.DeepEqual(result, lastResult) {
+			cResult <- RpcSubResult{Result: result, Error: nil}
+			lastResult = result
+		}
+		return result.IsDelivered
+	}
+	if notifyStatus() {
+		return
+	}
+	for {
+		select {
+		case <-shardSubChan:
+			if notifyStatus() {
+				return
+			}
+		case <-beaconSubChan:
+			if notifyStatus() {
+				return
+			}
+		case <-closeChan:
+			{
+				cResult <- RpcSubResult{Result: jsonresult.UnsubcribeResult{Message: "Unsubscribe Cross Shard Transaction Status " + txHashTemp}}
+				return
+			}
+		}
+	}
+}
@@ -299,6 +299,11 @@ func (serverObj *Server) NewServer(listenAddrs string, db database.DatabaseInter
 			//mubft
 			OnBFTMsg:    serverObj.OnBFTMsg,
 			OnPeerState: serverObj.OnPeerState,
+
+			// compact block relay
+			OnCompactBlockShard: serverObj.OnCompactBlockShard,
+			OnGetBlockTxn:       serverObj.OnGetBlockTxn,
+			OnBlockTxn:          serverObj.OnBlockTxn,
 		},
 	}
 
@@ -329,12 +334,15 @@ func (serverObj *Server) NewServer(listenAddrs string, db database.DatabaseInter
 		CrossShardPool:    serverObj.crossShardPool,
 		Server:            serverObj,
 		// UserKeySet:        serverObj.userKeySet,
-		NodeMode:        cfg.NodeMode,
-		FeeEstimator:    make(map[byte]blockchain.FeeEstimator),
-		PubSubManager:   pubsubManager,
-		RandomClient:    randomClient,
-		ConsensusEngine: serverObj.consensusEngine,
-		Highway:         serverObj.highway,
+		NodeMode:          cfg.NodeMode,
+		FeeEstimator:      make(map[byte]blockchain.FeeEstimator),
+		PubSubManager:     pubsubManager,
+		RandomClient:      randomClient,
+		PDEStateRetention: cfg.PDEStateRetention,
+		ConsensusEngine:   serverObj.consensusEngine,
+		Highway:           serverObj.highway,
+		// market data indexer is opt-in, it writes extra data on every beacon block
+		EnablePDEMarketIndexer: cfg.PDEMarketIndexer,
 	})
 	if err != nil {
 		return err
@@ -410,6 +418,10 @@ func (serverObj *Server) NewServer(listenAddrs string, db database.DatabaseInter
 		FeeEstimator:      serverObj.feeEstimator,
 		TxLifeTime:        cfg.TxPoolTTL,
 		MaxTx:             cfg.TxPoolMaxTx,
+		StakingLaneMaxTx:  cfg.TxPoolStakingLaneMaxTx,
+		BridgeLaneMaxTx:   cfg.TxPoolBridgeLaneMaxTx,
+		PDELaneMaxTx:      cfg.TxPoolPDELaneMaxTx,
+		MaxTxPerSender:    cfg.TxPoolMaxTxPerSender,
 		DataBaseMempool:   dbmp,
 		IsLoadFromMempool: cfg.LoadMempool,
 		PersistMempool:    cfg.PersistMempool,
@@ -947,6 +959,11 @@ func (serverObj *Server) NewPeerConfig() *peer.Config {
 			PushRawBytesToShard:  serverObj.PushRawBytesToShard,
 			PushRawBytesToBeacon: serverObj.PushRawBytesToBeacon,
 			GetCurrentRoleShard:  serverObj.GetCurrentRoleShard,
+
+			// compact block relay
+			OnCompactBlockShard: serverObj.OnCompactBlockShard,
+			OnGetBlockTxn:       serverObj.OnGetBlockTxn,
+			OnBlockTxn:          serverObj.OnBlockTxn,
 		},
 		MaxInPeers:      cfg.MaxInPeers,
 		MaxPeers:        cfg.MaxPeers,
@@ -1106,6 +1123,9 @@ func (serverObj *Server) OnVersion(peerConn *peer.PeerConn, msg *wire.MessageVer
 		return
 	}
 
+	// send next messages with the best codec both peers support
+	peerConn.SetWireCodec(wire.NegotiateCodec(wire.CurrentCodec, msg.WireCodec))
+
 	msgV, err := wire.MakeEmptyMessage(wire.CmdVerack)
 	if err != nil {
 		return
@@ -1254,6 +1274,27 @@ func (serverObj *Server) OnPeerState(_ *peer.PeerConn, msg *wire.MessagePeerStat
 	Logger.log.Debug("Receive a peerstate END")
 }
 
+func (serverObj *Server) OnCompactBlockShard(_ *peer.PeerConn, msg *wire.MessageCompactBlockShard) {
+	Logger.log.Debug("Receive a compact block shard START")
+	var txProcessed chan struct{}
+	serverObj.netSync.QueueMessage(nil, msg, txProcessed)
+	Logger.log.Debug("Receive a compact block shard END")
+}
+
+func (serverObj *Server) OnGetBlockTxn(_ *peer.PeerConn, msg *wire.MessageGetBlockTxn) {
+	Logger.log.Debug("Receive a getblocktxn START")
+	var txProcessed chan struct{}
+	serverObj.netSync.QueueMessage(nil, msg, txProcessed)
+	Logger.log.Debug("Receive a getblocktxn END")
+}
+
+func (serverObj *Server) OnBlockTxn(_ *peer.PeerConn, msg *wire.MessageBlockTxn) {
+	Logger.log.Debug("Receive a blocktxn START")
+	var txProcessed chan struct{}
+	serverObj.netSync.QueueMessage(nil, msg, txProcessed)
+	Logger.log.Debug("Receive a blocktxn END")
+}
+
 func (serverObj *Server) GetPeerIDsFromPublicKey(pubKey string) []libp2p.ID {
 	result := []libp2p.ID{}
 	// panic(pubKey)
@@ -1460,6 +1501,7 @@ func (serverObj *Server) PushVersionMessage(peerConn *peer.PeerConn) error {
 	msg.(*wire.MessageVersion).RawRemoteAddress = peerConn.GetListenerPeer().GetRawAddress()
 	msg.(*wire.MessageVersion).RemotePeerId = peerConn.GetListenerPeer().GetPeerID()
 	msg.(*wire.MessageVersion).ProtocolVersion = serverObj.protocolVersion
+	msg.(*wire.MessageVersion).WireCodec = wire.CurrentCodec
 
 	// ValidateTransaction Public Key from ProducerPrvKey
 	// publicKeyInBase58CheckEncode, publicKeyType := peerConn.GetListenerPeer().GetConfig().ConsensusEngine.GetCurrentMiningPublicKey()
@@ -1908,13 +1950,31 @@ func (serverObj *Server) PushBlockToAll(block common.BlockInterface, isBeacon bo
 		return nil
 	} else {
 		shardBlock := block.(*blockchain.ShardBlock)
-		msgShard, err := wire.MakeEmptyMessage(wire.CmdBlockShard)
-		if err != nil {
-			Logger.log.Error(err)
-			return err
+		// nodes of shard reconstruct block from their mempool, full block is sent before compact block break point
+		// or if compact block can't be published
+		isSent := false
+		if serverObj.blockChain.IsCompactShardBlockActive(shardBlock) {
+			msgCompactShard, err := wire.MakeEmptyMessage(wire.CmdCompactBlockShard)
+			if err != nil {
+				Logger.log.Error(err)
+				return err
+			}
+			msgCompactShard.(*wire.MessageCompactBlockShard).Block = blockchain.NewCompactShardBlock(shardBlock)
+			if err := serverObj.PushMessageToShard(msgCompactShard, shardBlock.Header.ShardID, map[libp2p.ID]bool{}); err != nil {
+				Logger.log.Debug(err)
+			} else {
+				isSent = true
+			}
+		}
+		if !isSent {
+			msgShard, err := wire.MakeEmptyMessage(wire.CmdBlockShard)
+			if err != nil {
+				Logger.log.Error(err)
+				return err
+			}
+			msgShard.(*wire.MessageBlockShard).Block = shardBlock
+			serverObj.PushMessageToShard(msgShard, shardBlock.Header.ShardID, map[libp2p.ID]bool{})
 		}
-		msgShard.(*wire.MessageBlockShard).Block = shardBlock
-		serverObj.PushMessageToShard(msgShard, shardBlock.Header.ShardID, map[libp2p.ID]bool{})
 
 		shardToBeaconBlk := shardBlock.CreateShardToBeaconBlock(serverObj.blockChain)
 		msgShardToBeacon, err := wire.MakeEmptyMessage(wire.CmdBlkShardToBeacon)
@@ -0,0 +1,319 @@
+package transaction
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/incognitochain/incognito-chain/privacy"
+)
+
+// MaxInputCoinNumber is max number of input coins of a payment proof, the number is encoded in 1 byte
+const MaxInputCoinNumber = 255
+
+// coin selection strategies
+const (
+	// CoinSelectionDefault takes the smallest coins, or a single coin if it is large enough
+	CoinSelectionDefault = "default"
+	// CoinSelectionMinInputs takes the largest coins first
+	CoinSelectionMinInputs = "mininputs"
+	// CoinSelectionMinChange takes coins whose total is closest above amount
+	CoinSelectionMinChange = "minchange"
+	// CoinSelectionRandom takes random coins so spending patterns don't link coins of an account
+	CoinSelectionRandom = "random"
+	// CoinSelectionBranchAndBound searches coins whose total matches amount exactly, it falls back to CoinSelectionMinChange
+	CoinSelectionBranchAndBound = "bnb"
+)
+
+// maxBranchAndBoundTries is number of nodes which branch and bound searches before it falls back
+const maxBranchAndBoundTries = 100000
+
+// maxFeeSelectionRounds is number of times coins are selected again when fee of selected coins is larger than estimated
+const maxFeeSelectionRounds = 8
+
+// CoinSelector chooses coins to spend for amount, it never chooses more than maxInputs coins
+type CoinSelector interface {
+	SelectCoins(outCoins []*privacy.OutputCoin, amount uint64, maxInputs int) ([]*privacy.OutputCoin, error)
+}
+
+type coinSelectorFunc func(outCoins []*privacy.OutputCoin, amount uint64, maxInputs int) ([]*privacy.OutputCoin, error)
+
+func (selector coinSelectorFunc) SelectCoins(outCoins []*privacy.OutputCoin, amount uint64, maxInputs int) ([]*privacy.OutputCoin, error) {
+	if maxInputs <= 0 || maxInputs > MaxInputCoinNumber {
+		maxInputs = MaxInputCoinNumber
+	}
+	return selector(outCoins, amount, maxInputs)
+}
+
+var coinSelectors = map[string]CoinSelector{
+	CoinSelectionDefault:        coinSelectorFunc(selectDefaultCoins),
+	CoinSelectionMinInputs:      coinSelectorFunc(selectMinInputCoins),
+	CoinSelectionMinChange:      coinSelectorFunc(selectMinChangeCoins),
+	CoinSelectionRandom:         coinSelectorFunc(selectRandomCoins),
+	CoinSelectionBranchAndBound: coinSelectorFunc(selectBranchAndBoundCoins),
+}
+
+// NewCoinSelector returns selector of strategy, empty strategy is CoinSelectionDefault
+func NewCoinSelector(strategy string) (CoinSelector, error) {
+	if strategy == "" {
+		strategy = CoinSelectionDefault
+	}
+	selector, ok := coinSelectors[strategy]
+	if !ok {
+		return nil, fmt.Errorf("coin selection strategy %+v is not supported", strategy)
+	}
+	return selector, nil
+}
+
+// IsValidCoinSelection returns true if strategy is supported, empty strategy is CoinSelectionDefault
+func IsValidCoinSelection(strategy string) bool {
+	_, err := NewCoinSelector(strategy)
+	return err == nil
+}
+
+// MaxInputCoins returns max number of input coins which keeps estimated size of tx under common.MaxTxSize
+func MaxInputCoins(numPayments int, hasPrivacy bool, metadata metadata.Metadata, privacyCustomTokenParams *CustomTokenPrivacyParamTx, limitFee uint64) int {
+	// size of tx grows with number of inputs, find the largest number which fits
+	return sort.Search(MaxInputCoinNumber, func(i int) bool {
+		numInputs := i + 1
+		return EstimateTxSize(NewEstimateTxSizeParam(numInputs, numPayments, hasPrivacy, metadata, privacyCustomTokenParams, limitFee)) > common.MaxTxSize
+	})
+}
+
+/*
+	SelectCoinsWithFee chooses coins which pay amount and fee of tx spending them
+	- fee returns fee of a tx with numInputs input coins and numPayments outputs
+	- a change output is counted when selected coins are more than amount and fee
+	It returns selected coins, their total value and fee
+*/
+func SelectCoinsWithFee(selector CoinSelector, outCoins []*privacy.OutputCoin, amount uint64, numPayments int, maxInputs int, fee func(numInputs int, numPayments int) (uint64, error)) ([]*privacy.OutputCoin, uint64, uint64, error) {
+	target := amount
+	for i := 0; i < maxFeeSelectionRounds; i++ {
+		coins, err := selector.SelectCoins(outCoins, target, maxInputs)
+		if err != nil {
+			return nil, 0, 0, err
+		}
+		total := totalCoinValue(coins)
+		numOutputs := numPayments
+		if total > target {
+			numOutputs++
+		}
+		realFee, err := fee(len(coins), numOutputs)
+		if err != nil {
+			return nil, 0, 0, err
+		}
+		if total >= amount+realFee {
+			return coins, total, realFee, nil
+		}
+		target = amount + realFee
+	}
+	return nil, 0, 0, errors.New("can not choose coins to pay fee")
+}
+
+func totalCoinValue(coins []*privacy.OutputCoin) uint64 {
+	total := uint64(0)
+	for _, coin := range coins {
+		total += coin.CoinDetails.GetValue()
+	}
+	return total
+}
+
+func sortCoinsByValue(outCoins []*privacy.OutputCoin, desc bool) []*privacy.OutputCoin {
+	sorted := make([]*privacy.OutputCoin, len(outCoins))
+	copy(sorted, outCoins)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if desc {
+			return sorted[i].CoinDetails.GetValue() > sorted[j].CoinDetails.GetValue()
+		}
+		return sorted[i].CoinDetails.GetValue() < sorted[j].CoinDetails.GetValue()
+	})
+	return sorted
+}
+
+func notEnoughCoinError(amount uint64, maxInputs int) error {
+	return fmt.Errorf("not enough coin to pay %+v with at most %+v input coins", amount, maxInputs)
+}
+
+// selectDefaultCoins either takes the smallest coins, or a single largest one
+// it takes the largest coins if the smallest coins are more than maxInputs
+func selectDefaultCoins(outCoins []*privacy.OutputCoin, amount uint64, maxInputs int) ([]*privacy.OutputCoin, error) {
+	var outCoinOverLimit *privacy.OutputCoin
+	outCoinsUnderLimit := make([]*privacy.OutputCoin, 0)
+	for _, outCoin := range outCoins {
+		if outCoin.CoinDetails.GetValue() < amount {
+			outCoinsUnderLimit = append(outCoinsUnderLimit, outCoin)
+		} else if outCoinOverLimit == nil || outCoinOverLimit.CoinDetails.GetValue() < outCoin.CoinDetails.GetValue() {
+			outCoinOverLimit = outCoin
+		}
+	}
+	outCoinsUnderLimit = sortCoinsByValue(outCoinsUnderLimit, false)
+
+	result := make([]*privacy.OutputCoin, 0)
+	total := uint64(0)
+	for _, outCoin := range outCoinsUnderLimit {
+		if total >= amount {
+			break
+		}
+		total += outCoin.CoinDetails.GetValue()
+		result = append(result, outCoin)
+	}
+
+	if outCoinOverLimit != nil && (outCoinOverLimit.CoinDetails.GetValue() > 2*amount || total < amount || len(result) > maxInputs) {
+		return []*privacy.OutputCoin{outCoinOverLimit}, nil
+	}
+	if len(result) > maxInputs {
+		return selectMinInputCoins(outCoins, amount, maxInputs)
+	}
+	if total < amount {
+		return nil, notEnoughCoinError(amount, maxInputs)
+	}
+	return result, nil
+}
+
+// selectMinInputCoins takes the largest coins until amount is paid
+func selectMinInputCoins(outCoins []*privacy.OutputCoin, amount uint64, maxInputs int) ([]*privacy.OutputCoin, error) {
+	result := make([]*privacy.OutputCoin, 0)
+	total := uint64(0)
+	for _, outCoin := range sortCoinsByValue(outCoins, true) {
+		if total >= amount && len(result) > 0 {
+			break
+		}
+		if len(result) == maxInputs {
+			return nil, notEnoughCoinError(amount, maxInputs)
+		}
+		total += outCoin.CoinDetails.GetValue()
+		result = append(result, outCoin)
+	}
+	if total < amount {
+		return nil, notEnoughCoinError(amount, maxInputs)
+	}
+	return result, nil
+}
+
+// selectMinChangeCoins takes the largest coins which don't exceed amount, then the smallest coin which pays the rest,
+// the result is compared with the smallest single coin which pays amount
+func selectMinChangeCoins(outCoins []*privacy.OutputCoin, amount uint64, maxInputs int) ([]*privacy.OutputCoin, error) {
+	sorted := sortCoinsByValue(outCoins, true)
+
+	var singleCoin *privacy.OutputCoin
+	for _, outCoin := range sorted {
+		if outCoin.CoinDetails.GetValue() >= amount {
+			singleCoin = outCoin
+		}
+	}
+
+	result := make([]*privacy.OutputCoin, 0)
+	picked := make([]bool, len(sorted))
+	total := uint64(0)
+	for i, outCoin := range sorted {
+		if len(result) == maxInputs-1 || total >= amount {
+			break
+		}
+		if total+outCoin.CoinDetails.GetValue() <= amount {
+			total += outCoin.CoinDetails.GetValue()
+			result = append(result, outCoin)
+			picked[i] = true
+		}
+	}
+	if total < amount {
+		var restCoin *privacy.OutputCoin
+		for i, outCoin := range sorted {
+			if !picked[i] && total+outCoin.CoinDetails.GetValue() >= amount {
+				restCoin = outCoin
+			}
+		}
+		if restCoin == nil {
+			result = nil
+		} else {
+			result = append(result, restCoin)
+			total += restCoin.CoinDetails.GetValue()
+		}
+	}
+
+	if singleCoin != nil && (result == nil || singleCoin.CoinDetails.GetValue()-amount <= total-amount) {
+		return []*privacy.OutputCoin{singleCoin}, nil
+	}
+	if result == nil {
+		return selectMinInputCoins(outCoins, amount, maxInputs)
+	}
+	return result, nil
+}
+
+// selectRandomCoins takes coins in random order until amount is paid,
+// it takes the largest coins if random coins are more than maxInputs
+func selectRandomCoins(outCoins []*privacy.OutputCoin, amount uint64, maxInputs int) ([]*privacy.OutputCoin, error) {
+	shuffled := make([]*privacy.OutputCoin, len(outCoins))
+	copy(shuffled, outCoins)
+	for i := len(shuffled) - 1; i > 0; i-- {
+		j, err := rand.Int(rand.Reader, big.NewInt(int64(i+1)))
+		if err != nil {
+			return nil, err
+		}
+		shuffled[i], shuffled[j.Int64()] = shuffled[j.Int64()], shuffled[i]
+	}
+
+	result := make([]*privacy.OutputCoin, 0)
+	total := uint64(0)
+	for _, outCoin := range shuffled {
+		if total >= amount && len(result) > 0 {
+			break
+		}
+		total += outCoin.CoinDetails.GetValue()
+		result = append(result, outCoin)
+	}
+	if total < amount {
+		return nil, notEnoughCoinError(amount, maxInputs)
+	}
+	if len(result) > maxInputs {
+		return selectMinInputCoins(outCoins, amount, maxInputs)
+	}
+	return result, nil
+}
+
+// selectBranchAndBoundCoins searches depth first for coins whose total is exactly amount, so tx has no change output
+func selectBranchAndBoundCoins(outCoins []*privacy.OutputCoin, amount uint64, maxInputs int) ([]*privacy.OutputCoin, error) {
+	sorted := sortCoinsByValue(outCoins, true)
+	// remaining[i] is total value of sorted[i:]
+	remaining := make([]uint64, len(sorted)+1)
+	for i := len(sorted) - 1; i >= 0; i-- {
+		remaining[i] = remaining[i+1] + sorted[i].CoinDetails.GetValue()
+	}
+
+	tries := 0
+	selected := make([]int, 0)
+	var search func(index int, total uint64) bool
+	search = func(index int, total uint64) bool {
+		tries++
+		if total == amount {
+			return len(selected) > 0
+		}
+		if total > amount || index == len(sorted) || len(selected) == maxInputs || total+remaining[index] < amount || tries > maxBranchAndBoundTries {
+			return false
+		}
+		// include sorted[index]
+		selected = append(selected, index)
+		if search(index+1, total+sorted[index].CoinDetails.GetValue()) {
+			return true
+		}
+		selected = selected[:len(selected)-1]
+		// exclude sorted[index] and coins of the same value, they lead to the same totals
+		next := index + 1
+		for next < len(sorted) && sorted[next].CoinDetails.GetValue() == sorted[index].CoinDetails.GetValue() {
+			next++
+		}
+		return search(next, total)
+	}
+
+	if search(0, 0) {
+		result := make([]*privacy.OutputCoin, 0, len(selected))
+		for _, index := range selected {
+			result = append(result, sorted[index])
+		}
+		return result, nil
+	}
+	return selectMinChangeCoins(outCoins, amount, maxInputs)
+}
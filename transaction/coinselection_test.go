@@ -0,0 +1,99 @@
+package transaction
+
+import (
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/privacy"
+	"github.com/stretchr/testify/assert"
+)
+
+func newCoinsWithValues(values ...uint64) []*privacy.OutputCoin {
+	coins := make([]*privacy.OutputCoin, 0, len(values))
+	for _, value := range values {
+		coin := new(privacy.OutputCoin).Init()
+		coin.CoinDetails.SetValue(value)
+		coins = append(coins, coin)
+	}
+	return coins
+}
+
+func TestCoinSelectionStrategies(t *testing.T) {
+	coins := newCoinsWithValues(1, 2, 3, 5, 8, 40, 100)
+	testCases := []struct {
+		strategy string
+		amount   uint64
+		expected uint64 // total value of chosen coins
+		numCoins int
+	}{
+		{CoinSelectionDefault, 6, 100, 1},
+		{CoinSelectionDefault, 55, 59, 6},
+		{CoinSelectionDefault, 1000, 0, 0},
+		{CoinSelectionMinInputs, 120, 140, 2},
+		{CoinSelectionMinChange, 45, 45, 2},
+		{CoinSelectionMinChange, 39, 40, 1},
+		{CoinSelectionBranchAndBound, 16, 16, 3},
+		{CoinSelectionBranchAndBound, 39, 40, 1},
+	}
+	for _, testCase := range testCases {
+		selector, err := NewCoinSelector(testCase.strategy)
+		assert.Equal(t, nil, err)
+		result, err := selector.SelectCoins(coins, testCase.amount, 0)
+		if testCase.expected == 0 {
+			assert.NotEqual(t, nil, err, testCase.strategy)
+			continue
+		}
+		assert.Equal(t, nil, err, testCase.strategy)
+		assert.Equal(t, testCase.expected, totalCoinValue(result), testCase.strategy)
+		assert.Equal(t, testCase.numCoins, len(result), testCase.strategy)
+	}
+
+	_, err := NewCoinSelector("abc")
+	assert.NotEqual(t, nil, err)
+}
+
+func TestCoinSelectionRespectsMaxInputs(t *testing.T) {
+	values := make([]uint64, 0)
+	for i := 0; i < 300; i++ {
+		values = append(values, 1)
+	}
+	values = append(values, 50, 60)
+	coins := newCoinsWithValues(values...)
+
+	for strategy := range coinSelectors {
+		selector, _ := NewCoinSelector(strategy)
+		result, err := selector.SelectCoins(coins, 200, 100)
+		assert.Equal(t, nil, err, strategy)
+		assert.Equal(t, true, len(result) <= 100, strategy)
+		assert.Equal(t, true, totalCoinValue(result) >= 200, strategy)
+
+		_, err = selector.SelectCoins(coins, 200, 50)
+		assert.NotEqual(t, nil, err, strategy)
+	}
+}
+
+func TestSelectCoinsWithFee(t *testing.T) {
+	coins := newCoinsWithValues(10, 10, 10, 10, 10)
+	selector, _ := NewCoinSelector(CoinSelectionMinInputs)
+	fee := func(numInputs int, numPayments int) (uint64, error) {
+		return uint64(numInputs * 2), nil
+	}
+
+	result, total, realFee, err := SelectCoinsWithFee(selector, coins, 25, 1, 0, fee)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 4, len(result))
+	assert.Equal(t, uint64(40), total)
+	assert.Equal(t, uint64(8), realFee)
+
+	_, _, _, err = SelectCoinsWithFee(selector, coins, 45, 1, 0, fee)
+	assert.NotEqual(t, nil, err)
+}
+
+func TestMaxInputCoins(t *testing.T) {
+	maxInputs := MaxInputCoins(2, true, nil, nil, 0)
+	assert.Equal(t, true, maxInputs > 0 && maxInputs <= MaxInputCoinNumber)
+	if maxInputs < MaxInputCoinNumber {
+		assert.Equal(t, true, EstimateTxSize(NewEstimateTxSizeParam(maxInputs+1, 2, true, nil, nil, 0)) > common.MaxTxSize)
+	}
+	assert.Equal(t, true, EstimateTxSize(NewEstimateTxSizeParam(maxInputs, 2, true, nil, nil, 0)) <= common.MaxTxSize)
+}
@@ -0,0 +1,121 @@
+package transaction
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/privacy"
+	"github.com/incognitochain/incognito-chain/privacy/zeroknowledge/aggregaterange"
+	"github.com/incognitochain/incognito-chain/wallet"
+)
+
+// MaxOutputCoinNumber is max number of output coins of a payment proof, including change coin
+const MaxOutputCoinNumber = aggregaterange.MaxOutputNumber
+
+// PayoutEntry is a payment of amount of token TokenID to PaymentAddress in a payout batch
+type PayoutEntry struct {
+	PaymentAddress string
+	Amount         uint64
+	TokenID        common.Hash
+}
+
+/*
+	PayoutChunk is a group of payout entries which are paid by one tx
+	- Receivers has one payment per address, amounts of entries to the same address are merged
+	- EntryIndices are indices of entries in the batch which are paid by the chunk
+*/
+type PayoutChunk struct {
+	TokenID      common.Hash
+	Receivers    []PayoutEntry
+	EntryIndices []int
+}
+
+// MaxPayoutsPerTx returns max number of receivers of a tx which spends at least one input coin,
+// one output is kept for change and estimated size of tx is under common.MaxTxSize
+func MaxPayoutsPerTx(hasPrivacy bool, isToken bool) int {
+	for numPayments := MaxOutputCoinNumber - 1; numPayments > 0; numPayments-- {
+		var param *EstimateTxSizeParam
+		if isToken {
+			tokenParams := &CustomTokenPrivacyParamTx{
+				Receiver:   make([]*privacy.PaymentInfo, numPayments+1),
+				TokenInput: make([]*privacy.InputCoin, 1),
+			}
+			param = NewEstimateTxSizeParam(1, 1, hasPrivacy, nil, tokenParams, 0)
+		} else {
+			param = NewEstimateTxSizeParam(1, numPayments+1, hasPrivacy, nil, nil, 0)
+		}
+		if EstimateTxSize(param) <= common.MaxTxSize {
+			return numPayments
+		}
+	}
+	return 0
+}
+
+/*
+	PlanPayouts splits entries into the minimum number of txs:
+	- entries are grouped by token, PRV and tokens are kept in order of their first entry
+	- entries of the same token to the same address are merged into one payment
+	- each tx has at most maxPayouts(isToken) receivers
+	It returns error if an entry is invalid
+*/
+func PlanPayouts(entries []PayoutEntry, maxPayouts func(isToken bool) int) ([]PayoutChunk, error) {
+	if len(entries) == 0 {
+		return nil, errors.New("payout entries are empty")
+	}
+
+	type receiver struct {
+		entry        PayoutEntry
+		entryIndices []int
+	}
+	tokenIDs := []common.Hash{}
+	receivers := make(map[common.Hash][]*receiver)
+	receiverByAddress := make(map[common.Hash]map[string]*receiver)
+	for i, entry := range entries {
+		if entry.Amount == 0 {
+			return nil, fmt.Errorf("amount of payout entry %+v is zero", i)
+		}
+		keyWallet, err := wallet.Base58CheckDeserialize(entry.PaymentAddress)
+		if err != nil || len(keyWallet.KeySet.PaymentAddress.Pk) == 0 {
+			return nil, fmt.Errorf("payment address of payout entry %+v is invalid", i)
+		}
+		if _, ok := receiverByAddress[entry.TokenID]; !ok {
+			tokenIDs = append(tokenIDs, entry.TokenID)
+			receiverByAddress[entry.TokenID] = make(map[string]*receiver)
+		}
+		r, ok := receiverByAddress[entry.TokenID][entry.PaymentAddress]
+		if !ok {
+			r = &receiver{entry: PayoutEntry{PaymentAddress: entry.PaymentAddress, TokenID: entry.TokenID}}
+			receiverByAddress[entry.TokenID][entry.PaymentAddress] = r
+			receivers[entry.TokenID] = append(receivers[entry.TokenID], r)
+		}
+		if r.entry.Amount > math.MaxUint64-entry.Amount {
+			return nil, fmt.Errorf("total amount to %+v is out of range", entry.PaymentAddress)
+		}
+		r.entry.Amount += entry.Amount
+		r.entryIndices = append(r.entryIndices, i)
+	}
+
+	chunks := []PayoutChunk{}
+	for _, tokenID := range tokenIDs {
+		maxReceivers := maxPayouts(!tokenID.IsEqual(&common.PRVCoinID))
+		if maxReceivers <= 0 {
+			return nil, errors.New("a tx can not pay any receiver")
+		}
+		tokenReceivers := receivers[tokenID]
+		for start := 0; start < len(tokenReceivers); start += maxReceivers {
+			end := start + maxReceivers
+			if end > len(tokenReceivers) {
+				end = len(tokenReceivers)
+			}
+			chunk := PayoutChunk{TokenID: tokenID}
+			for _, r := range tokenReceivers[start:end] {
+				chunk.Receivers = append(chunk.Receivers, r.entry)
+				chunk.EntryIndices = append(chunk.EntryIndices, r.entryIndices...)
+			}
+			chunks = append(chunks, chunk)
+		}
+	}
+	return chunks, nil
+}
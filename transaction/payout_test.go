@@ -0,0 +1,84 @@
+package transaction
+
+import (
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/wallet"
+	"github.com/stretchr/testify/assert"
+)
+
+func newPayoutAddresses(t *testing.T, n int) []string {
+	masterKey, err := wallet.NewMasterKey([]byte("payout test seed"))
+	assert.Equal(t, nil, err)
+	addresses := make([]string, 0, n)
+	for i := 0; i < n; i++ {
+		childKey, err := masterKey.NewChildKey(uint32(i))
+		assert.Equal(t, nil, err)
+		addresses = append(addresses, childKey.Base58CheckSerialize(wallet.PaymentAddressType))
+	}
+	return addresses
+}
+
+func TestMaxPayoutsPerTx(t *testing.T) {
+	for _, isToken := range []bool{false, true} {
+		maxPayouts := MaxPayoutsPerTx(true, isToken)
+		assert.Equal(t, true, maxPayouts > 0 && maxPayouts < MaxOutputCoinNumber)
+	}
+}
+
+func TestPlanPayouts(t *testing.T) {
+	addresses := newPayoutAddresses(t, 10)
+	tokenID := common.Hash{1}
+	entries := []PayoutEntry{}
+	for _, address := range addresses {
+		entries = append(entries, PayoutEntry{PaymentAddress: address, Amount: 10, TokenID: common.PRVCoinID})
+		entries = append(entries, PayoutEntry{PaymentAddress: address, Amount: 20, TokenID: tokenID})
+	}
+	// the same address is paid twice
+	entries = append(entries, PayoutEntry{PaymentAddress: addresses[0], Amount: 5, TokenID: common.PRVCoinID})
+
+	chunks, err := PlanPayouts(entries, func(isToken bool) int {
+		if isToken {
+			return 3
+		}
+		return 4
+	})
+	assert.Equal(t, nil, err)
+	// 10 PRV receivers in 3 txs, 10 token receivers in 4 txs
+	assert.Equal(t, 7, len(chunks))
+	paidEntries := make(map[int]bool)
+	for i, chunk := range chunks {
+		if i < 3 {
+			assert.Equal(t, common.PRVCoinID, chunk.TokenID)
+		} else {
+			assert.Equal(t, tokenID, chunk.TokenID)
+		}
+		receivers := make(map[string]bool)
+		for _, receiver := range chunk.Receivers {
+			assert.Equal(t, false, receivers[receiver.PaymentAddress])
+			receivers[receiver.PaymentAddress] = true
+		}
+		for _, index := range chunk.EntryIndices {
+			assert.Equal(t, false, paidEntries[index])
+			paidEntries[index] = true
+		}
+	}
+	assert.Equal(t, len(entries), len(paidEntries))
+	assert.Equal(t, uint64(15), chunks[0].Receivers[0].Amount)
+	assert.Equal(t, []int{0, len(entries) - 1, 2, 4, 6}, chunks[0].EntryIndices)
+}
+
+func TestPlanPayoutsWithInvalidEntries(t *testing.T) {
+	addresses := newPayoutAddresses(t, 1)
+	maxPayouts := func(isToken bool) int { return MaxPayoutsPerTx(true, isToken) }
+
+	_, err := PlanPayouts([]PayoutEntry{}, maxPayouts)
+	assert.NotEqual(t, nil, err)
+
+	_, err = PlanPayouts([]PayoutEntry{{PaymentAddress: addresses[0], Amount: 0, TokenID: common.PRVCoinID}}, maxPayouts)
+	assert.NotEqual(t, nil, err)
+
+	_, err = PlanPayouts([]PayoutEntry{{PaymentAddress: "abc", Amount: 1, TokenID: common.PRVCoinID}}, maxPayouts)
+	assert.NotEqual(t, nil, err)
+}
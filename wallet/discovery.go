@@ -0,0 +1,241 @@
+package wallet
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/incognitochain/incognito-chain/common"
+)
+
+// DefaultDiscoveryGapLimit is number of consecutive unused child indices of a shard
+// after which discovery stops scanning that shard
+const DefaultDiscoveryGapLimit = 20
+
+// AccountUsageChecker returns true if payment address public key pubKey of shardID has any output coin on chain
+type AccountUsageChecker func(pubKey []byte, shardID byte) (bool, error)
+
+type DiscoveredAccount struct {
+	ChildIndex     uint32
+	Name           string
+	ShardID        byte
+	PaymentAddress string
+	// IsExisted is true if account is already in wallet before discovery
+	IsExisted bool
+}
+
+type AccountDiscoveryProgress struct {
+	IsRunning    bool
+	GapLimit     uint32
+	ShardIDs     []int
+	ScannedIndex uint32 // number of child indices which are scanned
+	Accounts     []DiscoveredAccount
+	Error        string
+}
+
+// InitFromMnemonic restores wallet from mnemonic, passPhrase must be the one which wallet was initialized with
+// because it is also password of seed. Wallet is restored without any account, use DiscoverAccounts to find used accounts
+func (wallet *Wallet) InitFromMnemonic(mnemonic string, passPhrase string, name string) error {
+	if name == "" {
+		return NewWalletError(EmptyWalletNameErr, nil)
+	}
+
+	mnemonicGen := MnemonicGenerator{}
+	entropy, err := mnemonicGen.mnemonicToByteArray(mnemonic, true)
+	if err != nil {
+		return NewWalletError(MnemonicInvalidError, err)
+	}
+	keystore, err := newKeystoreKey(passPhrase, wallet.kdfParams())
+	if err != nil {
+		return NewWalletError(UnexpectedErr, err)
+	}
+
+	wallet.Name = name
+	wallet.Entropy = entropy
+	wallet.Mnemonic = mnemonic
+	wallet.Seed = mnemonicGen.NewSeed(mnemonic, passPhrase)
+	wallet.keystore = keystore
+
+	masterKey, err := NewMasterKey(wallet.Seed)
+	if err != nil {
+		return err
+	}
+	wallet.MasterAccount = AccountWallet{
+		Key:   *masterKey,
+		Child: make([]AccountWallet, 0),
+		Name:  "master",
+	}
+	return nil
+}
+
+/*
+	DiscoverAccounts derives child keys of master account from index 0 and checks them on chain by isUsed,
+	scanning stops when every shard in shardIDs has gapLimit consecutive unused child indices.
+	Used accounts which are not in wallet yet are added to wallet and wallet is saved.
+	- only accounts of shardIDs are scanned, a node can only find accounts of shards which it has data
+	- progress is called after each child index is scanned, it can be nil
+	It returns all used accounts of shardIDs
+*/
+func (wallet *Wallet) DiscoverAccounts(gapLimit uint32, shardIDs []byte, isUsed AccountUsageChecker, progress func(scannedIndex uint32, accounts []DiscoveredAccount)) ([]DiscoveredAccount, error) {
+	if gapLimit == 0 {
+		return nil, NewWalletError(AccountDiscoveryErr, errors.New("gap limit must be greater than 0"))
+	}
+	if len(shardIDs) == 0 {
+		return nil, NewWalletError(AccountDiscoveryErr, errors.New("shard list is empty"))
+	}
+	gaps := make(map[byte]uint32)
+	for _, shardID := range shardIDs {
+		if int(shardID) >= common.MaxShardNumber {
+			return nil, NewWalletError(AccountDiscoveryErr, fmt.Errorf("shard %+v is invalid", shardID))
+		}
+		gaps[shardID] = 0
+	}
+
+	result := []DiscoveredAccount{}
+	newAccounts := []AccountWallet{}
+	for index := uint32(0); !discoveryDone(gaps, gapLimit); index++ {
+		childKey, err := wallet.MasterAccount.Key.NewChildKey(index)
+		if err != nil {
+			return nil, NewWalletError(NewChildKeyError, err)
+		}
+		pubKey := childKey.KeySet.PaymentAddress.Pk
+		shardID := common.GetShardIDFromLastByte(pubKey[len(pubKey)-1])
+		if _, ok := gaps[shardID]; ok {
+			used, err := isUsed(pubKey, shardID)
+			if err != nil {
+				return nil, NewWalletError(AccountDiscoveryErr, err)
+			}
+			if !used {
+				gaps[shardID]++
+			} else {
+				gaps[shardID] = 0
+				account := DiscoveredAccount{
+					ChildIndex:     index,
+					ShardID:        shardID,
+					PaymentAddress: childKey.Base58CheckSerialize(PaymentAddressType),
+				}
+				if existed := wallet.findAccountByPublicKey(pubKey); existed != nil {
+					account.Name = existed.Name
+					account.IsExisted = true
+				} else {
+					account.Name = wallet.newDiscoveredAccountName(index, newAccounts)
+					newAccounts = append(newAccounts, AccountWallet{
+						Key:   *childKey,
+						Child: make([]AccountWallet, 0),
+						Name:  account.Name,
+					})
+				}
+				result = append(result, account)
+			}
+		}
+		if progress != nil {
+			progress(index+1, result)
+		}
+	}
+
+	if len(newAccounts) > 0 {
+		// accounts are kept in wallet only if they are saved, so memory and wallet file don't diverge
+		children := wallet.MasterAccount.Child
+		wallet.MasterAccount.Child = append(append([]AccountWallet{}, children...), newAccounts...)
+		if err := wallet.Save(""); err != nil {
+			wallet.MasterAccount.Child = children
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+func discoveryDone(gaps map[byte]uint32, gapLimit uint32) bool {
+	for _, gap := range gaps {
+		if gap < gapLimit {
+			return false
+		}
+	}
+	return true
+}
+
+func (wallet *Wallet) findAccountByPublicKey(pubKey []byte) *AccountWallet {
+	for i := range wallet.MasterAccount.Child {
+		if bytes.Equal(wallet.MasterAccount.Child[i].Key.KeySet.PaymentAddress.Pk, pubKey) {
+			return &wallet.MasterAccount.Child[i]
+		}
+	}
+	return nil
+}
+
+// newDiscoveredAccountName returns "AccountWallet <index>", with a suffix if the name is already used
+func (wallet *Wallet) newDiscoveredAccountName(index uint32, newAccounts []AccountWallet) string {
+	isUsed := func(name string) bool {
+		for _, account := range newAccounts {
+			if account.Name == name {
+				return true
+			}
+		}
+		for _, account := range wallet.MasterAccount.Child {
+			if account.Name == name {
+				return true
+			}
+		}
+		return false
+	}
+	name := fmt.Sprintf("AccountWallet %d", index)
+	for i := 1; isUsed(name); i++ {
+		name = fmt.Sprintf("AccountWallet %d-%d", index, i)
+	}
+	return name
+}
+
+// AccountDiscovery runs DiscoverAccounts in background and keeps its progress, only one discovery runs at a time
+type AccountDiscovery struct {
+	lock     sync.RWMutex
+	progress AccountDiscoveryProgress
+}
+
+// Start runs discovery on wallet in a new goroutine, it returns error if a discovery is running
+func (discovery *AccountDiscovery) Start(wallet *Wallet, gapLimit uint32, shardIDs []byte, isUsed AccountUsageChecker) error {
+	discovery.lock.Lock()
+	defer discovery.lock.Unlock()
+	if discovery.progress.IsRunning {
+		return NewWalletError(AccountDiscoveryRunningErr, nil)
+	}
+	discovery.progress = AccountDiscoveryProgress{
+		IsRunning: true,
+		GapLimit:  gapLimit,
+		ShardIDs:  make([]int, 0, len(shardIDs)),
+		Accounts:  []DiscoveredAccount{},
+	}
+	for _, shardID := range shardIDs {
+		discovery.progress.ShardIDs = append(discovery.progress.ShardIDs, int(shardID))
+	}
+
+	go func() {
+		accounts, err := wallet.DiscoverAccounts(gapLimit, shardIDs, isUsed, func(scannedIndex uint32, accounts []DiscoveredAccount) {
+			discovery.lock.Lock()
+			discovery.progress.ScannedIndex = scannedIndex
+			discovery.progress.Accounts = append([]DiscoveredAccount{}, accounts...)
+			discovery.lock.Unlock()
+		})
+		discovery.lock.Lock()
+		defer discovery.lock.Unlock()
+		discovery.progress.IsRunning = false
+		if err != nil {
+			Logger.log.Errorf("Discover accounts of wallet %+v error %+v", wallet.Name, err)
+			discovery.progress.Error = err.Error()
+			return
+		}
+		discovery.progress.Accounts = accounts
+		Logger.log.Infof("Discover accounts of wallet %+v found %+v used accounts", wallet.Name, len(accounts))
+	}()
+	return nil
+}
+
+// Progress returns a copy of progress of the last discovery
+func (discovery *AccountDiscovery) Progress() AccountDiscoveryProgress {
+	discovery.lock.RLock()
+	defer discovery.lock.RUnlock()
+	progress := discovery.progress
+	progress.ShardIDs = append([]int{}, discovery.progress.ShardIDs...)
+	progress.Accounts = append([]DiscoveredAccount{}, discovery.progress.Accounts...)
+	return progress
+}
@@ -0,0 +1,132 @@
+package wallet
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/stretchr/testify/require"
+)
+
+/*
+	Unit test for InitFromMnemonic and DiscoverAccounts function
+*/
+
+// newDiscoveryTestWallet returns a wallet with numOfAccount accounts which is saved in a temp dir of test
+func newDiscoveryTestWallet(t *testing.T, passPhrase string, numOfAccount uint32) *Wallet {
+	dataDir := t.TempDir()
+	testWallet := new(Wallet)
+	testWallet.SetConfig(&WalletConfig{
+		DataDir:  dataDir,
+		DataFile: "wallet",
+		DataPath: filepath.Join(dataDir, "wallet"),
+	})
+	testWallet.Init(passPhrase, numOfAccount, "Wallet")
+	return testWallet
+}
+
+func allShardIDs() []byte {
+	shardIDs := []byte{}
+	for shardID := 0; shardID < common.MaxShardNumber; shardID++ {
+		shardIDs = append(shardIDs, byte(shardID))
+	}
+	return shardIDs
+}
+
+func TestWalletInitFromMnemonic(t *testing.T) {
+	passPhrase := "123"
+	testWallet := newDiscoveryTestWallet(t, passPhrase, 1)
+
+	restoredWallet := new(Wallet)
+	restoredWallet.SetConfig(testWallet.config)
+	err := restoredWallet.InitFromMnemonic(testWallet.Mnemonic, passPhrase, "Wallet")
+	require.NoError(t, err)
+	require.Equal(t, testWallet.Entropy, restoredWallet.Entropy)
+	require.Equal(t, testWallet.Seed, restoredWallet.Seed)
+	require.Equal(t, testWallet.MasterAccount.Key, restoredWallet.MasterAccount.Key)
+	require.Len(t, restoredWallet.MasterAccount.Child, 0)
+	require.True(t, restoredWallet.CheckPassPhrase(passPhrase))
+
+	err = restoredWallet.InitFromMnemonic("abc abc", passPhrase, "Wallet")
+	require.Error(t, err)
+	require.Equal(t, ErrCodeMessage[MnemonicInvalidError].code, err.(*WalletError).GetCode())
+}
+
+func TestWalletDiscoverAccounts(t *testing.T) {
+	passPhrase := "123"
+	testWallet := newDiscoveryTestWallet(t, passPhrase, 1)
+
+	// child 0 is in wallet, children 3 and 30 are used on chain
+	usedIndices := map[uint32]bool{0: true, 3: true, 30: true}
+	usedPubKeys := make(map[string]bool)
+	for index := range usedIndices {
+		childKey, err := testWallet.MasterAccount.Key.NewChildKey(index)
+		require.NoError(t, err)
+		usedPubKeys[string(childKey.KeySet.PaymentAddress.Pk)] = true
+	}
+	isUsed := func(pubKey []byte, shardID byte) (bool, error) {
+		return usedPubKeys[string(pubKey)], nil
+	}
+
+	lastScannedIndex := uint32(0)
+	accounts, err := testWallet.DiscoverAccounts(DefaultDiscoveryGapLimit, allShardIDs(), isUsed, func(scannedIndex uint32, accounts []DiscoveredAccount) {
+		lastScannedIndex = scannedIndex
+	})
+	require.NoError(t, err)
+	require.Len(t, accounts, 3)
+	require.True(t, accounts[0].IsExisted)
+	require.Equal(t, uint32(3), accounts[1].ChildIndex)
+	require.Equal(t, "AccountWallet 3", accounts[1].Name)
+	require.Equal(t, uint32(30), accounts[2].ChildIndex)
+	require.Len(t, testWallet.MasterAccount.Child, 3)
+	require.True(t, lastScannedIndex > 30)
+
+	for _, account := range accounts {
+		keyWallet, err := Base58CheckDeserialize(account.PaymentAddress)
+		require.NoError(t, err)
+		pubKey := keyWallet.KeySet.PaymentAddress.Pk
+		require.Equal(t, common.GetShardIDFromLastByte(pubKey[len(pubKey)-1]), account.ShardID)
+	}
+
+	// discovered accounts are saved in wallet file
+	loadedWallet := new(Wallet)
+	loadedWallet.SetConfig(testWallet.config)
+	require.NoError(t, loadedWallet.LoadWallet(passPhrase))
+	require.Len(t, loadedWallet.MasterAccount.Child, 3)
+
+	// accounts are not added again
+	accounts, err = testWallet.DiscoverAccounts(DefaultDiscoveryGapLimit, allShardIDs(), isUsed, nil)
+	require.NoError(t, err)
+	require.Len(t, accounts, 3)
+	require.Len(t, testWallet.MasterAccount.Child, 3)
+}
+
+func TestWalletDiscoverAccountsWithSaveError(t *testing.T) {
+	testWallet := newDiscoveryTestWallet(t, "123", 1)
+	childKey, err := testWallet.MasterAccount.Key.NewChildKey(3)
+	require.NoError(t, err)
+	isUsed := func(pubKey []byte, shardID byte) (bool, error) {
+		return string(pubKey) == string(childKey.KeySet.PaymentAddress.Pk), nil
+	}
+
+	// wallet file can't be written, discovered accounts must not be kept in memory
+	testWallet.config.DataPath = filepath.Join(testWallet.config.DataDir, "not-existed", "wallet")
+	_, err = testWallet.DiscoverAccounts(DefaultDiscoveryGapLimit, allShardIDs(), isUsed, nil)
+	require.Error(t, err)
+	require.Len(t, testWallet.MasterAccount.Child, 1)
+}
+
+func TestWalletDiscoverAccountsWithInvalidParams(t *testing.T) {
+	testWallet := newDiscoveryTestWallet(t, "123", 1)
+	isUsed := func(pubKey []byte, shardID byte) (bool, error) {
+		return false, nil
+	}
+
+	_, err := testWallet.DiscoverAccounts(0, []byte{0}, isUsed, nil)
+	require.Error(t, err)
+	require.Equal(t, ErrCodeMessage[AccountDiscoveryErr].code, err.(*WalletError).GetCode())
+
+	_, err = testWallet.DiscoverAccounts(DefaultDiscoveryGapLimit, []byte{}, isUsed, nil)
+	require.Error(t, err)
+	require.Equal(t, ErrCodeMessage[AccountDiscoveryErr].code, err.(*WalletError).GetCode())
+}
@@ -26,6 +26,11 @@ const (
 	NewMnemonicError
 	MnemonicInvalidError
 	InvalidSeserializedKey
+	InvalidKeystoreErr
+	WatchOnlyKeyMismatchErr
+	WatchOnlyAccountErr
+	AccountDiscoveryErr
+	AccountDiscoveryRunningErr
 )
 
 var ErrCodeMessage = map[int]struct {
@@ -52,7 +57,12 @@ var ErrCodeMessage = map[int]struct {
 	NewEntropyError:       {-1014, "Can not create entropy"},
 	NewMnemonicError:      {-1015, "Can not create mnemonic"},
 	MnemonicInvalidError:  {-1016, "Mnemonic is invalid"},
-	InvalidSeserializedKey:  {-1016, "Serialized key is invalid"},
+	InvalidSeserializedKey:     {-1016, "Serialized key is invalid"},
+	InvalidKeystoreErr:         {-1017, "Keystore is invalid"},
+	WatchOnlyKeyMismatchErr:    {-1018, "Readonly key does not belong to payment address"},
+	WatchOnlyAccountErr:        {-1019, "Account is watch-only"},
+	AccountDiscoveryErr:        {-1020, "Can not discover accounts"},
+	AccountDiscoveryRunningErr: {-1021, "Account discovery is running"},
 }
 
 type WalletError struct {
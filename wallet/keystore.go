@@ -0,0 +1,230 @@
+package wallet
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	keystoreVersion = 2
+
+	KDFArgon2id = "argon2id"
+	KDFScrypt   = "scrypt"
+
+	keystoreCipher  = "aes-256-gcm"
+	keystoreSaltLen = 32 // bytes
+
+	// kdf params are read from wallet file, bounds stop a crafted file from exhausting memory or cpu of node
+	maxArgon2idTime   = 64
+	maxArgon2idMemory = 1024 * 1024 // KiB, 1 GiB
+	maxScryptN        = 1 << 20
+	maxScryptR        = 32
+	maxScryptP        = 16
+	maxScryptMemory   = 1024 * 1024 * 1024 // bytes, scrypt uses 128 * N * r bytes
+)
+
+/*
+	KeystoreKDFParams is key derivation function and its parameters which derive encryption key of wallet file from passphrase
+	- argon2id uses Time, Memory (in KiB) and Threads
+	- scrypt uses N, R and P
+	Params are stored in wallet file so a wallet can always be opened with params it was saved with
+*/
+type KeystoreKDFParams struct {
+	Algorithm string
+	Salt      []byte `json:",omitempty"`
+	KeyLen    uint32
+
+	Time    uint32 `json:",omitempty"`
+	Memory  uint32 `json:",omitempty"`
+	Threads uint8  `json:",omitempty"`
+
+	N int `json:",omitempty"`
+	R int `json:",omitempty"`
+	P int `json:",omitempty"`
+}
+
+// DefaultKeystoreKDFParams is argon2id with parameters recommended by RFC 9106 for memory constrained environments
+func DefaultKeystoreKDFParams() KeystoreKDFParams {
+	return KeystoreKDFParams{
+		Algorithm: KDFArgon2id,
+		KeyLen:    common.AESKeySize,
+		Time:      3,
+		Memory:    64 * 1024,
+		Threads:   4,
+	}
+}
+
+func (params KeystoreKDFParams) validate() error {
+	if params.KeyLen != common.AESKeySize {
+		return fmt.Errorf("key length must be %+v", common.AESKeySize)
+	}
+	switch params.Algorithm {
+	case KDFArgon2id:
+		if params.Time == 0 || params.Memory == 0 || params.Threads == 0 {
+			return errors.New("argon2id params must be greater than 0")
+		}
+		if params.Time > maxArgon2idTime || params.Memory > maxArgon2idMemory {
+			return fmt.Errorf("argon2id time must not exceed %+v and memory must not exceed %+v KiB", maxArgon2idTime, maxArgon2idMemory)
+		}
+	case KDFScrypt:
+		if params.N <= 1 || params.N&(params.N-1) != 0 || params.R <= 0 || params.P <= 0 {
+			return errors.New("scrypt N must be a power of 2 greater than 1, r and p must be greater than 0")
+		}
+		if params.N > maxScryptN || params.R > maxScryptR || params.P > maxScryptP || 128*params.N*params.R > maxScryptMemory {
+			return fmt.Errorf("scrypt N must not exceed %+v, r %+v, p %+v and memory %+v bytes", maxScryptN, maxScryptR, maxScryptP, maxScryptMemory)
+		}
+	default:
+		return fmt.Errorf("unsupported kdf %+v", params.Algorithm)
+	}
+	return nil
+}
+
+func (params KeystoreKDFParams) deriveKey(passPhrase string) ([]byte, error) {
+	if err := params.validate(); err != nil {
+		return nil, err
+	}
+	if len(params.Salt) == 0 {
+		return nil, errors.New("kdf salt is empty")
+	}
+	switch params.Algorithm {
+	case KDFArgon2id:
+		return argon2.IDKey([]byte(passPhrase), params.Salt, params.Time, params.Memory, params.Threads, params.KeyLen), nil
+	default:
+		return scrypt.Key([]byte(passPhrase), params.Salt, params.N, params.R, params.P, int(params.KeyLen))
+	}
+}
+
+// keystoreKey is encryption key of wallet file, it is kept in memory instead of passphrase
+type keystoreKey struct {
+	kdf KeystoreKDFParams
+	key []byte
+}
+
+// newKeystoreKey derive a new key from passPhrase with a new random salt
+func newKeystoreKey(passPhrase string, params KeystoreKDFParams) (*keystoreKey, error) {
+	params.Salt = make([]byte, keystoreSaltLen)
+	if _, err := rand.Read(params.Salt); err != nil {
+		return nil, err
+	}
+	key, err := params.deriveKey(passPhrase)
+	if err != nil {
+		return nil, err
+	}
+	return &keystoreKey{kdf: params, key: key}, nil
+}
+
+// match check passPhrase by deriving key again with the same salt
+func (keystore *keystoreKey) match(passPhrase string) bool {
+	key, err := keystore.kdf.deriveKey(passPhrase)
+	if err != nil {
+		return false
+	}
+	return subtle.ConstantTimeCompare(key, keystore.key) == 1
+}
+
+/*
+	keystoreFile is format of wallet file from version 2:
+	- wallet data is encrypted by AES-256-GCM with key derived by KDF
+	- header (version, wallet name, kdf and cipher params) is authenticated as associated data,
+	  so params can't be downgraded without knowing the passphrase
+	Wallet file of version 1 is "hex(salt)-hex(ciphertext)" of encryptByPassPhrase, it is upgraded when loaded
+*/
+type keystoreFile struct {
+	keystoreHeader
+	CipherText []byte
+}
+
+type keystoreHeader struct {
+	Version int
+	Name    string
+	KDF     KeystoreKDFParams
+	Cipher  string
+	Nonce   []byte
+}
+
+func (header keystoreHeader) associatedData() ([]byte, error) {
+	return json.Marshal(header)
+}
+
+func newKeystoreGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// encryptKeystore encrypt wallet data with a new random nonce
+func encryptKeystore(keystore *keystoreKey, name string, plaintext []byte) ([]byte, error) {
+	if len(plaintext) == 0 {
+		return nil, NewWalletError(InvalidPlaintextErr, nil)
+	}
+	gcm, err := newKeystoreGCM(keystore.key)
+	if err != nil {
+		return nil, err
+	}
+	file := keystoreFile{
+		keystoreHeader: keystoreHeader{
+			Version: keystoreVersion,
+			Name:    name,
+			KDF:     keystore.kdf,
+			Cipher:  keystoreCipher,
+			Nonce:   make([]byte, gcm.NonceSize()),
+		},
+	}
+	if _, err := rand.Read(file.Nonce); err != nil {
+		return nil, err
+	}
+	associatedData, err := file.associatedData()
+	if err != nil {
+		return nil, err
+	}
+	file.CipherText = gcm.Seal(nil, file.Nonce, plaintext, associatedData)
+	return json.MarshalIndent(file, "", "\t")
+}
+
+// decryptKeystore decrypt wallet file of version 2 and return wallet data and key to save it again
+func decryptKeystore(passPhrase string, data []byte) ([]byte, *keystoreKey, error) {
+	file := keystoreFile{}
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, nil, NewWalletError(InvalidKeystoreErr, err)
+	}
+	if file.Version != keystoreVersion || file.Cipher != keystoreCipher {
+		return nil, nil, NewWalletError(InvalidKeystoreErr, fmt.Errorf("unsupported keystore version %+v with cipher %+v", file.Version, file.Cipher))
+	}
+	key, err := file.KDF.deriveKey(passPhrase)
+	if err != nil {
+		return nil, nil, NewWalletError(InvalidKeystoreErr, err)
+	}
+	gcm, err := newKeystoreGCM(key)
+	if err != nil {
+		return nil, nil, NewWalletError(InvalidKeystoreErr, err)
+	}
+	if len(file.Nonce) != gcm.NonceSize() {
+		return nil, nil, NewWalletError(InvalidKeystoreErr, errors.New("wrong nonce size"))
+	}
+	associatedData, err := file.associatedData()
+	if err != nil {
+		return nil, nil, NewWalletError(InvalidKeystoreErr, err)
+	}
+	plaintext, err := gcm.Open(nil, file.Nonce, file.CipherText, associatedData)
+	if err != nil {
+		// authentication fails with wrong passphrase or modified file
+		return nil, nil, NewWalletError(WrongPassphraseErr, err)
+	}
+	return plaintext, &keystoreKey{kdf: file.KDF, key: key}, nil
+}
+
+// isKeystoreFile check if wallet file has json format of version 2
+func isKeystoreFile(data []byte) bool {
+	return len(data) > 0 && data[0] == '{'
+}
@@ -0,0 +1,134 @@
+package wallet
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+/*
+	Unit test for keystore file
+*/
+
+// newKeystoreTestWallet returns a wallet which is saved in a temp dir of test
+func newKeystoreTestWallet(t *testing.T) *Wallet {
+	dataDir := t.TempDir()
+	testWallet := new(Wallet)
+	testWallet.SetConfig(&WalletConfig{
+		DataDir:  dataDir,
+		DataFile: "wallet",
+		DataPath: filepath.Join(dataDir, "wallet"),
+	})
+	return testWallet
+}
+
+func TestKeystoreLoadLegacyWallet(t *testing.T) {
+	passPhrase := "123"
+	wallet := newKeystoreTestWallet(t)
+	wallet.Init(passPhrase, 2, "Wallet")
+
+	// write wallet file of version 1
+	data, _ := json.Marshal(*wallet)
+	cipherText, err := encryptByPassPhrase(passPhrase, data)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, nil, ioutil.WriteFile(wallet.config.DataPath, []byte(cipherText), 0600))
+
+	wallet2 := new(Wallet)
+	wallet2.SetConfig(wallet.config)
+	err = wallet2.LoadWallet(passPhrase)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, wallet.MasterAccount, wallet2.MasterAccount)
+
+	// file is upgraded
+	fileData, _ := ioutil.ReadFile(wallet.config.DataPath)
+	assert.Equal(t, true, isKeystoreFile(fileData))
+	wallet3 := new(Wallet)
+	wallet3.SetConfig(wallet.config)
+	assert.Equal(t, nil, wallet3.LoadWallet(passPhrase))
+	assert.Equal(t, wallet.MasterAccount, wallet3.MasterAccount)
+}
+
+func TestKeystoreChangePassPhrase(t *testing.T) {
+	passPhrase := "123"
+	newPassPhrase := "456"
+	wallet := newKeystoreTestWallet(t)
+	wallet.Init(passPhrase, 1, "Wallet")
+	wallet.Save(passPhrase)
+
+	assert.Equal(t, ErrCodeMessage[WrongPassphraseErr].code, wallet.ChangePassPhrase(newPassPhrase, newPassPhrase).(*WalletError).GetCode())
+	assert.Equal(t, nil, wallet.ChangePassPhrase(passPhrase, newPassPhrase))
+	assert.Equal(t, false, wallet.CheckPassPhrase(passPhrase))
+
+	wallet2 := new(Wallet)
+	wallet2.SetConfig(wallet.config)
+	assert.Equal(t, ErrCodeMessage[WrongPassphraseErr].code, wallet2.LoadWallet(passPhrase).(*WalletError).GetCode())
+	assert.Equal(t, nil, wallet2.LoadWallet(newPassPhrase))
+	assert.Equal(t, wallet.Seed, wallet2.Seed)
+}
+
+func TestKeystoreWithModifiedHeader(t *testing.T) {
+	passPhrase := "123"
+	wallet := newKeystoreTestWallet(t)
+	wallet.Init(passPhrase, 1, "Wallet")
+	wallet.Save(passPhrase)
+
+	fileData, _ := ioutil.ReadFile(wallet.config.DataPath)
+	file := keystoreFile{}
+	assert.Equal(t, nil, json.Unmarshal(fileData, &file))
+	file.Name = "Other wallet"
+	fileData, _ = json.Marshal(file)
+	_, _, err := decryptKeystore(passPhrase, fileData)
+	assert.Equal(t, ErrCodeMessage[WrongPassphraseErr].code, err.(*WalletError).GetCode())
+}
+
+func TestKeystoreScrypt(t *testing.T) {
+	passPhrase := "123"
+	params := KeystoreKDFParams{Algorithm: KDFScrypt, KeyLen: 32, N: 1 << 12, R: 8, P: 1}
+	keystore, err := newKeystoreKey(passPhrase, params)
+	assert.Equal(t, nil, err)
+
+	fileData, err := encryptKeystore(keystore, "Wallet", []byte("data"))
+	assert.Equal(t, nil, err)
+	plaintext, keystore2, err := decryptKeystore(passPhrase, fileData)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []byte("data"), plaintext)
+	assert.Equal(t, keystore, keystore2)
+
+	params.N = 1000
+	_, err = newKeystoreKey(passPhrase, params)
+	assert.NotEqual(t, nil, err)
+}
+
+func TestKeystoreKDFParamsBounds(t *testing.T) {
+	params := DefaultKeystoreKDFParams()
+	assert.Equal(t, nil, params.validate())
+	params.Memory = maxArgon2idMemory + 1
+	assert.NotEqual(t, nil, params.validate())
+	params = DefaultKeystoreKDFParams()
+	params.Time = maxArgon2idTime + 1
+	assert.NotEqual(t, nil, params.validate())
+
+	params = KeystoreKDFParams{Algorithm: KDFScrypt, KeyLen: 32, N: maxScryptN, R: 8, P: 1}
+	assert.Equal(t, nil, params.validate())
+	params.N = maxScryptN << 1
+	assert.NotEqual(t, nil, params.validate())
+	params = KeystoreKDFParams{Algorithm: KDFScrypt, KeyLen: 32, N: maxScryptN, R: maxScryptR, P: 1}
+	assert.NotEqual(t, nil, params.validate())
+	params = KeystoreKDFParams{Algorithm: KDFScrypt, KeyLen: 32, N: 1 << 12, R: 8, P: maxScryptP + 1}
+	assert.NotEqual(t, nil, params.validate())
+
+	// crafted wallet file is rejected before key is derived
+	keystore, err := newKeystoreKey("123", KeystoreKDFParams{Algorithm: KDFScrypt, KeyLen: 32, N: 1 << 12, R: 8, P: 1})
+	assert.Equal(t, nil, err)
+	fileData, err := encryptKeystore(keystore, "Wallet", []byte("data"))
+	assert.Equal(t, nil, err)
+	file := keystoreFile{}
+	assert.Equal(t, nil, json.Unmarshal(fileData, &file))
+	file.KDF.N = 1 << 40
+	fileData, _ = json.Marshal(file)
+	_, _, err = decryptKeystore("123", fileData)
+	assert.Equal(t, ErrCodeMessage[InvalidKeystoreErr].code, err.(*WalletError).GetCode())
+}
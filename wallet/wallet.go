@@ -4,9 +4,11 @@ import (
 	"bytes"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/incognitochain/incognito-chain/common"
 	"io/ioutil"
+	"time"
 )
 
 type AccountWallet struct {
@@ -14,16 +16,19 @@ type AccountWallet struct {
 	Key        KeyWallet
 	Child      []AccountWallet
 	IsImported bool
+	// IsWatchOnly account only has payment address and readonly key, it can see incoming coins but can't spend them
+	IsWatchOnly bool
 }
 
 type Wallet struct {
 	Seed          []byte
 	Entropy       []byte
-	PassPhrase    string
 	Mnemonic      string
 	MasterAccount AccountWallet
 	Name          string
 	config        *WalletConfig
+	// keystore is encryption key of wallet file, passphrase is not kept in memory
+	keystore *keystoreKey
 }
 
 type WalletConfig struct {
@@ -31,7 +36,18 @@ type WalletConfig struct {
 	DataFile       string
 	DataPath       string
 	IncrementalFee uint64
-	ShardID        *byte //default is nil -> create account for any shard
+	ShardID        *byte                // default is nil -> create account for any shard
+	KDFParams      *KeystoreKDFParams   // default is nil -> DefaultKeystoreKDFParams
+	CoinSelection  string               // default is empty -> default coin selection strategy of tx builders
+	Consolidation  *ConsolidationPolicy // default is nil -> small coins of accounts are not consolidated
+}
+
+// ConsolidationPolicy is policy to merge small coins of wallet accounts in background,
+// an account is consolidated when it has more than MinCoins spendable PRV coins with value at most MaxCoinValue
+type ConsolidationPolicy struct {
+	MinCoins     int
+	MaxCoinValue uint64
+	Interval     time.Duration
 }
 
 // GetConfig returns configuration of wallet
@@ -59,7 +75,11 @@ func (wallet *Wallet) Init(passPhrase string, numOfAccount uint32, name string)
 	wallet.Entropy, _ = mnemonicGen.newEntropy(128)
 	wallet.Mnemonic, _ = mnemonicGen.newMnemonic(wallet.Entropy)
 	wallet.Seed = mnemonicGen.NewSeed(wallet.Mnemonic, passPhrase)
-	wallet.PassPhrase = passPhrase
+	keystore, err := newKeystoreKey(passPhrase, wallet.kdfParams())
+	if err != nil {
+		return NewWalletError(UnexpectedErr, err)
+	}
+	wallet.keystore = keystore
 
 	masterKey, err := NewMasterKey(wallet.Seed)
 	if err != nil {
@@ -139,7 +159,7 @@ func (wallet *Wallet) CreateNewAccount(accountName string, shardID *byte) (*Acco
 			Name:  accountName,
 		}
 		wallet.MasterAccount.Child = append(wallet.MasterAccount.Child, account)
-		err := wallet.Save(wallet.PassPhrase)
+		err := wallet.Save("")
 		if err != nil {
 			Logger.log.Error(err)
 		}
@@ -157,7 +177,7 @@ func (wallet *Wallet) CreateNewAccount(accountName string, shardID *byte) (*Acco
 			Name:  accountName,
 		}
 		wallet.MasterAccount.Child = append(wallet.MasterAccount.Child, account)
-		err := wallet.Save(wallet.PassPhrase)
+		err := wallet.Save("")
 		if err != nil {
 			Logger.log.Error(err)
 		}
@@ -168,18 +188,23 @@ func (wallet *Wallet) CreateNewAccount(accountName string, shardID *byte) (*Acco
 // ExportAccount returns a private key string of account at childIndex in wallet
 // It is base58 check serialized
 func (wallet *Wallet) ExportAccount(childIndex uint32) string {
-	if int(childIndex) >= len(wallet.MasterAccount.Child) {
+	if int(childIndex) >= len(wallet.MasterAccount.Child) || wallet.MasterAccount.Child[childIndex].IsWatchOnly {
 		return ""
 	}
 	return wallet.MasterAccount.Child[childIndex].Key.Base58CheckSerialize(PriKeyType)
 }
 
+// RemoveAccount removes account with privateKeyStr, watch-only account is removed by its payment address
 func (wallet *Wallet) RemoveAccount(privateKeyStr string, passPhrase string) error {
-	if passPhrase != wallet.PassPhrase {
+	if !wallet.CheckPassPhrase(passPhrase) {
 		return NewWalletError(WrongPassphraseErr, nil)
 	}
 	for i, account := range wallet.MasterAccount.Child {
-		if account.Key.Base58CheckSerialize(PriKeyType) == privateKeyStr {
+		keyType := PriKeyType
+		if account.IsWatchOnly {
+			keyType = PaymentAddressType
+		}
+		if account.Key.Base58CheckSerialize(keyType) == privateKeyStr {
 			wallet.MasterAccount.Child = append(wallet.MasterAccount.Child[:i], wallet.MasterAccount.Child[i+1:]...)
 			err := wallet.Save(passPhrase)
 			if err != nil {
@@ -194,12 +219,12 @@ func (wallet *Wallet) RemoveAccount(privateKeyStr string, passPhrase string) err
 // ImportAccount adds account into wallet with privateKeyStr, accountName, and passPhrase which is used to init wallet
 // It returns AccountWallet which is imported and errors (if any)
 func (wallet *Wallet) ImportAccount(privateKeyStr string, accountName string, passPhrase string) (*AccountWallet, error) {
-	if passPhrase != wallet.PassPhrase {
+	if !wallet.CheckPassPhrase(passPhrase) {
 		return nil, NewWalletError(WrongPassphraseErr, nil)
 	}
 
 	for _, account := range wallet.MasterAccount.Child {
-		if account.Key.Base58CheckSerialize(PriKeyType) == privateKeyStr {
+		if !account.IsWatchOnly && account.Key.Base58CheckSerialize(PriKeyType) == privateKeyStr {
 			return nil, NewWalletError(ExistedAccountErr, nil)
 		}
 		if account.Name == accountName {
@@ -227,21 +252,78 @@ func (wallet *Wallet) ImportAccount(privateKeyStr string, accountName string, pa
 		Name:       accountName,
 	}
 	wallet.MasterAccount.Child = append(wallet.MasterAccount.Child, account)
-	err = wallet.Save(wallet.PassPhrase)
+	err = wallet.Save("")
 	if err != nil {
 		return nil, err
 	}
 	return &account, nil
 }
 
-// Save saves encrypted wallet (using AES encryption scheme) in config data file of wallet
-// It returns error if any
-func (wallet *Wallet) Save(password string) error {
-	if password == "" {
-		password = wallet.PassPhrase
+// ImportWatchOnlyAccount adds account which only has paymentAddressStr and readonlyKeyStr into wallet
+// readonly key is used to decrypt amount of incoming output coins, account can't spend them without private key
+// It returns AccountWallet which is imported and errors (if any)
+func (wallet *Wallet) ImportWatchOnlyAccount(paymentAddressStr string, readonlyKeyStr string, accountName string, passPhrase string) (*AccountWallet, error) {
+	if !wallet.CheckPassPhrase(passPhrase) {
+		return nil, NewWalletError(WrongPassphraseErr, nil)
 	}
 
-	if password != wallet.PassPhrase {
+	paymentAddressKey, err := Base58CheckDeserialize(paymentAddressStr)
+	if err != nil {
+		return nil, err
+	}
+	if len(paymentAddressKey.KeySet.PaymentAddress.Pk) == 0 {
+		return nil, NewWalletError(InvalidKeyTypeErr, nil)
+	}
+	readonlyKey, err := Base58CheckDeserialize(readonlyKeyStr)
+	if err != nil {
+		return nil, err
+	}
+	if len(readonlyKey.KeySet.ReadonlyKey.Rk) == 0 {
+		return nil, NewWalletError(InvalidKeyTypeErr, nil)
+	}
+	if !bytes.Equal(readonlyKey.KeySet.ReadonlyKey.Pk, paymentAddressKey.KeySet.PaymentAddress.Pk) {
+		return nil, NewWalletError(WatchOnlyKeyMismatchErr, nil)
+	}
+
+	for _, account := range wallet.MasterAccount.Child {
+		if bytes.Equal(account.Key.KeySet.PaymentAddress.Pk, paymentAddressKey.KeySet.PaymentAddress.Pk) {
+			return nil, NewWalletError(ExistedAccountErr, nil)
+		}
+		if account.Name == accountName {
+			return nil, NewWalletError(ExistedAccountNameErr, nil)
+		}
+	}
+
+	keyWallet := KeyWallet{}
+	keyWallet.KeySet.PaymentAddress = paymentAddressKey.KeySet.PaymentAddress
+	keyWallet.KeySet.ReadonlyKey = readonlyKey.KeySet.ReadonlyKey
+	account := AccountWallet{
+		Key:         keyWallet,
+		Child:       make([]AccountWallet, 0),
+		IsImported:  true,
+		IsWatchOnly: true,
+		Name:        accountName,
+	}
+	wallet.MasterAccount.Child = append(wallet.MasterAccount.Child, account)
+	err = wallet.Save("")
+	if err != nil {
+		return nil, err
+	}
+	return &account, nil
+}
+
+// Save saves encrypted wallet (using AES-GCM with key derived by memory-hard KDF) in config data file of wallet
+// password must be passphrase of wallet, empty password means wallet is saved with its current key
+// It returns error if any
+func (wallet *Wallet) Save(password string) error {
+	if wallet.keystore == nil {
+		// wallet is not created by Init or LoadWallet, password becomes its passphrase
+		keystore, err := newKeystoreKey(password, wallet.kdfParams())
+		if err != nil {
+			return NewWalletError(UnexpectedErr, err)
+		}
+		wallet.keystore = keystore
+	} else if password != "" && !wallet.CheckPassPhrase(password) {
 		return NewWalletError(WrongPassphraseErr, nil)
 	}
 
@@ -253,15 +335,17 @@ func (wallet *Wallet) Save(password string) error {
 	}
 
 	// encrypt data
-	cipherText, err := encryptByPassPhrase(password, data)
+	fileData, err := encryptKeystore(wallet.keystore, wallet.Name, data)
 	if err != nil {
 		Logger.log.Error(err)
-		return NewWalletError(UnexpectedErr, err)
+		return NewWalletError(AESEncryptErr, err)
 	}
 	// and
 	// save file
-	cipherTexInBytes := []byte(cipherText)
-	err = ioutil.WriteFile(wallet.config.DataPath, cipherTexInBytes, 0644)
+	if wallet.config == nil {
+		return NewWalletError(WriteFileErr, errors.New("wallet config is not set"))
+	}
+	err = ioutil.WriteFile(wallet.config.DataPath, fileData, 0600)
 	if err != nil {
 		return NewWalletError(WriteFileErr, err)
 	}
@@ -269,6 +353,7 @@ func (wallet *Wallet) Save(password string) error {
 }
 
 // LoadWallet loads encrypted wallet from file and then decrypts it to wallet struct
+// Wallet file of old format is upgraded to current format after it is loaded
 // It returns error if any
 func (wallet *Wallet) LoadWallet(password string) error {
 	// read file and decrypt
@@ -276,19 +361,76 @@ func (wallet *Wallet) LoadWallet(password string) error {
 	if err != nil {
 		return NewWalletError(ReadFileErr, err)
 	}
+	if isKeystoreFile(bytesData) {
+		bufBytes, keystore, err := decryptKeystore(password, bytesData)
+		if err != nil {
+			return err
+		}
+		// read to struct
+		err = json.Unmarshal(bufBytes, &wallet)
+		if err != nil {
+			return NewWalletError(JsonUnmarshalErr, err)
+		}
+		wallet.keystore = keystore
+		return nil
+	}
+
 	bufBytes, err := decryptByPassPhrase(password, string(bytesData))
 	if err != nil {
 		return NewWalletError(AESDecryptErr, err)
 	}
-
 	// read to struct
 	err = json.Unmarshal(bufBytes, &wallet)
 	if err != nil {
 		return NewWalletError(JsonUnmarshalErr, err)
 	}
+	keystore, err := newKeystoreKey(password, wallet.kdfParams())
+	if err != nil {
+		return NewWalletError(UnexpectedErr, err)
+	}
+	wallet.keystore = keystore
+	if err := wallet.Save(""); err != nil {
+		return err
+	}
+	Logger.log.Infof("Wallet %+v is upgraded to keystore version %+v", wallet.config.DataPath, keystoreVersion)
+	return nil
+}
+
+// CheckPassPhrase returns true if passPhrase is passphrase of wallet
+func (wallet *Wallet) CheckPassPhrase(passPhrase string) bool {
+	if wallet.keystore == nil {
+		return false
+	}
+	return wallet.keystore.match(passPhrase)
+}
+
+// ChangePassPhrase re-encrypts wallet file with key derived from newPassPhrase and a new salt
+// Seed of wallet is not changed, so accounts stay the same
+func (wallet *Wallet) ChangePassPhrase(oldPassPhrase string, newPassPhrase string) error {
+	if !wallet.CheckPassPhrase(oldPassPhrase) {
+		return NewWalletError(WrongPassphraseErr, nil)
+	}
+	keystore, err := newKeystoreKey(newPassPhrase, wallet.kdfParams())
+	if err != nil {
+		return NewWalletError(UnexpectedErr, err)
+	}
+	oldKeystore := wallet.keystore
+	wallet.keystore = keystore
+	if err := wallet.Save(""); err != nil {
+		wallet.keystore = oldKeystore
+		return err
+	}
 	return nil
 }
 
+// kdfParams returns kdf params in config of wallet, or default params
+func (wallet *Wallet) kdfParams() KeystoreKDFParams {
+	if wallet.config != nil && wallet.config.KDFParams != nil {
+		return *wallet.config.KDFParams
+	}
+	return DefaultKeystoreKDFParams()
+}
+
 // DumpPrivkey receives base58 check serialized payment address (paymentAddrSerialized)
 // and returns KeySerializedData object contains PrivateKey
 // which is corresponding to paymentAddrSerialized in all wallet accounts
@@ -297,6 +439,9 @@ func (wallet *Wallet) DumpPrivateKey(paymentAddrSerialized string) KeySerialized
 	for _, account := range wallet.MasterAccount.Child {
 		address := account.Key.Base58CheckSerialize(PaymentAddressType)
 		if address == paymentAddrSerialized {
+			if account.IsWatchOnly {
+				return KeySerializedData{}
+			}
 			key := KeySerializedData{
 				PrivateKey: account.Key.Base58CheckSerialize(PriKeyType),
 			}
@@ -317,7 +462,9 @@ func (wallet *Wallet) GetAddressByAccName(accountName string, shardID *byte) Key
 				PaymentAddress: account.Key.Base58CheckSerialize(PaymentAddressType),
 				Pubkey:         hex.EncodeToString(account.Key.KeySet.PaymentAddress.Pk),
 				ReadonlyKey:    account.Key.Base58CheckSerialize(ReadonlyKeyType),
-				PrivateKey:     account.Key.Base58CheckSerialize(PriKeyType),
+			}
+			if !account.IsWatchOnly {
+				key.PrivateKey = account.Key.Base58CheckSerialize(PriKeyType)
 			}
 			return key
 		}
@@ -350,14 +497,29 @@ func (wallet *Wallet) GetAddressesByAccName(accountName string) []KeySerializedD
 }
 
 // ListAccounts returns a map with key is account name and value is account wallet
+// ListAccounts returns accounts which can spend their coins, watch-only accounts are listed by ListWatchOnlyAccounts
 func (wallet *Wallet) ListAccounts() map[string]AccountWallet {
 	result := make(map[string]AccountWallet)
 	for _, account := range wallet.MasterAccount.Child {
+		if account.IsWatchOnly {
+			continue
+		}
 		result[account.Name] = account
 	}
 	return result
 }
 
+// ListWatchOnlyAccounts returns accounts which only have payment address and readonly key
+func (wallet *Wallet) ListWatchOnlyAccounts() map[string]AccountWallet {
+	result := make(map[string]AccountWallet)
+	for _, account := range wallet.MasterAccount.Child {
+		if account.IsWatchOnly {
+			result[account.Name] = account
+		}
+	}
+	return result
+}
+
 // ContainPubKey checks whether the wallet contains any account with pubKey or not
 func (wallet *Wallet) ContainPublicKey(pubKey []byte) bool {
 	for _, account := range wallet.MasterAccount.Child {
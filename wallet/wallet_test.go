@@ -57,7 +57,7 @@ func TestInit(t *testing.T) {
 		assert.Equal(t, nil, err)
 		assert.Equal(t, int(item.numOfAccount), len(wallet.MasterAccount.Child))
 		assert.Equal(t, item.name, wallet.Name)
-		assert.Equal(t, item.passPhrase, wallet.PassPhrase)
+		assert.Equal(t, true, wallet.CheckPassPhrase(item.passPhrase))
 		assert.Equal(t, seedKeyLen, len(wallet.Seed))
 		assert.Greater(t, len(wallet.Mnemonic), 0)
 	}
@@ -299,6 +299,65 @@ func TestWalletImportAccountWithUnmatchedPassPhrase(t *testing.T) {
 	assert.Equal(t, NewWalletError(WrongPassphraseErr, nil), err)
 }
 
+/*
+	Unit test for ImportWatchOnlyAccount function
+*/
+
+func TestWalletImportWatchOnlyAccount(t *testing.T) {
+	privateKeyStr := "112t8rnY6orkxdArx6fH7xV8C3kiEAJMuDmf7ptrgQ3iqo6VKzSzippYzqT3kPqCXyVmb4iP5AnyTzD1thrhybntuWockJrtYHq6CeSWK5VZ"
+	passPhrase := "123"
+
+	keyWallet, _ := Base58CheckDeserialize(privateKeyStr)
+	keyWallet.KeySet.InitFromPrivateKey(&keyWallet.KeySet.PrivateKey)
+	paymentAddressStr := keyWallet.Base58CheckSerialize(PaymentAddressType)
+	readonlyKeyStr := keyWallet.Base58CheckSerialize(ReadonlyKeyType)
+
+	// importing saves the wallet, so it is saved in a temp dir of test
+	testDataDir := t.TempDir()
+	wallet := new(Wallet)
+	wallet.SetConfig(&WalletConfig{
+		DataDir:  testDataDir,
+		DataFile: "wallet",
+		DataPath: filepath.Join(testDataDir, "wallet"),
+	})
+	wallet.Init(passPhrase, 0, "Wallet")
+	numAccount := len(wallet.MasterAccount.Child)
+
+	newAccount, err := wallet.ImportWatchOnlyAccount(paymentAddressStr, readonlyKeyStr, "Watch A", passPhrase)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, numAccount+1, len(wallet.MasterAccount.Child))
+	assert.Equal(t, true, newAccount.IsWatchOnly)
+	assert.Equal(t, 0, len(newAccount.Key.KeySet.PrivateKey))
+	assert.Equal(t, keyWallet.KeySet.PaymentAddress, newAccount.Key.KeySet.PaymentAddress)
+	assert.Equal(t, keyWallet.KeySet.ReadonlyKey, newAccount.Key.KeySet.ReadonlyKey)
+
+	dumpData := wallet.DumpPrivateKey(paymentAddressStr)
+	assert.Equal(t, "", dumpData.PrivateKey)
+
+	_, err = wallet.ImportWatchOnlyAccount(paymentAddressStr, readonlyKeyStr, "Watch B", passPhrase)
+	assert.Equal(t, NewWalletError(ExistedAccountErr, nil), err)
+
+	// watch-only account can't spend coins so it is only listed separately
+	_, ok := wallet.ListAccounts()["Watch A"]
+	assert.Equal(t, false, ok)
+	assert.Equal(t, newAccount.Name, wallet.ListWatchOnlyAccounts()["Watch A"].Name)
+	assert.Equal(t, numAccount, len(wallet.ListAccounts()))
+}
+
+func TestWalletImportWatchOnlyAccountWithMismatchedKey(t *testing.T) {
+	passPhrase := "123"
+
+	keyWalletA, _ := Base58CheckDeserialize("112t8rnY6orkxdArx6fH7xV8C3kiEAJMuDmf7ptrgQ3iqo6VKzSzippYzqT3kPqCXyVmb4iP5AnyTzD1thrhybntuWockJrtYHq6CeSWK5VZ")
+	keyWalletA.KeySet.InitFromPrivateKey(&keyWalletA.KeySet.PrivateKey)
+	keyWalletB, _ := Base58CheckDeserialize("112t8rnYJncU5TRMexdSX2X9a58c9dKPfzWMEaS7AXY3WniXbVUXvDVmZaKms2QEXtviEUKPdrqq3auNqZB8wQPtuXv8JfzprtMtgdGRiFij")
+	keyWalletB.KeySet.InitFromPrivateKey(&keyWalletB.KeySet.PrivateKey)
+
+	wallet.Init(passPhrase, 0, "Wallet")
+
+	_, err := wallet.ImportWatchOnlyAccount(keyWalletA.Base58CheckSerialize(PaymentAddressType), keyWalletB.Base58CheckSerialize(ReadonlyKeyType), "Watch A", passPhrase)
+	assert.Equal(t, NewWalletError(WatchOnlyKeyMismatchErr, nil), err)
+}
+
 /*
 	Unit test for RemoveAccount function
 */
@@ -472,7 +531,7 @@ func TestWalletLoadWalletWithUnmatchedPassPhrase(t *testing.T) {
 	wallet2.SetConfig(wallet.config)
 	err := wallet2.LoadWallet(passPhrase2)
 
-	assert.Equal(t, ErrCodeMessage[JsonUnmarshalErr].code, err.(*WalletError).GetCode())
+	assert.Equal(t, ErrCodeMessage[WrongPassphraseErr].code, err.(*WalletError).GetCode())
 }
 
 func TestWalletLoadWalletWithEmptyPassPhrase(t *testing.T) {
@@ -487,7 +546,7 @@ func TestWalletLoadWalletWithEmptyPassPhrase(t *testing.T) {
 	wallet2.SetConfig(wallet.config)
 	err := wallet2.LoadWallet(passPhrase2)
 
-	assert.Equal(t, ErrCodeMessage[JsonUnmarshalErr].code, err.(*WalletError).GetCode())
+	assert.Equal(t, ErrCodeMessage[WrongPassphraseErr].code, err.(*WalletError).GetCode())
 }
 
 func TestWalletLoadWalletWithWrongConfig(t *testing.T) {
@@ -4,6 +4,7 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/database/lvdb"
 	"github.com/incognitochain/incognito-chain/metadata"
 	"github.com/incognitochain/incognito-chain/transaction"
 	"github.com/pkg/errors"
@@ -399,4 +400,36 @@ func WithdrawDexTx(args string) (string, error) {
 	B64Res := base64.StdEncoding.EncodeToString(resBytes)
 
 	return B64Res, nil
-}
\ No newline at end of file
+}
+// QuotePDETrade simulates a trade request on a pool pair with the pricing of beacon
+// args: {"PoolPair", "TradeRequest", "PendingTrades" (optional), "SlippagePercent" (optional)}
+func QuotePDETrade(args string) (string, error) {
+	params := struct {
+		PoolPair        *lvdb.PDEPoolForPair
+		TradeRequest    *metadata.PDETradeRequest
+		PendingTrades   []*metadata.PDETradeRequest
+		SlippagePercent *float64
+	}{}
+	err := json.Unmarshal([]byte(args), &params)
+	if err != nil {
+		println("Error can not unmarshal data : %v\n", err)
+		return "", err
+	}
+	slippagePercent := metadata.DefaultPDETradeSlippagePercent
+	if params.SlippagePercent != nil {
+		slippagePercent = *params.SlippagePercent
+	}
+
+	quote, err := metadata.QuotePDETrade(params.PoolPair, params.TradeRequest, params.PendingTrades, slippagePercent)
+	if err != nil {
+		println("Can not quote trade: ", err)
+		return "", err
+	}
+
+	quoteJson, err := json.Marshal(quote)
+	if err != nil {
+		println("Can not marshal quote: ", err)
+		return "", err
+	}
+	return string(quoteJson), nil
+}
@@ -106,7 +106,12 @@ func InitBurningRequestTx(args string) (string, error) {
 		return "", errors.New("Invalid meta data remote address param")
 	}
 
-	metaData, err := metadata.NewBurningRequest(burnerAddress, uint64(burningAmount), *tokenIDHash, tokenName, remoteAddress, int(metaDataType))
+	chainID := uint64(metadata.DefaultEVMChainID)
+	if chainIDParam, ok := metaDataParam["ChainID"].(float64); ok {
+		chainID = uint64(chainIDParam)
+	}
+
+	metaData, err := metadata.NewBurningRequest(burnerAddress, uint64(burningAmount), *tokenIDHash, tokenName, remoteAddress, chainID, int(metaDataType))
 	if err != nil {
 		return "", err
 	}
@@ -184,6 +184,15 @@ func hybridDecryptionASM(_ js.Value, args []js.Value) interface{} {
 	return result
 }
 
+func quotePDETrade(_ js.Value, args []js.Value) interface{} {
+	result, err := gomobile.QuotePDETrade(args[0].String())
+	if err != nil {
+		return nil
+	}
+
+	return result
+}
+
 func main() {
 	c := make(chan struct{}, 0)
 	println("Hello WASM")
@@ -208,6 +217,7 @@ func main() {
 	js.Global().Set("initPRVTradeTx", js.FuncOf(initPRVTradeTx))
 	js.Global().Set("initPTokenTradeTx", js.FuncOf(initPTokenTradeTx))
 	js.Global().Set("withdrawDexTx", js.FuncOf(withdrawDexTx))
+	js.Global().Set("quotePDETrade", js.FuncOf(quotePDETrade))
 
 	js.Global().Set("hybridEncryptionASM", js.FuncOf(hybridEncryptionASM))
 	js.Global().Set("hybridDecryptionASM", js.FuncOf(hybridDecryptionASM))
@@ -0,0 +1,169 @@
+package wire
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/incognitochain/incognito-chain/common"
+)
+
+/*
+Codec of message on p2p network:
+  - LegacyCodec: JSON body + 24 bytes header, gzip, the transport hex encodes the result
+  - BinaryCodec: frame of [magic][codec][cmd type 12 bytes][forward type][forward value][payload format][payload],
+    payload is canonical binary encoding for blocks, txs and BFT messages, other small messages keep JSON payload
+
+Peers announce supported codec in version message, a node only sends binary frames to peers announced BinaryCodec,
+and it can always decode both codecs (binary frame never starts with gzip magic bytes).
+On peerv2 pubsub, binary frames are only published on topics versioned by highway for nodes announcing binary codec protocol
+*/
+const (
+	LegacyCodec  = 1
+	BinaryCodec  = 2
+	CurrentCodec = BinaryCodec
+
+	binaryFrameMagic      = byte(0xEC)
+	binaryFrameHeaderSize = 2 + MessageCmdTypeSize + 3
+
+	binaryPayload = byte(0)
+	jsonPayload   = byte(1)
+)
+
+// BinaryMessage is implemented by messages having canonical binary encoding
+type BinaryMessage interface {
+	BinarySerialize() ([]byte, error)
+	BinaryDeserialize([]byte) error
+}
+
+// NegotiateCodec return codec used to send messages to a peer, peers of old version don't announce codec
+func NegotiateCodec(localCodec int, remoteCodec int) int {
+	if remoteCodec < LegacyCodec {
+		return LegacyCodec
+	}
+	if remoteCodec < localCodec {
+		return remoteCodec
+	}
+	return localCodec
+}
+
+// IsBinaryFrame check whether data is encoded by BinaryCodec
+func IsBinaryFrame(data []byte) bool {
+	return len(data) >= binaryFrameHeaderSize && data[0] == binaryFrameMagic && data[1] == BinaryCodec
+}
+
+// EncodeMessage serialize message with codec, forward type and value are put in header like legacy header
+func EncodeMessage(msg Message, codec int, forwardType byte, forwardValue byte) ([]byte, error) {
+	cmdType, err := GetCmdType(reflect.TypeOf(msg))
+	if err != nil {
+		return nil, err
+	}
+	switch codec {
+	case LegacyCodec:
+		messageBytes, err := msg.JsonSerialize()
+		if err != nil {
+			return nil, err
+		}
+		headerBytes := make([]byte, MessageHeaderSize)
+		copy(headerBytes[:], []byte(cmdType))
+		copy(headerBytes[MessageCmdTypeSize:], []byte{forwardType})
+		copy(headerBytes[MessageCmdTypeSize+1:], []byte{forwardValue})
+		messageBytes = append(messageBytes, headerBytes...)
+		return common.GZipFromBytes(messageBytes)
+	case BinaryCodec:
+		frame := make([]byte, binaryFrameHeaderSize)
+		frame[0] = binaryFrameMagic
+		frame[1] = BinaryCodec
+		copy(frame[2:], []byte(cmdType))
+		frame[2+MessageCmdTypeSize] = forwardType
+		frame[2+MessageCmdTypeSize+1] = forwardValue
+		var payload []byte
+		if binaryMsg, ok := msg.(BinaryMessage); ok {
+			frame[2+MessageCmdTypeSize+2] = binaryPayload
+			payload, err = binaryMsg.BinarySerialize()
+		} else {
+			frame[2+MessageCmdTypeSize+2] = jsonPayload
+			payload, err = msg.JsonSerialize()
+		}
+		if err != nil {
+			return nil, err
+		}
+		return append(frame, payload...), nil
+	default:
+		return nil, fmt.Errorf("unsupported codec %d", codec)
+	}
+}
+
+// DecodeMessage parse message encoded by any supported codec
+func DecodeMessage(data []byte) (msg Message, err error) {
+	// decoders of proofs and coins may panic on malformed bytes from network
+	defer func() {
+		if r := recover(); r != nil {
+			msg = nil
+			err = fmt.Errorf("decode message panic: %v", r)
+		}
+	}()
+	if IsBinaryFrame(data) {
+		return decodeBinaryFrame(data)
+	}
+	return decodeLegacyMessage(data)
+}
+
+func getCmdTypeFromHeader(header []byte) string {
+	return string(bytes.Trim(header[:MessageCmdTypeSize], "\x00"))
+}
+
+func decodeBinaryFrame(data []byte) (Message, error) {
+	commandType := getCmdTypeFromHeader(data[2 : 2+MessageCmdTypeSize])
+	msg, err := MakeEmptyMessage(commandType)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > msg.MaxPayloadLength(Version) {
+		return nil, fmt.Errorf("message %s exceed max size %d", commandType, msg.MaxPayloadLength(Version))
+	}
+	payload := data[binaryFrameHeaderSize:]
+	switch data[2+MessageCmdTypeSize+2] {
+	case binaryPayload:
+		binaryMsg, ok := msg.(BinaryMessage)
+		if !ok {
+			return nil, fmt.Errorf("message %s has no binary encoding", commandType)
+		}
+		err = binaryMsg.BinaryDeserialize(payload)
+	case jsonPayload:
+		err = json.Unmarshal(payload, &msg)
+	default:
+		err = errors.New("unknown payload format")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
+
+func decodeLegacyMessage(data []byte) (Message, error) {
+	jsonDecodeBytes, err := common.GZipToBytes(data)
+	if err != nil {
+		return nil, err
+	}
+	if len(jsonDecodeBytes) < MessageHeaderSize {
+		return nil, errors.New("message is shorter than header")
+	}
+	messageBody := jsonDecodeBytes[:len(jsonDecodeBytes)-MessageHeaderSize]
+	messageHeader := jsonDecodeBytes[len(jsonDecodeBytes)-MessageHeaderSize:]
+	commandType := getCmdTypeFromHeader(messageHeader)
+	msg, err := MakeEmptyMessage(commandType)
+	if err != nil {
+		return nil, err
+	}
+	if len(jsonDecodeBytes) > msg.MaxPayloadLength(Version) {
+		return nil, fmt.Errorf("message %s exceed max size %d", commandType, msg.MaxPayloadLength(Version))
+	}
+	err = json.Unmarshal(messageBody, &msg)
+	if err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
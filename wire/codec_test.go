@@ -0,0 +1,282 @@
+package wire
+
+import (
+	"testing"
+	"time"
+
+	"github.com/incognitochain/incognito-chain/blockchain"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/incognitochain/incognito-chain/privacy"
+	zkp "github.com/incognitochain/incognito-chain/privacy/zeroknowledge"
+	"github.com/incognitochain/incognito-chain/transaction"
+	peer "github.com/libp2p/go-libp2p-peer"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestOutputCoin(value uint64) *privacy.OutputCoin {
+	coin := new(privacy.Coin)
+	coin.SetPublicKey(privacy.RandomPoint())
+	coin.SetCoinCommitment(privacy.RandomPoint())
+	coin.SetSNDerivator(privacy.RandomScalar())
+	coin.SetRandomness(privacy.RandomScalar())
+	coin.SetValue(value)
+	coin.SetInfo([]byte("info"))
+	return &privacy.OutputCoin{CoinDetails: coin}
+}
+
+func newTestTx(txType string, withMetadata bool) transaction.Tx {
+	proof := new(zkp.PaymentProof)
+	proof.Init()
+	proof.SetOutputCoins([]*privacy.OutputCoin{newTestOutputCoin(10), newTestOutputCoin(20)})
+	tx := transaction.Tx{
+		Version:              1,
+		Type:                 txType,
+		LockTime:             time.Now().Unix(),
+		Fee:                  100,
+		Info:                 []byte("memo"),
+		SigPubKey:            privacy.RandomPoint().ToBytesS(),
+		Sig:                  []byte{1, 2, 3, 4},
+		Proof:                proof,
+		PubKeyLastByteSender: 7,
+	}
+	if withMetadata {
+		meta, _ := metadata.NewStakingMetadata(metadata.ShardStakingMeta, "funder", "receiver", 1750000000000, "committee", true)
+		tx.Metadata = meta
+	}
+	return tx
+}
+
+func newTestShardHeader() blockchain.ShardHeader {
+	return blockchain.ShardHeader{
+		Producer:          "producer",
+		ProducerPubKeyStr: "producerpubkey",
+		ShardID:           3,
+		Version:           1,
+		PreviousBlockHash: common.HashH([]byte("previous")),
+		Height:            100,
+		Round:             2,
+		Epoch:             5,
+		CrossShardBitMap:  []byte{0, 1},
+		BeaconHeight:      50,
+		BeaconHash:        common.HashH([]byte("beacon")),
+		// hash keys of map are not restored by JSON decoding, so legacy codec can't round trip fee of txs
+		TotalTxsFee:           map[common.Hash]uint64{},
+		ConsensusType:         "bls",
+		Timestamp:             time.Now().Unix(),
+		TxRoot:                common.HashH([]byte("txroot")),
+		ShardTxRoot:           common.HashH([]byte("shardtxroot")),
+		CrossTransactionRoot:  common.HashH([]byte("crosstxroot")),
+		InstructionsRoot:      common.HashH([]byte("instructionsroot")),
+		CommitteeRoot:         common.HashH([]byte("committeeroot")),
+		PendingValidatorRoot:  common.HashH([]byte("pendingvalidatorroot")),
+		StakingTxRoot:         common.HashH([]byte("stakingtxroot")),
+		InstructionMerkleRoot: common.HashH([]byte("instructionmerkleroot")),
+	}
+}
+
+func newTestMessages() []Message {
+	tx := newTestTx(common.TxNormalType, true)
+	tokenTx := &transaction.TxCustomTokenPrivacy{
+		Tx: newTestTx(common.TxCustomTokenPrivacyType, false),
+		TxPrivacyTokenData: transaction.TxPrivacyTokenData{
+			TxNormal:       newTestTx(common.TxNormalType, false),
+			PropertyID:     common.HashH([]byte("token")),
+			PropertyName:   "token",
+			PropertySymbol: "TKN",
+			Type:           1,
+			Mintable:       true,
+			Amount:         1000,
+		},
+	}
+	crossOutputCoins := []privacy.OutputCoin{*newTestOutputCoin(30)}
+	tokenPrivacyData := []blockchain.ContentCrossShardTokenPrivacyData{{
+		OutputCoin:     crossOutputCoins,
+		PropertyID:     common.HashH([]byte("token")),
+		PropertyName:   "token",
+		PropertySymbol: "TKN",
+		Amount:         1000,
+	}}
+	shardBlock := &blockchain.ShardBlock{
+		ValidationData: "validation",
+		Header:         newTestShardHeader(),
+		Body: blockchain.ShardBody{
+			Instructions: [][]string{{"stake", "key"}, {"swap"}},
+			CrossTransactions: map[byte][]blockchain.CrossTransaction{
+				1: {{BlockHeight: 10, BlockHash: common.HashH([]byte("cross")), TokenPrivacyData: tokenPrivacyData, OutputCoin: crossOutputCoins}},
+			},
+			Transactions: []metadata.Transaction{&tx, tokenTx},
+		},
+	}
+	beaconBlock := &blockchain.BeaconBlock{
+		ValidationData: "validation",
+		Header: blockchain.BeaconHeader{
+			Version:           1,
+			Height:            20,
+			Epoch:             2,
+			Timestamp:         time.Now().Unix(),
+			PreviousBlockHash: common.HashH([]byte("previous")),
+			ConsensusType:     "bls",
+			Producer:          "producer",
+			ProducerPubKeyStr: "producerpubkey",
+		},
+		Body: blockchain.BeaconBody{
+			ShardState: map[byte][]blockchain.ShardState{
+				0: {{Height: 10, Hash: common.HashH([]byte("shard")), CrossShard: []byte{1, 2}}},
+			},
+			Instructions: [][]string{{"random", "1"}},
+		},
+	}
+	crossShardBlock := &blockchain.CrossShardBlock{
+		ValidationData:          "validation",
+		Header:                  newTestShardHeader(),
+		ToShardID:               1,
+		MerklePathShard:         []common.Hash{common.HashH([]byte("path"))},
+		CrossOutputCoin:         crossOutputCoins,
+		CrossTxTokenPrivacyData: tokenPrivacyData,
+	}
+	shardToBeaconBlock := &blockchain.ShardToBeaconBlock{
+		ValidationData: "validation",
+		Instructions:   [][]string{{"stake", "key"}},
+		Header:         newTestShardHeader(),
+	}
+	rewardTx := newTestTx(common.TxRewardType, false)
+	compactBlock := blockchain.NewCompactShardBlock(&blockchain.ShardBlock{
+		ValidationData: shardBlock.ValidationData,
+		Header:         shardBlock.Header,
+		Body: blockchain.ShardBody{
+			Instructions:      shardBlock.Body.Instructions,
+			CrossTransactions: shardBlock.Body.CrossTransactions,
+			Transactions:      []metadata.Transaction{&tx, &rewardTx, tokenTx},
+		},
+	})
+	peerID, _ := peer.IDB58Decode("QmYyQSo1c1Ym7orWxLYvCrM2EmxFTANf8wXmmE7DWjhx5N")
+	now := time.Now().Unix()
+	return []Message{
+		&MessageBlockShard{Block: shardBlock},
+		&MessageBlockBeacon{Block: beaconBlock},
+		&MessageCrossShard{Block: crossShardBlock},
+		&MessageShardToBeacon{Block: shardToBeaconBlock},
+		&MessageTx{Transaction: &tx},
+		&MessageTxPrivacyToken{Transaction: tokenTx},
+		&MessageBFT{Type: "propose", Content: []byte(`{"Block":"data"}`), ChainKey: "beacon", Timestamp: now},
+		&MessageGetBlockBeacon{ByHash: true, BlkHashes: []common.Hash{common.HashH([]byte("block"))}, SenderID: "sender", Timestamp: now},
+		&MessageGetBlockShard{BlkHeights: []uint64{1, 2}, ShardID: 1, SenderID: "sender", Timestamp: now},
+		&MessageGetCrossShard{BlkHeights: []uint64{1, 2}, FromShardID: 1, ToShardID: 2, SenderID: "sender", Timestamp: now},
+		&MessageGetShardToBeacon{BlkHeights: []uint64{1, 2}, ShardID: 1, SenderID: "sender", Timestamp: now},
+		&MessageVersion{ProtocolVersion: "0.0.1", Timestamp: now, RemotePeerId: peerID, LocalPeerId: peerID, PublicKey: "key", WireCodec: CurrentCodec},
+		&MessageVerAck{Valid: true, Timestamp: time.Unix(now, 0)},
+		&MessageGetAddr{Timestamp: time.Unix(now, 0)},
+		&MessageAddr{Timestamp: time.Unix(now, 0), RawPeers: []RawPeer{{RawAddress: "address", PublicKeyType: "bls", PublicKey: "key"}}},
+		&MessagePing{Timestamp: time.Unix(now, 0)},
+		&MessagePeerState{
+			Beacon:            blockchain.ChainState{Height: 10},
+			Shards:            map[byte]blockchain.ChainState{0: {Height: 5}},
+			ShardToBeaconPool: map[byte][]uint64{0: {1, 2}},
+			CrossShardPool:    map[byte]map[byte][]uint64{0: {1: {3}}},
+			Timestamp:         now,
+			SenderID:          "sender",
+		},
+		&MessageMsgCheck{HashStr: "hash", Timestamp: now},
+		&MessageMsgCheckResp{HashStr: "hash", Accept: true, Timestamp: now},
+		&MessageCompactBlockShard{Block: compactBlock, SenderID: "sender"},
+		&MessageGetBlockTxn{BlockHash: common.HashH([]byte("block")), ShardID: 1, ShortTxIDs: []uint64{1, 2}, SenderID: "sender", Timestamp: now},
+		&MessageBlockTxn{BlockHash: common.HashH([]byte("block")), ShardID: 1, Txs: []metadata.Transaction{&tx, tokenTx}, SenderID: "sender", Timestamp: now},
+	}
+}
+
+func TestEncodeDecodeMessage(t *testing.T) {
+	for _, msg := range newTestMessages() {
+		for _, codec := range []int{LegacyCodec, BinaryCodec} {
+			data, err := EncodeMessage(msg, codec, 's', 1)
+			assert.Nil(t, err, msg.MessageType())
+			assert.Equal(t, codec == BinaryCodec, IsBinaryFrame(data), msg.MessageType())
+			decoded, err := DecodeMessage(data)
+			assert.Nil(t, err, msg.MessageType())
+			if err != nil {
+				continue
+			}
+			assert.Equal(t, msg.MessageType(), decoded.MessageType())
+			assert.Equal(t, msg.Hash(), decoded.Hash(), msg.MessageType())
+			// binary encoding is canonical, decoded message is encoded to the same bytes
+			expected, _ := EncodeMessage(msg, BinaryCodec, 's', 1)
+			actual, err := EncodeMessage(decoded, BinaryCodec, 's', 1)
+			assert.Nil(t, err, msg.MessageType())
+			assert.Equal(t, expected, actual, msg.MessageType())
+		}
+	}
+}
+
+func TestBinaryCodecKeepsBlockAndTxHash(t *testing.T) {
+	messages := newTestMessages()
+	shardBlockMsg := messages[0].(*MessageBlockShard)
+	data, err := EncodeMessage(shardBlockMsg, BinaryCodec, 's', 0)
+	assert.Nil(t, err)
+	decoded, err := DecodeMessage(data)
+	assert.Nil(t, err)
+	decodedBlock := decoded.(*MessageBlockShard).Block
+	assert.Equal(t, shardBlockMsg.Block.Header.Hash(), decodedBlock.Header.Hash())
+	assert.Equal(t, shardBlockMsg.Block.Body.Hash(), decodedBlock.Body.Hash())
+	for i, tx := range shardBlockMsg.Block.Body.Transactions {
+		assert.Equal(t, tx.Hash(), decodedBlock.Body.Transactions[i].Hash())
+	}
+
+	beaconBlockMsg := messages[1].(*MessageBlockBeacon)
+	data, err = EncodeMessage(beaconBlockMsg, BinaryCodec, 'b', 0)
+	assert.Nil(t, err)
+	decoded, err = DecodeMessage(data)
+	assert.Nil(t, err)
+	assert.Equal(t, beaconBlockMsg.Block.Hash(), decoded.(*MessageBlockBeacon).Block.Hash())
+}
+
+func TestBinaryCodecIsSmallerThanLegacy(t *testing.T) {
+	for _, msg := range newTestMessages()[:7] {
+		legacyData, err := EncodeMessage(msg, LegacyCodec, 's', 0)
+		assert.Nil(t, err)
+		binaryData, err := EncodeMessage(msg, BinaryCodec, 's', 0)
+		assert.Nil(t, err)
+		// legacy data is hex encoded by transport
+		assert.True(t, len(binaryData) < 2*len(legacyData), msg.MessageType())
+	}
+}
+
+func TestDecodeMessageRejectsTrailingBytes(t *testing.T) {
+	msg := &MessageBFT{Type: "vote", Content: []byte("vote"), ChainKey: "shard-0", Timestamp: 1}
+	data, err := EncodeMessage(msg, BinaryCodec, 's', 0)
+	assert.Nil(t, err)
+	_, err = DecodeMessage(append(data, 0))
+	assert.NotNil(t, err)
+}
+
+func TestNegotiateCodec(t *testing.T) {
+	assert.Equal(t, LegacyCodec, NegotiateCodec(BinaryCodec, 0))
+	assert.Equal(t, LegacyCodec, NegotiateCodec(BinaryCodec, LegacyCodec))
+	assert.Equal(t, BinaryCodec, NegotiateCodec(BinaryCodec, BinaryCodec))
+	assert.Equal(t, BinaryCodec, NegotiateCodec(BinaryCodec, BinaryCodec+1))
+	assert.Equal(t, LegacyCodec, NegotiateCodec(LegacyCodec, BinaryCodec))
+}
+
+func FuzzDecodeMessage(f *testing.F) {
+	for _, msg := range newTestMessages() {
+		for _, codec := range []int{LegacyCodec, BinaryCodec} {
+			data, err := EncodeMessage(msg, codec, 's', 0)
+			if err == nil {
+				f.Add(data)
+			}
+		}
+	}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		msg, err := DecodeMessage(data)
+		if err != nil || !IsBinaryFrame(data) {
+			return
+		}
+		// a valid binary frame must survive another round trip
+		encoded, err := EncodeMessage(msg, BinaryCodec, data[2+MessageCmdTypeSize], data[2+MessageCmdTypeSize+1])
+		if err != nil {
+			return
+		}
+		if _, err := DecodeMessage(encoded); err != nil {
+			t.Fatalf("re-encoded %s message can't be decoded: %v", msg.MessageType(), err)
+		}
+	})
+}
@@ -0,0 +1,477 @@
+package wire
+
+import (
+	"bytes"
+	"sort"
+
+	"github.com/incognitochain/incognito-chain/blockchain"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/incognitochain/incognito-chain/privacy"
+)
+
+func writeShardHeader(w *binaryWriter, header *blockchain.ShardHeader) {
+	w.writeString(header.Producer)
+	w.writeString(header.ProducerPubKeyStr)
+	w.writeByte(header.ShardID)
+	w.writeVarint(int64(header.Version))
+	w.writeHash(header.PreviousBlockHash)
+	w.writeUvarint(header.Height)
+	w.writeVarint(int64(header.Round))
+	w.writeUvarint(header.Epoch)
+	w.writeBytes(header.CrossShardBitMap)
+	w.writeUvarint(header.BeaconHeight)
+	w.writeHash(header.BeaconHash)
+	// map is encoded by sorted token id
+	tokenIDs := []common.Hash{}
+	for tokenID := range header.TotalTxsFee {
+		tokenIDs = append(tokenIDs, tokenID)
+	}
+	sort.Slice(tokenIDs, func(i, j int) bool {
+		return bytes.Compare(tokenIDs[i][:], tokenIDs[j][:]) < 0
+	})
+	w.writeUvarint(uint64(len(tokenIDs)))
+	for _, tokenID := range tokenIDs {
+		w.writeHash(tokenID)
+		w.writeUvarint(header.TotalTxsFee[tokenID])
+	}
+	w.writeString(header.ConsensusType)
+	w.writeVarint(header.Timestamp)
+	w.writeHash(header.TxRoot)
+	w.writeHash(header.ShardTxRoot)
+	w.writeHash(header.CrossTransactionRoot)
+	w.writeHash(header.InstructionsRoot)
+	w.writeHash(header.CommitteeRoot)
+	w.writeHash(header.PendingValidatorRoot)
+	w.writeHash(header.StakingTxRoot)
+	w.writeHash(header.InstructionMerkleRoot)
+}
+
+func readShardHeader(r *binaryReader, header *blockchain.ShardHeader) error {
+	var err error
+	if header.Producer, err = r.readString(); err != nil {
+		return err
+	}
+	if header.ProducerPubKeyStr, err = r.readString(); err != nil {
+		return err
+	}
+	if header.ShardID, err = r.readByte(); err != nil {
+		return err
+	}
+	version, err := r.readVarint()
+	if err != nil {
+		return err
+	}
+	header.Version = int(version)
+	if header.PreviousBlockHash, err = r.readHash(); err != nil {
+		return err
+	}
+	if header.Height, err = r.readUvarint(); err != nil {
+		return err
+	}
+	round, err := r.readVarint()
+	if err != nil {
+		return err
+	}
+	header.Round = int(round)
+	if header.Epoch, err = r.readUvarint(); err != nil {
+		return err
+	}
+	if header.CrossShardBitMap, err = r.readBytes(); err != nil {
+		return err
+	}
+	if header.BeaconHeight, err = r.readUvarint(); err != nil {
+		return err
+	}
+	if header.BeaconHash, err = r.readHash(); err != nil {
+		return err
+	}
+	numberOfTokens, err := r.readLength()
+	if err != nil {
+		return err
+	}
+	header.TotalTxsFee = make(map[common.Hash]uint64)
+	for i := 0; i < numberOfTokens; i++ {
+		tokenID, err := r.readHash()
+		if err != nil {
+			return err
+		}
+		if header.TotalTxsFee[tokenID], err = r.readUvarint(); err != nil {
+			return err
+		}
+	}
+	if header.ConsensusType, err = r.readString(); err != nil {
+		return err
+	}
+	if header.Timestamp, err = r.readVarint(); err != nil {
+		return err
+	}
+	for _, root := range []*common.Hash{
+		&header.TxRoot,
+		&header.ShardTxRoot,
+		&header.CrossTransactionRoot,
+		&header.InstructionsRoot,
+		&header.CommitteeRoot,
+		&header.PendingValidatorRoot,
+		&header.StakingTxRoot,
+		&header.InstructionMerkleRoot,
+	} {
+		if *root, err = r.readHash(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeOutputCoins(w *binaryWriter, outputCoins []privacy.OutputCoin) {
+	w.writeUvarint(uint64(len(outputCoins)))
+	for i := range outputCoins {
+		w.writeBytes(outputCoins[i].Bytes())
+	}
+}
+
+func readOutputCoins(r *binaryReader) ([]privacy.OutputCoin, error) {
+	length, err := r.readLength()
+	if err != nil {
+		return nil, err
+	}
+	outputCoins := make([]privacy.OutputCoin, length)
+	for i := 0; i < length; i++ {
+		outputCoinBytes, err := r.readBytes()
+		if err != nil {
+			return nil, err
+		}
+		if err := outputCoins[i].SetBytes(outputCoinBytes); err != nil {
+			return nil, err
+		}
+	}
+	return outputCoins, nil
+}
+
+func writeTokenPrivacyData(w *binaryWriter, tokenPrivacyData []blockchain.ContentCrossShardTokenPrivacyData) {
+	w.writeUvarint(uint64(len(tokenPrivacyData)))
+	for _, content := range tokenPrivacyData {
+		writeOutputCoins(w, content.OutputCoin)
+		w.writeHash(content.PropertyID)
+		w.writeString(content.PropertyName)
+		w.writeString(content.PropertySymbol)
+		w.writeVarint(int64(content.Type))
+		w.writeBool(content.Mintable)
+		w.writeUvarint(content.Amount)
+	}
+}
+
+func readTokenPrivacyData(r *binaryReader) ([]blockchain.ContentCrossShardTokenPrivacyData, error) {
+	length, err := r.readLength()
+	if err != nil {
+		return nil, err
+	}
+	tokenPrivacyData := make([]blockchain.ContentCrossShardTokenPrivacyData, length)
+	for i := 0; i < length; i++ {
+		content := &tokenPrivacyData[i]
+		if content.OutputCoin, err = readOutputCoins(r); err != nil {
+			return nil, err
+		}
+		if content.PropertyID, err = r.readHash(); err != nil {
+			return nil, err
+		}
+		if content.PropertyName, err = r.readString(); err != nil {
+			return nil, err
+		}
+		if content.PropertySymbol, err = r.readString(); err != nil {
+			return nil, err
+		}
+		tokenType, err := r.readVarint()
+		if err != nil {
+			return nil, err
+		}
+		content.Type = int(tokenType)
+		if content.Mintable, err = r.readBool(); err != nil {
+			return nil, err
+		}
+		if content.Amount, err = r.readUvarint(); err != nil {
+			return nil, err
+		}
+	}
+	return tokenPrivacyData, nil
+}
+
+func writeShardBody(w *binaryWriter, body *blockchain.ShardBody) error {
+	w.writeStringMatrix(body.Instructions)
+	// map is encoded by sorted shard id
+	shardIDs := []byte{}
+	for shardID := range body.CrossTransactions {
+		shardIDs = append(shardIDs, shardID)
+	}
+	w.writeUvarint(uint64(len(shardIDs)))
+	for _, shardID := range sortedByteKeys(shardIDs) {
+		w.writeByte(shardID)
+		crossTransactions := body.CrossTransactions[shardID]
+		w.writeUvarint(uint64(len(crossTransactions)))
+		for _, crossTransaction := range crossTransactions {
+			w.writeUvarint(crossTransaction.BlockHeight)
+			w.writeHash(crossTransaction.BlockHash)
+			writeTokenPrivacyData(w, crossTransaction.TokenPrivacyData)
+			writeOutputCoins(w, crossTransaction.OutputCoin)
+		}
+	}
+	w.writeUvarint(uint64(len(body.Transactions)))
+	for _, tx := range body.Transactions {
+		if err := writeTransaction(w, tx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func readShardBody(r *binaryReader, body *blockchain.ShardBody) error {
+	var err error
+	if body.Instructions, err = r.readStringMatrix(); err != nil {
+		return err
+	}
+	numberOfShards, err := r.readLength()
+	if err != nil {
+		return err
+	}
+	body.CrossTransactions = make(map[byte][]blockchain.CrossTransaction)
+	for i := 0; i < numberOfShards; i++ {
+		shardID, err := r.readByte()
+		if err != nil {
+			return err
+		}
+		length, err := r.readLength()
+		if err != nil {
+			return err
+		}
+		crossTransactions := make([]blockchain.CrossTransaction, length)
+		for j := 0; j < length; j++ {
+			crossTransaction := &crossTransactions[j]
+			if crossTransaction.BlockHeight, err = r.readUvarint(); err != nil {
+				return err
+			}
+			if crossTransaction.BlockHash, err = r.readHash(); err != nil {
+				return err
+			}
+			if crossTransaction.TokenPrivacyData, err = readTokenPrivacyData(r); err != nil {
+				return err
+			}
+			if crossTransaction.OutputCoin, err = readOutputCoins(r); err != nil {
+				return err
+			}
+		}
+		body.CrossTransactions[shardID] = crossTransactions
+	}
+	numberOfTxs, err := r.readLength()
+	if err != nil {
+		return err
+	}
+	body.Transactions = make([]metadata.Transaction, 0, numberOfTxs)
+	for i := 0; i < numberOfTxs; i++ {
+		tx, err := readTransaction(r)
+		if err != nil {
+			return err
+		}
+		body.Transactions = append(body.Transactions, tx)
+	}
+	return nil
+}
+
+func writeShardBlock(w *binaryWriter, block *blockchain.ShardBlock) error {
+	w.writeString(block.ValidationData)
+	writeShardHeader(w, &block.Header)
+	return writeShardBody(w, &block.Body)
+}
+
+func readShardBlock(r *binaryReader, block *blockchain.ShardBlock) error {
+	var err error
+	if block.ValidationData, err = r.readString(); err != nil {
+		return err
+	}
+	if err := readShardHeader(r, &block.Header); err != nil {
+		return err
+	}
+	return readShardBody(r, &block.Body)
+}
+
+func writeBeaconHeader(w *binaryWriter, header *blockchain.BeaconHeader) {
+	w.writeVarint(int64(header.Version))
+	w.writeUvarint(header.Height)
+	w.writeUvarint(header.Epoch)
+	w.writeVarint(int64(header.Round))
+	w.writeVarint(header.Timestamp)
+	w.writeHash(header.PreviousBlockHash)
+	w.writeHash(header.InstructionHash)
+	w.writeHash(header.ShardStateHash)
+	w.writeHash(header.InstructionMerkleRoot)
+	w.writeHash(header.BeaconCommitteeAndValidatorRoot)
+	w.writeHash(header.BeaconCandidateRoot)
+	w.writeHash(header.ShardCandidateRoot)
+	w.writeHash(header.ShardCommitteeAndValidatorRoot)
+	w.writeHash(header.AutoStakingRoot)
+	w.writeString(header.ConsensusType)
+	w.writeString(header.Producer)
+	w.writeString(header.ProducerPubKeyStr)
+}
+
+func readBeaconHeader(r *binaryReader, header *blockchain.BeaconHeader) error {
+	version, err := r.readVarint()
+	if err != nil {
+		return err
+	}
+	header.Version = int(version)
+	if header.Height, err = r.readUvarint(); err != nil {
+		return err
+	}
+	if header.Epoch, err = r.readUvarint(); err != nil {
+		return err
+	}
+	round, err := r.readVarint()
+	if err != nil {
+		return err
+	}
+	header.Round = int(round)
+	if header.Timestamp, err = r.readVarint(); err != nil {
+		return err
+	}
+	for _, root := range []*common.Hash{
+		&header.PreviousBlockHash,
+		&header.InstructionHash,
+		&header.ShardStateHash,
+		&header.InstructionMerkleRoot,
+		&header.BeaconCommitteeAndValidatorRoot,
+		&header.BeaconCandidateRoot,
+		&header.ShardCandidateRoot,
+		&header.ShardCommitteeAndValidatorRoot,
+		&header.AutoStakingRoot,
+	} {
+		if *root, err = r.readHash(); err != nil {
+			return err
+		}
+	}
+	if header.ConsensusType, err = r.readString(); err != nil {
+		return err
+	}
+	if header.Producer, err = r.readString(); err != nil {
+		return err
+	}
+	if header.ProducerPubKeyStr, err = r.readString(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func writeBeaconBody(w *binaryWriter, body *blockchain.BeaconBody) {
+	shardIDs := []byte{}
+	for shardID := range body.ShardState {
+		shardIDs = append(shardIDs, shardID)
+	}
+	w.writeUvarint(uint64(len(shardIDs)))
+	for _, shardID := range sortedByteKeys(shardIDs) {
+		w.writeByte(shardID)
+		shardStates := body.ShardState[shardID]
+		w.writeUvarint(uint64(len(shardStates)))
+		for _, shardState := range shardStates {
+			w.writeUvarint(shardState.Height)
+			w.writeHash(shardState.Hash)
+			w.writeBytes(shardState.CrossShard)
+		}
+	}
+	w.writeStringMatrix(body.Instructions)
+}
+
+func readBeaconBody(r *binaryReader, body *blockchain.BeaconBody) error {
+	numberOfShards, err := r.readLength()
+	if err != nil {
+		return err
+	}
+	body.ShardState = make(map[byte][]blockchain.ShardState)
+	for i := 0; i < numberOfShards; i++ {
+		shardID, err := r.readByte()
+		if err != nil {
+			return err
+		}
+		length, err := r.readLength()
+		if err != nil {
+			return err
+		}
+		shardStates := make([]blockchain.ShardState, length)
+		for j := 0; j < length; j++ {
+			if shardStates[j].Height, err = r.readUvarint(); err != nil {
+				return err
+			}
+			if shardStates[j].Hash, err = r.readHash(); err != nil {
+				return err
+			}
+			if shardStates[j].CrossShard, err = r.readBytes(); err != nil {
+				return err
+			}
+		}
+		body.ShardState[shardID] = shardStates
+	}
+	body.Instructions, err = r.readStringMatrix()
+	return err
+}
+
+func writeBeaconBlock(w *binaryWriter, block *blockchain.BeaconBlock) {
+	w.writeString(block.ValidationData)
+	writeBeaconHeader(w, &block.Header)
+	writeBeaconBody(w, &block.Body)
+}
+
+func readBeaconBlock(r *binaryReader, block *blockchain.BeaconBlock) error {
+	var err error
+	if block.ValidationData, err = r.readString(); err != nil {
+		return err
+	}
+	if err := readBeaconHeader(r, &block.Header); err != nil {
+		return err
+	}
+	return readBeaconBody(r, &block.Body)
+}
+
+func writeCrossShardBlock(w *binaryWriter, block *blockchain.CrossShardBlock) {
+	w.writeString(block.ValidationData)
+	writeShardHeader(w, &block.Header)
+	w.writeByte(block.ToShardID)
+	w.writeHashes(block.MerklePathShard)
+	writeOutputCoins(w, block.CrossOutputCoin)
+	writeTokenPrivacyData(w, block.CrossTxTokenPrivacyData)
+}
+
+func readCrossShardBlock(r *binaryReader, block *blockchain.CrossShardBlock) error {
+	var err error
+	if block.ValidationData, err = r.readString(); err != nil {
+		return err
+	}
+	if err := readShardHeader(r, &block.Header); err != nil {
+		return err
+	}
+	if block.ToShardID, err = r.readByte(); err != nil {
+		return err
+	}
+	if block.MerklePathShard, err = r.readHashes(); err != nil {
+		return err
+	}
+	if block.CrossOutputCoin, err = readOutputCoins(r); err != nil {
+		return err
+	}
+	block.CrossTxTokenPrivacyData, err = readTokenPrivacyData(r)
+	return err
+}
+
+func writeShardToBeaconBlock(w *binaryWriter, block *blockchain.ShardToBeaconBlock) {
+	w.writeString(block.ValidationData)
+	w.writeStringMatrix(block.Instructions)
+	writeShardHeader(w, &block.Header)
+}
+
+func readShardToBeaconBlock(r *binaryReader, block *blockchain.ShardToBeaconBlock) error {
+	var err error
+	if block.ValidationData, err = r.readString(); err != nil {
+		return err
+	}
+	if block.Instructions, err = r.readStringMatrix(); err != nil {
+		return err
+	}
+	return readShardHeader(r, &block.Header)
+}
@@ -0,0 +1,232 @@
+package wire
+
+import (
+	"encoding/binary"
+	"errors"
+	"sort"
+
+	"github.com/incognitochain/incognito-chain/common"
+)
+
+// maxCodecSliceLength bound length prefix read from network before allocating memory
+const maxCodecSliceLength = MaxBFTPayload
+
+var errCodecOutOfRange = errors.New("binary codec: out of range")
+
+// binaryWriter append fields in canonical order: integers are varint, slices and strings are length prefixed
+type binaryWriter struct {
+	buf []byte
+}
+
+func (w *binaryWriter) writeUvarint(value uint64) {
+	temp := make([]byte, binary.MaxVarintLen64)
+	n := binary.PutUvarint(temp, value)
+	w.buf = append(w.buf, temp[:n]...)
+}
+
+func (w *binaryWriter) writeVarint(value int64) {
+	temp := make([]byte, binary.MaxVarintLen64)
+	n := binary.PutVarint(temp, value)
+	w.buf = append(w.buf, temp[:n]...)
+}
+
+// writeFixedUint64 is used for random values like short tx ids, they don't benefit from varint
+func (w *binaryWriter) writeFixedUint64(value uint64) {
+	temp := make([]byte, 8)
+	binary.LittleEndian.PutUint64(temp, value)
+	w.buf = append(w.buf, temp...)
+}
+
+func (w *binaryWriter) writeByte(value byte) {
+	w.buf = append(w.buf, value)
+}
+
+func (w *binaryWriter) writeBool(value bool) {
+	if value {
+		w.writeByte(1)
+	} else {
+		w.writeByte(0)
+	}
+}
+
+func (w *binaryWriter) writeBytes(value []byte) {
+	w.writeUvarint(uint64(len(value)))
+	w.buf = append(w.buf, value...)
+}
+
+func (w *binaryWriter) writeString(value string) {
+	w.writeBytes([]byte(value))
+}
+
+func (w *binaryWriter) writeHash(value common.Hash) {
+	w.buf = append(w.buf, value[:]...)
+}
+
+func (w *binaryWriter) writeHashes(values []common.Hash) {
+	w.writeUvarint(uint64(len(values)))
+	for _, value := range values {
+		w.writeHash(value)
+	}
+}
+
+func (w *binaryWriter) writeStringMatrix(values [][]string) {
+	w.writeUvarint(uint64(len(values)))
+	for _, row := range values {
+		w.writeUvarint(uint64(len(row)))
+		for _, value := range row {
+			w.writeString(value)
+		}
+	}
+}
+
+type binaryReader struct {
+	buf    []byte
+	offset int
+}
+
+func newBinaryReader(buf []byte) *binaryReader {
+	return &binaryReader{buf: buf}
+}
+
+func (r *binaryReader) readUvarint() (uint64, error) {
+	value, n := binary.Uvarint(r.buf[r.offset:])
+	if n <= 0 {
+		return 0, errCodecOutOfRange
+	}
+	r.offset += n
+	return value, nil
+}
+
+func (r *binaryReader) readVarint() (int64, error) {
+	value, n := binary.Varint(r.buf[r.offset:])
+	if n <= 0 {
+		return 0, errCodecOutOfRange
+	}
+	r.offset += n
+	return value, nil
+}
+
+func (r *binaryReader) readFixedUint64() (uint64, error) {
+	if r.offset+8 > len(r.buf) {
+		return 0, errCodecOutOfRange
+	}
+	value := binary.LittleEndian.Uint64(r.buf[r.offset : r.offset+8])
+	r.offset += 8
+	return value, nil
+}
+
+func (r *binaryReader) readLength() (int, error) {
+	length, err := r.readUvarint()
+	if err != nil {
+		return 0, err
+	}
+	if length > maxCodecSliceLength || length > uint64(len(r.buf)-r.offset) {
+		return 0, errCodecOutOfRange
+	}
+	return int(length), nil
+}
+
+func (r *binaryReader) readByte() (byte, error) {
+	if r.offset >= len(r.buf) {
+		return 0, errCodecOutOfRange
+	}
+	value := r.buf[r.offset]
+	r.offset++
+	return value, nil
+}
+
+func (r *binaryReader) readBool() (bool, error) {
+	value, err := r.readByte()
+	if err != nil {
+		return false, err
+	}
+	if value > 1 {
+		return false, errors.New("binary codec: invalid bool")
+	}
+	return value == 1, nil
+}
+
+// readBytes return nil for empty slice, the same as JSON decoding of empty []byte field
+func (r *binaryReader) readBytes() ([]byte, error) {
+	length, err := r.readLength()
+	if err != nil {
+		return nil, err
+	}
+	if length == 0 {
+		return nil, nil
+	}
+	value := make([]byte, length)
+	copy(value, r.buf[r.offset:r.offset+length])
+	r.offset += length
+	return value, nil
+}
+
+func (r *binaryReader) readString() (string, error) {
+	value, err := r.readBytes()
+	return string(value), err
+}
+
+func (r *binaryReader) readHash() (common.Hash, error) {
+	value := common.Hash{}
+	if r.offset+common.HashSize > len(r.buf) {
+		return value, errCodecOutOfRange
+	}
+	copy(value[:], r.buf[r.offset:r.offset+common.HashSize])
+	r.offset += common.HashSize
+	return value, nil
+}
+
+func (r *binaryReader) readHashes() ([]common.Hash, error) {
+	length, err := r.readLength()
+	if err != nil {
+		return nil, err
+	}
+	values := make([]common.Hash, 0, length)
+	for i := 0; i < length; i++ {
+		value, err := r.readHash()
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+	}
+	return values, nil
+}
+
+func (r *binaryReader) readStringMatrix() ([][]string, error) {
+	length, err := r.readLength()
+	if err != nil {
+		return nil, err
+	}
+	values := make([][]string, 0, length)
+	for i := 0; i < length; i++ {
+		rowLength, err := r.readLength()
+		if err != nil {
+			return nil, err
+		}
+		row := make([]string, 0, rowLength)
+		for j := 0; j < rowLength; j++ {
+			value, err := r.readString()
+			if err != nil {
+				return nil, err
+			}
+			row = append(row, value)
+		}
+		values = append(values, row)
+	}
+	return values, nil
+}
+
+// done make sure the whole buffer is consumed, so each message has only one valid encoding
+func (r *binaryReader) done() error {
+	if r.offset != len(r.buf) {
+		return errors.New("binary codec: unexpected trailing bytes")
+	}
+	return nil
+}
+
+func sortedByteKeys(keys []byte) []byte {
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i] < keys[j]
+	})
+	return keys
+}
@@ -0,0 +1,613 @@
+package wire
+
+import (
+	"sort"
+	"time"
+
+	"github.com/incognitochain/incognito-chain/blockchain"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+)
+
+// storageCodec encodes blocks and best states stored in db with the binary codec of messages,
+// blockchain can't import wire so the codec is registered when wire is loaded
+type storageCodec struct{}
+
+func init() {
+	blockchain.RegisterStorageCodec(storageCodec{})
+}
+
+func (storageCodec) EncodeShardBlock(shardBlock *blockchain.ShardBlock) ([]byte, error) {
+	w := &binaryWriter{}
+	if err := writeShardBlock(w, shardBlock); err != nil {
+		return nil, err
+	}
+	return w.buf, nil
+}
+
+func (storageCodec) DecodeShardBlock(data []byte, shardBlock *blockchain.ShardBlock) error {
+	r := newBinaryReader(data)
+	if err := readShardBlock(r, shardBlock); err != nil {
+		return err
+	}
+	return r.done()
+}
+
+func (storageCodec) EncodeBeaconBlock(beaconBlock *blockchain.BeaconBlock) ([]byte, error) {
+	w := &binaryWriter{}
+	writeBeaconBlock(w, beaconBlock)
+	return w.buf, nil
+}
+
+func (storageCodec) DecodeBeaconBlock(data []byte, beaconBlock *blockchain.BeaconBlock) error {
+	r := newBinaryReader(data)
+	if err := readBeaconBlock(r, beaconBlock); err != nil {
+		return err
+	}
+	return r.done()
+}
+
+func (storageCodec) EncodeShardBestState(shardBestState *blockchain.ShardBestState) ([]byte, error) {
+	w := &binaryWriter{}
+	if err := writeShardBestState(w, shardBestState); err != nil {
+		return nil, err
+	}
+	return w.buf, nil
+}
+
+func (storageCodec) DecodeShardBestState(data []byte, shardBestState *blockchain.ShardBestState) error {
+	r := newBinaryReader(data)
+	if err := readShardBestState(r, shardBestState); err != nil {
+		return err
+	}
+	return r.done()
+}
+
+func (storageCodec) EncodeBeaconBestState(beaconBestState *blockchain.BeaconBestState) ([]byte, error) {
+	w := &binaryWriter{}
+	writeBeaconBestState(w, beaconBestState)
+	return w.buf, nil
+}
+
+func (storageCodec) DecodeBeaconBestState(data []byte, beaconBestState *blockchain.BeaconBestState) error {
+	r := newBinaryReader(data)
+	if err := readBeaconBestState(r, beaconBestState); err != nil {
+		return err
+	}
+	return r.done()
+}
+
+func sortedStringKeys(keys []string) []string {
+	sort.Strings(keys)
+	return keys
+}
+
+func writeCommitteePublicKeys(w *binaryWriter, keys []incognitokey.CommitteePublicKey) {
+	w.writeUvarint(uint64(len(keys)))
+	for _, key := range keys {
+		w.writeBytes(key.IncPubKey)
+		consensusTypes := []string{}
+		for consensusType := range key.MiningPubKey {
+			consensusTypes = append(consensusTypes, consensusType)
+		}
+		w.writeUvarint(uint64(len(consensusTypes)))
+		for _, consensusType := range sortedStringKeys(consensusTypes) {
+			w.writeString(consensusType)
+			w.writeBytes(key.MiningPubKey[consensusType])
+		}
+	}
+}
+
+func readCommitteePublicKeys(r *binaryReader) ([]incognitokey.CommitteePublicKey, error) {
+	length, err := r.readLength()
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]incognitokey.CommitteePublicKey, 0, length)
+	for i := 0; i < length; i++ {
+		key := incognitokey.CommitteePublicKey{MiningPubKey: make(map[string][]byte)}
+		if key.IncPubKey, err = r.readBytes(); err != nil {
+			return nil, err
+		}
+		numConsensusTypes, err := r.readLength()
+		if err != nil {
+			return nil, err
+		}
+		for j := 0; j < numConsensusTypes; j++ {
+			consensusType, err := r.readString()
+			if err != nil {
+				return nil, err
+			}
+			if key.MiningPubKey[consensusType], err = r.readBytes(); err != nil {
+				return nil, err
+			}
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+func writeCommitteePublicKeysByShard(w *binaryWriter, keysByShard map[byte][]incognitokey.CommitteePublicKey) {
+	shardIDs := []byte{}
+	for shardID := range keysByShard {
+		shardIDs = append(shardIDs, shardID)
+	}
+	w.writeUvarint(uint64(len(shardIDs)))
+	for _, shardID := range sortedByteKeys(shardIDs) {
+		w.writeByte(shardID)
+		writeCommitteePublicKeys(w, keysByShard[shardID])
+	}
+}
+
+func readCommitteePublicKeysByShard(r *binaryReader) (map[byte][]incognitokey.CommitteePublicKey, error) {
+	length, err := r.readLength()
+	if err != nil {
+		return nil, err
+	}
+	keysByShard := make(map[byte][]incognitokey.CommitteePublicKey, length)
+	for i := 0; i < length; i++ {
+		shardID, err := r.readByte()
+		if err != nil {
+			return nil, err
+		}
+		if keysByShard[shardID], err = readCommitteePublicKeys(r); err != nil {
+			return nil, err
+		}
+	}
+	return keysByShard, nil
+}
+
+func writeStringUint64Map(w *binaryWriter, values map[string]uint64) {
+	keys := []string{}
+	for key := range values {
+		keys = append(keys, key)
+	}
+	w.writeUvarint(uint64(len(keys)))
+	for _, key := range sortedStringKeys(keys) {
+		w.writeString(key)
+		w.writeUvarint(values[key])
+	}
+}
+
+func readStringUint64Map(r *binaryReader) (map[string]uint64, error) {
+	length, err := r.readLength()
+	if err != nil {
+		return nil, err
+	}
+	values := make(map[string]uint64, length)
+	for i := 0; i < length; i++ {
+		key, err := r.readString()
+		if err != nil {
+			return nil, err
+		}
+		if values[key], err = r.readUvarint(); err != nil {
+			return nil, err
+		}
+	}
+	return values, nil
+}
+
+func writeStringStringMap(w *binaryWriter, values map[string]string) {
+	keys := []string{}
+	for key := range values {
+		keys = append(keys, key)
+	}
+	w.writeUvarint(uint64(len(keys)))
+	for _, key := range sortedStringKeys(keys) {
+		w.writeString(key)
+		w.writeString(values[key])
+	}
+}
+
+func readStringStringMap(r *binaryReader) (map[string]string, error) {
+	length, err := r.readLength()
+	if err != nil {
+		return nil, err
+	}
+	values := make(map[string]string, length)
+	for i := 0; i < length; i++ {
+		key, err := r.readString()
+		if err != nil {
+			return nil, err
+		}
+		if values[key], err = r.readString(); err != nil {
+			return nil, err
+		}
+	}
+	return values, nil
+}
+
+func writeStringBoolMap(w *binaryWriter, values map[string]bool) {
+	keys := []string{}
+	for key := range values {
+		keys = append(keys, key)
+	}
+	w.writeUvarint(uint64(len(keys)))
+	for _, key := range sortedStringKeys(keys) {
+		w.writeString(key)
+		w.writeBool(values[key])
+	}
+}
+
+func readStringBoolMap(r *binaryReader) (map[string]bool, error) {
+	length, err := r.readLength()
+	if err != nil {
+		return nil, err
+	}
+	values := make(map[string]bool, length)
+	for i := 0; i < length; i++ {
+		key, err := r.readString()
+		if err != nil {
+			return nil, err
+		}
+		if values[key], err = r.readBool(); err != nil {
+			return nil, err
+		}
+	}
+	return values, nil
+}
+
+func writeByteUint64Map(w *binaryWriter, values map[byte]uint64) {
+	keys := []byte{}
+	for key := range values {
+		keys = append(keys, key)
+	}
+	w.writeUvarint(uint64(len(keys)))
+	for _, key := range sortedByteKeys(keys) {
+		w.writeByte(key)
+		w.writeUvarint(values[key])
+	}
+}
+
+func readByteUint64Map(r *binaryReader) (map[byte]uint64, error) {
+	length, err := r.readLength()
+	if err != nil {
+		return nil, err
+	}
+	values := make(map[byte]uint64, length)
+	for i := 0; i < length; i++ {
+		key, err := r.readByte()
+		if err != nil {
+			return nil, err
+		}
+		if values[key], err = r.readUvarint(); err != nil {
+			return nil, err
+		}
+	}
+	return values, nil
+}
+
+func writeShardBestState(w *binaryWriter, bestState *blockchain.ShardBestState) error {
+	w.writeHash(bestState.BestBlockHash)
+	w.writeBool(bestState.BestBlock != nil)
+	if bestState.BestBlock != nil {
+		if err := writeShardBlock(w, bestState.BestBlock); err != nil {
+			return err
+		}
+	}
+	w.writeHash(bestState.BestBeaconHash)
+	w.writeUvarint(bestState.BeaconHeight)
+	w.writeByte(bestState.ShardID)
+	w.writeUvarint(bestState.Epoch)
+	w.writeUvarint(bestState.ShardHeight)
+	w.writeVarint(int64(bestState.MaxShardCommitteeSize))
+	w.writeVarint(int64(bestState.MinShardCommitteeSize))
+	w.writeVarint(int64(bestState.ShardProposerIdx))
+	writeCommitteePublicKeys(w, bestState.ShardCommittee)
+	writeCommitteePublicKeys(w, bestState.ShardPendingValidator)
+	writeByteUint64Map(w, bestState.BestCrossShard)
+	writeStringStringMap(w, bestState.StakingTx)
+	w.writeUvarint(bestState.NumTxns)
+	w.writeUvarint(bestState.TotalTxns)
+	w.writeUvarint(bestState.TotalTxnsExcludeSalary)
+	w.writeVarint(int64(bestState.ActiveShards))
+	w.writeString(bestState.ConsensusAlgorithm)
+	writeStringUint64Map(w, bestState.NumOfBlocksByProducers)
+	w.writeVarint(int64(bestState.BlockInterval))
+	w.writeVarint(int64(bestState.BlockMaxCreateTime))
+	w.writeUvarint(bestState.MetricBlockHeight)
+	return nil
+}
+
+func readShardBestState(r *binaryReader, bestState *blockchain.ShardBestState) error {
+	var err error
+	var value int64
+	if bestState.BestBlockHash, err = r.readHash(); err != nil {
+		return err
+	}
+	hasBestBlock, err := r.readBool()
+	if err != nil {
+		return err
+	}
+	if hasBestBlock {
+		bestState.BestBlock = blockchain.NewShardBlock()
+		if err := readShardBlock(r, bestState.BestBlock); err != nil {
+			return err
+		}
+	}
+	if bestState.BestBeaconHash, err = r.readHash(); err != nil {
+		return err
+	}
+	if bestState.BeaconHeight, err = r.readUvarint(); err != nil {
+		return err
+	}
+	if bestState.ShardID, err = r.readByte(); err != nil {
+		return err
+	}
+	if bestState.Epoch, err = r.readUvarint(); err != nil {
+		return err
+	}
+	if bestState.ShardHeight, err = r.readUvarint(); err != nil {
+		return err
+	}
+	if value, err = r.readVarint(); err != nil {
+		return err
+	}
+	bestState.MaxShardCommitteeSize = int(value)
+	if value, err = r.readVarint(); err != nil {
+		return err
+	}
+	bestState.MinShardCommitteeSize = int(value)
+	if value, err = r.readVarint(); err != nil {
+		return err
+	}
+	bestState.ShardProposerIdx = int(value)
+	if bestState.ShardCommittee, err = readCommitteePublicKeys(r); err != nil {
+		return err
+	}
+	if bestState.ShardPendingValidator, err = readCommitteePublicKeys(r); err != nil {
+		return err
+	}
+	if bestState.BestCrossShard, err = readByteUint64Map(r); err != nil {
+		return err
+	}
+	if bestState.StakingTx, err = readStringStringMap(r); err != nil {
+		return err
+	}
+	if bestState.NumTxns, err = r.readUvarint(); err != nil {
+		return err
+	}
+	if bestState.TotalTxns, err = r.readUvarint(); err != nil {
+		return err
+	}
+	if bestState.TotalTxnsExcludeSalary, err = r.readUvarint(); err != nil {
+		return err
+	}
+	if value, err = r.readVarint(); err != nil {
+		return err
+	}
+	bestState.ActiveShards = int(value)
+	if bestState.ConsensusAlgorithm, err = r.readString(); err != nil {
+		return err
+	}
+	if bestState.NumOfBlocksByProducers, err = readStringUint64Map(r); err != nil {
+		return err
+	}
+	if value, err = r.readVarint(); err != nil {
+		return err
+	}
+	bestState.BlockInterval = time.Duration(value)
+	if value, err = r.readVarint(); err != nil {
+		return err
+	}
+	bestState.BlockMaxCreateTime = time.Duration(value)
+	bestState.MetricBlockHeight, err = r.readUvarint()
+	return err
+}
+
+func writeBeaconBestState(w *binaryWriter, bestState *blockchain.BeaconBestState) {
+	w.writeHash(bestState.BestBlockHash)
+	w.writeHash(bestState.PreviousBestBlockHash)
+	writeBeaconBlock(w, &bestState.BestBlock)
+	shardIDs := []byte{}
+	for shardID := range bestState.BestShardHash {
+		shardIDs = append(shardIDs, shardID)
+	}
+	w.writeUvarint(uint64(len(shardIDs)))
+	for _, shardID := range sortedByteKeys(shardIDs) {
+		w.writeByte(shardID)
+		w.writeHash(bestState.BestShardHash[shardID])
+	}
+	writeByteUint64Map(w, bestState.BestShardHeight)
+	w.writeUvarint(bestState.Epoch)
+	w.writeUvarint(bestState.BeaconHeight)
+	w.writeVarint(int64(bestState.BeaconProposerIndex))
+	writeCommitteePublicKeys(w, bestState.BeaconCommittee)
+	writeCommitteePublicKeys(w, bestState.BeaconPendingValidator)
+	writeCommitteePublicKeys(w, bestState.CandidateShardWaitingForCurrentRandom)
+	writeCommitteePublicKeys(w, bestState.CandidateBeaconWaitingForCurrentRandom)
+	writeCommitteePublicKeys(w, bestState.CandidateShardWaitingForNextRandom)
+	writeCommitteePublicKeys(w, bestState.CandidateBeaconWaitingForNextRandom)
+	writeCommitteePublicKeysByShard(w, bestState.ShardCommittee)
+	writeCommitteePublicKeysByShard(w, bestState.ShardPendingValidator)
+	writeStringBoolMap(w, bestState.AutoStaking)
+	writeStringUint64Map(w, bestState.UnbondingStakes)
+	w.writeVarint(bestState.CurrentRandomNumber)
+	w.writeVarint(bestState.CurrentRandomTimeStamp)
+	w.writeBool(bestState.IsGetRandomNumber)
+	writeStringStringMap(w, bestState.Params)
+	w.writeVarint(int64(bestState.MaxBeaconCommitteeSize))
+	w.writeVarint(int64(bestState.MinBeaconCommitteeSize))
+	w.writeVarint(int64(bestState.MaxShardCommitteeSize))
+	w.writeVarint(int64(bestState.MinShardCommitteeSize))
+	w.writeVarint(int64(bestState.ActiveShards))
+	w.writeString(bestState.ConsensusAlgorithm)
+	shardIDs = []byte{}
+	for shardID := range bestState.ShardConsensusAlgorithm {
+		shardIDs = append(shardIDs, shardID)
+	}
+	w.writeUvarint(uint64(len(shardIDs)))
+	for _, shardID := range sortedByteKeys(shardIDs) {
+		w.writeByte(shardID)
+		w.writeString(bestState.ShardConsensusAlgorithm[shardID])
+	}
+	writeStringStringMap(w, bestState.RewardReceiver)
+	shardIDs = []byte{}
+	for shardID := range bestState.LastCrossShardState {
+		shardIDs = append(shardIDs, shardID)
+	}
+	w.writeUvarint(uint64(len(shardIDs)))
+	for _, shardID := range sortedByteKeys(shardIDs) {
+		w.writeByte(shardID)
+		writeByteUint64Map(w, bestState.LastCrossShardState[shardID])
+	}
+	shardIDs = []byte{}
+	for shardID := range bestState.ShardHandle {
+		shardIDs = append(shardIDs, shardID)
+	}
+	w.writeUvarint(uint64(len(shardIDs)))
+	for _, shardID := range sortedByteKeys(shardIDs) {
+		w.writeByte(shardID)
+		w.writeBool(bestState.ShardHandle[shardID])
+	}
+	writeStringUint64Map(w, bestState.NumOfBlocksByProducers)
+	w.writeVarint(int64(bestState.BlockInterval))
+	w.writeVarint(int64(bestState.BlockMaxCreateTime))
+}
+
+func readBeaconBestState(r *binaryReader, bestState *blockchain.BeaconBestState) error {
+	var err error
+	var value int64
+	if bestState.BestBlockHash, err = r.readHash(); err != nil {
+		return err
+	}
+	if bestState.PreviousBestBlockHash, err = r.readHash(); err != nil {
+		return err
+	}
+	bestState.BestBlock = *blockchain.NewBeaconBlock()
+	if err := readBeaconBlock(r, &bestState.BestBlock); err != nil {
+		return err
+	}
+	length, err := r.readLength()
+	if err != nil {
+		return err
+	}
+	bestState.BestShardHash = make(map[byte]common.Hash, length)
+	for i := 0; i < length; i++ {
+		shardID, err := r.readByte()
+		if err != nil {
+			return err
+		}
+		if bestState.BestShardHash[shardID], err = r.readHash(); err != nil {
+			return err
+		}
+	}
+	if bestState.BestShardHeight, err = readByteUint64Map(r); err != nil {
+		return err
+	}
+	if bestState.Epoch, err = r.readUvarint(); err != nil {
+		return err
+	}
+	if bestState.BeaconHeight, err = r.readUvarint(); err != nil {
+		return err
+	}
+	if value, err = r.readVarint(); err != nil {
+		return err
+	}
+	bestState.BeaconProposerIndex = int(value)
+	for _, keys := range []*[]incognitokey.CommitteePublicKey{
+		&bestState.BeaconCommittee,
+		&bestState.BeaconPendingValidator,
+		&bestState.CandidateShardWaitingForCurrentRandom,
+		&bestState.CandidateBeaconWaitingForCurrentRandom,
+		&bestState.CandidateShardWaitingForNextRandom,
+		&bestState.CandidateBeaconWaitingForNextRandom,
+	} {
+		if *keys, err = readCommitteePublicKeys(r); err != nil {
+			return err
+		}
+	}
+	if bestState.ShardCommittee, err = readCommitteePublicKeysByShard(r); err != nil {
+		return err
+	}
+	if bestState.ShardPendingValidator, err = readCommitteePublicKeysByShard(r); err != nil {
+		return err
+	}
+	if bestState.AutoStaking, err = readStringBoolMap(r); err != nil {
+		return err
+	}
+	if bestState.UnbondingStakes, err = readStringUint64Map(r); err != nil {
+		return err
+	}
+	if bestState.CurrentRandomNumber, err = r.readVarint(); err != nil {
+		return err
+	}
+	if bestState.CurrentRandomTimeStamp, err = r.readVarint(); err != nil {
+		return err
+	}
+	if bestState.IsGetRandomNumber, err = r.readBool(); err != nil {
+		return err
+	}
+	if bestState.Params, err = readStringStringMap(r); err != nil {
+		return err
+	}
+	for _, size := range []*int{
+		&bestState.MaxBeaconCommitteeSize,
+		&bestState.MinBeaconCommitteeSize,
+		&bestState.MaxShardCommitteeSize,
+		&bestState.MinShardCommitteeSize,
+		&bestState.ActiveShards,
+	} {
+		if value, err = r.readVarint(); err != nil {
+			return err
+		}
+		*size = int(value)
+	}
+	if bestState.ConsensusAlgorithm, err = r.readString(); err != nil {
+		return err
+	}
+	if length, err = r.readLength(); err != nil {
+		return err
+	}
+	bestState.ShardConsensusAlgorithm = make(map[byte]string, length)
+	for i := 0; i < length; i++ {
+		shardID, err := r.readByte()
+		if err != nil {
+			return err
+		}
+		if bestState.ShardConsensusAlgorithm[shardID], err = r.readString(); err != nil {
+			return err
+		}
+	}
+	if bestState.RewardReceiver, err = readStringStringMap(r); err != nil {
+		return err
+	}
+	if length, err = r.readLength(); err != nil {
+		return err
+	}
+	bestState.LastCrossShardState = make(map[byte]map[byte]uint64, length)
+	for i := 0; i < length; i++ {
+		shardID, err := r.readByte()
+		if err != nil {
+			return err
+		}
+		if bestState.LastCrossShardState[shardID], err = readByteUint64Map(r); err != nil {
+			return err
+		}
+	}
+	if length, err = r.readLength(); err != nil {
+		return err
+	}
+	bestState.ShardHandle = make(map[byte]bool, length)
+	for i := 0; i < length; i++ {
+		shardID, err := r.readByte()
+		if err != nil {
+			return err
+		}
+		if bestState.ShardHandle[shardID], err = r.readBool(); err != nil {
+			return err
+		}
+	}
+	if bestState.NumOfBlocksByProducers, err = readStringUint64Map(r); err != nil {
+		return err
+	}
+	if value, err = r.readVarint(); err != nil {
+		return err
+	}
+	bestState.BlockInterval = time.Duration(value)
+	if value, err = r.readVarint(); err != nil {
+		return err
+	}
+	bestState.BlockMaxCreateTime = time.Duration(value)
+	return nil
+}
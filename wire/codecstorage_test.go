@@ -0,0 +1,82 @@
+package wire
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/incognitochain/incognito-chain/blockchain"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStorageCodecKeepsBlocks(t *testing.T) {
+	messages := newTestMessages()
+	shardBlock := messages[0].(*MessageBlockShard).Block
+	data, err := shardBlock.MarshalStorage()
+	assert.Nil(t, err)
+	decodedShardBlock := blockchain.NewShardBlock()
+	assert.Nil(t, storageCodec{}.DecodeShardBlock(data[2:], decodedShardBlock))
+	assert.Equal(t, shardBlock.Header.Hash(), decodedShardBlock.Header.Hash())
+	assert.Equal(t, shardBlock.Body.Hash(), decodedShardBlock.Body.Hash())
+
+	beaconBlock := messages[1].(*MessageBlockBeacon).Block
+	data, err = beaconBlock.MarshalStorage()
+	assert.Nil(t, err)
+	decodedBeaconBlock := blockchain.NewBeaconBlock()
+	assert.Nil(t, storageCodec{}.DecodeBeaconBlock(data[2:], decodedBeaconBlock))
+	assert.Equal(t, beaconBlock.Hash(), decodedBeaconBlock.Hash())
+}
+
+func TestStorageCodecKeepsBestStates(t *testing.T) {
+	messages := newTestMessages()
+	committee := []incognitokey.CommitteePublicKey{{IncPubKey: []byte("inc"), MiningPubKey: map[string][]byte{"bls": []byte("bls")}}}
+	shardBestState := &blockchain.ShardBestState{
+		BestBlockHash:          common.HashH([]byte("best")),
+		BestBlock:              messages[0].(*MessageBlockShard).Block,
+		ShardID:                3,
+		ShardHeight:            100,
+		ShardCommittee:         committee,
+		ShardPendingValidator:  []incognitokey.CommitteePublicKey{},
+		BestCrossShard:         map[byte]uint64{1: 10},
+		StakingTx:              map[string]string{"key": "tx"},
+		NumOfBlocksByProducers: map[string]uint64{"producer": 2},
+	}
+	data, err := shardBestState.MarshalStorage()
+	assert.Nil(t, err)
+	decodedShardBestState := &blockchain.ShardBestState{}
+	assert.Nil(t, blockchain.UnmarshalStoredShardBestState(data, decodedShardBestState))
+	expected, _ := json.Marshal(shardBestState)
+	actual, _ := json.Marshal(decodedShardBestState)
+	assert.Equal(t, string(expected), string(actual))
+
+	beaconBestState := &blockchain.BeaconBestState{
+		BestBlockHash:                          common.HashH([]byte("best")),
+		BestBlock:                              *messages[1].(*MessageBlockBeacon).Block,
+		BestShardHash:                          map[byte]common.Hash{0: common.HashH([]byte("shard"))},
+		BestShardHeight:                        map[byte]uint64{0: 7},
+		BeaconHeight:                           5,
+		BeaconCommittee:                        committee,
+		BeaconPendingValidator:                 committee,
+		CandidateShardWaitingForCurrentRandom:  committee,
+		CandidateBeaconWaitingForCurrentRandom: committee,
+		CandidateShardWaitingForNextRandom:     committee,
+		CandidateBeaconWaitingForNextRandom:    committee,
+		ShardCommittee:                         map[byte][]incognitokey.CommitteePublicKey{0: committee},
+		ShardPendingValidator:                  map[byte][]incognitokey.CommitteePublicKey{1: committee},
+		AutoStaking:                            map[string]bool{"key": true},
+		UnbondingStakes:                        map[string]uint64{"key": 9},
+		ShardConsensusAlgorithm:                map[byte]string{0: "bls"},
+		NumOfBlocksByProducers:                 map[string]uint64{"producer": 2},
+		RewardReceiver:                         map[string]string{"key": "receiver"},
+		LastCrossShardState:                    map[byte]map[byte]uint64{0: {1: 3}},
+		ShardHandle:                            map[byte]bool{0: true},
+	}
+	data, err = beaconBestState.MarshalStorage()
+	assert.Nil(t, err)
+	decodedBeaconBestState := &blockchain.BeaconBestState{}
+	assert.Nil(t, blockchain.UnmarshalStoredBeaconBestState(data, decodedBeaconBestState))
+	expected, _ = json.Marshal(beaconBestState)
+	actual, _ = json.Marshal(decodedBeaconBestState)
+	assert.Equal(t, string(expected), string(actual))
+}
@@ -0,0 +1,201 @@
+package wire
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/metadata"
+	zkp "github.com/incognitochain/incognito-chain/privacy/zeroknowledge"
+	"github.com/incognitochain/incognito-chain/transaction"
+)
+
+// kind of tx in binary encoding
+const (
+	binaryTxNormal             = byte(0)
+	binaryTxCustomTokenPrivacy = byte(1)
+)
+
+/*
+Tx is encoded field by field:
+- Proof uses its own canonical bytes (the same bytes which are base64 encoded in JSON)
+- Metadata has many types with their own JSON parser, so it is kept as JSON bytes
+*/
+func writeTx(w *binaryWriter, tx *transaction.Tx) error {
+	w.writeVarint(int64(tx.Version))
+	w.writeString(tx.Type)
+	w.writeVarint(tx.LockTime)
+	w.writeUvarint(tx.Fee)
+	w.writeBytes(tx.Info)
+	w.writeBytes(tx.SigPubKey)
+	w.writeBytes(tx.Sig)
+	if tx.Proof != nil {
+		w.writeBool(true)
+		w.writeBytes(tx.Proof.Bytes())
+	} else {
+		w.writeBool(false)
+	}
+	w.writeByte(tx.PubKeyLastByteSender)
+	if tx.Metadata != nil {
+		metadataBytes, err := json.Marshal(tx.Metadata)
+		if err != nil {
+			return err
+		}
+		w.writeBytes(metadataBytes)
+	} else {
+		w.writeBytes(nil)
+	}
+	return nil
+}
+
+func readTx(r *binaryReader, tx *transaction.Tx) error {
+	version, err := r.readVarint()
+	if err != nil {
+		return err
+	}
+	tx.Version = int8(version)
+	if tx.Type, err = r.readString(); err != nil {
+		return err
+	}
+	if tx.LockTime, err = r.readVarint(); err != nil {
+		return err
+	}
+	if tx.Fee, err = r.readUvarint(); err != nil {
+		return err
+	}
+	if tx.Info, err = r.readBytes(); err != nil {
+		return err
+	}
+	if tx.SigPubKey, err = r.readBytes(); err != nil {
+		return err
+	}
+	if tx.Sig, err = r.readBytes(); err != nil {
+		return err
+	}
+	hasProof, err := r.readBool()
+	if err != nil {
+		return err
+	}
+	if hasProof {
+		proofBytes, err := r.readBytes()
+		if err != nil {
+			return err
+		}
+		proof := new(zkp.PaymentProof)
+		if err := proof.SetBytes(proofBytes); err != nil {
+			return err
+		}
+		tx.Proof = proof
+	}
+	if tx.PubKeyLastByteSender, err = r.readByte(); err != nil {
+		return err
+	}
+	metadataBytes, err := r.readBytes()
+	if err != nil {
+		return err
+	}
+	if len(metadataBytes) > 0 {
+		var metadataTemp interface{}
+		if err := json.Unmarshal(metadataBytes, &metadataTemp); err != nil {
+			return err
+		}
+		meta, err := metadata.ParseMetadata(metadataTemp)
+		if err != nil {
+			return err
+		}
+		tx.SetMetadata(meta)
+	}
+	return nil
+}
+
+func writeTxCustomTokenPrivacy(w *binaryWriter, tx *transaction.TxCustomTokenPrivacy) error {
+	if err := writeTx(w, &tx.Tx); err != nil {
+		return err
+	}
+	tokenData := tx.TxPrivacyTokenData
+	if err := writeTx(w, &tokenData.TxNormal); err != nil {
+		return err
+	}
+	w.writeHash(tokenData.PropertyID)
+	w.writeString(tokenData.PropertyName)
+	w.writeString(tokenData.PropertySymbol)
+	w.writeVarint(int64(tokenData.Type))
+	w.writeBool(tokenData.Mintable)
+	w.writeUvarint(tokenData.Amount)
+	return nil
+}
+
+func readTxCustomTokenPrivacy(r *binaryReader, tx *transaction.TxCustomTokenPrivacy) error {
+	if err := readTx(r, &tx.Tx); err != nil {
+		return err
+	}
+	tokenData := &tx.TxPrivacyTokenData
+	if err := readTx(r, &tokenData.TxNormal); err != nil {
+		return err
+	}
+	var err error
+	if tokenData.PropertyID, err = r.readHash(); err != nil {
+		return err
+	}
+	if tokenData.PropertyName, err = r.readString(); err != nil {
+		return err
+	}
+	if tokenData.PropertySymbol, err = r.readString(); err != nil {
+		return err
+	}
+	tokenType, err := r.readVarint()
+	if err != nil {
+		return err
+	}
+	tokenData.Type = int(tokenType)
+	if tokenData.Mintable, err = r.readBool(); err != nil {
+		return err
+	}
+	if tokenData.Amount, err = r.readUvarint(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// writeTransaction prefix tx with its kind, so list of txs in block can be decoded without JSON type switch
+func writeTransaction(w *binaryWriter, tx metadata.Transaction) error {
+	switch tempTx := tx.(type) {
+	case *transaction.Tx:
+		w.writeByte(binaryTxNormal)
+		return writeTx(w, tempTx)
+	case *transaction.TxCustomTokenPrivacy:
+		w.writeByte(binaryTxCustomTokenPrivacy)
+		return writeTxCustomTokenPrivacy(w, tempTx)
+	default:
+		return errors.New("binary codec: unsupported tx type " + tx.GetType())
+	}
+}
+
+func readTransaction(r *binaryReader) (metadata.Transaction, error) {
+	txKind, err := r.readByte()
+	if err != nil {
+		return nil, err
+	}
+	switch txKind {
+	case binaryTxNormal:
+		tx := &transaction.Tx{}
+		if err := readTx(r, tx); err != nil {
+			return nil, err
+		}
+		if tx.Type != common.TxNormalType && tx.Type != common.TxRewardType && tx.Type != common.TxReturnStakingType {
+			return nil, errors.New("binary codec: wrong type of normal tx " + tx.Type)
+		}
+		return tx, nil
+	case binaryTxCustomTokenPrivacy:
+		tx := &transaction.TxCustomTokenPrivacy{}
+		if err := readTxCustomTokenPrivacy(r, tx); err != nil {
+			return nil, err
+		}
+		if tx.Type != common.TxCustomTokenPrivacyType {
+			return nil, errors.New("binary codec: wrong type of privacy token tx " + tx.Type)
+		}
+		return tx, nil
+	default:
+		return nil, errors.New("binary codec: unknown tx kind")
+	}
+}
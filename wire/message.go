@@ -38,6 +38,11 @@ const (
 	// heavy message check cmd
 	CmdMsgCheck     = "msgcheck"
 	CmdMsgCheckResp = "msgcheckresp"
+
+	// compact block relay cmd
+	CmdCompactBlockShard = "cmpblkshard"
+	CmdGetBlockTxn       = "getblocktxn"
+	CmdBlockTxn          = "blocktxn"
 )
 
 // Interface for message wire on P2P network
@@ -166,6 +171,19 @@ func MakeEmptyMessage(messageType string) (Message, error) {
 		msg = &MessageBFT{
 			Timestamp: time.Now().Unix(),
 		}
+	case CmdCompactBlockShard:
+		msg = &MessageCompactBlockShard{}
+		break
+	case CmdGetBlockTxn:
+		msg = &MessageGetBlockTxn{
+			Timestamp: time.Now().Unix(),
+		}
+		break
+	case CmdBlockTxn:
+		msg = &MessageBlockTxn{
+			Timestamp: time.Now().Unix(),
+		}
+		break
 	default:
 		return nil, fmt.Errorf("unhandled this message type [%s]", messageType)
 	}
@@ -226,6 +244,12 @@ func GetCmdType(msgType reflect.Type) (string, error) {
 		return CmdMsgCheckResp, nil
 	case reflect.TypeOf(&MessageBFT{}):
 		return CmdBFT, nil
+	case reflect.TypeOf(&MessageCompactBlockShard{}):
+		return CmdCompactBlockShard, nil
+	case reflect.TypeOf(&MessageGetBlockTxn{}):
+		return CmdGetBlockTxn, nil
+	case reflect.TypeOf(&MessageBlockTxn{}):
+		return CmdBlockTxn, nil
 	default:
 		return common.EmptyString, fmt.Errorf("unhandled this message type [%s]", msgType)
 	}
@@ -45,6 +45,33 @@ func (msg *MessageBFT) JsonDeserialize(jsonStr string) error {
 	return err
 }
 
+func (msg *MessageBFT) BinarySerialize() ([]byte, error) {
+	w := &binaryWriter{}
+	w.writeString(msg.Type)
+	w.writeBytes(msg.Content)
+	w.writeString(msg.ChainKey)
+	w.writeVarint(msg.Timestamp)
+	return w.buf, nil
+}
+
+func (msg *MessageBFT) BinaryDeserialize(data []byte) error {
+	r := newBinaryReader(data)
+	var err error
+	if msg.Type, err = r.readString(); err != nil {
+		return err
+	}
+	if msg.Content, err = r.readBytes(); err != nil {
+		return err
+	}
+	if msg.ChainKey, err = r.readString(); err != nil {
+		return err
+	}
+	if msg.Timestamp, err = r.readVarint(); err != nil {
+		return err
+	}
+	return r.done()
+}
+
 func (msg *MessageBFT) SetSenderID(senderID peer.ID) error {
 	return nil
 }
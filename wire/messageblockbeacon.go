@@ -43,6 +43,22 @@ func (msg *MessageBlockBeacon) JsonDeserialize(jsonStr string) error {
 	return err
 }
 
+func (msg *MessageBlockBeacon) BinarySerialize() ([]byte, error) {
+	w := &binaryWriter{}
+	writeBeaconBlock(w, msg.Block)
+	return w.buf, nil
+}
+
+func (msg *MessageBlockBeacon) BinaryDeserialize(data []byte) error {
+	r := newBinaryReader(data)
+	block := blockchain.NewBeaconBlock()
+	if err := readBeaconBlock(r, block); err != nil {
+		return err
+	}
+	msg.Block = block
+	return r.done()
+}
+
 func (msg *MessageBlockBeacon) SetSenderID(senderID peer.ID) error {
 	return nil
 }
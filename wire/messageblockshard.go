@@ -39,6 +39,24 @@ func (msg *MessageBlockShard) JsonDeserialize(jsonStr string) error {
 	return err
 }
 
+func (msg *MessageBlockShard) BinarySerialize() ([]byte, error) {
+	w := &binaryWriter{}
+	if err := writeShardBlock(w, msg.Block); err != nil {
+		return nil, err
+	}
+	return w.buf, nil
+}
+
+func (msg *MessageBlockShard) BinaryDeserialize(data []byte) error {
+	r := newBinaryReader(data)
+	block := blockchain.NewShardBlock()
+	if err := readShardBlock(r, block); err != nil {
+		return err
+	}
+	msg.Block = block
+	return r.done()
+}
+
 func (msg *MessageBlockShard) SetSenderID(senderID peer.ID) error {
 	return nil
 }
@@ -0,0 +1,85 @@
+package wire
+
+import (
+	"encoding/json"
+
+	"github.com/incognitochain/incognito-chain/blockchain"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+	"github.com/incognitochain/incognito-chain/metadata"
+	peer "github.com/libp2p/go-libp2p-peer"
+)
+
+// MessageBlockTxn response txs requested by MessageGetBlockTxn
+type MessageBlockTxn struct {
+	BlockHash common.Hash
+	ShardID   byte
+	Txs       []metadata.Transaction
+	SenderID  string
+	Timestamp int64
+}
+
+func (msg *MessageBlockTxn) UnmarshalJSON(data []byte) error {
+	temp := &struct {
+		BlockHash common.Hash
+		ShardID   byte
+		Txs       []json.RawMessage
+		SenderID  string
+		Timestamp int64
+	}{}
+	if err := json.Unmarshal(data, temp); err != nil {
+		return err
+	}
+	msg.Txs = make([]metadata.Transaction, 0, len(temp.Txs))
+	for _, txData := range temp.Txs {
+		tx, err := blockchain.UnmarshalTransaction(txData)
+		if err != nil {
+			return err
+		}
+		msg.Txs = append(msg.Txs, tx)
+	}
+	msg.BlockHash = temp.BlockHash
+	msg.ShardID = temp.ShardID
+	msg.SenderID = temp.SenderID
+	msg.Timestamp = temp.Timestamp
+	return nil
+}
+
+func (msg *MessageBlockTxn) Hash() string {
+	rawBytes, err := msg.JsonSerialize()
+	if err != nil {
+		return ""
+	}
+	return common.HashH(rawBytes).String()
+}
+
+func (msg *MessageBlockTxn) MessageType() string {
+	return CmdBlockTxn
+}
+
+func (msg *MessageBlockTxn) MaxPayloadLength(pver int) int {
+	return MaxBlockPayload
+}
+
+func (msg *MessageBlockTxn) JsonSerialize() ([]byte, error) {
+	jsonBytes, err := json.Marshal(msg)
+	return jsonBytes, err
+}
+
+func (msg *MessageBlockTxn) JsonDeserialize(jsonStr string) error {
+	err := json.Unmarshal([]byte(jsonStr), msg)
+	return err
+}
+
+func (msg *MessageBlockTxn) SetSenderID(senderID peer.ID) error {
+	msg.SenderID = senderID.Pretty()
+	return nil
+}
+
+func (msg *MessageBlockTxn) SignMsg(_ *incognitokey.KeySet) error {
+	return nil
+}
+
+func (msg *MessageBlockTxn) VerifyMsgSanity() error {
+	return nil
+}
@@ -0,0 +1,133 @@
+package wire
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/incognitochain/incognito-chain/blockchain"
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+	"github.com/incognitochain/incognito-chain/metadata"
+	peer "github.com/libp2p/go-libp2p-peer"
+)
+
+// MessageCompactBlockShard relay shard block with short ids of txs instead of full txs
+type MessageCompactBlockShard struct {
+	Block    *blockchain.CompactShardBlock
+	SenderID string
+}
+
+func (msg *MessageCompactBlockShard) Hash() string {
+	rawBytes, err := msg.JsonSerialize()
+	if err != nil {
+		return ""
+	}
+	return common.HashH(rawBytes).String()
+}
+
+func (msg *MessageCompactBlockShard) MessageType() string {
+	return CmdCompactBlockShard
+}
+
+func (msg *MessageCompactBlockShard) MaxPayloadLength(pver int) int {
+	return MaxBlockPayload
+}
+
+func (msg *MessageCompactBlockShard) JsonSerialize() ([]byte, error) {
+	jsonBytes, err := json.Marshal(msg)
+	return jsonBytes, err
+}
+
+func (msg *MessageCompactBlockShard) JsonDeserialize(jsonStr string) error {
+	err := json.Unmarshal([]byte(jsonStr), msg)
+	return err
+}
+
+// BinarySerialize encode prefilled txs as body of shard block, followed by their indexes and short ids
+func (msg *MessageCompactBlockShard) BinarySerialize() ([]byte, error) {
+	if msg.Block == nil {
+		return nil, errors.New("binary codec: compact block is nil")
+	}
+	w := &binaryWriter{}
+	w.writeString(msg.Block.ValidationData)
+	writeShardHeader(w, &msg.Block.Header)
+	body := &blockchain.ShardBody{
+		Instructions:      msg.Block.Instructions,
+		CrossTransactions: msg.Block.CrossTransactions,
+		Transactions:      make([]metadata.Transaction, 0, len(msg.Block.PrefilledTxs)),
+	}
+	for _, prefilledTx := range msg.Block.PrefilledTxs {
+		body.Transactions = append(body.Transactions, prefilledTx.Tx)
+	}
+	if err := writeShardBody(w, body); err != nil {
+		return nil, err
+	}
+	for _, prefilledTx := range msg.Block.PrefilledTxs {
+		w.writeVarint(int64(prefilledTx.Index))
+	}
+	w.writeUvarint(uint64(len(msg.Block.ShortTxIDs)))
+	for _, shortTxID := range msg.Block.ShortTxIDs {
+		w.writeFixedUint64(shortTxID)
+	}
+	w.writeString(msg.SenderID)
+	return w.buf, nil
+}
+
+func (msg *MessageCompactBlockShard) BinaryDeserialize(data []byte) error {
+	r := newBinaryReader(data)
+	block := &blockchain.CompactShardBlock{}
+	var err error
+	if block.ValidationData, err = r.readString(); err != nil {
+		return err
+	}
+	if err := readShardHeader(r, &block.Header); err != nil {
+		return err
+	}
+	body := &blockchain.ShardBody{}
+	if err := readShardBody(r, body); err != nil {
+		return err
+	}
+	block.Instructions = body.Instructions
+	block.CrossTransactions = body.CrossTransactions
+	block.PrefilledTxs = make([]blockchain.PrefilledTx, 0, len(body.Transactions))
+	for _, tx := range body.Transactions {
+		index, err := r.readVarint()
+		if err != nil {
+			return err
+		}
+		block.PrefilledTxs = append(block.PrefilledTxs, blockchain.PrefilledTx{Index: int(index), Tx: tx})
+	}
+	numberOfShortTxIDs, err := r.readLength()
+	if err != nil {
+		return err
+	}
+	block.ShortTxIDs = make([]uint64, 0, numberOfShortTxIDs)
+	for i := 0; i < numberOfShortTxIDs; i++ {
+		shortTxID, err := r.readFixedUint64()
+		if err != nil {
+			return err
+		}
+		block.ShortTxIDs = append(block.ShortTxIDs, shortTxID)
+	}
+	if msg.SenderID, err = r.readString(); err != nil {
+		return err
+	}
+	msg.Block = block
+	return r.done()
+}
+
+func (msg *MessageCompactBlockShard) SetSenderID(senderID peer.ID) error {
+	msg.SenderID = senderID.Pretty()
+	return nil
+}
+
+func (msg *MessageCompactBlockShard) SignMsg(_ *incognitokey.KeySet) error {
+	return nil
+}
+
+func (msg *MessageCompactBlockShard) VerifyMsgSanity() error {
+	if msg.Block == nil {
+		return errors.New("compact block is nil")
+	}
+	return nil
+}
@@ -43,6 +43,22 @@ func (msg *MessageCrossShard) JsonDeserialize(jsonStr string) error {
 	return err
 }
 
+func (msg *MessageCrossShard) BinarySerialize() ([]byte, error) {
+	w := &binaryWriter{}
+	writeCrossShardBlock(w, msg.Block)
+	return w.buf, nil
+}
+
+func (msg *MessageCrossShard) BinaryDeserialize(data []byte) error {
+	r := newBinaryReader(data)
+	block := &blockchain.CrossShardBlock{}
+	if err := readCrossShardBlock(r, block); err != nil {
+		return err
+	}
+	msg.Block = block
+	return r.done()
+}
+
 func (msg *MessageCrossShard) SetSenderID(senderID peer.ID) error {
 	return nil
 }
@@ -0,0 +1,57 @@
+package wire
+
+import (
+	"encoding/json"
+
+	"github.com/incognitochain/incognito-chain/common"
+	"github.com/incognitochain/incognito-chain/incognitokey"
+	peer "github.com/libp2p/go-libp2p-peer"
+)
+
+// MessageGetBlockTxn request txs of a compact block which are not found in mempool
+type MessageGetBlockTxn struct {
+	BlockHash  common.Hash
+	ShardID    byte
+	ShortTxIDs []uint64
+	SenderID   string
+	Timestamp  int64
+}
+
+func (msg *MessageGetBlockTxn) Hash() string {
+	rawBytes, err := msg.JsonSerialize()
+	if err != nil {
+		return ""
+	}
+	return common.HashH(rawBytes).String()
+}
+
+func (msg *MessageGetBlockTxn) MessageType() string {
+	return CmdGetBlockTxn
+}
+
+func (msg *MessageGetBlockTxn) MaxPayloadLength(pver int) int {
+	return MaxBlockPayload
+}
+
+func (msg *MessageGetBlockTxn) JsonSerialize() ([]byte, error) {
+	jsonBytes, err := json.Marshal(msg)
+	return jsonBytes, err
+}
+
+func (msg *MessageGetBlockTxn) JsonDeserialize(jsonStr string) error {
+	err := json.Unmarshal([]byte(jsonStr), msg)
+	return err
+}
+
+func (msg *MessageGetBlockTxn) SetSenderID(senderID peer.ID) error {
+	msg.SenderID = senderID.Pretty()
+	return nil
+}
+
+func (msg *MessageGetBlockTxn) SignMsg(_ *incognitokey.KeySet) error {
+	return nil
+}
+
+func (msg *MessageGetBlockTxn) VerifyMsgSanity() error {
+	return nil
+}
@@ -43,6 +43,22 @@ func (msg *MessageShardToBeacon) JsonDeserialize(jsonStr string) error {
 	return err
 }
 
+func (msg *MessageShardToBeacon) BinarySerialize() ([]byte, error) {
+	w := &binaryWriter{}
+	writeShardToBeaconBlock(w, msg.Block)
+	return w.buf, nil
+}
+
+func (msg *MessageShardToBeacon) BinaryDeserialize(data []byte) error {
+	r := newBinaryReader(data)
+	block := &blockchain.ShardToBeaconBlock{}
+	if err := readShardToBeaconBlock(r, block); err != nil {
+		return err
+	}
+	msg.Block = block
+	return r.done()
+}
+
 func (msg *MessageShardToBeacon) SetSenderID(senderID peer.ID) error {
 	return nil
 }
@@ -3,10 +3,12 @@ package wire
 import (
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 
 	"github.com/incognitochain/incognito-chain/incognitokey"
 	"github.com/incognitochain/incognito-chain/common"
 	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/incognitochain/incognito-chain/transaction"
 	"github.com/libp2p/go-libp2p-peer"
 )
 
@@ -45,6 +47,27 @@ func (msg *MessageTx) JsonDeserialize(jsonStr string) error {
 	return err
 }
 
+func (msg *MessageTx) BinarySerialize() ([]byte, error) {
+	w := &binaryWriter{}
+	if err := writeTransaction(w, msg.Transaction); err != nil {
+		return nil, err
+	}
+	return w.buf, nil
+}
+
+func (msg *MessageTx) BinaryDeserialize(data []byte) error {
+	r := newBinaryReader(data)
+	tx, err := readTransaction(r)
+	if err != nil {
+		return err
+	}
+	if _, ok := tx.(*transaction.Tx); !ok {
+		return errors.New("binary codec: wrong tx type " + tx.GetType())
+	}
+	msg.Transaction = tx
+	return r.done()
+}
+
 func (msg *MessageTx) SetSenderID(senderID peer.ID) error {
 	return nil
 }
@@ -3,10 +3,12 @@ package wire
 import (
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 
 	"github.com/incognitochain/incognito-chain/incognitokey"
 	"github.com/incognitochain/incognito-chain/common"
 	"github.com/incognitochain/incognito-chain/metadata"
+	"github.com/incognitochain/incognito-chain/transaction"
 	"github.com/libp2p/go-libp2p-peer"
 )
 
@@ -45,6 +47,27 @@ func (msg *MessageTxPrivacyToken) JsonDeserialize(jsonStr string) error {
 	return err
 }
 
+func (msg *MessageTxPrivacyToken) BinarySerialize() ([]byte, error) {
+	w := &binaryWriter{}
+	if err := writeTransaction(w, msg.Transaction); err != nil {
+		return nil, err
+	}
+	return w.buf, nil
+}
+
+func (msg *MessageTxPrivacyToken) BinaryDeserialize(data []byte) error {
+	r := newBinaryReader(data)
+	tx, err := readTransaction(r)
+	if err != nil {
+		return err
+	}
+	if _, ok := tx.(*transaction.TxCustomTokenPrivacy); !ok {
+		return errors.New("binary codec: wrong tx type " + tx.GetType())
+	}
+	msg.Transaction = tx
+	return r.done()
+}
+
 func (msg *MessageTxPrivacyToken) SetSenderID(senderID peer.ID) error {
 	return nil
 }
@@ -25,6 +25,7 @@ type MessageVersion struct {
 	PublicKey        string
 	PublicKeyType    string
 	SignDataB58      string
+	WireCodec        int // highest codec supported by sender, 0 for peers of old version
 }
 
 func (msg *MessageVersion) Hash() string {